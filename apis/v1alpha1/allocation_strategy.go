@@ -5,7 +5,7 @@ package v1alpha1
 
 type (
 	// OpenTelemetryTargetAllocatorAllocationStrategy represent which strategy to distribute target to each collector
-	// +kubebuilder:validation:Enum=least-weighted;consistent-hashing;per-node
+	// +kubebuilder:validation:Enum=least-weighted;consistent-hashing;per-node;zone-aware
 	OpenTelemetryTargetAllocatorAllocationStrategy string
 )
 
@@ -18,4 +18,7 @@ const (
 
 	// OpenTelemetryTargetAllocatorAllocationStrategyPerNode targets will be assigned to the collector on the node they reside on (use only with daemon set).
 	OpenTelemetryTargetAllocatorAllocationStrategyPerNode OpenTelemetryTargetAllocatorAllocationStrategy = "per-node"
+
+	// OpenTelemetryTargetAllocatorAllocationStrategyZoneAware targets will preferably be assigned to a collector in the same topology zone.
+	OpenTelemetryTargetAllocatorAllocationStrategyZoneAware OpenTelemetryTargetAllocatorAllocationStrategy = "zone-aware"
 )
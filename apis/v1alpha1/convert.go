@@ -4,9 +4,11 @@
 package v1alpha1
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	go_yaml "github.com/goccy/go-yaml"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
@@ -16,6 +18,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 )
 
+// ConversionDataAnnotation holds the fields of a v1beta1 collector its v1alpha1 version can't represent, so that
+// converting the collector back to v1beta1 doesn't lose them.
+const ConversionDataAnnotation = "opentelemetry.io/v1beta1-conversion-data"
+
 var _ conversion.Convertible = &OpenTelemetryCollector{}
 
 func (src *OpenTelemetryCollector) ConvertTo(dstRaw conversion.Hub) error {
@@ -26,6 +32,9 @@ func (src *OpenTelemetryCollector) ConvertTo(dstRaw conversion.Hub) error {
 		if err != nil {
 			return fmt.Errorf("failed to convert to v1beta1: %w", err)
 		}
+		if err := restoreConversionData(&convertedSrc); err != nil {
+			return fmt.Errorf("failed to convert to v1beta1: %w", err)
+		}
 		dst.ObjectMeta = convertedSrc.ObjectMeta
 		dst.Spec = convertedSrc.Spec
 		dst.Status = convertedSrc.Status
@@ -43,6 +52,9 @@ func (dst *OpenTelemetryCollector) ConvertFrom(srcRaw conversion.Hub) error {
 		if err != nil {
 			return fmt.Errorf("failed to convert to v1alpha1: %w", err)
 		}
+		if err := storeConversionData(src, srcConverted); err != nil {
+			return fmt.Errorf("failed to convert to v1alpha1: %w", err)
+		}
 		dst.ObjectMeta = srcConverted.ObjectMeta
 		dst.Spec = srcConverted.Spec
 		dst.Status = srcConverted.Status
@@ -52,6 +64,115 @@ func (dst *OpenTelemetryCollector) ConvertFrom(srcRaw conversion.Hub) error {
 	return nil
 }
 
+// conversionData is the content of the conversion data annotation: the spec and the status of the v1beta1 collector,
+// without the configuration both versions represent.
+type conversionData struct {
+	Spec   v1beta1.OpenTelemetryCollectorSpec   `json:"spec"`
+	Status v1beta1.OpenTelemetryCollectorStatus `json:"status"`
+}
+
+func newConversionData(in v1beta1.OpenTelemetryCollector) conversionData {
+	spec := *in.Spec.DeepCopy()
+	spec.Config = v1beta1.Config{}
+	return conversionData{Spec: spec, Status: *in.Status.DeepCopy()}
+}
+
+// storeConversionData annotates the v1alpha1 collector with the fields of the v1beta1 collector it was converted from
+// the conversion lost, if any.
+func storeConversionData(in *v1beta1.OpenTelemetryCollector, out *OpenTelemetryCollector) error {
+	delete(out.Annotations, ConversionDataAnnotation)
+	back, err := tov1beta1(*out)
+	if err != nil {
+		return err
+	}
+	data := newConversionData(*in)
+	// the v1alpha1 selectors of the Prometheus CRs can't be nil, the conversion defaults them to empty ones
+	withDefaultSelectors := newConversionData(*in)
+	if withDefaultSelectors.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector == nil {
+		withDefaultSelectors.Spec.TargetAllocator.PrometheusCR.PodMonitorSelector = &metav1.LabelSelector{}
+	}
+	if withDefaultSelectors.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector == nil {
+		withDefaultSelectors.Spec.TargetAllocator.PrometheusCR.ServiceMonitorSelector = &metav1.LabelSelector{}
+	}
+	expected, err := json.Marshal(withDefaultSelectors)
+	if err != nil {
+		return err
+	}
+	actual, err := json.Marshal(newConversionData(back))
+	if err != nil {
+		return err
+	}
+	if jsonpatch.Equal(expected, actual) {
+		return nil
+	}
+
+	content, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if out.Annotations == nil {
+		out.Annotations = map[string]string{}
+	}
+	out.Annotations[ConversionDataAnnotation] = string(content)
+	return nil
+}
+
+// restoreConversionData restores the fields of the v1beta1 collector converted from a v1alpha1 one its conversion
+// data annotation holds. The fields changed in v1alpha1 since its conversion from v1beta1 take precedence over the
+// annotation.
+func restoreConversionData(out *v1beta1.OpenTelemetryCollector) error {
+	content, ok := out.Annotations[ConversionDataAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(out.Annotations, ConversionDataAnnotation)
+	if len(out.Annotations) == 0 {
+		out.Annotations = nil
+	}
+	var stored conversionData
+	if err := json.Unmarshal([]byte(content), &stored); err != nil {
+		return fmt.Errorf("invalid %s annotation: %w", ConversionDataAnnotation, err)
+	}
+
+	// the v1beta1 collector the v1alpha1 one was converted from, as v1alpha1 represents it
+	storedV1alpha1, err := tov1alpha1(v1beta1.OpenTelemetryCollector{Spec: stored.Spec, Status: stored.Status})
+	if err != nil {
+		return err
+	}
+	unchanged, err := tov1beta1(*storedV1alpha1)
+	if err != nil {
+		return err
+	}
+	original, err := json.Marshal(newConversionData(unchanged))
+	if err != nil {
+		return err
+	}
+	modified, err := json.Marshal(newConversionData(*out))
+	if err != nil {
+		return err
+	}
+	changes, err := jsonpatch.CreateMergePatch(original, modified)
+	if err != nil {
+		return err
+	}
+	storedContent, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	restoredContent, err := jsonpatch.MergePatch(storedContent, changes)
+	if err != nil {
+		return err
+	}
+	var restored conversionData
+	if err := json.Unmarshal(restoredContent, &restored); err != nil {
+		return err
+	}
+	restored.Spec.Config = out.Spec.Config
+	out.Spec = restored.Spec
+	out.Status = restored.Status
+	return nil
+}
+
 func tov1beta1(in OpenTelemetryCollector) (v1beta1.OpenTelemetryCollector, error) {
 	copy := in.DeepCopy()
 	cfg := &v1beta1.Config{}
@@ -474,6 +595,8 @@ func tov1alpha1TAAllocationStrategy(strategy v1beta1.TargetAllocatorAllocationSt
 		return OpenTelemetryTargetAllocatorAllocationStrategyPerNode
 	case v1beta1.TargetAllocatorAllocationStrategyLeastWeighted:
 		return OpenTelemetryTargetAllocatorAllocationStrategyLeastWeighted
+	case v1beta1.TargetAllocatorAllocationStrategyZoneAware:
+		return OpenTelemetryTargetAllocatorAllocationStrategyZoneAware
 	}
 	return ""
 }
@@ -493,6 +616,8 @@ func tov1beta1TAAllocationStrategy(strategy OpenTelemetryTargetAllocatorAllocati
 		return v1beta1.TargetAllocatorAllocationStrategyConsistentHashing
 	case OpenTelemetryTargetAllocatorAllocationStrategyLeastWeighted:
 		return v1beta1.TargetAllocatorAllocationStrategyLeastWeighted
+	case OpenTelemetryTargetAllocatorAllocationStrategyZoneAware:
+		return v1beta1.TargetAllocatorAllocationStrategyZoneAware
 	}
 	return ""
 }
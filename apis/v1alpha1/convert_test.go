@@ -4,18 +4,26 @@
 package v1alpha1
 
 import (
+	"math/rand"
 	"testing"
 	"time"
 
 	go_yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metafuzzer "k8s.io/apimachinery/pkg/apis/meta/fuzzer"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
@@ -563,3 +571,112 @@ func TestConvertFrom(t *testing.T) {
 		},
 	}, col)
 }
+
+func TestConvertFromKeepsV1beta1Fields(t *testing.T) {
+	colbeta1 := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "otel",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Presets: v1beta1.Presets{HostMetrics: v1beta1.Preset{Enabled: true}},
+			TargetAllocator: v1beta1.TargetAllocatorEmbedded{
+				Enabled:            true,
+				AllocationStrategy: v1beta1.TargetAllocatorAllocationStrategyConsistentHashing,
+				ConsistentHashing:  v1beta1.TargetAllocatorConsistentHashing{PartitionCount: 7},
+			},
+		},
+	}
+	col := OpenTelemetryCollector{}
+	require.NoError(t, col.ConvertFrom(&colbeta1))
+	assert.Contains(t, col.Annotations, ConversionDataAnnotation)
+
+	// the changes made to the v1alpha1 collector take precedence over the annotation
+	col.Spec.TargetAllocator.AllocationStrategy = OpenTelemetryTargetAllocatorAllocationStrategyPerNode
+	converted := v1beta1.OpenTelemetryCollector{}
+	require.NoError(t, col.ConvertTo(&converted))
+	assert.NotContains(t, converted.Annotations, ConversionDataAnnotation)
+	assert.True(t, converted.Spec.Presets.HostMetrics.Enabled)
+	assert.Equal(t, v1beta1.TargetAllocatorAllocationStrategyPerNode, converted.Spec.TargetAllocator.AllocationStrategy)
+	assert.Equal(t, int32(7), converted.Spec.TargetAllocator.ConsistentHashing.PartitionCount)
+}
+
+func TestConvertFromInvalidConversionData(t *testing.T) {
+	col := OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "otel",
+			Annotations: map[string]string{ConversionDataAnnotation: "{"},
+		},
+	}
+	err := col.ConvertTo(&v1beta1.OpenTelemetryCollector{})
+	assert.ErrorContains(t, err, "invalid opentelemetry.io/v1beta1-conversion-data annotation")
+}
+
+// conversionFuzzSeed is fixed, so that a failure of the round trip tests is reproducible.
+const conversionFuzzSeed = 20240601
+
+// collectorFuzzer fills the v1beta1 collectors with random values which survive their serialization.
+func collectorFuzzer(t *testing.T, seed int64) *fuzz.Fuzzer {
+	cfg := v1beta1.Config{}
+	require.NoError(t, go_yaml.Unmarshal([]byte(collectorCfg), &cfg))
+	funcs := func(_ serializer.CodecFactory) []interface{} {
+		return []interface{}{
+			func(in *v1beta1.OpenTelemetryCollector, c fuzz.Continue) {
+				c.FuzzNoCustom(in)
+				in.TypeMeta = metav1.TypeMeta{}
+			},
+			// the configuration is the same in both versions, and isn't random
+			func(in *v1beta1.Config, _ fuzz.Continue) {
+				*in = *cfg.DeepCopy()
+			},
+			func(in *intstr.IntOrString, c fuzz.Continue) {
+				if c.RandBool() {
+					*in = intstr.FromInt32(c.Int31())
+				} else {
+					*in = intstr.FromString(c.RandString())
+				}
+			},
+		}
+	}
+	codecs := serializer.NewCodecFactory(runtime.NewScheme())
+	return fuzzer.FuzzerFor(fuzzer.MergeFuzzerFuncs(metafuzzer.Funcs, funcs), rand.NewSource(seed), codecs).
+		NilChance(0.3).
+		NumElements(0, 2)
+}
+
+func TestConversionRoundTrip(t *testing.T) {
+	f := collectorFuzzer(t, conversionFuzzSeed)
+	for i := 0; i < 50; i++ {
+		colbeta1 := v1beta1.OpenTelemetryCollector{}
+		f.Fuzz(&colbeta1)
+
+		col := OpenTelemetryCollector{}
+		require.NoError(t, col.ConvertFrom(colbeta1.DeepCopy()))
+		converted := v1beta1.OpenTelemetryCollector{}
+		require.NoError(t, col.ConvertTo(&converted))
+
+		if !apiequality.Semantic.DeepEqual(colbeta1, converted) {
+			t.Fatalf("the round trip through v1alpha1 changed the collector:\n%s", cmp.Diff(colbeta1, converted))
+		}
+	}
+}
+
+func TestConversionRoundTripWithChanges(t *testing.T) {
+	f := collectorFuzzer(t, conversionFuzzSeed)
+	for i := 0; i < 50; i++ {
+		colbeta1 := v1beta1.OpenTelemetryCollector{}
+		f.Fuzz(&colbeta1)
+
+		col := OpenTelemetryCollector{}
+		require.NoError(t, col.ConvertFrom(colbeta1.DeepCopy()))
+		col.Spec.ServiceAccount = "changed"
+		col.Spec.TargetAllocator.Image = "changed"
+		converted := v1beta1.OpenTelemetryCollector{}
+		require.NoError(t, col.ConvertTo(&converted))
+
+		colbeta1.Spec.ServiceAccount = "changed"
+		colbeta1.Spec.TargetAllocator.Image = "changed"
+		if !apiequality.Semantic.DeepEqual(colbeta1, converted) {
+			t.Fatalf("the round trip through v1alpha1 changed the collector:\n%s", cmp.Diff(colbeta1, converted))
+		}
+	}
+}
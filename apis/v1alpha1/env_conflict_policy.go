@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+type (
+	// EnvConflictPolicy represents what the webhook does with the OTEL_ environment variables already defined by the
+	// instrumented containers.
+	// +kubebuilder:validation:Enum=skip;override;append
+	EnvConflictPolicy string
+)
+
+const (
+	// EnvConflictPolicySkip specifies that the variables of the containers are kept, the operator only appends its
+	// resource attributes to OTEL_RESOURCE_ATTRIBUTES.
+	EnvConflictPolicySkip EnvConflictPolicy = "skip"
+
+	// EnvConflictPolicyOverride specifies that the variables set by the operator replace the ones of the containers,
+	// and its resource attributes the ones of the containers with the same keys.
+	EnvConflictPolicyOverride EnvConflictPolicy = "override"
+
+	// EnvConflictPolicyAppend specifies that the values of the list variables, e.g. OTEL_PROPAGATORS, are merged, and
+	// the resource attributes of the operator added to the ones of the containers with other keys. The other variables
+	// of the containers are kept.
+	EnvConflictPolicyAppend EnvConflictPolicy = "append"
+)
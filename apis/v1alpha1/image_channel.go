@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/distribution/reference"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/imageversion"
+)
+
+type (
+	// ImageChannel represents the images the operator sets for the auto-instrumentation of the languages.
+	// +kubebuilder:validation:Enum=stable;latest
+	ImageChannel string
+)
+
+const (
+	// ImageChannelStable specifies the images bundled with the operator, upgraded along with the operator.
+	ImageChannelStable ImageChannel = "stable"
+
+	// ImageChannelLatest specifies the latest tag of the images bundled with the operator.
+	ImageChannelLatest ImageChannel = "latest"
+)
+
+// Image returns the image of the channel for an image bundled with the operator.
+func (c ImageChannel) Image(defaultImage string) string {
+	if c != ImageChannelLatest {
+		return defaultImage
+	}
+	ref, err := reference.Parse(defaultImage)
+	if err != nil {
+		return defaultImage
+	}
+	named, ok := ref.(reference.Named)
+	if !ok {
+		return defaultImage
+	}
+	return named.Name() + ":latest"
+}
+
+// imageVersion returns the version of the tag of an image, e.g. 2.10.0 for ghcr.io/org/autoinstrumentation-java:2.10.0.
+func imageVersion(image string) (*semver.Version, error) {
+	version := imageversion.FromImage(image)
+	if version == "" {
+		return nil, fmt.Errorf("the image %s has no version tag", image)
+	}
+	return semver.NewVersion(version)
+}
+
+// validateImageVersion warns when the image of a language has a major version, or a minor version before 1.0, other
+// than the one of the image bundled with the operator: the agent may not support the environment variables set by the
+// operator, or emit telemetry with other semantic conventions. The images without a version tag aren't checked.
+func validateImageVersion(field, image, defaultImage string) []string {
+	version, err := imageVersion(image)
+	if err != nil {
+		return nil
+	}
+	defaultVersion, err := imageVersion(defaultImage)
+	if err != nil {
+		return nil
+	}
+	if version.Major() == defaultVersion.Major() && (version.Major() > 0 || version.Minor() == defaultVersion.Minor()) {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s %s may not be compatible with the operator, which is released with the version %s", field, image, defaultVersion.Original())}
+}
@@ -69,11 +69,43 @@ type InstrumentationSpec struct {
 	// +optional
 	Nginx Nginx `json:"nginx,omitempty"`
 
+	// PHP defines configuration for PHP auto-instrumentation.
+	// +optional
+	PHP PHP `json:"php,omitempty"`
+
+	// Ruby defines configuration for Ruby auto-instrumentation.
+	// +optional
+	Ruby Ruby `json:"ruby,omitempty"`
+
+	// ImageChannel selects the images the operator sets for the languages whose image isn't set: `stable`, the
+	// default, for the images released with the operator, upgraded along with it, or `latest` for their latest tag.
+	// +optional
+	ImageChannel ImageChannel `json:"imageChannel,omitempty"`
+
 	// ImagePullPolicy
 	// One of Always, Never, IfNotPresent.
 	// Defaults to Always if :latest tag is specified, or IfNotPresent otherwise.
 	// +optional
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// EnvConflictPolicy defines what the webhook does with the OTEL_ environment variables the instrumented containers
+	// already define: `skip`, the default, keeps them, `override` replaces them with the values of the operator, and
+	// `append` merges the values of the list variables. The resource attributes of OTEL_RESOURCE_ATTRIBUTES are merged
+	// by key, the ones of the operator taking precedence with `override`.
+	// +optional
+	EnvConflictPolicy EnvConflictPolicy `json:"envConflictPolicy,omitempty"`
+
+	// NamespaceSelector selects the namespaces whose pods annotated with `inject-<language>: "true"` can use this
+	// Instrumentation, including namespaces other than its own. Without it, only the pods of its own namespace can.
+	// The other namespaces must allow it with the `instrumentation.opentelemetry.io/allow-foreign-instrumentations: "true"`
+	// annotation, and their own Instrumentations are always preferred.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ObjectSelector selects, by their labels, the pods annotated with `inject-<language>: "true"` that can use
+	// this Instrumentation. When several Instrumentations match a pod, the one with the most specific selectors is used.
+	// +optional
+	ObjectSelector *metav1.LabelSelector `json:"objectSelector,omitempty"`
 }
 
 // Resource defines the configuration for the resource attributes, as defined by the OpenTelemetry specification.
@@ -87,6 +119,16 @@ type Resource struct {
 	// AddK8sUIDAttributes defines whether K8s UID attributes should be collected (e.g. k8s.deployment.uid).
 	// +optional
 	AddK8sUIDAttributes bool `json:"addK8sUIDAttributes,omitempty"`
+
+	// LabelsToAttributes maps labels of the pod, or else of its namespace, to the resource attributes set to their
+	// value, e.g. team: team.name. They take precedence over Attributes, but not over the Kubernetes attributes.
+	// +optional
+	LabelsToAttributes map[string]string `json:"labelsToAttributes,omitempty"`
+
+	// AnnotationsToAttributes maps annotations of the pod, or else of its namespace, to the resource attributes set to
+	// their value, as LabelsToAttributes does for the labels.
+	// +optional
+	AnnotationsToAttributes map[string]string `json:"annotationsToAttributes,omitempty"`
 }
 
 // Exporter defines OTLP exporter configuration.
@@ -123,6 +165,33 @@ type TLS struct {
 	// Key defines a key (e.g. tls.key) of the private key in the secret or absolute path to a certificate.
 	// The absolute path can be used when certificate is already present on the workload filesystem.
 	Key string `json:"key_file,omitempty"`
+
+	// Certificate requests the client certificate of the exporter from cert-manager, which stores it in the secret
+	// SecretName, by default <name>-exporter-tls, with its private key and the CA certificate. The operator creates
+	// the cert-manager Certificate in the namespace of the Instrumentation, the pods of the other namespaces need
+	// their own secret.
+	// +optional
+	Certificate *ExporterCertificate `json:"certificate,omitempty"`
+}
+
+// ExporterCertificate defines the cert-manager Certificate of the exporter.
+type ExporterCertificate struct {
+	// IssuerName is the name of the cert-manager issuer signing the certificate.
+	IssuerName string `json:"issuerName"`
+
+	// IssuerKind is the kind of the cert-manager issuer, Issuer, in the namespace of the Instrumentation, or ClusterIssuer.
+	// +optional
+	// +kubebuilder:default:=Issuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	IssuerKind string `json:"issuerKind,omitempty"`
+
+	// CommonName of the certificate, the identity of the agents for the gateway. The default is the name of the Instrumentation.
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// Duration of the certificate, renewed by cert-manager before it expires. The default is the one of cert-manager, 90 days.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
 }
 
 // Sampler defines sampling configuration.
@@ -175,10 +244,23 @@ type Java struct {
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 
+	// ImagePullPolicy of the init containers, overriding the ImagePullPolicy of the Instrumentation.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// SecurityContext of the init containers. By default, they get the security context of the instrumented container.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
 	// Extensions defines java specific extensions.
 	// All extensions are copied to a single directory; if a JAR with the same name exists, it will be overwritten.
 	// +optional
 	Extensions []Extensions `json:"extensions,omitempty"`
+
+	// ConfigMapRef selects a key of a ConfigMap, in the namespace of the pod, holding a configuration file of the
+	// javaagent, e.g. a properties file. It's mounted into the instrumented containers and set in OTEL_JAVAAGENT_CONFIGURATION_FILE.
+	// +optional
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
 }
 
 type Extensions struct {
@@ -212,6 +294,19 @@ type NodeJS struct {
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+
+	// ImagePullPolicy of the init containers, overriding the ImagePullPolicy of the Instrumentation.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// SecurityContext of the init containers. By default, they get the security context of the instrumented container.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// ConfigMapRef selects a key of a ConfigMap, in the namespace of the pod, holding a JavaScript configuration file.
+	// It's mounted into the instrumented containers and required in NODE_OPTIONS before the auto-instrumentation.
+	// +optional
+	ConfigMapRef *corev1.ConfigMapKeySelector `json:"configMapRef,omitempty"`
 }
 
 // Python defines Python SDK and instrumentation configuration.
@@ -237,6 +332,14 @@ type Python struct {
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+
+	// ImagePullPolicy of the init containers, overriding the ImagePullPolicy of the Instrumentation.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// SecurityContext of the init containers. By default, they get the security context of the instrumented container.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 }
 
 // DotNet defines DotNet SDK and instrumentation configuration.
@@ -261,6 +364,22 @@ type DotNet struct {
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+
+	// ImagePullPolicy of the init containers, overriding the ImagePullPolicy of the Instrumentation.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// SecurityContext of the init containers. By default, they get the security context of the instrumented container.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+
+	// Runtime is the runtime identifier of the profiler loaded by the instrumented containers: `linux-x64`, the
+	// default, for glibc based images, `linux-musl-x64` for musl based images, e.g. Alpine, or `auto` to detect it with
+	// an init container running the image of each instrumented container with the busybox of the `image`.
+	// The instrumentation.opentelemetry.io/otel-dotnet-auto-runtime annotation takes precedence.
+	// +kubebuilder:validation:Enum=linux-x64;linux-musl-x64;auto
+	// +optional
+	Runtime string `json:"runtime,omitempty"`
 }
 
 type Go struct {
@@ -285,6 +404,16 @@ type Go struct {
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+
+	// ImagePullPolicy of the agent sidecar, overriding the ImagePullPolicy of the Instrumentation.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Privileged runs the eBPF agent sidecar as a privileged container, the default. When false, the agent runs as
+	// root with the SYS_ADMIN, SYS_PTRACE and SYS_RESOURCE capabilities only, which some kernels and container
+	// runtimes don't allow to load the eBPF programs.
+	// +optional
+	Privileged *bool `json:"privileged,omitempty"`
 }
 
 // ApacheHttpd defines Apache SDK and instrumentation configuration.
@@ -325,6 +454,14 @@ type ApacheHttpd struct {
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+
+	// ImagePullPolicy of the init containers, overriding the ImagePullPolicy of the Instrumentation.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// SecurityContext of the init containers. By default, they get the security context of the instrumented container.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 }
 
 // Nginx defines Nginx SDK and instrumentation configuration.
@@ -361,10 +498,127 @@ type Nginx struct {
 	// Resources describes the compute resource requirements.
 	// +optional
 	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+
+	// ImagePullPolicy of the init containers, overriding the ImagePullPolicy of the Instrumentation.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// SecurityContext of the init containers. By default, they get the security context of the instrumented container.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+}
+
+// PHP defines PHP SDK and instrumentation configuration.
+type PHP struct {
+	// Image is a container image with the PHP extension and auto-instrumentation packages.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// VolumeClaimTemplate defines an ephemeral volume used for auto-instrumentation.
+	// If omitted, an emptyDir is used with size limit VolumeSizeLimit
+	VolumeClaimTemplate corev1.PersistentVolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
+
+	// VolumeSizeLimit defines size limit for volume used for auto-instrumentation.
+	// The default size is 200Mi.
+	VolumeSizeLimit *resource.Quantity `json:"volumeLimitSize,omitempty"`
+
+	// Env defines PHP specific env vars. There are four layers for env vars' definitions and
+	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
+	// If the former var had been defined, then the other vars would be ignored.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Resources describes the compute resource requirements.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+
+	// ImagePullPolicy of the init containers, overriding the ImagePullPolicy of the Instrumentation.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// SecurityContext of the init containers. By default, they get the security context of the instrumented container.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
 }
 
+// Ruby defines Ruby SDK and instrumentation configuration.
+type Ruby struct {
+	// Image is a container image with the Ruby SDK and auto-instrumentation gems.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// VolumeClaimTemplate defines an ephemeral volume used for auto-instrumentation.
+	// If omitted, an emptyDir is used with size limit VolumeSizeLimit
+	VolumeClaimTemplate corev1.PersistentVolumeClaimTemplate `json:"volumeClaimTemplate,omitempty"`
+
+	// VolumeSizeLimit defines size limit for volume used for auto-instrumentation.
+	// The default size is 200Mi.
+	VolumeSizeLimit *resource.Quantity `json:"volumeLimitSize,omitempty"`
+
+	// Env defines Ruby specific env vars. There are four layers for env vars' definitions and
+	// the precedence order is: `original container env vars` > `language specific env vars` > `common env vars` > `instrument spec configs' vars`.
+	// If the former var had been defined, then the other vars would be ignored.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Resources describes the compute resource requirements.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resourceRequirements,omitempty"`
+
+	// ImagePullPolicy of the init containers, overriding the ImagePullPolicy of the Instrumentation.
+	// +optional
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// SecurityContext of the init containers. By default, they get the security context of the instrumented container.
+	// +optional
+	SecurityContext *corev1.SecurityContext `json:"securityContext,omitempty"`
+}
+
+const (
+	// InstrumentationConditionDegraded tells whether the injection of the instrumentation fails in some pods, e.g.
+	// when the image of the auto-instrumentation can't be pulled.
+	InstrumentationConditionDegraded = "Degraded"
+
+	// InstrumentationReasonInjectionFailed means the auto-instrumentation containers of some injected pods fail.
+	InstrumentationReasonInjectionFailed = "InjectionFailed"
+	// InstrumentationReasonInjectionSucceeded means the auto-instrumentation containers of the injected pods don't fail.
+	InstrumentationReasonInjectionSucceeded = "InjectionSucceeded"
+)
+
 // InstrumentationStatus defines status of the instrumentation.
 type InstrumentationStatus struct {
+	// Languages are the usage statistics of the languages injected with the instrumentation into existing pods.
+	// +optional
+	// +listType=map
+	// +listMapKey=language
+	Languages []InstrumentationLanguageStatus `json:"languages,omitempty"`
+
+	// ObservedGeneration is the generation of the Instrumentation the status was last computed from.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions of the instrumentation: Degraded, when the injection fails in some pods.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// InstrumentationLanguageStatus is the usage of a language of the instrumentation.
+type InstrumentationLanguageStatus struct {
+	// Language injected, e.g. java or python, or sdk for the SDK environment variables only.
+	Language string `json:"language"`
+
+	// InjectedPods is the number of existing pods injected with the language.
+	InjectedPods int32 `json:"injectedPods"`
+
+	// FailedPods is the number of injected pods whose auto-instrumentation containers fail.
+	// +optional
+	FailedPods int32 `json:"failedPods,omitempty"`
+
+	// LastInjectionTime is the creation time of the last pod injected with the language.
+	// +optional
+	LastInjectionTime *metav1.Time `json:"lastInjectionTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -374,6 +628,7 @@ type InstrumentationStatus struct {
 // +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".spec.exporter.endpoint"
 // +kubebuilder:printcolumn:name="Sampler",type="string",JSONPath=".spec.sampler.type"
 // +kubebuilder:printcolumn:name="Sampler Arg",type="string",JSONPath=".spec.sampler.argument"
+// +kubebuilder:printcolumn:name="Degraded",type="string",JSONPath=".status.conditions[?(@.type==\"Degraded\")].status",priority=1
 // +operator-sdk:csv:customresourcedefinitions:displayName="OpenTelemetry Instrumentation"
 // +operator-sdk:csv:customresourcedefinitions:resources={{Pod,v1}}
 
@@ -6,17 +6,21 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 )
@@ -41,7 +45,7 @@ var (
 
 type InstrumentationWebhook struct {
 	logger logr.Logger
-	cfg    config.Config
+	cfg    *config.Provider
 	scheme *runtime.Scheme
 }
 
@@ -50,6 +54,10 @@ func (w InstrumentationWebhook) Default(ctx context.Context, obj runtime.Object)
 	if !ok {
 		return fmt.Errorf("expected an Instrumentation, received %T", obj)
 	}
+	// the instrumentations of the other operators are left to their webhooks
+	if !w.cfg.Config().ManagesResource(instrumentation) {
+		return nil
+	}
 	return w.defaulter(instrumentation)
 }
 
@@ -58,6 +66,9 @@ func (w InstrumentationWebhook) ValidateCreate(ctx context.Context, obj runtime.
 	if !ok {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", obj)
 	}
+	if !w.cfg.Config().ManagesResource(inst) {
+		return nil, nil
+	}
 	return w.validate(inst)
 }
 
@@ -66,6 +77,9 @@ func (w InstrumentationWebhook) ValidateUpdate(ctx context.Context, oldObj, newO
 	if !ok {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", newObj)
 	}
+	if !w.cfg.Config().ManagesResource(inst) {
+		return nil, nil
+	}
 	return w.validate(inst)
 }
 
@@ -74,15 +88,23 @@ func (w InstrumentationWebhook) ValidateDelete(ctx context.Context, obj runtime.
 	if !ok || inst == nil {
 		return nil, fmt.Errorf("expected an Instrumentation, received %T", obj)
 	}
+	if !w.cfg.Config().ManagesResource(inst) {
+		return nil, nil
+	}
 	return w.validate(inst)
 }
 
 func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
+	cfg := w.cfg.Config()
 	if r.Labels == nil {
 		r.Labels = map[string]string{}
 	}
-	if r.Spec.Java.Image == "" {
-		r.Spec.Java.Image = w.cfg.AutoInstrumentationJavaImage
+	if r.Spec.ImageChannel == "" {
+		r.Spec.ImageChannel = ImageChannelStable
+	}
+	// the images previously set by the operator follow its defaults and the channel
+	if r.Spec.Java.Image == "" || r.Spec.Java.Image == r.Annotations[constants.AnnotationDefaultAutoInstrumentationJava] {
+		r.Spec.Java.Image = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationJavaImage)
 	}
 	if r.Spec.Java.Resources.Limits == nil {
 		r.Spec.Java.Resources.Limits = corev1.ResourceList{
@@ -96,8 +118,8 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 			corev1.ResourceMemory: resource.MustParse("64Mi"),
 		}
 	}
-	if r.Spec.NodeJS.Image == "" {
-		r.Spec.NodeJS.Image = w.cfg.AutoInstrumentationNodeJSImage
+	if r.Spec.NodeJS.Image == "" || r.Spec.NodeJS.Image == r.Annotations[constants.AnnotationDefaultAutoInstrumentationNodeJS] {
+		r.Spec.NodeJS.Image = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationNodeJSImage)
 	}
 	if r.Spec.NodeJS.Resources.Limits == nil {
 		r.Spec.NodeJS.Resources.Limits = corev1.ResourceList{
@@ -111,8 +133,8 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 			corev1.ResourceMemory: resource.MustParse("128Mi"),
 		}
 	}
-	if r.Spec.Python.Image == "" {
-		r.Spec.Python.Image = w.cfg.AutoInstrumentationPythonImage
+	if r.Spec.Python.Image == "" || r.Spec.Python.Image == r.Annotations[constants.AnnotationDefaultAutoInstrumentationPython] {
+		r.Spec.Python.Image = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationPythonImage)
 	}
 	if r.Spec.Python.Resources.Limits == nil {
 		r.Spec.Python.Resources.Limits = corev1.ResourceList{
@@ -126,8 +148,8 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 			corev1.ResourceMemory: resource.MustParse("64Mi"),
 		}
 	}
-	if r.Spec.DotNet.Image == "" {
-		r.Spec.DotNet.Image = w.cfg.AutoInstrumentationDotNetImage
+	if r.Spec.DotNet.Image == "" || r.Spec.DotNet.Image == r.Annotations[constants.AnnotationDefaultAutoInstrumentationDotNet] {
+		r.Spec.DotNet.Image = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationDotNetImage)
 	}
 	if r.Spec.DotNet.Resources.Limits == nil {
 		r.Spec.DotNet.Resources.Limits = corev1.ResourceList{
@@ -141,8 +163,8 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 			corev1.ResourceMemory: resource.MustParse("128Mi"),
 		}
 	}
-	if r.Spec.Go.Image == "" {
-		r.Spec.Go.Image = w.cfg.AutoInstrumentationGoImage
+	if r.Spec.Go.Image == "" || r.Spec.Go.Image == r.Annotations[constants.AnnotationDefaultAutoInstrumentationGo] {
+		r.Spec.Go.Image = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationGoImage)
 	}
 	if r.Spec.Go.Resources.Limits == nil {
 		r.Spec.Go.Resources.Limits = corev1.ResourceList{
@@ -156,8 +178,8 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 			corev1.ResourceMemory: resource.MustParse("64Mi"),
 		}
 	}
-	if r.Spec.ApacheHttpd.Image == "" {
-		r.Spec.ApacheHttpd.Image = w.cfg.AutoInstrumentationApacheHttpdImage
+	if r.Spec.ApacheHttpd.Image == "" || r.Spec.ApacheHttpd.Image == r.Annotations[constants.AnnotationDefaultAutoInstrumentationApacheHttpd] {
+		r.Spec.ApacheHttpd.Image = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationApacheHttpdImage)
 	}
 	if r.Spec.ApacheHttpd.Resources.Limits == nil {
 		r.Spec.ApacheHttpd.Resources.Limits = initContainerDefaultLimitResources
@@ -171,8 +193,8 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 	if r.Spec.ApacheHttpd.ConfigPath == "" {
 		r.Spec.ApacheHttpd.ConfigPath = "/usr/local/apache2/conf"
 	}
-	if r.Spec.Nginx.Image == "" {
-		r.Spec.Nginx.Image = w.cfg.AutoInstrumentationNginxImage
+	if r.Spec.Nginx.Image == "" || r.Spec.Nginx.Image == r.Annotations[constants.AnnotationDefaultAutoInstrumentationNginx] {
+		r.Spec.Nginx.Image = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationNginxImage)
 	}
 	if r.Spec.Nginx.Resources.Limits == nil {
 		r.Spec.Nginx.Resources.Limits = initContainerDefaultLimitResources
@@ -183,48 +205,77 @@ func (w InstrumentationWebhook) defaulter(r *Instrumentation) error {
 	if r.Spec.Nginx.ConfigFile == "" {
 		r.Spec.Nginx.ConfigFile = "/etc/nginx/nginx.conf"
 	}
+	if r.Spec.PHP.Image == "" || r.Spec.PHP.Image == r.Annotations[constants.AnnotationDefaultAutoInstrumentationPHP] {
+		r.Spec.PHP.Image = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationPHPImage)
+	}
+	if r.Spec.PHP.Resources.Limits == nil {
+		r.Spec.PHP.Resources.Limits = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		}
+	}
+	if r.Spec.PHP.Resources.Requests == nil {
+		r.Spec.PHP.Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+	}
+	if r.Spec.Ruby.Image == "" || r.Spec.Ruby.Image == r.Annotations[constants.AnnotationDefaultAutoInstrumentationRuby] {
+		r.Spec.Ruby.Image = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationRubyImage)
+	}
+	if r.Spec.Ruby.Resources.Limits == nil {
+		r.Spec.Ruby.Resources.Limits = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		}
+	}
+	if r.Spec.Ruby.Resources.Requests == nil {
+		r.Spec.Ruby.Resources.Requests = corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		}
+	}
+	if tls := r.Spec.Exporter.TLS; tls != nil && tls.Certificate != nil {
+		// the keys of the secrets written by cert-manager
+		if tls.SecretName == "" {
+			tls.SecretName = r.Name + "-exporter-tls"
+		}
+		if tls.CA == "" {
+			tls.CA = "ca.crt"
+		}
+		if tls.Cert == "" {
+			tls.Cert = "tls.crt"
+		}
+		if tls.Key == "" {
+			tls.Key = "tls.key"
+		}
+		if tls.Certificate.IssuerKind == "" {
+			tls.Certificate.IssuerKind = "Issuer"
+		}
+	}
 	// Set the defaulting annotations
 	if r.Annotations == nil {
 		r.Annotations = map[string]string{}
 	}
-	r.Annotations[constants.AnnotationDefaultAutoInstrumentationJava] = w.cfg.AutoInstrumentationJavaImage
-	r.Annotations[constants.AnnotationDefaultAutoInstrumentationNodeJS] = w.cfg.AutoInstrumentationNodeJSImage
-	r.Annotations[constants.AnnotationDefaultAutoInstrumentationPython] = w.cfg.AutoInstrumentationPythonImage
-	r.Annotations[constants.AnnotationDefaultAutoInstrumentationDotNet] = w.cfg.AutoInstrumentationDotNetImage
-	r.Annotations[constants.AnnotationDefaultAutoInstrumentationGo] = w.cfg.AutoInstrumentationGoImage
-	r.Annotations[constants.AnnotationDefaultAutoInstrumentationApacheHttpd] = w.cfg.AutoInstrumentationApacheHttpdImage
-	r.Annotations[constants.AnnotationDefaultAutoInstrumentationNginx] = w.cfg.AutoInstrumentationNginxImage
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationJava] = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationJavaImage)
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationNodeJS] = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationNodeJSImage)
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationPython] = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationPythonImage)
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationDotNet] = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationDotNetImage)
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationGo] = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationGoImage)
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationApacheHttpd] = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationApacheHttpdImage)
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationNginx] = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationNginxImage)
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationPHP] = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationPHPImage)
+	r.Annotations[constants.AnnotationDefaultAutoInstrumentationRuby] = r.Spec.ImageChannel.Image(cfg.AutoInstrumentationRubyImage)
 	return nil
 }
 
 func (w InstrumentationWebhook) validate(r *Instrumentation) (admission.Warnings, error) {
+	cfg := w.cfg.Config()
 	var warnings []string
-	switch r.Spec.Sampler.Type {
-	case "":
+	if r.Spec.Sampler.Type == "" {
 		warnings = append(warnings, "sampler type not set")
-	case TraceIDRatio, ParentBasedTraceIDRatio:
-		if r.Spec.Sampler.Argument != "" {
-			rate, err := strconv.ParseFloat(r.Spec.Sampler.Argument, 64)
-			if err != nil {
-				return warnings, fmt.Errorf("spec.sampler.argument is not a number: %s", r.Spec.Sampler.Argument)
-			}
-			if rate < 0 || rate > 1 {
-				return warnings, fmt.Errorf("spec.sampler.argument should be in rage [0..1]: %s", r.Spec.Sampler.Argument)
-			}
-		}
-	case JaegerRemote, ParentBasedJaegerRemote:
-		// value is a comma separated list of endpoint, pollingIntervalMs, initialSamplingRate
-		// Example: `endpoint=http://localhost:14250,pollingIntervalMs=5000,initialSamplingRate=0.25`
-		if r.Spec.Sampler.Argument != "" {
-			err := validateJaegerRemoteSamplerArgument(r.Spec.Sampler.Argument)
-
-			if err != nil {
-				return warnings, fmt.Errorf("spec.sampler.argument is not a valid argument for sampler %s: %w", r.Spec.Sampler.Type, err)
-			}
-		}
-	case AlwaysOn, AlwaysOff, ParentBasedAlwaysOn, ParentBasedAlwaysOff, XRaySampler:
-	default:
-		return warnings, fmt.Errorf("spec.sampler.type is not valid: %s", r.Spec.Sampler.Type)
+	} else if err := ValidateSampler(r.Spec.Sampler, "spec.sampler.type", "spec.sampler.argument"); err != nil {
+		return warnings, err
 	}
 
 	var err error
@@ -252,12 +303,62 @@ func (w InstrumentationWebhook) validate(r *Instrumentation) (admission.Warnings
 	if err != nil {
 		return warnings, fmt.Errorf("spec.nodejs.volumeClaimTemplate and spec.nodejs.volumeSizeLimit cannot both be defined: %w", err)
 	}
+	err = validateInstrVolume(r.Spec.PHP.VolumeClaimTemplate, r.Spec.PHP.VolumeSizeLimit)
+	if err != nil {
+		return warnings, fmt.Errorf("spec.php.volumeClaimTemplate and spec.php.volumeSizeLimit cannot both be defined: %w", err)
+	}
 	err = validateInstrVolume(r.Spec.Python.VolumeClaimTemplate, r.Spec.Python.VolumeSizeLimit)
 	if err != nil {
 		return warnings, fmt.Errorf("spec.python.volumeClaimTemplate and spec.python.volumeSizeLimit cannot both be defined: %w", err)
 	}
+	err = validateInstrVolume(r.Spec.Ruby.VolumeClaimTemplate, r.Spec.Ruby.VolumeSizeLimit)
+	if err != nil {
+		return warnings, fmt.Errorf("spec.ruby.volumeClaimTemplate and spec.ruby.volumeSizeLimit cannot both be defined: %w", err)
+	}
 
+	warnings = append(warnings, validateImageVersion("spec.java.image", r.Spec.Java.Image, cfg.AutoInstrumentationJavaImage)...)
+	warnings = append(warnings, validateImageVersion("spec.nodejs.image", r.Spec.NodeJS.Image, cfg.AutoInstrumentationNodeJSImage)...)
+	warnings = append(warnings, validateImageVersion("spec.python.image", r.Spec.Python.Image, cfg.AutoInstrumentationPythonImage)...)
+	warnings = append(warnings, validateImageVersion("spec.dotnet.image", r.Spec.DotNet.Image, cfg.AutoInstrumentationDotNetImage)...)
+	warnings = append(warnings, validateImageVersion("spec.go.image", r.Spec.Go.Image, cfg.AutoInstrumentationGoImage)...)
+	warnings = append(warnings, validateImageVersion("spec.apacheHttpd.image", r.Spec.ApacheHttpd.Image, cfg.AutoInstrumentationApacheHttpdImage)...)
+	warnings = append(warnings, validateImageVersion("spec.nginx.image", r.Spec.Nginx.Image, cfg.AutoInstrumentationNginxImage)...)
+	warnings = append(warnings, validateImageVersion("spec.php.image", r.Spec.PHP.Image, cfg.AutoInstrumentationPHPImage)...)
+	warnings = append(warnings, validateImageVersion("spec.ruby.image", r.Spec.Ruby.Image, cfg.AutoInstrumentationRubyImage)...)
 	warnings = append(warnings, validateExporter(r.Spec.Exporter)...)
+	if tls := r.Spec.Exporter.TLS; tls != nil && tls.Certificate != nil {
+		if tls.Certificate.IssuerName == "" {
+			return warnings, fmt.Errorf("spec.exporter.tls.certificate.issuerName must be set")
+		}
+		if cfg.CertManagerAvailability != certmanager.Available {
+			warnings = append(warnings, "spec.exporter.tls.certificate is ignored, cert-manager isn't available to the operator")
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(r.Spec.Resource.LabelsToAttributes)) {
+		if r.Spec.Resource.LabelsToAttributes[key] == "" {
+			return warnings, fmt.Errorf("spec.resource.labelsToAttributes[%s] must be set to the name of an attribute", key)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(r.Spec.Resource.AnnotationsToAttributes)) {
+		if r.Spec.Resource.AnnotationsToAttributes[key] == "" {
+			return warnings, fmt.Errorf("spec.resource.annotationsToAttributes[%s] must be set to the name of an attribute", key)
+		}
+	}
+
+	if ref := r.Spec.Java.ConfigMapRef; ref != nil && (ref.Name == "" || ref.Key == "") {
+		return warnings, fmt.Errorf("spec.java.configMapRef.name and spec.java.configMapRef.key must be set")
+	}
+	if ref := r.Spec.NodeJS.ConfigMapRef; ref != nil && (ref.Name == "" || ref.Key == "") {
+		return warnings, fmt.Errorf("spec.nodejs.configMapRef.name and spec.nodejs.configMapRef.key must be set")
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(r.Spec.NamespaceSelector); err != nil {
+		return warnings, fmt.Errorf("spec.namespaceSelector is invalid: %w", err)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(r.Spec.ObjectSelector); err != nil {
+		return warnings, fmt.Errorf("spec.objectSelector is invalid: %w", err)
+	}
 
 	return warnings, nil
 }
@@ -281,6 +382,36 @@ func validateExporter(exporter Exporter) []string {
 	return warnings
 }
 
+// ValidateSampler validates the type and the argument of a sampler, set by the typeField and argumentField.
+func ValidateSampler(sampler Sampler, typeField, argumentField string) error {
+	switch sampler.Type {
+	case TraceIDRatio, ParentBasedTraceIDRatio:
+		if sampler.Argument != "" {
+			rate, err := strconv.ParseFloat(sampler.Argument, 64)
+			if err != nil {
+				return fmt.Errorf("%s is not a number: %s", argumentField, sampler.Argument)
+			}
+			if rate < 0 || rate > 1 {
+				return fmt.Errorf("%s should be in rage [0..1]: %s", argumentField, sampler.Argument)
+			}
+		}
+	case JaegerRemote, ParentBasedJaegerRemote:
+		// value is a comma separated list of endpoint, pollingIntervalMs, initialSamplingRate
+		// Example: `endpoint=http://localhost:14250,pollingIntervalMs=5000,initialSamplingRate=0.25`
+		if sampler.Argument != "" {
+			err := validateJaegerRemoteSamplerArgument(sampler.Argument)
+
+			if err != nil {
+				return fmt.Errorf("%s is not a valid argument for sampler %s: %w", argumentField, sampler.Type, err)
+			}
+		}
+	case AlwaysOn, AlwaysOff, ParentBasedAlwaysOn, ParentBasedAlwaysOff, XRaySampler:
+	default:
+		return fmt.Errorf("%s is not valid: %s", typeField, sampler.Type)
+	}
+	return nil
+}
+
 func validateJaegerRemoteSamplerArgument(argument string) error {
 	parts := strings.Split(argument, ",")
 
@@ -319,7 +450,7 @@ func validateInstrVolume(volumeClaimTemplate corev1.PersistentVolumeClaimTemplat
 	return nil
 }
 
-func NewInstrumentationWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg config.Config) *InstrumentationWebhook {
+func NewInstrumentationWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg *config.Provider) *InstrumentationWebhook {
 	return &InstrumentationWebhook{
 		logger: logger,
 		scheme: scheme,
@@ -327,7 +458,7 @@ func NewInstrumentationWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg c
 	}
 }
 
-func SetupInstrumentationWebhook(mgr ctrl.Manager, cfg config.Config) error {
+func SetupInstrumentationWebhook(mgr ctrl.Manager, cfg *config.Provider) error {
 	ivw := NewInstrumentationWebhook(
 		mgr.GetLogger().WithValues("handler", "InstrumentationWebhook"),
 		mgr.GetScheme(),
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -37,6 +39,8 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 				config.WithAutoInstrumentationGoImage("go-img:1"),
 				config.WithAutoInstrumentationNginxImage("nginx-img:1"),
 				config.WithAutoInstrumentationApacheHttpdImage("apache-httpd-img:1"),
+				config.WithAutoInstrumentationPHPImage("php-img:1"),
+				config.WithAutoInstrumentationRubyImage("ruby-img:1"),
 			},
 			verify: func(t *testing.T, inst *Instrumentation) {
 				assert.Equal(t, "java-img:1", inst.Spec.Java.Image)
@@ -46,6 +50,8 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 				assert.Equal(t, "go-img:1", inst.Spec.Go.Image)
 				assert.Equal(t, "nginx-img:1", inst.Spec.Nginx.Image)
 				assert.Equal(t, "apache-httpd-img:1", inst.Spec.ApacheHttpd.Image)
+				assert.Equal(t, "php-img:1", inst.Spec.PHP.Image)
+				assert.Equal(t, "ruby-img:1", inst.Spec.Ruby.Image)
 
 				assert.Equal(t, "java-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-java-image"])
 				assert.Equal(t, "nodejs-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-nodejs-image"])
@@ -54,6 +60,8 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 				assert.Equal(t, "go-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-go-image"])
 				assert.Equal(t, "nginx-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-nginx-image"])
 				assert.Equal(t, "apache-httpd-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-apache-httpd-image"])
+				assert.Equal(t, "php-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-php-image"])
+				assert.Equal(t, "ruby-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-ruby-image"])
 			},
 		},
 		{
@@ -81,6 +89,12 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 					ApacheHttpd: ApacheHttpd{
 						Image: "custom-apache-httpd-img:2",
 					},
+					PHP: PHP{
+						Image: "custom-php-img:2",
+					},
+					Ruby: Ruby{
+						Image: "custom-ruby-img:2",
+					},
 				},
 			},
 			config: []config.Option{
@@ -91,6 +105,8 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 				config.WithAutoInstrumentationGoImage("go-img:1"),
 				config.WithAutoInstrumentationNginxImage("nginx-img:1"),
 				config.WithAutoInstrumentationApacheHttpdImage("apache-httpd-img:1"),
+				config.WithAutoInstrumentationPHPImage("php-img:1"),
+				config.WithAutoInstrumentationRubyImage("ruby-img:1"),
 			},
 			verify: func(t *testing.T, inst *Instrumentation) {
 				assert.Equal(t, "custom-java-img:2", inst.Spec.Java.Image)
@@ -100,6 +116,8 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 				assert.Equal(t, "custom-go-img:2", inst.Spec.Go.Image)
 				assert.Equal(t, "custom-nginx-img:2", inst.Spec.Nginx.Image)
 				assert.Equal(t, "custom-apache-httpd-img:2", inst.Spec.ApacheHttpd.Image)
+				assert.Equal(t, "custom-php-img:2", inst.Spec.PHP.Image)
+				assert.Equal(t, "custom-ruby-img:2", inst.Spec.Ruby.Image)
 
 				assert.Equal(t, "java-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-java-image"])
 				assert.Equal(t, "nodejs-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-nodejs-image"])
@@ -108,6 +126,8 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 				assert.Equal(t, "go-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-go-image"])
 				assert.Equal(t, "nginx-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-nginx-image"])
 				assert.Equal(t, "apache-httpd-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-apache-httpd-image"])
+				assert.Equal(t, "php-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-php-image"])
+				assert.Equal(t, "ruby-img:1", inst.ObjectMeta.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-ruby-image"])
 			},
 		},
 		{
@@ -150,6 +170,16 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 				assert.Equal(t, resource.MustParse("1m"), inst.Spec.ApacheHttpd.Resources.Requests[corev1.ResourceCPU])
 				assert.Equal(t, resource.MustParse("128Mi"), inst.Spec.ApacheHttpd.Resources.Requests[corev1.ResourceMemory])
 
+				assert.Equal(t, resource.MustParse("500m"), inst.Spec.PHP.Resources.Limits[corev1.ResourceCPU])
+				assert.Equal(t, resource.MustParse("256Mi"), inst.Spec.PHP.Resources.Limits[corev1.ResourceMemory])
+				assert.Equal(t, resource.MustParse("50m"), inst.Spec.PHP.Resources.Requests[corev1.ResourceCPU])
+				assert.Equal(t, resource.MustParse("64Mi"), inst.Spec.PHP.Resources.Requests[corev1.ResourceMemory])
+
+				assert.Equal(t, resource.MustParse("500m"), inst.Spec.Ruby.Resources.Limits[corev1.ResourceCPU])
+				assert.Equal(t, resource.MustParse("256Mi"), inst.Spec.Ruby.Resources.Limits[corev1.ResourceMemory])
+				assert.Equal(t, resource.MustParse("50m"), inst.Spec.Ruby.Resources.Requests[corev1.ResourceCPU])
+				assert.Equal(t, resource.MustParse("64Mi"), inst.Spec.Ruby.Resources.Requests[corev1.ResourceMemory])
+
 				assert.Equal(t, "/etc/nginx/nginx.conf", inst.Spec.Nginx.ConfigFile)
 				assert.Equal(t, "/usr/local/apache2/conf", inst.Spec.ApacheHttpd.ConfigPath)
 				assert.Equal(t, "2.4", inst.Spec.ApacheHttpd.Version)
@@ -289,12 +319,117 @@ func TestInstrumentationDefaultingWebhook(t *testing.T) {
 				assert.Equal(t, "2.5", inst.Spec.ApacheHttpd.Version)
 			},
 		},
+		{
+			name: "exporter certificate",
+			input: &Instrumentation{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-inst"},
+				Spec: InstrumentationSpec{
+					Exporter: Exporter{
+						Endpoint: "https://gateway:4318",
+						TLS:      &TLS{Certificate: &ExporterCertificate{IssuerName: "gateway-ca"}},
+					},
+				},
+			},
+			verify: func(t *testing.T, inst *Instrumentation) {
+				assert.Equal(t, &TLS{
+					SecretName:  "my-inst-exporter-tls",
+					CA:          "ca.crt",
+					Cert:        "tls.crt",
+					Key:         "tls.key",
+					Certificate: &ExporterCertificate{IssuerName: "gateway-ca", IssuerKind: "Issuer"},
+				}, inst.Spec.Exporter.TLS)
+			},
+		},
+		{
+			name: "exporter certificate with a secret",
+			input: &Instrumentation{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-inst"},
+				Spec: InstrumentationSpec{
+					Exporter: Exporter{
+						Endpoint: "https://gateway:4318",
+						TLS: &TLS{
+							SecretName:    "agents-tls",
+							ConfigMapName: "gateway-ca",
+							CA:            "ca.pem",
+							Certificate:   &ExporterCertificate{IssuerName: "gateway-ca", IssuerKind: "ClusterIssuer"},
+						},
+					},
+				},
+			},
+			verify: func(t *testing.T, inst *Instrumentation) {
+				assert.Equal(t, &TLS{
+					SecretName:    "agents-tls",
+					ConfigMapName: "gateway-ca",
+					CA:            "ca.pem",
+					Cert:          "tls.crt",
+					Key:           "tls.key",
+					Certificate:   &ExporterCertificate{IssuerName: "gateway-ca", IssuerKind: "ClusterIssuer"},
+				}, inst.Spec.Exporter.TLS)
+			},
+		},
+		{
+			name: "latest image channel",
+			input: &Instrumentation{
+				Spec: InstrumentationSpec{
+					ImageChannel: ImageChannelLatest,
+					Python:       Python{Image: "custom-python-img:2"},
+				},
+			},
+			config: []config.Option{
+				config.WithAutoInstrumentationJavaImage("ghcr.io/org/java-img:1.2.3"),
+				config.WithAutoInstrumentationPythonImage("ghcr.io/org/python-img:1.2.3"),
+			},
+			verify: func(t *testing.T, inst *Instrumentation) {
+				assert.Equal(t, "ghcr.io/org/java-img:latest", inst.Spec.Java.Image)
+				assert.Equal(t, "custom-python-img:2", inst.Spec.Python.Image)
+				assert.Equal(t, "ghcr.io/org/java-img:latest", inst.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-java-image"])
+			},
+		},
+		{
+			name: "images set by the operator follow its defaults",
+			input: &Instrumentation{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"instrumentation.opentelemetry.io/default-auto-instrumentation-java-image":   "java-img:1",
+						"instrumentation.opentelemetry.io/default-auto-instrumentation-python-image": "python-img:1",
+					},
+				},
+				Spec: InstrumentationSpec{
+					Java:   Java{Image: "java-img:1"},
+					Python: Python{Image: "custom-python-img:1"},
+				},
+			},
+			config: []config.Option{
+				config.WithAutoInstrumentationJavaImage("java-img:2"),
+				config.WithAutoInstrumentationPythonImage("python-img:2"),
+			},
+			verify: func(t *testing.T, inst *Instrumentation) {
+				assert.Equal(t, ImageChannelStable, inst.Spec.ImageChannel)
+				assert.Equal(t, "java-img:2", inst.Spec.Java.Image)
+				assert.Equal(t, "custom-python-img:1", inst.Spec.Python.Image)
+				assert.Equal(t, "python-img:2", inst.Annotations["instrumentation.opentelemetry.io/default-auto-instrumentation-python-image"])
+			},
+		},
+		{
+			name: "instrumentation of another operator",
+			input: &Instrumentation{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "b"}},
+			},
+			config: []config.Option{
+				config.WithAutoInstrumentationJavaImage("java-img:1"),
+				config.WithResourceSelector(labels.SelectorFromSet(labels.Set{"team": "a"})),
+			},
+			verify: func(t *testing.T, inst *Instrumentation) {
+				assert.Empty(t, inst.Spec.ImageChannel)
+				assert.Empty(t, inst.Spec.Java.Image)
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			webhook := InstrumentationWebhook{
-				cfg: config.New(test.config...),
+				cfg: config.NewProvider(config.New(test.config...)),
 			}
 
 			err := webhook.Default(context.Background(), test.input)
@@ -391,6 +526,94 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 			},
 			warnings: []string{"both exporter.tls.key and exporter.tls.cert mut be set"},
 		},
+		{
+			name: "exporter certificate without issuer",
+			err:  "spec.exporter.tls.certificate.issuerName must be set",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Exporter: Exporter{
+						Endpoint: "https://gateway:4318",
+						TLS:      &TLS{SecretName: "my-inst-exporter-tls", Certificate: &ExporterCertificate{}},
+					},
+				},
+			},
+		},
+		{
+			name: "label mapped to no attribute",
+			err:  "spec.resource.labelsToAttributes[team] must be set to the name of an attribute",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Resource: Resource{
+						LabelsToAttributes: map[string]string{"team": ""},
+					},
+				},
+			},
+		},
+		{
+			name: "annotation mapped to no attribute",
+			err:  "spec.resource.annotationsToAttributes[example.com/cost-center] must be set to the name of an attribute",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Resource: Resource{
+						LabelsToAttributes:      map[string]string{"team": "team.name"},
+						AnnotationsToAttributes: map[string]string{"example.com/cost-center": ""},
+					},
+				},
+			},
+		},
+		{
+			name: "java config file without key",
+			err:  "spec.java.configMapRef.name and spec.java.configMapRef.key must be set",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Java: Java{
+						ConfigMapRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "javaagent-config"}},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid object selector",
+			err:  "spec.objectSelector is invalid: \"Like\" is not a valid label selector operator",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}},
+					ObjectSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Like"}},
+					},
+				},
+			},
+		},
+		{
+			name: "exporter certificate without cert-manager",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Sampler: Sampler{
+						Type: ParentBasedAlwaysOn,
+					},
+					Exporter: Exporter{
+						Endpoint: "https://gateway:4318",
+						TLS:      &TLS{SecretName: "my-inst-exporter-tls", Certificate: &ExporterCertificate{IssuerName: "gateway-ca"}},
+					},
+				},
+			},
+			warnings: []string{"spec.exporter.tls.certificate is ignored, cert-manager isn't available to the operator"},
+		},
 		{
 			name: "exporter: tls key set but missing cert",
 			inst: Instrumentation{
@@ -591,6 +814,40 @@ func TestInstrumentationValidatingWebhook(t *testing.T) {
 			},
 			warnings: []string{"sampler type not set"},
 		},
+		{
+			name: "PHP with volume and volumeSizeLimit",
+			err:  "spec.php.volumeClaimTemplate and spec.php.volumeSizeLimit cannot both be defined",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					PHP: PHP{
+						VolumeClaimTemplate: corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+							},
+						},
+						VolumeSizeLimit: &defaultVolumeSize,
+					},
+				},
+			},
+			warnings: []string{"sampler type not set"},
+		},
+		{
+			name: "Ruby with volume and volumeSizeLimit",
+			err:  "spec.ruby.volumeClaimTemplate and spec.ruby.volumeSizeLimit cannot both be defined",
+			inst: Instrumentation{
+				Spec: InstrumentationSpec{
+					Ruby: Ruby{
+						VolumeClaimTemplate: corev1.PersistentVolumeClaimTemplate{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+							},
+						},
+						VolumeSizeLimit: &defaultVolumeSize,
+					},
+				},
+			},
+			warnings: []string{"sampler type not set"},
+		},
 	}
 
 	for _, test := range tests {
@@ -715,3 +972,37 @@ func TestInstrumentationJaegerRemote(t *testing.T) {
 		}
 	}
 }
+
+func TestInstrumentationImageVersion(t *testing.T) {
+	webhook := InstrumentationWebhook{
+		cfg: config.NewProvider(config.New(
+			config.WithAutoInstrumentationJavaImage("ghcr.io/org/autoinstrumentation-java:2.10.0"),
+			config.WithAutoInstrumentationGoImage("ghcr.io/org/autoinstrumentation-go:v0.19.0-alpha"),
+			config.WithAutoInstrumentationPythonImage("ghcr.io/org/autoinstrumentation-python:0.50b0"),
+		)),
+	}
+	inst := &Instrumentation{
+		Spec: InstrumentationSpec{
+			Sampler: Sampler{Type: AlwaysOn},
+			Java:    Java{Image: "ghcr.io/org/autoinstrumentation-java:1.33.5"},
+			NodeJS:  NodeJS{Image: "ghcr.io/org/autoinstrumentation-nodejs:latest"},
+			Go:      Go{Image: "ghcr.io/org/autoinstrumentation-go:v0.21.0-alpha"},
+			Python:  Python{Image: "ghcr.io/org/autoinstrumentation-python:0.48b0"},
+		},
+	}
+
+	warnings, err := webhook.ValidateCreate(context.Background(), inst)
+	assert.NoError(t, err)
+	assert.Equal(t, admission.Warnings{
+		"spec.java.image ghcr.io/org/autoinstrumentation-java:1.33.5 may not be compatible with the operator, which is released with the version 2.10.0",
+		"spec.python.image ghcr.io/org/autoinstrumentation-python:0.48b0 may not be compatible with the operator, which is released with the version 0.50",
+		"spec.go.image ghcr.io/org/autoinstrumentation-go:v0.21.0-alpha may not be compatible with the operator, which is released with the version 0.19.0",
+	}, warnings)
+
+	inst.Spec.Java.Image = "ghcr.io/org/autoinstrumentation-java:2.12.0"
+	inst.Spec.Go.Image = "ghcr.io/org/autoinstrumentation-go:v0.19.1-alpha"
+	inst.Spec.Python.Image = "ghcr.io/org/autoinstrumentation-python:0.50b1"
+	warnings, err = webhook.ValidateCreate(context.Background(), inst)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+}
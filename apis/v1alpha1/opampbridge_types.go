@@ -17,12 +17,36 @@ type OpAMPBridgeSpec struct {
 	// typically used to set access tokens or other authorization headers.
 	// +optional
 	Headers map[string]string `json:"headers,omitempty"`
+	// TLS configures the TLS connection to the OpAMP Server, with the client certificate of the OpAMP Bridge when the
+	// server requires mutual TLS.
+	// +optional
+	TLS *OpAMPBridgeTLS `json:"tls,omitempty"`
+	// Transport is the transport the OpAMP Bridge connects to the OpAMP Server with: websocket keeps a WebSocket
+	// connection, http polls the server with plain HTTP requests, e.g. when proxies don't keep long-lived WebSockets.
+	// The default is http for the http and https endpoints, and websocket otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=websocket;http
+	Transport string `json:"transport,omitempty"`
+	// PollingInterval is the interval the OpAMP Server is polled at with the http transport.
+	// +optional
+	PollingInterval *metav1.Duration `json:"pollingInterval,omitempty"`
+	// HeartbeatInterval is the interval the OpAMP Bridge reports its health to the OpAMP Server at, 30s by default.
+	// +optional
+	HeartbeatInterval *metav1.Duration `json:"heartbeatInterval,omitempty"`
+	// EnableCompression compresses the messages sent to the OpAMP Server.
+	// +optional
+	EnableCompression bool `json:"enableCompression,omitempty"`
 	// Capabilities supported by the OpAMP Bridge
 	// +required
 	Capabilities map[OpAMPBridgeCapability]bool `json:"capabilities"`
 	// ComponentsAllowed is a list of allowed OpenTelemetry components for each pipeline type (receiver, processor, etc.)
 	// +optional
 	ComponentsAllowed map[string][]string `json:"componentsAllowed,omitempty"`
+	// ManagedKinds are the kinds of the resources the OpAMP Bridge reports and manages besides the collectors,
+	// under the <kind>:<namespace>/<name> keys of the remote and effective configurations.
+	// +optional
+	// +listType=set
+	ManagedKinds []OpAMPBridgeManagedKind `json:"managedKinds,omitempty"`
 	// Description allows the customization of the non identifying attributes for the OpAMP Bridge.
 	// +optional
 	Description *AgentDescription `json:"description,omitempty"`
@@ -120,6 +144,51 @@ type AgentDescription struct {
 	NonIdentifyingAttributes map[string]string `json:"non_identifying_attributes"`
 }
 
+// OpAMPBridgeTLS defines the TLS configuration of the connection to the OpAMP Server.
+type OpAMPBridgeTLS struct {
+	// SecretName is the name of the secret with the client certificate (tls.crt) and the private key (tls.key) of the
+	// OpAMP Bridge. The secret is mounted into the OpAMP Bridge pods, which reconnect to the OpAMP Server when the
+	// certificate is rotated.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// ConfigMapName is the name of the config map with the CA certificate (ca.crt) of the OpAMP Server. When it isn't
+	// set, the CA certificate of the secret requested with Certificate is used, or the system CA certificates otherwise.
+	// +optional
+	ConfigMapName string `json:"configMapName,omitempty"`
+
+	// ServerName is the name the certificate of the OpAMP Server is verified against. The default is the host of the
+	// endpoint.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// Certificate requests the client certificate of the OpAMP Bridge from cert-manager, which stores it in the secret
+	// SecretName, by default <name>-opamp-bridge-tls, with its private key and the CA certificate.
+	// +optional
+	Certificate *OpAMPBridgeCertificate `json:"certificate,omitempty"`
+}
+
+// OpAMPBridgeCertificate defines the cert-manager Certificate of the OpAMP Bridge.
+type OpAMPBridgeCertificate struct {
+	// IssuerName is the name of the cert-manager issuer signing the certificate.
+	IssuerName string `json:"issuerName"`
+
+	// IssuerKind is the kind of the cert-manager issuer, Issuer, in the namespace of the OpAMPBridge, or ClusterIssuer.
+	// +optional
+	// +kubebuilder:default:=Issuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	IssuerKind string `json:"issuerKind,omitempty"`
+
+	// CommonName of the certificate, the identity of the OpAMP Bridge for the OpAMP Server. The default is the name of
+	// the OpAMPBridge.
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// Duration of the certificate, renewed by cert-manager before it expires. The default is the one of cert-manager, 90 days.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
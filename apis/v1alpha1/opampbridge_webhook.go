@@ -14,6 +14,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 )
 
@@ -29,7 +30,7 @@ var (
 
 type OpAMPBridgeWebhook struct {
 	logger logr.Logger
-	cfg    config.Config
+	cfg    *config.Provider
 	scheme *runtime.Scheme
 }
 
@@ -86,6 +87,15 @@ func (o *OpAMPBridgeWebhook) defaulter(r *OpAMPBridge) error {
 	if !enabled || !found {
 		r.Spec.Capabilities[OpAMPBridgeCapabilityReportsStatus] = true
 	}
+
+	if tls := r.Spec.TLS; tls != nil && tls.Certificate != nil {
+		if tls.SecretName == "" {
+			tls.SecretName = r.Name + "-opamp-bridge-tls"
+		}
+		if tls.Certificate.IssuerKind == "" {
+			tls.Certificate.IssuerKind = "Issuer"
+		}
+	}
 	return nil
 }
 
@@ -102,6 +112,11 @@ func (o *OpAMPBridgeWebhook) validate(r *OpAMPBridge) (admission.Warnings, error
 		return warnings, fmt.Errorf("the capabilities supported by OpAMP Bridge are not specified")
 	}
 
+	// the collector images are offered as packages and their statuses reported together
+	if r.Spec.Capabilities[OpAMPBridgeCapabilityAcceptsPackages] != r.Spec.Capabilities[OpAMPBridgeCapabilityReportsPackageStatuses] {
+		return warnings, fmt.Errorf("the AcceptsPackages and ReportsPackageStatuses capabilities must be enabled together")
+	}
+
 	// validate port config
 	for _, p := range r.Spec.Ports {
 		nameErrs := validation.IsValidPortName(p.Name)
@@ -116,10 +131,34 @@ func (o *OpAMPBridgeWebhook) validate(r *OpAMPBridge) (admission.Warnings, error
 	if r.Spec.Replicas != nil && *r.Spec.Replicas > 1 {
 		return warnings, fmt.Errorf("replica count must not be greater than 1")
 	}
+
+	if r.Spec.PollingInterval != nil {
+		transport := r.Spec.Transport
+		if scheme := strings.SplitN(r.Spec.Endpoint, "://", 2)[0]; transport == "" && scheme != "http" && scheme != "https" {
+			transport = "websocket"
+		}
+		if transport == "websocket" {
+			warnings = append(warnings, "spec.pollingInterval is ignored with the websocket transport")
+		}
+	}
+
+	if tls := r.Spec.TLS; tls != nil {
+		if scheme := strings.SplitN(r.Spec.Endpoint, "://", 2)[0]; scheme != "wss" && scheme != "https" {
+			return warnings, fmt.Errorf("spec.tls requires a wss or https OpAMP server endpoint")
+		}
+		if tls.Certificate != nil {
+			if tls.Certificate.IssuerName == "" {
+				return warnings, fmt.Errorf("spec.tls.certificate.issuerName must be set")
+			}
+			if o.cfg.Config().CertManagerAvailability != certmanager.Available {
+				warnings = append(warnings, "spec.tls.certificate is ignored, cert-manager isn't available to the operator")
+			}
+		}
+	}
 	return warnings, nil
 }
 
-func SetupOpAMPBridgeWebhook(mgr ctrl.Manager, cfg config.Config) error {
+func SetupOpAMPBridgeWebhook(mgr ctrl.Manager, cfg *config.Provider) error {
 	webhook := &OpAMPBridgeWebhook{
 		logger: mgr.GetLogger().WithValues("handler", "OpAMPBridgeWebhook"),
 		scheme: mgr.GetScheme(),
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
@@ -89,6 +90,34 @@ func TestOpAMPBridgeDefaultingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "default the secret and the issuer kind of the certificate",
+			opampBridge: OpAMPBridge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "bridge",
+				},
+				Spec: OpAMPBridgeSpec{
+					TLS: &OpAMPBridgeTLS{
+						Certificate: &OpAMPBridgeCertificate{IssuerName: "opamp-ca"},
+					},
+				},
+			},
+			expected: OpAMPBridge{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "bridge",
+					Labels: map[string]string{},
+				},
+				Spec: OpAMPBridgeSpec{
+					Replicas:        &one,
+					UpgradeStrategy: UpgradeStrategyAutomatic,
+					Capabilities:    map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+					TLS: &OpAMPBridgeTLS{
+						SecretName:  "bridge-opamp-bridge-tls",
+						Certificate: &OpAMPBridgeCertificate{IssuerName: "opamp-ca", IssuerKind: "Issuer"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -97,11 +126,11 @@ func TestOpAMPBridgeDefaultingWebhook(t *testing.T) {
 			webhook := &OpAMPBridgeWebhook{
 				logger: logr.Discard(),
 				scheme: testScheme,
-				cfg: config.New(
+				cfg: config.NewProvider(config.New(
 					config.WithCollectorImage("collector:v0.0.0"),
 					config.WithTargetAllocatorImage("ta:v0.0.0"),
 					config.WithOperatorOpAMPBridgeImage("opampbridge:v0.0.0"),
-				),
+				)),
 			}
 			ctx := context.Background()
 			err := webhook.Default(ctx, &test.opampBridge)
@@ -164,6 +193,45 @@ func TestOpAMPBridgeValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the capabilities supported by OpAMP Bridge are not specified",
 		},
+		{
+			name: "polling interval with the websocket transport",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:        "ws://opamp-server:4320/v1/opamp",
+					PollingInterval: &metav1.Duration{Duration: 10 * time.Second},
+					Capabilities: map[OpAMPBridgeCapability]bool{
+						OpAMPBridgeCapabilityReportsStatus: true,
+					},
+				},
+			},
+			expectedWarnings: []string{"spec.pollingInterval is ignored with the websocket transport"},
+		},
+		{
+			name: "polling interval with the http transport",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:        "ws://opamp-server:4320/v1/opamp",
+					Transport:       "http",
+					PollingInterval: &metav1.Duration{Duration: 10 * time.Second},
+					Capabilities: map[OpAMPBridgeCapability]bool{
+						OpAMPBridgeCapabilityReportsStatus: true,
+					},
+				},
+			},
+		},
+		{
+			name: "packages accepted without reporting their statuses",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint: "ws://opamp-server:4320/v1/opamp",
+					Capabilities: map[OpAMPBridgeCapability]bool{
+						OpAMPBridgeCapabilityReportsStatus:   true,
+						OpAMPBridgeCapabilityAcceptsPackages: true,
+					},
+				},
+			},
+			expectedErr: "the AcceptsPackages and ReportsPackageStatuses capabilities must be enabled together",
+		},
 		{
 			name: "replica count greater than 1 should return error",
 			opampBridge: OpAMPBridge{
@@ -276,6 +344,28 @@ func TestOpAMPBridgeValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpAMPBridge Spec Ports configuration is incorrect",
 		},
+		{
+			name: "tls with a plain text endpoint",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:     "ws://opamp-server:4320/v1/opamp",
+					Capabilities: map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+					TLS:          &OpAMPBridgeTLS{SecretName: "opamp-bridge-tls"},
+				},
+			},
+			expectedErr: "spec.tls requires a wss or https OpAMP server endpoint",
+		},
+		{
+			name: "tls certificate without issuer",
+			opampBridge: OpAMPBridge{
+				Spec: OpAMPBridgeSpec{
+					Endpoint:     "wss://opamp-server:4320/v1/opamp",
+					Capabilities: map[OpAMPBridgeCapability]bool{OpAMPBridgeCapabilityReportsStatus: true},
+					TLS:          &OpAMPBridgeTLS{SecretName: "opamp-bridge-tls", Certificate: &OpAMPBridgeCertificate{}},
+				},
+			},
+			expectedErr: "spec.tls.certificate.issuerName must be set",
+		},
 	}
 
 	for _, test := range tests {
@@ -284,11 +374,11 @@ func TestOpAMPBridgeValidatingWebhook(t *testing.T) {
 			webhook := &OpAMPBridgeWebhook{
 				logger: logr.Discard(),
 				scheme: testScheme,
-				cfg: config.New(
+				cfg: config.NewProvider(config.New(
 					config.WithCollectorImage("collector:v0.0.0"),
 					config.WithTargetAllocatorImage("ta:v0.0.0"),
 					config.WithOperatorOpAMPBridgeImage("opampbridge:v0.0.0"),
-				),
+				)),
 			}
 			ctx := context.Background()
 			warnings, err := webhook.ValidateCreate(ctx, &test.opampBridge)
@@ -15,6 +15,7 @@ func init() {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.scale.replicas,selectorpath=.status.scale.selector
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.image"
 // +kubebuilder:printcolumn:name="Management",type="string",JSONPath=".spec.managementState",description="Management State"
@@ -48,6 +49,10 @@ type TargetAllocatorStatus struct {
 	// Image indicates the container image to use for the Target Allocator.
 	// +optional
 	Image string `json:"image,omitempty"`
+
+	// Scale is the TargetAllocator's scale subresource status.
+	// +optional
+	Scale v1beta1.ScaleSubresourceStatus `json:"scale,omitempty"`
 }
 
 // TargetAllocatorSpec defines the desired state of TargetAllocator.
@@ -55,12 +60,15 @@ type TargetAllocatorSpec struct {
 	// Common defines fields that are common to all OpenTelemetry CRD workloads.
 	v1beta1.OpenTelemetryCommonFields `json:",inline"`
 	// AllocationStrategy determines which strategy the target allocator should use for allocation.
-	// The current options are least-weighted, consistent-hashing and per-node. The default is
+	// The current options are least-weighted, consistent-hashing, per-node and zone-aware. The default is
 	// consistent-hashing.
 	// WARNING: The per-node strategy currently ignores targets without a Node, like control plane components.
 	// +optional
 	// +kubebuilder:default:=consistent-hashing
 	AllocationStrategy v1beta1.TargetAllocatorAllocationStrategy `json:"allocationStrategy,omitempty"`
+	// ConsistentHashing tunes the hash ring of the consistent-hashing and zone-aware allocation strategies.
+	// +optional
+	ConsistentHashing v1beta1.TargetAllocatorConsistentHashing `json:"consistentHashing,omitempty"`
 	// FilterStrategy determines how to filter targets before allocating them among the collectors.
 	// The only current option is relabel-config (drops targets based on prom relabel_config).
 	// The default is relabel-config.
@@ -87,10 +95,17 @@ type TargetAllocatorSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Observability"
 	Observability v1beta1.ObservabilitySpec `json:"observability,omitempty"`
 	// CollectorNotReadyGracePeriod defines the grace period after which a TargetAllocator stops considering a collector is target assignable.
-	// The default is 30s, which means that if a collector becomes not Ready, the target allocator will wait for 30 seconds before reassigning its targets. The assumption is that the state is temporary, and an expensive target reallocation should be avoided if possible.
+	// The default is 30s, which means that if a collector becomes not Ready, the target allocator will wait for 30 seconds before reassigning its targets. The assumption is that the state is temporary, and an expensive target reallocation should be avoided if possible. New collectors are only assigned targets once they become Ready.
 	//
 	// +optional
 	// +kubebuilder:default:="30s"
 	// +kubebuilder:validation:Format:=duration
 	CollectorNotReadyGracePeriod *metav1.Duration `json:"collectorNotReadyGracePeriod,omitempty"`
+	// CollectorDrainTimeout defines how long a collector being deleted keeps its targets, while no new targets are assigned to it.
+	// Its targets are reassigned once the timeout expires, before the collector terminates. The default is 0s, which means that
+	// the targets of a collector being deleted are reassigned right away.
+	//
+	// +optional
+	// +kubebuilder:validation:Format:=duration
+	CollectorDrainTimeout *metav1.Duration `json:"collectorDrainTimeout,omitempty"`
 }
@@ -6,8 +6,10 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -15,8 +17,10 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/fips"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var (
@@ -31,7 +35,7 @@ var (
 
 type TargetAllocatorWebhook struct {
 	logger   logr.Logger
-	cfg      config.Config
+	cfg      *config.Provider
 	scheme   *runtime.Scheme
 	reviewer *rbac.Reviewer
 }
@@ -49,7 +53,14 @@ func (w TargetAllocatorWebhook) ValidateCreate(ctx context.Context, obj runtime.
 	if !ok {
 		return nil, fmt.Errorf("expected an TargetAllocator, received %T", obj)
 	}
-	return w.validate(ctx, otelcol)
+	warnings, err := w.validate(ctx, otelcol)
+	if err != nil {
+		return warnings, err
+	}
+	if err := w.validateFIPSImage(otelcol); err != nil {
+		return warnings, err
+	}
+	return append(warnings, adviceWarnings(otelcol)...), nil
 }
 
 func (w TargetAllocatorWebhook) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
@@ -57,7 +68,14 @@ func (w TargetAllocatorWebhook) ValidateUpdate(ctx context.Context, _, newObj ru
 	if !ok {
 		return nil, fmt.Errorf("expected an TargetAllocator, received %T", newObj)
 	}
-	return w.validate(ctx, otelcol)
+	warnings, err := w.validate(ctx, otelcol)
+	if err != nil {
+		return warnings, err
+	}
+	if err := w.validateFIPSImage(otelcol); err != nil {
+		return warnings, err
+	}
+	return append(warnings, adviceWarnings(otelcol)...), nil
 }
 
 func (w TargetAllocatorWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -96,6 +114,33 @@ func (w TargetAllocatorWebhook) defaulter(ta *TargetAllocator) error {
 	return nil
 }
 
+// adviceWarnings returns the warnings about the risky settings of the target allocators managed by the users. The
+// target allocators of the collectors are left out, the webhook of the collectors warning about their settings.
+func adviceWarnings(ta *TargetAllocator) admission.Warnings {
+	if slices.ContainsFunc(ta.GetOwnerReferences(), func(reference metav1.OwnerReference) bool {
+		return reference.Kind == "OpenTelemetryCollector"
+	}) {
+		return nil
+	}
+	return v1beta1.ResourceLimitsWarnings("spec.resources", ta.Spec.Resources)
+}
+
+// validateFIPSImage rejects an image not built for FIPS when the operator.fips.enforce feature gate is enabled. It only
+// applies to the target allocators created or updated, so that the existing ones can still be deleted.
+func (w TargetAllocatorWebhook) validateFIPSImage(ta *TargetAllocator) error {
+	if !featuregate.EnforceFIPS.IsEnabled() {
+		return nil
+	}
+	image := ta.Spec.Image
+	if image == "" {
+		image = w.cfg.Config().TargetAllocatorImage
+	}
+	if !fips.IsCompliantImage(image) {
+		return fmt.Errorf("the image %s isn't built for FIPS, which the %s feature gate requires", image, featuregate.EnforceFIPS.ID())
+	}
+	return nil
+}
+
 func (w TargetAllocatorWebhook) validate(ctx context.Context, ta *TargetAllocator) (admission.Warnings, error) {
 	// TODO: Further validate scrape configs
 
@@ -106,6 +151,10 @@ func (w TargetAllocatorWebhook) validate(ctx context.Context, ta *TargetAllocato
 		return warnings, err
 	}
 
+	if err := v1beta1.ValidatePodDisruptionBudget("podDisruptionBudget", ta.Spec.PodDisruptionBudget); err != nil {
+		return warnings, err
+	}
+
 	// if the prometheusCR is enabled, it needs a suite of permissions to function
 	if ta.Spec.PrometheusCR.Enabled {
 		saname := ta.Spec.ServiceAccount
@@ -126,7 +175,17 @@ func (w TargetAllocatorWebhook) validate(ctx context.Context, ta *TargetAllocato
 	return warnings, nil
 }
 
-func SetupTargetAllocatorWebhook(mgr ctrl.Manager, cfg config.Config, reviewer *rbac.Reviewer) error {
+// NewTargetAllocatorWebhook creates a new TargetAllocatorWebhook.
+func NewTargetAllocatorWebhook(logger logr.Logger, scheme *runtime.Scheme, cfg *config.Provider, reviewer *rbac.Reviewer) *TargetAllocatorWebhook {
+	return &TargetAllocatorWebhook{
+		logger:   logger,
+		scheme:   scheme,
+		cfg:      cfg,
+		reviewer: reviewer,
+	}
+}
+
+func SetupTargetAllocatorWebhook(mgr ctrl.Manager, cfg *config.Provider, reviewer *rbac.Reviewer) error {
 	cvw := &TargetAllocatorWebhook{
 		reviewer: reviewer,
 		logger:   mgr.GetLogger().WithValues("handler", "TargetAllocatorWebhook", "version", "v1beta1"),
@@ -11,8 +11,11 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfg "go.opentelemetry.io/collector/featuregate"
 	authv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -22,6 +25,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestTargetAllocatorDefaultingWebhook(t *testing.T) {
@@ -158,9 +162,9 @@ func TestTargetAllocatorDefaultingWebhook(t *testing.T) {
 			webhook := &TargetAllocatorWebhook{
 				logger: logr.Discard(),
 				scheme: testScheme,
-				cfg: config.New(
+				cfg: config.NewProvider(config.New(
 					config.WithTargetAllocatorImage("ta:v0.0.0"),
-				),
+				)),
 			}
 			ctx := context.Background()
 			err := webhook.Default(ctx, &test.targetallocator)
@@ -181,8 +185,17 @@ func TestTargetAllocatorValidatingWebhook(t *testing.T) {
 		shouldFailSar    bool
 	}{
 		{
-			name:            "valid empty spec",
-			targetallocator: TargetAllocator{},
+			name:             "valid empty spec",
+			targetallocator:  TargetAllocator{},
+			expectedWarnings: []string{"spec.resources sets no memory limit, the pods can use all the memory of their node until they or other pods are evicted"},
+		},
+		{
+			name: "valid spec of a collector",
+			targetallocator: TargetAllocator{
+				ObjectMeta: metav1.ObjectMeta{
+					OwnerReferences: []metav1.OwnerReference{{Kind: "OpenTelemetryCollector", Name: "test"}},
+				},
+			},
 		},
 		{
 			name: "valid full spec",
@@ -190,6 +203,9 @@ func TestTargetAllocatorValidatingWebhook(t *testing.T) {
 				Spec: TargetAllocatorSpec{
 					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
 						Replicas: &three,
+						Resources: v1.ResourceRequirements{
+							Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("512Mi")},
+						},
 						Ports: []v1beta1.PortsSpec{
 							{
 								ServicePort: v1.ServicePort{
@@ -236,6 +252,7 @@ func TestTargetAllocatorValidatingWebhook(t *testing.T) {
 				"missing the following rules for system:serviceaccount:test-ns:test-ta-targetallocator - configmaps: [get]",
 				"missing the following rules for system:serviceaccount:test-ns:test-ta-targetallocator - discovery.k8s.io/endpointslices: [get,list,watch]",
 				"missing the following rules for system:serviceaccount:test-ns:test-ta-targetallocator - nonResourceURL: /metrics: [get]",
+				"spec.resources sets no memory limit, the pods can use all the memory of their node until they or other pods are evicted",
 			},
 		},
 		{
@@ -244,6 +261,7 @@ func TestTargetAllocatorValidatingWebhook(t *testing.T) {
 			targetallocator: TargetAllocator{
 				Spec: TargetAllocatorSpec{},
 			},
+			expectedWarnings: []string{"spec.resources sets no memory limit, the pods can use all the memory of their node until they or other pods are evicted"},
 		},
 		{
 			name: "invalid port name",
@@ -301,6 +319,21 @@ func TestTargetAllocatorValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
 		},
+		{
+			name: "pod disruption budget with minAvailable and maxUnavailable",
+			targetallocator: TargetAllocator{
+				Spec: TargetAllocatorSpec{
+					AllocationStrategy: v1beta1.TargetAllocatorAllocationStrategyConsistentHashing,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						PodDisruptionBudget: &v1beta1.PodDisruptionBudgetSpec{
+							MinAvailable:   &intstr.IntOrString{Type: intstr.String, StrVal: "50%"},
+							MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+						},
+					},
+				},
+			},
+			expectedErr: "minAvailable and maxUnavailable are mutually exclusive",
+		},
 		{
 			name: "allowNamespaces and denyNamespaces can't both be set",
 			targetallocator: TargetAllocator{
@@ -322,10 +355,10 @@ func TestTargetAllocatorValidatingWebhook(t *testing.T) {
 			cvw := &TargetAllocatorWebhook{
 				logger: logr.Discard(),
 				scheme: testScheme,
-				cfg: config.New(
+				cfg: config.NewProvider(config.New(
 					config.WithCollectorImage("targetallocator:v0.0.0"),
 					config.WithTargetAllocatorImage("ta:v0.0.0"),
-				),
+				)),
 				reviewer: getReviewer(test.shouldFailSar),
 			}
 			ctx := context.Background()
@@ -341,6 +374,31 @@ func TestTargetAllocatorValidatingWebhook(t *testing.T) {
 	}
 }
 
+func TestTargetAllocatorValidatingWebhook_EnforceFIPS(t *testing.T) {
+	require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnforceFIPS.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnforceFIPS.ID(), false))
+	})
+
+	cvw := &TargetAllocatorWebhook{
+		logger:   logr.Discard(),
+		scheme:   testScheme,
+		cfg:      config.NewProvider(config.New(config.WithTargetAllocatorImage("ta:v0.0.0"))),
+		reviewer: getReviewer(false),
+	}
+	_, err := cvw.ValidateCreate(context.Background(), &TargetAllocator{})
+	assert.EqualError(t, err, "the image ta:v0.0.0 isn't built for FIPS, which the operator.fips.enforce feature gate requires")
+
+	// the existing target allocators can still be deleted
+	_, err = cvw.ValidateDelete(context.Background(), &TargetAllocator{})
+	assert.NoError(t, err)
+
+	ta := &TargetAllocator{}
+	ta.Spec.Image = "ta:v0.0.0-fips"
+	_, err = cvw.ValidateCreate(context.Background(), ta)
+	assert.NoError(t, err)
+}
+
 func getReviewer(shouldFailSAR bool) *rbac.Reviewer {
 	c := fake.NewSimpleClientset()
 	c.PrependReactor("create", "subjectaccessreviews", func(action kubeTesting.Action) (handled bool, ret runtime.Object, err error) {
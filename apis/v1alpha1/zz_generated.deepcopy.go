@@ -63,6 +63,11 @@ func (in *ApacheHttpd) DeepCopyInto(out *ApacheHttpd) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApacheHttpd.
@@ -169,6 +174,11 @@ func (in *DotNet) DeepCopyInto(out *DotNet) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DotNet.
@@ -187,7 +197,7 @@ func (in *Exporter) DeepCopyInto(out *Exporter) {
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = new(TLS)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -201,6 +211,26 @@ func (in *Exporter) DeepCopy() *Exporter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExporterCertificate) DeepCopyInto(out *ExporterCertificate) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExporterCertificate.
+func (in *ExporterCertificate) DeepCopy() *ExporterCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ExporterCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Extensions) DeepCopyInto(out *Extensions) {
 	*out = *in
@@ -233,6 +263,11 @@ func (in *Go) DeepCopyInto(out *Go) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Privileged != nil {
+		in, out := &in.Privileged, &out.Privileged
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Go.
@@ -283,7 +318,7 @@ func (in *Ingress) DeepCopy() *Ingress {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Instrumentation) DeepCopyInto(out *Instrumentation) {
 	*out = *in
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	out.TypeMeta = in.TypeMeta
 	in.Spec.DeepCopyInto(&out.Spec)
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -307,6 +342,25 @@ func (in *Instrumentation) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstrumentationLanguageStatus) DeepCopyInto(out *InstrumentationLanguageStatus) {
+	*out = *in
+	if in.LastInjectionTime != nil {
+		in, out := &in.LastInjectionTime, &out.LastInjectionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationLanguageStatus.
+func (in *InstrumentationLanguageStatus) DeepCopy() *InstrumentationLanguageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstrumentationLanguageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstrumentationList) DeepCopyInto(out *InstrumentationList) {
 	*out = *in
@@ -365,6 +419,18 @@ func (in *InstrumentationSpec) DeepCopyInto(out *InstrumentationSpec) {
 	in.Go.DeepCopyInto(&out.Go)
 	in.ApacheHttpd.DeepCopyInto(&out.ApacheHttpd)
 	in.Nginx.DeepCopyInto(&out.Nginx)
+	in.PHP.DeepCopyInto(&out.PHP)
+	in.Ruby.DeepCopyInto(&out.Ruby)
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectSelector != nil {
+		in, out := &in.ObjectSelector, &out.ObjectSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationSpec.
@@ -380,6 +446,20 @@ func (in *InstrumentationSpec) DeepCopy() *InstrumentationSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstrumentationStatus) DeepCopyInto(out *InstrumentationStatus) {
 	*out = *in
+	if in.Languages != nil {
+		in, out := &in.Languages, &out.Languages
+		*out = make([]InstrumentationLanguageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstrumentationStatus.
@@ -409,11 +489,21 @@ func (in *Java) DeepCopyInto(out *Java) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Extensions != nil {
 		in, out := &in.Extensions, &out.Extensions
 		*out = make([]Extensions, len(*in))
 		copy(*out, *in)
 	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Java.
@@ -485,6 +575,11 @@ func (in *Nginx) DeepCopyInto(out *Nginx) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Nginx.
@@ -514,6 +609,16 @@ func (in *NodeJS) DeepCopyInto(out *NodeJS) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeJS.
@@ -569,6 +674,26 @@ func (in *OpAMPBridge) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpAMPBridgeCertificate) DeepCopyInto(out *OpAMPBridgeCertificate) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpAMPBridgeCertificate.
+func (in *OpAMPBridgeCertificate) DeepCopy() *OpAMPBridgeCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(OpAMPBridgeCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpAMPBridgeList) DeepCopyInto(out *OpAMPBridgeList) {
 	*out = *in
@@ -611,6 +736,21 @@ func (in *OpAMPBridgeSpec) DeepCopyInto(out *OpAMPBridgeSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(OpAMPBridgeTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PollingInterval != nil {
+		in, out := &in.PollingInterval, &out.PollingInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.HeartbeatInterval != nil {
+		in, out := &in.HeartbeatInterval, &out.HeartbeatInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Capabilities != nil {
 		in, out := &in.Capabilities, &out.Capabilities
 		*out = make(map[OpAMPBridgeCapability]bool, len(*in))
@@ -634,6 +774,11 @@ func (in *OpAMPBridgeSpec) DeepCopyInto(out *OpAMPBridgeSpec) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.ManagedKinds != nil {
+		in, out := &in.ManagedKinds, &out.ManagedKinds
+		*out = make([]OpAMPBridgeManagedKind, len(*in))
+		copy(*out, *in)
+	}
 	if in.Description != nil {
 		in, out := &in.Description, &out.Description
 		*out = new(AgentDescription)
@@ -761,6 +906,26 @@ func (in *OpAMPBridgeStatus) DeepCopy() *OpAMPBridgeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpAMPBridgeTLS) DeepCopyInto(out *OpAMPBridgeTLS) {
+	*out = *in
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(OpAMPBridgeCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpAMPBridgeTLS.
+func (in *OpAMPBridgeTLS) DeepCopy() *OpAMPBridgeTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(OpAMPBridgeTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenShiftRoute) DeepCopyInto(out *OpenShiftRoute) {
 	*out = *in
@@ -1133,6 +1298,40 @@ func (in *OpenTelemetryTargetAllocatorPrometheusCR) DeepCopy() *OpenTelemetryTar
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PHP) DeepCopyInto(out *PHP) {
+	*out = *in
+	in.VolumeClaimTemplate.DeepCopyInto(&out.VolumeClaimTemplate)
+	if in.VolumeSizeLimit != nil {
+		in, out := &in.VolumeSizeLimit, &out.VolumeSizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PHP.
+func (in *PHP) DeepCopy() *PHP {
+	if in == nil {
+		return nil
+	}
+	out := new(PHP)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
 	*out = *in
@@ -1236,6 +1435,11 @@ func (in *Python) DeepCopyInto(out *Python) {
 		}
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Python.
@@ -1258,6 +1462,20 @@ func (in *Resource) DeepCopyInto(out *Resource) {
 			(*out)[key] = val
 		}
 	}
+	if in.LabelsToAttributes != nil {
+		in, out := &in.LabelsToAttributes, &out.LabelsToAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AnnotationsToAttributes != nil {
+		in, out := &in.AnnotationsToAttributes, &out.AnnotationsToAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resource.
@@ -1270,6 +1488,40 @@ func (in *Resource) DeepCopy() *Resource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Ruby) DeepCopyInto(out *Ruby) {
+	*out = *in
+	in.VolumeClaimTemplate.DeepCopyInto(&out.VolumeClaimTemplate)
+	if in.VolumeSizeLimit != nil {
+		in, out := &in.VolumeSizeLimit, &out.VolumeSizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Ruby.
+func (in *Ruby) DeepCopy() *Ruby {
+	if in == nil {
+		return nil
+	}
+	out := new(Ruby)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Sampler) DeepCopyInto(out *Sampler) {
 	*out = *in
@@ -1303,6 +1555,11 @@ func (in *ScaleSubresourceStatus) DeepCopy() *ScaleSubresourceStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLS) DeepCopyInto(out *TLS) {
 	*out = *in
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(ExporterCertificate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLS.
@@ -1387,12 +1644,17 @@ func (in *TargetAllocatorSpec) DeepCopyInto(out *TargetAllocatorSpec) {
 		}
 	}
 	in.PrometheusCR.DeepCopyInto(&out.PrometheusCR)
-	out.Observability = in.Observability
+	in.Observability.DeepCopyInto(&out.Observability)
 	if in.CollectorNotReadyGracePeriod != nil {
 		in, out := &in.CollectorNotReadyGracePeriod, &out.CollectorNotReadyGracePeriod
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.CollectorDrainTimeout != nil {
+		in, out := &in.CollectorDrainTimeout, &out.CollectorDrainTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetAllocatorSpec.
@@ -1408,6 +1670,7 @@ func (in *TargetAllocatorSpec) DeepCopy() *TargetAllocatorSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetAllocatorStatus) DeepCopyInto(out *TargetAllocatorStatus) {
 	*out = *in
+	out.Scale = in.Scale
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetAllocatorStatus.
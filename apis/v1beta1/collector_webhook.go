@@ -6,13 +6,21 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/go-logr/logr"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -35,12 +43,13 @@ var (
 
 type CollectorWebhook struct {
 	logger   logr.Logger
-	cfg      config.Config
+	cfg      *config.Provider
 	scheme   *runtime.Scheme
 	reviewer *rbac.Reviewer
 	metrics  *Metrics
 	bv       BuildValidator
 	fips     fips.FIPSCheck
+	reader   client.Reader
 }
 
 func (c CollectorWebhook) Default(_ context.Context, obj runtime.Object) error {
@@ -48,6 +57,10 @@ func (c CollectorWebhook) Default(_ context.Context, obj runtime.Object) error {
 	if !ok {
 		return fmt.Errorf("expected an OpenTelemetryCollector, received %T", obj)
 	}
+	// the collectors of the other operators are left to their webhooks
+	if !c.cfg.Config().ManagesResource(otelcol) {
+		return nil
+	}
 	if len(otelcol.Spec.Mode) == 0 {
 		otelcol.Spec.Mode = ModeDeployment
 	}
@@ -74,7 +87,9 @@ func (c CollectorWebhook) Default(_ context.Context, obj runtime.Object) error {
 			otelcol.Spec.Autoscaler.MinReplicas = otelcol.Spec.Replicas
 		}
 
-		if otelcol.Spec.Autoscaler.TargetMemoryUtilization == nil && otelcol.Spec.Autoscaler.TargetCPUUtilization == nil {
+		// with KEDA, the triggers are the scaling signals and no resource utilization target is implied
+		if otelcol.Spec.Autoscaler.TargetMemoryUtilization == nil && otelcol.Spec.Autoscaler.TargetCPUUtilization == nil &&
+			len(otelcol.Spec.Autoscaler.KedaTriggers) == 0 {
 			defaultCPUTarget := int32(90)
 			otelcol.Spec.Autoscaler.TargetCPUUtilization = &defaultCPUTarget
 		}
@@ -94,7 +109,8 @@ func (c CollectorWebhook) Default(_ context.Context, obj runtime.Object) error {
 	if !featuregate.EnableConfigDefaulting.IsEnabled() {
 		return nil
 	}
-	return otelcol.Spec.Config.ApplyDefaults(c.logger)
+	otelcol.Spec.Config.ApplyResourceDetectionDefaults(c.cfg.Config().Platform.ResourceDetectors())
+	return otelcol.Spec.Config.ApplyDefaultsForIPFamily(c.logger, otelcol.Spec.ListenIPFamily(c.cfg.Config().IPFamilies))
 }
 
 func (c CollectorWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -102,11 +118,21 @@ func (c CollectorWebhook) ValidateCreate(ctx context.Context, obj runtime.Object
 	if !ok {
 		return nil, fmt.Errorf("expected an OpenTelemetryCollector, received %T", obj)
 	}
+	if !c.cfg.Config().ManagesResource(otelcol) {
+		return nil, nil
+	}
 
 	warnings, err := c.Validate(ctx, otelcol)
 	if err != nil {
 		return warnings, err
 	}
+	if err := c.validateFIPSImages(otelcol); err != nil {
+		return warnings, err
+	}
+	if err := c.validateConfigEnvFrom(ctx, otelcol); err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, AdviceWarnings(otelcol)...)
 	if c.metrics != nil {
 		c.metrics.create(ctx, otelcol)
 	}
@@ -127,6 +153,9 @@ func (c CollectorWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj run
 	if !ok {
 		return nil, fmt.Errorf("expected an OpenTelemetryCollector, received %T", oldObj)
 	}
+	if !c.cfg.Config().ManagesResource(otelcol) {
+		return nil, nil
+	}
 
 	if otelcolOld.Spec.Mode != otelcol.Spec.Mode {
 		return admission.Warnings{}, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support modification", otelcolOld.Spec.Mode)
@@ -135,6 +164,13 @@ func (c CollectorWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj run
 	if err != nil {
 		return warnings, err
 	}
+	if err := c.validateFIPSImages(otelcol); err != nil {
+		return warnings, err
+	}
+	if err := c.validateConfigEnvFrom(ctx, otelcol); err != nil {
+		return warnings, err
+	}
+	warnings = append(warnings, AdviceWarnings(otelcol)...)
 
 	if c.metrics != nil {
 		c.metrics.update(ctx, otelcolOld, otelcol)
@@ -152,6 +188,9 @@ func (c CollectorWebhook) ValidateDelete(ctx context.Context, obj runtime.Object
 	if !ok || otelcol == nil {
 		return nil, fmt.Errorf("expected an OpenTelemetryCollector, received %T", obj)
 	}
+	if !c.cfg.Config().ManagesResource(otelcol) {
+		return nil, nil
+	}
 
 	warnings, err := c.Validate(ctx, otelcol)
 	if err != nil {
@@ -165,13 +204,21 @@ func (c CollectorWebhook) ValidateDelete(ctx context.Context, obj runtime.Object
 	return warnings, nil
 }
 
+// validateFIPSImages rejects the images not built for FIPS when the operator.fips.enforce feature gate is enabled. It
+// only applies to the collectors created or updated, so that the existing ones can still be deleted.
+func (c CollectorWebhook) validateFIPSImages(r *OpenTelemetryCollector) error {
+	if !featuregate.EnforceFIPS.IsEnabled() {
+		return nil
+	}
+	if images := NonFIPSImages(r, c.cfg.Config()); len(images) > 0 {
+		return fmt.Errorf("the images %s aren't built for FIPS, which the %s feature gate requires", strings.Join(images, ", "), featuregate.EnforceFIPS.ID())
+	}
+	return nil
+}
+
 func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollector) (admission.Warnings, error) {
 	warnings := admission.Warnings{}
-
-	nullObjects := r.Spec.Config.nullObjects()
-	if len(nullObjects) > 0 {
-		warnings = append(warnings, fmt.Sprintf("Collector config spec.config has null objects: %s. For compatibility with other tooling, such as kustomize and kubectl edit, it is recommended to use empty objects e.g. batch: {}.", strings.Join(nullObjects, ", ")))
-	}
+	warnings = append(warnings, nullObjectsWarnings(r)...)
 
 	// validate volumeClaimTemplates
 	if r.Spec.Mode != ModeStatefulSet && len(r.Spec.VolumeClaimTemplates) > 0 {
@@ -183,6 +230,41 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'persistentVolumeClaimRetentionPolicy'", r.Spec.Mode)
 	}
 
+	// validate networkPolicy
+	if r.Spec.Mode == ModeSidecar && r.Spec.NetworkPolicy.Enabled {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'networkPolicy'", r.Spec.Mode)
+	}
+
+	// validate enrichment
+	if r.Spec.Mode == ModeSidecar && r.Spec.Enrichment.Enabled {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'enrichment'", r.Spec.Mode)
+	}
+
+	// validate presets
+	if r.Spec.Mode != ModeDaemonSet && r.Spec.Presets.Enabled() {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'presets'", r.Spec.Mode)
+	}
+	warnings = append(warnings, presetWarnings(r)...)
+
+	// validate services
+	if err := validateServices(r); err != nil {
+		return warnings, err
+	}
+
+	// validate topologyAwareRouting
+	routing := r.Spec.TopologyAwareRouting
+	if r.Spec.Mode == ModeSidecar && (routing.Enabled || routing.InternalTrafficPolicy != "") {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'topologyAwareRouting'", r.Spec.Mode)
+	}
+	if r.Spec.Mode != ModeDaemonSet && routing.InternalTrafficPolicy == v1.ServiceInternalTrafficPolicyLocal {
+		warnings = append(warnings, fmt.Sprintf("the internal traffic policy %s only routes the clients to the collector pods of their node, which the %s mode doesn't run on every node", routing.InternalTrafficPolicy, r.Spec.Mode))
+	}
+
+	// validate persistence
+	if r.Spec.Mode != ModeStatefulSet && r.Spec.Persistence != nil {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'persistence'", r.Spec.Mode)
+	}
+
 	// validate tolerations
 	// NOTE: this validation is also implemented in CRDs using CEL (Common Expression Language)
 	if r.Spec.Mode == ModeSidecar && len(r.Spec.Tolerations) > 0 {
@@ -205,6 +287,13 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 	if r.Spec.Mode == ModeSidecar && len(r.Spec.AdditionalContainers) > 0 {
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'AdditionalContainers'", r.Spec.Mode)
 	}
+	if r.Spec.Mode == ModeSidecar && r.Spec.AdditionalContainersPosition != "" {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'additionalContainersPosition'", r.Spec.Mode)
+	}
+
+	// the init and additional containers mounting unknown volumes only fail to start, existing collectors must stay
+	// updatable
+	warnings = append(warnings, containerVolumeMountsWarnings(r)...)
 
 	// validate target allocator configs
 	if r.Spec.TargetAllocator.Enabled {
@@ -222,6 +311,29 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 		return warnings, err
 	}
 
+	// validate the pod disruption budgets, only created for the deployments and the statefulsets
+	if err := ValidatePodDisruptionBudget("podDisruptionBudget", r.Spec.PodDisruptionBudget); err != nil {
+		return warnings, err
+	}
+	if err := ValidatePodDisruptionBudget("targetAllocator.podDisruptionBudget", r.Spec.TargetAllocator.PodDisruptionBudget); err != nil {
+		return warnings, err
+	}
+	if r.Spec.PodDisruptionBudget != nil && r.Spec.Mode != ModeDeployment && r.Spec.Mode != ModeStatefulSet {
+		warnings = append(warnings, fmt.Sprintf("spec.podDisruptionBudget is ignored in the %s mode, the pod disruption budgets are only created for the %s and %s modes", r.Spec.Mode, ModeDeployment, ModeStatefulSet))
+	}
+
+	// validate the jobs, before the autoscaler validation which returns early
+	jobWarnings, err := validateJob(r)
+	warnings = append(warnings, jobWarnings...)
+	if err != nil {
+		return warnings, err
+	}
+
+	// validate the operating systems of the nodes
+	if err := validateNodeOS(r); err != nil {
+		return warnings, err
+	}
+
 	var maxReplicas *int32
 	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.MaxReplicas != nil {
 		maxReplicas = r.Spec.Autoscaler.MaxReplicas
@@ -235,6 +347,22 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 		minReplicas = r.Spec.Replicas
 	}
 
+	if r.Spec.Autoscaler != nil && len(r.Spec.Autoscaler.KedaTriggers) > 0 && maxReplicas == nil {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, maxReplicas should be defined when kedaTriggers are used")
+	}
+
+	if r.Spec.Autoscaler != nil && r.Spec.Autoscaler.VPA != nil {
+		if r.Spec.Mode != ModeDeployment && r.Spec.Mode != ModeDaemonSet {
+			return warnings, fmt.Errorf("the OpenTelemetry Spec autoscale configuration is incorrect, vpa can only be used in combination with the modes: %s, %s",
+				ModeDeployment, ModeDaemonSet,
+			)
+		}
+		if r.Spec.Autoscaler.VPA.UpdateMode == VPAUpdateModeAuto && maxReplicas != nil &&
+			(r.Spec.Autoscaler.TargetCPUUtilization != nil || r.Spec.Autoscaler.TargetMemoryUtilization != nil) {
+			warnings = append(warnings, "the vpa in the Auto update mode and the horizontal autoscaling on the CPU or memory utilization both react to the same signals and may conflict, consider scaling horizontally on other metrics")
+		}
+	}
+
 	// validate autoscale with horizontal pod autoscaler
 	if maxReplicas != nil {
 		if *maxReplicas < int32(1) {
@@ -269,12 +397,25 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 			ModeDeployment, ModeDaemonSet, ModeStatefulSet,
 		)
 	}
+	if r.Spec.Ingress.Type == IngressTypeGateway && r.Spec.Mode == ModeSidecar {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. Gateway routes can only be used in combination with the modes: %s, %s, %s",
+			ModeDeployment, ModeDaemonSet, ModeStatefulSet,
+		)
+	}
+	if r.Spec.Ingress.Type == IngressTypeGateway && len(r.Spec.Ingress.Gateway.ParentRefs) == 0 {
+		return warnings, fmt.Errorf("the OpenTelemetry Spec Ingress configuration is incorrect. At least one Gateway parentRef has to be defined for the %s type", IngressTypeGateway)
+	}
 	if r.Spec.Ingress.RuleType == IngressRuleTypeSubdomain && (r.Spec.Ingress.Hostname == "" || r.Spec.Ingress.Hostname == "*") {
 		return warnings, fmt.Errorf("a valid Ingress hostname has to be defined for subdomain ruleType")
 	}
+	if r.Spec.Ingress.Type == IngressTypeRoute {
+		if err := validateRoutePorts(r.Spec.Ingress.Route); err != nil {
+			return warnings, err
+		}
+	}
 
 	// validate probes Liveness/Readiness
-	err := ValidateProbe("LivenessProbe", r.Spec.LivenessProbe)
+	err = ValidateProbe("LivenessProbe", r.Spec.LivenessProbe)
 	if err != nil {
 		return warnings, err
 	}
@@ -282,6 +423,12 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 	if err != nil {
 		return warnings, err
 	}
+	if err = c.validateProbeHandler("livenessProbe", r.Spec.LivenessProbe, r); err != nil {
+		return warnings, err
+	}
+	if err = c.validateProbeHandler("readinessProbe", r.Spec.ReadinessProbe, r); err != nil {
+		return warnings, err
+	}
 
 	// validate updateStrategy for DaemonSet
 	if r.Spec.Mode != ModeDaemonSet && len(r.Spec.DaemonSetUpdateStrategy.Type) > 0 {
@@ -293,6 +440,29 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'deploymentUpdateStrategy'", r.Spec.Mode)
 	}
 
+	// validate the canary rollout, which runs a canary Deployment
+	if r.Spec.Mode != ModeDeployment && r.Spec.Rollout != nil && r.Spec.Rollout.Canary != nil {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'rollout.canary'", r.Spec.Mode)
+	}
+
+	// validate the partitioned rollout, which manages the partition of the collector StatefulSet
+	if r.Spec.Mode != ModeStatefulSet && r.Spec.Rollout != nil && r.Spec.Rollout.Partitioned != nil {
+		return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'rollout.partitioned'", r.Spec.Mode)
+	}
+
+	// validate the reload strategy, which keeps the pods running
+	if r.Spec.Rollout != nil && r.Spec.Rollout.Strategy == RolloutStrategyReload {
+		if r.Spec.Mode == ModeSidecar {
+			return warnings, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the rollout strategy %s", r.Spec.Mode, RolloutStrategyReload)
+		}
+		if r.Spec.Rollout.Canary != nil {
+			return warnings, fmt.Errorf("the rollout strategy %s does not support canary rollouts", RolloutStrategyReload)
+		}
+		if r.Spec.Rollout.Partitioned != nil {
+			return warnings, fmt.Errorf("the rollout strategy %s does not support partitioned rollouts", RolloutStrategyReload)
+		}
+	}
+
 	if c.fips != nil {
 		components := r.Spec.Config.GetEnabledComponents()
 		if notAllowedComponents := c.fips.DisabledComponents(components[KindReceiver], components[KindExporter], components[KindProcessor], components[KindExtension]); notAllowedComponents != nil {
@@ -300,9 +470,193 @@ func (c CollectorWebhook) Validate(ctx context.Context, r *OpenTelemetryCollecto
 		}
 	}
 
+	// the config sources are only merged by the reconciler, which validates the merged config instead
+	if featuregate.EnableStructuralConfigValidation.IsEnabled() && len(r.Spec.ConfigSources) == 0 {
+		if err := r.Spec.Config.Validate(); err != nil {
+			return warnings, fmt.Errorf("the collector configuration is invalid: %w", err)
+		}
+	}
+
 	return warnings, nil
 }
 
+// validateJob checks the attributes of the job and cronjob modes.
+func validateJob(r *OpenTelemetryCollector) (admission.Warnings, error) {
+	isJob := r.Spec.Mode == ModeJob || r.Spec.Mode == ModeCronJob
+	if !isJob && r.Spec.Job != nil {
+		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'job'", r.Spec.Mode)
+	}
+	if r.Spec.Mode != ModeCronJob && r.Spec.CronJob != nil {
+		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'cronJob'", r.Spec.Mode)
+	}
+	if !isJob {
+		return nil, nil
+	}
+
+	if r.Spec.Mode == ModeCronJob && r.Spec.CronJob == nil {
+		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which requires the attribute 'cronJob'", r.Spec.Mode)
+	}
+	// the operator would create the job again once deleted
+	if r.Spec.Mode == ModeJob && r.Spec.Job != nil && r.Spec.Job.TTLSecondsAfterFinished != nil {
+		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'job.ttlSecondsAfterFinished'", r.Spec.Mode)
+	}
+	if r.Spec.Autoscaler != nil && (r.Spec.Autoscaler.MaxReplicas != nil || r.Spec.Autoscaler.VPA != nil) {
+		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'autoscaler'", r.Spec.Mode)
+	}
+	if r.Spec.Rollout != nil && r.Spec.Rollout.Strategy == RolloutStrategyReload {
+		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the rollout strategy %s", r.Spec.Mode, RolloutStrategyReload)
+	}
+
+	if r.Spec.Job == nil || r.Spec.Job.Duration == nil {
+		return admission.Warnings{"the collectors of the jobs run until they stop on their own or the jobs are terminated, set 'job.duration' to stop them gracefully"}, nil
+	}
+	if r.Spec.Job.Duration.Seconds() < 1 {
+		return nil, fmt.Errorf("the attribute 'job.duration' must be at least one second")
+	}
+	return nil, nil
+}
+
+// ConfigWarnings returns the warnings about the configuration of the collector, returned by the webhook on admission
+// and recorded as events on the collector by its reconciliation.
+func ConfigWarnings(r *OpenTelemetryCollector) admission.Warnings {
+	return append(nullObjectsWarnings(r), presetWarnings(r)...)
+}
+
+// nullObjectsWarnings warns about the null objects of the configuration.
+func nullObjectsWarnings(r *OpenTelemetryCollector) admission.Warnings {
+	nullObjects := r.Spec.Config.nullObjects()
+	if len(nullObjects) == 0 {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("Collector config spec.config has null objects: %s. For compatibility with other tooling, such as kustomize and kubectl edit, it is recommended to use empty objects e.g. batch: {}.", strings.Join(nullObjects, ", "))}
+}
+
+// presetWarnings warns about the enabled presets without a pipeline of their signal to add their receiver to.
+func presetWarnings(r *OpenTelemetryCollector) admission.Warnings {
+	var warnings admission.Warnings
+	hasPipeline := func(signal string) bool {
+		for name := range r.Spec.Config.Service.Pipelines {
+			if pipelineSignal, _, _ := strings.Cut(name, "/"); pipelineSignal == signal {
+				return true
+			}
+		}
+		return false
+	}
+	for _, preset := range []struct {
+		name    string
+		signal  string
+		enabled bool
+	}{
+		{"hostMetrics", "metrics", r.Spec.Presets.HostMetrics.Enabled},
+		{"kubeletMetrics", "metrics", r.Spec.Presets.KubeletMetrics.Enabled},
+		{"logsCollection", "logs", r.Spec.Presets.LogsCollection.Enabled},
+	} {
+		if preset.enabled && !hasPipeline(preset.signal) {
+			warnings = append(warnings, fmt.Sprintf("the preset '%s' has no %s pipeline to add its receiver to", preset.name, preset.signal))
+		}
+	}
+	return warnings
+}
+
+// SkipConfigEnvValidationAnnotation disables the check of the environment variables referenced by the config of a
+// collector when set to "true", e.g. for the collectors created before the Secrets and ConfigMaps of their variables.
+const SkipConfigEnvValidationAnnotation = "opentelemetry.io/skip-config-env-validation"
+
+// validateConfigEnvFrom checks that the environment variables the config references are set, when the collector lists
+// the sources of the variables of its config. It only applies to the collectors created or updated, so that the
+// existing ones can still be deleted.
+func (c CollectorWebhook) validateConfigEnvFrom(ctx context.Context, r *OpenTelemetryCollector) error {
+	if len(r.Spec.ConfigEnvFrom) == 0 || c.reader == nil || r.Annotations[SkipConfigEnvValidationAnnotation] == "true" {
+		return nil
+	}
+	references, err := r.Spec.Config.EnvVarReferences()
+	if err != nil {
+		return fmt.Errorf("failed to find the environment variables of the collector configuration: %w", err)
+	}
+	if len(references) == 0 {
+		return nil
+	}
+	defined, err := c.definedEnvVars(ctx, r)
+	if err != nil {
+		return err
+	}
+	var undefined []string
+	for _, name := range references {
+		if !defined[name] {
+			undefined = append(undefined, name)
+		}
+	}
+	if len(undefined) > 0 {
+		return fmt.Errorf("the collector configuration references the environment variables %s, which aren't set by 'configEnvFrom', 'envFrom', 'env' or the operator", strings.Join(undefined, ", "))
+	}
+	return nil
+}
+
+// definedEnvVars returns the environment variables of the collector container, the ones it sets and the keys of its
+// envFrom and configEnvFrom sources.
+func (c CollectorWebhook) definedEnvVars(ctx context.Context, r *OpenTelemetryCollector) (map[string]bool, error) {
+	defined := map[string]bool{}
+	for _, env := range r.ContainerEnvVars(c.logger) {
+		defined[env.Name] = true
+	}
+
+	for _, source := range append(slices.Clone(r.Spec.EnvFrom), r.Spec.ConfigEnvFrom...) {
+		var keys []string
+		switch {
+		case source.ConfigMapRef != nil:
+			configMap := &v1.ConfigMap{}
+			err := c.reader.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: source.ConfigMapRef.Name}, configMap)
+			if apierrors.IsNotFound(err) && ptr.Deref(source.ConfigMapRef.Optional, false) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the ConfigMap %s of the environment variables: %w", source.ConfigMapRef.Name, err)
+			}
+			keys = append(slices.Collect(maps.Keys(configMap.Data)), slices.Collect(maps.Keys(configMap.BinaryData))...)
+		case source.SecretRef != nil:
+			secret := &v1.Secret{}
+			err := c.reader.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: source.SecretRef.Name}, secret)
+			if apierrors.IsNotFound(err) && ptr.Deref(source.SecretRef.Optional, false) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the Secret %s of the environment variables: %w", source.SecretRef.Name, err)
+			}
+			keys = slices.Collect(maps.Keys(secret.Data))
+		}
+		for _, key := range keys {
+			defined[source.Prefix+key] = true
+		}
+	}
+	return defined, nil
+}
+
+// validateNodeOS checks the attributes of the collector on the Windows nodes.
+func validateNodeOS(r *OpenTelemetryCollector) error {
+	if r.Spec.Mode != ModeDaemonSet && len(r.Spec.NodeOS) > 0 {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'nodeOS'", r.Spec.Mode)
+	}
+	if !r.Spec.RunsOn(NodeOSWindows) {
+		if r.Spec.Windows != nil {
+			return fmt.Errorf("the attribute 'windows' requires %s in the attribute 'nodeOS'", NodeOSWindows)
+		}
+		return nil
+	}
+	// the hostmetrics receiver only reads the file system of the Linux nodes from another root path
+	if r.Spec.Presets.HostMetrics.Enabled {
+		return fmt.Errorf("the node OS %s does not support the preset 'hostMetrics'", NodeOSWindows)
+	}
+	// the config reloader is a shell script signaling the collector through the shared process namespace
+	if r.Spec.ReloadsConfig() {
+		return fmt.Errorf("the node OS %s does not support the rollout strategy %s", NodeOSWindows, RolloutStrategyReload)
+	}
+	if r.Spec.Windows != nil && r.Spec.Windows.SecurityContext != nil &&
+		ptr.Deref(r.Spec.Windows.SecurityContext.HostProcess, false) && !r.Spec.HostNetwork {
+		return fmt.Errorf("the attribute 'windows.securityContext.hostProcess' requires the attribute 'hostNetwork'")
+	}
+	return nil
+}
+
 func (c CollectorWebhook) validateTargetAllocatorConfig(ctx context.Context, r *OpenTelemetryCollector) (admission.Warnings, error) {
 	if r.Spec.Mode != ModeStatefulSet && r.Spec.Mode != ModeDaemonSet {
 		return nil, fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the target allocation deployment", r.Spec.Mode)
@@ -373,6 +727,23 @@ func ValidateProbe(probeName string, probe *Probe) error {
 	return nil
 }
 
+// validateProbeHandler checks the receiverPort handler of the given probe finds a receiver port to check.
+func (c CollectorWebhook) validateProbeHandler(probeName string, probe *Probe, r *OpenTelemetryCollector) error {
+	if probe == nil {
+		return nil
+	}
+	if probe.Handler != ProbeHandlerReceiverPort {
+		if probe.Receiver != "" {
+			return fmt.Errorf("the attribute 'receiver' of the %s requires the handler %s", probeName, ProbeHandlerReceiverPort)
+		}
+		return nil
+	}
+	if _, err := r.Spec.Config.GetReceiverProbePort(c.logger, probe.Receiver); err != nil {
+		return fmt.Errorf("the %s can't check a receiver port: %w", probeName, err)
+	}
+	return nil
+}
+
 func ValidatePorts(ports []PortsSpec) error {
 	for _, p := range ports {
 		nameErrs := validation.IsValidPortName(p.Name)
@@ -385,6 +756,35 @@ func ValidatePorts(ports []PortsSpec) error {
 	return nil
 }
 
+// ValidatePodDisruptionBudget checks that the pod disruption budget sets either minAvailable or maxUnavailable, as a
+// non-negative number of pods or a percentage, which the API server would otherwise only reject when the operator
+// creates it.
+func ValidatePodDisruptionBudget(field string, pdb *PodDisruptionBudgetSpec) error {
+	if pdb == nil {
+		return nil
+	}
+	if pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		return fmt.Errorf("the OpenTelemetry Spec %s configuration is incorrect, minAvailable and maxUnavailable are mutually exclusive", field)
+	}
+	name, value := "minAvailable", pdb.MinAvailable
+	if value == nil {
+		name, value = "maxUnavailable", pdb.MaxUnavailable
+	}
+	if value == nil {
+		return nil
+	}
+	if value.Type == intstr.Int && value.IntVal < 0 {
+		return fmt.Errorf("the OpenTelemetry Spec %s configuration is incorrect, %s should be greater than or equal to 0", field, name)
+	}
+	if value.Type == intstr.String {
+		percent, err := strconv.Atoi(strings.TrimSuffix(value.StrVal, "%"))
+		if err != nil || !strings.HasSuffix(value.StrVal, "%") || percent < 0 || percent > 100 {
+			return fmt.Errorf("the OpenTelemetry Spec %s configuration is incorrect, %s should be a number of pods or a percentage between 0%% and 100%%, got %q", field, name, value.StrVal)
+		}
+	}
+	return nil
+}
+
 func checkAutoscalerSpec(autoscaler *AutoscalerSpec) error {
 	if autoscaler.Behavior != nil {
 		if autoscaler.Behavior.ScaleDown != nil && autoscaler.Behavior.ScaleDown.StabilizationWindowSeconds != nil &&
@@ -433,11 +833,12 @@ type BuildValidator func(ctx context.Context, c OpenTelemetryCollector) admissio
 func NewCollectorWebhook(
 	logger logr.Logger,
 	scheme *runtime.Scheme,
-	cfg config.Config,
+	cfg *config.Provider,
 	reviewer *rbac.Reviewer,
 	metrics *Metrics,
 	bv BuildValidator,
 	fips fips.FIPSCheck,
+	reader client.Reader,
 ) *CollectorWebhook {
 	return &CollectorWebhook{
 		logger:   logger,
@@ -447,14 +848,99 @@ func NewCollectorWebhook(
 		metrics:  metrics,
 		bv:       bv,
 		fips:     fips,
+		reader:   reader,
 	}
 }
 
-func SetupCollectorWebhook(mgr ctrl.Manager, cfg config.Config, reviewer *rbac.Reviewer, metrics *Metrics, bv BuildValidator, fipsCheck fips.FIPSCheck) error {
-	cvw := NewCollectorWebhook(mgr.GetLogger().WithValues("handler", "CollectorWebhook", "version", "v1beta1"), mgr.GetScheme(), cfg, reviewer, metrics, bv, fipsCheck)
+func SetupCollectorWebhook(mgr ctrl.Manager, cfg *config.Provider, reviewer *rbac.Reviewer, metrics *Metrics, bv BuildValidator, fipsCheck fips.FIPSCheck) error {
+	cvw := NewCollectorWebhook(mgr.GetLogger().WithValues("handler", "CollectorWebhook", "version", "v1beta1"), mgr.GetScheme(), cfg, reviewer, metrics, bv, fipsCheck, mgr.GetAPIReader())
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&OpenTelemetryCollector{}).
 		WithValidator(cvw).
 		WithDefaulter(cvw).
 		Complete()
 }
+
+// validateRoutePorts checks the certificates of the routes of the ports are only set for the terminations using them.
+func validateRoutePorts(route OpenShiftRoute) error {
+	for _, port := range route.Ports {
+		termination := port.Termination
+		if termination == "" {
+			termination = route.Termination
+		}
+		if termination == "" {
+			termination = TLSRouteTerminationTypeEdge
+		}
+		if port.CertificateSecret != "" && termination != TLSRouteTerminationTypeEdge && termination != TLSRouteTerminationTypeReencrypt {
+			return fmt.Errorf("the route of the port %s has a certificate, which is not supported by the %s termination", port.Name, termination)
+		}
+		if port.DestinationCACertificate != "" && termination != TLSRouteTerminationTypeReencrypt {
+			return fmt.Errorf("the route of the port %s has a destination CA certificate, which is only supported by the %s termination", port.Name, TLSRouteTerminationTypeReencrypt)
+		}
+	}
+	return nil
+}
+
+// containerVolumeMountsWarnings warns about the init and additional containers mounting other volumes than the ones of
+// the pod, the ones of the spec or the ones added by the operator.
+func containerVolumeMountsWarnings(r *OpenTelemetryCollector) admission.Warnings {
+	if r.Spec.Mode == ModeSidecar {
+		return nil
+	}
+	volumes := map[string]bool{naming.ConfigMapVolume(): true}
+	for _, volume := range r.Spec.Volumes {
+		volumes[volume.Name] = true
+	}
+	for _, cm := range r.Spec.ConfigMaps {
+		volumes[naming.ConfigMapExtra(cm.Name)] = true
+	}
+	if r.Spec.Mode == ModeStatefulSet {
+		for _, claim := range r.Spec.VolumeClaimTemplates {
+			volumes[claim.Name] = true
+		}
+		if r.Spec.Persistence != nil {
+			volumes[naming.PersistenceVolume()] = true
+		}
+	}
+	if r.Spec.TargetAllocator.Enabled {
+		volumes[naming.TAClientCertificate(r.Name)] = true
+	}
+
+	var warnings admission.Warnings
+	for _, containers := range [][]v1.Container{r.Spec.InitContainers, r.Spec.AdditionalContainers} {
+		for _, container := range containers {
+			for _, mount := range container.VolumeMounts {
+				if !volumes[mount.Name] {
+					warnings = append(warnings, fmt.Sprintf("the container %s mounts the volume %s, which isn't a volume of the collector pods, they won't start", container.Name, mount.Name))
+				}
+			}
+		}
+	}
+	return warnings
+}
+
+// validateServices checks the additional services expose some ports and don't clash with the services created by the
+// operator.
+func validateServices(r *OpenTelemetryCollector) error {
+	if len(r.Spec.Services) == 0 {
+		return nil
+	}
+	if r.Spec.Mode == ModeSidecar {
+		return fmt.Errorf("the OpenTelemetry Collector mode is set to %s, which does not support the attribute 'services'", r.Spec.Mode)
+	}
+	for _, service := range r.Spec.Services {
+		switch service.Name {
+		case "headless", "monitoring", "extension", "otlp-headless":
+			return fmt.Errorf("the name of the service %s is reserved for the services created by the operator", service.Name)
+		}
+		if len(service.Receivers) == 0 && len(service.Ports) == 0 {
+			return fmt.Errorf("the service %s must expose some receivers or ports", service.Name)
+		}
+		for _, receiver := range service.Receivers {
+			if _, ok := r.Spec.Config.Receivers.Object[receiver]; !ok {
+				return fmt.Errorf("the service %s exposes the receiver %s, which isn't configured", service.Name, receiver)
+			}
+		}
+	}
+	return nil
+}
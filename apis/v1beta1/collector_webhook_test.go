@@ -9,30 +9,37 @@ import (
 	"math"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	go_yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	colfg "go.opentelemetry.io/collector/featuregate"
 	appsv1 "k8s.io/api/apps/v1"
 	authv1 "k8s.io/api/authorization/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	kubeTesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	collectorManifests "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 var (
@@ -97,14 +104,15 @@ func TestValidate(t *testing.T) {
 		webhook := v1beta1.NewCollectorWebhook(
 			logr.Discard(),
 			testScheme,
-			config.New(
+			config.NewProvider(config.New(
 				config.WithCollectorImage("collector:v0.0.0"),
 				config.WithTargetAllocatorImage("ta:v0.0.0"),
-			),
+			)),
 			getReviewer(test.shouldFailSar),
 			nil,
 			bv,
 			nil,
+			nil,
 		)
 		t.Run(tt.name, func(t *testing.T) {
 			tt := tt
@@ -298,6 +306,36 @@ func TestCollectorDefaultingWebhook(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Setting Autoscaler KEDA triggers",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Autoscaler: &v1beta1.AutoscalerSpec{
+						MaxReplicas:  &five,
+						MinReplicas:  &one,
+						KedaTriggers: []v1beta1.KedaTrigger{{Type: "kafka"}},
+					},
+				},
+			},
+			expected: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{},
+				},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:            v1beta1.ModeDeployment,
+					UpgradeStrategy: v1beta1.UpgradeStrategyAutomatic,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Replicas:        &one,
+						ManagementState: v1beta1.ManagementStateManaged,
+					},
+					Autoscaler: &v1beta1.AutoscalerSpec{
+						MaxReplicas:  &five,
+						MinReplicas:  &one,
+						KedaTriggers: []v1beta1.KedaTrigger{{Type: "kafka"}},
+					},
+				},
+			},
+		},
 		{
 			name: "Missing route termination",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -535,14 +573,15 @@ func TestCollectorDefaultingWebhook(t *testing.T) {
 			cvw := v1beta1.NewCollectorWebhook(
 				logr.Discard(),
 				testScheme,
-				config.New(
+				config.NewProvider(config.New(
 					config.WithCollectorImage("collector:v0.0.0"),
 					config.WithTargetAllocatorImage("ta:v0.0.0"),
-				),
+				)),
 				getReviewer(test.shouldFailSar),
 				nil,
 				bv,
 				nil,
+				nil,
 			)
 			ctx := context.Background()
 			err := cvw.Default(ctx, &test.otelcol)
@@ -557,6 +596,40 @@ func TestCollectorDefaultingWebhook(t *testing.T) {
 	}
 }
 
+func TestCollectorDefaultingWebhook_ResourceDetectionDefaults(t *testing.T) {
+	cvw := v1beta1.NewCollectorWebhook(
+		logr.Discard(),
+		testScheme,
+		config.NewProvider(config.New(config.WithPlatform(platform.EKS))),
+		getReviewer(false),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	newCollector := func() *v1beta1.OpenTelemetryCollector {
+		return &v1beta1.OpenTelemetryCollector{
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Config: v1beta1.Config{
+					Processors: &v1beta1.AnyConfig{Object: map[string]interface{}{"resourcedetection": nil}},
+				},
+			},
+		}
+	}
+
+	otelcol := newCollector()
+	require.NoError(t, cvw.Default(context.Background(), otelcol))
+	assert.Equal(t, map[string]interface{}{"detectors": []interface{}{"env", "eks", "ec2"}}, otelcol.Spec.Config.Processors.Object["resourcedetection"])
+
+	require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableConfigDefaulting.ID(), false))
+	t.Cleanup(func() {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableConfigDefaulting.ID(), true))
+	})
+	otelcol = newCollector()
+	require.NoError(t, cvw.Default(context.Background(), otelcol))
+	assert.Nil(t, otelcol.Spec.Config.Processors.Object["resourcedetection"], "the defaults require the feature gate")
+}
+
 var cfgYaml = `receivers:
  examplereceiver:
    endpoint: "0.0.0.0:12345"
@@ -581,6 +654,9 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 	three := int32(3)
 	five := int32(5)
 	maxInt := int32(math.MaxInt32)
+	// the collectors without memory limits are warned about, see TestAdviceWarnings
+	memoryLimitWarning := "spec.resources sets no memory limit, the pods can use all the memory of their node until they or other pods are evicted"
+	taMemoryLimitWarning := "spec.targetAllocator.resources sets no memory limit, the pods can use all the memory of their node until they or other pods are evicted"
 
 	cfg := v1beta1.Config{
 		Service: v1beta1.Service{
@@ -606,6 +682,17 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 	}
 	err := go_yaml.Unmarshal([]byte(cfgYaml), &cfg)
 	require.NoError(t, err)
+	probeCfg := v1beta1.Config{
+		Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+			"otlp":   map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}}},
+			"statsd": map[string]interface{}{},
+		}},
+		Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": map[string]interface{}{}}},
+		Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+			"traces":  {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+			"metrics": {Receivers: []string{"statsd"}, Exporters: []string{"debug"}},
+		}},
+	}
 
 	tests := []struct { //nolint:govet
 		name             string
@@ -615,8 +702,32 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 		shouldFailSar    bool
 	}{
 		{
-			name:    "valid empty spec",
-			otelcol: v1beta1.OpenTelemetryCollector{},
+			name:             "valid empty spec",
+			otelcol:          v1beta1.OpenTelemetryCollector{},
+			expectedWarnings: []string{memoryLimitWarning},
+		},
+		{
+			name: "valid containers mounting the volumes of the pod",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeStatefulSet,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Volumes: []v1.Volume{{Name: "logs"}},
+						InitContainers: []v1.Container{{
+							Name:         "preprocessor",
+							VolumeMounts: []v1.VolumeMount{{Name: "otc-internal"}, {Name: "otc-persistence"}},
+						}},
+						AdditionalContainers: []v1.Container{{
+							Name:         "log-shipper",
+							VolumeMounts: []v1.VolumeMount{{Name: "logs"}, {Name: "configmap-extra"}},
+						}},
+					},
+					AdditionalContainersPosition: v1beta1.ContainerPositionAfter,
+					Persistence:                  &v1beta1.Persistence{},
+					ConfigMaps:                   []v1beta1.ConfigMapsSpec{{Name: "extra"}},
+				},
+			},
+			expectedWarnings: []string{memoryLimitWarning},
 		},
 		{
 			name: "valid full spec",
@@ -661,6 +772,7 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 					Config: cfg,
 				},
 			},
+			expectedWarnings: []string{memoryLimitWarning, taMemoryLimitWarning},
 		},
 		{
 			name:          "prom CR admissions warning",
@@ -724,6 +836,8 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 				"missing the following rules for system:serviceaccount:test-ns:adm-warning-targetallocator - configmaps: [get]",
 				"missing the following rules for system:serviceaccount:test-ns:adm-warning-targetallocator - discovery.k8s.io/endpointslices: [get,list,watch]",
 				"missing the following rules for system:serviceaccount:test-ns:adm-warning-targetallocator - nonResourceURL: /metrics: [get]",
+				memoryLimitWarning,
+				taMemoryLimitWarning,
 			},
 		},
 		{
@@ -769,6 +883,7 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 					Config: cfg,
 				},
 			},
+			expectedWarnings: []string{memoryLimitWarning, taMemoryLimitWarning},
 		},
 		{
 			name: "invalid mode with volume claim templates",
@@ -797,6 +912,501 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "does not support the attribute 'persistentVolumeClaimRetentionPolicy'",
 		},
+		{
+			name: "invalid mode with networkPolicy",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:          v1beta1.ModeSidecar,
+					NetworkPolicy: v1beta1.NetworkPolicy{Enabled: true},
+				},
+			},
+			expectedErr: "does not support the attribute 'networkPolicy'",
+		},
+		{
+			name: "invalid mode with enrichment",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:       v1beta1.ModeSidecar,
+					Enrichment: v1beta1.Enrichment{Enabled: true},
+				},
+			},
+			expectedErr: "does not support the attribute 'enrichment'",
+		},
+		{
+			name: "valid job mode",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeJob,
+					Job:  &v1beta1.JobSpec{Duration: &metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			expectedWarnings: []string{memoryLimitWarning},
+		},
+		{
+			name: "job mode without duration",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeJob,
+				},
+			},
+			expectedWarnings: []string{
+				"the collectors of the jobs run until they stop on their own or the jobs are terminated, set 'job.duration' to stop them gracefully",
+				memoryLimitWarning,
+			},
+		},
+		{
+			name: "invalid mode with job",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					Job:  &v1beta1.JobSpec{Duration: &metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			expectedErr: "does not support the attribute 'job'",
+		},
+		{
+			name: "invalid mode with cronJob",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    v1beta1.ModeJob,
+					CronJob: &v1beta1.CronJobSpec{Schedule: "0 * * * *"},
+				},
+			},
+			expectedErr: "does not support the attribute 'cronJob'",
+		},
+		{
+			name: "cronjob mode without cronJob",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeCronJob,
+					Job:  &v1beta1.JobSpec{Duration: &metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			expectedErr: "which requires the attribute 'cronJob'",
+		},
+		{
+			name: "job mode with ttlSecondsAfterFinished",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeJob,
+					Job: &v1beta1.JobSpec{
+						Duration:                &metav1.Duration{Duration: time.Hour},
+						TTLSecondsAfterFinished: &one,
+					},
+				},
+			},
+			expectedErr: "does not support the attribute 'job.ttlSecondsAfterFinished'",
+		},
+		{
+			name: "cronjob mode with autoscaler",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:       v1beta1.ModeCronJob,
+					CronJob:    &v1beta1.CronJobSpec{Schedule: "0 * * * *"},
+					Job:        &v1beta1.JobSpec{Duration: &metav1.Duration{Duration: time.Hour}},
+					Autoscaler: &v1beta1.AutoscalerSpec{MaxReplicas: &five},
+				},
+			},
+			expectedErr: "does not support the attribute 'autoscaler'",
+		},
+		{
+			name: "job mode with the reload rollout strategy",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    v1beta1.ModeJob,
+					Job:     &v1beta1.JobSpec{Duration: &metav1.Duration{Duration: time.Hour}},
+					Rollout: &v1beta1.Rollout{Strategy: v1beta1.RolloutStrategyReload},
+				},
+			},
+			expectedErr: "does not support the rollout strategy Reload",
+		},
+		{
+			name: "job duration below one second",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeJob,
+					Job:  &v1beta1.JobSpec{Duration: &metav1.Duration{Duration: time.Millisecond}},
+				},
+			},
+			expectedErr: "the attribute 'job.duration' must be at least one second",
+		},
+		{
+			name: "valid windows nodes",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:   v1beta1.ModeDaemonSet,
+					NodeOS: []v1beta1.NodeOS{v1beta1.NodeOSLinux, v1beta1.NodeOSWindows},
+					Windows: &v1beta1.WindowsSpec{
+						SecurityContext: &v1.WindowsSecurityContextOptions{HostProcess: ptr.To(true)},
+					},
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						HostNetwork: true,
+					},
+				},
+			},
+			expectedWarnings: []string{memoryLimitWarning},
+		},
+		{
+			name: "invalid mode with nodeOS",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:   v1beta1.ModeDeployment,
+					NodeOS: []v1beta1.NodeOS{v1beta1.NodeOSWindows},
+				},
+			},
+			expectedErr: "does not support the attribute 'nodeOS'",
+		},
+		{
+			name: "windows without the windows nodes",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    v1beta1.ModeDaemonSet,
+					Windows: &v1beta1.WindowsSpec{Image: "collector:windows"},
+				},
+			},
+			expectedErr: "the attribute 'windows' requires windows in the attribute 'nodeOS'",
+		},
+		{
+			name: "windows nodes with the reload rollout strategy",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    v1beta1.ModeDaemonSet,
+					NodeOS:  []v1beta1.NodeOS{v1beta1.NodeOSWindows},
+					Rollout: &v1beta1.Rollout{Strategy: v1beta1.RolloutStrategyReload},
+				},
+			},
+			expectedErr: "the node OS windows does not support the rollout strategy Reload",
+		},
+		{
+			name: "windows host process containers without the host network",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:   v1beta1.ModeDaemonSet,
+					NodeOS: []v1beta1.NodeOS{v1beta1.NodeOSWindows},
+					Windows: &v1beta1.WindowsSpec{
+						SecurityContext: &v1.WindowsSecurityContextOptions{HostProcess: ptr.To(true)},
+					},
+				},
+			},
+			expectedErr: "the attribute 'windows.securityContext.hostProcess' requires the attribute 'hostNetwork'",
+		},
+		{
+			name: "valid receiver port probes",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Config:         probeCfg,
+					LivenessProbe:  &v1beta1.Probe{Handler: v1beta1.ProbeHandlerReceiverPort},
+					ReadinessProbe: &v1beta1.Probe{Handler: v1beta1.ProbeHandlerReceiverPort, Receiver: "otlp"},
+				},
+			},
+			expectedWarnings: []string{memoryLimitWarning},
+		},
+		{
+			name: "receiver probe without the receiverPort handler",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Config:         probeCfg,
+					ReadinessProbe: &v1beta1.Probe{Receiver: "otlp"},
+				},
+			},
+			expectedErr: "the attribute 'receiver' of the readinessProbe requires the handler receiverPort",
+		},
+		{
+			name: "receiver probe without a TCP port",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Config:         probeCfg,
+					ReadinessProbe: &v1beta1.Probe{Handler: v1beta1.ProbeHandlerReceiverPort, Receiver: "statsd"},
+				},
+			},
+			expectedErr: "the readinessProbe can't check a receiver port: the receiver statsd isn't used by any pipeline or has no TCP port to probe",
+		},
+		{
+			name: "receiver probe without receivers",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					LivenessProbe: &v1beta1.Probe{Handler: v1beta1.ProbeHandlerReceiverPort},
+				},
+			},
+			expectedErr: "the livenessProbe can't check a receiver port: no receiver has a TCP port to probe",
+		},
+		{
+			name: "valid partitioned rollout",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    v1beta1.ModeStatefulSet,
+					Rollout: &v1beta1.Rollout{Partitioned: &v1beta1.PartitionedRollout{MaxRestarts: 2}},
+				},
+			},
+			expectedWarnings: []string{memoryLimitWarning},
+		},
+		{
+			name: "invalid mode with partitioned rollout",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    v1beta1.ModeDeployment,
+					Rollout: &v1beta1.Rollout{Partitioned: &v1beta1.PartitionedRollout{}},
+				},
+			},
+			expectedErr: "does not support the attribute 'rollout.partitioned'",
+		},
+		{
+			name: "partitioned rollout with the reload strategy",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeStatefulSet,
+					Rollout: &v1beta1.Rollout{
+						Strategy:    v1beta1.RolloutStrategyReload,
+						Partitioned: &v1beta1.PartitionedRollout{},
+					},
+				},
+			},
+			expectedErr: "the rollout strategy Reload does not support partitioned rollouts",
+		},
+		{
+			name: "valid presets",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:   v1beta1.ModeDaemonSet,
+					Config: probeCfg,
+					Presets: v1beta1.Presets{
+						HostMetrics:    v1beta1.Preset{Enabled: true},
+						KubeletMetrics: v1beta1.Preset{Enabled: true},
+					},
+				},
+			},
+			expectedWarnings: []string{memoryLimitWarning},
+		},
+		{
+			name: "preset without pipeline",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    v1beta1.ModeDaemonSet,
+					Config:  probeCfg,
+					Presets: v1beta1.Presets{LogsCollection: v1beta1.LogsCollectionPreset{Preset: v1beta1.Preset{Enabled: true}}},
+				},
+			},
+			expectedWarnings: []string{
+				"the preset 'logsCollection' has no logs pipeline to add its receiver to",
+				memoryLimitWarning,
+			},
+		},
+		{
+			name: "invalid mode with presets",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    v1beta1.ModeDeployment,
+					Presets: v1beta1.Presets{KubeletMetrics: v1beta1.Preset{Enabled: true}},
+				},
+			},
+			expectedErr: "does not support the attribute 'presets'",
+		},
+		{
+			name: "host metrics preset on windows nodes",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    v1beta1.ModeDaemonSet,
+					Config:  probeCfg,
+					NodeOS:  []v1beta1.NodeOS{v1beta1.NodeOSLinux, v1beta1.NodeOSWindows},
+					Presets: v1beta1.Presets{HostMetrics: v1beta1.Preset{Enabled: true}},
+				},
+			},
+			expectedErr: "the node OS windows does not support the preset 'hostMetrics'",
+		},
+		{
+			name: "valid topology-aware routing",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:                 v1beta1.ModeDaemonSet,
+					TopologyAwareRouting: v1beta1.TopologyAwareRouting{Enabled: true, InternalTrafficPolicy: v1.ServiceInternalTrafficPolicyLocal},
+				},
+			},
+			expectedWarnings: []string{memoryLimitWarning},
+		},
+		{
+			name: "invalid mode with topology-aware routing",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:                 v1beta1.ModeSidecar,
+					TopologyAwareRouting: v1beta1.TopologyAwareRouting{Enabled: true},
+				},
+			},
+			expectedErr: "does not support the attribute 'topologyAwareRouting'",
+		},
+		{
+			name: "local internal traffic policy outside of the daemonset mode",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:                 v1beta1.ModeDeployment,
+					TopologyAwareRouting: v1beta1.TopologyAwareRouting{InternalTrafficPolicy: v1.ServiceInternalTrafficPolicyLocal},
+				},
+			},
+			expectedWarnings: []string{
+				"the internal traffic policy Local only routes the clients to the collector pods of their node, which the deployment mode doesn't run on every node",
+				memoryLimitWarning,
+			},
+		},
+		{
+			name: "invalid mode with services",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:     v1beta1.ModeSidecar,
+					Services: []v1beta1.CollectorService{{Name: "otlp", Ports: []string{"otlp-grpc"}}},
+				},
+			},
+			expectedErr: "does not support the attribute 'services'",
+		},
+		{
+			name: "service with a reserved name",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:     v1beta1.ModeDeployment,
+					Services: []v1beta1.CollectorService{{Name: "monitoring", Ports: []string{"otlp-grpc"}}},
+				},
+			},
+			expectedErr: "the name of the service monitoring is reserved",
+		},
+		{
+			name: "service with the name of the otlp-headless service",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:     v1beta1.ModeDeployment,
+					Services: []v1beta1.CollectorService{{Name: "otlp-headless", Ports: []string{"otlp-grpc"}}},
+				},
+			},
+			expectedErr: "the name of the service otlp-headless is reserved",
+		},
+		{
+			name: "service without ports",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:     v1beta1.ModeDeployment,
+					Services: []v1beta1.CollectorService{{Name: "otlp"}},
+				},
+			},
+			expectedErr: "the service otlp must expose some receivers or ports",
+		},
+		{
+			name: "service with an unknown receiver",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:     v1beta1.ModeDeployment,
+					Config:   cfg,
+					Services: []v1beta1.CollectorService{{Name: "ingest", Receivers: []string{"zipkin"}}},
+				},
+			},
+			expectedErr: "the service ingest exposes the receiver zipkin, which isn't configured",
+		},
+		{
+			name: "additional containers position in sidecar mode",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:                         v1beta1.ModeSidecar,
+					AdditionalContainersPosition: v1beta1.ContainerPositionAfter,
+				},
+			},
+			expectedErr: "does not support the attribute 'additionalContainersPosition'",
+		},
+		{
+			name: "additional container mounting an unknown volume",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						AdditionalContainers: []v1.Container{{
+							Name:         "log-shipper",
+							VolumeMounts: []v1.VolumeMount{{Name: "otc-internal"}, {Name: "logs"}},
+						}},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"the container log-shipper mounts the volume logs, which isn't a volume of the collector pods, they won't start",
+				memoryLimitWarning,
+			},
+		},
+		{
+			name: "init container mounting the persistent volume outside of the statefulset mode",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						InitContainers: []v1.Container{{
+							Name:         "preprocessor",
+							VolumeMounts: []v1.VolumeMount{{Name: "otc-persistence"}},
+						}},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"the container preprocessor mounts the volume otc-persistence, which isn't a volume of the collector pods, they won't start",
+				memoryLimitWarning,
+			},
+		},
+		{
+			name: "reload strategy in sidecar mode",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    v1beta1.ModeSidecar,
+					Rollout: &v1beta1.Rollout{Strategy: v1beta1.RolloutStrategyReload},
+				},
+			},
+			expectedErr: "does not support the rollout strategy Reload",
+		},
+		{
+			name: "reload strategy with a canary rollout",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					Rollout: &v1beta1.Rollout{
+						Strategy: v1beta1.RolloutStrategyReload,
+						Canary:   &v1beta1.CanaryRollout{},
+					},
+				},
+			},
+			expectedErr: "the rollout strategy Reload does not support canary rollouts",
+		},
+		{
+			name: "route certificate with the passthrough termination",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					Ingress: v1beta1.Ingress{
+						Type: v1beta1.IngressTypeRoute,
+						Route: v1beta1.OpenShiftRoute{
+							Termination: v1beta1.TLSRouteTerminationTypePassthrough,
+							Ports:       []v1beta1.OpenShiftRoutePort{{Name: "otlp-http", CertificateSecret: "otlp-http-tls"}},
+						},
+					},
+				},
+			},
+			expectedErr: "the route of the port otlp-http has a certificate, which is not supported by the passthrough termination",
+		},
+		{
+			name: "route destination CA certificate with the edge termination",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					Ingress: v1beta1.Ingress{
+						Type: v1beta1.IngressTypeRoute,
+						Route: v1beta1.OpenShiftRoute{
+							Ports: []v1beta1.OpenShiftRoutePort{{Name: "otlp-grpc", DestinationCACertificate: "-----BEGIN CERTIFICATE-----"}},
+						},
+					},
+				},
+			},
+			expectedErr: "the route of the port otlp-grpc has a destination CA certificate, which is only supported by the reencrypt termination",
+		},
+		{
+			name: "invalid mode with persistence",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:        v1beta1.ModeDeployment,
+					Persistence: &v1beta1.Persistence{},
+				},
+			},
+			expectedErr: "does not support the attribute 'persistence'",
+		},
 		{
 			name: "invalid mode with tolerations",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -902,6 +1512,66 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Spec Ports configuration is incorrect",
 		},
+		{
+			name: "pod disruption budget with minAvailable and maxUnavailable",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						PodDisruptionBudget: &v1beta1.PodDisruptionBudgetSpec{
+							MinAvailable:   &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+							MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec podDisruptionBudget configuration is incorrect, minAvailable and maxUnavailable are mutually exclusive",
+		},
+		{
+			name: "pod disruption budget with an invalid percentage",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeStatefulSet,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						PodDisruptionBudget: &v1beta1.PodDisruptionBudgetSpec{
+							MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "150%"},
+						},
+					},
+				},
+			},
+			expectedErr: "maxUnavailable should be a number of pods or a percentage between 0% and 100%",
+		},
+		{
+			name: "target allocator pod disruption budget with a negative minAvailable",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeStatefulSet,
+					TargetAllocator: v1beta1.TargetAllocatorEmbedded{
+						PodDisruptionBudget: &v1beta1.PodDisruptionBudgetSpec{
+							MinAvailable: &intstr.IntOrString{Type: intstr.Int, IntVal: -1},
+						},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Spec targetAllocator.podDisruptionBudget configuration is incorrect, minAvailable should be greater than or equal to 0",
+		},
+		{
+			name: "pod disruption budget outside of the deployment and statefulset modes",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDaemonSet,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						PodDisruptionBudget: &v1beta1.PodDisruptionBudgetSpec{
+							MinAvailable: &intstr.IntOrString{Type: intstr.String, StrVal: "50%"},
+						},
+					},
+				},
+			},
+			expectedWarnings: []string{
+				"spec.podDisruptionBudget is ignored in the daemonset mode, the pod disruption budgets are only created for the deployment and statefulset modes",
+				memoryLimitWarning,
+			},
+		},
 		{
 			name: "invalid max replicas",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -1129,6 +1799,55 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: fmt.Sprintf("Ingress can only be used in combination with the modes: %s, %s, %s", v1beta1.ModeDeployment, v1beta1.ModeDaemonSet, v1beta1.ModeStatefulSet),
 		},
+		{
+			name: "keda triggers without maxReplicas",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Autoscaler: &v1beta1.AutoscalerSpec{
+						KedaTriggers: []v1beta1.KedaTrigger{{Type: "kafka"}},
+					},
+				},
+			},
+			expectedErr: "maxReplicas should be defined when kedaTriggers are used",
+		},
+		{
+			name: "invalid mode with vpa",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeStatefulSet,
+					Autoscaler: &v1beta1.AutoscalerSpec{
+						VPA: &v1beta1.VPASpec{UpdateMode: v1beta1.VPAUpdateModeAuto},
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf("vpa can only be used in combination with the modes: %s, %s", v1beta1.ModeDeployment, v1beta1.ModeDaemonSet),
+		},
+		{
+			name: "invalid deployment mode incompatible with gateway route settings",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeSidecar,
+					Ingress: v1beta1.Ingress{
+						Type: v1beta1.IngressTypeGateway,
+						Gateway: v1beta1.GatewayRoute{
+							ParentRefs: []v1beta1.GatewayParentReference{{Name: "gateway"}},
+						},
+					},
+				},
+			},
+			expectedErr: fmt.Sprintf("Gateway routes can only be used in combination with the modes: %s, %s, %s", v1beta1.ModeDeployment, v1beta1.ModeDaemonSet, v1beta1.ModeStatefulSet),
+		},
+		{
+			name: "gateway route without parentRefs",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Ingress: v1beta1.Ingress{
+						Type: v1beta1.IngressTypeGateway,
+					},
+				},
+			},
+			expectedErr: "At least one Gateway parentRef has to be defined",
+		},
 		{
 			name: "invalid mode with priorityClassName",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -1360,6 +2079,18 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			},
 			expectedErr: "the OpenTelemetry Collector mode is set to statefulset, which does not support the attribute 'deploymentUpdateStrategy'",
 		},
+		{
+			name: "invalid canary rollout for DaemonSet mode",
+			otelcol: v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDaemonSet,
+					Rollout: &v1beta1.Rollout{
+						Canary: &v1beta1.CanaryRollout{},
+					},
+				},
+			},
+			expectedErr: "the OpenTelemetry Collector mode is set to daemonset, which does not support the attribute 'rollout.canary'",
+		},
 		{
 			name: "missing port for ingress type",
 			otelcol: v1beta1.OpenTelemetryCollector{
@@ -1405,14 +2136,15 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 			cvw := v1beta1.NewCollectorWebhook(
 				logr.Discard(),
 				testScheme,
-				config.New(
+				config.NewProvider(config.New(
 					config.WithCollectorImage("collector:v0.0.0"),
 					config.WithTargetAllocatorImage("ta:v0.0.0"),
-				),
+				)),
 				getReviewer(test.shouldFailSar),
 				nil,
 				bv,
 				nil,
+				nil,
 			)
 			ctx := context.Background()
 			warnings, err := cvw.ValidateCreate(ctx, &test.otelcol)
@@ -1427,6 +2159,164 @@ func TestOTELColValidatingWebhook(t *testing.T) {
 	}
 }
 
+func TestOTELColValidatingWebhook_StructuralConfigValidation(t *testing.T) {
+	require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableStructuralConfigValidation.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableStructuralConfigValidation.ID(), false))
+	})
+
+	cfg := v1beta1.Config{}
+	require.NoError(t, go_yaml.Unmarshal([]byte(`
+receivers:
+  otlp: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+`), &cfg))
+
+	cvw := v1beta1.NewCollectorWebhook(
+		logr.Discard(),
+		testScheme,
+		config.NewProvider(config.New(
+			config.WithCollectorImage("collector:v0.0.0"),
+			config.WithTargetAllocatorImage("ta:v0.0.0"),
+		)),
+		getReviewer(false),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: cfg,
+		},
+	}
+	_, err := cvw.ValidateCreate(context.Background(), otelcol)
+	assert.EqualError(t, err, `the collector configuration is invalid: service::pipelines::traces: references processor "batch" which is not configured`)
+
+	// the config sources are merged and validated by the reconciler
+	otelcol.Spec.ConfigSources = []v1beta1.ConfigSource{
+		{ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "base"}, Key: "collector.yaml"}},
+	}
+	_, err = cvw.ValidateCreate(context.Background(), otelcol)
+	assert.NoError(t, err)
+}
+
+func TestOTELColValidatingWebhook_EnforceFIPS(t *testing.T) {
+	require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnforceFIPS.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnforceFIPS.ID(), false))
+	})
+
+	cvw := v1beta1.NewCollectorWebhook(
+		logr.Discard(),
+		testScheme,
+		config.NewProvider(config.New(
+			config.WithCollectorImage("collector:v0.0.0"),
+			config.WithTargetAllocatorImage("ta-fips:v0.0.0"),
+		)),
+		getReviewer(false),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeStatefulSet,
+			TargetAllocator: v1beta1.TargetAllocatorEmbedded{
+				Enabled: true,
+				Image:   "ta:v0.0.0",
+			},
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"prometheus": map[string]interface{}{
+						"config": map[string]interface{}{
+							"scrape_configs": []interface{}{map[string]interface{}{"job_name": "self"}},
+						},
+					},
+				}},
+			},
+		},
+	}
+	_, err := cvw.ValidateCreate(context.Background(), otelcol)
+	assert.EqualError(t, err, "the images collector:v0.0.0, ta:v0.0.0 aren't built for FIPS, which the operator.fips.enforce feature gate requires")
+	_, err = cvw.ValidateUpdate(context.Background(), otelcol, otelcol)
+	assert.EqualError(t, err, "the images collector:v0.0.0, ta:v0.0.0 aren't built for FIPS, which the operator.fips.enforce feature gate requires")
+
+	// the existing collectors can still be deleted
+	_, err = cvw.ValidateDelete(context.Background(), otelcol)
+	assert.NoError(t, err)
+
+	otelcol.Spec.Image = "collector:v0.0.0-fips"
+	otelcol.Spec.TargetAllocator.Image = ""
+	_, err = cvw.ValidateCreate(context.Background(), otelcol)
+	assert.NoError(t, err)
+}
+
+func TestOTELColWebhookResourceSelector(t *testing.T) {
+	cvw := v1beta1.NewCollectorWebhook(
+		logr.Discard(),
+		testScheme,
+		config.NewProvider(config.New(
+			config.WithCollectorImage("collector:v0.0.0"),
+			config.WithResourceSelector(labels.SelectorFromSet(labels.Set{"team": "a"})),
+		)),
+		getReviewer(false),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	// the collector of another operator is neither defaulted nor validated
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "b"}},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeSidecar,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Tolerations: []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}},
+			},
+		},
+	}
+	require.NoError(t, cvw.Default(context.Background(), otelcol))
+	assert.Empty(t, otelcol.Spec.UpgradeStrategy)
+	_, err := cvw.ValidateCreate(context.Background(), otelcol)
+	assert.NoError(t, err)
+
+	otelcol.Labels["team"] = "a"
+	require.NoError(t, cvw.Default(context.Background(), otelcol))
+	assert.Equal(t, v1beta1.UpgradeStrategyAutomatic, otelcol.Spec.UpgradeStrategy)
+	_, err = cvw.ValidateCreate(context.Background(), otelcol)
+	assert.Error(t, err)
+}
+
+func TestConfigWarnings(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDaemonSet,
+			Config: v1beta1.Config{
+				Receivers:  v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": nil}},
+				Processors: &v1beta1.AnyConfig{Object: map[string]interface{}{"batch": map[string]interface{}{}}},
+			},
+			Presets: v1beta1.Presets{HostMetrics: v1beta1.Preset{Enabled: true}},
+		},
+	}
+	assert.Equal(t, admission.Warnings{
+		"Collector config spec.config has null objects: receivers.otlp:. For compatibility with other tooling, such as kustomize and kubectl edit, it is recommended to use empty objects e.g. batch: {}.",
+		"the preset 'hostMetrics' has no metrics pipeline to add its receiver to",
+	}, v1beta1.ConfigWarnings(otelcol))
+
+	otelcol.Spec.Config.Receivers.Object["otlp"] = map[string]interface{}{}
+	otelcol.Spec.Presets = v1beta1.Presets{}
+	assert.Empty(t, v1beta1.ConfigWarnings(otelcol))
+}
+
 func TestOTELColValidateUpdateWebhook(t *testing.T) {
 	tests := []struct { //nolint:govet
 		name             string
@@ -1473,14 +2363,15 @@ func TestOTELColValidateUpdateWebhook(t *testing.T) {
 			cvw := v1beta1.NewCollectorWebhook(
 				logr.Discard(),
 				testScheme,
-				config.New(
+				config.NewProvider(config.New(
 					config.WithCollectorImage("collector:v0.0.0"),
 					config.WithTargetAllocatorImage("ta:v0.0.0"),
-				),
+				)),
 				getReviewer(test.shouldFailSar),
 				nil,
 				bv,
 				nil,
+				nil,
 			)
 			ctx := context.Background()
 			warnings, err := cvw.ValidateUpdate(ctx, &test.otelcolOld, &test.otelcolNew)
@@ -1514,3 +2405,139 @@ func getReviewer(shouldFailSAR bool) *rbac.Reviewer {
 	})
 	return rbac.NewReviewer(c)
 }
+
+func TestOTELColConfigEnvFrom(t *testing.T) {
+	reader := crfake.NewClientBuilder().WithObjects(
+		&v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default"},
+			Data:       map[string][]byte{"TOKEN": []byte("secret")},
+		},
+		&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"},
+			Data:       map[string]string{"ENDPOINT": "backend:4317"},
+		},
+	).Build()
+	newConfig := func(endpoint, token string) v1beta1.Config {
+		return v1beta1.Config{
+			Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+				"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}}},
+			}},
+			Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{
+				"otlp": map[string]interface{}{
+					"endpoint": endpoint,
+					"headers": map[string]interface{}{
+						"authorization": token,
+						"x-pod":         "${env:POD_NAME}",
+						"x-tenant":      "${env:TENANT:-default}",
+						"x-literal":     "$${env:LITERAL}",
+					},
+				},
+			}},
+			Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+				"traces": {Receivers: []string{"otlp"}, Exporters: []string{"otlp"}},
+			}},
+		}
+	}
+	configEnvFrom := []v1.EnvFromSource{
+		{Prefix: "BACKEND_", SecretRef: &v1.SecretEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "backend"}}},
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		spec        v1beta1.OpenTelemetryCollectorSpec
+		expectedErr string
+	}{
+		{
+			name: "variables set",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Config:        newConfig("${env:ENDPOINT}", "Bearer ${env:BACKEND_TOKEN}"),
+				ConfigEnvFrom: configEnvFrom,
+				OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+					EnvFrom: []v1.EnvFromSource{
+						{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "settings"}}},
+					},
+				},
+			},
+		},
+		{
+			name: "variable set by env",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Config:        newConfig("${env:OTLP_ENDPOINT}", "Bearer ${env:BACKEND_TOKEN}"),
+				ConfigEnvFrom: configEnvFrom,
+				OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+					Env: []v1.EnvVar{{Name: "OTLP_ENDPOINT", Value: "backend:4317"}},
+				},
+			},
+		},
+		{
+			name: "undefined variables",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Config:        newConfig("${env:ENDPOINT}", "Bearer ${env:TOKEN}"),
+				ConfigEnvFrom: configEnvFrom,
+			},
+			expectedErr: "the collector configuration references the environment variables ENDPOINT, TOKEN, which aren't set by 'configEnvFrom', 'envFrom', 'env' or the operator",
+		},
+		{
+			name: "missing source",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Config: newConfig("${env:ENDPOINT}", "Bearer ${env:BACKEND_TOKEN}"),
+				ConfigEnvFrom: []v1.EnvFromSource{
+					{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "missing"}}},
+				},
+			},
+			expectedErr: "failed to get the ConfigMap missing of the environment variables",
+		},
+		{
+			name:        "missing source with the validation skipped",
+			annotations: map[string]string{v1beta1.SkipConfigEnvValidationAnnotation: "true"},
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Config: newConfig("${env:ENDPOINT}", "Bearer ${env:BACKEND_TOKEN}"),
+				ConfigEnvFrom: []v1.EnvFromSource{
+					{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "missing"}}},
+				},
+			},
+		},
+		{
+			name: "missing optional source",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Config: newConfig("${env:ENDPOINT}", "Bearer ${env:BACKEND_TOKEN}"),
+				ConfigEnvFrom: append([]v1.EnvFromSource{
+					{ConfigMapRef: &v1.ConfigMapEnvSource{LocalObjectReference: v1.LocalObjectReference{Name: "missing"}, Optional: ptr.To(true)}},
+				}, configEnvFrom...),
+			},
+			expectedErr: "the collector configuration references the environment variables ENDPOINT, which aren't set by 'configEnvFrom', 'envFrom', 'env' or the operator",
+		},
+		{
+			name: "without configEnvFrom",
+			spec: v1beta1.OpenTelemetryCollectorSpec{
+				Config: newConfig("${env:ENDPOINT}", "Bearer ${env:TOKEN}"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cvw := v1beta1.NewCollectorWebhook(logr.Discard(), testScheme, config.NewProvider(config.New()), getReviewer(false), nil, nil, nil, reader)
+			otelcol := &v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Annotations: tt.annotations},
+				Spec:       tt.spec,
+			}
+			_, err := cvw.ValidateCreate(context.Background(), otelcol)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+			_, err = cvw.ValidateUpdate(context.Background(), otelcol, otelcol)
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expectedErr)
+			}
+
+			// the collectors are deleted whatever their variables
+			_, err = cvw.ValidateDelete(context.Background(), otelcol)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -55,6 +55,77 @@ type AutoscalerSpec struct {
 	// +optional
 	// TargetMemoryUtilization sets the target average memory utilization across all replicas
 	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+	// KedaTriggers makes the operator create a KEDA ScaledObject instead of a HorizontalPodAutoscaler,
+	// scaling the collector on the given triggers (e.g. Kafka consumer lag, queue depth or Prometheus queries).
+	// TargetCPUUtilization and TargetMemoryUtilization are translated into the KEDA cpu and memory triggers.
+	// Only considered when the KEDA API is available in the cluster, an HPA is created otherwise.
+	// +optional
+	// +listType=atomic
+	KedaTriggers []KedaTrigger `json:"kedaTriggers,omitempty"`
+	// VPA makes the operator create a VerticalPodAutoscaler for the collector, to right-size the resources of the
+	// collector container from its actual usage. Only considered in the deployment and daemonset modes and when the
+	// VerticalPodAutoscaler API is available in the cluster.
+	// +optional
+	VPA *VPASpec `json:"vpa,omitempty"`
+}
+
+// VPAUpdateMode defines when the VerticalPodAutoscaler applies its recommendations to the collector pods.
+//
+// +kubebuilder:validation:Enum=Off;Auto
+type VPAUpdateMode string
+
+const (
+	// VPAUpdateModeOff only computes the recommendations, which are reported in the status of the
+	// VerticalPodAutoscaler, without changing the resources of the pods.
+	VPAUpdateModeOff VPAUpdateMode = "Off"
+
+	// VPAUpdateModeAuto applies the recommendations to the pods, evicting them when their resources have to change.
+	VPAUpdateModeAuto VPAUpdateMode = "Auto"
+)
+
+// VPASpec defines the VerticalPodAutoscaler of the collector.
+type VPASpec struct {
+	// UpdateMode defines whether the recommendations are only computed (Off) or also applied to the pods (Auto).
+	// +optional
+	// +kubebuilder:default:=Off
+	UpdateMode VPAUpdateMode `json:"updateMode,omitempty"`
+	// MinAllowed is the lower bound of the resources recommended for the collector container.
+	// +optional
+	MinAllowed v1.ResourceList `json:"minAllowed,omitempty"`
+	// MaxAllowed is the upper bound of the resources recommended for the collector container.
+	// +optional
+	MaxAllowed v1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// KedaTrigger defines a KEDA scaler the collector is scaled on.
+// SEE: https://keda.sh/docs/latest/scalers/
+type KedaTrigger struct {
+	// Type of the KEDA scaler, e.g. kafka, rabbitmq or prometheus.
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+	// Name of the trigger, used to identify it in the KEDA metrics and conditions.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Metadata is the scaler specific configuration.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// AuthenticationRef references the TriggerAuthentication (or ClusterTriggerAuthentication) used by the scaler.
+	// +optional
+	AuthenticationRef *KedaAuthenticationRef `json:"authenticationRef,omitempty"`
+	// MetricType is the type of the metric target: AverageValue (default), Value or Utilization.
+	// +optional
+	// +kubebuilder:validation:Enum=AverageValue;Value;Utilization
+	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+}
+
+// KedaAuthenticationRef references a KEDA TriggerAuthentication or ClusterTriggerAuthentication.
+type KedaAuthenticationRef struct {
+	// Name of the TriggerAuthentication.
+	Name string `json:"name"`
+	// Kind is either TriggerAuthentication (default) or ClusterTriggerAuthentication.
+	// +optional
+	// +kubebuilder:validation:Enum=TriggerAuthentication;ClusterTriggerAuthentication
+	Kind string `json:"kind,omitempty"`
 }
 
 // PodDisruptionBudgetSpec defines the OpenTelemetryCollector's pod disruption budget specification.
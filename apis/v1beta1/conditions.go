@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+const (
+	// ConditionReady tells whether the collector is reconciled and its workloads are ready, for the health checks of
+	// the GitOps tools and kubectl wait --for=condition=Ready.
+	ConditionReady = "Ready"
+	// ConditionConfigValid tells whether the configuration rendered by the operator is valid.
+	ConditionConfigValid = "ConfigValid"
+	// ConditionConfigWarnings tells whether the configuration rendered by the operator has warnings, listed in its
+	// message.
+	ConditionConfigWarnings = "ConfigWarnings"
+	// ConditionRolloutComplete tells whether all the collector pods run the current pod template.
+	ConditionRolloutComplete = "RolloutComplete"
+	// ConditionExporterHealthy tells whether the health_check extension of the collector pods reports them healthy,
+	// which includes the failures of the exporters when its check_collector_pipeline is enabled.
+	ConditionExporterHealthy = "ExporterHealthy"
+	// ConditionFIPSCompliant tells whether the images of the collector and of its target allocator are built for FIPS,
+	// only set when the operator.fips.enforce feature gate is enabled.
+	ConditionFIPSCompliant = "FIPSCompliant"
+	// ConditionUpgradeHeldBack tells why the automatic upgrade of the collector is held back, only set while it is.
+	ConditionUpgradeHeldBack = "UpgradeHeldBack"
+)
+
+const (
+	// ReasonReady means the collector is ready.
+	ReasonReady = "Ready"
+	// ReasonProgressing means the collector workloads aren't ready yet.
+	ReasonProgressing = "Progressing"
+	// ReasonDegraded means the collector can't become ready without a change, e.g. of an invalid configuration.
+	ReasonDegraded = "Degraded"
+	// ReasonReconcileFailed means the operator failed to reconcile the collector.
+	ReasonReconcileFailed = "ReconcileFailed"
+	// ReasonValid means the configuration is valid.
+	ReasonValid = "Valid"
+	// ReasonInvalid means the configuration is invalid.
+	ReasonInvalid = "Invalid"
+	// ReasonUnvalidated means the configuration includes Secret config sources, which only the collector reads.
+	ReasonUnvalidated = "Unvalidated"
+	// ReasonWarnings means the configuration has warnings.
+	ReasonWarnings = "Warnings"
+	// ReasonNoWarnings means the configuration has no warnings.
+	ReasonNoWarnings = "NoWarnings"
+	// ReasonRolloutComplete means all the collector pods are updated and available.
+	ReasonRolloutComplete = "Complete"
+	// ReasonRolloutInProgress means some collector pods aren't updated or available yet.
+	ReasonRolloutInProgress = "InProgress"
+	// ReasonHealthy means the checked collector pods are healthy.
+	ReasonHealthy = "Healthy"
+	// ReasonUnhealthy means some checked collector pods are unhealthy.
+	ReasonUnhealthy = "Unhealthy"
+	// ReasonUnreachable means the health of the collector pods couldn't be checked.
+	ReasonUnreachable = "Unreachable"
+	// ReasonCompliant means the images are built for FIPS.
+	ReasonCompliant = "Compliant"
+	// ReasonNonCompliant means some images aren't built for FIPS.
+	ReasonNonCompliant = "NonCompliant"
+	// ReasonHeldBack means the automatic upgrade of the collector is held back.
+	ReasonHeldBack = "HeldBack"
+)
@@ -5,8 +5,16 @@ package v1beta1
 
 import (
 	"bytes"
+	"cmp"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"slices"
 	"sort"
+	"strings"
 
 	"dario.cat/mergo"
 	"github.com/go-logr/logr"
@@ -122,6 +130,118 @@ func (c *Config) GetEnabledComponents() map[ComponentKind]map[string]interface{}
 	return toReturn
 }
 
+var (
+	componentTypeRegexp = regexp.MustCompile(`^[a-zA-Z][0-9a-zA-Z_]{0,62}$`)
+	componentNameRegexp = regexp.MustCompile(`^[^\pZ\pC\pS]+$`)
+	pipelineSignals     = []string{"traces", "metrics", "logs", "profiles"}
+)
+
+// Validate checks the config the way the collector does when it starts: the component IDs, the extensions and the
+// pipelines of the service, which must only reference configured components, and the connectors, which must link two
+// pipelines. The operator doesn't know the components of the collector image, so their settings aren't validated.
+func (c *Config) Validate() error {
+	var errs []error
+	sections := []struct {
+		name   string
+		config *AnyConfig
+	}{
+		{"receivers", &c.Receivers},
+		{"exporters", &c.Exporters},
+		{"processors", c.Processors},
+		{"connectors", c.Connectors},
+		{"extensions", c.Extensions},
+	}
+	configured := map[string]map[string]bool{}
+	for _, section := range sections {
+		configured[section.name] = map[string]bool{}
+		if section.config == nil {
+			continue
+		}
+		for _, id := range slices.Sorted(maps.Keys(section.config.Object)) {
+			if err := validateComponentID(id); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", section.name, err))
+			}
+			configured[section.name][id] = true
+		}
+	}
+
+	for _, id := range c.Service.Extensions {
+		if !configured["extensions"][id] {
+			errs = append(errs, fmt.Errorf("service::extensions: references extension %q which is not configured", id))
+		}
+	}
+
+	if len(c.Service.Pipelines) == 0 {
+		errs = append(errs, errors.New("service::pipelines: must have at least one pipeline"))
+	}
+	connectorsAsReceiver := map[string]bool{}
+	connectorsAsExporter := map[string]bool{}
+	for _, id := range slices.Sorted(maps.Keys(c.Service.Pipelines)) {
+		path := "service::pipelines::" + id
+		if err := validateComponentID(id); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		} else if signal := components.ComponentType(id); !slices.Contains(pipelineSignals, signal) {
+			errs = append(errs, fmt.Errorf("%s: unknown signal %q, must be one of %s", path, signal, strings.Join(pipelineSignals, ", ")))
+		}
+
+		pipeline := c.Service.Pipelines[id]
+		if pipeline == nil {
+			pipeline = &Pipeline{}
+		}
+		if len(pipeline.Receivers) == 0 {
+			errs = append(errs, fmt.Errorf("%s: must have at least one receiver", path))
+		}
+		for _, receiver := range pipeline.Receivers {
+			switch {
+			case configured["receivers"][receiver]:
+			case configured["connectors"][receiver]:
+				connectorsAsReceiver[receiver] = true
+			default:
+				errs = append(errs, fmt.Errorf("%s: references receiver %q which is not configured", path, receiver))
+			}
+		}
+		for _, processor := range pipeline.Processors {
+			if !configured["processors"][processor] {
+				errs = append(errs, fmt.Errorf("%s: references processor %q which is not configured", path, processor))
+			}
+		}
+		if len(pipeline.Exporters) == 0 {
+			errs = append(errs, fmt.Errorf("%s: must have at least one exporter", path))
+		}
+		for _, exporter := range pipeline.Exporters {
+			switch {
+			case configured["exporters"][exporter]:
+			case configured["connectors"][exporter]:
+				connectorsAsExporter[exporter] = true
+			default:
+				errs = append(errs, fmt.Errorf("%s: references exporter %q which is not configured", path, exporter))
+			}
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(configured["connectors"])) {
+		if connectorsAsReceiver[id] && !connectorsAsExporter[id] {
+			errs = append(errs, fmt.Errorf("connectors::%s: used as a receiver but not as an exporter in any pipeline", id))
+		}
+		if connectorsAsExporter[id] && !connectorsAsReceiver[id] {
+			errs = append(errs, fmt.Errorf("connectors::%s: used as an exporter but not as a receiver in any pipeline", id))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateComponentID checks a component or pipeline ID, made of a type and an optional name: type[/name].
+func validateComponentID(id string) error {
+	componentType, name, hasName := strings.Cut(id, "/")
+	if !componentTypeRegexp.MatchString(componentType) {
+		return fmt.Errorf("invalid ID %q: the type must start with a letter and contain at most 63 letters, digits and underscores", id)
+	}
+	if hasName && !componentNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid ID %q: the name after the / must not be empty nor contain spaces, control characters or symbols", id)
+	}
+	return nil
+}
+
 // Config encapsulates collector config.
 type Config struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
@@ -252,9 +372,14 @@ func (c *Config) getEnvironmentVariablesForComponentKinds(logger logr.Logger, co
 	return envVars, nil
 }
 
-// applyDefaultForComponentKinds applies defaults to the endpoints for the given ComponentKind(s).
-func (c *Config) applyDefaultForComponentKinds(logger logr.Logger, componentKinds ...ComponentKind) error {
-	if err := c.Service.ApplyDefaults(logger); err != nil {
+// applyDefaultForComponentKinds applies defaults to the endpoints for the given ComponentKind(s), the defaulted
+// endpoints listening on all the addresses of the given IP family.
+func (c *Config) applyDefaultForComponentKinds(logger logr.Logger, family corev1.IPFamily, componentKinds ...ComponentKind) error {
+	host := defaultServiceHost
+	if family == corev1.IPv6Protocol {
+		host = defaultServiceHostIPv6
+	}
+	if err := c.Service.applyDefaults(logger, host); err != nil {
 		return err
 	}
 	enabledComponents := c.GetEnabledComponents()
@@ -294,6 +419,10 @@ func (c *Config) applyDefaultForComponentKinds(logger logr.Logger, componentKind
 				continue
 			}
 
+			if family == corev1.IPv6Protocol {
+				listenOnIPv6(mappedCfg, componentConf)
+			}
+
 			if componentConf == nil {
 				componentConf = map[string]interface{}{}
 			}
@@ -307,10 +436,62 @@ func (c *Config) applyDefaultForComponentKinds(logger logr.Logger, componentKind
 	return nil
 }
 
+// listenOnIPv6 rewrites the endpoints of the given defaulted component configuration listening on all the IPv4
+// addresses to listen on all the IPv6 ones, keeping the endpoints already set in the original configuration.
+func listenOnIPv6(defaulted map[string]interface{}, original interface{}) {
+	originalMap, _ := original.(map[string]interface{})
+	for key, value := range defaulted {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			listenOnIPv6(v, originalMap[key])
+		case string:
+			if port, ok := strings.CutPrefix(v, components.DefaultRecAddress+":"); ok && originalMap[key] != v {
+				defaulted[key] = net.JoinHostPort(defaultServiceHostIPv6, port)
+			}
+		}
+	}
+}
+
 func (c *Config) GetReceiverPorts(logger logr.Logger) ([]corev1.ServicePort, error) {
 	return c.getPortsForComponentKinds(logger, KindReceiver)
 }
 
+// GetPortsForReceiver returns the ports of the given receiver, or nil when it isn't used by any pipeline.
+func (c *Config) GetPortsForReceiver(logger logr.Logger, name string) ([]corev1.ServicePort, error) {
+	if _, ok := c.GetEnabledComponents()[KindReceiver][name]; !ok {
+		return nil, nil
+	}
+	return receivers.ReceiverFor(name).Ports(logger, name, c.Receivers.Object[name])
+}
+
+// GetReceiverProbePort returns the lowest TCP port of the given receiver, or else of the first receiver, by name, with
+// a TCP port, for the receiverPort probes.
+func (c *Config) GetReceiverProbePort(logger logr.Logger, receiver string) (*corev1.ServicePort, error) {
+	names := []string{receiver}
+	if receiver == "" {
+		names = slices.Sorted(maps.Keys(c.GetEnabledComponents()[KindReceiver]))
+	}
+	for _, name := range names {
+		ports, err := c.GetPortsForReceiver(logger, name)
+		if err != nil {
+			return nil, err
+		}
+		// the ports of the receivers with several protocols come in no particular order
+		slices.SortFunc(ports, func(a, b corev1.ServicePort) int {
+			return cmp.Compare(a.Port, b.Port)
+		})
+		for _, port := range ports {
+			if port.Protocol == "" || port.Protocol == corev1.ProtocolTCP {
+				return &port, nil
+			}
+		}
+	}
+	if receiver != "" {
+		return nil, fmt.Errorf("the receiver %s isn't used by any pipeline or has no TCP port to probe", receiver)
+	}
+	return nil, fmt.Errorf("no receiver has a TCP port to probe")
+}
+
 func (c *Config) GetExporterPorts(logger logr.Logger) ([]corev1.ServicePort, error) {
 	return c.getPortsForComponentKinds(logger, KindExporter)
 }
@@ -336,7 +517,46 @@ func (c *Config) GetAllRbacRules(logger logr.Logger) ([]rbacv1.PolicyRule, error
 }
 
 func (c *Config) ApplyDefaults(logger logr.Logger) error {
-	return c.applyDefaultForComponentKinds(logger, KindReceiver, KindExtension)
+	return c.ApplyDefaultsForIPFamily(logger, corev1.IPv4Protocol)
+}
+
+// ApplyDefaultsForIPFamily applies the configuration defaults, the defaulted endpoints listening on all the addresses
+// of the given IP family. On Linux, listening on all the IPv6 addresses also accepts the IPv4 connections, which suits
+// the dual-stack clusters.
+func (c *Config) ApplyDefaultsForIPFamily(logger logr.Logger, family corev1.IPFamily) error {
+	return c.applyDefaultForComponentKinds(logger, family, KindReceiver, KindExtension)
+}
+
+// ApplyResourceDetectionDefaults sets the given detectors on the resourcedetection processors which don't configure any.
+func (c *Config) ApplyResourceDetectionDefaults(detectors []string) {
+	if c.Processors == nil || len(detectors) == 0 {
+		return
+	}
+	for componentName, componentConf := range c.Processors.Object {
+		if components.ComponentType(componentName) != "resourcedetection" {
+			continue
+		}
+		newCfg := map[string]interface{}{}
+		if componentConf != nil {
+			mappedCfg, ok := componentConf.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if _, ok := mappedCfg["detectors"]; ok {
+				continue
+			}
+			// the nested maps are shared with copies of the config, so they are not modified in place
+			for k, v := range mappedCfg {
+				newCfg[k] = v
+			}
+		}
+		defaultDetectors := make([]interface{}, len(detectors))
+		for i, d := range detectors {
+			defaultDetectors[i] = d
+		}
+		newCfg["detectors"] = defaultDetectors
+		c.Processors.Object[componentName] = newCfg
+	}
 }
 
 // GetLivenessProbe gets the first enabled liveness probe. There should only ever be one extension enabled
@@ -427,8 +647,9 @@ type Service struct {
 }
 
 const (
-	defaultServicePort int32 = 8888
-	defaultServiceHost       = "0.0.0.0"
+	defaultServicePort     int32 = 8888
+	defaultServiceHost           = "0.0.0.0"
+	defaultServiceHostIPv6       = "::"
 )
 
 // MetricsEndpoint attempts gets the host and port number from the host address without doing any validation regarding the
@@ -457,6 +678,12 @@ func (s *Service) MetricsEndpoint(logger logr.Logger) (string, int32, error) {
 
 // ApplyDefaults inserts configuration defaults if it has not been set.
 func (s *Service) ApplyDefaults(logger logr.Logger) error {
+	return s.applyDefaults(logger, defaultServiceHost)
+}
+
+// applyDefaults inserts configuration defaults if it has not been set, the metrics being exposed on the given host
+// unless the telemetry sets an address.
+func (s *Service) applyDefaults(logger logr.Logger, defaultHost string) error {
 	tel := s.GetTelemetry()
 
 	if tel == nil {
@@ -471,12 +698,12 @@ func (s *Service) ApplyDefaults(logger logr.Logger) error {
 		return nil
 	}
 
-	host, port, err := s.MetricsEndpoint(logger)
+	_, port, err := s.MetricsEndpoint(logger)
 	if err != nil {
 		return err
 	}
 
-	reader := AddPrometheusMetricsEndpoint(host, port)
+	reader := AddPrometheusMetricsEndpoint(defaultHost, port)
 	tel.Metrics.Readers = append(tel.Metrics.Readers, reader)
 
 	telConfig, err := tel.ToAnyConfig()
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/operator-framework/operator-lib/proxy"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// envVarReference matches the escaped dollar signs, so that they are skipped, and the ${env:VAR} references, with
+// the ":-" of their default values.
+var envVarReference = regexp.MustCompile(`\$\$|\$\{env:([A-Za-z_][A-Za-z0-9_]*)(:-)?`)
+
+// EnvVarReferences returns the sorted names of the environment variables the config references as ${env:VAR}, leaving
+// out the references with a default value, as in ${env:VAR:-default}, and the escaped ones, as in $${env:VAR}.
+func (c *Config) EnvVarReferences() ([]string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, match := range envVarReference.FindAllStringSubmatch(string(data), -1) {
+		if match[1] == "" || match[2] != "" || slices.Contains(names, match[1]) {
+			continue
+		}
+		names = append(names, match[1])
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// ContainerEnvVars returns the environment variables for the collector container.
+// It combines user-defined environment variables from the OpenTelemetryCollector spec
+// with automatically inferred environment variables, giving precedence to user-defined ones.
+func (otelcol OpenTelemetryCollector) ContainerEnvVars(logger logr.Logger) []corev1.EnvVar {
+	inferredEnvVars := otelcol.inferredContainerEnvVars(logger)
+
+	envVars := []corev1.EnvVar{}
+	envVars = append(envVars, otelcol.Spec.Env...)
+
+	userDefinedEnvVars := make(map[string]bool, len(otelcol.Spec.Env))
+	for _, env := range otelcol.Spec.Env {
+		userDefinedEnvVars[env.Name] = true
+	}
+
+	// We only append the inferred env vars that are not defined by the user.
+	for _, env := range inferredEnvVars {
+		if _, ok := userDefinedEnvVars[env.Name]; !ok {
+			envVars = append(envVars, env)
+		}
+	}
+
+	return envVars
+}
+
+// ConfigSourceEnvVar returns the environment variable holding the Secret config source of the given index, which the
+// operator never reads.
+func ConfigSourceEnvVar(index int) string {
+	return fmt.Sprintf("OTEL_CONFIG_SOURCE_%d", index)
+}
+
+// inferredContainerEnvVars returns environment variables that are automatically added to the collector container.
+// Those include parsing the collector config and adding the env vars derived from it.
+func (otelcol OpenTelemetryCollector) inferredContainerEnvVars(logger logr.Logger) []corev1.EnvVar {
+	envVars := []corev1.EnvVar{}
+
+	for i, source := range otelcol.Spec.ConfigSources {
+		if source.SecretKeyRef != nil {
+			envVars = append(envVars, corev1.EnvVar{
+				Name:      ConfigSourceEnvVar(i),
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: source.SecretKeyRef.DeepCopy()},
+			})
+		}
+	}
+
+	envVars = append(envVars, corev1.EnvVar{
+		Name: "POD_NAME",
+		ValueFrom: &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: "metadata.name",
+			},
+		},
+	})
+
+	if otelcol.Spec.TargetAllocator.Enabled {
+		// We need to add a SHARD here so the collector is able to keep targets after the hashmod operation which is
+		// added by default by the Prometheus operator's config generator.
+		// All collector instances use SHARD == 0 as they only receive targets
+		// allocated to them and should not use the Prometheus hashmod-based
+		// allocation.
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "SHARD",
+			Value: "0",
+		})
+	}
+
+	if otelcol.Spec.Mode == ModeDaemonSet {
+		// the node of the daemonset collectors, which the enrichment processors and the presets use
+		envVars = append(envVars, corev1.EnvVar{
+			Name: EnrichmentNodeNameEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "spec.nodeName",
+				},
+			},
+		})
+	}
+
+	if featuregate.SetGolangFlags.IsEnabled() {
+		envVars = append(envVars,
+			corev1.EnvVar{
+				Name: "GOMEMLIMIT",
+				ValueFrom: &corev1.EnvVarSource{
+					ResourceFieldRef: &corev1.ResourceFieldSelector{
+						Resource:      "limits.memory",
+						ContainerName: naming.Container(),
+					},
+				},
+			},
+			corev1.EnvVar{
+				Name: "GOMAXPROCS",
+				ValueFrom: &corev1.EnvVarSource{
+					ResourceFieldRef: &corev1.ResourceFieldSelector{
+						Resource:      "limits.cpu",
+						ContainerName: naming.Container(),
+					},
+				},
+			},
+		)
+	}
+
+	if configEnvVars, err := otelcol.Spec.Config.GetEnvironmentVariables(logger); err != nil {
+		logger.Error(err, "could not get the environment variables from the config")
+	} else {
+		for _, env := range configEnvVars {
+			if !slices.ContainsFunc(envVars, func(e corev1.EnvVar) bool { return e.Name == env.Name }) {
+				envVars = append(envVars, env)
+			}
+		}
+	}
+
+	return append(envVars, proxy.ReadProxyVarsFromEnv()...)
+}
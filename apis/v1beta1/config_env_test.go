@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_EnvVarReferences(t *testing.T) {
+	cfg := Config{
+		Receivers: AnyConfig{Object: map[string]interface{}{
+			"otlp": map[string]interface{}{"protocols": map[string]interface{}{
+				"grpc": map[string]interface{}{"endpoint": "${env:POD_IP}:4317"},
+			}},
+		}},
+		Exporters: AnyConfig{Object: map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"endpoint": "${env:ENDPOINT}",
+				"headers": map[string]interface{}{
+					"authorization": "Bearer ${env:TOKEN}",
+					"x-tenant":      "${env:TENANT:-default}",
+					"x-literal":     "$${env:LITERAL}",
+					"x-other":       "${file:/var/token} ${POD_IP}",
+				},
+			},
+		}},
+	}
+
+	references, err := cfg.EnvVarReferences()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ENDPOINT", "POD_IP", "TOKEN"}, references)
+}
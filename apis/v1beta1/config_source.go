@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"maps"
+	"slices"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// ConfigSource references a ConfigMap or Secret key holding a part of the collector configuration, in YAML.
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef and secretKeyRef must be set"
+type ConfigSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap in the namespace of the collector.
+	// +optional
+	ConfigMapKeyRef *v1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret in the namespace of the collector.
+	// +optional
+	SecretKeyRef *v1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// HasSecretConfigSources returns whether some config sources are Secrets. The operator never reads them, the collector
+// merges them itself, so the configuration rendered by the operator is only a part of the collector configuration.
+func (s *OpenTelemetryCollectorSpec) HasSecretConfigSources() bool {
+	return slices.ContainsFunc(s.ConfigSources, func(source ConfigSource) bool {
+		return source.SecretKeyRef != nil
+	})
+}
+
+// MergeConfigs deep-merges the configuration layers in order, the later layers overriding the earlier ones. Maps are
+// merged key by key, any other value, lists included, is replaced, and null values don't override anything. The
+// layers are left untouched. The paths where a layer replaces a map with another kind of value, or the other way
+// around, are returned as conflicts.
+func MergeConfigs(layers ...map[string]interface{}) (map[string]interface{}, []string) {
+	merged := map[string]interface{}{}
+	var conflicts []string
+	for _, layer := range layers {
+		conflicts = mergeConfig(merged, layer, "", conflicts)
+	}
+	return merged, conflicts
+}
+
+func mergeConfig(dst, src map[string]interface{}, path string, conflicts []string) []string {
+	// sorted, so that the conflicts are deterministic
+	for _, key := range slices.Sorted(maps.Keys(src)) {
+		value := src[key]
+		if value == nil {
+			continue
+		}
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+
+		existing, exists := dst[key]
+		dstMap, dstIsMap := existing.(map[string]interface{})
+		srcMap, srcIsMap := value.(map[string]interface{})
+		switch {
+		case srcIsMap && dstIsMap:
+			conflicts = mergeConfig(dstMap, srcMap, keyPath, conflicts)
+		case srcIsMap:
+			if exists && existing != nil {
+				conflicts = append(conflicts, keyPath)
+			}
+			dstMap = map[string]interface{}{}
+			conflicts = mergeConfig(dstMap, srcMap, keyPath, conflicts)
+			dst[key] = dstMap
+		default:
+			if dstIsMap {
+				conflicts = append(conflicts, keyPath)
+			}
+			dst[key] = value
+		}
+	}
+	return conflicts
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeConfigs(t *testing.T) {
+	tests := []struct {
+		name          string
+		layers        []map[string]interface{}
+		want          map[string]interface{}
+		wantConflicts []string
+	}{
+		{
+			name: "no layers",
+			want: map[string]interface{}{},
+		},
+		{
+			name: "maps are merged",
+			layers: []map[string]interface{}{
+				{
+					"receivers": map[string]interface{}{"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}}}},
+					"exporters": map[string]interface{}{"debug": map[string]interface{}{}},
+				},
+				{
+					"receivers":  map[string]interface{}{"otlp": map[string]interface{}{"protocols": map[string]interface{}{"http": map[string]interface{}{}}}},
+					"processors": map[string]interface{}{"batch": map[string]interface{}{}},
+				},
+			},
+			want: map[string]interface{}{
+				"receivers":  map[string]interface{}{"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}, "http": map[string]interface{}{}}}},
+				"exporters":  map[string]interface{}{"debug": map[string]interface{}{}},
+				"processors": map[string]interface{}{"batch": map[string]interface{}{}},
+			},
+		},
+		{
+			name: "later layers override values and lists",
+			layers: []map[string]interface{}{
+				{"service": map[string]interface{}{"pipelines": map[string]interface{}{"traces": map[string]interface{}{"receivers": []interface{}{"otlp"}, "exporters": []interface{}{"debug"}}}}},
+				{"service": map[string]interface{}{"pipelines": map[string]interface{}{"traces": map[string]interface{}{"exporters": []interface{}{"otlp"}}}}},
+			},
+			want: map[string]interface{}{
+				"service": map[string]interface{}{"pipelines": map[string]interface{}{"traces": map[string]interface{}{"receivers": []interface{}{"otlp"}, "exporters": []interface{}{"otlp"}}}},
+			},
+		},
+		{
+			name: "null values don't override",
+			layers: []map[string]interface{}{
+				{"receivers": map[string]interface{}{"otlp": map[string]interface{}{}}},
+				{"receivers": nil, "exporters": map[string]interface{}{"debug": nil}},
+			},
+			want: map[string]interface{}{
+				"receivers": map[string]interface{}{"otlp": map[string]interface{}{}},
+				"exporters": map[string]interface{}{},
+			},
+		},
+		{
+			name: "conflicts",
+			layers: []map[string]interface{}{
+				{"receivers": map[string]interface{}{"otlp": map[string]interface{}{"protocols": "grpc"}}, "exporters": "debug"},
+				{"receivers": map[string]interface{}{"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}}}}, "exporters": map[string]interface{}{"debug": map[string]interface{}{}}},
+				{"exporters": []interface{}{"debug"}},
+			},
+			want: map[string]interface{}{
+				"receivers": map[string]interface{}{"otlp": map[string]interface{}{"protocols": map[string]interface{}{"grpc": map[string]interface{}{}}}},
+				"exporters": []interface{}{"debug"},
+			},
+			wantConflicts: []string{"exporters", "receivers.otlp.protocols", "exporters"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts := MergeConfigs(tt.layers...)
+			assert.Equal(t, tt.want, merged)
+			assert.Equal(t, tt.wantConflicts, conflicts)
+		})
+	}
+}
+
+func TestMergeConfigs_LayersUntouched(t *testing.T) {
+	base := map[string]interface{}{"receivers": map[string]interface{}{"otlp": map[string]interface{}{}}}
+	merged, _ := MergeConfigs(base, map[string]interface{}{"receivers": map[string]interface{}{"jaeger": map[string]interface{}{}}})
+	assert.Len(t, merged["receivers"], 2)
+	assert.Equal(t, map[string]interface{}{"receivers": map[string]interface{}{"otlp": map[string]interface{}{}}}, base)
+}
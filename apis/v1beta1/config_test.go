@@ -1144,3 +1144,238 @@ func TestTelemetryLogsPreservedWithMetrics(t *testing.T) {
 	require.NotNil(t, telemetry)
 	require.Equal(t, expected, cfg)
 }
+
+func TestConfig_ApplyResourceDetectionDefaults(t *testing.T) {
+	detectors := map[string]interface{}{
+		"detectors": []interface{}{"env"},
+	}
+	cfg := &Config{
+		Processors: &AnyConfig{
+			Object: map[string]interface{}{
+				"resourcedetection":        nil,
+				"resourcedetection/custom": detectors,
+				"resourcedetection/other": map[string]interface{}{
+					"timeout": "2s",
+				},
+				"batch": nil,
+			},
+		},
+	}
+
+	cfg.ApplyResourceDetectionDefaults([]string{"env", "eks", "ec2"})
+
+	assert.Equal(t, map[string]interface{}{
+		"resourcedetection": map[string]interface{}{
+			"detectors": []interface{}{"env", "eks", "ec2"},
+		},
+		"resourcedetection/custom": detectors,
+		"resourcedetection/other": map[string]interface{}{
+			"timeout":   "2s",
+			"detectors": []interface{}{"env", "eks", "ec2"},
+		},
+		"batch": nil,
+	}, cfg.Processors.Object)
+
+	// no detectors for the platform leave the config untouched
+	cfg = &Config{Processors: &AnyConfig{Object: map[string]interface{}{"resourcedetection": nil}}}
+	cfg.ApplyResourceDetectionDefaults(nil)
+	assert.Equal(t, map[string]interface{}{"resourcedetection": nil}, cfg.Processors.Object)
+}
+
+func TestConfig_ApplyDefaultsForIPFamily(t *testing.T) {
+	newConfig := func() *Config {
+		cfg := &Config{}
+		require.NoError(t, go_yaml.Unmarshal([]byte(`
+receivers:
+  otlp:
+    protocols:
+      grpc: {}
+      http:
+        endpoint: 0.0.0.0:4318
+extensions:
+  health_check: {}
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+`), cfg))
+		return cfg
+	}
+
+	for _, tt := range []struct {
+		family          v1.IPFamily
+		grpc            string
+		healthCheck     string
+		metricsHost     string
+		expectedMetrics string
+	}{
+		{family: v1.IPv4Protocol, grpc: "0.0.0.0:4317", healthCheck: "0.0.0.0:13133", metricsHost: "0.0.0.0"},
+		{family: v1.IPv6Protocol, grpc: "[::]:4317", healthCheck: "[::]:13133", metricsHost: "::"},
+	} {
+		t.Run(string(tt.family), func(t *testing.T) {
+			cfg := newConfig()
+			require.NoError(t, cfg.ApplyDefaultsForIPFamily(logr.Discard(), tt.family))
+
+			protocols := cfg.Receivers.Object["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})
+			assert.Equal(t, tt.grpc, protocols["grpc"].(map[string]interface{})["endpoint"])
+			// the endpoints set by the user are kept
+			assert.Equal(t, "0.0.0.0:4318", protocols["http"].(map[string]interface{})["endpoint"])
+			assert.Equal(t, tt.healthCheck, cfg.Extensions.Object["health_check"].(map[string]interface{})["endpoint"])
+
+			telemetry := cfg.Service.GetTelemetry()
+			require.NotNil(t, telemetry)
+			require.Len(t, telemetry.Metrics.Readers, 1)
+			assert.Equal(t, tt.metricsHost, *telemetry.Metrics.Readers[0].Pull.Exporter.Prometheus.Host)
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			config: `
+receivers:
+  otlp: {}
+processors:
+  batch: {}
+exporters:
+  debug/verbose: {}
+connectors:
+  spanmetrics: {}
+extensions:
+  health_check: {}
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug/verbose, spanmetrics]
+    metrics/spans:
+      receivers: [spanmetrics]
+      exporters: [debug/verbose]
+`,
+		},
+		{
+			name: "invalid IDs",
+			config: `
+receivers:
+  otlp/: {}
+  1otlp: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces/my pipeline:
+      receivers: [otlp/]
+      exporters: [debug]
+`,
+			wantErr: []string{
+				`receivers: invalid ID "1otlp": the type must start with a letter and contain at most 63 letters, digits and underscores`,
+				`receivers: invalid ID "otlp/": the name after the / must not be empty nor contain spaces, control characters or symbols`,
+				`service::pipelines::traces/my pipeline: invalid ID "traces/my pipeline": the name after the / must not be empty nor contain spaces, control characters or symbols`,
+			},
+		},
+		{
+			name: "unknown components",
+			config: `
+receivers:
+  otlp: {}
+exporters:
+  debug: {}
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp, jaeger]
+      processors: [batch]
+      exporters: [otlp]
+`,
+			wantErr: []string{
+				`service::extensions: references extension "health_check" which is not configured`,
+				`service::pipelines::traces: references receiver "jaeger" which is not configured`,
+				`service::pipelines::traces: references processor "batch" which is not configured`,
+				`service::pipelines::traces: references exporter "otlp" which is not configured`,
+			},
+		},
+		{
+			name: "invalid pipelines",
+			config: `
+receivers:
+  otlp: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    spans:
+      receivers: [otlp]
+      exporters: [debug]
+    logs:
+      receivers: []
+      exporters: []
+`,
+			wantErr: []string{
+				`service::pipelines::logs: must have at least one receiver`,
+				`service::pipelines::logs: must have at least one exporter`,
+				`service::pipelines::spans: unknown signal "spans", must be one of traces, metrics, logs, profiles`,
+			},
+		},
+		{
+			name: "no pipelines",
+			config: `
+receivers:
+  otlp: {}
+exporters:
+  debug: {}
+service:
+  pipelines: {}
+`,
+			wantErr: []string{
+				`service::pipelines: must have at least one pipeline`,
+			},
+		},
+		{
+			name: "unlinked connectors",
+			config: `
+receivers:
+  otlp: {}
+exporters:
+  debug: {}
+connectors:
+  count: {}
+  forward: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [count]
+    metrics:
+      receivers: [forward]
+      exporters: [debug]
+`,
+			wantErr: []string{
+				`connectors::count: used as an exporter but not as a receiver in any pipeline`,
+				`connectors::forward: used as a receiver but not as an exporter in any pipeline`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{}
+			require.NoError(t, go_yaml.Unmarshal([]byte(tt.config), cfg))
+			err := cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, strings.Join(tt.wantErr, "\n"), err.Error())
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+const (
+	// EnrichmentK8sAttributesProcessor is the k8sattributes processor the operator adds for the enrichment of the
+	// telemetry.
+	EnrichmentK8sAttributesProcessor = "k8sattributes/enrichment"
+	// EnrichmentResourceDetectionProcessor is the resourcedetection processor the operator adds for the enrichment of
+	// the telemetry.
+	EnrichmentResourceDetectionProcessor = "resourcedetection/enrichment"
+	// EnrichmentNodeNameEnvVar is the environment variable holding the node name of the daemonset collectors, used to
+	// only watch the pods of their node and to scrape their kubelet.
+	EnrichmentNodeNameEnvVar = "K8S_NODE_NAME"
+)
+
+// Enrichment defines the processors the operator adds to the collector configuration to enrich the telemetry with
+// the attributes of its Kubernetes and cloud resources.
+type Enrichment struct {
+	// Enabled adds a k8sattributes/enrichment processor and a resourcedetection/enrichment processor, with the
+	// detectors of the platform of the cluster, to the traces, metrics and logs pipelines without processors of these
+	// types. The processors already defined with these names are kept. The processors are only added to the
+	// configuration rendered by the operator, along with the RBAC permissions they need when the operator can create
+	// them.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// AddEnrichment adds the EnrichmentK8sAttributesProcessor and the EnrichmentResourceDetectionProcessor, with the given
+// detectors, to the traces, metrics and logs pipelines without processors of these types, after their leading
+// memory_limiter processors. With filterNode, the k8sattributes processor only watches the pods of the node in the
+// EnrichmentNodeNameEnvVar environment variable. The components of the config are copied before being changed.
+func (c *Config) AddEnrichment(detectors []string, filterNode bool) {
+	if len(detectors) == 0 {
+		detectors = []string{"env"}
+	}
+
+	var added []string
+	pipelines := make(map[string]*Pipeline, len(c.Service.Pipelines))
+	for name, pipeline := range c.Service.Pipelines {
+		pipelines[name] = pipeline
+		if pipeline == nil {
+			continue
+		}
+		signal, _, _ := strings.Cut(name, "/")
+		if signal != "traces" && signal != "metrics" && signal != "logs" {
+			continue
+		}
+
+		var missing []string
+		for _, processor := range []string{EnrichmentK8sAttributesProcessor, EnrichmentResourceDetectionProcessor} {
+			if !slices.ContainsFunc(pipeline.Processors, func(p string) bool {
+				return components.ComponentType(p) == components.ComponentType(processor)
+			}) {
+				missing = append(missing, processor)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		// the memory_limiter processors come first, to refuse the data as soon as possible
+		position := 0
+		for position < len(pipeline.Processors) && components.ComponentType(pipeline.Processors[position]) == "memory_limiter" {
+			position++
+		}
+		copied := *pipeline
+		copied.Processors = slices.Insert(slices.Clone(pipeline.Processors), position, missing...)
+		pipelines[name] = &copied
+		for _, processor := range missing {
+			if !slices.Contains(added, processor) {
+				added = append(added, processor)
+			}
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+	c.Service.Pipelines = pipelines
+
+	processors := c.Processors.DeepCopy()
+	if processors == nil {
+		processors = &AnyConfig{}
+	}
+	if processors.Object == nil {
+		processors.Object = map[string]interface{}{}
+	}
+	_, defined := processors.Object[EnrichmentK8sAttributesProcessor]
+	if slices.Contains(added, EnrichmentK8sAttributesProcessor) && !defined {
+		k8sattributes := map[string]interface{}{}
+		if filterNode {
+			k8sattributes["filter"] = map[string]interface{}{"node_from_env_var": EnrichmentNodeNameEnvVar}
+		}
+		processors.Object[EnrichmentK8sAttributesProcessor] = k8sattributes
+	}
+	_, defined = processors.Object[EnrichmentResourceDetectionProcessor]
+	if slices.Contains(added, EnrichmentResourceDetectionProcessor) && !defined {
+		defaultDetectors := make([]interface{}, len(detectors))
+		for i, d := range detectors {
+			defaultDetectors[i] = d
+		}
+		processors.Object[EnrichmentResourceDetectionProcessor] = map[string]interface{}{
+			"detectors": defaultDetectors,
+			"override":  false,
+		}
+	}
+	c.Processors = processors
+}
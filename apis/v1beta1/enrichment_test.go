@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_AddEnrichment(t *testing.T) {
+	traces := &Pipeline{Receivers: []string{"otlp"}, Processors: []string{"memory_limiter", "batch"}, Exporters: []string{"debug"}}
+	cfg := Config{
+		Processors: &AnyConfig{Object: map[string]interface{}{
+			"memory_limiter":      map[string]interface{}{"limit_percentage": 80},
+			"batch":               map[string]interface{}{},
+			"k8sattributes/mine":  map[string]interface{}{"passthrough": true},
+			"resourcedetection/x": nil,
+		}},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces":       traces,
+				"metrics":      {Receivers: []string{"otlp"}, Processors: []string{"k8sattributes/mine"}, Exporters: []string{"debug"}},
+				"logs/full":    {Receivers: []string{"otlp"}, Processors: []string{"resourcedetection/x", "k8sattributes/mine"}, Exporters: []string{"debug"}},
+				"profiles/all": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+			},
+		},
+	}
+	processors := cfg.Processors
+
+	cfg.AddEnrichment([]string{"env", "eks"}, true)
+
+	assert.Equal(t, []string{"memory_limiter", EnrichmentK8sAttributesProcessor, EnrichmentResourceDetectionProcessor, "batch"}, cfg.Service.Pipelines["traces"].Processors)
+	assert.Equal(t, []string{EnrichmentResourceDetectionProcessor, "k8sattributes/mine"}, cfg.Service.Pipelines["metrics"].Processors)
+	assert.Equal(t, []string{"resourcedetection/x", "k8sattributes/mine"}, cfg.Service.Pipelines["logs/full"].Processors)
+	assert.Empty(t, cfg.Service.Pipelines["profiles/all"].Processors)
+	assert.Equal(t, map[string]interface{}{"filter": map[string]interface{}{"node_from_env_var": "K8S_NODE_NAME"}}, cfg.Processors.Object[EnrichmentK8sAttributesProcessor])
+	assert.Equal(t, map[string]interface{}{"detectors": []interface{}{"env", "eks"}, "override": false}, cfg.Processors.Object[EnrichmentResourceDetectionProcessor])
+
+	// the original config is left untouched
+	assert.Equal(t, []string{"memory_limiter", "batch"}, traces.Processors)
+	assert.Len(t, processors.Object, 4)
+
+	// adding it again changes nothing
+	added := cfg.DeepCopy()
+	cfg.AddEnrichment([]string{"env", "eks"}, true)
+	assert.Equal(t, added, &cfg)
+}
+
+func TestConfig_AddEnrichmentDefaults(t *testing.T) {
+	cfg := Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+			},
+		},
+	}
+
+	cfg.AddEnrichment(nil, false)
+
+	assert.Equal(t, []string{EnrichmentK8sAttributesProcessor, EnrichmentResourceDetectionProcessor}, cfg.Service.Pipelines["traces"].Processors)
+	assert.Equal(t, map[string]interface{}{
+		EnrichmentK8sAttributesProcessor:     map[string]interface{}{},
+		EnrichmentResourceDetectionProcessor: map[string]interface{}{"detectors": []interface{}{"env"}, "override": false},
+	}, cfg.Processors.Object)
+}
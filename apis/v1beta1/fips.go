@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"slices"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/fips"
+)
+
+// NonFIPSImages returns the images of the collector, and of its target allocator, which aren't built for FIPS. The
+// default images of the operator are checked when the collector doesn't set them.
+func NonFIPSImages(otelcol *OpenTelemetryCollector, cfg config.Config) []string {
+	images := []string{otelcol.Spec.Image}
+	if images[0] == "" {
+		images[0] = cfg.CollectorImage
+	}
+	if otelcol.Spec.TargetAllocator.Enabled {
+		image := otelcol.Spec.TargetAllocator.Image
+		if image == "" {
+			image = cfg.TargetAllocatorImage
+		}
+		images = append(images, image)
+	}
+	return slices.DeleteFunc(images, fips.IsCompliantImage)
+}
+
+// EnforceFIPSTLS restricts the TLS settings of the components of the config, the tls blocks at any depth, to the
+// ones approved by FIPS 140: TLS 1.2 or later, with the approved cipher suites only. The tls blocks of the clients
+// with insecure set are left as they are, as they don't use TLS. The components are copied before being changed.
+func (c *Config) EnforceFIPSTLS() {
+	c.Receivers.Object = enforceFIPSTLS(c.Receivers.Object)
+	c.Exporters.Object = enforceFIPSTLS(c.Exporters.Object)
+	for _, components := range []**AnyConfig{&c.Processors, &c.Connectors, &c.Extensions} {
+		if *components != nil {
+			*components = &AnyConfig{Object: enforceFIPSTLS((*components).Object)}
+		}
+	}
+}
+
+// enforceFIPSTLS returns a copy of the settings with their tls blocks restricted to the FIPS approved settings.
+func enforceFIPSTLS(settings map[string]interface{}) map[string]interface{} {
+	if settings == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(settings))
+	for key, value := range settings {
+		if tls, ok := value.(map[string]interface{}); ok && key == "tls" {
+			out[key] = fipsTLSSettings(tls)
+			continue
+		}
+		out[key] = enforceFIPSTLSValue(value)
+	}
+	return out
+}
+
+func enforceFIPSTLSValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return enforceFIPSTLS(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = enforceFIPSTLSValue(item)
+		}
+		return out
+	}
+	return value
+}
+
+func fipsTLSSettings(tls map[string]interface{}) map[string]interface{} {
+	out := enforceFIPSTLS(tls)
+	if insecure, ok := out["insecure"].(bool); ok && insecure {
+		return out
+	}
+	if version, _ := out["min_version"].(string); version == "" || version == "1.0" || version == "1.1" {
+		out["min_version"] = fips.MinTLSVersion
+	}
+	if version, _ := out["max_version"].(string); version == "1.0" || version == "1.1" {
+		delete(out, "max_version")
+	}
+	var cipherSuites []interface{}
+	if configured, ok := out["cipher_suites"].([]interface{}); ok {
+		cipherSuites = slices.DeleteFunc(slices.Clone(configured), func(suite interface{}) bool {
+			name, _ := suite.(string)
+			return !fips.IsApprovedCipherSuite(name)
+		})
+	}
+	if len(cipherSuites) == 0 {
+		for _, suite := range fips.TLSCipherSuites {
+			cipherSuites = append(cipherSuites, suite)
+		}
+	}
+	out["cipher_suites"] = cipherSuites
+	return out
+}
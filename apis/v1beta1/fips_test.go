@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnforceFIPSTLS(t *testing.T) {
+	cfg := &Config{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"receivers": {
+			"otlp": {"protocols": {"grpc": {"tls": {"cert_file": "/tls/tls.crt", "key_file": "/tls/tls.key", "min_version": "1.1"}}}}
+		},
+		"exporters": {
+			"otlp": {"endpoint": "backend:4317", "tls": {"min_version": "1.3", "cipher_suites": ["TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"]}},
+			"otlp/insecure": {"endpoint": "local:4317", "tls": {"insecure": true}},
+			"debug": {}
+		},
+		"extensions": {
+			"oauth2client": {"tls": {"max_version": "1.1"}}
+		},
+		"service": {"pipelines": {"traces": {"receivers": ["otlp"], "exporters": ["otlp"]}}}
+	}`), cfg))
+	original, err := cfg.Yaml()
+	require.NoError(t, err)
+	enforced := cfg.DeepCopy()
+
+	enforced.EnforceFIPSTLS()
+
+	approved := []interface{}{
+		"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+		"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+		"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+	}
+	assert.Equal(t, map[string]interface{}{
+		"cert_file":     "/tls/tls.crt",
+		"key_file":      "/tls/tls.key",
+		"min_version":   "1.2",
+		"cipher_suites": approved,
+	}, enforced.Receivers.Object["otlp"].(map[string]interface{})["protocols"].(map[string]interface{})["grpc"].(map[string]interface{})["tls"])
+	assert.Equal(t, map[string]interface{}{
+		"min_version":   "1.3",
+		"cipher_suites": []interface{}{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+	}, enforced.Exporters.Object["otlp"].(map[string]interface{})["tls"])
+	assert.Equal(t, map[string]interface{}{"insecure": true}, enforced.Exporters.Object["otlp/insecure"].(map[string]interface{})["tls"])
+	assert.Equal(t, map[string]interface{}{
+		"min_version":   "1.2",
+		"cipher_suites": approved,
+	}, enforced.Extensions.Object["oauth2client"].(map[string]interface{})["tls"])
+
+	unchanged, err := cfg.Yaml()
+	require.NoError(t, err)
+	assert.Equal(t, original, unchanged, "the original config must not be changed")
+}
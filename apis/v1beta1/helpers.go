@@ -9,10 +9,27 @@ import (
 	"math"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
+// ListenIPFamily returns the IP family of the addresses the defaulted endpoints of the collector listen on: IPv6 when
+// the services of the collector or the pods of the cluster use it, IPv4 otherwise.
+func (s *OpenTelemetryCollectorSpec) ListenIPFamily(clusterIPFamilies []corev1.IPFamily) corev1.IPFamily {
+	if slices.Contains(s.IpFamilies, corev1.IPv6Protocol) || slices.Contains(clusterIPFamilies, corev1.IPv6Protocol) {
+		return corev1.IPv6Protocol
+	}
+	return corev1.IPv4Protocol
+}
+
+// ReloadsConfig returns whether the collector pods reload the configuration changes instead of being restarted.
+func (s *OpenTelemetryCollectorSpec) ReloadsConfig() bool {
+	return s.Mode != ModeSidecar && s.Rollout != nil && s.Rollout.Strategy == RolloutStrategyReload
+}
+
 // parseAddressEndpoint parses the address and returns the host and port.
 // If the address is an environment variable, it returns the default port.
 // If the address is an explicit port, it returns the port.
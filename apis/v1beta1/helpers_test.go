@@ -6,7 +6,9 @@ package v1beta1
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
 )
 
 func TestParseAddressEndpoint(t *testing.T) {
@@ -270,3 +272,31 @@ func TestNormalizeConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestListenIPFamily(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		spec     []v1.IPFamily
+		cluster  []v1.IPFamily
+		expected v1.IPFamily
+	}{
+		{desc: "default", expected: v1.IPv4Protocol},
+		{desc: "ipv4 cluster", cluster: []v1.IPFamily{v1.IPv4Protocol}, expected: v1.IPv4Protocol},
+		{desc: "ipv6 cluster", cluster: []v1.IPFamily{v1.IPv6Protocol}, expected: v1.IPv6Protocol},
+		{desc: "dual-stack cluster", cluster: []v1.IPFamily{v1.IPv4Protocol, v1.IPv6Protocol}, expected: v1.IPv6Protocol},
+		{desc: "dual-stack services", spec: []v1.IPFamily{v1.IPv4Protocol, v1.IPv6Protocol}, expected: v1.IPv6Protocol},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			spec := OpenTelemetryCollectorSpec{OpenTelemetryCommonFields: OpenTelemetryCommonFields{IpFamilies: tt.spec}}
+			assert.Equal(t, tt.expected, spec.ListenIPFamily(tt.cluster))
+		})
+	}
+}
+
+func TestReloadsConfig(t *testing.T) {
+	reload := &Rollout{Strategy: RolloutStrategyReload}
+	assert.True(t, (&OpenTelemetryCollectorSpec{Mode: ModeDeployment, Rollout: reload}).ReloadsConfig())
+	assert.False(t, (&OpenTelemetryCollectorSpec{Mode: ModeSidecar, Rollout: reload}).ReloadsConfig())
+	assert.False(t, (&OpenTelemetryCollectorSpec{Mode: ModeDeployment, Rollout: &Rollout{Strategy: RolloutStrategyRestart}}).ReloadsConfig())
+	assert.False(t, (&OpenTelemetryCollectorSpec{Mode: ModeDeployment}).ReloadsConfig())
+}
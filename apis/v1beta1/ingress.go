@@ -7,7 +7,7 @@ import networkingv1 "k8s.io/api/networking/v1"
 
 type (
 	// IngressType represents how a collector should be exposed (ingress vs route).
-	// +kubebuilder:validation:Enum=ingress;route
+	// +kubebuilder:validation:Enum=ingress;route;route-gateway
 	IngressType string
 )
 
@@ -16,6 +16,8 @@ const (
 	IngressTypeIngress IngressType = "ingress"
 	// IngressTypeRoute IngressTypeOpenshiftRoute specifies that a route should be created.
 	IngressTypeRoute IngressType = "route"
+	// IngressTypeGateway specifies that Gateway API routes (HTTPRoute and GRPCRoute) should be created.
+	IngressTypeGateway IngressType = "route-gateway"
 )
 
 type (
@@ -65,7 +67,7 @@ const (
 // SEE: OpenTelemetryCollector.spec.ports[index].
 type Ingress struct {
 	// Type default value is: ""
-	// Supported types are: ingress, route
+	// Supported types are: ingress, route, route-gateway
 	Type IngressType `json:"type,omitempty"`
 
 	// RuleType defines how Ingress exposes collector receivers.
@@ -97,10 +99,72 @@ type Ingress struct {
 	// type "route" is used.
 	// +optional
 	Route OpenShiftRoute `json:"route,omitempty"`
+
+	// Gateway is a Gateway API specific section that is only considered when
+	// type "route-gateway" is used.
+	// +optional
+	Gateway GatewayRoute `json:"gateway,omitempty"`
 }
 
 // OpenShiftRoute defines openshift route specific settings.
 type OpenShiftRoute struct {
 	// Termination indicates termination type. By default "edge" is used.
 	Termination TLSRouteTerminationType `json:"termination,omitempty"`
+
+	// Ports overrides the settings of the routes of some ports of the collector.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Ports []OpenShiftRoutePort `json:"ports,omitempty"`
+}
+
+// OpenShiftRoutePort defines the settings of the route of a port of the collector.
+type OpenShiftRoutePort struct {
+	// Name of the port, as in the collector Service, e.g. otlp-grpc.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Termination of the route, defaults to the termination of the routes.
+	// +optional
+	Termination TLSRouteTerminationType `json:"termination,omitempty"`
+
+	// Hostname of the route, defaults to the name of the port followed by the hostname of the ingress,
+	// e.g. otlp-grpc.example.com.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// CertificateSecret is the name of a kubernetes.io/tls Secret holding the certificate and key served by the
+	// router, only considered with the edge and reencrypt terminations. The router service account must be allowed
+	// to read it.
+	// +optional
+	CertificateSecret string `json:"certificateSecret,omitempty"`
+
+	// DestinationCACertificate is the PEM encoded CA certificate the router uses to verify the certificate of the
+	// collector, only considered with the reencrypt termination.
+	// +optional
+	DestinationCACertificate string `json:"destinationCACertificate,omitempty"`
+}
+
+// GatewayRoute defines Gateway API route specific settings.
+type GatewayRoute struct {
+	// ParentRefs references the Gateways the generated HTTPRoutes and GRPCRoutes attach to.
+	// +optional
+	// +listType=atomic
+	ParentRefs []GatewayParentReference `json:"parentRefs,omitempty"`
+}
+
+// GatewayParentReference identifies a Gateway the collector routes attach to.
+type GatewayParentReference struct {
+	// Name is the name of the Gateway.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway. When unspecified, the namespace of the collector is used.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the Gateway listener to attach to. When unspecified, the routes
+	// attach to all listeners that allow them.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
 }
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// JobSpec defines the collector Jobs of the job and cronjob modes. A Job runs spec.replicas collector pods, which
+// complete when their collector stops successfully.
+type JobSpec struct {
+	// Duration is how long the collector of a Job pod runs before a job-stopper container asks it to shut down
+	// gracefully, flushing its pipelines. Without it, the collector runs until it stops on its own or the Job is
+	// terminated, for example by the ActiveDeadlineSeconds.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// ActiveDeadlineSeconds is the duration in seconds relative to the startTime that the Job may be active before
+	// the system tries to terminate it.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// BackoffLimit is the number of retries before marking a Job as failed. Defaults to 6.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// TTLSecondsAfterFinished limits the lifetime of the finished Jobs of the cronjob mode. In the job mode, the
+	// finished Job is kept so that the operator doesn't run it again.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// CronJobSpec defines the schedule of the collector Jobs of the cronjob mode.
+type CronJobSpec struct {
+	// Schedule is the schedule of the Jobs, in the Cron format, see https://en.wikipedia.org/wiki/Cron.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// TimeZone is the name of the time zone of the schedule. Defaults to the time zone of the kube-controller-manager.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+	// ConcurrencyPolicy specifies how to treat the concurrent executions of the Jobs: Allow, Forbid or Replace.
+	// Defaults to Allow.
+	// +optional
+	// +kubebuilder:validation:Enum=Allow;Forbid;Replace
+	ConcurrencyPolicy batchv1.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// Suspend tells the controller to suspend the subsequent executions.
+	// +optional
+	Suspend *bool `json:"suspend,omitempty"`
+	// StartingDeadlineSeconds is the deadline in seconds for starting a Job if it misses its scheduled time.
+	// +optional
+	StartingDeadlineSeconds *int64 `json:"startingDeadlineSeconds,omitempty"`
+	// SuccessfulJobsHistoryLimit is the number of successful finished Jobs to keep. Defaults to 3.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	SuccessfulJobsHistoryLimit *int32 `json:"successfulJobsHistoryLimit,omitempty"`
+	// FailedJobsHistoryLimit is the number of failed finished Jobs to keep. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	FailedJobsHistoryLimit *int32 `json:"failedJobsHistoryLimit,omitempty"`
+}
+
+type (
+	// JobPhase is the phase of the collector Job.
+	// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+	JobPhase string
+)
+
+const (
+	// JobPhaseRunning means the Job has not finished yet.
+	JobPhaseRunning JobPhase = "Running"
+	// JobPhaseSucceeded means the Job completed.
+	JobPhaseSucceeded JobPhase = "Succeeded"
+	// JobPhaseFailed means the Job failed.
+	JobPhaseFailed JobPhase = "Failed"
+)
+
+// JobStatus is the status of the collector Job of the job mode, or of the collector CronJob of the cronjob mode.
+type JobStatus struct {
+	// Phase of the Job, in the job mode.
+	// +optional
+	Phase JobPhase `json:"phase,omitempty"`
+	// Active is the number of running collector pods, or of running Jobs in the cronjob mode.
+	// +optional
+	Active int32 `json:"active,omitempty"`
+	// Succeeded is the number of collector pods which completed, in the job mode.
+	// +optional
+	Succeeded int32 `json:"succeeded,omitempty"`
+	// Failed is the number of collector pods which failed, in the job mode.
+	// +optional
+	Failed int32 `json:"failed,omitempty"`
+	// StartTime is when the Job started, in the job mode.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the Job completed, in the job mode.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// LastScheduleTime is when a Job was last scheduled, in the cronjob mode.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastSuccessfulTime is when a Job last completed, in the cronjob mode.
+	// +optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+}
@@ -5,7 +5,7 @@ package v1beta1
 
 type (
 	// Mode represents how the collector should be deployed (deployment vs. daemonset)
-	// +kubebuilder:validation:Enum=daemonset;deployment;sidecar;statefulset
+	// +kubebuilder:validation:Enum=daemonset;deployment;sidecar;statefulset;job;cronjob
 	Mode string
 )
 
@@ -21,4 +21,10 @@ const (
 
 	// ModeStatefulSet specifies that the collector should be deployed as a Kubernetes StatefulSet.
 	ModeStatefulSet Mode = "statefulset"
+
+	// ModeJob specifies that the collector should be deployed as a Kubernetes Job.
+	ModeJob Mode = "job"
+
+	// ModeCronJob specifies that the collector should be deployed as a Kubernetes CronJob.
+	ModeCronJob Mode = "cronjob"
 )
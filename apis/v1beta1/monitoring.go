@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// The scrape settings of the generated ServiceMonitors and PodMonitors mirror the ones of the Prometheus Operator, so
+// that the API doesn't depend on its module.
+
+// PrometheusDuration is a duration of Prometheus, a number and a unit among y, w, d, h, m, s and ms, like 30s or 1h20m.
+// +kubebuilder:validation:Pattern:="^(0|(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+type PrometheusDuration string
+
+// LabelName is the name of a Prometheus label.
+// +kubebuilder:validation:Pattern:="^[a-zA-Z_][a-zA-Z0-9_]*$"
+type LabelName string
+
+// RelabelConfig is a relabeling step of Prometheus, see
+// https://prometheus.io/docs/prometheus/latest/configuration/configuration/#relabel_config.
+type RelabelConfig struct {
+	// SourceLabels select the values of existing labels, concatenated with the separator and matched against the regex.
+	// +optional
+	SourceLabels []LabelName `json:"sourceLabels,omitempty"`
+	// Separator is the string between the concatenated source labels.
+	// +optional
+	Separator *string `json:"separator,omitempty"`
+	// TargetLabel is the label the resulting string is written to in a replacement.
+	// +optional
+	TargetLabel string `json:"targetLabel,omitempty"`
+	// Regex is the regular expression the extracted value is matched against.
+	// +optional
+	Regex string `json:"regex,omitempty"`
+	// Modulus to take of the hash of the source label values, with the HashMod action.
+	// +optional
+	Modulus uint64 `json:"modulus,omitempty"`
+	// Replacement is the value written to the target label when the regex matches, with the Replace action.
+	// +optional
+	Replacement *string `json:"replacement,omitempty"`
+	// Action to perform based on the regex matching.
+	// +kubebuilder:validation:Enum=replace;Replace;keep;Keep;drop;Drop;hashmod;HashMod;labelmap;LabelMap;labeldrop;LabelDrop;labelkeep;LabelKeep;lowercase;Lowercase;uppercase;Uppercase;keepequal;KeepEqual;dropequal;DropEqual
+	// +kubebuilder:default=replace
+	// +optional
+	Action string `json:"action,omitempty"`
+}
+
+// SecretOrConfigMap selects a key of a Secret or of a ConfigMap.
+type SecretOrConfigMap struct {
+	// Secret containing the data.
+	// +optional
+	Secret *v1.SecretKeySelector `json:"secret,omitempty"`
+	// ConfigMap containing the data.
+	// +optional
+	ConfigMap *v1.ConfigMapKeySelector `json:"configMap,omitempty"`
+}
+
+// TLSVersion is a version of TLS.
+// +kubebuilder:validation:Enum=TLS10;TLS11;TLS12;TLS13
+type TLSVersion string
+
+// SafeTLSConfig is the TLS configuration Prometheus scrapes the targets with.
+type SafeTLSConfig struct {
+	// CA is the certificate authority verifying the certificates of the targets.
+	// +optional
+	CA SecretOrConfigMap `json:"ca,omitempty"`
+	// Cert is the client certificate presented to the targets.
+	// +optional
+	Cert SecretOrConfigMap `json:"cert,omitempty"`
+	// KeySecret is the Secret containing the key of the client certificate.
+	// +optional
+	KeySecret *v1.SecretKeySelector `json:"keySecret,omitempty"`
+	// ServerName verifies the hostname of the targets.
+	// +optional
+	ServerName *string `json:"serverName,omitempty"`
+	// InsecureSkipVerify disables the verification of the certificates of the targets.
+	// +optional
+	InsecureSkipVerify *bool `json:"insecureSkipVerify,omitempty"`
+	// MinVersion is the minimum acceptable TLS version.
+	// +optional
+	MinVersion *TLSVersion `json:"minVersion,omitempty"`
+	// MaxVersion is the maximum acceptable TLS version.
+	// +optional
+	MaxVersion *TLSVersion `json:"maxVersion,omitempty"`
+}
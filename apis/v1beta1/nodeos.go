@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type (
+	// NodeOS is an operating system of the nodes running the collector pods.
+	// +kubebuilder:validation:Enum=linux;windows
+	NodeOS string
+)
+
+const (
+	// NodeOSLinux runs the collector on the Linux nodes.
+	NodeOSLinux NodeOS = "linux"
+	// NodeOSWindows runs the collector on the Windows nodes.
+	NodeOSWindows NodeOS = "windows"
+)
+
+// WindowsSpec defines the DaemonSet of the collector on the Windows nodes.
+type WindowsSpec struct {
+	// Image of the collector on the Windows nodes, which must be available for Windows. Defaults to the image of the
+	// collector.
+	// +optional
+	Image string `json:"image,omitempty"`
+	// HostPaths maps the paths of the hostPath volumes, and the paths below them, to their paths on the Windows
+	// nodes. The other paths are put on the C: drive, e.g. /var/log/pods becomes C:\var\log\pods.
+	// +optional
+	HostPaths map[string]string `json:"hostPaths,omitempty"`
+	// SecurityContext defines the Windows options of the collector pods, e.g. to run them as HostProcess containers,
+	// which requires the host network. The Linux options of the security contexts are left out of the pods on the
+	// Windows nodes.
+	// +optional
+	SecurityContext *corev1.WindowsSecurityContextOptions `json:"securityContext,omitempty"`
+}
+
+// RunsOn returns whether the collector runs on the nodes of the given operating system.
+func (s *OpenTelemetryCollectorSpec) RunsOn(os NodeOS) bool {
+	if len(s.NodeOS) == 0 {
+		return os == NodeOSLinux
+	}
+	return slices.Contains(s.NodeOS, os)
+}
@@ -63,6 +63,53 @@ type OpenTelemetryCollectorStatus struct {
 	// Image indicates the container image to use for the OpenTelemetry Collector.
 	// +optional
 	Image string `json:"image,omitempty"`
+
+	// ImageVersion is the version of the collector, detected from the tag of its image.
+	// +optional
+	ImageVersion string `json:"imageVersion,omitempty"`
+
+	// ConfigHash is the sha256 hash of the collector configuration applied by the operator, which the collector pods
+	// carry in their opentelemetry-operator-config/sha256 annotation unless they reload their configuration.
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
+
+	// ConfigConflicts lists the configuration paths where merging the config sources and the inline config
+	// replaced a map with another kind of value, or the other way around.
+	// +optional
+	// +listType=atomic
+	ConfigConflicts []string `json:"configConflicts,omitempty"`
+
+	// MissingPermissions lists the RBAC rules the components of the configuration need and the service account of the
+	// collector misses, when the operator can't create them.
+	// +optional
+	// +listType=atomic
+	MissingPermissions []string `json:"missingPermissions,omitempty"`
+
+	// ConfigMigrations lists the changes the upgrades of the operator made to the configuration, to replace the
+	// components and settings the collector versions it upgraded to no longer accept.
+	// +optional
+	// +listType=atomic
+	ConfigMigrations []ConfigMigration `json:"configMigrations,omitempty"`
+
+	// Rollout is the status of the canary or partitioned rollout of the configuration.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// Job is the status of the collector Job, or CronJob, in the job and cronjob modes.
+	// +optional
+	Job *JobStatus `json:"job,omitempty"`
+
+	// ObservedGeneration is the generation of the OpenTelemetryCollector the status was last computed from.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions of the collector: Ready, summing up the others, ConfigValid, RolloutComplete, outside of the
+	// sidecar and job modes, ExporterHealthy, when the health_check extension is enabled, and FIPSCompliant, when the
+	// operator.fips.enforce feature gate is enabled.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="!(self.mode == 'sidecar' && size(self.tolerations) > 0) || !has(self.tolerations)",message="the OpenTelemetry Collector mode is set to sidecar, which does not support the attribute 'tolerations'"
@@ -83,7 +130,8 @@ type OpenTelemetryCollectorSpec struct {
 	// TargetAllocator indicates a value which determines whether to spawn a target allocation resource or not.
 	// +optional
 	TargetAllocator TargetAllocatorEmbedded `json:"targetAllocator,omitempty"`
-	// Mode represents how the collector should be deployed (deployment, daemonset, statefulset or sidecar)
+	// Mode represents how the collector should be deployed (deployment, daemonset, statefulset, sidecar, job or
+	// cronjob)
 	// +optional
 	Mode Mode `json:"mode,omitempty"`
 	// UpgradeStrategy represents how the operator will handle upgrades to the CR when a newer version of the operator is deployed
@@ -100,17 +148,92 @@ type OpenTelemetryCollectorSpec struct {
 	// +kubebuilder:default:=3
 	// +kubebuilder:validation:Minimum:=1
 	ConfigVersions int `json:"configVersions,omitempty"`
+	// ConfigSources is an ordered list of ConfigMap and Secret keys holding parts of the collector configuration.
+	// The operator deep-merges the ConfigMaps in order, then Config on top, the later ones overriding the earlier ones:
+	// maps are merged key by key, any other value, lists included, is replaced. The operator never reads the Secrets,
+	// they're passed to the collector through environment variables, and the collector merges them in order under the
+	// configuration rendered by the operator.
+	// +optional
+	// +listType=atomic
+	ConfigSources []ConfigSource `json:"configSources,omitempty"`
+	// ConfigEnvFrom lists the Secrets and ConfigMaps setting the environment variables the collector configuration
+	// references as ${env:VAR}. They are added to the envFrom of the collector container and, when the list isn't
+	// empty, the webhook rejects configurations referencing variables without a default value which aren't set by
+	// them, EnvFrom, Env or the operator.
+	// +optional
+	// +listType=atomic
+	ConfigEnvFrom []v1.EnvFromSource `json:"configEnvFrom,omitempty"`
+	// Rollout defines how configuration changes are rolled out to the collector pods.
+	// +optional
+	Rollout *Rollout `json:"rollout,omitempty"`
+	// Persistence creates a persistent volume for each collector pod and mounts it in the collector container.
+	// This only works with the following OpenTelemetryCollector mode's: statefulset.
+	// +optional
+	Persistence *Persistence `json:"persistence,omitempty"`
+	// Enrichment adds processors enriching the telemetry with the attributes of its Kubernetes and cloud resources to
+	// the collector configuration.
+	// This only works with the following OpenTelemetryCollector mode's: deployment, daemonset, statefulset, job and cronjob.
+	// +optional
+	Enrichment Enrichment `json:"enrichment,omitempty"`
+	// Presets add the receivers of node-level telemetry to the collector configuration, along with the host volumes,
+	// tolerations and RBAC permissions they need.
+	// This only works with the following OpenTelemetryCollector mode's: daemonset.
+	// +optional
+	Presets Presets `json:"presets,omitempty"`
+	// Job defines the collector Jobs.
+	// This only works with the following OpenTelemetryCollector mode's: job and cronjob.
+	// +optional
+	Job *JobSpec `json:"job,omitempty"`
+	// CronJob defines the schedule of the collector Jobs.
+	// This only works with the following OpenTelemetryCollector mode's: cronjob, where it is required.
+	// +optional
+	CronJob *CronJobSpec `json:"cronJob,omitempty"`
+	// NodeOS lists the operating systems of the nodes running the collector pods. A DaemonSet runs the collector on
+	// the Linux nodes, and a second one, named <name>-collector-windows, on the Windows nodes. Defaults to linux,
+	// without restricting the pods to the Linux nodes.
+	// This only works with the following OpenTelemetryCollector mode's: daemonset.
+	// +optional
+	// +listType=set
+	NodeOS []NodeOS `json:"nodeOS,omitempty"`
+	// Windows defines the DaemonSet of the collector on the Windows nodes, when windows is one of the NodeOS.
+	// +optional
+	Windows *WindowsSpec `json:"windows,omitempty"`
+	// NetworkPolicy defines the NetworkPolicy restricting the ingress traffic of the collector pods.
+	// This only works with the following OpenTelemetryCollector mode's: deployment, daemonset, statefulset, job and cronjob.
+	// +optional
+	NetworkPolicy NetworkPolicy `json:"networkPolicy,omitempty"`
+	// Services defines additional Services exposing a subset of the collector ports, each with its own type and
+	// annotations, e.g. a LoadBalancer Service for the OTLP receivers. The ClusterIP Service exposing all the ports
+	// is still created.
+	// This only works with the following OpenTelemetryCollector mode's: deployment, daemonset, statefulset, job and cronjob.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Services []CollectorService `json:"services,omitempty"`
+	// TopologyAwareRouting defines how the clients of the collector Services, like the agents sending OTLP data to a
+	// gateway, are routed to the collector pods.
+	// This only works with the following OpenTelemetryCollector mode's: deployment, daemonset, statefulset, job and cronjob.
+	// +optional
+	TopologyAwareRouting TopologyAwareRouting `json:"topologyAwareRouting,omitempty"`
+	// AdditionalContainersPosition defines whether the additional containers come before (the default) or after
+	// the collector container in the pods, which is the order in which the kubelet starts them. The additional and
+	// init containers can mount the volumes of the pod by name, including the ones of the operator: otc-internal
+	// for the configuration of the collector, configmap-<name> for the ConfigMaps, otc-persistence for the
+	// persistent volume and <name>-ta-client-cert for the target allocator client certificate.
+	// This only works with the following OpenTelemetryCollector mode's: deployment, daemonset, statefulset, job and cronjob.
+	// +optional
+	AdditionalContainersPosition ContainerPosition `json:"additionalContainersPosition,omitempty"`
 	// Ingress is used to specify how OpenTelemetry Collector is exposed. This
 	// functionality is only available if one of the valid modes is set.
 	// Valid modes are: deployment, daemonset and statefulset.
 	// +optional
 	Ingress Ingress `json:"ingress,omitempty"`
 	// Liveness config for the OpenTelemetry Collector except the probe handler which is auto generated from the health extension of the collector.
-	// It is only effective when healthcheckextension is configured in the OpenTelemetry Collector pipeline.
+	// It is only effective when healthcheckextension is configured in the OpenTelemetry Collector pipeline, or with the receiverPort handler.
 	// +optional
 	LivenessProbe *Probe `json:"livenessProbe,omitempty"`
 	// Readiness config for the OpenTelemetry Collector except the probe handler which is auto generated from the health extension of the collector.
-	// It is only effective when healthcheckextension is configured in the OpenTelemetry Collector pipeline.
+	// It is only effective when healthcheckextension is configured in the OpenTelemetry Collector pipeline, or with the receiverPort handler.
 	// +optional
 	ReadinessProbe *Probe `json:"readinessProbe,omitempty"`
 
@@ -152,12 +275,15 @@ type TargetAllocatorEmbedded struct {
 	// +optional
 	Resources v1.ResourceRequirements `json:"resources,omitempty"`
 	// AllocationStrategy determines which strategy the target allocator should use for allocation.
-	// The current options are least-weighted, consistent-hashing and per-node. The default is
+	// The current options are least-weighted, consistent-hashing, per-node and zone-aware. The default is
 	// consistent-hashing.
 	// WARNING: The per-node strategy currently ignores targets without a Node, like control plane components.
 	// +optional
 	// +kubebuilder:default:=consistent-hashing
 	AllocationStrategy TargetAllocatorAllocationStrategy `json:"allocationStrategy,omitempty"`
+	// ConsistentHashing tunes the hash ring of the consistent-hashing and zone-aware allocation strategies.
+	// +optional
+	ConsistentHashing TargetAllocatorConsistentHashing `json:"consistentHashing,omitempty"`
 	// FilterStrategy determines how to filter targets before allocating them among the collectors.
 	// The only current option is relabel-config (drops targets based on prom relabel_config).
 	// The default is relabel-config.
@@ -216,12 +342,19 @@ type TargetAllocatorEmbedded struct {
 	// +optional
 	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 	// CollectorNotReadyGracePeriod defines the grace period after which a TargetAllocator stops considering a collector is target assignable.
-	// The default is 30s, which means that if a collector becomes not Ready, the target allocator will wait for 30 seconds before reassigning its targets. The assumption is that the state is temporary, and an expensive target reallocation should be avoided if possible.
+	// The default is 30s, which means that if a collector becomes not Ready, the target allocator will wait for 30 seconds before reassigning its targets. The assumption is that the state is temporary, and an expensive target reallocation should be avoided if possible. New collectors are only assigned targets once they become Ready.
 	//
 	// +optional
 	// +kubebuilder:default:="30s"
 	// +kubebuilder:validation:Format:=duration
 	CollectorNotReadyGracePeriod *metav1.Duration `json:"collectorNotReadyGracePeriod,omitempty"`
+	// CollectorDrainTimeout defines how long a collector being deleted keeps its targets, while no new targets are assigned to it.
+	// Its targets are reassigned once the timeout expires, before the collector terminates. The default is 0s, which means that
+	// the targets of a collector being deleted are reassigned right away.
+	//
+	// +optional
+	// +kubebuilder:validation:Format:=duration
+	CollectorDrainTimeout *metav1.Duration `json:"collectorDrainTimeout,omitempty"`
 }
 
 // Probe defines the OpenTelemetry's pod probe config.
@@ -260,8 +393,30 @@ type Probe struct {
 	// Minimum value is 1. spec.terminationGracePeriodSeconds is used if unset.
 	// +optional
 	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+	// Handler is what the probe checks: the endpoint of the health_check extension, the default, or a TCP
+	// connection to the port of a receiver, so a collector failing to bind the receiver isn't ready, or alive,
+	// even without the health_check extension.
+	// +optional
+	Handler ProbeHandler `json:"handler,omitempty"`
+	// Receiver is the receiver whose port the receiverPort handler checks, by default the first receiver, by name,
+	// with a TCP port.
+	// +optional
+	Receiver string `json:"receiver,omitempty"`
 }
 
+// ProbeHandler defines what the probes of the collector check.
+//
+// +kubebuilder:validation:Enum=healthCheck;receiverPort
+type ProbeHandler string
+
+const (
+	// ProbeHandlerHealthCheck checks the endpoint of the health_check extension.
+	ProbeHandlerHealthCheck ProbeHandler = "healthCheck"
+
+	// ProbeHandlerReceiverPort checks a TCP connection to the port of a receiver.
+	ProbeHandlerReceiverPort ProbeHandler = "receiverPort"
+)
+
 // ObservabilitySpec defines how telemetry data gets handled.
 type ObservabilitySpec struct {
 	// Metrics defines the metrics configuration for operands.
@@ -287,6 +442,107 @@ type MetricsConfigSpec struct {
 	// +optional
 	// +kubebuilder:validation:Optional
 	DisablePrometheusAnnotations bool `json:"disablePrometheusAnnotations,omitempty"`
+	// Interval at which the generated ServiceMonitors and PodMonitor scrape the metrics.
+	// The default interval of Prometheus is used when unset.
+	//
+	// +optional
+	Interval PrometheusDuration `json:"interval,omitempty"`
+	// MetricRelabelings are applied by the generated ServiceMonitors and PodMonitor to the scraped samples before ingestion.
+	//
+	// +optional
+	// +listType=atomic
+	MetricRelabelings []RelabelConfig `json:"metricRelabelings,omitempty"`
+	// TLSConfig makes the generated ServiceMonitors and PodMonitor scrape the metrics port of the operand over HTTPS with
+	// this configuration. The ports of the prometheus exporters of the collector are still scraped over HTTP.
+	//
+	// +optional
+	TLSConfig *SafeTLSConfig `json:"tlsConfig,omitempty"`
+	// EnableAlerts creates a PrometheusRule with the recommended alerts on the metrics of the OpenTelemetry Collector:
+	// exporter queues filling up, exporters failing to send spans and receivers refusing spans.
+	// It requires enableMetrics, and isn't supported by the TargetAllocator.
+	//
+	// +optional
+	EnableAlerts bool `json:"enableAlerts,omitempty"`
+}
+
+// NetworkPolicy defines the NetworkPolicy of the collector pods.
+type NetworkPolicy struct {
+	// Enabled creates a NetworkPolicy allowing the ingress traffic of the collector pods only on the ports of their
+	// container: the ports of the receivers, exporters and extensions parsed from the configuration, the metrics port
+	// of the collector and the ports of the spec. The egress traffic isn't restricted.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// ContainerPosition defines the position of containers relative to the collector container.
+//
+// +kubebuilder:validation:Enum=Before;After
+type ContainerPosition string
+
+const (
+	// ContainerPositionBefore places the containers before the collector container, so they're started first.
+	ContainerPositionBefore ContainerPosition = "Before"
+
+	// ContainerPositionAfter places the containers after the collector container, so they're started after the
+	// collector container.
+	ContainerPositionAfter ContainerPosition = "After"
+)
+
+// CollectorService defines an additional Service of the collector, exposing the ports of some receivers and the
+// ports with the given names.
+type CollectorService struct {
+	// Name of the Service, appended to the name of the collector Service, e.g. my-collector-collector-otlp for
+	// the otlp Service of the my-collector collector.
+	// +required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=30
+	Name string `json:"name"`
+	// Type of the Service, defaults to ClusterIP.
+	// +optional
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	Type v1.ServiceType `json:"type,omitempty"`
+	// Receivers lists the receivers of the configuration whose ports are exposed by the Service.
+	// +optional
+	// +listType=atomic
+	Receivers []string `json:"receivers,omitempty"`
+	// Ports lists the names of the ports exposed by the Service, among the ports of the spec and the ones parsed
+	// from the receivers and exporters of the configuration.
+	// +optional
+	// +listType=atomic
+	Ports []string `json:"ports,omitempty"`
+	// Annotations to add to the Service, on top of the ones of the collector.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// LoadBalancerSourceRanges restricts the client IPs allowed by the load balancer of a LoadBalancer Service.
+	// +optional
+	// +listType=atomic
+	LoadBalancerSourceRanges []string `json:"loadBalancerSourceRanges,omitempty"`
+}
+
+// TopologyAwareRouting defines how the clients of the collector Services are routed to the collector pods.
+type TopologyAwareRouting struct {
+	// Enabled sets the trafficDistribution of the collector Service and of the additional Services to PreferClose,
+	// which routes the clients to the collector pods of their zone when there are some, and adds the otlp-headless
+	// Service, e.g. my-collector-collector-otlp-headless, exposing the ports of the otlp receivers to the clients
+	// balancing the load themselves, like the loadbalancing exporter. The trafficDistribution requires Kubernetes 1.31.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+	// InternalTrafficPolicy of the collector Service and of the additional Services, Local only routing the clients
+	// to the collector pods of their node. Defaults to Local in the daemonset mode, and to Cluster otherwise.
+	// +optional
+	// +kubebuilder:validation:Enum=Cluster;Local
+	InternalTrafficPolicy v1.ServiceInternalTrafficPolicy `json:"internalTrafficPolicy,omitempty"`
+}
+
+// ConfigMigration is a change of the configuration made by an upgrade of the collector.
+type ConfigMigration struct {
+	// Version is the collector version the configuration was migrated to.
+	// +required
+	Version string `json:"version"`
+
+	// Description of the change.
+	// +required
+	Description string `json:"description"`
 }
 
 // ScaleSubresourceStatus defines the observed state of the OpenTelemetryCollector's
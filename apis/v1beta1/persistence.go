@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"maps"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// FileStorageExtension is the file_storage extension the operator adds for the persistence of the collector.
+const FileStorageExtension = "file_storage/persistence"
+
+// Persistence defines a persistent volume for each collector pod.
+type Persistence struct {
+	// Size of the persistent volume of each pod.
+	// +optional
+	// +kubebuilder:default:="1Gi"
+	Size resource.Quantity `json:"size,omitempty"`
+	// StorageClassName of the persistent volume claims. The default storage class is used when unset.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// MountPath of the persistent volume in the collector container.
+	// +optional
+	// +kubebuilder:default:="/var/lib/otelcol"
+	MountPath string `json:"mountPath,omitempty"`
+	// FileStorage adds a file_storage/persistence extension storing its files in the persistent volume to the
+	// collector configuration, and makes the exporters with a sending_queue keep their queue in it, so that the
+	// queued data survives the restarts of the pods.
+	// +optional
+	FileStorage bool `json:"fileStorage,omitempty"`
+}
+
+// AddFileStorage adds the FileStorageExtension, storing its files in the given directory, to the config and makes the
+// exporters with a sending_queue without storage use it. The components of the config are copied before being changed.
+func (c *Config) AddFileStorage(directory string) {
+	extensions := c.Extensions.DeepCopy()
+	if extensions == nil {
+		extensions = &AnyConfig{}
+	}
+	if extensions.Object == nil {
+		extensions.Object = map[string]interface{}{}
+	}
+	if _, ok := extensions.Object[FileStorageExtension]; !ok {
+		extensions.Object[FileStorageExtension] = map[string]interface{}{"directory": directory}
+	}
+	c.Extensions = extensions
+	if !slices.Contains(c.Service.Extensions, FileStorageExtension) {
+		c.Service.Extensions = append(slices.Clone(c.Service.Extensions), FileStorageExtension)
+	}
+
+	exporters := c.Exporters.DeepCopy()
+	for name, exporter := range exporters.Object {
+		exporterCfg, ok := exporter.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		queue, ok := exporterCfg["sending_queue"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if _, ok = queue["storage"]; ok {
+			continue
+		}
+		queue = maps.Clone(queue)
+		queue["storage"] = FileStorageExtension
+		exporterCfg = maps.Clone(exporterCfg)
+		exporterCfg["sending_queue"] = queue
+		exporters.Object[name] = exporterCfg
+	}
+	c.Exporters = *exporters
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_AddFileStorage(t *testing.T) {
+	queue := map[string]interface{}{"enabled": true}
+	cfg := Config{
+		Exporters: AnyConfig{Object: map[string]interface{}{
+			"debug":         nil,
+			"otlp":          map[string]interface{}{"endpoint": "backend:4317", "sending_queue": queue},
+			"otlp/redis":    map[string]interface{}{"sending_queue": map[string]interface{}{"storage": "redis_storage"}},
+			"otlp/no-queue": map[string]interface{}{"endpoint": "backend:4317"},
+		}},
+		Extensions: &AnyConfig{Object: map[string]interface{}{"health_check": map[string]interface{}{}}},
+		Service: Service{
+			Extensions: []string{"health_check"},
+		},
+	}
+	original := cfg.DeepCopy()
+
+	cfg.AddFileStorage("/var/lib/otelcol")
+
+	assert.Equal(t, map[string]interface{}{
+		"health_check":             map[string]interface{}{},
+		"file_storage/persistence": map[string]interface{}{"directory": "/var/lib/otelcol"},
+	}, cfg.Extensions.Object)
+	assert.Equal(t, []string{"health_check", "file_storage/persistence"}, cfg.Service.Extensions)
+	assert.Equal(t, map[string]interface{}{
+		"debug":         nil,
+		"otlp":          map[string]interface{}{"endpoint": "backend:4317", "sending_queue": map[string]interface{}{"enabled": true, "storage": "file_storage/persistence"}},
+		"otlp/redis":    map[string]interface{}{"sending_queue": map[string]interface{}{"storage": "redis_storage"}},
+		"otlp/no-queue": map[string]interface{}{"endpoint": "backend:4317"},
+	}, cfg.Exporters.Object)
+
+	// the original config is left untouched
+	assert.Equal(t, map[string]interface{}{"enabled": true}, queue)
+	assert.Len(t, original.Extensions.Object, 1)
+	assert.Equal(t, []string{"health_check"}, original.Service.Extensions)
+
+	// adding it again changes nothing
+	added := cfg.DeepCopy()
+	cfg.AddFileStorage("/var/lib/otelcol")
+	assert.Equal(t, added, &cfg)
+}
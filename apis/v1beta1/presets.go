@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+)
+
+const (
+	// PresetHostMetricsReceiver is the hostmetrics receiver the operator adds for the hostMetrics preset.
+	PresetHostMetricsReceiver = "hostmetrics/preset"
+	// PresetKubeletMetricsReceiver is the kubeletstats receiver the operator adds for the kubeletMetrics preset.
+	PresetKubeletMetricsReceiver = "kubeletstats/preset"
+	// PresetLogsCollectionReceiver is the filelog receiver the operator adds for the logsCollection preset.
+	PresetLogsCollectionReceiver = "filelog/preset"
+	// PresetHostFSMountPath is where the hostMetrics preset mounts the file system of the node in the collector
+	// container.
+	PresetHostFSMountPath = "/hostfs"
+	// PresetPodLogsPath is the directory of the pod logs on the nodes, mounted at the same path in the collector
+	// container by the logsCollection preset.
+	PresetPodLogsPath = "/var/log/pods"
+	// PresetContainerLogsPath is the directory of the Docker container logs on the nodes, which the pod logs may link
+	// to, mounted at the same path in the collector container by the logsCollection preset.
+	PresetContainerLogsPath = "/var/lib/docker/containers"
+)
+
+// Presets defines the node-level telemetry the operator adds to the configuration of the daemonset collectors, along
+// with the host volumes, tolerations and RBAC permissions it needs.
+type Presets struct {
+	// HostMetrics adds a hostmetrics/preset receiver, scraping the CPU, load, memory, disk, file system and network
+	// metrics of the node from its file system mounted at /hostfs, to the metrics pipelines.
+	// +optional
+	HostMetrics Preset `json:"hostMetrics,omitempty"`
+	// KubeletMetrics adds a kubeletstats/preset receiver, scraping the node, pod and container metrics of the kubelet
+	// of the node with the service account of the collector, to the metrics pipelines.
+	// +optional
+	KubeletMetrics Preset `json:"kubeletMetrics,omitempty"`
+	// LogsCollection adds a filelog/preset receiver, reading the logs of the containers of the node from
+	// /var/log/pods, to the logs pipelines.
+	// +optional
+	LogsCollection LogsCollectionPreset `json:"logsCollection,omitempty"`
+}
+
+// Preset enables a preset.
+type Preset struct {
+	// Enabled adds the receiver of the preset to the pipelines of its signal which don't have a receiver of its type.
+	// The receivers already defined with the name of the preset are kept. The receivers are only added to the
+	// configuration rendered by the operator.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// LogsCollectionPreset enables the logsCollection preset.
+type LogsCollectionPreset struct {
+	Preset `json:",inline"`
+	// IncludeCollectorLogs also reads the logs of the collector itself, which are left out by default as exporting
+	// them to the console may loop.
+	// +optional
+	IncludeCollectorLogs bool `json:"includeCollectorLogs,omitempty"`
+}
+
+// Enabled tells whether any preset is enabled.
+func (p Presets) Enabled() bool {
+	return p.HostMetrics.Enabled || p.KubeletMetrics.Enabled || p.LogsCollection.Enabled
+}
+
+// AddPresets adds the receivers of the enabled presets to the pipelines of their signal without receivers of their
+// types. The collectorLogs path pattern of the logs of the collector containers is left out of the logsCollection
+// preset, unless it includes them. The components of the config are copied before being changed.
+func (c *Config) AddPresets(presets Presets, collectorLogs string) {
+	if presets.HostMetrics.Enabled {
+		c.addPresetReceiver("metrics", PresetHostMetricsReceiver, hostMetricsPreset())
+	}
+	if presets.KubeletMetrics.Enabled {
+		c.addPresetReceiver("metrics", PresetKubeletMetricsReceiver, map[string]interface{}{
+			"collection_interval": "20s",
+			"auth_type":           "serviceAccount",
+			"endpoint":            "${env:" + EnrichmentNodeNameEnvVar + "}:10250",
+		})
+	}
+	if presets.LogsCollection.Enabled {
+		filelog := map[string]interface{}{
+			"include":           []interface{}{PresetPodLogsPath + "/*/*/*.log"},
+			"start_at":          "end",
+			"include_file_path": true,
+			"include_file_name": false,
+			"operators": []interface{}{
+				map[string]interface{}{"type": "container", "id": "container-parser"},
+			},
+		}
+		if !presets.LogsCollection.IncludeCollectorLogs {
+			filelog["exclude"] = []interface{}{collectorLogs}
+		}
+		c.addPresetReceiver("logs", PresetLogsCollectionReceiver, filelog)
+	}
+}
+
+// hostMetricsPreset is the configuration of the hostmetrics receiver of the hostMetrics preset, leaving out the
+// virtual and container file systems.
+func hostMetricsPreset() map[string]interface{} {
+	return map[string]interface{}{
+		"root_path":           PresetHostFSMountPath,
+		"collection_interval": "10s",
+		"scrapers": map[string]interface{}{
+			"cpu":    map[string]interface{}{},
+			"load":   map[string]interface{}{},
+			"memory": map[string]interface{}{},
+			"disk":   map[string]interface{}{},
+			"filesystem": map[string]interface{}{
+				"exclude_mount_points": map[string]interface{}{
+					"mount_points": []interface{}{"/dev/*", "/proc/*", "/sys/*", "/run/k3s/containerd/*", "/var/lib/docker/*", "/var/lib/kubelet/*", "/snap/*"},
+					"match_type":   "regexp",
+				},
+				"exclude_fs_types": map[string]interface{}{
+					"fs_types": []interface{}{
+						"autofs", "binfmt_misc", "bpf", "cgroup2", "configfs", "debugfs", "devpts", "devtmpfs", "fusectl",
+						"hugetlbfs", "iso9660", "mqueue", "nsfs", "overlay", "proc", "procfs", "pstore", "rpc_pipefs",
+						"securityfs", "selinuxfs", "squashfs", "sysfs", "tracefs",
+					},
+					"match_type": "strict",
+				},
+			},
+			"network": map[string]interface{}{},
+		},
+	}
+}
+
+// addPresetReceiver adds the given receiver to the pipelines of the given signal without a receiver of its type, and
+// defines it with the given config unless it is already defined.
+func (c *Config) addPresetReceiver(signal, receiver string, config map[string]interface{}) {
+	added := false
+	pipelines := make(map[string]*Pipeline, len(c.Service.Pipelines))
+	for name, pipeline := range c.Service.Pipelines {
+		pipelines[name] = pipeline
+		if pipeline == nil {
+			continue
+		}
+		if pipelineSignal, _, _ := strings.Cut(name, "/"); pipelineSignal != signal {
+			continue
+		}
+		if slices.ContainsFunc(pipeline.Receivers, func(r string) bool {
+			return components.ComponentType(r) == components.ComponentType(receiver)
+		}) {
+			continue
+		}
+		copied := *pipeline
+		copied.Receivers = append(slices.Clone(pipeline.Receivers), receiver)
+		pipelines[name] = &copied
+		added = true
+	}
+	if !added {
+		return
+	}
+	c.Service.Pipelines = pipelines
+
+	receivers := c.Receivers.DeepCopy()
+	if receivers.Object == nil {
+		receivers.Object = map[string]interface{}{}
+	}
+	if _, defined := receivers.Object[receiver]; !defined {
+		receivers.Object[receiver] = config
+	}
+	c.Receivers = *receivers
+}
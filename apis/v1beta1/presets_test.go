@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_AddPresets(t *testing.T) {
+	metrics := &Pipeline{Receivers: []string{"otlp"}, Exporters: []string{"debug"}}
+	cfg := Config{
+		Receivers: AnyConfig{Object: map[string]interface{}{
+			"otlp":                 map[string]interface{}{},
+			"kubeletstats/mine":    map[string]interface{}{"auth_type": "none"},
+			"hostmetrics/preset":   map[string]interface{}{"scrapers": map[string]interface{}{"cpu": map[string]interface{}{}}},
+			"filelog/unreferenced": map[string]interface{}{},
+		}},
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"metrics":      metrics,
+				"metrics/mine": {Receivers: []string{"kubeletstats/mine"}, Exporters: []string{"debug"}},
+				"logs":         {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+				"traces":       {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+			},
+		},
+	}
+	receivers := cfg.Receivers
+
+	presets := Presets{
+		HostMetrics:    Preset{Enabled: true},
+		KubeletMetrics: Preset{Enabled: true},
+		LogsCollection: LogsCollectionPreset{Preset: Preset{Enabled: true}},
+	}
+	cfg.AddPresets(presets, "/var/log/pods/default_test-collector-*_*/otc-container/*.log")
+
+	assert.Equal(t, []string{"otlp", PresetHostMetricsReceiver, PresetKubeletMetricsReceiver}, cfg.Service.Pipelines["metrics"].Receivers)
+	assert.Equal(t, []string{"kubeletstats/mine", PresetHostMetricsReceiver}, cfg.Service.Pipelines["metrics/mine"].Receivers)
+	assert.Equal(t, []string{"otlp", PresetLogsCollectionReceiver}, cfg.Service.Pipelines["logs"].Receivers)
+	assert.Equal(t, []string{"otlp"}, cfg.Service.Pipelines["traces"].Receivers)
+	// the receivers already defined are kept
+	assert.Equal(t, map[string]interface{}{"scrapers": map[string]interface{}{"cpu": map[string]interface{}{}}}, cfg.Receivers.Object[PresetHostMetricsReceiver])
+	assert.Equal(t, map[string]interface{}{
+		"collection_interval": "20s",
+		"auth_type":           "serviceAccount",
+		"endpoint":            "${env:K8S_NODE_NAME}:10250",
+	}, cfg.Receivers.Object[PresetKubeletMetricsReceiver])
+	filelog := cfg.Receivers.Object[PresetLogsCollectionReceiver].(map[string]interface{})
+	assert.Equal(t, []interface{}{"/var/log/pods/*/*/*.log"}, filelog["include"])
+	assert.Equal(t, []interface{}{"/var/log/pods/default_test-collector-*_*/otc-container/*.log"}, filelog["exclude"])
+
+	// the original config is left untouched
+	assert.Equal(t, []string{"otlp"}, metrics.Receivers)
+	assert.Len(t, receivers.Object, 4)
+
+	// adding them again changes nothing
+	added := cfg.DeepCopy()
+	cfg.AddPresets(presets, "/var/log/pods/default_test-collector-*_*/otc-container/*.log")
+	assert.Equal(t, added, &cfg)
+}
+
+func TestConfig_AddPresetsCollectorLogs(t *testing.T) {
+	cfg := Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"logs": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+			},
+		},
+	}
+
+	cfg.AddPresets(Presets{LogsCollection: LogsCollectionPreset{Preset: Preset{Enabled: true}, IncludeCollectorLogs: true}}, "/var/log/pods/x/*.log")
+
+	filelog := cfg.Receivers.Object[PresetLogsCollectionReceiver].(map[string]interface{})
+	assert.NotContains(t, filelog, "exclude")
+}
+
+func TestConfig_AddPresetsWithoutPipeline(t *testing.T) {
+	cfg := Config{
+		Service: Service{
+			Pipelines: map[string]*Pipeline{
+				"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+			},
+		},
+	}
+
+	cfg.AddPresets(Presets{HostMetrics: Preset{Enabled: true}}, "")
+
+	assert.Nil(t, cfg.Receivers.Object)
+}
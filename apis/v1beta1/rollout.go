@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Rollout defines how configuration changes are rolled out to the collector pods.
+type Rollout struct {
+	// Strategy defines whether a configuration change restarts the collector pods (Restart, the default) or is
+	// reloaded by the running collectors (Reload). With Reload, the pods mount a ConfigMap updated in place and a
+	// config-reloader container sends SIGHUP to the collector when the configuration file changes, so that the
+	// pipelines don't stop. Changes of the ports of the collector still restart the pods.
+	// Not supported in the sidecar mode and with a canary or partitioned rollout.
+	// +optional
+	Strategy RolloutStrategy `json:"strategy,omitempty"`
+	// Canary rolls a configuration change out to a small canary Deployment first. The collector Deployment keeps
+	// running the previous configuration until the canary pods have been healthy for the stabilization period.
+	// Only supported in the deployment mode.
+	// +optional
+	Canary *CanaryRollout `json:"canary,omitempty"`
+	// Partitioned rolls a configuration change out to the collector StatefulSet one pod, so one shard of the
+	// target allocator, at a time, from the highest ordinal, through the partition of its rolling update. The
+	// StatefulSet is rolled back to its previous revision when an updated pod restarts too often.
+	// Only supported in the statefulset mode.
+	// +optional
+	Partitioned *PartitionedRollout `json:"partitioned,omitempty"`
+}
+
+// RolloutStrategy defines how configuration changes are applied to the collector pods.
+//
+// +kubebuilder:validation:Enum=Restart;Reload
+type RolloutStrategy string
+
+const (
+	// RolloutStrategyRestart rolls the collector pods out with the new configuration.
+	RolloutStrategyRestart RolloutStrategy = "Restart"
+
+	// RolloutStrategyReload makes the running collectors reload the new configuration.
+	RolloutStrategyReload RolloutStrategy = "Reload"
+)
+
+// CanaryRollout defines a canary rollout of the configuration changes.
+type CanaryRollout struct {
+	// Replicas is the number of canary pods running the new configuration.
+	// +optional
+	// +kubebuilder:default:=1
+	// +kubebuilder:validation:Minimum:=1
+	Replicas *int32 `json:"replicas,omitempty"`
+	// StabilizationPeriod is how long every canary pod must stay ready before the change is rolled out to the
+	// collector Deployment.
+	// +optional
+	// +kubebuilder:default:="5m"
+	StabilizationPeriod metav1.Duration `json:"stabilizationPeriod,omitempty"`
+	// MaxRestarts is the number of container restarts of a canary pod above which the canary fails. A failed
+	// canary is kept for inspection and the collector Deployment keeps running the previous configuration until
+	// the configuration changes again.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	MaxRestarts int32 `json:"maxRestarts,omitempty"`
+}
+
+// PartitionedRollout defines a partitioned rollout of the configuration changes to the collector StatefulSet.
+type PartitionedRollout struct {
+	// StabilizationPeriod is how long every updated pod must stay ready before the next pod is updated.
+	// +optional
+	// +kubebuilder:default:="5m"
+	StabilizationPeriod metav1.Duration `json:"stabilizationPeriod,omitempty"`
+	// MaxRestarts is the number of container restarts of an updated pod above which the rollout fails. The
+	// StatefulSet is then rolled back to its previous revision until the configuration changes again.
+	// +optional
+	// +kubebuilder:validation:Minimum:=0
+	MaxRestarts int32 `json:"maxRestarts,omitempty"`
+}
+
+type (
+	// RolloutPhase is the phase of a canary or partitioned rollout.
+	// +kubebuilder:validation:Enum=Progressing;Succeeded;Failed
+	RolloutPhase string
+)
+
+const (
+	// RolloutPhaseProgressing means the canary pods, or the updated pods of the StatefulSet, run the new configuration
+	// and aren't stable yet.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// RolloutPhaseSucceeded means the collector Deployment, or StatefulSet, runs the current configuration.
+	RolloutPhaseSucceeded RolloutPhase = "Succeeded"
+	// RolloutPhaseFailed means the canary pods, or the updated pods of the StatefulSet, restarted too often with the
+	// new configuration.
+	RolloutPhaseFailed RolloutPhase = "Failed"
+)
+
+// RolloutStatus is the status of the canary or partitioned rollout of the configuration.
+type RolloutStatus struct {
+	// Phase of the rollout.
+	// +optional
+	Phase RolloutPhase `json:"phase,omitempty"`
+	// ConfigMap is the name of the collector ConfigMap being rolled out.
+	// +optional
+	ConfigMap string `json:"configMap,omitempty"`
+	// Message describes the state of the canary pods, or of the updated pods of the StatefulSet.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
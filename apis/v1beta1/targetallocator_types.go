@@ -51,9 +51,29 @@ type TargetAllocatorPrometheusCR struct {
 	ProbeSelector *metav1.LabelSelector `json:"probeSelector,omitempty"`
 }
 
+// TargetAllocatorConsistentHashing tunes the hash ring of the consistent-hashing and zone-aware allocation strategies.
+type TargetAllocatorConsistentHashing struct {
+	// PartitionCount is the number of partitions of the hash ring, which are distributed between the collectors.
+	// More partitions distribute the targets more evenly, at the cost of more memory. The default is 1061.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PartitionCount int32 `json:"partitionCount,omitempty"`
+	// ReplicationFactor is the number of virtual nodes of each collector on the hash ring.
+	// More virtual nodes distribute the partitions more evenly, at the cost of slower rebalancing when the collectors change.
+	// The default is 5.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	ReplicationFactor int32 `json:"replicationFactor,omitempty"`
+	// Hash is the hash function placing the targets and the collectors on the hash ring, xxhash or sha256.
+	// The default is xxhash.
+	// +optional
+	// +kubebuilder:validation:Enum=xxhash;sha256
+	Hash string `json:"hash,omitempty"`
+}
+
 type (
 	// TargetAllocatorAllocationStrategy represent a strategy Target Allocator uses to distribute targets to each collector
-	// +kubebuilder:validation:Enum=least-weighted;consistent-hashing;per-node
+	// +kubebuilder:validation:Enum=least-weighted;consistent-hashing;per-node;zone-aware
 	TargetAllocatorAllocationStrategy string
 	// TargetAllocatorFilterStrategy represent a filtering strategy for targets before they are assigned to collectors
 	// +kubebuilder:validation:Enum="";relabel-config
@@ -70,6 +90,9 @@ const (
 	// TargetAllocatorAllocationStrategyPerNode targets will be assigned to the collector on the node they reside on (use only with daemon set).
 	TargetAllocatorAllocationStrategyPerNode TargetAllocatorAllocationStrategy = "per-node"
 
+	// TargetAllocatorAllocationStrategyZoneAware targets will preferably be assigned to a collector in the same topology zone.
+	TargetAllocatorAllocationStrategyZoneAware TargetAllocatorAllocationStrategy = "zone-aware"
+
 	// TargetAllocatorFilterStrategyRelabelConfig targets will be consistently drops targets based on the relabel_config.
 	TargetAllocatorFilterStrategyRelabelConfig TargetAllocatorFilterStrategy = "relabel-config"
 )
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// deprecatedComponents are the component types deprecated or removed from the collector, with their replacements.
+var deprecatedComponents = map[ComponentKind]map[string]string{
+	KindReceiver: {
+		"opencensus": "the otlp receiver",
+	},
+	KindExporter: {
+		"logging":    "the debug exporter",
+		"jaeger":     "the otlp exporter, Jaeger accepts OTLP",
+		"opencensus": "the otlp exporter",
+	},
+	KindProcessor: {
+		"spanmetrics":  "the spanmetrics connector",
+		"servicegraph": "the servicegraph connector",
+	},
+	KindExtension: {
+		"memory_ballast": "the GOMEMLIMIT environment variable, see spec.env",
+	},
+}
+
+// deprecatedArgs are the collector flags replaced by the service.telemetry settings of the configuration.
+var deprecatedArgs = map[string]string{
+	"metrics-addr":  "service.telemetry.metrics",
+	"metrics-level": "service.telemetry.metrics",
+	"log-level":     "service.telemetry.logs",
+	"log-profile":   "service.telemetry.logs",
+	"log-format":    "service.telemetry.logs",
+}
+
+// AdviceWarnings returns the warnings about the settings of the collector which are accepted but deprecated or risky,
+// returned by the webhook on admission to guide the users without rejecting the collector.
+func AdviceWarnings(r *OpenTelemetryCollector) admission.Warnings {
+	warnings := deprecationWarnings(r)
+	warnings = append(warnings, ResourceLimitsWarnings("spec.resources", r.Spec.Resources)...)
+	if r.Spec.TargetAllocator.Enabled {
+		warnings = append(warnings, ResourceLimitsWarnings("spec.targetAllocator.resources", r.Spec.TargetAllocator.Resources)...)
+	}
+	return append(warnings, debugExporterWarnings(r)...)
+}
+
+// deprecationWarnings warns about the deprecated collector flags of spec.args, and the deprecated components and
+// settings of the configuration, which the upgrades of the collector may migrate or remove.
+func deprecationWarnings(r *OpenTelemetryCollector) admission.Warnings {
+	var warnings admission.Warnings
+	for _, arg := range slices.Sorted(maps.Keys(r.Spec.Args)) {
+		if replacement, ok := deprecatedArgs[strings.TrimPrefix(arg, "--")]; ok {
+			warnings = append(warnings, fmt.Sprintf("the collector flag '%s' of spec.args is deprecated, use %s of the configuration instead", arg, replacement))
+		}
+	}
+	sections := []struct {
+		kind   ComponentKind
+		config *AnyConfig
+	}{
+		{KindReceiver, &r.Spec.Config.Receivers},
+		{KindExporter, &r.Spec.Config.Exporters},
+		{KindProcessor, r.Spec.Config.Processors},
+		{KindExtension, r.Spec.Config.Extensions},
+	}
+	for _, section := range sections {
+		if section.config == nil {
+			continue
+		}
+		for _, id := range slices.Sorted(maps.Keys(section.config.Object)) {
+			componentType, _, _ := strings.Cut(id, "/")
+			if replacement, ok := deprecatedComponents[section.kind][componentType]; ok {
+				warnings = append(warnings, fmt.Sprintf("the %s %s is deprecated, use %s instead", section.kind, id, replacement))
+			}
+		}
+	}
+	if telemetry := r.Spec.Config.Service.GetTelemetry(); telemetry != nil && telemetry.Metrics.Address != "" {
+		warnings = append(warnings, "service.telemetry.metrics.address is deprecated, use a Prometheus reader of service.telemetry.metrics.readers instead")
+	}
+	return warnings
+}
+
+// ResourceLimitsWarnings warns about the resources without a memory limit, which let the pods use all the memory of
+// their node, until the node evicts them or other pods. The CPU limits are left out on purpose, as they throttle the
+// pods even when the node has CPU to spare.
+func ResourceLimitsWarnings(field string, resources corev1.ResourceRequirements) admission.Warnings {
+	if _, ok := resources.Limits[corev1.ResourceMemory]; ok {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("%s sets no memory limit, the pods can use all the memory of their node until they or other pods are evicted", field)}
+}
+
+// debugExporterWarnings warns about the verbose debug exporters of the pipelines which also export their telemetry
+// to other exporters, as the debug exporters slow down the collector and flood its logs with the telemetry.
+func debugExporterWarnings(r *OpenTelemetryCollector) admission.Warnings {
+	isDebug := func(id string) bool {
+		componentType, _, _ := strings.Cut(id, "/")
+		return componentType == "debug"
+	}
+	var warnings admission.Warnings
+	for _, name := range slices.Sorted(maps.Keys(r.Spec.Config.Service.Pipelines)) {
+		pipeline := r.Spec.Config.Service.Pipelines[name]
+		if pipeline == nil || !slices.ContainsFunc(pipeline.Exporters, func(id string) bool { return !isDebug(id) }) {
+			continue
+		}
+		for _, id := range slices.DeleteFunc(slices.Clone(pipeline.Exporters), func(id string) bool { return !isDebug(id) }) {
+			settings, _ := r.Spec.Config.Exporters.Object[id].(map[string]interface{})
+			if verbosity, _ := settings["verbosity"].(string); verbosity == "normal" || verbosity == "detailed" {
+				warnings = append(warnings, fmt.Sprintf("the exporter %s logs the telemetry of the pipeline %s with the %s verbosity, remove it from the production pipelines or lower its verbosity to basic", id, name, verbosity))
+			}
+		}
+	}
+	return warnings
+}
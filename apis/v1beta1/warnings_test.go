@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestAdviceWarnings(t *testing.T) {
+	limits := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+	}
+	for _, tt := range []struct {
+		name     string
+		config   string
+		args     map[string]string
+		ta       bool
+		noLimits bool
+		expected admission.Warnings
+	}{
+		{
+			name: "no warnings",
+			config: `{
+				"receivers": {"otlp": {}},
+				"exporters": {"otlp": {}, "debug": {"verbosity": "detailed"}},
+				"service": {"pipelines": {"traces": {"receivers": ["otlp"], "exporters": ["otlp"]}, "logs": {"receivers": ["otlp"], "exporters": ["debug"]}}}
+			}`,
+		},
+		{
+			name: "deprecated components",
+			config: `{
+				"receivers": {"opencensus": {}, "otlp": {}},
+				"processors": {"spanmetrics": {}},
+				"exporters": {"logging": {}, "jaeger/backend": {}},
+				"extensions": {"memory_ballast": {}},
+				"service": {"pipelines": {"traces": {"receivers": ["otlp"], "exporters": ["jaeger/backend"]}}}
+			}`,
+			expected: admission.Warnings{
+				"the receiver opencensus is deprecated, use the otlp receiver instead",
+				"the exporter jaeger/backend is deprecated, use the otlp exporter, Jaeger accepts OTLP instead",
+				"the exporter logging is deprecated, use the debug exporter instead",
+				"the processor spanmetrics is deprecated, use the spanmetrics connector instead",
+				"the extension memory_ballast is deprecated, use the GOMEMLIMIT environment variable, see spec.env instead",
+			},
+		},
+		{
+			name: "deprecated args and telemetry settings",
+			config: `{
+				"receivers": {"otlp": {}},
+				"exporters": {"otlp": {}},
+				"service": {
+					"telemetry": {"metrics": {"address": "0.0.0.0:8888"}},
+					"pipelines": {"traces": {"receivers": ["otlp"], "exporters": ["otlp"]}}
+				}
+			}`,
+			args: map[string]string{"--metrics-addr": "0.0.0.0:8888", "feature-gates": "-component.UseLocalHostAsDefaultHost"},
+			expected: admission.Warnings{
+				"the collector flag '--metrics-addr' of spec.args is deprecated, use service.telemetry.metrics of the configuration instead",
+				"service.telemetry.metrics.address is deprecated, use a Prometheus reader of service.telemetry.metrics.readers instead",
+			},
+		},
+		{
+			name: "verbose debug exporter",
+			config: `{
+				"receivers": {"otlp": {}},
+				"exporters": {"otlp": {}, "debug": {"verbosity": "detailed"}, "debug/basic": {"verbosity": "basic"}},
+				"service": {"pipelines": {"traces": {"receivers": ["otlp"], "exporters": ["otlp", "debug", "debug/basic"]}}}
+			}`,
+			expected: admission.Warnings{
+				"the exporter debug logs the telemetry of the pipeline traces with the detailed verbosity, remove it from the production pipelines or lower its verbosity to basic",
+			},
+		},
+		{
+			name: "no memory limits",
+			config: `{
+				"receivers": {"otlp": {}},
+				"exporters": {"otlp": {}},
+				"service": {"pipelines": {"traces": {"receivers": ["otlp"], "exporters": ["otlp"]}}}
+			}`,
+			ta:       true,
+			noLimits: true,
+			expected: admission.Warnings{
+				"spec.resources sets no memory limit, the pods can use all the memory of their node until they or other pods are evicted",
+				"spec.targetAllocator.resources sets no memory limit, the pods can use all the memory of their node until they or other pods are evicted",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := &OpenTelemetryCollector{
+				Spec: OpenTelemetryCollectorSpec{
+					OpenTelemetryCommonFields: OpenTelemetryCommonFields{Args: tt.args},
+					TargetAllocator:           TargetAllocatorEmbedded{Enabled: tt.ta},
+				},
+			}
+			require.NoError(t, json.Unmarshal([]byte(tt.config), &otelcol.Spec.Config))
+			if !tt.noLimits {
+				otelcol.Spec.Resources = limits
+				otelcol.Spec.TargetAllocator.Resources = limits
+			}
+			assert.Equal(t, tt.expected, AdviceWarnings(otelcol))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+# frozen_string_literal: true
+
+# Preloaded with RUBYOPT by the OpenTelemetry Operator.
+#
+# The gems of the image are appended to the load path, so the versions locked by the application bundle win,
+# and the SDK is configured once Bundler.require has loaded the application gems, which lets `use_all`
+# install the instrumentation of every library the application uses.
+Dir.glob(File.join(__dir__, 'gems', 'gems', '*', 'lib')).each { |lib| $LOAD_PATH.push(lib) }
+
+module OpenTelemetryOperator
+  module AutoInstrumentation
+    def self.configure
+      return if @configured
+
+      @configured = true
+      require 'opentelemetry/sdk'
+      require 'opentelemetry/exporter/otlp'
+      require 'opentelemetry/instrumentation/all'
+      OpenTelemetry::SDK.configure(&:use_all)
+    rescue StandardError, LoadError => e
+      warn "OpenTelemetry auto-instrumentation is disabled: #{e.message}"
+    end
+
+    module BundlerRequire
+      def require(*groups)
+        super.tap { AutoInstrumentation.configure }
+      end
+    end
+  end
+end
+
+if ENV.key?('BUNDLE_GEMFILE') || File.exist?('Gemfile')
+  require 'bundler'
+  Bundler::Runtime.prepend(OpenTelemetryOperator::AutoInstrumentation::BundlerRequire)
+else
+  OpenTelemetryOperator::AutoInstrumentation.configure
+end
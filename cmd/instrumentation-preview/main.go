@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/pflag"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/instrumentation-preview/preview"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+var scheme *k8sruntime.Scheme
+
+func init() {
+	scheme = k8sruntime.NewScheme()
+	utilruntime.Must(otelv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(appsv1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+}
+
+func main() {
+	var (
+		filenames                        []string
+		namespace                        string
+		verbose                          bool
+		enableMultiInstrumentation       bool
+		enableApacheHttpdInstrumentation bool
+		enableDotNetInstrumentation      bool
+		enableGoInstrumentation          bool
+		enablePythonInstrumentation      bool
+		enableNginxInstrumentation       bool
+		enablePHPInstrumentation         bool
+		enableRubyInstrumentation        bool
+		enableNodeJSInstrumentation      bool
+		enableJavaInstrumentation        bool
+	)
+	pflag.StringArrayVarP(&filenames, "filename", "f", nil, "The manifests with the workloads, the Instrumentations and the other objects the injection depends on, '-' reads the standard input")
+	pflag.StringVarP(&namespace, "namespace", "n", "default", "The namespace of the objects without one")
+	pflag.BoolVarP(&verbose, "verbose", "v", false, "Prints the logs of the webhook on the standard error")
+	// the same flags and defaults as the operator
+	pflag.BoolVar(&enableMultiInstrumentation, "enable-multi-instrumentation", true, "Controls whether the operator supports multi instrumentation")
+	pflag.BoolVar(&enableApacheHttpdInstrumentation, constants.FlagApacheHttpd, true, "Controls whether the operator supports Apache HTTPD auto-instrumentation")
+	pflag.BoolVar(&enableDotNetInstrumentation, constants.FlagDotNet, true, "Controls whether the operator supports dotnet auto-instrumentation")
+	pflag.BoolVar(&enableGoInstrumentation, constants.FlagGo, false, "Controls whether the operator supports Go auto-instrumentation")
+	pflag.BoolVar(&enablePythonInstrumentation, constants.FlagPython, true, "Controls whether the operator supports python auto-instrumentation")
+	pflag.BoolVar(&enableNginxInstrumentation, constants.FlagNginx, false, "Controls whether the operator supports nginx auto-instrumentation")
+	pflag.BoolVar(&enablePHPInstrumentation, constants.FlagPHP, false, "Controls whether the operator supports PHP auto-instrumentation")
+	pflag.BoolVar(&enableRubyInstrumentation, constants.FlagRuby, false, "Controls whether the operator supports Ruby auto-instrumentation")
+	pflag.BoolVar(&enableNodeJSInstrumentation, constants.FlagNodeJS, true, "Controls whether the operator supports nodejs auto-instrumentation")
+	pflag.BoolVar(&enableJavaInstrumentation, constants.FlagJava, true, "Controls whether the operator supports java auto-instrumentation")
+	pflag.Parse()
+
+	if len(filenames) == 0 {
+		fmt.Fprintln(os.Stderr, "at least one manifest is required, see --help")
+		os.Exit(1)
+	}
+
+	v := version.Get()
+	cfg := config.New(
+		config.WithEnableMultiInstrumentation(enableMultiInstrumentation),
+		config.WithEnableApacheHttpdInstrumentation(enableApacheHttpdInstrumentation),
+		config.WithEnableDotNetInstrumentation(enableDotNetInstrumentation),
+		config.WithEnableGoInstrumentation(enableGoInstrumentation),
+		config.WithEnableNginxInstrumentation(enableNginxInstrumentation),
+		config.WithEnablePHPInstrumentation(enablePHPInstrumentation),
+		config.WithEnableRubyInstrumentation(enableRubyInstrumentation),
+		config.WithEnablePythonInstrumentation(enablePythonInstrumentation),
+		config.WithEnableNodeJSInstrumentation(enableNodeJSInstrumentation),
+		config.WithEnableJavaInstrumentation(enableJavaInstrumentation),
+		config.WithAutoInstrumentationJavaImage(fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-java:%s", v.AutoInstrumentationJava)),
+		config.WithAutoInstrumentationNodeJSImage(fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-nodejs:%s", v.AutoInstrumentationNodeJS)),
+		config.WithAutoInstrumentationPythonImage(fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-python:%s", v.AutoInstrumentationPython)),
+		config.WithAutoInstrumentationDotNetImage(fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-dotnet:%s", v.AutoInstrumentationDotNet)),
+		config.WithAutoInstrumentationGoImage(fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-go-instrumentation/autoinstrumentation-go:%s", v.AutoInstrumentationGo)),
+		config.WithAutoInstrumentationApacheHttpdImage(fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationApacheHttpd)),
+		config.WithAutoInstrumentationNginxImage(fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationNginx)),
+		config.WithAutoInstrumentationPHPImage(fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-php:%s", v.AutoInstrumentationPHP)),
+		config.WithAutoInstrumentationRubyImage(fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-ruby:%s", v.AutoInstrumentationRuby)),
+	)
+
+	var objects []client.Object
+	for _, filename := range filenames {
+		decoded, err := decodeFile(filename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read %s: %v\n", filename, err)
+			os.Exit(1)
+		}
+		objects = append(objects, decoded...)
+	}
+
+	logger := zap.New(zap.WriteTo(io.Discard))
+	if verbose {
+		logger = zap.New(zap.WriteTo(os.Stderr), zap.UseDevMode(true))
+	}
+
+	results, err := preview.Run(context.Background(), logger, scheme, cfg, namespace, objects)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for i, result := range results {
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "%s %s: %s\n", result.Object.GetObjectKind().GroupVersionKind().Kind, result.Object.GetName(), warning)
+		}
+		out, err := yaml.Marshal(result.Object)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if i > 0 {
+			fmt.Println("---")
+		}
+		fmt.Print(string(out))
+	}
+}
+
+func decodeFile(filename string) ([]client.Object, error) {
+	if filename == "-" {
+		return preview.Decode(scheme, os.Stdin)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return preview.Decode(scheme, f)
+}
@@ -0,0 +1,193 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package preview runs the instrumentation pod mutator of the operator against manifests, without a cluster.
+package preview
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+// Result is a workload of the manifests with its pod mutated as the webhook would.
+type Result struct {
+	Object client.Object
+	// Warnings are the events the webhook would have recorded for the pod.
+	Warnings []string
+}
+
+// Decode reads the objects of a multi-document YAML or JSON stream. The items of the v1 Lists, e.g. printed by
+// kubectl get -o yaml, are read as the other objects.
+func Decode(scheme *runtime.Scheme, r io.Reader) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLOrJSONDecoder(bufio.NewReader(r), 4096)
+	var objects []client.Object
+	for {
+		raw := runtime.RawExtension{}
+		if err := reader.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, err
+		}
+		if len(raw.Raw) == 0 || string(raw.Raw) == "null" {
+			continue
+		}
+		decoded, err := decodeObject(decoder, raw.Raw)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, decoded...)
+	}
+}
+
+func decodeObject(decoder runtime.Decoder, raw []byte) ([]client.Object, error) {
+	obj, _, err := decoder.Decode(raw, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if list, ok := obj.(*corev1.List); ok {
+		var objects []client.Object
+		for _, item := range list.Items {
+			decoded, err := decodeObject(decoder, item.Raw)
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, decoded...)
+		}
+		return objects, nil
+	}
+	cObj, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("unsupported object %s", obj.GetObjectKind().GroupVersionKind())
+	}
+	return []client.Object{cObj}, nil
+}
+
+// Run mutates the pods and the pod templates of the workloads of the objects as the instrumentation webhook would.
+// The Instrumentations, Namespaces and the other objects, e.g. the Secrets of the exporter TLS configuration,
+// stand in for the cluster. Objects without a namespace belong to the given namespace.
+func Run(ctx context.Context, logger logr.Logger, scheme *runtime.Scheme, cfg config.Config, namespace string, objects []client.Object) ([]Result, error) {
+	var workloads []client.Object
+	var cluster []client.Object
+	namespaces := map[string]corev1.Namespace{}
+	provider := config.NewProvider(cfg)
+	defaulter := v1alpha1.NewInstrumentationWebhook(logger, scheme, provider)
+	for _, obj := range objects {
+		if ns, ok := obj.(*corev1.Namespace); ok {
+			namespaces[ns.Name] = *ns
+			continue
+		}
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+		if inst, ok := obj.(*v1alpha1.Instrumentation); ok {
+			if err := defaulter.Default(ctx, inst); err != nil {
+				return nil, fmt.Errorf("failed to default the Instrumentation %s: %w", inst.Name, err)
+			}
+		}
+		if _, err := podTemplate(obj); err == nil {
+			workloads = append(workloads, obj)
+			continue
+		}
+		cluster = append(cluster, obj)
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster...).Build()
+	var results []Result
+	for _, workload := range workloads {
+		recorder := &warningRecorder{}
+		mutator := instrumentation.NewMutator(logger, cl, recorder, provider)
+
+		ns, ok := namespaces[workload.GetNamespace()]
+		if !ok {
+			ns = corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: workload.GetNamespace()}}
+		}
+		template, _ := podTemplate(workload)
+		pod := corev1.Pod{ObjectMeta: template.ObjectMeta, Spec: template.Spec}
+		pod.Namespace = workload.GetNamespace()
+		if _, isPod := workload.(*corev1.Pod); !isPod {
+			// the owner gives the resource attributes of the workload, the ReplicaSet of a Deployment is skipped
+			pod.OwnerReferences = []metav1.OwnerReference{{
+				Kind: workload.GetObjectKind().GroupVersionKind().Kind,
+				Name: workload.GetName(),
+				UID:  workload.GetUID(),
+			}}
+		}
+
+		mutated, err := mutator.Mutate(ctx, ns, pod)
+		if err != nil {
+			return nil, fmt.Errorf("the webhook would admit %s %s/%s without mutating it: %w", workload.GetObjectKind().GroupVersionKind().Kind, workload.GetNamespace(), workload.GetName(), err)
+		}
+		mutated.Namespace = template.Namespace
+		mutated.OwnerReferences = template.OwnerReferences
+		if p, isPod := workload.(*corev1.Pod); isPod {
+			p.ObjectMeta = mutated.ObjectMeta
+			p.Spec = mutated.Spec
+		} else {
+			template.ObjectMeta = mutated.ObjectMeta
+			template.Spec = mutated.Spec
+		}
+		results = append(results, Result{Object: workload, Warnings: recorder.warnings})
+	}
+	return results, nil
+}
+
+// podTemplate returns the pod template of the workload, the metadata and spec of a pod.
+func podTemplate(obj client.Object) (*corev1.PodTemplateSpec, error) {
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		return &corev1.PodTemplateSpec{ObjectMeta: o.ObjectMeta, Spec: o.Spec}, nil
+	case *appsv1.Deployment:
+		return &o.Spec.Template, nil
+	case *appsv1.StatefulSet:
+		return &o.Spec.Template, nil
+	case *appsv1.DaemonSet:
+		return &o.Spec.Template, nil
+	case *appsv1.ReplicaSet:
+		return &o.Spec.Template, nil
+	case *batchv1.Job:
+		return &o.Spec.Template, nil
+	case *batchv1.CronJob:
+		return &o.Spec.JobTemplate.Spec.Template, nil
+	}
+	return nil, fmt.Errorf("%s isn't a workload", obj.GetObjectKind().GroupVersionKind().Kind)
+}
+
+// warningRecorder keeps the events recorded by the mutator.
+type warningRecorder struct {
+	warnings []string
+}
+
+var _ record.EventRecorder = (*warningRecorder)(nil)
+
+func (r *warningRecorder) Event(_ runtime.Object, _, reason, message string) {
+	r.warnings = append(r.warnings, fmt.Sprintf("%s: %s", reason, message))
+}
+
+func (r *warningRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *warningRecorder) AnnotatedEventf(object runtime.Object, _ map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Eventf(object, eventtype, reason, messageFmt, args...)
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package preview
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const instrumentationManifest = `
+apiVersion: opentelemetry.io/v1alpha1
+kind: Instrumentation
+metadata:
+  name: my-instrumentation
+spec:
+  exporter:
+    endpoint: http://otel-collector:4318
+`
+
+func testScheme() *k8sruntime.Scheme {
+	scheme := k8sruntime.NewScheme()
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(appsv1.AddToScheme(scheme))
+	utilruntime.Must(batchv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestRun(t *testing.T) {
+	scheme := testScheme()
+	cfg := config.New(
+		config.WithEnableJavaInstrumentation(true),
+		config.WithAutoInstrumentationJavaImage("java:1"),
+	)
+
+	for _, tt := range []struct {
+		name      string
+		manifests string
+		verify    func(t *testing.T, results []Result)
+		err       string
+	}{
+		{
+			name: "deployment",
+			manifests: instrumentationManifest + `
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+spec:
+  template:
+    metadata:
+      annotations:
+        instrumentation.opentelemetry.io/inject-java: "true"
+    spec:
+      containers:
+        - name: app
+          image: app:1
+`,
+			verify: func(t *testing.T, results []Result) {
+				require.Len(t, results, 1)
+				deployment := results[0].Object.(*appsv1.Deployment)
+				assert.Equal(t, "default", deployment.Namespace)
+				assert.Empty(t, deployment.Spec.Template.OwnerReferences)
+				require.Len(t, deployment.Spec.Template.Spec.InitContainers, 1)
+				assert.Equal(t, "java:1", deployment.Spec.Template.Spec.InitContainers[0].Image)
+				assert.Contains(t, deployment.Spec.Template.Spec.Containers[0].Env, corev1.EnvVar{Name: "OTEL_SERVICE_NAME", Value: "my-app"})
+				assert.Empty(t, results[0].Warnings)
+			},
+		},
+		{
+			name: "pod with the annotation on the namespace",
+			manifests: `
+apiVersion: opentelemetry.io/v1alpha1
+kind: Instrumentation
+metadata:
+  name: my-instrumentation
+  namespace: apps
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: apps
+  annotations:
+    instrumentation.opentelemetry.io/inject-java: "true"
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: my-pod
+  namespace: apps
+spec:
+  containers:
+    - name: app
+      image: app:1
+`,
+			verify: func(t *testing.T, results []Result) {
+				require.Len(t, results, 1)
+				pod := results[0].Object.(*corev1.Pod)
+				assert.Equal(t, "apps", pod.Namespace)
+				require.Len(t, pod.Spec.InitContainers, 1)
+				assert.Equal(t, "opentelemetry-auto-instrumentation-java", pod.Spec.InitContainers[0].Name)
+			},
+		},
+		{
+			name: "language not enabled",
+			manifests: instrumentationManifest + `
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: my-job
+spec:
+  schedule: "* * * * *"
+  jobTemplate:
+    spec:
+      template:
+        metadata:
+          annotations:
+            instrumentation.opentelemetry.io/inject-go: "true"
+        spec:
+          containers:
+            - name: app
+              image: app:1
+`,
+			verify: func(t *testing.T, results []Result) {
+				require.Len(t, results, 1)
+				cronJob := results[0].Object.(*batchv1.CronJob)
+				assert.Empty(t, cronJob.Spec.JobTemplate.Spec.Template.Spec.InitContainers)
+				assert.Equal(t, []string{"InstrumentationRequestRejected: support for Go auto instrumentation is not enabled"}, results[0].Warnings)
+			},
+		},
+		{
+			name: "no instrumentation",
+			manifests: `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: my-pod
+  annotations:
+    instrumentation.opentelemetry.io/inject-java: "true"
+spec:
+  containers:
+    - name: app
+      image: app:1
+`,
+			err: "the webhook would admit Pod default/my-pod without mutating it: no OpenTelemetry Instrumentation instances available",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			objects, err := Decode(scheme, strings.NewReader(tt.manifests))
+			require.NoError(t, err)
+
+			results, err := Run(context.Background(), logr.Discard(), scheme, cfg, "default", objects)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			tt.verify(t, results)
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	objects, err := Decode(testScheme(), strings.NewReader(instrumentationManifest+"\n---\n---\n"+`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "ca"}}`))
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.IsType(t, &v1alpha1.Instrumentation{}, objects[0])
+	assert.IsType(t, &corev1.ConfigMap{}, objects[1])
+
+	objects, err = Decode(testScheme(), strings.NewReader(`apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: first
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: second
+`))
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.Equal(t, "second", objects[1].GetName())
+
+	_, err = Decode(testScheme(), strings.NewReader("apiVersion: v1\nkind: Unknown\n"))
+	assert.Error(t, err)
+}
@@ -8,7 +8,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -17,6 +19,9 @@ import (
 	"github.com/open-telemetry/opamp-go/client/types"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"go.uber.org/multierr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/clock"
 	"sigs.k8s.io/yaml"
 
@@ -30,6 +35,8 @@ import (
 const (
 	// proxyPrefix is included to make clear if a collector configuration is proxied.
 	proxyPrefix = "proxy:"
+	// renderedPrefix is included to make clear if a collector configuration is the one rendered by the operator.
+	renderedPrefix = "rendered:"
 )
 
 type Agent struct {
@@ -44,13 +51,26 @@ type Agent struct {
 	agentDescription   *protobufs.AgentDescription
 	remoteConfigStatus *protobufs.RemoteConfigStatus
 
+	// clientMu guards the OpAMP client, replaced when the agent reconnects with rotated certificates.
+	clientMu            sync.RWMutex
 	opampClient         client.OpAMPClient
+	createClient        func() client.OpAMPClient
 	proxy               proxy.Server
 	metricReporter      *metrics.MetricReporter
 	config              *config.Config
 	applier             operator.ConfigApplier
 	remoteConfigEnabled bool
 
+	// mu guards the applied collectors, the rollout and the packages install, updated by the remote configurations,
+	// the packages and their checks.
+	mu                    sync.Mutex
+	lastGoodConfigs       map[kubeResourceKey]*protobufs.AgentConfigFile
+	cancelRollout         context.CancelFunc
+	cancelPackagesInstall context.CancelFunc
+
+	// packages is the state of the collector images offered as packages by the server.
+	packages *packagesState
+
 	done   chan struct{}
 	ticker *time.Ticker
 }
@@ -66,10 +86,13 @@ func NewAgent(logger logr.Logger, applier operator.ConfigApplier, cfg *config.Co
 		proxy:               p,
 		logger:              logger,
 		appliedKeys:         map[kubeResourceKey]bool{},
+		lastGoodConfigs:     map[kubeResourceKey]*protobufs.AgentConfigFile{},
+		packages:            &packagesState{},
 		instanceId:          cfg.GetInstanceId(),
 		agentDescription:    cfg.GetDescription(),
 		remoteConfigEnabled: cfg.RemoteConfigEnabled(),
 		opampClient:         opampClient,
+		createClient:        cfg.CreateClient,
 		clock:               clock.RealClock{},
 		done:                make(chan struct{}, 1),
 		ticker:              t,
@@ -130,6 +153,9 @@ func (agent *Agent) generateCollectorPoolHealth() (map[string]*protobufs.Compone
 		for _, pod := range podMap {
 			isPoolHealthy = isPoolHealthy && pod.Healthy
 		}
+		// the operator reports the health_check extension results and the failed rollouts in the conditions
+		lastError := collectorLastError(col)
+		isPoolHealthy = isPoolHealthy && lastError == ""
 		podStartTime, err := timeToUnixNanoUnsigned(col.ObjectMeta.GetCreationTimestamp().Time)
 		if err != nil {
 			return nil, err
@@ -144,6 +170,7 @@ func (agent *Agent) generateCollectorPoolHealth() (map[string]*protobufs.Compone
 			Status:             col.Status.Scale.StatusReplicas,
 			ComponentHealthMap: podMap,
 			Healthy:            isPoolHealthy,
+			LastError:          lastError,
 		}
 	}
 	// TODO: Figure out how to tie this to the agent health from the proxy.
@@ -187,27 +214,72 @@ func (agent *Agent) generateCollectorHealth(selectorLabels map[string]string, na
 	healthMap := map[string]*protobufs.ComponentHealth{}
 	for _, item := range pods.Items {
 		key := newKubeResourceKey(item.GetNamespace(), item.GetName())
-		healthy := true
-		if item.Status.Phase != "Running" {
-			healthy = false
+		health, err := podHealth(item)
+		if err != nil {
+			return nil, err
 		}
-		var startTime uint64
-		if item.Status.StartTime != nil {
-			startTime, err = timeToUnixNanoUnsigned(item.Status.StartTime.Time)
-			if err != nil {
-				return nil, err
+		health.StatusTimeUnixNano = statusTime
+		healthMap[key.String()] = health
+	}
+	return healthMap, nil
+}
+
+// podHealth returns the health of a collector pod, which is healthy when it runs and is ready. The last error is the
+// one of the container waiting to start or restarted last, with its restart count.
+func podHealth(pod v1.Pod) (*protobufs.ComponentHealth, error) {
+	health := &protobufs.ComponentHealth{
+		Status:  string(pod.Status.Phase),
+		Healthy: pod.Status.Phase == v1.PodRunning,
+	}
+	if pod.Status.StartTime != nil {
+		startTime, err := timeToUnixNanoUnsigned(pod.Status.StartTime.Time)
+		if err != nil {
+			return nil, err
+		}
+		health.StartTimeUnixNano = startTime
+	} else {
+		health.Healthy = false
+	}
+	if health.Healthy {
+		ready := false
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == v1.PodReady {
+				ready = condition.Status == v1.ConditionTrue
 			}
-		} else {
-			healthy = false
 		}
-		healthMap[key.String()] = &protobufs.ComponentHealth{
-			StartTimeUnixNano:  startTime,
-			StatusTimeUnixNano: statusTime,
-			Status:             string(item.Status.Phase),
-			Healthy:            healthy,
+		health.Healthy = ready
+	}
+
+	var lastTerminated time.Time
+	for _, container := range pod.Status.ContainerStatuses {
+		switch {
+		case container.State.Waiting != nil && !slices.Contains([]string{"", "ContainerCreating", "PodInitializing"}, container.State.Waiting.Reason):
+			health.LastError = fmt.Sprintf("container %s is waiting with %s after %d restarts", container.Name, container.State.Waiting.Reason, container.RestartCount)
+			if container.State.Waiting.Message != "" {
+				health.LastError += ": " + container.State.Waiting.Message
+			}
+			return health, nil
+		case container.RestartCount > 0 && container.LastTerminationState.Terminated != nil &&
+			!container.LastTerminationState.Terminated.FinishedAt.Time.Before(lastTerminated):
+			terminated := container.LastTerminationState.Terminated
+			lastTerminated = terminated.FinishedAt.Time
+			health.LastError = fmt.Sprintf("container %s restarted %d times, last terminated with %s, exit code %d", container.Name, container.RestartCount, terminated.Reason, terminated.ExitCode)
 		}
 	}
-	return healthMap, nil
+	return health, nil
+}
+
+// collectorLastError returns the error the operator reports in the conditions of the collector: the failures of the
+// exporters the health_check extension reports, or why the collector isn't ready when it's degraded.
+func collectorLastError(col v1beta1.OpenTelemetryCollector) string {
+	if condition := meta.FindStatusCondition(col.Status.Conditions, v1beta1.ConditionExporterHealthy); condition != nil && condition.Status == metav1.ConditionFalse {
+		return condition.Message
+	}
+	if condition := meta.FindStatusCondition(col.Status.Conditions, v1beta1.ConditionReady); condition != nil && condition.Status == metav1.ConditionFalse &&
+		(condition.Reason == v1beta1.ReasonDegraded || condition.Reason == v1beta1.ReasonReconcileFailed) {
+		return condition.Message
+	}
+	return ""
 }
 
 // onConnect is called when an agent is successfully connected to a server.
@@ -237,10 +309,34 @@ func (agent *Agent) Start() error {
 		return err
 	}
 	agent.startTime = startTime
+
+	agent.logger.V(3).Info("Starting OpAMP client...")
+
+	err = agent.startClient(agent.opampClient)
+	if err != nil {
+		return err
+	}
+
+	if agent.config.HeartbeatInterval > 0 {
+		go agent.runHeartbeat()
+	}
+	go agent.checkForProxyUpdates()
+	if agent.config.TLS != nil {
+		go agent.watchCertificates()
+	}
+
+	agent.logger.V(3).Info("OpAMP Client started.")
+
+	return nil
+}
+
+// startClient starts the connection of the OpAMP client to the server.
+func (agent *Agent) startClient(opampClient client.OpAMPClient) error {
 	settings := types.StartSettings{
-		OpAMPServerURL: agent.config.Endpoint,
-		Header:         agent.config.Headers.ToHTTPHeader(),
-		InstanceUid:    types.InstanceUid(agent.instanceId),
+		OpAMPServerURL:    agent.config.GetServerURL(),
+		Header:            agent.config.Headers.ToHTTPHeader(),
+		InstanceUid:       types.InstanceUid(agent.instanceId),
+		EnableCompression: agent.config.EnableCompression,
 		Callbacks: types.CallbacksStruct{
 			OnConnectFunc:              agent.onConnect,
 			OnConnectFailedFunc:        agent.onConnectFailed,
@@ -250,33 +346,32 @@ func (agent *Agent) Start() error {
 			OnMessageFunc:              agent.onMessage,
 		},
 		RemoteConfigStatus:    agent.remoteConfigStatus,
-		PackagesStateProvider: nil,
+		PackagesStateProvider: agent.packagesStateProvider(),
 		Capabilities:          agent.config.GetCapabilities(),
 	}
-	err = agent.opampClient.SetAgentDescription(agent.agentDescription)
-	if err != nil {
-		return err
+	if agent.config.TLS != nil {
+		tlsConfig, err := agent.config.TLS.NewTLSConfig()
+		if err != nil {
+			return err
+		}
+		settings.TLSConfig = tlsConfig
 	}
-	err = agent.opampClient.SetHealth(agent.getHealth())
+	err := opampClient.SetAgentDescription(agent.agentDescription)
 	if err != nil {
 		return err
 	}
-
-	agent.logger.V(3).Info("Starting OpAMP client...")
-
-	err = agent.opampClient.Start(context.Background(), settings)
+	err = opampClient.SetHealth(agent.getHealth())
 	if err != nil {
 		return err
 	}
+	return opampClient.Start(context.Background(), settings)
+}
 
-	if agent.config.HeartbeatInterval > 0 {
-		go agent.runHeartbeat()
-	}
-	go agent.checkForProxyUpdates()
-
-	agent.logger.V(3).Info("OpAMP Client started.")
-
-	return nil
+// client returns the OpAMP client connected to the server.
+func (agent *Agent) client() client.OpAMPClient {
+	agent.clientMu.RLock()
+	defer agent.clientMu.RUnlock()
+	return agent.opampClient
 }
 
 func (agent *Agent) checkForProxyUpdates() {
@@ -284,12 +379,12 @@ func (agent *Agent) checkForProxyUpdates() {
 		select {
 		case <-agent.proxy.HasUpdates():
 			agent.logger.Info("new update from agent proxy")
-			err := agent.opampClient.SetHealth(agent.getHealth())
+			err := agent.client().SetHealth(agent.getHealth())
 			if err != nil {
 				agent.logger.Error(err, "failed to update from proxy")
 				return
 			}
-			updateErr := agent.opampClient.UpdateEffectiveConfig(context.Background())
+			updateErr := agent.client().UpdateEffectiveConfig(context.Background())
 			if updateErr != nil {
 				agent.logger.Error(updateErr, "failed to update from proxy")
 				return
@@ -311,7 +406,7 @@ func (agent *Agent) runHeartbeat() {
 		select {
 		case <-agent.ticker.C:
 			agent.logger.V(4).Info("sending heartbeat")
-			err := agent.opampClient.SetHealth(agent.getHealth())
+			err := agent.client().SetHealth(agent.getHealth())
 			if err != nil {
 				agent.logger.Error(err, "failed to heartbeat")
 				return
@@ -354,6 +449,21 @@ func (agent *Agent) getEffectiveConfig(ctx context.Context) (*protobufs.Effectiv
 			Body:        marshaled,
 			ContentType: "yaml",
 		}
+		rendered, err := agent.applier.GetRenderedConfig(instance)
+		if err != nil {
+			agent.logger.Error(err, "failed to get the rendered config", "collector", mapKey.String())
+			continue
+		}
+		if len(rendered) > 0 {
+			instanceMap[renderedPrefix+mapKey.String()] = &protobufs.AgentConfigFile{
+				Body:        []byte(rendered),
+				ContentType: "yaml",
+			}
+		}
+	}
+	err = agent.getResourcesEffectiveConfig(instanceMap)
+	if err != nil {
+		return nil, err
 	}
 	for id, instance := range agent.proxy.GetConfigurations() {
 		if cfg, ok := instance.GetConfigMap().GetConfigMap()[""]; ok {
@@ -387,14 +497,24 @@ func (agent *Agent) initMeter(settings *protobufs.TelemetryConnectionSettings) {
 //
 //	map[name/namespace] -> collector CRD spec
 //
-// For every key in the received remote configuration, the agent attempts to apply it to the connected
-// Kubernetes cluster. If an agent fails to apply a collector CRD, it will continue to the next entry. The agent will
-// store the received configuration hash regardless of application status as per the OpAMP spec.
+// Every key in the received remote configuration is first validated, nothing being applied unless they all are valid.
+// The agent then attempts to apply them to the connected Kubernetes cluster. If an agent fails to apply a collector
+// CRD, it will continue to the next entry. The agent will store the received configuration hash regardless of
+// application status as per the OpAMP spec.
+//
+// When a rollout timeout is configured, the configuration is applying until the collectors are rolled out, and the
+// collectors are rolled back to their last good configuration if they fail to.
 //
 // INVARIANT: The caller must verify that config isn't nil _and_ the configuration has changed between calls.
 func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*protobufs.RemoteConfigStatus, error) {
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+	// a newer configuration replaces the one being rolled out
+	agent.stopRollout()
+	agent.lastHash = config.GetConfigHash()
+
 	var multiErr error
-	// Apply changes from the received config map
+	files := map[kubeResourceKey]*protobufs.AgentConfigFile{}
 	for key, file := range config.Config.GetConfigMap() {
 		if len(key) == 0 || len(file.Body) == 0 {
 			continue
@@ -404,42 +524,91 @@ func (agent *Agent) applyRemoteConfig(config *protobufs.AgentRemoteConfig) (*pro
 			multiErr = multierr.Append(multiErr, err)
 			continue
 		}
-		err = agent.applier.Apply(colKey.name, colKey.namespace, file)
+		err = agent.validateResource(colKey, file)
 		if err != nil {
 			multiErr = multierr.Append(multiErr, err)
 			continue
 		}
-		agent.appliedKeys[colKey] = true
+		files[colKey] = file
+	}
+	if multiErr != nil {
+		return agent.newRemoteConfigStatus(multiErr), multiErr
 	}
-	// Check if anything was deleted
+	var deleted []kubeResourceKey
 	for collectorKey := range agent.appliedKeys {
 		if _, ok := config.Config.GetConfigMap()[collectorKey.String()]; !ok {
-			err := agent.applier.Delete(collectorKey.name, collectorKey.namespace)
-			if err != nil {
-				multiErr = multierr.Append(multiErr, err)
-			}
+			deleted = append(deleted, collectorKey)
 		}
 	}
-	agent.lastHash = config.GetConfigHash()
-	if multiErr != nil {
+
+	var r *rollout
+	if agent.config.RolloutTimeout > 0 {
+		var err error
+		r, err = agent.newRollout(files, deleted)
+		if err != nil {
+			return agent.newRemoteConfigStatus(err), err
+		}
+	}
+
+	// Apply changes from the received config map
+	for colKey, file := range files {
+		err := agent.applyResource(colKey, file)
+		if err != nil {
+			multiErr = multierr.Append(multiErr, err)
+			continue
+		}
+		agent.appliedKeys[colKey] = true
+	}
+	// Delete the collectors which were removed
+	for _, collectorKey := range deleted {
+		err := agent.deleteResource(collectorKey)
+		if err != nil {
+			multiErr = multierr.Append(multiErr, err)
+			continue
+		}
+		delete(agent.appliedKeys, collectorKey)
+	}
+
+	switch {
+	case multiErr != nil && r != nil:
+		multiErr = agent.rollback(r, multiErr)
+		return agent.newRemoteConfigStatus(multiErr), multiErr
+	case multiErr != nil:
+		return agent.newRemoteConfigStatus(multiErr), multiErr
+	case r != nil:
+		ctx, cancel := context.WithCancel(context.Background())
+		agent.cancelRollout = cancel
+		go agent.watchRollout(ctx, r)
+		return &protobufs.RemoteConfigStatus{
+			LastRemoteConfigHash: agent.lastHash,
+			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING,
+		}, nil
+	default:
+		return agent.newRemoteConfigStatus(nil), nil
+	}
+}
+
+// newRemoteConfigStatus returns the status of the last remote configuration, applied without error or failed.
+func (agent *Agent) newRemoteConfigStatus(err error) *protobufs.RemoteConfigStatus {
+	if err != nil {
 		return &protobufs.RemoteConfigStatus{
 			LastRemoteConfigHash: agent.lastHash,
 			Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
-			ErrorMessage:         multiErr.Error(),
-		}, multiErr
+			ErrorMessage:         err.Error(),
+		}
 	}
 	return &protobufs.RemoteConfigStatus{
 		LastRemoteConfigHash: agent.lastHash,
 		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
-	}, nil
+	}
 }
 
 // Shutdown will stop the OpAMP client gracefully.
 func (agent *Agent) Shutdown() {
 	agent.logger.V(3).Info("Agent shutting down...")
 	close(agent.done)
-	if agent.opampClient != nil {
-		err := agent.opampClient.Stop(context.Background())
+	if opampClient := agent.client(); opampClient != nil {
+		err := opampClient.Stop(context.Background())
 		if err != nil {
 			agent.logger.Error(err, "failed to stop client")
 		}
@@ -460,17 +629,21 @@ func (agent *Agent) onMessage(ctx context.Context, msg *types.MessageData) {
 		if err != nil {
 			agent.logger.Error(err, "failed to apply remote config")
 		}
-		err = agent.opampClient.SetRemoteConfigStatus(status)
+		err = agent.client().SetRemoteConfigStatus(status)
 		if err != nil {
 			agent.logger.Error(err, "failed to set remote config status")
 			return
 		}
-		err = agent.opampClient.UpdateEffectiveConfig(ctx)
+		err = agent.client().UpdateEffectiveConfig(ctx)
 		if err != nil {
 			agent.logger.Error(err, "failed to update effective config")
 		}
 	}
 
+	if msg.PackagesAvailable != nil {
+		agent.syncPackages(ctx, msg.PackagesAvailable)
+	}
+
 	// The instance id is updated prior to the meter initialization so that the new meter will report using the updated
 	// instanceId.
 	if msg.AgentIdentification != nil {
@@ -90,6 +90,9 @@ var (
 				Status: v1.PodStatus{
 					StartTime: &podTime,
 					Phase:     v1.PodRunning,
+					Conditions: []v1.PodCondition{
+						{Type: v1.PodReady, Status: v1.ConditionTrue},
+					},
 				},
 			},
 		}}
@@ -174,7 +177,9 @@ type mockOpampClient struct {
 	lastStatus          *protobufs.RemoteConfigStatus
 	lastHealth          *protobufs.ComponentHealth
 	lastEffectiveConfig *protobufs.EffectiveConfig
+	lastPackageStatuses *protobufs.PackageStatuses
 	settings            types.StartSettings
+	stopped             bool
 }
 
 func (m *mockOpampClient) SetCustomCapabilities(_ *protobufs.CustomCapabilities) error {
@@ -197,6 +202,9 @@ func (m *mockOpampClient) Start(_ context.Context, settings types.StartSettings)
 }
 
 func (m *mockOpampClient) Stop(_ context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopped = true
 	return nil
 }
 
@@ -233,13 +241,18 @@ func (m *mockOpampClient) SetRemoteConfigStatus(status *protobufs.RemoteConfigSt
 	return nil
 }
 
-func (m *mockOpampClient) SetPackageStatuses(_ *protobufs.PackageStatuses) error {
+func (m *mockOpampClient) SetPackageStatuses(statuses *protobufs.PackageStatuses) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastPackageStatuses = statuses
 	return nil
 }
 
 func getFakeApplier(t *testing.T, conf *config.Config, lists ...runtimeClient.ObjectList) *operator.Client {
 	schemeBuilder := runtime.NewSchemeBuilder(func(s *runtime.Scheme) error {
 		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
+		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.TargetAllocator{}, &v1alpha1.TargetAllocatorList{})
+		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.Instrumentation{}, &v1alpha1.InstrumentationList{})
 		s.AddKnownTypes(v1beta1.GroupVersion, &v1beta1.OpenTelemetryCollector{}, &v1beta1.OpenTelemetryCollectorList{})
 		s.AddKnownTypes(v1.SchemeGroupVersion, &v1.Pod{}, &v1.PodList{})
 		metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
@@ -694,6 +707,27 @@ func TestAgent_onMessage(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "nothing is applied when a collector is invalid",
+			fields: fields{
+				configFile: agentTestFileName,
+			},
+			args: args{
+				ctx: context.Background(),
+				configFile: map[string]string{
+					testCollectorKey:  collectorInvalidFile,
+					otherCollectorKey: collectorBasicFile,
+				},
+			},
+			want: want{
+				contents: nil,
+				status: &protobufs.RemoteConfigStatus{
+					LastRemoteConfigHash: []byte(invalidYamlConfigHash + getConfigHash(otherCollectorKey, collectorBasicFile)),
+					Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+					ErrorMessage:         "failed to unmarshal config into v1beta1 API Version: error converting YAML to JSON: yaml: line 23: could not find expected ':'",
+				},
+			},
+		},
 		{
 			name: "all components are allowed",
 			fields: fields{
@@ -1127,3 +1161,110 @@ func getMessageDataFromConfigFile(filemap map[string]string) (*types.MessageData
 	}
 	return toReturn, nil
 }
+
+func TestPodHealth(t *testing.T) {
+	ready := []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}
+	tests := []struct {
+		name   string
+		status v1.PodStatus
+		want   *protobufs.ComponentHealth
+	}{
+		{
+			name:   "ready",
+			status: v1.PodStatus{Phase: v1.PodRunning, StartTime: &podTime, Conditions: ready},
+			want:   &protobufs.ComponentHealth{Healthy: true, Status: "Running", StartTimeUnixNano: podTimeUnsigned},
+		},
+		{
+			name:   "not ready",
+			status: v1.PodStatus{Phase: v1.PodRunning, StartTime: &podTime, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse}}},
+			want:   &protobufs.ComponentHealth{Healthy: false, Status: "Running", StartTimeUnixNano: podTimeUnsigned},
+		},
+		{
+			name: "restarted",
+			status: v1.PodStatus{Phase: v1.PodRunning, StartTime: &podTime, Conditions: ready, ContainerStatuses: []v1.ContainerStatus{{
+				Name:                 "otc-container",
+				RestartCount:         2,
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}},
+			}}},
+			want: &protobufs.ComponentHealth{
+				Healthy:           true,
+				Status:            "Running",
+				StartTimeUnixNano: podTimeUnsigned,
+				LastError:         "container otc-container restarted 2 times, last terminated with OOMKilled, exit code 137",
+			},
+		},
+		{
+			name: "crash looping",
+			status: v1.PodStatus{Phase: v1.PodRunning, StartTime: &podTime, ContainerStatuses: []v1.ContainerStatus{{
+				Name:                 "otc-container",
+				RestartCount:         5,
+				State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s restarting failed container"}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			}}},
+			want: &protobufs.ComponentHealth{
+				Healthy:           false,
+				Status:            "Running",
+				StartTimeUnixNano: podTimeUnsigned,
+				LastError:         "container otc-container is waiting with CrashLoopBackOff after 5 restarts: back-off 5m0s restarting failed container",
+			},
+		},
+		{
+			name: "creating",
+			status: v1.PodStatus{Phase: v1.PodPending, ContainerStatuses: []v1.ContainerStatus{{
+				Name:  "otc-container",
+				State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+			}}},
+			want: &protobufs.ComponentHealth{Healthy: false, Status: "Pending"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := podHealth(v1.Pod{Status: tt.status})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCollectorLastError(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		want       string
+	}{
+		{
+			name: "healthy",
+			conditions: []metav1.Condition{
+				{Type: v1beta1.ConditionReady, Status: metav1.ConditionTrue, Reason: v1beta1.ReasonReady},
+				{Type: v1beta1.ConditionExporterHealthy, Status: metav1.ConditionTrue, Reason: v1beta1.ReasonHealthy},
+			},
+		},
+		{
+			name: "progressing",
+			conditions: []metav1.Condition{
+				{Type: v1beta1.ConditionReady, Status: metav1.ConditionFalse, Reason: v1beta1.ReasonProgressing, Message: "1/2 pods are available"},
+			},
+		},
+		{
+			name: "exporter unhealthy",
+			conditions: []metav1.Condition{
+				{Type: v1beta1.ConditionReady, Status: metav1.ConditionTrue, Reason: v1beta1.ReasonReady},
+				{Type: v1beta1.ConditionExporterHealthy, Status: metav1.ConditionFalse, Reason: v1beta1.ReasonUnhealthy, Message: "the otlp exporter fails to export"},
+			},
+			want: "the otlp exporter fails to export",
+		},
+		{
+			name: "degraded",
+			conditions: []metav1.Condition{
+				{Type: v1beta1.ConditionReady, Status: metav1.ConditionFalse, Reason: v1beta1.ReasonDegraded, Message: "the collector configuration is invalid"},
+			},
+			want: "the collector configuration is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col := v1beta1.OpenTelemetryCollector{Status: v1beta1.OpenTelemetryCollectorStatus{Conditions: tt.conditions}}
+			assert.Equal(t, tt.want, collectorLastError(col))
+		})
+	}
+}
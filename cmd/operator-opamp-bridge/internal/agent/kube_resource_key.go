@@ -7,9 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
 )
 
 type kubeResourceKey struct {
+	// kind is the kind of the resource, empty for the collectors.
+	kind      string
 	name      string
 	namespace string
 }
@@ -18,15 +22,35 @@ func newKubeResourceKey(namespace string, name string) kubeResourceKey {
 	return kubeResourceKey{name: name, namespace: namespace}
 }
 
+func newKindResourceKey(kind string, namespace string, name string) kubeResourceKey {
+	return kubeResourceKey{kind: kind, name: name, namespace: namespace}
+}
+
 func kubeResourceFromKey(key string) (kubeResourceKey, error) {
+	// The keys of the resources other than collectors are prefixed with their lowercase kind
+	var kind string
+	if prefix, rest, found := strings.Cut(key, ":"); found {
+		for _, resourceKind := range operator.ResourceKinds {
+			if prefix == strings.ToLower(resourceKind) {
+				kind = resourceKind
+			}
+		}
+		if kind == "" {
+			return kubeResourceKey{}, fmt.Errorf("invalid key, unknown kind %s", prefix)
+		}
+		key = rest
+	}
 	s := strings.Split(key, "/")
 	// We expect map keys to be of the form name/namespace
 	if len(s) != 2 {
 		return kubeResourceKey{}, errors.New("invalid key")
 	}
-	return newKubeResourceKey(s[0], s[1]), nil
+	return newKindResourceKey(kind, s[0], s[1]), nil
 }
 
 func (k kubeResourceKey) String() string {
+	if k.kind != "" {
+		return fmt.Sprintf("%s:%s/%s", strings.ToLower(k.kind), k.namespace, k.name)
+	}
 	return fmt.Sprintf("%s/%s", k.namespace, k.name)
 }
@@ -31,6 +31,26 @@ func Test_collectorKeyFromKey(t *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "target allocator",
+			args: args{
+				key: "targetallocator:namespace/good",
+			},
+			want: kubeResourceKey{
+				kind:      "TargetAllocator",
+				name:      "good",
+				namespace: "namespace",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "unknown kind",
+			args: args{
+				key: "deployment:namespace/good",
+			},
+			want:    kubeResourceKey{},
+			wantErr: assert.Error,
+		},
 		{
 			name: "unable to get key",
 			args: args{
@@ -61,6 +81,7 @@ func Test_collectorKeyFromKey(t *testing.T) {
 
 func Test_collectorKey_String(t *testing.T) {
 	type fields struct {
+		kind      string
 		name      string
 		namespace string
 	}
@@ -77,10 +98,19 @@ func Test_collectorKey_String(t *testing.T) {
 			},
 			want: "namespace/good",
 		},
+		{
+			name: "can make an instrumentation key",
+			fields: fields{
+				kind:      "Instrumentation",
+				name:      "good",
+				namespace: "namespace",
+			},
+			want: "instrumentation:namespace/good",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			k := newKubeResourceKey(tt.fields.namespace, tt.fields.name)
+			k := newKindResourceKey(tt.fields.kind, tt.fields.namespace, tt.fields.name)
 			assert.Equalf(t, tt.want, k.String(), "String()")
 		})
 	}
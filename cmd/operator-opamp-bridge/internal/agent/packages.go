@@ -0,0 +1,338 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/imageversion"
+)
+
+// errPackageContent is returned when the client tries to download a package: the packages offered by the server are
+// the images of the collectors, which are pulled by the cluster.
+var errPackageContent = errors.New("the packages are collector images, their content isn't downloaded by the bridge")
+
+// packagesState is the state of the packages offered by the server, one per managed collector, named after its
+// <namespace>/<name> key. It's used by the client to report the package statuses it saves, when it connects to the
+// server.
+type packagesState struct {
+	mu              sync.Mutex
+	allPackagesHash []byte
+	statuses        *protobufs.PackageStatuses
+	packages        map[string]types.PackageState
+}
+
+var _ types.PackagesStateProvider = &packagesState{}
+
+func (p *packagesState) AllPackagesHash() ([]byte, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.allPackagesHash, nil
+}
+
+func (p *packagesState) SetAllPackagesHash(hash []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.allPackagesHash = hash
+	return nil
+}
+
+func (p *packagesState) Packages() ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var names []string
+	for name := range p.packages {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func (p *packagesState) PackageState(packageName string) (types.PackageState, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.packages[packageName], nil
+}
+
+func (p *packagesState) SetPackageState(packageName string, state types.PackageState) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.packages == nil {
+		p.packages = map[string]types.PackageState{}
+	}
+	p.packages[packageName] = state
+	return nil
+}
+
+func (p *packagesState) CreatePackage(_ string, _ protobufs.PackageType) error {
+	return errPackageContent
+}
+
+func (p *packagesState) FileContentHash(_ string) ([]byte, error) {
+	return nil, nil
+}
+
+func (p *packagesState) UpdateContent(_ context.Context, _ string, _ io.Reader, _ []byte) error {
+	return errPackageContent
+}
+
+func (p *packagesState) DeletePackage(packageName string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.packages, packageName)
+	return nil
+}
+
+func (p *packagesState) LastReportedStatuses() (*protobufs.PackageStatuses, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.statuses == nil {
+		return nil, nil
+	}
+	return proto.Clone(p.statuses).(*protobufs.PackageStatuses), nil
+}
+
+func (p *packagesState) SetLastReportedStatuses(statuses *protobufs.PackageStatuses) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.statuses = statuses
+	return nil
+}
+
+// packagesStateProvider returns the state of the packages the client reports, nil when the server can't offer
+// packages.
+func (agent *Agent) packagesStateProvider() types.PackagesStateProvider {
+	if !agent.config.PackagesEnabled() {
+		return nil
+	}
+	return agent.packages
+}
+
+// syncPackages switches the collectors to the images offered as packages by the server, and reports the status of
+// each package. The packages are installing until their collectors are rolled out, and are checked until then. The
+// packages are synced again when the server offers them with a different hash, or when one of them failed to be
+// installed.
+func (agent *Agent) syncPackages(ctx context.Context, available *protobufs.PackagesAvailable) {
+	allPackagesHash := available.GetAllPackagesHash()
+	if allPackagesHash == nil {
+		allPackagesHash = []byte{}
+	}
+	lastHash, _ := agent.packages.AllPackagesHash()
+	if lastHash != nil && bytes.Equal(lastHash, allPackagesHash) {
+		return
+	}
+
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+	// newer packages replace the ones being installed
+	agent.stopPackagesInstall()
+	statuses, installing := agent.installPackages(available)
+	agent.reportPackageStatuses(ctx, statuses)
+	if installing {
+		watchCtx, cancel := context.WithCancel(context.Background())
+		agent.cancelPackagesInstall = cancel
+		go agent.watchPackagesInstall(watchCtx, available)
+	}
+}
+
+// installPackages installs the packages, and returns their statuses and whether some of them are still installing.
+//
+// INVARIANT: The caller must hold the lock of the agent.
+func (agent *Agent) installPackages(available *protobufs.PackagesAvailable) (*protobufs.PackageStatuses, bool) {
+	allPackagesHash := available.GetAllPackagesHash()
+	if allPackagesHash == nil {
+		allPackagesHash = []byte{}
+	}
+	statuses := &protobufs.PackageStatuses{
+		Packages:                      map[string]*protobufs.PackageStatus{},
+		ServerProvidedAllPackagesHash: allPackagesHash,
+	}
+	installing, failed := false, false
+	for name, pkg := range available.GetPackages() {
+		status := agent.installPackage(name, pkg)
+		statuses.Packages[name] = status
+		switch status.GetStatus() {
+		case protobufs.PackageStatusEnum_PackageStatusEnum_Installing:
+			installing = true
+			continue
+		case protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed:
+			failed = true
+			continue
+		}
+		_ = agent.packages.SetPackageState(name, types.PackageState{
+			Exists:  true,
+			Type:    pkg.GetType(),
+			Hash:    pkg.GetHash(),
+			Version: status.GetAgentHasVersion(),
+		})
+	}
+	if failed {
+		statuses.ErrorMessage = "some packages failed to be installed"
+	} else if !installing {
+		_ = agent.packages.SetAllPackagesHash(allPackagesHash)
+	}
+	_ = agent.packages.SetLastReportedStatuses(statuses)
+	return statuses, installing
+}
+
+// reportPackageStatuses reports the statuses of the packages, and the effective configuration using their images.
+func (agent *Agent) reportPackageStatuses(ctx context.Context, statuses *protobufs.PackageStatuses) {
+	err := agent.client().SetPackageStatuses(statuses)
+	if err != nil {
+		agent.logger.Error(err, "failed to set package statuses")
+		return
+	}
+	err = agent.client().UpdateEffectiveConfig(ctx)
+	if err != nil {
+		agent.logger.Error(err, "failed to update effective config")
+	}
+}
+
+// stopPackagesInstall stops checking the install of the previous packages.
+//
+// INVARIANT: The caller must hold the lock of the agent.
+func (agent *Agent) stopPackagesInstall() {
+	if agent.cancelPackagesInstall != nil {
+		agent.cancelPackagesInstall()
+		agent.cancelPackagesInstall = nil
+	}
+}
+
+// watchPackagesInstall checks the packages until none of them is installing anymore, and reports their statuses.
+func (agent *Agent) watchPackagesInstall(ctx context.Context, available *protobufs.PackagesAvailable) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-agent.done:
+			return
+		case <-agent.clock.After(rolloutCheckInterval):
+		}
+		if agent.checkPackagesInstall(ctx, available) {
+			return
+		}
+	}
+}
+
+// checkPackagesInstall installs the packages again, and reports their statuses once none of them is installing
+// anymore. It returns whether the install is over.
+func (agent *Agent) checkPackagesInstall(ctx context.Context, available *protobufs.PackagesAvailable) bool {
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+	// the packages were replaced by newer ones
+	if ctx.Err() != nil {
+		return true
+	}
+	statuses, installing := agent.installPackages(available)
+	if installing {
+		return false
+	}
+	agent.stopPackagesInstall()
+	agent.reportPackageStatuses(ctx, statuses)
+	return true
+}
+
+// installPackage sets the image of the collector the package is named after, and returns the status of the package,
+// installed once the collector is rolled out.
+//
+// INVARIANT: The caller must hold the lock of the agent.
+func (agent *Agent) installPackage(name string, pkg *protobufs.PackageAvailable) *protobufs.PackageStatus {
+	status := &protobufs.PackageStatus{
+		Name:                 name,
+		ServerOfferedVersion: pkg.GetVersion(),
+		ServerOfferedHash:    pkg.GetHash(),
+	}
+	failed := func(err error) *protobufs.PackageStatus {
+		agent.logger.Error(err, "failed to install package", "package", name)
+		status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed
+		status.ErrorMessage = err.Error()
+		return status
+	}
+
+	if pkg.GetType() != protobufs.PackageType_PackageType_TopLevel {
+		return failed(fmt.Errorf("the package %s isn't a top-level package", name))
+	}
+	key, err := kubeResourceFromKey(name)
+	if err != nil || key.kind != "" {
+		return failed(fmt.Errorf("the package %s isn't named after the <namespace>/<name> key of a collector", name))
+	}
+	instance, err := agent.applier.GetInstance(key.name, key.namespace)
+	if err != nil {
+		return failed(err)
+	}
+	if instance == nil {
+		return failed(fmt.Errorf("the collector %s doesn't exist", name))
+	}
+	status.AgentHasVersion = imageversion.FromImage(instance.Spec.Image)
+
+	image, err := packageImage(instance.Spec.Image, pkg)
+	if err != nil {
+		return failed(fmt.Errorf("the collector %s can't be switched to the package: %w", name, err))
+	}
+	if image != instance.Spec.Image {
+		err = agent.applier.SetImage(key.name, key.namespace, image)
+		if err != nil {
+			return failed(err)
+		}
+		// the collector keeps the new image when it is rolled back
+		if _, ok := agent.lastGoodConfigs[key]; ok {
+			file, err := agent.getConfigFile(key)
+			if err == nil && file != nil {
+				agent.lastGoodConfigs[key] = file
+			}
+		}
+		status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_Installing
+		return status
+	}
+	complete, err := operator.RolloutComplete(instance)
+	if err != nil {
+		return failed(fmt.Errorf("the collector %s failed to be rolled out: %w", name, err))
+	}
+	if !complete {
+		status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_Installing
+		return status
+	}
+
+	status.Status = protobufs.PackageStatusEnum_PackageStatusEnum_Installed
+	status.AgentHasVersion = pkg.GetVersion()
+	if status.AgentHasVersion == "" {
+		status.AgentHasVersion = imageversion.FromImage(image)
+	}
+	status.AgentHasHash = pkg.GetHash()
+	return status
+}
+
+// packageImage returns the image offered by the package: the download URL of its file when set, otherwise the image
+// of the collector with the version of the package as tag.
+func packageImage(currentImage string, pkg *protobufs.PackageAvailable) (string, error) {
+	if image := pkg.GetFile().GetDownloadUrl(); image != "" {
+		return image, nil
+	}
+	if pkg.GetVersion() == "" {
+		return "", errors.New("the package sets neither a version nor an image")
+	}
+	if currentImage == "" {
+		return "", errors.New("the collector uses the default image of the operator, the package must set the image as the download URL of its file")
+	}
+	return imageRepository(currentImage) + ":" + pkg.GetVersion(), nil
+}
+
+// imageRepository returns the image without its tag and digest.
+func imageRepository(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		return image[:i]
+	}
+	return image
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testingclock "k8s.io/utils/clock/testing"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+)
+
+const collectorImage = "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-contrib:0.110.0"
+
+func TestAgent_syncPackages(t *testing.T) {
+	schemeBuilder := runtime.NewSchemeBuilder(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(v1beta1.GroupVersion, &v1beta1.OpenTelemetryCollector{}, &v1beta1.OpenTelemetryCollectorList{})
+		s.AddKnownTypes(v1.SchemeGroupVersion, &v1.Pod{}, &v1.PodList{})
+		metav1.AddToGroupVersion(s, v1beta1.GroupVersion)
+		return nil
+	})
+	scheme := runtime.NewScheme()
+	require.NoError(t, schemeBuilder.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	mockClient := &mockOpampClient{}
+	conf := config.NewConfig(logr.Discard())
+	require.NoError(t, config.LoadFromFile(conf, agentTestFileName))
+	conf.Capabilities[config.AcceptsPackages] = true
+	conf.Capabilities[config.ReportsPackageStatuses] = true
+
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	applier := operator.NewClient("test-bridge", l, k8sClient, conf.GetComponentsAllowed())
+	agent := NewAgent(l, applier, conf, mockClient, newMockProxy(nil, nil))
+	agent.clock = fakeClock
+	require.NoError(t, agent.Start(), "should be able to start agent")
+	defer agent.Shutdown()
+	assert.Equal(t, agent.packages, mockClient.settings.PackagesStateProvider)
+
+	// packageStatuses returns the last package statuses reported
+	packageStatuses := func() *protobufs.PackageStatuses {
+		mockClient.mu.Lock()
+		defer mockClient.mu.Unlock()
+		return mockClient.lastPackageStatuses
+	}
+	// rollOut sets the Ready condition the operator sets on the rolled out collector, and waits for the agent to
+	// report the package of the collector as installed
+	rollOut := func() *protobufs.PackageStatus {
+		col := &v1beta1.OpenTelemetryCollector{}
+		require.NoError(t, k8sClient.Get(context.Background(), runtimeClient.ObjectKey{Namespace: testNamespace, Name: testCollectorName}, col))
+		col.Status.ObservedGeneration = col.Generation
+		col.Status.Conditions = []metav1.Condition{{Type: v1beta1.ConditionReady, Status: metav1.ConditionTrue, Reason: v1beta1.ReasonReady}}
+		require.NoError(t, k8sClient.Update(context.Background(), col))
+		require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+		fakeClock.Step(rolloutCheckInterval)
+		require.Eventually(t, func() bool {
+			return packageStatuses().GetPackages()[testCollectorKey].GetStatus() != protobufs.PackageStatusEnum_PackageStatusEnum_Installing
+		}, time.Second, time.Millisecond)
+		return packageStatuses().GetPackages()[testCollectorKey]
+	}
+
+	data, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+	require.NoError(t, err, "should be able to load data")
+	agent.onMessage(context.Background(), data)
+	require.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, mockClient.lastStatus.GetStatus())
+
+	// the collector using the default image of the operator is switched to the image of the package, which is
+	// installing until the collector is rolled out
+	agent.onMessage(context.Background(), newPackagesMessage("first", map[string]*protobufs.PackageAvailable{
+		testCollectorKey:  {Type: protobufs.PackageType_PackageType_TopLevel, Version: "0.110.0", File: &protobufs.DownloadableFile{DownloadUrl: collectorImage}, Hash: []byte("first")},
+		otherCollectorKey: {Type: protobufs.PackageType_PackageType_TopLevel, Version: "0.110.0"},
+	}))
+	assert.Equal(t, []byte("first"), packageStatuses().GetServerProvidedAllPackagesHash())
+	assert.Equal(t, &protobufs.PackageStatus{
+		Name:                 testCollectorKey,
+		ServerOfferedVersion: "0.110.0",
+		ServerOfferedHash:    []byte("first"),
+		Status:               protobufs.PackageStatusEnum_PackageStatusEnum_Installing,
+	}, packageStatuses().GetPackages()[testCollectorKey])
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, packageStatuses().GetPackages()[otherCollectorKey].GetStatus())
+	assert.Equal(t, "the collector testnamespace/other doesn't exist", packageStatuses().GetPackages()[otherCollectorKey].GetErrorMessage())
+	instance, err := applier.GetInstance(testCollectorName, testNamespace)
+	require.NoError(t, err)
+	assert.Equal(t, collectorImage, instance.Spec.Image)
+	assert.Contains(t, string(mockClient.lastEffectiveConfig.GetConfigMap().GetConfigMap()[testCollectorKey].GetBody()), "image: "+collectorImage)
+	assert.Equal(t, &protobufs.PackageStatus{
+		Name:                 testCollectorKey,
+		AgentHasVersion:      "0.110.0",
+		AgentHasHash:         []byte("first"),
+		ServerOfferedVersion: "0.110.0",
+		ServerOfferedHash:    []byte("first"),
+		Status:               protobufs.PackageStatusEnum_PackageStatusEnum_Installed,
+	}, rollOut())
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, packageStatuses().GetPackages()[otherCollectorKey].GetStatus())
+
+	// the version of the package replaces the tag of the image
+	agent.onMessage(context.Background(), newPackagesMessage("second", map[string]*protobufs.PackageAvailable{
+		testCollectorKey: {Type: protobufs.PackageType_PackageType_TopLevel, Version: "0.111.0", Hash: []byte("second")},
+	}))
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_Installing, packageStatuses().GetPackages()[testCollectorKey].GetStatus())
+	lastHash, err := agent.packages.AllPackagesHash()
+	require.NoError(t, err)
+	assert.Nil(t, lastHash)
+	instance, err = applier.GetInstance(testCollectorName, testNamespace)
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-contrib:0.111.0", instance.Spec.Image)
+	status := rollOut()
+	assert.Equal(t, protobufs.PackageStatusEnum_PackageStatusEnum_Installed, status.GetStatus())
+	assert.Equal(t, "0.111.0", status.GetAgentHasVersion())
+	assert.Empty(t, packageStatuses().GetErrorMessage())
+	lastHash, err = agent.packages.AllPackagesHash()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), lastHash)
+
+	// packages which aren't named after a collector are rejected
+	agent.onMessage(context.Background(), newPackagesMessage("third", map[string]*protobufs.PackageAvailable{
+		"instrumentation:" + testCollectorKey: {Type: protobufs.PackageType_PackageType_TopLevel, Version: "0.111.0"},
+		testCollectorKey:                      {Type: protobufs.PackageType_PackageType_Addon, Version: "0.111.0"},
+	}))
+	assert.Equal(t, "some packages failed to be installed", packageStatuses().GetErrorMessage())
+	assert.Equal(t, "the package instrumentation:testnamespace/collector isn't named after the <namespace>/<name> key of a collector",
+		packageStatuses().GetPackages()["instrumentation:"+testCollectorKey].GetErrorMessage())
+	assert.Equal(t, "the package testnamespace/collector isn't a top-level package",
+		packageStatuses().GetPackages()[testCollectorKey].GetErrorMessage())
+}
+
+func newPackagesMessage(hash string, packages map[string]*protobufs.PackageAvailable) *types.MessageData {
+	return &types.MessageData{PackagesAvailable: &protobufs.PackagesAvailable{Packages: packages, AllPackagesHash: []byte(hash)}}
+}
+
+func TestPackageImage(t *testing.T) {
+	tests := []struct {
+		name         string
+		currentImage string
+		pkg          *protobufs.PackageAvailable
+		want         string
+		wantErr      string
+	}{
+		{
+			name:         "download url",
+			currentImage: "otel/opentelemetry-collector:0.110.0",
+			pkg:          &protobufs.PackageAvailable{Version: "0.111.0", File: &protobufs.DownloadableFile{DownloadUrl: "otel/opentelemetry-collector-contrib:0.111.0"}},
+			want:         "otel/opentelemetry-collector-contrib:0.111.0",
+		},
+		{
+			name:         "version",
+			currentImage: "otel/opentelemetry-collector:0.110.0",
+			pkg:          &protobufs.PackageAvailable{Version: "0.111.0"},
+			want:         "otel/opentelemetry-collector:0.111.0",
+		},
+		{
+			name:         "version of an image with a registry port and a digest",
+			currentImage: "registry:5000/otel/collector:0.110.0@sha256:00738c3a6bca8f143995c9c89fd0c1976784d9785ea394fcdfe580fb18754e1e",
+			pkg:          &protobufs.PackageAvailable{Version: "0.111.0"},
+			want:         "registry:5000/otel/collector:0.111.0",
+		},
+		{
+			name:         "version of an image without tag",
+			currentImage: "registry:5000/otel/collector",
+			pkg:          &protobufs.PackageAvailable{Version: "0.111.0"},
+			want:         "registry:5000/otel/collector:0.111.0",
+		},
+		{
+			name:    "version of the default image",
+			pkg:     &protobufs.PackageAvailable{Version: "0.111.0"},
+			wantErr: "the collector uses the default image of the operator, the package must set the image as the download URL of its file",
+		},
+		{
+			name:         "no version",
+			currentImage: "otel/opentelemetry-collector:0.110.0",
+			pkg:          &protobufs.PackageAvailable{},
+			wantErr:      "the package sets neither a version nor an image",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := packageImage(tt.currentImage, tt.pkg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// checkKind returns an error when the bridge doesn't manage the kind of the resource.
+func (agent *Agent) checkKind(key kubeResourceKey) error {
+	if key.kind != "" && !agent.config.ManagesKind(key.kind) {
+		return fmt.Errorf("the bridge doesn't manage the %s resources, %s can't be applied", key.kind, key.String())
+	}
+	return nil
+}
+
+// validateResource checks that the configuration of the collector or of the resource can be applied.
+func (agent *Agent) validateResource(key kubeResourceKey, file *protobufs.AgentConfigFile) error {
+	if err := agent.checkKind(key); err != nil {
+		return err
+	}
+	if key.kind == "" {
+		return agent.applier.Validate(key.name, key.namespace, file)
+	}
+	return agent.applier.ValidateResource(key.kind, key.name, key.namespace, file)
+}
+
+// applyResource applies the configuration of the collector or of the resource.
+func (agent *Agent) applyResource(key kubeResourceKey, file *protobufs.AgentConfigFile) error {
+	if err := agent.checkKind(key); err != nil {
+		return err
+	}
+	if key.kind == "" {
+		return agent.applier.Apply(key.name, key.namespace, file)
+	}
+	return agent.applier.ApplyResource(key.kind, key.name, key.namespace, file)
+}
+
+// deleteResource deletes the collector or the resource.
+func (agent *Agent) deleteResource(key kubeResourceKey) error {
+	if key.kind == "" {
+		return agent.applier.Delete(key.name, key.namespace)
+	}
+	return agent.applier.DeleteResource(key.kind, key.name, key.namespace)
+}
+
+// getConfigFile returns the config file applying the current spec of the collector or of the resource, nil when it
+// doesn't exist.
+func (agent *Agent) getConfigFile(key kubeResourceKey) (*protobufs.AgentConfigFile, error) {
+	if key.kind == "" {
+		instance, err := agent.applier.GetInstance(key.name, key.namespace)
+		if err != nil {
+			return nil, err
+		}
+		return configFileOf(instance)
+	}
+	resource, err := agent.applier.GetResource(key.kind, key.name, key.namespace)
+	if err != nil {
+		return nil, err
+	}
+	return resourceConfigFileOf(resource)
+}
+
+// resourceConfigFileOf returns the config file applying the spec, the labels and the annotations of the resource, nil
+// when the resource doesn't exist.
+func resourceConfigFileOf(resource client.Object) (*protobufs.AgentConfigFile, error) {
+	if resource == nil {
+		return nil, nil
+	}
+	objectMeta := metav1.ObjectMeta{
+		Labels:      resource.GetLabels(),
+		Annotations: resource.GetAnnotations(),
+	}
+	var trimmed client.Object
+	switch resource := resource.(type) {
+	case *v1alpha1.TargetAllocator:
+		trimmed = &v1alpha1.TargetAllocator{TypeMeta: resource.TypeMeta, ObjectMeta: objectMeta, Spec: resource.Spec}
+	case *v1alpha1.Instrumentation:
+		trimmed = &v1alpha1.Instrumentation{TypeMeta: resource.TypeMeta, ObjectMeta: objectMeta, Spec: resource.Spec}
+	default:
+		return nil, fmt.Errorf("unsupported resource %T", resource)
+	}
+	body, err := yaml.Marshal(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	return &protobufs.AgentConfigFile{Body: body, ContentType: "yaml"}, nil
+}
+
+// getResourcesEffectiveConfig adds the resources of the kinds the bridge manages to the effective configuration,
+// under their <kind>:<namespace>/<name> keys.
+func (agent *Agent) getResourcesEffectiveConfig(instanceMap map[string]*protobufs.AgentConfigFile) error {
+	for _, kind := range agent.config.ManagedKinds {
+		resources, err := agent.applier.ListResources(kind)
+		if err != nil {
+			agent.logger.Error(err, "failed to list resources", "kind", kind)
+			return err
+		}
+		for _, resource := range resources {
+			marshaled, err := yaml.Marshal(resource)
+			if err != nil {
+				agent.logger.Error(err, "failed to marshal resource", "kind", kind)
+				return err
+			}
+			mapKey := newKindResourceKey(kind, resource.GetNamespace(), resource.GetName())
+			instanceMap[mapKey.String()] = &protobufs.AgentConfigFile{
+				Body:        marshaled,
+				ContentType: "yaml",
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+)
+
+const (
+	targetAllocatorFile = "testdata/targetallocator.yaml"
+	instrumentationFile = "testdata/instrumentation.yaml"
+
+	testTargetAllocatorKey = "targetallocator:" + testNamespace + "/" + testCollectorName
+	testInstrumentationKey = "instrumentation:" + testNamespace + "/" + testCollectorName
+)
+
+func TestAgent_applyResources(t *testing.T) {
+	tests := []struct {
+		name         string
+		managedKinds []string
+		wantStatus   protobufs.RemoteConfigStatuses
+		wantError    string
+		wantKeys     []string
+	}{
+		{
+			name:         "manages the resources",
+			managedKinds: []string{operator.TargetAllocatorResource, operator.InstrumentationResource},
+			wantStatus:   protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+			wantKeys:     []string{testCollectorKey, testTargetAllocatorKey, testInstrumentationKey},
+		},
+		{
+			name:         "doesn't manage the instrumentations",
+			managedKinds: []string{operator.TargetAllocatorResource},
+			wantStatus:   protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+			wantError:    "the bridge doesn't manage the Instrumentation resources, instrumentation:testnamespace/collector can't be applied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockOpampClient{}
+			conf := config.NewConfig(logr.Discard())
+			require.NoError(t, config.LoadFromFile(conf, agentTestFileName))
+			conf.ManagedKinds = tt.managedKinds
+
+			applier := getFakeApplier(t, conf)
+			agent := NewAgent(l, applier, conf, mockClient, newMockProxy(nil, nil))
+			require.NoError(t, agent.Start(), "should be able to start agent")
+			defer agent.Shutdown()
+
+			data, err := getMessageDataFromConfigFile(map[string]string{
+				testCollectorKey:       collectorBasicFile,
+				testTargetAllocatorKey: targetAllocatorFile,
+				testInstrumentationKey: instrumentationFile,
+			})
+			require.NoError(t, err, "should be able to load data")
+			agent.onMessage(context.Background(), data)
+			assert.Equal(t, tt.wantStatus, mockClient.lastStatus.GetStatus())
+			assert.Contains(t, mockClient.lastStatus.GetErrorMessage(), tt.wantError)
+
+			effectiveConfig, err := agent.getEffectiveConfig(context.Background())
+			require.NoError(t, err, "should be able to get effective config")
+			configMap := effectiveConfig.ConfigMap.GetConfigMap()
+			assert.Len(t, configMap, len(tt.wantKeys))
+			for _, key := range tt.wantKeys {
+				assert.Contains(t, configMap, key)
+			}
+			if tt.wantStatus != protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED {
+				return
+			}
+			assert.Contains(t, string(configMap[testTargetAllocatorKey].GetBody()), "allocationStrategy: consistent-hashing")
+			assert.Contains(t, string(configMap[testInstrumentationKey].GetBody()), "endpoint: http://otel-collector:4317")
+
+			// the resources removed from the remote configuration are deleted
+			data, err = getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+			require.NoError(t, err, "should be able to load data")
+			agent.onMessage(context.Background(), data)
+			assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, mockClient.lastStatus.GetStatus())
+			resources, err := applier.ListResources(operator.InstrumentationResource)
+			require.NoError(t, err)
+			assert.Empty(t, resources)
+		})
+	}
+}
+
+func TestResourceConfigFileOf(t *testing.T) {
+	file, err := resourceConfigFileOf(nil)
+	require.NoError(t, err)
+	assert.Nil(t, file)
+
+	instrumentation := &v1alpha1.Instrumentation{}
+	instrumentation.Kind = operator.InstrumentationResource
+	instrumentation.Name = "instrumentation"
+	instrumentation.Labels = map[string]string{operator.ManagedLabelKey: "true"}
+	instrumentation.ResourceVersion = "42"
+	instrumentation.Spec.Exporter.Endpoint = "http://otel-collector:4317"
+	file, err = resourceConfigFileOf(instrumentation)
+	require.NoError(t, err)
+	body := string(file.GetBody())
+	assert.Contains(t, body, "kind: Instrumentation")
+	assert.Contains(t, body, "opentelemetry.io/opamp-managed: \"true\"")
+	assert.Contains(t, body, "endpoint: http://otel-collector:4317")
+	assert.NotContains(t, body, "resourceVersion")
+	assert.NotContains(t, body, "name: instrumentation")
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/multierr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+)
+
+// rolloutCheckInterval is the interval the collectors are checked at while a remote configuration is rolled out.
+const rolloutCheckInterval = 5 * time.Second
+
+// rollout is a remote configuration being rolled out to the collectors.
+type rollout struct {
+	// files are the configurations applied to the collectors.
+	files map[kubeResourceKey]*protobufs.AgentConfigFile
+	// previous are the configurations the collectors are rolled back to, nil for the collectors to delete.
+	previous map[kubeResourceKey]*protobufs.AgentConfigFile
+}
+
+// newRollout returns the rollout of the configurations applied to the collectors and to the other resources, and of
+// the deleted ones, which are rolled back to their last good configuration, or to the one they have before the rollout when there's none.
+func (agent *Agent) newRollout(files map[kubeResourceKey]*protobufs.AgentConfigFile, deleted []kubeResourceKey) (*rollout, error) {
+	r := &rollout{files: files, previous: map[kubeResourceKey]*protobufs.AgentConfigFile{}}
+	keys := deleted
+	for key := range files {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		if file, ok := agent.lastGoodConfigs[key]; ok {
+			r.previous[key] = file
+			continue
+		}
+		var err error
+		r.previous[key], err = agent.getConfigFile(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// configFileOf returns the config file applying the spec, the labels and the annotations of the collector, nil when
+// the collector doesn't exist.
+func configFileOf(collector *v1beta1.OpenTelemetryCollector) (*protobufs.AgentConfigFile, error) {
+	if collector == nil {
+		return nil, nil
+	}
+	body, err := yaml.Marshal(&v1beta1.OpenTelemetryCollector{
+		TypeMeta: collector.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      collector.GetLabels(),
+			Annotations: collector.GetAnnotations(),
+		},
+		Spec: collector.Spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &protobufs.AgentConfigFile{Body: body, ContentType: "yaml"}, nil
+}
+
+// stopRollout stops checking the rollout of the previous remote configuration.
+//
+// INVARIANT: The caller must hold the lock of the agent.
+func (agent *Agent) stopRollout() {
+	if agent.cancelRollout != nil {
+		agent.cancelRollout()
+		agent.cancelRollout = nil
+	}
+}
+
+// watchRollout checks the collectors until they are rolled out, or until the rollout fails or times out, and reports
+// the status of the remote configuration.
+func (agent *Agent) watchRollout(ctx context.Context, r *rollout) {
+	deadline := agent.clock.Now().Add(agent.config.RolloutTimeout)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-agent.done:
+			return
+		case <-agent.clock.After(rolloutCheckInterval):
+		}
+		complete, err := agent.rolloutComplete(r)
+		if err == nil && !complete && !agent.clock.Now().Before(deadline) {
+			err = fmt.Errorf("the collectors weren't rolled out within %s", agent.config.RolloutTimeout)
+		}
+		if err == nil && !complete {
+			continue
+		}
+		agent.finishRollout(ctx, r, err)
+		return
+	}
+}
+
+// rolloutComplete tells whether all the collectors the configuration was applied to are rolled out, and returns an
+// error when one of them failed to.
+func (agent *Agent) rolloutComplete(r *rollout) (bool, error) {
+	complete := true
+	for key := range r.files {
+		// the other resources are rolled out once applied
+		if key.kind != "" {
+			resource, err := agent.applier.GetResource(key.kind, key.name, key.namespace)
+			if err != nil {
+				agent.logger.Error(err, "failed to get the resource", "resource", key.String())
+				return false, nil
+			}
+			if resource == nil {
+				return false, fmt.Errorf("the %s %s was deleted", key.kind, key.String())
+			}
+			continue
+		}
+		instance, err := agent.applier.GetInstance(key.name, key.namespace)
+		if err != nil {
+			agent.logger.Error(err, "failed to get the collector", "collector", key.String())
+			return false, nil
+		}
+		if instance == nil {
+			return false, fmt.Errorf("the collector %s was deleted", key.String())
+		}
+		instanceComplete, err := operator.RolloutComplete(instance)
+		if err != nil {
+			return false, err
+		}
+		complete = complete && instanceComplete
+	}
+	return complete, nil
+}
+
+// finishRollout records the configurations of the collectors as good when they are rolled out, rolls them back
+// otherwise, and reports the status of the remote configuration.
+func (agent *Agent) finishRollout(ctx context.Context, r *rollout, rolloutErr error) {
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+	// the rollout was replaced by a newer configuration
+	if ctx.Err() != nil {
+		return
+	}
+	agent.stopRollout()
+
+	var status *protobufs.RemoteConfigStatus
+	if rolloutErr != nil {
+		agent.logger.Error(rolloutErr, "failed to roll out the remote config")
+		status = agent.newRemoteConfigStatus(agent.rollback(r, rolloutErr))
+	} else {
+		for key := range r.previous {
+			if file, ok := r.files[key]; ok {
+				agent.lastGoodConfigs[key] = file
+			} else {
+				delete(agent.lastGoodConfigs, key)
+			}
+		}
+		status = agent.newRemoteConfigStatus(nil)
+	}
+
+	err := agent.client().SetRemoteConfigStatus(status)
+	if err != nil {
+		agent.logger.Error(err, "failed to set remote config status")
+		return
+	}
+	err = agent.client().UpdateEffectiveConfig(ctx)
+	if err != nil {
+		agent.logger.Error(err, "failed to update effective config")
+	}
+}
+
+// rollback restores the configurations the collectors had before the rollout, and returns the error reporting the
+// failed rollout.
+//
+// INVARIANT: The caller must hold the lock of the agent.
+func (agent *Agent) rollback(r *rollout, rolloutErr error) error {
+	var multiErr error
+	for key, file := range r.previous {
+		if file == nil {
+			err := agent.deleteResource(key)
+			if err != nil {
+				multiErr = multierr.Append(multiErr, err)
+				continue
+			}
+			delete(agent.appliedKeys, key)
+			continue
+		}
+		err := agent.applyResource(key, file)
+		if err != nil {
+			multiErr = multierr.Append(multiErr, err)
+			continue
+		}
+		agent.appliedKeys[key] = true
+	}
+	if multiErr != nil {
+		return fmt.Errorf("%w, and the collectors failed to be rolled back: %w", rolloutErr, multiErr)
+	}
+	return fmt.Errorf("%w, the collectors were rolled back to their last good configuration", rolloutErr)
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testingclock "k8s.io/utils/clock/testing"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/operator"
+)
+
+func TestAgent_rollout(t *testing.T) {
+	schemeBuilder := runtime.NewSchemeBuilder(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(v1beta1.GroupVersion, &v1beta1.OpenTelemetryCollector{}, &v1beta1.OpenTelemetryCollectorList{})
+		s.AddKnownTypes(v1.SchemeGroupVersion, &v1.Pod{}, &v1.PodList{})
+		metav1.AddToGroupVersion(s, v1beta1.GroupVersion)
+		return nil
+	})
+	scheme := runtime.NewScheme()
+	require.NoError(t, schemeBuilder.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	conf := config.NewConfig(l)
+	require.NoError(t, config.LoadFromFile(conf, agentTestFileName))
+	conf.RolloutTimeout = time.Minute
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	mockClient := &mockOpampClient{}
+	agent := NewAgent(l, operator.NewClient("test-bridge", l, k8sClient, conf.GetComponentsAllowed()), conf, mockClient, newMockProxy(nil, nil))
+	agent.clock = fakeClock
+	require.NoError(t, agent.Start())
+	defer agent.Shutdown()
+
+	// setReady sets the Ready condition the operator would set on the collector
+	setReady := func(status metav1.ConditionStatus, reason string) {
+		col := &v1beta1.OpenTelemetryCollector{}
+		require.NoError(t, k8sClient.Get(context.Background(), runtimeClient.ObjectKey{Namespace: testNamespace, Name: testCollectorName}, col))
+		col.Status.ObservedGeneration = col.Generation
+		col.Status.Conditions = []metav1.Condition{{Type: v1beta1.ConditionReady, Status: status, Reason: reason, Message: "the collector pods are crashing"}}
+		require.NoError(t, k8sClient.Update(context.Background(), col))
+	}
+	// checkRollout waits for the agent to check the rollout, and returns the status it reports
+	checkRollout := func(step time.Duration) *protobufs.RemoteConfigStatus {
+		require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+		fakeClock.Step(step)
+		var status *protobufs.RemoteConfigStatus
+		require.Eventually(t, func() bool {
+			mockClient.mu.Lock()
+			defer mockClient.mu.Unlock()
+			status = mockClient.lastStatus
+			return status.GetStatus() != protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING
+		}, time.Second, time.Millisecond)
+		return status
+	}
+
+	// the collector is created, and is good once it's ready
+	data, err := getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile})
+	require.NoError(t, err)
+	agent.onMessage(context.Background(), data)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, mockClient.lastStatus.GetStatus())
+	setReady(metav1.ConditionTrue, v1beta1.ReasonReady)
+	assert.Equal(t, &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: []byte(basicYamlConfigHash),
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED,
+	}, checkRollout(rolloutCheckInterval))
+
+	// the updated collector is degraded, and is rolled back
+	data, err = getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorUpdatedFile})
+	require.NoError(t, err)
+	agent.onMessage(context.Background(), data)
+	setReady(metav1.ConditionFalse, v1beta1.ReasonDegraded)
+	assert.Equal(t, &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: []byte(updatedYamlConfigHash),
+		Status:               protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED,
+		ErrorMessage:         "the collector testnamespace/collector is degraded: the collector pods are crashing, the collectors were rolled back to their last good configuration",
+	}, checkRollout(rolloutCheckInterval))
+	col := &v1beta1.OpenTelemetryCollector{}
+	require.NoError(t, k8sClient.Get(context.Background(), runtimeClient.ObjectKey{Namespace: testNamespace, Name: testCollectorName}, col))
+	assert.Empty(t, col.Spec.Config.Service.Pipelines["traces"].Processors)
+
+	// the new collector never gets ready, and is deleted
+	data, err = getMessageDataFromConfigFile(map[string]string{testCollectorKey: collectorBasicFile, otherCollectorKey: collectorBasicFile})
+	require.NoError(t, err)
+	agent.onMessage(context.Background(), data)
+	status := checkRollout(time.Minute)
+	assert.Equal(t, protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, status.GetStatus())
+	assert.Contains(t, status.GetErrorMessage(), "the collectors weren't rolled out within 1m0s")
+	instances, err := agent.applier.ListInstances()
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, testCollectorName, instances[0].Name)
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"time"
+)
+
+const (
+	// certificateCheckInterval is the interval the certificate files are checked for rotation at.
+	certificateCheckInterval = 30 * time.Second
+	// clientStopTimeout is the time the OpAMP client has to disconnect from the server before reconnecting.
+	clientStopTimeout = 10 * time.Second
+)
+
+// watchCertificates checks the certificate files until the agent is shut down, and reconnects the agent to the
+// server with the new certificates when they are rotated, e.g. by cert-manager updating the mounted secret.
+func (agent *Agent) watchCertificates() {
+	fingerprint, err := agent.config.TLS.Fingerprint()
+	if err != nil {
+		agent.logger.Error(err, "failed to read the certificates")
+	}
+	for {
+		select {
+		case <-agent.done:
+			agent.logger.Info("stopping check for certificate rotation")
+			return
+		case <-agent.clock.After(certificateCheckInterval):
+		}
+		current, err := agent.config.TLS.Fingerprint()
+		if err != nil {
+			// the files are being updated, or were removed
+			agent.logger.Error(err, "failed to read the certificates")
+			continue
+		}
+		if bytes.Equal(fingerprint, current) {
+			continue
+		}
+		agent.logger.Info("the certificates were rotated, reconnecting to the server")
+		err = agent.reconnect()
+		if err != nil {
+			agent.logger.Error(err, "failed to reconnect to the server")
+			continue
+		}
+		fingerprint = current
+	}
+}
+
+// reconnect replaces the OpAMP client with a new one connected to the server with the current certificates, as the
+// client can't be restarted once stopped.
+func (agent *Agent) reconnect() error {
+	// the certificates are loaded first, not to disconnect the agent when the new ones are invalid
+	if _, err := agent.config.TLS.NewTLSConfig(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), clientStopTimeout)
+	defer cancel()
+	if err := agent.client().Stop(ctx); err != nil {
+		agent.logger.Error(err, "failed to stop client")
+	}
+	opampClient := agent.createClient()
+	if err := agent.startClient(opampClient); err != nil {
+		return err
+	}
+	agent.clientMu.Lock()
+	defer agent.clientMu.Unlock()
+	agent.opampClient = opampClient
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package agent
+
+import (
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testingclock "k8s.io/utils/clock/testing"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/operator-opamp-bridge/internal/config"
+)
+
+const certificatesDir = "../config/testdata/tls"
+
+func TestAgent_watchCertificates(t *testing.T) {
+	// copyFile writes a certificate file of the testdata to the directory the secret is mounted into
+	dir := t.TempDir()
+	copyFile := func(src, dst string) {
+		content, err := os.ReadFile(filepath.Join(certificatesDir, src))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, dst), content, 0600))
+	}
+	copyFile("ca.crt", "ca.crt")
+	copyFile("tls.crt", "tls.crt")
+	copyFile("tls.key", "tls.key")
+
+	conf := config.NewConfig(l)
+	require.NoError(t, config.LoadFromFile(conf, agentTestFileName))
+	conf.TLS = &config.TLS{
+		CAFile:   filepath.Join(dir, "ca.crt"),
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+	}
+	fakeClock := testingclock.NewFakeClock(time.Now())
+	first, second := &mockOpampClient{}, &mockOpampClient{}
+	agent := NewAgent(l, getFakeApplier(t, conf), conf, first, newMockProxy(nil, nil))
+	agent.clock = fakeClock
+	agent.createClient = func() client.OpAMPClient { return second }
+	require.NoError(t, agent.Start())
+	defer agent.Shutdown()
+
+	// certificate returns the client certificate the client was started with
+	certificate := func(m *mockOpampClient) []byte {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if m.settings.TLSConfig == nil || len(m.settings.TLSConfig.Certificates) == 0 {
+			return nil
+		}
+		return m.settings.TLSConfig.Certificates[0].Certificate[0]
+	}
+	loadCertificate := func(name string) []byte {
+		cert, err := tls.LoadX509KeyPair(filepath.Join(certificatesDir, name+".crt"), filepath.Join(certificatesDir, name+".key"))
+		require.NoError(t, err)
+		return cert.Certificate[0]
+	}
+	assert.Equal(t, loadCertificate("tls"), certificate(first))
+
+	// the agent stays connected while the certificates don't change
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	fakeClock.Step(certificateCheckInterval)
+	require.Eventually(t, fakeClock.HasWaiters, time.Second, time.Millisecond)
+	assert.Same(t, first, agent.client())
+
+	// the agent reconnects with the rotated certificate
+	copyFile("rotated.crt", "tls.crt")
+	copyFile("rotated.key", "tls.key")
+	fakeClock.Step(certificateCheckInterval)
+	require.Eventually(t, func() bool {
+		return certificate(second) != nil
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, loadCertificate("rotated"), certificate(second))
+	require.Eventually(t, func() bool {
+		return agent.client() == second
+	}, time.Second, time.Millisecond)
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	assert.True(t, first.stopped)
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"runtime"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -45,6 +46,8 @@ var (
 
 func registerKnownTypes(s *k8sruntime.Scheme) error {
 	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
+	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.TargetAllocator{}, &v1alpha1.TargetAllocatorList{})
+	s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.Instrumentation{}, &v1alpha1.InstrumentationList{})
 	s.AddKnownTypes(v1beta1.GroupVersion, &v1beta1.OpenTelemetryCollector{}, &v1beta1.OpenTelemetryCollectorList{})
 	metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
 	metav1.AddToGroupVersion(s, v1beta1.GroupVersion)
@@ -74,6 +77,16 @@ const (
 	ReportsRemoteConfig            Capability = "ReportsRemoteConfig"
 )
 
+// Transport is the transport the bridge connects to the server with.
+type Transport string
+
+const (
+	// WebSocketTransport keeps a WebSocket connection to the server.
+	WebSocketTransport Transport = "websocket"
+	// HTTPTransport polls the server with plain HTTP requests, e.g. when proxies don't keep long-lived WebSockets.
+	HTTPTransport Transport = "http"
+)
+
 type Config struct {
 	// KubeConfigFilePath is empty if InClusterConfig() should be used, otherwise it's a path to where a valid
 	// kubernetes configuration file.
@@ -87,10 +100,26 @@ type Config struct {
 	ComponentsAllowed map[string][]string `yaml:"componentsAllowed,omitempty"`
 	Endpoint          string              `yaml:"endpoint"`
 	Headers           Headers             `yaml:"headers,omitempty"`
+	// TLS configures the TLS connection to the server, nil to use the defaults of the endpoint scheme.
+	TLS               *TLS                `yaml:"tls,omitempty"`
 	Capabilities      map[Capability]bool `yaml:"capabilities"`
 	HeartbeatInterval time.Duration       `yaml:"heartbeatInterval,omitempty"`
-	Name              string              `yaml:"name,omitempty"`
-	AgentDescription  AgentDescription    `yaml:"description,omitempty"`
+	// RolloutTimeout is the time the collectors have to roll out a remote configuration before the bridge rolls them
+	// back to their last good configuration. Setting it to 0 disables the rollbacks.
+	RolloutTimeout   time.Duration    `yaml:"rolloutTimeout,omitempty"`
+	Name             string           `yaml:"name,omitempty"`
+	AgentDescription AgentDescription `yaml:"description,omitempty"`
+	// ManagedKinds are the kinds of the resources the bridge reports and manages besides the collectors,
+	// TargetAllocator and Instrumentation.
+	ManagedKinds []string `yaml:"managedKinds,omitempty"`
+	// Transport is the transport the bridge connects to the server with, websocket or http. When empty, it's http
+	// for the http and https endpoints, and websocket otherwise.
+	Transport Transport `yaml:"transport,omitempty"`
+	// PollingInterval is the interval the server is polled at with the http transport, 0 for the client default.
+	PollingInterval time.Duration `yaml:"pollingInterval,omitempty"`
+	// EnableCompression compresses the messages sent to the server: with gzip for the http transport, and with the
+	// WebSocket compression extension when the server supports it.
+	EnableCompression bool `yaml:"enableCompression,omitempty"`
 }
 
 // AgentDescription is copied from the OpAMP Extension in the collector.
@@ -114,11 +143,43 @@ func NewConfig(logger logr.Logger) *Config {
 
 func (c *Config) CreateClient() opampclient.OpAMPClient {
 	opampLogger := logger.NewLogger(c.RootLogger.WithName("client"))
+	if c.GetTransport() == HTTPTransport {
+		httpClient := opampclient.NewHTTP(opampLogger)
+		if c.PollingInterval > 0 {
+			httpClient.SetPollingInterval(c.PollingInterval)
+		}
+		return httpClient
+	}
+	return opampclient.NewWebSocket(opampLogger)
+}
+
+// GetTransport returns the transport the bridge connects to the server with.
+func (c *Config) GetTransport() Transport {
+	if c.Transport != "" {
+		return c.Transport
+	}
 	agentScheme := c.GetAgentScheme()
 	if agentScheme == "http" || agentScheme == "https" {
-		return opampclient.NewHTTP(opampLogger)
+		return HTTPTransport
 	}
-	return opampclient.NewWebSocket(opampLogger)
+	return WebSocketTransport
+}
+
+// GetServerURL returns the endpoint of the server with the scheme of the transport, e.g. https for a wss endpoint
+// polled with the http transport.
+func (c *Config) GetServerURL() string {
+	uri, err := url.Parse(c.Endpoint)
+	if err != nil {
+		return c.Endpoint
+	}
+	schemes := map[string]string{"http": "ws", "https": "wss"}
+	if c.GetTransport() == HTTPTransport {
+		schemes = map[string]string{"ws": "http", "wss": "https"}
+	}
+	if scheme, ok := schemes[uri.Scheme]; ok {
+		uri.Scheme = scheme
+	}
+	return uri.String()
 }
 
 func (c *Config) GetComponentsAllowed() map[string]map[string]bool {
@@ -134,6 +195,11 @@ func (c *Config) GetComponentsAllowed() map[string]map[string]bool {
 	return m
 }
 
+// ManagesKind tells whether the bridge reports and manages the resources of the given kind besides the collectors.
+func (c *Config) ManagesKind(kind string) bool {
+	return slices.Contains(c.ManagedKinds, kind)
+}
+
 func (c *Config) GetCapabilities() protobufs.AgentCapabilities {
 	var capabilities int32
 	for capability, enabled := range c.Capabilities {
@@ -224,6 +290,14 @@ func (c *Config) RemoteConfigEnabled() bool {
 	return capabilities&protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig != 0
 }
 
+// PackagesEnabled tells whether the server can offer the collector images as packages, which requires both the
+// AcceptsPackages and ReportsPackageStatuses capabilities.
+func (c *Config) PackagesEnabled() bool {
+	capabilities := c.GetCapabilities()
+	return capabilities&protobufs.AgentCapabilities_AgentCapabilities_AcceptsPackages != 0 ||
+		capabilities&protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses != 0
+}
+
 func (c *Config) GetKubernetesClient() (client.Client, error) {
 	err := schemeBuilder.AddToScheme(scheme.Scheme)
 	if err != nil {
@@ -260,6 +334,10 @@ func Load(logger logr.Logger, args []string) (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.Transport != "" && cfg.Transport != WebSocketTransport && cfg.Transport != HTTPTransport {
+		return nil, fmt.Errorf("unsupported transport %s, must be %s or %s", cfg.Transport, WebSocketTransport, HTTPTransport)
+	}
+
 	return cfg, nil
 }
 
@@ -295,6 +373,26 @@ func LoadFromCLI(target *Config, flagSet *pflag.FlagSet) error {
 	} else if changed {
 		target.HeartbeatInterval = heartbeatInterval
 	}
+	if rolloutTimeout, changed, err := getRolloutTimeout(flagSet); err != nil {
+		return err
+	} else if changed {
+		target.RolloutTimeout = rolloutTimeout
+	}
+	if transport, changed, err := getTransport(flagSet); err != nil {
+		return err
+	} else if changed {
+		target.Transport = Transport(transport)
+	}
+	if pollingInterval, changed, err := getPollingInterval(flagSet); err != nil {
+		return err
+	} else if changed {
+		target.PollingInterval = pollingInterval
+	}
+	if enableCompression, changed, err := getEnableCompression(flagSet); err != nil {
+		return err
+	} else if changed {
+		target.EnableCompression = enableCompression
+	}
 	if name, changed, err := getName(flagSet); err != nil {
 		return err
 	} else if changed {
@@ -56,6 +56,77 @@ func TestConfigLoadPriority(t *testing.T) {
 		assert.Equal(t, 45*time.Second, cfg.HeartbeatInterval, "config file priority is higher than default time.Duration value")
 		assert.Equal(t, testOpAMPBridgeName, cfg.Name, "command-line priority is higher than config, overwrite string value")
 	})
+
+	t.Run("command-line has priority over config file for the transport", func(t *testing.T) {
+		args := []string{
+			"--" + configFilePathFlagName + "=./testdata/agentwithtransport.yaml",
+			"--" + kubeConfigPathFlagName + "=./testdata/kubeconfig.yaml",
+			"--" + transportFlagName + "=websocket",
+		}
+		cfg, err := Load(GetLogger(), args)
+
+		assert.NoError(t, err)
+		assert.Equal(t, WebSocketTransport, cfg.Transport, "command-line priority is higher than config, overwrite string value")
+		assert.Equal(t, 10*time.Second, cfg.PollingInterval, "config file priority is higher than default time.Duration value")
+		assert.True(t, cfg.EnableCompression, "config file priority is higher than default bool value")
+	})
+
+	t.Run("unsupported transport", func(t *testing.T) {
+		args := []string{
+			"--" + configFilePathFlagName + "=./testdata/agent.yaml",
+			"--" + kubeConfigPathFlagName + "=./testdata/kubeconfig.yaml",
+			"--" + transportFlagName + "=grpc",
+		}
+		_, err := Load(GetLogger(), args)
+
+		assert.EqualError(t, err, "unsupported transport grpc, must be websocket or http")
+	})
+}
+
+func TestGetTransport(t *testing.T) {
+	tests := []struct {
+		name          string
+		endpoint      string
+		transport     Transport
+		wantTransport Transport
+		wantURL       string
+	}{
+		{
+			name:          "websocket endpoint",
+			endpoint:      "ws://127.0.0.1:4320/v1/opamp",
+			wantTransport: WebSocketTransport,
+			wantURL:       "ws://127.0.0.1:4320/v1/opamp",
+		},
+		{
+			name:          "http endpoint",
+			endpoint:      "https://127.0.0.1:4320/v1/opamp",
+			wantTransport: HTTPTransport,
+			wantURL:       "https://127.0.0.1:4320/v1/opamp",
+		},
+		{
+			name:          "websocket endpoint polled over http",
+			endpoint:      "wss://127.0.0.1:4320/v1/opamp",
+			transport:     HTTPTransport,
+			wantTransport: HTTPTransport,
+			wantURL:       "https://127.0.0.1:4320/v1/opamp",
+		},
+		{
+			name:          "http endpoint connected over websocket",
+			endpoint:      "http://127.0.0.1:4320/v1/opamp",
+			transport:     WebSocketTransport,
+			wantTransport: WebSocketTransport,
+			wantURL:       "ws://127.0.0.1:4320/v1/opamp",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewConfig(logr.Discard())
+			cfg.Endpoint = tt.endpoint
+			cfg.Transport = tt.transport
+			assert.Equal(t, tt.wantTransport, cfg.GetTransport())
+			assert.Equal(t, tt.wantURL, cfg.GetServerURL())
+		})
+	}
 }
 
 func TestLoadFromFile(t *testing.T) {
@@ -268,6 +339,55 @@ func TestLoadFromFile(t *testing.T) {
 			needErr: false,
 			wantErr: assert.NoError,
 		},
+		{
+			name: "base case with tls",
+			args: args{
+				file: "./testdata/agentwithtls.yaml",
+			},
+			want: &Config{
+				instanceId: instanceId,
+				RootLogger: logr.Discard(),
+				Endpoint:   "wss://127.0.0.1:4320/v1/opamp",
+				TLS: &TLS{
+					CAFile:     "./testdata/tls/ca.crt",
+					CertFile:   "./testdata/tls/tls.crt",
+					KeyFile:    "./testdata/tls/tls.key",
+					ServerName: "opamp-server",
+				},
+				ListenAddr:         defaultServerListenAddr,
+				KubeConfigFilePath: defaultKubeConfigPath,
+				HeartbeatInterval:  defaultHeartbeatInterval,
+				Name:               opampBridgeName,
+				Capabilities: map[Capability]bool{
+					AcceptsRemoteConfig:    true,
+					ReportsEffectiveConfig: true,
+				},
+			},
+			needErr: false,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "base case with managed kinds",
+			args: args{
+				file: "./testdata/agentwithmanagedkinds.yaml",
+			},
+			want: &Config{
+				instanceId:         instanceId,
+				RootLogger:         logr.Discard(),
+				Endpoint:           "ws://127.0.0.1:4320/v1/opamp",
+				ManagedKinds:       []string{"TargetAllocator", "Instrumentation"},
+				ListenAddr:         defaultServerListenAddr,
+				KubeConfigFilePath: defaultKubeConfigPath,
+				HeartbeatInterval:  defaultHeartbeatInterval,
+				Name:               opampBridgeName,
+				Capabilities: map[Capability]bool{
+					AcceptsRemoteConfig:    true,
+					ReportsEffectiveConfig: true,
+				},
+			},
+			needErr: false,
+			wantErr: assert.NoError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
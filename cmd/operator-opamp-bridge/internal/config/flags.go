@@ -23,6 +23,10 @@ const (
 	listenAddrFlagName        = "listen-addr"
 	kubeConfigPathFlagName    = "kubeconfig-path"
 	heartbeatIntervalFlagName = "heartbeat-interval"
+	rolloutTimeoutFlagName    = "rollout-timeout"
+	transportFlagName         = "transport"
+	pollingIntervalFlagName   = "polling-interval"
+	enableCompressionFlagName = "enable-compression"
 	nameFlagName              = "name"
 	defaultHeartbeatInterval  = 30 * time.Second
 )
@@ -40,6 +44,10 @@ func GetFlagSet(errorHandling pflag.ErrorHandling) *pflag.FlagSet {
 	flagSet.String(listenAddrFlagName, defaultServerListenAddr, "The address where this service serves.")
 	flagSet.String(kubeConfigPathFlagName, defaultKubeConfigPath, "absolute path to the KubeconfigPath file.")
 	flagSet.Duration(heartbeatIntervalFlagName, defaultHeartbeatInterval, "The interval to use for sending a heartbeat. Setting it to 0 disables the heartbeat.")
+	flagSet.Duration(rolloutTimeoutFlagName, 0, "The time the collectors have to roll out a remote configuration before they are rolled back. Setting it to 0 disables the rollbacks.")
+	flagSet.String(transportFlagName, "", "The transport to connect to the OpAMP server with, websocket or http. Defaults to http for the http and https endpoints, and to websocket otherwise.")
+	flagSet.Duration(pollingIntervalFlagName, 0, "The interval to poll the OpAMP server at with the http transport. Setting it to 0 uses the client default.")
+	flagSet.Bool(enableCompressionFlagName, false, "Compress the messages sent to the OpAMP server.")
 	flagSet.String(nameFlagName, opampBridgeName, "The name of the bridge to use for querying managed collectors.")
 	zapFlagSet := flag.NewFlagSet("", flag.ErrorHandling(errorHandling))
 	zapCmdLineOpts.BindFlags(zapFlagSet)
@@ -51,6 +59,22 @@ func getHeartbeatInterval(flagSet *pflag.FlagSet) (value time.Duration, changed
 	return getFlagValueAndChanged[time.Duration](flagSet, heartbeatIntervalFlagName)
 }
 
+func getRolloutTimeout(flagSet *pflag.FlagSet) (value time.Duration, changed bool, err error) {
+	return getFlagValueAndChanged[time.Duration](flagSet, rolloutTimeoutFlagName)
+}
+
+func getTransport(flagSet *pflag.FlagSet) (value string, changed bool, err error) {
+	return getFlagValueAndChanged[string](flagSet, transportFlagName)
+}
+
+func getPollingInterval(flagSet *pflag.FlagSet) (value time.Duration, changed bool, err error) {
+	return getFlagValueAndChanged[time.Duration](flagSet, pollingIntervalFlagName)
+}
+
+func getEnableCompression(flagSet *pflag.FlagSet) (value bool, changed bool, err error) {
+	return getFlagValueAndChanged[bool](flagSet, enableCompressionFlagName)
+}
+
 func getConfigFilePath(flagSet *pflag.FlagSet) (value string, changed bool, err error) {
 	return getFlagValueAndChanged[string](flagSet, configFilePathFlagName)
 }
@@ -82,6 +106,10 @@ func getFlagValueAndChanged[T any](flagSet *pflag.FlagSet, flagName string) (val
 		val, e := flagSet.GetDuration(flagName)
 		value = any(val).(T)
 		err = e
+	case bool:
+		val, e := flagSet.GetBool(flagName)
+		value = any(val).(T)
+		err = e
 	default:
 		err = fmt.Errorf("unsupported flag type %T", zero)
 	}
@@ -74,6 +74,42 @@ func TestFlagGetters(t *testing.T) {
 				return value, err
 			},
 		},
+		{
+			name:          "GetRolloutTimeout",
+			flagArgs:      []string{"--" + rolloutTimeoutFlagName, "5m"},
+			expectedValue: 5 * time.Minute,
+			getterFunc: func(fs *pflag.FlagSet) (interface{}, error) {
+				value, _, err := getRolloutTimeout(fs)
+				return value, err
+			},
+		},
+		{
+			name:          "GetTransport",
+			flagArgs:      []string{"--" + transportFlagName, "http"},
+			expectedValue: "http",
+			getterFunc: func(fs *pflag.FlagSet) (interface{}, error) {
+				value, _, err := getTransport(fs)
+				return value, err
+			},
+		},
+		{
+			name:          "GetPollingInterval",
+			flagArgs:      []string{"--" + pollingIntervalFlagName, "10s"},
+			expectedValue: 10 * time.Second,
+			getterFunc: func(fs *pflag.FlagSet) (interface{}, error) {
+				value, _, err := getPollingInterval(fs)
+				return value, err
+			},
+		},
+		{
+			name:          "GetEnableCompression",
+			flagArgs:      []string{"--" + enableCompressionFlagName},
+			expectedValue: true,
+			getterFunc: func(fs *pflag.FlagSet) (interface{}, error) {
+				value, _, err := getEnableCompression(fs)
+				return value, err
+			},
+		},
 		{
 			name:        "InvalidFlag",
 			flagArgs:    []string{"--invalid-flag", "value"},
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLS configures the TLS connection to the OpAMP server, with the client certificate of the bridge when the server
+// requires mutual TLS.
+type TLS struct {
+	// CAFile is the path of the CA certificate the certificate of the server is verified with. The system CA
+	// certificates are used when it isn't set.
+	CAFile string `yaml:"caFile,omitempty"`
+	// CertFile and KeyFile are the paths of the client certificate of the bridge and of its private key.
+	CertFile string `yaml:"certFile,omitempty"`
+	KeyFile  string `yaml:"keyFile,omitempty"`
+	// ServerName is the name the certificate of the server is verified against, the host of the endpoint by default.
+	ServerName string `yaml:"serverName,omitempty"`
+}
+
+// NewTLSConfig loads the certificates into the TLS configuration of the connection to the server.
+func (t *TLS) NewTLSConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: t.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if t.CAFile != "" {
+		caCert, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no CA certificate found in %s", t.CAFile)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// Fingerprint returns the hash of the certificate files, which changes when they are rotated.
+func (t *TLS) Fingerprint() ([]byte, error) {
+	hash := sha256.New()
+	for _, file := range []string{t.CAFile, t.CertFile, t.KeyFile} {
+		if file == "" {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		hash.Write(content)
+	}
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLS_NewTLSConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		tls              TLS
+		wantCertificates int
+		wantErr          string
+	}{
+		{
+			name: "server certificate verified with the system CA certificates",
+			tls:  TLS{},
+		},
+		{
+			name:             "client certificate and CA certificate",
+			tls:              TLS{CAFile: "./testdata/tls/ca.crt", CertFile: "./testdata/tls/tls.crt", KeyFile: "./testdata/tls/tls.key", ServerName: "opamp-server"},
+			wantCertificates: 1,
+		},
+		{
+			name:    "missing private key",
+			tls:     TLS{CertFile: "./testdata/tls/tls.crt", KeyFile: "./testdata/tls/missing.key"},
+			wantErr: "no such file or directory",
+		},
+		{
+			name:    "invalid CA certificate",
+			tls:     TLS{CAFile: "./testdata/tls/tls.key"},
+			wantErr: "no CA certificate found in ./testdata/tls/tls.key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tls.NewTLSConfig()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.tls.ServerName, got.ServerName)
+			assert.Equal(t, uint16(tls.VersionTLS12), got.MinVersion)
+			assert.Equal(t, tt.tls.CAFile != "", got.RootCAs != nil)
+			assert.Len(t, got.Certificates, tt.wantCertificates)
+		})
+	}
+}
+
+func TestTLS_Fingerprint(t *testing.T) {
+	current, err := (&TLS{CAFile: "./testdata/tls/ca.crt", CertFile: "./testdata/tls/tls.crt", KeyFile: "./testdata/tls/tls.key"}).Fingerprint()
+	require.NoError(t, err)
+	same, err := (&TLS{CAFile: "./testdata/tls/ca.crt", CertFile: "./testdata/tls/tls.crt", KeyFile: "./testdata/tls/tls.key"}).Fingerprint()
+	require.NoError(t, err)
+	rotated, err := (&TLS{CAFile: "./testdata/tls/ca.crt", CertFile: "./testdata/tls/rotated.crt", KeyFile: "./testdata/tls/rotated.key"}).Fingerprint()
+	require.NoError(t, err)
+
+	assert.Equal(t, current, same)
+	assert.NotEqual(t, current, rotated)
+
+	_, err = (&TLS{CertFile: "./testdata/tls/missing.crt"}).Fingerprint()
+	assert.Error(t, err)
+}
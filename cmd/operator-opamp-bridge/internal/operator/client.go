@@ -18,6 +18,7 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
 const (
@@ -26,12 +27,20 @@ const (
 	ResourceIdentifierValue = "operator-opamp-bridge"
 	ReportingLabelKey       = "opentelemetry.io/opamp-reporting"
 	ManagedLabelKey         = "opentelemetry.io/opamp-managed"
+
+	// collectorConfigMapEntry is the entry of the ConfigMap of a collector holding the configuration rendered by the
+	// operator.
+	collectorConfigMapEntry = "collector.yaml"
 )
 
 type ConfigApplier interface {
 	// Apply receives a name and namespace to apply an OpenTelemetryCollector CRD that is contained in the configmap.
 	Apply(name string, namespace string, configmap *protobufs.AgentConfigFile) error
 
+	// Validate checks that the OpenTelemetryCollector CRD contained in the configmap can be applied, without applying
+	// it: the API server runs the admission webhooks of the operator in dry-run mode.
+	Validate(name string, namespace string, configmap *protobufs.AgentConfigFile) error
+
 	// Delete attempts to delete an OpenTelemetryCollector object given a name and namespace.
 	Delete(name string, namespace string) error
 
@@ -43,6 +52,30 @@ type ConfigApplier interface {
 
 	// GetCollectorPods retrieves all pods that match the given collector's selector labels and namespace.
 	GetCollectorPods(selectorLabels map[string]string, namespace string) (*v1.PodList, error)
+
+	// GetRenderedConfig retrieves the collector configuration rendered by the operator, with the config sources, the
+	// presets and the enrichment processors, which is empty until the operator renders it.
+	GetRenderedConfig(collector v1beta1.OpenTelemetryCollector) (string, error)
+
+	// SetImage sets the image of the managed OpenTelemetryCollector CRD given a name and namespace.
+	SetImage(name string, namespace string, image string) error
+
+	// ApplyResource applies a TargetAllocator or Instrumentation CRD of the given kind that is contained in the
+	// configmap.
+	ApplyResource(kind string, name string, namespace string, configmap *protobufs.AgentConfigFile) error
+
+	// ValidateResource checks that the CRD of the given kind contained in the configmap can be applied, without
+	// applying it.
+	ValidateResource(kind string, name string, namespace string, configmap *protobufs.AgentConfigFile) error
+
+	// DeleteResource attempts to delete a CRD of the given kind given a name and namespace.
+	DeleteResource(kind string, name string, namespace string) error
+
+	// ListResources retrieves all the managed and reporting CRDs of the given kind.
+	ListResources(kind string) ([]client.Object, error)
+
+	// GetResource retrieves a CRD of the given kind given a name and namespace, nil when it doesn't exist.
+	GetResource(kind string, name string, namespace string) (client.Object, error)
 }
 
 type Client struct {
@@ -67,7 +100,14 @@ func NewClient(name string, log logr.Logger, c client.Client, componentsAllowed
 
 func (c Client) Apply(name string, namespace string, configmap *protobufs.AgentConfigFile) error {
 	c.log.Info("Received new config", "name", name, "namespace", namespace)
+	return c.apply(name, namespace, configmap, false)
+}
 
+func (c Client) Validate(name string, namespace string, configmap *protobufs.AgentConfigFile) error {
+	return c.apply(name, namespace, configmap, true)
+}
+
+func (c Client) apply(name string, namespace string, configmap *protobufs.AgentConfigFile, dryRun bool) error {
 	if len(configmap.Body) == 0 {
 		return errors.NewBadRequest("invalid config to apply: config is empty")
 	}
@@ -83,6 +123,13 @@ func (c Client) Apply(name string, namespace string, configmap *protobufs.AgentC
 		return err
 	}
 
+	// the config sources are only merged by the operator, which validates the merged config instead
+	if len(collector.Spec.ConfigSources) == 0 {
+		if err = collector.Spec.Config.Validate(); err != nil {
+			return errors.NewBadRequest(fmt.Sprintf("the collector configuration is invalid: %v", err))
+		}
+	}
+
 	ctx := context.Background()
 	updatedCollector := collector.DeepCopy()
 	instance, err := c.GetInstance(name, namespace)
@@ -100,9 +147,9 @@ func (c Client) Apply(name string, namespace string, configmap *protobufs.AgentC
 	}
 
 	if instance == nil {
-		return c.create(ctx, name, namespace, updatedCollector)
+		return c.create(ctx, name, namespace, updatedCollector, dryRun)
 	}
-	return c.update(ctx, instance, updatedCollector)
+	return c.update(ctx, instance, updatedCollector, dryRun)
 }
 
 func (c Client) validateComponents(collectorConfig *v1beta1.Config) error {
@@ -166,7 +213,7 @@ func labelSetContainsLabel(resourceLabelSet map[string]string, label, value stri
 	return strings.EqualFold(resourceLabelSet[label], value)
 }
 
-func (c Client) create(ctx context.Context, name string, namespace string, collector *v1beta1.OpenTelemetryCollector) error {
+func (c Client) create(ctx context.Context, name string, namespace string, collector *v1beta1.OpenTelemetryCollector, dryRun bool) error {
 	// Set the defaults
 	collector.TypeMeta.Kind = CollectorResource
 	collector.TypeMeta.APIVersion = v1beta1.GroupVersion.String()
@@ -178,14 +225,20 @@ func (c Client) create(ctx context.Context, name string, namespace string, colle
 	}
 	collector.ObjectMeta.Labels[ResourceIdentifierKey] = ResourceIdentifierValue
 
+	if dryRun {
+		return c.k8sClient.Create(ctx, collector, client.DryRunAll)
+	}
 	c.log.Info("Creating collector")
 	return c.k8sClient.Create(ctx, collector)
 }
 
-func (c Client) update(ctx context.Context, old *v1beta1.OpenTelemetryCollector, new *v1beta1.OpenTelemetryCollector) error {
+func (c Client) update(ctx context.Context, old *v1beta1.OpenTelemetryCollector, new *v1beta1.OpenTelemetryCollector, dryRun bool) error {
 	new.ObjectMeta = old.ObjectMeta
 	new.TypeMeta = old.TypeMeta
 
+	if dryRun {
+		return c.k8sClient.Update(ctx, new, client.DryRunAll)
+	}
 	c.log.Info("Updating collector")
 	return c.k8sClient.Update(ctx, new)
 }
@@ -257,9 +310,51 @@ func (c Client) GetInstance(name string, namespace string) (*v1beta1.OpenTelemet
 	return &result, nil
 }
 
+func (c Client) SetImage(name string, namespace string, image string) error {
+	instance, err := c.GetInstance(name, namespace)
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return errors.NewNotFound(v1beta1.GroupVersion.WithResource("opentelemetrycollectors").GroupResource(), name)
+	}
+	err = c.validateLabels(instance)
+	if err != nil {
+		return err
+	}
+	instance.Spec.Image = image
+	c.log.Info("Updating collector image", "name", name, "namespace", namespace, "image", image)
+	return c.k8sClient.Update(context.Background(), instance)
+}
+
 func (c Client) GetCollectorPods(selectorLabels map[string]string, namespace string) (*v1.PodList, error) {
 	ctx := context.Background()
 	podList := &v1.PodList{}
 	err := c.k8sClient.List(ctx, podList, client.MatchingLabels(selectorLabels), client.InNamespace(namespace))
 	return podList, err
 }
+
+func (c Client) GetRenderedConfig(collector v1beta1.OpenTelemetryCollector) (string, error) {
+	// the ConfigMap is named after the hash of the rendered configuration, unless it's updated in place
+	name := naming.ReloadedConfigMap(collector.GetName())
+	if !collector.Spec.ReloadsConfig() {
+		if len(collector.Status.ConfigHash) < 8 {
+			return "", nil
+		}
+		name = naming.ConfigMap(collector.GetName(), collector.Status.ConfigHash)
+	}
+
+	ctx := context.Background()
+	configMap := v1.ConfigMap{}
+	err := c.k8sClient.Get(ctx, client.ObjectKey{
+		Namespace: collector.GetNamespace(),
+		Name:      name,
+	}, &configMap)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return configMap.Data[collectorConfigMapEntry], nil
+}
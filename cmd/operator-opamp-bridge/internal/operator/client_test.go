@@ -35,8 +35,10 @@ const (
 func getFakeClient(t *testing.T, lists ...client.ObjectList) client.WithWatch {
 	schemeBuilder := runtime.NewSchemeBuilder(func(s *runtime.Scheme) error {
 		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.OpenTelemetryCollector{}, &v1alpha1.OpenTelemetryCollectorList{})
+		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.TargetAllocator{}, &v1alpha1.TargetAllocatorList{})
+		s.AddKnownTypes(v1alpha1.GroupVersion, &v1alpha1.Instrumentation{}, &v1alpha1.InstrumentationList{})
 		s.AddKnownTypes(v1beta1.GroupVersion, &v1beta1.OpenTelemetryCollector{}, &v1beta1.OpenTelemetryCollectorList{})
-		s.AddKnownTypes(v1.SchemeGroupVersion, &v1.Pod{}, &v1.PodList{})
+		s.AddKnownTypes(v1.SchemeGroupVersion, &v1.Pod{}, &v1.PodList{}, &v1.ConfigMap{}, &v1.ConfigMapList{})
 		metav1.AddToGroupVersion(s, v1alpha1.GroupVersion)
 		return nil
 	})
@@ -230,6 +232,32 @@ func TestClient_ApplyUpdate(t *testing.T) {
 	assert.Contains(t, allInstances, *updatedInstance)
 }
 
+func TestClient_Validate(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.Validate(name, namespace, &protobufs.AgentConfigFile{Body: colConfig, ContentType: "yaml"})
+	require.NoError(t, err, "Should validate base config")
+
+	// Nothing is created by the validation
+	instance, err := c.GetInstance(name, namespace)
+	require.NoError(t, err)
+	assert.Nil(t, instance, "Should not create the collector")
+
+	// A pipeline referencing a component which isn't configured is rejected
+	var collector v1beta1.OpenTelemetryCollector
+	require.NoError(t, yaml.Unmarshal(colConfig, &collector))
+	collector.Spec.Config.Service.Pipelines["traces"].Exporters = []string{"otlp"}
+	invalidConfig, err := yaml.Marshal(&collector)
+	require.NoError(t, err)
+	err = c.Validate(name, namespace, &protobufs.AgentConfigFile{Body: invalidConfig, ContentType: "yaml"})
+	assert.ErrorContains(t, err, "the collector configuration is invalid")
+}
+
 func TestClient_Delete(t *testing.T) {
 	name := "test"
 	namespace := "testing"
@@ -263,6 +291,39 @@ func TestClient_Delete(t *testing.T) {
 	require.Empty(t, allInstances, "Should be empty after deletion")
 }
 
+func TestClient_SetImage(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+
+	// The collector must exist
+	err := c.SetImage(name, namespace, "otel/opentelemetry-collector:0.111.0")
+	assert.ErrorContains(t, err, "not found")
+
+	colConfig, err := loadConfig("testdata/collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.Apply(name, namespace, &protobufs.AgentConfigFile{Body: colConfig, ContentType: "yaml"})
+	require.NoError(t, err, "Should apply base config")
+
+	err = c.SetImage(name, namespace, "otel/opentelemetry-collector:0.111.0")
+	require.NoError(t, err, "Should be able to set the image of the collector")
+	instance, err := c.GetInstance(name, namespace)
+	require.NoError(t, err)
+	assert.Equal(t, "otel/opentelemetry-collector:0.111.0", instance.Spec.Image)
+	assert.Contains(t, instance.Spec.Config.Service.Pipelines, "traces", "Should keep the configuration of the collector")
+
+	// The image of a reporting collector can't be set
+	reportingColConfig, err := loadConfig("testdata/reporting-collector.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	var reportingCol v1beta1.OpenTelemetryCollector
+	require.NoError(t, yaml.Unmarshal(reportingColConfig, &reportingCol))
+	reportingCol.Namespace = namespace
+	require.NoError(t, fakeClient.Create(context.Background(), &reportingCol))
+	err = c.SetImage(reportingCol.Name, namespace, "otel/opentelemetry-collector:0.111.0")
+	assert.ErrorContains(t, err, "cannot modify a collector with `opentelemetry.io/opamp-reporting: true`")
+}
+
 func loadConfig(file string) ([]byte, error) {
 	yamlFile, err := os.ReadFile(file)
 	if err != nil {
@@ -345,3 +406,33 @@ func TestClient_GetCollectorPods(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetRenderedConfig(t *testing.T) {
+	configMaps := &v1.ConfigMapList{Items: []v1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-collector-1234abcd", Namespace: "testing"},
+			Data:       map[string]string{"collector.yaml": "receivers:\n  otlp: {}\n"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-collector-config", Namespace: "testing"},
+			Data:       map[string]string{"collector.yaml": "receivers:\n  jaeger: {}\n"},
+		},
+	}}
+	fakeClient := getFakeClient(t, configMaps)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+
+	collector := v1beta1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "testing"}}
+	rendered, err := c.GetRenderedConfig(collector)
+	require.NoError(t, err)
+	assert.Empty(t, rendered, "Should be empty until the operator renders the config")
+
+	collector.Status.ConfigHash = "1234abcd5678"
+	rendered, err = c.GetRenderedConfig(collector)
+	require.NoError(t, err)
+	assert.Equal(t, "receivers:\n  otlp: {}\n", rendered)
+
+	collector.Spec.Rollout = &v1beta1.Rollout{Strategy: v1beta1.RolloutStrategyReload}
+	rendered, err = c.GetRenderedConfig(collector)
+	require.NoError(t, err)
+	assert.Equal(t, "receivers:\n  jaeger: {}\n", rendered)
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	TargetAllocatorResource = "TargetAllocator"
+	InstrumentationResource = "Instrumentation"
+)
+
+// ResourceKinds are the kinds of the resources the bridge can manage besides the collectors.
+var ResourceKinds = []string{TargetAllocatorResource, InstrumentationResource}
+
+// newResource returns an empty resource of the given kind.
+func newResource(kind string) (client.Object, error) {
+	switch kind {
+	case TargetAllocatorResource:
+		return &v1alpha1.TargetAllocator{}, nil
+	case InstrumentationResource:
+		return &v1alpha1.Instrumentation{}, nil
+	}
+	return nil, errors.NewBadRequest(fmt.Sprintf("unsupported resource kind %s", kind))
+}
+
+// newResourceList returns an empty list of the resources of the given kind.
+func newResourceList(kind string) (client.ObjectList, error) {
+	switch kind {
+	case TargetAllocatorResource:
+		return &v1alpha1.TargetAllocatorList{}, nil
+	case InstrumentationResource:
+		return &v1alpha1.InstrumentationList{}, nil
+	}
+	return nil, errors.NewBadRequest(fmt.Sprintf("unsupported resource kind %s", kind))
+}
+
+func (c Client) ApplyResource(kind string, name string, namespace string, configmap *protobufs.AgentConfigFile) error {
+	c.log.Info("Received new config", "kind", kind, "name", name, "namespace", namespace)
+	return c.applyResource(kind, name, namespace, configmap, false)
+}
+
+func (c Client) ValidateResource(kind string, name string, namespace string, configmap *protobufs.AgentConfigFile) error {
+	return c.applyResource(kind, name, namespace, configmap, true)
+}
+
+func (c Client) applyResource(kind string, name string, namespace string, configmap *protobufs.AgentConfigFile, dryRun bool) error {
+	if len(configmap.Body) == 0 {
+		return errors.NewBadRequest("invalid config to apply: config is empty")
+	}
+
+	resource, err := newResource(kind)
+	if err != nil {
+		return err
+	}
+	err = yaml.Unmarshal(configmap.Body, resource)
+	if err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("failed to unmarshal config into v1alpha1 API Version: %v", err))
+	}
+
+	instance, err := c.GetResource(kind, name, namespace)
+	if err != nil {
+		return err
+	}
+	if instance != nil {
+		err = c.validateResourceLabels(kind, instance.GetLabels())
+		if err != nil {
+			return err
+		}
+	}
+	err = c.validateResourceLabels(kind, resource.GetLabels())
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	resource.GetObjectKind().SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(kind))
+	if instance == nil {
+		resource.SetName(name)
+		resource.SetNamespace(namespace)
+		resourceLabels := resource.GetLabels()
+		if resourceLabels == nil {
+			resourceLabels = map[string]string{}
+		}
+		resourceLabels[ResourceIdentifierKey] = ResourceIdentifierValue
+		resource.SetLabels(resourceLabels)
+
+		if dryRun {
+			return c.k8sClient.Create(ctx, resource, client.DryRunAll)
+		}
+		c.log.Info("Creating resource", "kind", kind)
+		return c.k8sClient.Create(ctx, resource)
+	}
+
+	// as for the collectors, only the spec of the resource is updated
+	copyObjectMeta(resource, instance)
+	if dryRun {
+		return c.k8sClient.Update(ctx, resource, client.DryRunAll)
+	}
+	c.log.Info("Updating resource", "kind", kind)
+	return c.k8sClient.Update(ctx, resource)
+}
+
+// copyObjectMeta sets the metadata of the existing resource on the updated one, of the same kind.
+func copyObjectMeta(updated client.Object, existing client.Object) {
+	switch updated := updated.(type) {
+	case *v1alpha1.TargetAllocator:
+		updated.ObjectMeta = existing.(*v1alpha1.TargetAllocator).ObjectMeta
+	case *v1alpha1.Instrumentation:
+		updated.ObjectMeta = existing.(*v1alpha1.Instrumentation).ObjectMeta
+	}
+}
+
+// validateResourceLabels checks that the resource is managed by the bridge, and not only reported.
+func (c Client) validateResourceLabels(kind string, resourceLabels map[string]string) error {
+	if labelSetContainsLabel(resourceLabels, ReportingLabelKey, "true") {
+		return errors.NewBadRequest(fmt.Sprintf("cannot modify the %s resources with `%s: true`", kind, ReportingLabelKey))
+	}
+	if !labelSetContainsLabel(resourceLabels, ManagedLabelKey, "true") &&
+		!labelSetContainsLabel(resourceLabels, ManagedLabelKey, c.name) {
+		return errors.NewBadRequest(fmt.Sprintf("cannot modify the %s resources that don't have `%s: true | <bridge-name>` set", kind, ManagedLabelKey))
+	}
+	return nil
+}
+
+func (c Client) DeleteResource(kind string, name string, namespace string) error {
+	resource, err := c.GetResource(kind, name, namespace)
+	if err != nil || resource == nil {
+		return err
+	}
+	return c.k8sClient.Delete(context.Background(), resource)
+}
+
+func (c Client) GetResource(kind string, name string, namespace string) (client.Object, error) {
+	resource, err := newResource(kind)
+	if err != nil {
+		return nil, err
+	}
+	err = c.k8sClient.Get(context.Background(), client.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}, resource)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	resource.GetObjectKind().SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(kind))
+	return resource, nil
+}
+
+func (c Client) ListResources(kind string) ([]client.Object, error) {
+	ctx := context.Background()
+
+	labelSelector := labels.NewSelector()
+	requirement, err := labels.NewRequirement(ManagedLabelKey, selection.In, []string{c.name, "true"})
+	if err != nil {
+		return nil, err
+	}
+	var resources []client.Object
+	for _, opt := range []client.ListOption{
+		client.MatchingLabelsSelector{Selector: labelSelector.Add(*requirement)},
+		client.MatchingLabels{ReportingLabelKey: "true"},
+	} {
+		list, err := newResourceList(kind)
+		if err != nil {
+			return nil, err
+		}
+		err = c.k8sClient.List(ctx, list, opt)
+		if err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			resource := item.(client.Object)
+			resource.SetManagedFields(nil)
+			resource.GetObjectKind().SetGroupVersionKind(v1alpha1.GroupVersion.WithKind(kind))
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
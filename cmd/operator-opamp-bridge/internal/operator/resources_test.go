@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestClient_ApplyResource(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+
+	// Create a target allocator
+	taConfig, err := loadConfig("testdata/targetallocator.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.ApplyResource(TargetAllocatorResource, name, namespace, &protobufs.AgentConfigFile{Body: taConfig, ContentType: "yaml"})
+	require.NoError(t, err, "Should create the target allocator")
+
+	resource, err := c.GetResource(TargetAllocatorResource, name, namespace)
+	require.NoError(t, err)
+	require.NotNil(t, resource, "Should be able to get the newly created target allocator")
+	ta := resource.(*v1alpha1.TargetAllocator)
+	assert.Equal(t, v1beta1.TargetAllocatorAllocationStrategyConsistentHashing, ta.Spec.AllocationStrategy)
+	assert.Equal(t, ResourceIdentifierValue, ta.Labels[ResourceIdentifierKey])
+	assert.Equal(t, TargetAllocatorResource, ta.Kind)
+
+	// Update it
+	ta.Spec.AllocationStrategy = v1beta1.TargetAllocatorAllocationStrategyPerNode
+	ta.Labels = map[string]string{ManagedLabelKey: "true"}
+	updated, err := loadObject(ta)
+	require.NoError(t, err)
+	err = c.ApplyResource(TargetAllocatorResource, name, namespace, updated)
+	require.NoError(t, err, "Should update the target allocator")
+	resource, err = c.GetResource(TargetAllocatorResource, name, namespace)
+	require.NoError(t, err)
+	assert.Equal(t, v1beta1.TargetAllocatorAllocationStrategyPerNode, resource.(*v1alpha1.TargetAllocator).Spec.AllocationStrategy)
+	assert.Equal(t, ResourceIdentifierValue, resource.GetLabels()[ResourceIdentifierKey], "Should keep the metadata of the target allocator")
+
+	// Create an instrumentation
+	instrumentationConfig, err := loadConfig("testdata/instrumentation.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.ApplyResource(InstrumentationResource, name, namespace, &protobufs.AgentConfigFile{Body: instrumentationConfig, ContentType: "yaml"})
+	require.NoError(t, err, "Should create the instrumentation")
+	resource, err = c.GetResource(InstrumentationResource, name, namespace)
+	require.NoError(t, err)
+	require.NotNil(t, resource, "Should be able to get the newly created instrumentation")
+	assert.Equal(t, "http://otel-collector:4317", resource.(*v1alpha1.Instrumentation).Spec.Exporter.Endpoint)
+
+	// Unsupported kinds are rejected
+	err = c.ApplyResource("OpAMPBridge", name, namespace, &protobufs.AgentConfigFile{Body: taConfig, ContentType: "yaml"})
+	assert.ErrorContains(t, err, "unsupported resource kind OpAMPBridge")
+}
+
+func TestClient_ApplyResource_Labels(t *testing.T) {
+	namespace := "testing"
+	reporting := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reporting",
+			Namespace: namespace,
+			Labels:    map[string]string{ReportingLabelKey: "true"},
+		},
+	}
+	fakeClient := getFakeClient(t, &v1alpha1.InstrumentationList{Items: []v1alpha1.Instrumentation{*reporting}})
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+
+	instrumentationConfig, err := loadConfig("testdata/instrumentation.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	configFile := &protobufs.AgentConfigFile{Body: instrumentationConfig, ContentType: "yaml"}
+
+	// A reporting instrumentation can't be modified
+	err = c.ApplyResource(InstrumentationResource, "reporting", namespace, configFile)
+	assert.ErrorContains(t, err, "cannot modify the Instrumentation resources with `opentelemetry.io/opamp-reporting: true`")
+
+	// An instrumentation without the managed label can't be created
+	unmanaged := &v1alpha1.Instrumentation{}
+	unmanaged.Spec.Exporter.Endpoint = "http://otel-collector:4317"
+	unmanagedFile, err := loadObject(unmanaged)
+	require.NoError(t, err)
+	err = c.ApplyResource(InstrumentationResource, "unmanaged", namespace, unmanagedFile)
+	assert.ErrorContains(t, err, "cannot modify the Instrumentation resources that don't have `opentelemetry.io/opamp-managed: true | <bridge-name>` set")
+}
+
+func TestClient_ValidateResource(t *testing.T) {
+	name := "test"
+	namespace := "testing"
+	fakeClient := getFakeClient(t)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+
+	taConfig, err := loadConfig("testdata/targetallocator.yaml")
+	require.NoError(t, err, "Should be no error on loading test configuration")
+	err = c.ValidateResource(TargetAllocatorResource, name, namespace, &protobufs.AgentConfigFile{Body: taConfig, ContentType: "yaml"})
+	require.NoError(t, err, "Should validate the target allocator")
+
+	// Nothing is created by the validation
+	resource, err := c.GetResource(TargetAllocatorResource, name, namespace)
+	require.NoError(t, err)
+	assert.Nil(t, resource, "Should not create the target allocator")
+
+	err = c.ValidateResource(TargetAllocatorResource, name, namespace, &protobufs.AgentConfigFile{Body: []byte("empty, invalid!"), ContentType: "yaml"})
+	assert.ErrorContains(t, err, "failed to unmarshal config into v1alpha1 API Version")
+}
+
+func TestClient_ListAndDeleteResources(t *testing.T) {
+	namespace := "testing"
+	instrumentations := &v1alpha1.InstrumentationList{Items: []v1alpha1.Instrumentation{
+		{ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: namespace, Labels: map[string]string{ManagedLabelKey: "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bridge", Namespace: namespace, Labels: map[string]string{ManagedLabelKey: bridgeName}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other-bridge", Namespace: namespace, Labels: map[string]string{ManagedLabelKey: "other"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "reporting", Namespace: namespace, Labels: map[string]string{ReportingLabelKey: "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled", Namespace: namespace}},
+	}}
+	fakeClient := getFakeClient(t, instrumentations)
+	c := NewClient(bridgeName, clientLogger, fakeClient, nil)
+
+	resources, err := c.ListResources(InstrumentationResource)
+	require.NoError(t, err)
+	var names []string
+	for _, resource := range resources {
+		names = append(names, resource.GetName())
+		assert.Equal(t, InstrumentationResource, resource.GetObjectKind().GroupVersionKind().Kind)
+	}
+	assert.ElementsMatch(t, []string{"managed", "bridge", "reporting"}, names)
+
+	require.NoError(t, c.DeleteResource(InstrumentationResource, "managed", namespace))
+	require.NoError(t, c.DeleteResource(InstrumentationResource, "missing", namespace), "Should ignore missing resources")
+	err = fakeClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: "managed"}, &v1alpha1.Instrumentation{})
+	assert.True(t, errors.IsNotFound(err), "Should delete the instrumentation")
+}
+
+// loadObject returns the config file of the given resource.
+func loadObject(resource any) (*protobufs.AgentConfigFile, error) {
+	body, err := yaml.Marshal(resource)
+	if err != nil {
+		return nil, err
+	}
+	return &protobufs.AgentConfigFile{Body: body, ContentType: "yaml"}, nil
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// RolloutComplete tells whether the operator rolled out the current spec of the collector, from the Ready condition of
+// its status. It returns an error when the collector is degraded, e.g. by a configuration the collector rejects, as it
+// can't become ready without a change.
+func RolloutComplete(collector *v1beta1.OpenTelemetryCollector) (bool, error) {
+	if collector.Status.ObservedGeneration < collector.GetGeneration() {
+		return false, nil
+	}
+	ready := meta.FindStatusCondition(collector.Status.Conditions, v1beta1.ConditionReady)
+	switch {
+	case ready == nil:
+		return false, nil
+	case ready.Status == metav1.ConditionTrue:
+		return true, nil
+	case ready.Reason == v1beta1.ReasonDegraded:
+		return false, fmt.Errorf("the collector %s/%s is degraded: %s", collector.GetNamespace(), collector.GetName(), ready.Message)
+	default:
+		return false, nil
+	}
+}
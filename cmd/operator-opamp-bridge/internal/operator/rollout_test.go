@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package operator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestRolloutComplete(t *testing.T) {
+	tests := []struct {
+		name               string
+		generation         int64
+		observedGeneration int64
+		ready              *metav1.Condition
+		want               bool
+		wantErr            string
+	}{
+		{
+			name:               "ready",
+			generation:         2,
+			observedGeneration: 2,
+			ready:              &metav1.Condition{Status: metav1.ConditionTrue, Reason: v1beta1.ReasonReady},
+			want:               true,
+		},
+		{
+			name:               "ready for a previous generation",
+			generation:         3,
+			observedGeneration: 2,
+			ready:              &metav1.Condition{Status: metav1.ConditionTrue, Reason: v1beta1.ReasonReady},
+		},
+		{
+			name:               "no condition",
+			generation:         2,
+			observedGeneration: 2,
+		},
+		{
+			name:               "progressing",
+			generation:         2,
+			observedGeneration: 2,
+			ready:              &metav1.Condition{Status: metav1.ConditionFalse, Reason: v1beta1.ReasonProgressing},
+		},
+		{
+			name:               "degraded",
+			generation:         2,
+			observedGeneration: 2,
+			ready:              &metav1.Condition{Status: metav1.ConditionFalse, Reason: v1beta1.ReasonDegraded, Message: "the configuration is invalid"},
+			wantErr:            "the collector testing/test is degraded: the configuration is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := &v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "testing", Generation: tt.generation},
+				Status:     v1beta1.OpenTelemetryCollectorStatus{ObservedGeneration: tt.observedGeneration},
+			}
+			if tt.ready != nil {
+				tt.ready.Type = v1beta1.ConditionReady
+				collector.Status.Conditions = []metav1.Condition{*tt.ready}
+			}
+			got, err := RolloutComplete(collector)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
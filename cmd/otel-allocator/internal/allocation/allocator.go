@@ -5,6 +5,7 @@ package allocation
 
 import (
 	"errors"
+	"maps"
 	"runtime"
 	"slices"
 	"sync"
@@ -29,8 +30,10 @@ func newAllocator(log logr.Logger, strategy Strategy, opts ...Option) Allocator
 	chAllocator := &allocator{
 		strategy:                      strategy,
 		collectors:                    make(map[string]*Collector),
+		assignable:                    make(map[string]*Collector),
 		targetItems:                   make(map[target.ItemHash]*target.Item),
 		targetItemsPerJobPerCollector: make(map[string]map[string]map[target.ItemHash]bool),
+		changed:                       make(chan struct{}),
 		log:                           log,
 	}
 	for _, opt := range opts {
@@ -47,6 +50,9 @@ type allocator struct {
 	// collectorKey -> collector pointer
 	collectors map[string]*Collector
 
+	// assignable holds the collectors which aren't draining, and can be assigned new targets
+	assignable map[string]*Collector
+
 	// targetItems is a map from a target item's hash to the target items allocated state
 	// targetItem hash -> target item pointer
 	targetItems map[target.ItemHash]*target.Item
@@ -54,7 +60,19 @@ type allocator struct {
 	// collectorKey -> job -> target item hash -> true
 	targetItemsPerJobPerCollector map[string]map[string]map[target.ItemHash]bool
 
-	// m protects collectors, targetItems and targetItemsPerJobPerCollector for concurrent use.
+	// changed is closed and replaced whenever the targets or their assignments change
+	changed chan struct{}
+
+	// restored holds the assignments from before a restart, which haven't been applied yet
+	// target item hash -> collector name
+	restored map[target.ItemHash]string
+
+	// following holds the assignments of the leading allocator, which are served as they are while the allocator
+	// follows them, nil otherwise
+	// target item hash -> collector name
+	following map[target.ItemHash]string
+
+	// m protects collectors, targetItems, targetItemsPerJobPerCollector and changed for concurrent use.
 	m sync.RWMutex
 
 	log logr.Logger
@@ -94,6 +112,7 @@ func (a *allocator) SetTargets(targets []*target.Item) {
 	// If there are any additions or removals
 	if len(targetsDiff.Additions()) != 0 || len(targetsDiff.Removals()) != 0 {
 		a.handleTargets(targetsDiff)
+		a.notifyChanged()
 	}
 }
 
@@ -111,10 +130,26 @@ func (a *allocator) SetCollectors(collectors map[string]*Collector) {
 	a.m.Lock()
 	defer a.m.Unlock()
 
-	// Check for collector changes
+	// Check for collector changes, draining collectors and weight changes are updated in place
+	updated := false
+	for name, collector := range collectors {
+		current, ok := a.collectors[name]
+		if !ok {
+			continue
+		}
+		if current.Draining != collector.Draining {
+			current.Draining = collector.Draining
+			updated = true
+		}
+		if current.weight() != collector.weight() {
+			current.Weight = collector.Weight
+			updated = true
+		}
+	}
 	collectorsDiff := diff.Maps(a.collectors, collectors)
-	if len(collectorsDiff.Additions()) != 0 || len(collectorsDiff.Removals()) != 0 {
+	if len(collectorsDiff.Additions()) != 0 || len(collectorsDiff.Removals()) != 0 || updated {
 		a.handleCollectors(collectorsDiff)
+		a.notifyChanged()
 	}
 }
 
@@ -136,6 +171,104 @@ func (a *allocator) GetTargetsForCollectorAndJob(collector string, job string) [
 	return targetItemsCopy
 }
 
+// Changed returns a channel which is closed the next time the targets or their assignments change.
+func (a *allocator) Changed() <-chan struct{} {
+	a.m.RLock()
+	defer a.m.RUnlock()
+	return a.changed
+}
+
+// Assignments returns the names of the collectors the targets are assigned to, by target hash.
+func (a *allocator) Assignments() map[target.ItemHash]string {
+	a.m.RLock()
+	defer a.m.RUnlock()
+	assignments := make(map[target.ItemHash]string, len(a.targetItems))
+	for hash, item := range a.targetItems {
+		if item.CollectorName != "" {
+			assignments[hash] = item.CollectorName
+		}
+	}
+	return assignments
+}
+
+// RestoreAssignments makes the targets keep the collectors they were assigned to before a restart, or by the leading
+// allocator. A restored assignment is applied once, when both the target and its collector are known.
+func (a *allocator) RestoreAssignments(assignments map[target.ItemHash]string) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.restored = maps.Clone(assignments)
+
+	// move the current targets right away
+	changed := false
+	for hash, collectorName := range assignments {
+		item, ok := a.targetItems[hash]
+		if !ok {
+			continue
+		}
+		if item.CollectorName == collectorName {
+			delete(a.restored, hash)
+			continue
+		}
+		if _, ok = a.assignable[collectorName]; !ok {
+			continue
+		}
+		if err := a.addTargetToTargetItems(item); err != nil {
+			a.log.Info("Could not restore the target assignment", "error", err)
+			continue
+		}
+		changed = changed || item.CollectorName == collectorName
+	}
+	if changed {
+		a.notifyChanged()
+	}
+}
+
+// FollowAssignments makes the allocator serve the assignments of the leading allocator as they are, instead of
+// assigning the targets itself. The targets the leader hasn't assigned yet are left unassigned.
+func (a *allocator) FollowAssignments(assignments map[target.ItemHash]string) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.following = make(map[target.ItemHash]string, len(assignments))
+	maps.Copy(a.following, assignments)
+	a.reassignTargets(func(*target.Item) bool { return true })
+}
+
+// StopFollowing makes the allocator assign the targets itself again, the targets keep the collectors of the leader.
+func (a *allocator) StopFollowing() {
+	a.m.Lock()
+	defer a.m.Unlock()
+	if a.following == nil {
+		return
+	}
+	a.following = nil
+	a.reassignTargets(func(item *target.Item) bool { return item.CollectorName == "" })
+}
+
+// reassignTargets assigns the targets matching the predicate again, and wakes up the waiters if any assignment
+// changed. The caller must hold the write lock.
+func (a *allocator) reassignTargets(predicate func(*target.Item) bool) {
+	changed := false
+	for _, item := range a.targetItems {
+		if !predicate(item) {
+			continue
+		}
+		previous := item.CollectorName
+		if err := a.addTargetToTargetItems(item); err != nil {
+			a.log.Info("Could not assign the target", "error", err)
+		}
+		changed = changed || item.CollectorName != previous
+	}
+	if changed {
+		a.notifyChanged()
+	}
+}
+
+// notifyChanged wakes up the waiters for the next change. The caller must hold the write lock.
+func (a *allocator) notifyChanged() {
+	close(a.changed)
+	a.changed = make(chan struct{})
+}
+
 // TargetItems returns a shallow copy of the targetItems map.
 func (a *allocator) TargetItems() map[target.ItemHash]*target.Item {
 	a.m.RLock()
@@ -147,13 +280,14 @@ func (a *allocator) TargetItems() map[target.ItemHash]*target.Item {
 	return targetItemsCopy
 }
 
-// Collectors returns a shallow copy of the collectors map.
+// Collectors returns a copy of the collectors, which the allocator keeps updating.
 func (a *allocator) Collectors() map[string]*Collector {
 	a.m.RLock()
 	defer a.m.RUnlock()
 	collectorsCopy := make(map[string]*Collector)
 	for k, v := range a.collectors {
-		collectorsCopy[k] = v
+		collector := *v
+		collectorsCopy[k] = &collector
 	}
 	return collectorsCopy
 }
@@ -198,19 +332,34 @@ func (a *allocator) handleTargets(diff diff.Changes[target.ItemHash, *target.Ite
 
 func (a *allocator) addTargetToTargetItems(tg *target.Item) error {
 	a.targetItems[tg.Hash()] = tg
-	if len(a.collectors) == 0 {
+	if a.following != nil {
+		a.followTarget(tg)
+		return nil
+	}
+	// draining collectors keep their targets until they're removed, but don't get new ones
+	if current, ok := a.collectors[tg.CollectorName]; ok && current.Draining {
+		return nil
+	}
+	if len(a.assignable) == 0 {
 		return nil
 	}
 
-	colOwner, err := a.strategy.GetCollectorForTarget(a.collectors, tg)
-	if err != nil {
-		return err
+	colOwner, ok := a.restoredCollector(tg)
+	if !ok {
+		var err error
+		colOwner, err = a.strategy.GetCollectorForTarget(a.assignable, tg)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Check if this is a reassignment, if so, unassign first
 	// note: The ordering here is important, we want to determine the new assignment before unassigning, because
 	// the strategy might make use of previous assignment information
 	if _, ok := a.collectors[tg.CollectorName]; ok && tg.CollectorName != "" {
+		if tg.CollectorName != colOwner.Name {
+			TargetsReassigned.WithLabelValues(a.strategy.GetName()).Inc()
+		}
 		a.unassignTargetItem(tg)
 	}
 
@@ -222,6 +371,44 @@ func (a *allocator) addTargetToTargetItems(tg *target.Item) error {
 	return nil
 }
 
+// followTarget assigns the target to the collector the leading allocator assigned it to, or leaves it unassigned
+// when the leader hasn't assigned it to a known collector.
+func (a *allocator) followTarget(tg *target.Item) {
+	name := a.following[tg.Hash()]
+	_, known := a.collectors[name]
+	if known && tg.CollectorName == name {
+		return
+	}
+	a.unassignTargetItem(tg)
+	tg.CollectorName = ""
+	if !known {
+		return
+	}
+	tg.CollectorName = name
+	a.addCollectorTargetItemMapping(tg)
+	a.collectors[name].NumTargets++
+	TargetsPerCollector.WithLabelValues(name, a.strategy.GetName()).Set(float64(a.collectors[name].NumTargets))
+}
+
+// restoredCollector returns the collector the target was assigned to before a restart, if it can be assigned targets
+// and the strategy allows assigning the target to it. The assignments the strategy doesn't allow are discarded.
+func (a *allocator) restoredCollector(tg *target.Item) (*Collector, bool) {
+	name, ok := a.restored[tg.Hash()]
+	if !ok {
+		return nil, false
+	}
+	collector, ok := a.assignable[name]
+	if !ok {
+		return nil, false
+	}
+	delete(a.restored, tg.Hash())
+	if constrained, isConstrained := a.strategy.(constrainedStrategy); isConstrained && !constrained.CanAssign(collector, tg) {
+		a.log.V(2).Info("Discarding the restored assignment the strategy doesn't allow", "target", tg.Hash(), "collector", name)
+		return nil, false
+	}
+	return collector, true
+}
+
 // unassignTargetItem unassigns the target item from its Collector. The target item is still tracked.
 func (a *allocator) unassignTargetItem(item *target.Item) {
 	collectorName := item.CollectorName
@@ -245,19 +432,22 @@ func (a *allocator) unassignTargetItem(item *target.Item) {
 func (a *allocator) removeTargetItem(item *target.Item) {
 	a.unassignTargetItem(item)
 	delete(a.targetItems, item.Hash())
+	delete(a.restored, item.Hash())
 }
 
 // removeCollector removes a Collector from the allocator.
 func (a *allocator) removeCollector(collector *Collector) {
 	delete(a.collectors, collector.Name)
-	// Remove the collector from any target item records
+	delete(a.assignable, collector.Name)
+	// Remove the collector from any target item records, its targets are reassigned
 	for _, targetItems := range a.targetItemsPerJobPerCollector[collector.Name] {
 		for targetHash := range targetItems {
 			a.targetItems[targetHash].CollectorName = ""
 		}
+		TargetsReassigned.WithLabelValues(a.strategy.GetName()).Add(float64(len(targetItems)))
 	}
 	delete(a.targetItemsPerJobPerCollector, collector.Name)
-	TargetsPerCollector.WithLabelValues(collector.Name, a.strategy.GetName()).Set(0)
+	TargetsPerCollector.DeleteLabelValues(collector.Name, a.strategy.GetName())
 }
 
 // addCollectorTargetItemMapping keeps track of which collector has which jobs and targets
@@ -283,11 +473,20 @@ func (a *allocator) handleCollectors(diff diff.Changes[string, *Collector]) {
 	}
 	// Insert the new collectors
 	for _, i := range diff.Additions() {
-		a.collectors[i.Name] = NewCollector(i.Name, i.NodeName)
+		a.collectors[i.Name] = NewCollector(i.Name, i.NodeName, i.Zone)
+		a.collectors[i.Name].Draining = i.Draining
+		a.collectors[i.Name].Weight = i.Weight
+		a.collectors[i.Name].Tenant = i.Tenant
+	}
+	clear(a.assignable)
+	for name, collector := range a.collectors {
+		if !collector.Draining {
+			a.assignable[name] = collector
+		}
 	}
 
 	// Set collectors on the strategy
-	a.strategy.SetCollectors(a.collectors)
+	a.strategy.SetCollectors(a.assignable)
 
 	// Re-Allocate all targets
 	var assignmentErrors []error
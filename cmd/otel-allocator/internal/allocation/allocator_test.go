@@ -4,10 +4,15 @@
 package allocation
 
 import (
+	"maps"
+	"strings"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
 )
@@ -191,3 +196,182 @@ func TestAllocationCollision(t *testing.T) {
 		}
 	})
 }
+
+func TestDrainingCollectors(t *testing.T) {
+	for _, strategy := range []string{leastWeightedStrategyName, consistentHashingStrategyName, zoneAwareStrategyName} {
+		t.Run(strategy, func(t *testing.T) {
+			allocator, err := New(strategy, logger)
+			assert.NoError(t, err)
+			allocator.SetCollectors(MakeNCollectors(3, 0))
+			allocator.SetTargets(MakeNNewTargets(30, 3, 0))
+			drainingTargets := allocator.Collectors()["collector-0"].NumTargets
+
+			// the draining collector keeps its targets
+			cols := MakeNCollectors(3, 0)
+			cols["collector-0"].Draining = true
+			allocator.SetCollectors(cols)
+			assert.True(t, allocator.Collectors()["collector-0"].Draining)
+			assert.Equal(t, drainingTargets, allocator.Collectors()["collector-0"].NumTargets)
+
+			// but doesn't get new ones
+			allocator.SetTargets(append(MakeNNewTargets(30, 3, 0), MakeNNewTargetsWithEmptyCollectors(30, 30)...))
+			assert.Equal(t, drainingTargets, allocator.Collectors()["collector-0"].NumTargets)
+
+			// its targets are reassigned once it's removed
+			delete(cols, "collector-0")
+			allocator.SetCollectors(cols)
+			for _, item := range allocator.TargetItems() {
+				assert.Contains(t, []string{"collector-1", "collector-2"}, item.CollectorName)
+			}
+		})
+	}
+}
+
+func TestReassignmentMetrics(t *testing.T) {
+	allocator, err := New(leastWeightedStrategyName, logger)
+	assert.NoError(t, err)
+	allocator.SetCollectors(MakeNCollectors(3, 0))
+	allocator.SetTargets(MakeNNewTargets(30, 3, 0))
+	assert.Equal(t, float64(10), testutil.ToFloat64(TargetsPerCollector.WithLabelValues("collector-0", leastWeightedStrategyName)))
+	reassigned := testutil.ToFloat64(TargetsReassigned.WithLabelValues(leastWeightedStrategyName))
+
+	// the targets of a removed collector are reassigned, and its series is deleted
+	allocator.SetCollectors(MakeNCollectors(2, 1))
+	assert.Equal(t, reassigned+10, testutil.ToFloat64(TargetsReassigned.WithLabelValues(leastWeightedStrategyName)))
+	assert.False(t, TargetsPerCollector.DeleteLabelValues("collector-0", leastWeightedStrategyName))
+	assert.Equal(t, float64(15), testutil.ToFloat64(TargetsPerCollector.WithLabelValues("collector-1", leastWeightedStrategyName)))
+}
+
+func TestChanged(t *testing.T) {
+	RunForAllStrategies(t, func(t *testing.T, allocator Allocator) {
+		changed := allocator.Changed()
+		allocator.SetCollectors(MakeNCollectors(3, 0))
+		assert.True(t, isClosed(changed))
+
+		changed = allocator.Changed()
+		targets := MakeNNewTargetsWithEmptyCollectors(3, 0)
+		allocator.SetTargets(targets)
+		assert.True(t, isClosed(changed))
+
+		// setting the same targets again isn't a change
+		changed = allocator.Changed()
+		allocator.SetTargets(targets)
+		assert.False(t, isClosed(changed))
+	})
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestRestoreAssignments(t *testing.T) {
+	RunForAllStrategies(t, func(t *testing.T, allocator Allocator) {
+		if strings.HasSuffix(t.Name(), perNodeStrategyName) {
+			t.Skip("the targets are on the node of collector-0, the only one the per-node strategy allows")
+		}
+		targets := MakeNNewTargetsWithEmptyCollectors(10, 0)
+		restored := map[target.ItemHash]string{}
+		for _, item := range targets {
+			restored[item.Hash()] = "collector-2"
+		}
+		// collector-3 doesn't exist anymore
+		restored[targets[0].Hash()] = "collector-3"
+		allocator.RestoreAssignments(restored)
+
+		allocator.SetCollectors(MakeNCollectors(3, 0))
+		allocator.SetTargets(targets)
+
+		assignments := allocator.Assignments()
+		assert.Len(t, assignments, len(targets))
+		for _, item := range targets[1:] {
+			assert.Equal(t, "collector-2", assignments[item.Hash()])
+		}
+		assert.NotEqual(t, "collector-3", assignments[targets[0].Hash()])
+	})
+}
+
+func TestRestoreAssignmentsOfCurrentTargets(t *testing.T) {
+	RunForAllStrategies(t, func(t *testing.T, allocator Allocator) {
+		if strings.HasSuffix(t.Name(), perNodeStrategyName) {
+			t.Skip("the targets are on the node of collector-0, the only one the per-node strategy allows")
+		}
+		targets := MakeNNewTargetsWithEmptyCollectors(10, 0)
+		allocator.SetCollectors(MakeNCollectors(3, 0))
+		allocator.SetTargets(targets)
+
+		restored := map[target.ItemHash]string{}
+		for _, item := range targets {
+			restored[item.Hash()] = "collector-1"
+		}
+		changed := allocator.Changed()
+		allocator.RestoreAssignments(restored)
+
+		assert.True(t, isClosed(changed))
+		for _, collector := range allocator.Assignments() {
+			assert.Equal(t, "collector-1", collector)
+		}
+		assert.Len(t, allocator.GetTargetsForCollectorAndJob("collector-1", "test-job-0"), 1)
+		assert.Empty(t, allocator.GetTargetsForCollectorAndJob("collector-0", "test-job-0"))
+		assert.Equal(t, 10, allocator.Collectors()["collector-1"].NumTargets)
+	})
+}
+
+func TestFollowAssignments(t *testing.T) {
+	RunForAllStrategies(t, func(t *testing.T, allocator Allocator) {
+		targets := MakeNNewTargetsWithEmptyCollectors(10, 0)
+		allocator.SetCollectors(MakeNCollectors(3, 0))
+		allocator.SetTargets(targets)
+
+		// the leader only assigned half of the targets, one of them to a collector this replica doesn't know yet
+		leaderAssignments := map[target.ItemHash]string{}
+		for _, item := range targets[:5] {
+			leaderAssignments[item.Hash()] = "collector-2"
+		}
+		leaderAssignments[targets[0].Hash()] = "collector-3"
+		allocator.FollowAssignments(leaderAssignments)
+
+		expected := maps.Clone(leaderAssignments)
+		delete(expected, targets[0].Hash())
+		assert.Equal(t, expected, allocator.Assignments())
+		assert.Equal(t, 4, allocator.Collectors()["collector-2"].NumTargets)
+		assert.Zero(t, allocator.Collectors()["collector-0"].NumTargets)
+
+		// the new targets and collectors don't change the assignments of the leader
+		allocator.SetCollectors(MakeNCollectors(4, 0))
+		allocator.SetTargets(append(targets, MakeNNewTargetsWithEmptyCollectors(2, 10)...))
+		assert.Equal(t, leaderAssignments, allocator.Assignments())
+
+		// the replica assigns the targets the leader didn't assign once it leads
+		allocator.StopFollowing()
+		assignments := allocator.Assignments()
+		assert.Len(t, assignments, 12)
+		for hash, collector := range leaderAssignments {
+			assert.Equal(t, collector, assignments[hash])
+		}
+	})
+}
+
+func TestRestoreAssignmentsRespectsTheStrategy(t *testing.T) {
+	allocator, err := New(perNodeStrategyName, logf.Log.WithName("unit-tests"))
+	require.NoError(t, err)
+	targets := MakeNNewTargetsWithEmptyCollectors(10, 0)
+	restored := map[target.ItemHash]string{}
+	for _, item := range targets {
+		restored[item.Hash()] = "collector-1"
+	}
+	allocator.RestoreAssignments(restored)
+
+	allocator.SetCollectors(MakeNCollectors(3, 0))
+	allocator.SetTargets(targets)
+
+	// the targets are on the node of collector-0
+	for _, collector := range allocator.Assignments() {
+		assert.Equal(t, "collector-0", collector)
+	}
+	assert.Equal(t, 10, allocator.Collectors()["collector-0"].NumTargets)
+}
@@ -4,6 +4,8 @@
 package allocation
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 
 	"github.com/buraksezer/consistent"
@@ -12,7 +14,67 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
 )
 
-const consistentHashingStrategyName = "consistent-hashing"
+const (
+	consistentHashingStrategyName = "consistent-hashing"
+
+	xxhashName = "xxhash"
+	sha256Name = "sha256"
+)
+
+// ConsistentHashingConfig tunes the hash ring of the consistent-hashing strategy, also used within the zones of the
+// zone-aware strategy. Zero values keep the defaults.
+type ConsistentHashingConfig struct {
+	// PartitionCount is the number of partitions of the ring, which are distributed between the collectors.
+	PartitionCount int
+	// ReplicationFactor is the number of virtual nodes of each collector on the ring.
+	ReplicationFactor int
+	// Hash is the hash function, xxhash or sha256.
+	Hash string
+}
+
+var (
+	defaultConsistentHashingConfig = consistent.Config{
+		PartitionCount:    1061,
+		ReplicationFactor: 5,
+		Load:              1.1,
+		Hasher:            hasher{},
+	}
+	// consistentHashingConfig is the configuration of the consistent hashing strategies created from now on.
+	consistentHashingConfig = defaultConsistentHashingConfig
+)
+
+// ConfigureConsistentHashing sets the hash ring parameters of the consistent-hashing and zone-aware strategies. It must
+// be called before creating the allocator.
+func ConfigureConsistentHashing(cfg ConsistentHashingConfig) error {
+	config := defaultConsistentHashingConfig
+	if cfg.PartitionCount > 0 {
+		config.PartitionCount = cfg.PartitionCount
+	}
+	if cfg.ReplicationFactor > 0 {
+		config.ReplicationFactor = cfg.ReplicationFactor
+	}
+	switch cfg.Hash {
+	case "":
+	case xxhashName:
+		config.Hasher = hasher{}
+	case sha256Name:
+		config.Hasher = sha256Hasher{}
+	default:
+		return fmt.Errorf("unknown consistent hashing hash function: %s", cfg.Hash)
+	}
+	consistentHashingConfig = config
+	return nil
+}
+
+// weightedMember is an additional member of the hash ring, for each unit of weight of a collector above 1.
+type weightedMember struct {
+	collector string
+	index     int
+}
+
+func (m weightedMember) String() string {
+	return fmt.Sprintf("%s#%d", m.collector, m.index)
+}
 
 type hasher struct{}
 
@@ -20,24 +82,29 @@ func (h hasher) Sum64(data []byte) uint64 {
 	return xxhash.Sum64(data)
 }
 
+type sha256Hasher struct{}
+
+func (h sha256Hasher) Sum64(data []byte) uint64 {
+	sum := sha256.Sum256(data)
+	return binary.BigEndian.Uint64(sum[:8])
+}
+
 var _ Strategy = &consistentHashingStrategy{}
 
 type consistentHashingStrategy struct {
 	config           consistent.Config
 	consistentHasher *consistent.Consistent
+	// collectorByMember maps the weighted members of the ring to the name of their collector
+	collectorByMember map[string]string
 }
 
 func newConsistentHashingStrategy() Strategy {
-	config := consistent.Config{
-		PartitionCount:    1061,
-		ReplicationFactor: 5,
-		Load:              1.1,
-		Hasher:            hasher{},
-	}
+	config := consistentHashingConfig
 	consistentHasher := consistent.New(nil, config)
 	chStrategy := &consistentHashingStrategy{
-		consistentHasher: consistentHasher,
-		config:           config,
+		consistentHasher:  consistentHasher,
+		config:            config,
+		collectorByMember: map[string]string{},
 	}
 	return chStrategy
 }
@@ -50,6 +117,9 @@ func (s *consistentHashingStrategy) GetCollectorForTarget(collectors map[string]
 	hashKey := item.TargetURL
 	member := s.consistentHasher.LocateKey([]byte(hashKey))
 	collectorName := member.String()
+	if name, ok := s.collectorByMember[collectorName]; ok {
+		collectorName = name
+	}
 	collector, ok := collectors[collectorName]
 	if !ok {
 		return nil, fmt.Errorf("unknown collector %s", collectorName)
@@ -60,17 +130,27 @@ func (s *consistentHashingStrategy) GetCollectorForTarget(collectors map[string]
 func (s *consistentHashingStrategy) SetCollectors(collectors map[string]*Collector) {
 	// we simply recreate the hasher with the new member set
 	// this isn't any more expensive than doing a diff and then applying the change
+	// heavier collectors get one more member per unit of weight, and so proportionally more partitions of the ring
 	var members []consistent.Member
+	clear(s.collectorByMember)
 
 	if len(collectors) > 0 {
 		members = make([]consistent.Member, 0, len(collectors))
 		for _, collector := range collectors {
 			members = append(members, collector)
+			for i := 1; i < collector.weight(); i++ {
+				member := weightedMember{collector: collector.Name, index: i}
+				s.collectorByMember[member.String()] = collector.Name
+				members = append(members, member)
+			}
 		}
 	}
 
-	s.consistentHasher = consistent.New(members, s.config)
-
+	// the ring needs at least a partition per member, otherwise distributing the partitions panics, e.g. with many
+	// heavy collectors
+	config := s.config
+	config.PartitionCount = max(config.PartitionCount, len(members))
+	s.consistentHasher = consistent.New(members, config)
 }
 
 func (s *consistentHashingStrategy) SetFallbackStrategy(fallbackStrategy Strategy) {}
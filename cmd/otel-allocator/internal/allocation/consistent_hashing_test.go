@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRelativelyEvenDistribution(t *testing.T) {
@@ -119,3 +120,69 @@ func TestTargetsWithNoCollectorsConsistentHashing(t *testing.T) {
 		assert.InDelta(t, col.NumTargets, expectedPerCollector, expectedDelta)
 	}
 }
+
+func TestWeightedDistribution(t *testing.T) {
+	numItems := 7000
+	cols := MakeNCollectors(5, 0)
+	cols["collector-0"].Weight = 3
+	c, _ := New("consistent-hashing", logger)
+	c.SetCollectors(cols)
+	c.SetTargets(MakeNNewTargets(numItems, 0, 0))
+	for _, col := range c.Collectors() {
+		expectedPerCollector := float64(numItems / 7 * col.weight())
+		assert.InDelta(t, expectedPerCollector, col.NumTargets, expectedPerCollector*0.5, col.Name)
+	}
+
+	// lowering the weight reallocates the targets evenly
+	cols = MakeNCollectors(5, 0)
+	c.SetCollectors(cols)
+	for _, col := range c.Collectors() {
+		assert.Equal(t, 1, col.weight())
+		assert.InDelta(t, float64(numItems/5), col.NumTargets, float64(numItems/5)*0.5, col.Name)
+	}
+}
+
+func TestWeightedDistributionWithMoreMembersThanPartitions(t *testing.T) {
+	numItems := 11000
+	cols := MakeNCollectors(11, 0)
+	for _, col := range cols {
+		col.Weight = 100
+	}
+	c, _ := New("consistent-hashing", logger)
+	// the 1100 members of the ring outnumber its 1061 partitions
+	require.NotPanics(t, func() { c.SetCollectors(cols) })
+	c.SetTargets(MakeNNewTargets(numItems, 0, 0))
+	for _, col := range c.Collectors() {
+		assert.InDelta(t, numItems/len(cols), col.NumTargets, float64(numItems/len(cols))*0.5, col.Name)
+	}
+}
+
+func TestConfigureConsistentHashing(t *testing.T) {
+	defer func() {
+		assert.NoError(t, ConfigureConsistentHashing(ConsistentHashingConfig{}))
+	}()
+
+	assert.Error(t, ConfigureConsistentHashing(ConsistentHashingConfig{Hash: "md5"}))
+
+	assert.NoError(t, ConfigureConsistentHashing(ConsistentHashingConfig{PartitionCount: 2053, ReplicationFactor: 20, Hash: sha256Name}))
+	c, _ := New(consistentHashingStrategyName, logger)
+	strategy := c.(*allocator).strategy.(*consistentHashingStrategy)
+	assert.Equal(t, 2053, strategy.config.PartitionCount)
+	assert.Equal(t, 20, strategy.config.ReplicationFactor)
+	assert.Equal(t, sha256Hasher{}, strategy.config.Hasher)
+	assert.Equal(t, defaultConsistentHashingConfig.Load, strategy.config.Load)
+
+	numCols := 10
+	numItems := 10000
+	c.SetCollectors(MakeNCollectors(numCols, 0))
+	c.SetTargets(MakeNNewTargets(numItems, 0, 0))
+	for _, col := range c.Collectors() {
+		assert.InDelta(t, numItems/numCols, col.NumTargets, float64(numItems/numCols)*0.5)
+	}
+
+	// the zero values restore the defaults
+	assert.NoError(t, ConfigureConsistentHashing(ConsistentHashingConfig{}))
+	c, _ = New(consistentHashingStrategyName, logger)
+	strategy = c.(*allocator).strategy.(*consistentHashingStrategy)
+	assert.Equal(t, defaultConsistentHashingConfig, strategy.config)
+}
@@ -30,12 +30,13 @@ func (s *leastWeightedStrategy) GetCollectorForTarget(collectors map[string]*Col
 		}
 	}
 
+	// pick the collector with the fewest targets per unit of weight, comparing the cross products to avoid dividing
 	var col *Collector
 	for _, v := range collectors {
 		// If the initial collector is empty, set the initial collector to the first element of map
 		if col == nil {
 			col = v
-		} else if v.NumTargets < col.NumTargets {
+		} else if v.NumTargets*col.weight() < col.NumTargets*v.weight() {
 			col = v
 		}
 	}
@@ -62,11 +62,11 @@ func TestNoAssignmentToNewCollector(t *testing.T) {
 		assert.NotNil(t, s.Collectors()[i.Name])
 	}
 
-	initialColsBeforeAddingNewCol := s.Collectors()
 	initTargets := MakeNNewTargets(6, 0, 0)
 
 	// test that targets and collectors are added properly
 	s.SetTargets(initTargets)
+	initialColsBeforeAddingNewCol := s.Collectors()
 
 	// verify
 	expectedTargetLen := len(initTargets)
@@ -206,3 +206,16 @@ func TestTargetsWithNoCollectorsLeastWeighted(t *testing.T) {
 		assert.InDelta(t, i.NumTargets, count, math.Round(percent))
 	}
 }
+
+func TestWeightedCollectors(t *testing.T) {
+	s, _ := New("least-weighted", logger)
+
+	cols := MakeNCollectors(2, 0)
+	cols["collector-1"].Weight = 3
+	s.SetCollectors(cols)
+	s.SetTargets(MakeNNewTargetsWithEmptyCollectors(400, 0))
+
+	collectors := s.Collectors()
+	assert.InDelta(t, 100, collectors["collector-0"].NumTargets, 1)
+	assert.InDelta(t, 300, collectors["collector-1"].NumTargets, 1)
+}
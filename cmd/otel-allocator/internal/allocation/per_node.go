@@ -11,7 +11,10 @@ import (
 
 const perNodeStrategyName = "per-node"
 
-var _ Strategy = &perNodeStrategy{}
+var (
+	_ Strategy            = &perNodeStrategy{}
+	_ constrainedStrategy = &perNodeStrategy{}
+)
 
 type perNodeStrategy struct {
 	collectorByNode  map[string]*Collector
@@ -46,6 +49,16 @@ func (s *perNodeStrategy) GetCollectorForTarget(collectors map[string]*Collector
 	return collectors[collector.Name], nil
 }
 
+// CanAssign only allows the collector of the node of the target, or any collector for the targets without a node
+// when there is a fallback strategy.
+func (s *perNodeStrategy) CanAssign(collector *Collector, item *target.Item) bool {
+	targetNodeName := item.GetNodeName()
+	if targetNodeName == "" {
+		return s.fallbackStrategy != nil
+	}
+	return collector.NodeName == targetNodeName
+}
+
 func (s *perNodeStrategy) SetCollectors(collectors map[string]*Collector) {
 	clear(s.collectorByNode)
 	for _, collector := range collectors {
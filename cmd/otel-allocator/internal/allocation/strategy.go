@@ -17,10 +17,13 @@ import (
 type AllocatorProvider func(log logr.Logger, opts ...Option) Allocator
 
 var (
-	strategies = map[string]Strategy{
-		leastWeightedStrategyName:     newleastWeightedStrategy(),
-		consistentHashingStrategyName: newConsistentHashingStrategy(),
-		perNodeStrategyName:           newPerNodeStrategy(),
+	// strategies creates a new instance of each strategy, strategies keep state and can't be shared between
+	// allocators.
+	strategies = map[string]func() Strategy{
+		leastWeightedStrategyName:     newleastWeightedStrategy,
+		consistentHashingStrategyName: newConsistentHashingStrategy,
+		perNodeStrategyName:           newPerNodeStrategy,
+		zoneAwareStrategyName:         newZoneAwareStrategy,
 	}
 
 	// TargetsPerCollector records how many targets have been assigned to each collector.
@@ -45,6 +48,11 @@ var (
 		Name: "opentelemetry_allocator_targets_unassigned",
 		Help: "Number of targets that could not be assigned due to missing node label.",
 	})
+	// TargetsReassigned counts the targets moved from one collector to another, the churn of the allocation.
+	TargetsReassigned = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_allocator_targets_reassigned_total",
+		Help: "Number of targets moved from one collector to another.",
+	}, []string{"strategy"})
 )
 
 type Option func(Allocator)
@@ -60,11 +68,15 @@ func WithFilter(filter Filter) Option {
 }
 
 func WithFallbackStrategy(fallbackStrategy string) Option {
-	var strategy, ok = strategies[fallbackStrategy]
+	var newStrategy, ok = strategies[fallbackStrategy]
 	if fallbackStrategy != "" && !ok {
 		panic(fmt.Errorf("unregistered strategy used as fallback: %s", fallbackStrategy))
 	}
 	return func(allocator Allocator) {
+		var strategy Strategy
+		if newStrategy != nil {
+			strategy = newStrategy()
+		}
 		allocator.SetFallbackStrategy(strategy)
 	}
 }
@@ -74,8 +86,8 @@ func RecordTargetsKept(targets []*target.Item) {
 }
 
 func New(name string, log logr.Logger, opts ...Option) (Allocator, error) {
-	if strategy, ok := strategies[name]; ok {
-		return newAllocator(log.WithValues("allocator", name), strategy, opts...), nil
+	if newStrategy, ok := strategies[name]; ok {
+		return newAllocator(log.WithValues("allocator", name), newStrategy(), opts...), nil
 	}
 	return nil, fmt.Errorf("unregistered strategy: %s", name)
 }
@@ -96,6 +108,18 @@ type Allocator interface {
 	GetTargetsForCollectorAndJob(collector string, job string) []*target.Item
 	SetFilter(filter Filter)
 	SetFallbackStrategy(strategy Strategy)
+	// Changed returns a channel which is closed the next time the targets or their assignments change.
+	Changed() <-chan struct{}
+	// Assignments returns the names of the collectors the targets are assigned to, by target hash.
+	Assignments() map[target.ItemHash]string
+	// RestoreAssignments makes the targets keep the collectors they were assigned to before a restart, or by the
+	// leading allocator.
+	RestoreAssignments(assignments map[target.ItemHash]string)
+	// FollowAssignments makes the allocator serve the assignments of the leading allocator as they are, instead of
+	// assigning the targets itself, until StopFollowing is called.
+	FollowAssignments(assignments map[target.ItemHash]string)
+	// StopFollowing makes the allocator assign the targets itself again.
+	StopFollowing()
 }
 
 type Strategy interface {
@@ -109,6 +133,13 @@ type Strategy interface {
 	SetFallbackStrategy(Strategy)
 }
 
+// constrainedStrategy is implemented by the strategies which only assign a target to some of the collectors, like
+// the collector of its node. The restored assignments must respect these constraints.
+type constrainedStrategy interface {
+	// CanAssign returns whether the strategy allows assigning the target to the collector.
+	CanAssign(collector *Collector, item *target.Item) bool
+}
+
 var _ consistent.Member = Collector{}
 
 // Collector Creates a struct that holds Collector information.
@@ -117,7 +148,15 @@ var _ consistent.Member = Collector{}
 type Collector struct {
 	Name       string
 	NodeName   string
+	Zone       string
 	NumTargets int
+	// Draining collectors are being deleted, they keep their targets until they're removed but don't get new ones.
+	Draining bool
+	// Weight is the capacity of the collector relative to the others, the least-weighted and consistent-hashing
+	// strategies assign proportionally more targets to heavier collectors. Collectors without a weight count as 1.
+	Weight int
+	// Tenant is the tenant the collector scrapes the targets of, when the allocator is shared between tenants.
+	Tenant string
 }
 
 func (c Collector) Hash() string {
@@ -128,6 +167,14 @@ func (c Collector) String() string {
 	return c.Name
 }
 
-func NewCollector(name, node string) *Collector {
-	return &Collector{Name: name, NodeName: node}
+// weight returns the weight of the collector, defaulting to 1.
+func (c Collector) weight() int {
+	if c.Weight < 1 {
+		return 1
+	}
+	return c.Weight
+}
+
+func NewCollector(name, node, zone string) *Collector {
+	return &Collector{Name: name, NodeName: node, Zone: zone}
 }
@@ -0,0 +1,306 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package allocation
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+var (
+	TargetsPerTenant = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_targets_per_tenant",
+		Help: "The number of targets of each tenant.",
+	}, []string{"tenant", "strategy"})
+	CollectorsPerTenant = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "opentelemetry_allocator_collectors_per_tenant",
+		Help: "The number of collectors of each tenant.",
+	}, []string{"tenant", "strategy"})
+)
+
+// TenantAllocator is an allocator shared between tenants. The targets of a tenant are only assigned to the collectors
+// of the same tenant, each tenant has its own allocator.
+type TenantAllocator interface {
+	Allocator
+	// Tenant returns the allocator of a tenant, if it has targets or collectors.
+	Tenant(name string) (Allocator, bool)
+}
+
+var _ TenantAllocator = &tenantAllocator{}
+
+// NewWithTenants creates an allocator shared between tenants. The tenant of a target is the value of its tenantLabel,
+// the tenant of a collector is set by Collector.Tenant. Each tenant is allocated with the named strategy, unless
+// tenantStrategies sets a different one.
+func NewWithTenants(name string, log logr.Logger, tenantLabel string, tenantStrategies map[string]string, opts ...Option) (Allocator, error) {
+	if _, ok := strategies[name]; !ok {
+		return nil, fmt.Errorf("unregistered strategy: %s", name)
+	}
+	for tenant, strategy := range tenantStrategies {
+		if _, ok := strategies[strategy]; !ok {
+			return nil, fmt.Errorf("unregistered strategy for tenant %s: %s", tenant, strategy)
+		}
+	}
+	t := &tenantAllocator{
+		log:              log.WithValues("allocator", name),
+		label:            tenantLabel,
+		strategy:         name,
+		tenantStrategies: tenantStrategies,
+		tenants:          make(map[string]Allocator),
+		collectorTenants: make(map[string]string),
+		changed:          make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t, nil
+}
+
+type tenantAllocator struct {
+	log              logr.Logger
+	label            string
+	strategy         string
+	tenantStrategies map[string]string
+	fallbackStrategy string
+	filter           Filter
+
+	// tenants holds the allocator of each tenant with targets or collectors
+	// tenant -> allocator
+	tenants map[string]Allocator
+
+	// collectorTenants holds the tenant of each collector
+	// collector name -> tenant
+	collectorTenants map[string]string
+
+	// restored holds the assignments from before a restart, for the tenants created from now on
+	restored map[target.ItemHash]string
+
+	// following holds the assignments of the leading allocator while the tenants follow them, nil otherwise
+	following map[target.ItemHash]string
+
+	// changed is closed and replaced whenever the targets or their assignments change in any tenant
+	changed chan struct{}
+
+	// m protects the settings, tenants, collectorTenants, restored, following and changed for concurrent use.
+	m sync.RWMutex
+}
+
+// SetFilter sets the filtering hook, applied to all the targets before they're split between the tenants.
+func (t *tenantAllocator) SetFilter(filter Filter) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.filter = filter
+}
+
+// SetFallbackStrategy sets the fallback strategy of the tenants, each of them gets its own instance.
+func (t *tenantAllocator) SetFallbackStrategy(strategy Strategy) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.fallbackStrategy = ""
+	if strategy != nil {
+		t.fallbackStrategy = strategy.GetName()
+	}
+}
+
+// SetTargets splits the targets between the tenants, by the value of the tenant label.
+func (t *tenantAllocator) SetTargets(targets []*target.Item) {
+	t.m.RLock()
+	filter := t.filter
+	t.m.RUnlock()
+	if filter != nil {
+		targets = filter.Apply(targets)
+	}
+	targetsPerTenant := make(map[string][]*target.Item)
+	for _, item := range targets {
+		tenant := item.Labels.Get(t.label)
+		targetsPerTenant[tenant] = append(targetsPerTenant[tenant], item)
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.update(slices.Collect(maps.Keys(targetsPerTenant)), func(tenant string, allocator Allocator) {
+		allocator.SetTargets(targetsPerTenant[tenant])
+		TargetsPerTenant.WithLabelValues(tenant, t.tenantStrategy(tenant)).Set(float64(len(targetsPerTenant[tenant])))
+	})
+	RecordTargetsKept(targets)
+}
+
+// SetCollectors splits the collectors between the tenants, by their Tenant.
+func (t *tenantAllocator) SetCollectors(collectors map[string]*Collector) {
+	collectorsPerTenant := make(map[string]map[string]*Collector)
+	for name, collector := range collectors {
+		if collectorsPerTenant[collector.Tenant] == nil {
+			collectorsPerTenant[collector.Tenant] = make(map[string]*Collector)
+		}
+		collectorsPerTenant[collector.Tenant][name] = collector
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.collectorTenants = make(map[string]string, len(collectors))
+	for name, collector := range collectors {
+		t.collectorTenants[name] = collector.Tenant
+	}
+	t.update(slices.Collect(maps.Keys(collectorsPerTenant)), func(tenant string, allocator Allocator) {
+		allocator.SetCollectors(collectorsPerTenant[tenant])
+		CollectorsPerTenant.WithLabelValues(tenant, t.tenantStrategy(tenant)).Set(float64(len(collectorsPerTenant[tenant])))
+	})
+}
+
+// update creates the allocators of the new tenants, applies fn to every tenant, then removes the tenants left without
+// targets and collectors. The caller must hold the write lock.
+func (t *tenantAllocator) update(newTenants []string, fn func(tenant string, allocator Allocator)) {
+	for _, tenant := range newTenants {
+		if _, ok := t.tenants[tenant]; !ok {
+			t.tenants[tenant] = t.newTenant(tenant)
+		}
+	}
+	changed := make(map[string]<-chan struct{}, len(t.tenants))
+	for tenant, allocator := range t.tenants {
+		changed[tenant] = allocator.Changed()
+	}
+
+	for tenant, allocator := range t.tenants {
+		fn(tenant, allocator)
+		if len(allocator.TargetItems()) == 0 && len(allocator.Collectors()) == 0 {
+			delete(t.tenants, tenant)
+			TargetsPerTenant.DeleteLabelValues(tenant, t.tenantStrategy(tenant))
+			CollectorsPerTenant.DeleteLabelValues(tenant, t.tenantStrategy(tenant))
+		}
+	}
+
+	for _, ch := range changed {
+		select {
+		case <-ch:
+			close(t.changed)
+			t.changed = make(chan struct{})
+			return
+		default:
+		}
+	}
+}
+
+func (t *tenantAllocator) newTenant(tenant string) Allocator {
+	strategy := t.tenantStrategy(tenant)
+	t.log.Info("Adding tenant", "tenant", tenant, "strategy", strategy)
+	allocator := newAllocator(t.log.WithValues("tenant", tenant), strategies[strategy](), WithFallbackStrategy(t.fallbackStrategy))
+	if len(t.restored) > 0 {
+		allocator.RestoreAssignments(t.restored)
+	}
+	if t.following != nil {
+		allocator.FollowAssignments(t.following)
+	}
+	return allocator
+}
+
+// tenantStrategy returns the name of the allocation strategy of the tenant.
+func (t *tenantAllocator) tenantStrategy(tenant string) string {
+	if strategy, ok := t.tenantStrategies[tenant]; ok {
+		return strategy
+	}
+	return t.strategy
+}
+
+// Tenant returns the allocator of a tenant, if it has targets or collectors.
+func (t *tenantAllocator) Tenant(name string) (Allocator, bool) {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	allocator, ok := t.tenants[name]
+	return allocator, ok
+}
+
+// GetTargetsForCollectorAndJob returns the targets of the job assigned to the collector, which all belong to the
+// tenant of the collector.
+func (t *tenantAllocator) GetTargetsForCollectorAndJob(collector string, job string) []*target.Item {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	tenant, ok := t.collectorTenants[collector]
+	if !ok {
+		return []*target.Item{}
+	}
+	allocator, ok := t.tenants[tenant]
+	if !ok {
+		return []*target.Item{}
+	}
+	return allocator.GetTargetsForCollectorAndJob(collector, job)
+}
+
+// Changed returns a channel which is closed the next time the targets or their assignments change in any tenant.
+func (t *tenantAllocator) Changed() <-chan struct{} {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	return t.changed
+}
+
+// Assignments returns the names of the collectors the targets of all the tenants are assigned to, by target hash.
+func (t *tenantAllocator) Assignments() map[target.ItemHash]string {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	assignments := make(map[target.ItemHash]string)
+	for _, allocator := range t.tenants {
+		maps.Copy(assignments, allocator.Assignments())
+	}
+	return assignments
+}
+
+// RestoreAssignments restores the assignments in every tenant, including the ones created later.
+func (t *tenantAllocator) RestoreAssignments(assignments map[target.ItemHash]string) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.restored = maps.Clone(assignments)
+	t.update(nil, func(_ string, allocator Allocator) {
+		allocator.RestoreAssignments(assignments)
+	})
+}
+
+// FollowAssignments makes every tenant serve the assignments of the leading allocator, including the ones created
+// later.
+func (t *tenantAllocator) FollowAssignments(assignments map[target.ItemHash]string) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.following = make(map[target.ItemHash]string, len(assignments))
+	maps.Copy(t.following, assignments)
+	t.update(nil, func(_ string, allocator Allocator) {
+		allocator.FollowAssignments(assignments)
+	})
+}
+
+// StopFollowing makes every tenant assign its targets itself again.
+func (t *tenantAllocator) StopFollowing() {
+	t.m.Lock()
+	defer t.m.Unlock()
+	t.following = nil
+	t.update(nil, func(_ string, allocator Allocator) {
+		allocator.StopFollowing()
+	})
+}
+
+// TargetItems returns the targets of all the tenants.
+func (t *tenantAllocator) TargetItems() map[target.ItemHash]*target.Item {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	targetItems := make(map[target.ItemHash]*target.Item)
+	for _, allocator := range t.tenants {
+		maps.Copy(targetItems, allocator.TargetItems())
+	}
+	return targetItems
+}
+
+// Collectors returns the collectors of all the tenants.
+func (t *tenantAllocator) Collectors() map[string]*Collector {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	collectors := make(map[string]*Collector)
+	for _, allocator := range t.tenants {
+		maps.Copy(collectors, allocator.Collectors())
+	}
+	return collectors
+}
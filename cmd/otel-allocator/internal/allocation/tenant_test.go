@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package allocation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+const tenantLabel = "__meta_kubernetes_namespace"
+
+func makeTenantTargets(tenant string, n int) []*target.Item {
+	targets := make([]*target.Item, n)
+	for i := range targets {
+		targets[i] = target.NewItem(fmt.Sprintf("job-%d", i%2), fmt.Sprintf("%s-%d:8080", tenant, i), labels.FromStrings("__address__", fmt.Sprintf("%s-%d:8080", tenant, i), tenantLabel, tenant), "")
+	}
+	return targets
+}
+
+func TestTenantAllocator(t *testing.T) {
+	shared, err := NewWithTenants(consistentHashingStrategyName, logger, tenantLabel, map[string]string{
+		"team-b": leastWeightedStrategyName,
+	})
+	require.NoError(t, err)
+
+	changed := shared.Changed()
+	shared.SetCollectors(map[string]*Collector{
+		"collector-a-0": {Name: "collector-a-0", Tenant: "team-a"},
+		"collector-a-1": {Name: "collector-a-1", Tenant: "team-a"},
+		"collector-b-0": {Name: "collector-b-0", Tenant: "team-b"},
+	})
+	shared.SetTargets(append(makeTenantTargets("team-a", 10), makeTenantTargets("team-b", 5)...))
+	assert.True(t, isClosed(changed))
+
+	assert.Len(t, shared.TargetItems(), 15)
+	assert.Len(t, shared.Collectors(), 3)
+	for _, item := range shared.TargetItems() {
+		tenant := item.Labels.Get(tenantLabel)
+		assert.Equal(t, tenant, shared.Collectors()[item.CollectorName].Tenant, item.TargetURL)
+	}
+	assert.Len(t, shared.Assignments(), 15)
+
+	// the collectors only get the targets of their tenant
+	for _, job := range []string{"job-0", "job-1"} {
+		for _, item := range shared.GetTargetsForCollectorAndJob("collector-b-0", job) {
+			assert.Equal(t, "team-b", item.Labels.Get(tenantLabel))
+		}
+	}
+	assert.Len(t, append(shared.GetTargetsForCollectorAndJob("collector-b-0", "job-0"), shared.GetTargetsForCollectorAndJob("collector-b-0", "job-1")...), 5)
+	assert.Empty(t, shared.GetTargetsForCollectorAndJob("unknown", "job-0"))
+
+	// each tenant has its own strategy and metrics
+	tenants := shared.(TenantAllocator)
+	teamA, ok := tenants.Tenant("team-a")
+	require.True(t, ok)
+	assert.Equal(t, consistentHashingStrategyName, teamA.(*allocator).strategy.GetName())
+	teamB, ok := tenants.Tenant("team-b")
+	require.True(t, ok)
+	assert.Equal(t, leastWeightedStrategyName, teamB.(*allocator).strategy.GetName())
+	assert.Len(t, teamB.TargetItems(), 5)
+	assert.Equal(t, 10.0, testutil.ToFloat64(TargetsPerTenant.WithLabelValues("team-a", consistentHashingStrategyName)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(CollectorsPerTenant.WithLabelValues("team-b", leastWeightedStrategyName)))
+
+	// targets without collectors in their tenant stay unassigned
+	shared.SetTargets(append(makeTenantTargets("team-a", 10), makeTenantTargets("team-c", 3)...))
+	teamC, ok := tenants.Tenant("team-c")
+	require.True(t, ok)
+	for _, item := range teamC.TargetItems() {
+		assert.Empty(t, item.CollectorName)
+	}
+
+	// tenants without targets and collectors are removed
+	shared.SetCollectors(map[string]*Collector{
+		"collector-a-0": {Name: "collector-a-0", Tenant: "team-a"},
+	})
+	_, ok = tenants.Tenant("team-b")
+	assert.False(t, ok)
+	for _, item := range shared.GetTargetsForCollectorAndJob("collector-a-0", "job-0") {
+		assert.Equal(t, "team-a", item.Labels.Get(tenantLabel))
+	}
+}
+
+func TestTenantAllocator_RestoreAssignments(t *testing.T) {
+	allocator, err := NewWithTenants(consistentHashingStrategyName, logger, tenantLabel, nil)
+	require.NoError(t, err)
+	collectors := map[string]*Collector{
+		"collector-0": {Name: "collector-0", Tenant: "team-a"},
+		"collector-1": {Name: "collector-1", Tenant: "team-a"},
+	}
+	targets := makeTenantTargets("team-a", 10)
+
+	assignments := map[target.ItemHash]string{}
+	for _, item := range targets {
+		assignments[item.Hash()] = "collector-1"
+	}
+	allocator.RestoreAssignments(assignments)
+	allocator.SetCollectors(collectors)
+	allocator.SetTargets(targets)
+	assert.Equal(t, assignments, allocator.Assignments())
+}
+
+func TestTenantAllocator_FollowAssignments(t *testing.T) {
+	allocator, err := NewWithTenants(consistentHashingStrategyName, logger, tenantLabel, nil)
+	require.NoError(t, err)
+	collectors := map[string]*Collector{
+		"collector-0": {Name: "collector-0", Tenant: "team-a"},
+		"collector-1": {Name: "collector-1", Tenant: "team-a"},
+	}
+	targets := makeTenantTargets("team-a", 10)
+
+	assignments := map[target.ItemHash]string{}
+	for _, item := range targets[:5] {
+		assignments[item.Hash()] = "collector-1"
+	}
+	// the tenants created later follow the leader too
+	allocator.FollowAssignments(assignments)
+	allocator.SetCollectors(collectors)
+	allocator.SetTargets(targets)
+	assert.Equal(t, assignments, allocator.Assignments())
+
+	allocator.StopFollowing()
+	assert.Len(t, allocator.Assignments(), 10)
+}
+
+func TestNewWithTenants_UnknownStrategy(t *testing.T) {
+	_, err := NewWithTenants("unknown", logger, tenantLabel, nil)
+	assert.Error(t, err)
+	_, err = NewWithTenants(consistentHashingStrategyName, logger, tenantLabel, map[string]string{"team-a": "unknown"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package allocation
+
+import (
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+const zoneAwareStrategyName = "zone-aware"
+
+var (
+	_ Strategy            = &zoneAwareStrategy{}
+	_ constrainedStrategy = &zoneAwareStrategy{}
+)
+
+// zoneAwareStrategy assigns targets to collectors in the same topology zone, using consistent hashing within
+// the zone. Targets without a known zone, or in a zone without collectors, are assigned across all collectors.
+type zoneAwareStrategy struct {
+	// strategyByZone holds a consistent hashing strategy for the collectors of each zone
+	strategyByZone map[string]Strategy
+	// zoneByNode holds the zones of the nodes running collectors
+	zoneByNode       map[string]string
+	defaultStrategy  Strategy
+	fallbackStrategy Strategy
+}
+
+func newZoneAwareStrategy() Strategy {
+	return &zoneAwareStrategy{
+		strategyByZone:  make(map[string]Strategy),
+		zoneByNode:      make(map[string]string),
+		defaultStrategy: newConsistentHashingStrategy(),
+	}
+}
+
+func (s *zoneAwareStrategy) GetName() string {
+	return zoneAwareStrategyName
+}
+
+func (s *zoneAwareStrategy) SetFallbackStrategy(fallbackStrategy Strategy) {
+	s.fallbackStrategy = fallbackStrategy
+}
+
+func (s *zoneAwareStrategy) GetCollectorForTarget(collectors map[string]*Collector, item *target.Item) (*Collector, error) {
+	zone := s.targetZone(item)
+	if strategy, ok := s.strategyByZone[zone]; ok && zone != "" {
+		return strategy.GetCollectorForTarget(collectors, item)
+	}
+
+	if s.fallbackStrategy != nil {
+		return s.fallbackStrategy.GetCollectorForTarget(collectors, item)
+	}
+	return s.defaultStrategy.GetCollectorForTarget(collectors, item)
+}
+
+// CanAssign only allows the collectors of the zone of the target, when the zone has collectors.
+func (s *zoneAwareStrategy) CanAssign(collector *Collector, item *target.Item) bool {
+	zone := s.targetZone(item)
+	if _, ok := s.strategyByZone[zone]; ok && zone != "" {
+		return collector.Zone == zone
+	}
+	return true
+}
+
+// targetZone returns the zone of the target, or of its node when a collector runs on the same node.
+func (s *zoneAwareStrategy) targetZone(item *target.Item) string {
+	if zone := item.GetZone(); zone != "" {
+		return zone
+	}
+	return s.zoneByNode[item.GetNodeName()]
+}
+
+func (s *zoneAwareStrategy) SetCollectors(collectors map[string]*Collector) {
+	clear(s.zoneByNode)
+	collectorsByZone := make(map[string]map[string]*Collector)
+	for _, collector := range collectors {
+		if collector.Zone == "" {
+			continue
+		}
+		if collector.NodeName != "" {
+			s.zoneByNode[collector.NodeName] = collector.Zone
+		}
+		if collectorsByZone[collector.Zone] == nil {
+			collectorsByZone[collector.Zone] = make(map[string]*Collector)
+		}
+		collectorsByZone[collector.Zone][collector.Name] = collector
+	}
+
+	clear(s.strategyByZone)
+	for zone, zoneCollectors := range collectorsByZone {
+		strategy := newConsistentHashingStrategy()
+		strategy.SetCollectors(zoneCollectors)
+		s.strategyByZone[zone] = strategy
+	}
+
+	s.defaultStrategy.SetCollectors(collectors)
+	if s.fallbackStrategy != nil {
+		s.fallbackStrategy.SetCollectors(collectors)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package allocation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+func makeZonedCollectors(zones ...string) map[string]*Collector {
+	collectors := map[string]*Collector{}
+	for i, zone := range zones {
+		name := fmt.Sprintf("collector-%d", i)
+		collectors[name] = NewCollector(name, fmt.Sprintf("node-%d", i), zone)
+	}
+	return collectors
+}
+
+func TestAllocationZoneAware(t *testing.T) {
+	s, err := New(zoneAwareStrategyName, logger)
+	require.NoError(t, err)
+
+	cols := makeZonedCollectors("zone-a", "zone-a", "zone-b", "")
+	s.SetCollectors(cols)
+
+	var targets []*target.Item
+	for i := 0; i < 50; i++ {
+		// zone from the endpointslice labels
+		targets = append(targets, target.NewItem("job", fmt.Sprintf("10.0.0.%d:8080", i), labels.Labels{
+			{Name: "__meta_kubernetes_endpointslice_endpoint_zone", Value: "zone-a"},
+			{Name: "i", Value: fmt.Sprint(i)},
+		}, ""))
+		// zone from the node of a collector
+		targets = append(targets, target.NewItem("job", fmt.Sprintf("10.0.1.%d:8080", i), labels.Labels{
+			{Name: "__meta_kubernetes_pod_node_name", Value: "node-2"},
+			{Name: "i", Value: fmt.Sprint(i)},
+		}, ""))
+	}
+	s.SetTargets(targets)
+
+	for _, item := range s.TargetItems() {
+		collector := s.Collectors()[item.CollectorName]
+		require.NotNil(t, collector)
+		if item.GetZone() == "zone-a" {
+			assert.Equal(t, "zone-a", collector.Zone)
+		} else {
+			assert.Equal(t, "collector-2", collector.Name)
+		}
+	}
+	// the targets are spread across the collectors of the zone
+	assert.Positive(t, s.Collectors()["collector-0"].NumTargets)
+	assert.Positive(t, s.Collectors()["collector-1"].NumTargets)
+}
+
+func TestAllocationZoneAwareWithoutZone(t *testing.T) {
+	s, err := New(zoneAwareStrategyName, logger)
+	require.NoError(t, err)
+
+	cols := makeZonedCollectors("zone-a", "zone-b")
+	s.SetCollectors(cols)
+
+	noZone := target.NewItem("job", "10.0.0.1:8080", labels.Labels{{Name: "test", Value: "no-zone"}}, "")
+	otherZone := target.NewItem("job", "10.0.0.2:8080", labels.Labels{
+		{Name: "__meta_kubernetes_node_label_topology_kubernetes_io_zone", Value: "zone-c"},
+	}, "")
+	s.SetTargets([]*target.Item{noZone, otherZone})
+
+	// targets without a collector in their zone are still assigned
+	for _, item := range s.TargetItems() {
+		assert.Contains(t, cols, item.CollectorName)
+	}
+}
+
+func TestAllocationZoneAwareZoneRemoved(t *testing.T) {
+	s, err := New(zoneAwareStrategyName, logger)
+	require.NoError(t, err)
+
+	s.SetCollectors(makeZonedCollectors("zone-a", "zone-b"))
+	item := target.NewItem("job", "10.0.0.1:8080", labels.Labels{
+		{Name: "__meta_kubernetes_endpointslice_endpoint_zone", Value: "zone-b"},
+	}, "")
+	s.SetTargets([]*target.Item{item})
+	assert.Equal(t, "collector-1", s.TargetItems()[item.Hash()].CollectorName)
+
+	// the collector of zone-b is gone, the target moves to the remaining one
+	cols := makeZonedCollectors("zone-a")
+	s.SetCollectors(cols)
+	assert.Equal(t, "collector-0", s.TargetItems()[item.Hash()].CollectorName)
+}
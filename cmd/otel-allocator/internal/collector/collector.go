@@ -4,11 +4,14 @@
 package collector
 
 import (
+	"context"
+	"strconv"
 	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/informers"
@@ -21,6 +24,13 @@ import (
 
 const (
 	defaultMinUpdateInterval = time.Second * 5
+	// weightAnnotation sets the capacity of a collector relative to the others, as a positive integer.
+	weightAnnotation = "opentelemetry.io/ta-weight"
+	maxWeight        = 100
+	// tenantAnnotation sets the tenant a collector scrapes the targets of, when the allocator is shared between tenants.
+	tenantAnnotation = "opentelemetry.io/ta-tenant"
+	// nodeLookupTimeout bounds the lookup of the zone of a collector node, which delays the collector updates.
+	nodeLookupTimeout = 5 * time.Second
 )
 
 var (
@@ -30,15 +40,31 @@ var (
 	})
 )
 
+// Owner is the workload owning the collector pods, a StatefulSet, a Deployment or a DaemonSet. When set, only the pods
+// it owns are collectors, in addition to matching the label selector.
+type Owner struct {
+	Kind string
+	Name string
+}
+
 type Watcher struct {
 	log                          logr.Logger
 	k8sClient                    kubernetes.Interface
 	close                        chan struct{}
 	minUpdateInterval            time.Duration
 	collectorNotReadyGracePeriod time.Duration
+	// collectorDrainTimeout is how long a collector being deleted keeps its targets, while not getting new ones.
+	collectorDrainTimeout time.Duration
+	// zoneByNode caches the topology zone of the nodes running collectors
+	zoneByNode map[string]string
+	// assignable holds the collectors which have been Ready, or were already running when the watcher started.
+	// Collectors which never became Ready don't get targets.
+	assignable  map[string]struct{}
+	initialized bool
+	owner       Owner
 }
 
-func NewCollectorWatcher(logger logr.Logger, kubeConfig *rest.Config, collectorNotReadyGracePeriod time.Duration) (*Watcher, error) {
+func NewCollectorWatcher(logger logr.Logger, kubeConfig *rest.Config, collectorNotReadyGracePeriod, collectorDrainTimeout time.Duration, owner Owner) (*Watcher, error) {
 	clientset, err := kubernetes.NewForConfig(kubeConfig)
 	if err != nil {
 		return &Watcher{}, err
@@ -50,6 +76,10 @@ func NewCollectorWatcher(logger logr.Logger, kubeConfig *rest.Config, collectorN
 		close:                        make(chan struct{}),
 		minUpdateInterval:            defaultMinUpdateInterval,
 		collectorNotReadyGracePeriod: collectorNotReadyGracePeriod,
+		collectorDrainTimeout:        collectorDrainTimeout,
+		zoneByNode:                   make(map[string]string),
+		assignable:                   make(map[string]struct{}),
+		owner:                        owner,
 	}, nil
 }
 
@@ -98,11 +128,19 @@ func (k *Watcher) Watch(
 }
 
 // rateLimitedCollectorHandler runs fn on collectors present in the store whenever it gets a notification on the notify channel,
-// but not more frequently than once per k.eventPeriod.
+// but not more frequently than once per k.eventPeriod. When a collector is within its not ready grace period or is
+// draining, the collectors are evaluated again once that expires, so its targets get reassigned.
 func (k *Watcher) rateLimitedCollectorHandler(notify chan struct{}, store cache.Store, fn func(collectors map[string]*allocation.Collector)) {
 	ticker := time.NewTicker(k.minUpdateInterval)
 	defer ticker.Stop()
 
+	var reevaluate *time.Timer
+	defer func() {
+		if reevaluate != nil {
+			reevaluate.Stop()
+		}
+	}()
+
 	for {
 		select {
 		case <-k.close:
@@ -110,32 +148,149 @@ func (k *Watcher) rateLimitedCollectorHandler(notify chan struct{}, store cache.
 		case <-ticker.C: // throttle events to avoid excessive updates
 			select {
 			case <-notify:
-				k.runOnCollectors(store, fn)
+				if next := k.runOnCollectors(store, fn); next > 0 {
+					if reevaluate != nil {
+						reevaluate.Stop()
+					}
+					reevaluate = time.AfterFunc(next, func() {
+						select {
+						case notify <- struct{}{}:
+						default:
+						}
+					})
+				}
 			default:
 			}
 		}
 	}
 }
 
-// runOnCollectors runs the provided function on the set of collectors from the Store.
-func (k *Watcher) runOnCollectors(store cache.Store, fn func(collectors map[string]*allocation.Collector)) {
+// runOnCollectors runs the provided function on the set of collectors from the Store. It returns the time after
+// which the grace period of a not ready collector or the drain timeout of a draining collector expires, or zero if
+// there is no such collector.
+func (k *Watcher) runOnCollectors(store cache.Store, fn func(collectors map[string]*allocation.Collector)) time.Duration {
+	if k.assignable == nil {
+		k.assignable = make(map[string]struct{})
+	}
+
 	objects := store.List()
 	collectorMap := make(map[string]*allocation.Collector, len(objects))
+	names := make(map[string]struct{}, len(objects))
+	var next time.Duration
 	for _, obj := range objects {
 		pod := obj.(*v1.Pod)
+		if !k.isOwned(pod) {
+			continue
+		}
+		names[pod.Name] = struct{}{}
 		if pod.Spec.NodeName == "" {
 			continue
 		}
 
 		// pod healthiness check will always be disabled if CollectorNotReadyGracePeriod is set to 0 * time.Second
-		if k.isPodUnhealthy(pod, k.collectorNotReadyGracePeriod) {
-			continue
+		if k.collectorNotReadyGracePeriod != 0 {
+			if isPodReady(pod) || !k.initialized {
+				k.assignable[pod.Name] = struct{}{}
+			}
+			// don't assign targets to collectors which are still starting
+			if _, ok := k.assignable[pod.Name]; !ok {
+				continue
+			}
+			if k.isPodUnhealthy(pod, k.collectorNotReadyGracePeriod) {
+				continue
+			}
+			if remaining := gracePeriodRemaining(pod, k.collectorNotReadyGracePeriod); remaining > 0 && (next == 0 || remaining < next) {
+				next = remaining
+			}
 		}
 
-		collectorMap[pod.Name] = allocation.NewCollector(pod.Name, pod.Spec.NodeName)
+		collector := allocation.NewCollector(pod.Name, pod.Spec.NodeName, k.nodeZone(pod.Spec.NodeName))
+		// a collector being deleted drains: its targets are reassigned once the drain timeout expires, before the
+		// pod terminates, instead of waiting for the deletion
+		if pod.DeletionTimestamp != nil {
+			remaining := k.collectorDrainTimeout - time.Since(pod.DeletionTimestamp.Time)
+			if remaining <= 0 {
+				continue
+			}
+			collector.Draining = true
+			if next == 0 || remaining < next {
+				next = remaining
+			}
+		}
+		if weight, ok := k.podWeight(pod); ok {
+			collector.Weight = weight
+		}
+		collector.Tenant = pod.Annotations[tenantAnnotation]
+		collectorMap[pod.Name] = collector
 	}
+	for name := range k.assignable {
+		if _, ok := names[name]; !ok {
+			delete(k.assignable, name)
+		}
+	}
+	k.initialized = true
+
 	collectorsDiscovered.Set(float64(len(collectorMap)))
 	fn(collectorMap)
+	return next
+}
+
+// isOwned returns whether the pod belongs to the owner of the collectors, if any. The pods of a Deployment are owned
+// by its ReplicaSets, named after the Deployment and the pod template hash.
+func (k *Watcher) isOwned(pod *v1.Pod) bool {
+	if k.owner.Kind == "" {
+		return true
+	}
+	for _, ref := range pod.OwnerReferences {
+		switch k.owner.Kind {
+		case "Deployment":
+			hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+			if ref.Kind == "ReplicaSet" && hash != "" && ref.Name == k.owner.Name+"-"+hash {
+				return true
+			}
+		default:
+			if ref.Kind == k.owner.Kind && ref.Name == k.owner.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// podWeight returns the weight set by the annotation of the collector pod, if it's valid.
+func (k *Watcher) podWeight(pod *v1.Pod) (int, bool) {
+	value, ok := pod.Annotations[weightAnnotation]
+	if !ok {
+		return 0, false
+	}
+	weight, err := strconv.Atoi(value)
+	if err != nil || weight < 1 || weight > maxWeight {
+		k.log.Info("Ignoring invalid collector weight", "pod", pod.Name, "annotation", weightAnnotation, "value", value)
+		return 0, false
+	}
+	return weight, true
+}
+
+// nodeZone returns the topology zone of the given node. Lookups are cached, as the zone of a node doesn't change,
+// while failures result in an empty zone until a later lookup succeeds.
+func (k *Watcher) nodeZone(nodeName string) string {
+	if zone, ok := k.zoneByNode[nodeName]; ok {
+		return zone
+	}
+	if k.zoneByNode == nil {
+		k.zoneByNode = make(map[string]string)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), nodeLookupTimeout)
+	defer cancel()
+	node, err := k.k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		k.log.V(1).Info("Unable to get the zone of the collector node", "node", nodeName, "error", err)
+		return ""
+	}
+	zone := node.Labels[v1.LabelTopologyZone]
+	k.zoneByNode[nodeName] = zone
+	return zone
 }
 
 func (k *Watcher) Close() {
@@ -163,3 +318,30 @@ func (k *Watcher) isPodUnhealthy(pod *v1.Pod, collectorNotReadyGracePeriod time.
 	}
 	return isPodUnhealthy
 }
+
+// isPodReady returns whether the Ready condition of the pod is true.
+func isPodReady(pod *v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// gracePeriodRemaining returns the time left before a not running or not ready pod exceeds the grace period,
+// or zero if the pod is running and ready.
+func gracePeriodRemaining(pod *v1.Pod, collectorNotReadyGracePeriod time.Duration) time.Duration {
+	var remaining time.Duration
+	if pod.Status.Phase != v1.PodRunning && pod.Status.StartTime != nil {
+		remaining = collectorNotReadyGracePeriod - time.Since(pod.Status.StartTime.Time)
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady && condition.Status != v1.ConditionTrue {
+			if r := collectorNotReadyGracePeriod - time.Since(condition.LastTransitionTime.Time); remaining <= 0 || r < remaining {
+				remaining = r
+			}
+		}
+	}
+	return max(remaining, 0)
+}
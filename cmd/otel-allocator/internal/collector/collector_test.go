@@ -12,9 +12,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/allocation"
@@ -458,3 +460,219 @@ func Test_closeChannel(t *testing.T) {
 	podWatcher.Close()
 	wg.Wait()
 }
+
+func Test_nodeZone(t *testing.T) {
+	podWatcher := getTestPodWatcher(0 * time.Second)
+	_, err := podWatcher.k8sClient.CoreV1().Nodes().Create(context.Background(), &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-node",
+			Labels: map[string]string{v1.LabelTopologyZone: "zone-a"},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "zone-a", podWatcher.nodeZone("test-node"))
+	assert.Equal(t, "", podWatcher.nodeZone("missing-node"))
+
+	// lookups are cached
+	err = podWatcher.k8sClient.CoreV1().Nodes().Delete(context.Background(), "test-node", metav1.DeleteOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "zone-a", podWatcher.nodeZone("test-node"))
+
+	// failures aren't cached
+	_, err = podWatcher.k8sClient.CoreV1().Nodes().Create(context.Background(), &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "missing-node",
+			Labels: map[string]string{v1.LabelTopologyZone: "zone-b"},
+		},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "zone-b", podWatcher.nodeZone("missing-node"))
+}
+
+func Test_readinessAwareAssignment(t *testing.T) {
+	podWatcher := getTestPodWatcher(30 * time.Second)
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	var actual map[string]*allocation.Collector
+	fn := func(colMap map[string]*allocation.Collector) {
+		actual = colMap
+	}
+
+	// collectors running when the watcher starts are assignable, even if they're not ready
+	require.NoError(t, store.Add(podWithPodReadyConditionStatusAndLastTransitionTime("test-pod-existing", v1.ConditionFalse, time.Now())))
+	next := podWatcher.runOnCollectors(store, fn)
+	assert.Contains(t, actual, "test-pod-existing")
+	assert.InDelta(t, 30*time.Second, next, float64(time.Second))
+
+	// a new collector only gets targets once it's ready
+	starting := podWithPodReadyConditionStatusAndLastTransitionTime("test-pod-new", v1.ConditionFalse, time.Now())
+	require.NoError(t, store.Add(starting))
+	podWatcher.runOnCollectors(store, fn)
+	assert.NotContains(t, actual, "test-pod-new")
+
+	ready := podWithPodReadyConditionStatusAndLastTransitionTime("test-pod-new", v1.ConditionTrue, time.Now())
+	require.NoError(t, store.Update(ready))
+	podWatcher.runOnCollectors(store, fn)
+	assert.Contains(t, actual, "test-pod-new")
+
+	// a ready collector becoming not ready keeps its targets during the grace period
+	notReady := podWithPodReadyConditionStatusAndLastTransitionTime("test-pod-new", v1.ConditionFalse, time.Now().Add(-10*time.Second))
+	require.NoError(t, store.Update(notReady))
+	next = podWatcher.runOnCollectors(store, fn)
+	assert.Contains(t, actual, "test-pod-new")
+	assert.InDelta(t, 20*time.Second, next, float64(time.Second))
+
+	notReady = podWithPodReadyConditionStatusAndLastTransitionTime("test-pod-new", v1.ConditionFalse, time.Now().Add(-time.Minute))
+	require.NoError(t, store.Update(notReady))
+	podWatcher.runOnCollectors(store, fn)
+	assert.NotContains(t, actual, "test-pod-new")
+}
+
+func Test_reevaluateAfterGracePeriod(t *testing.T) {
+	podWatcher := getTestPodWatcher(time.Second)
+	defer close(podWatcher.close)
+	var actual map[string]*allocation.Collector
+	mapMutex := sync.Mutex{}
+
+	p := podWithPodReadyConditionStatusAndLastTransitionTime("test-pod", v1.ConditionTrue, time.Now())
+	_, err := podWatcher.k8sClient.CoreV1().Pods("test-ns").Create(context.Background(), p, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	go func(podWatcher Watcher) {
+		err := podWatcher.Watch("test-ns", &labelSelector, func(colMap map[string]*allocation.Collector) {
+			mapMutex.Lock()
+			defer mapMutex.Unlock()
+			actual = colMap
+		})
+		require.NoError(t, err)
+	}(podWatcher)
+
+	assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+		mapMutex.Lock()
+		defer mapMutex.Unlock()
+		assert.Contains(collect, actual, "test-pod")
+	}, time.Second*3, time.Millisecond*10)
+
+	// the collector is removed once the grace period expires, without further pod events
+	p = podWithPodReadyConditionStatusAndLastTransitionTime("test-pod", v1.ConditionFalse, time.Now())
+	_, err = podWatcher.k8sClient.CoreV1().Pods("test-ns").Update(context.Background(), p, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+		mapMutex.Lock()
+		defer mapMutex.Unlock()
+		assert.NotContains(collect, actual, "test-pod")
+	}, time.Second*5, time.Millisecond*10)
+}
+
+func Test_drainingCollectors(t *testing.T) {
+	podWatcher := getTestPodWatcher(0 * time.Second)
+	podWatcher.collectorDrainTimeout = 10 * time.Second
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	var actual map[string]*allocation.Collector
+	fn := func(colMap map[string]*allocation.Collector) {
+		actual = colMap
+	}
+
+	draining := pod("test-pod-draining")
+	draining.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-4 * time.Second)}
+	drained := pod("test-pod-drained")
+	drained.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	for _, p := range []*v1.Pod{pod("test-pod"), draining, drained} {
+		require.NoError(t, store.Add(p))
+	}
+
+	next := podWatcher.runOnCollectors(store, fn)
+	assert.Equal(t, map[string]*allocation.Collector{
+		"test-pod": {
+			Name:     "test-pod",
+			NodeName: "test-node",
+		},
+		"test-pod-draining": {
+			Name:     "test-pod-draining",
+			NodeName: "test-node",
+			Draining: true,
+		},
+	}, actual)
+	// the collectors are evaluated again when the drain timeout expires
+	assert.InDelta(t, 6*time.Second, next, float64(time.Second))
+}
+
+func Test_collectorWeight(t *testing.T) {
+	podWatcher := getTestPodWatcher(0 * time.Second)
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	var actual map[string]*allocation.Collector
+	fn := func(colMap map[string]*allocation.Collector) {
+		actual = colMap
+	}
+
+	heavy := pod("test-pod-heavy")
+	heavy.Annotations = map[string]string{weightAnnotation: "3"}
+	invalid := pod("test-pod-invalid")
+	invalid.Annotations = map[string]string{weightAnnotation: "-1"}
+	for _, p := range []*v1.Pod{pod("test-pod"), heavy, invalid} {
+		require.NoError(t, store.Add(p))
+	}
+
+	podWatcher.runOnCollectors(store, fn)
+	require.Len(t, actual, 3)
+	assert.Equal(t, 0, actual["test-pod"].Weight)
+	assert.Equal(t, 3, actual["test-pod-heavy"].Weight)
+	assert.Equal(t, 0, actual["test-pod-invalid"].Weight)
+}
+
+func Test_collectorTenant(t *testing.T) {
+	podWatcher := getTestPodWatcher(0 * time.Second)
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	var actual map[string]*allocation.Collector
+	fn := func(colMap map[string]*allocation.Collector) {
+		actual = colMap
+	}
+
+	tenantPod := pod("test-pod-team-a")
+	tenantPod.Annotations = map[string]string{tenantAnnotation: "team-a"}
+	for _, p := range []*v1.Pod{pod("test-pod"), tenantPod} {
+		require.NoError(t, store.Add(p))
+	}
+
+	podWatcher.runOnCollectors(store, fn)
+	require.Len(t, actual, 2)
+	assert.Empty(t, actual["test-pod"].Tenant)
+	assert.Equal(t, "team-a", actual["test-pod-team-a"].Tenant)
+}
+
+func Test_collectorOwner(t *testing.T) {
+	statefulSetPod := pod("collector-0")
+	statefulSetPod.OwnerReferences = []metav1.OwnerReference{{Kind: "StatefulSet", Name: "collector"}}
+	deploymentPod := pod("collector-5d4b8c7f9-abcde")
+	deploymentPod.Labels = map[string]string{"app.kubernetes.io/instance": "default.test", appsv1.DefaultDeploymentUniqueLabelKey: "5d4b8c7f9"}
+	deploymentPod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "collector-5d4b8c7f9"}}
+	otherPod := pod("other-0")
+	otherPod.OwnerReferences = []metav1.OwnerReference{{Kind: "StatefulSet", Name: "other"}}
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for _, p := range []*v1.Pod{statefulSetPod, deploymentPod, otherPod} {
+		require.NoError(t, store.Add(p))
+	}
+
+	for _, tt := range []struct {
+		owner    Owner
+		expected []string
+	}{
+		{owner: Owner{}, expected: []string{"collector-0", "collector-5d4b8c7f9-abcde", "other-0"}},
+		{owner: Owner{Kind: "StatefulSet", Name: "collector"}, expected: []string{"collector-0"}},
+		{owner: Owner{Kind: "Deployment", Name: "collector"}, expected: []string{"collector-5d4b8c7f9-abcde"}},
+		{owner: Owner{Kind: "DaemonSet", Name: "collector"}, expected: []string{}},
+	} {
+		t.Run(tt.owner.Kind, func(t *testing.T) {
+			podWatcher := getTestPodWatcher(0 * time.Second)
+			podWatcher.owner = tt.owner
+			actual := []string{}
+			podWatcher.runOnCollectors(store, func(colMap map[string]*allocation.Collector) {
+				for name := range colMap {
+					actual = append(actual, name)
+				}
+			})
+			assert.ElementsMatch(t, tt.expected, actual)
+		})
+	}
+}
@@ -14,6 +14,9 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -43,6 +46,13 @@ const (
 	DefaultAllocationStrategy                          = "consistent-hashing"
 	DefaultFilterStrategy                              = "relabel-config"
 	DefaultCollectorNotReadyGracePeriod                = 30 * time.Second
+	DefaultStateCheckpointInterval                     = time.Minute
+	DefaultTenantLabel                                 = "__meta_kubernetes_namespace"
+)
+
+const (
+	StateBackendFile      = "file"
+	StateBackendConfigMap = "configmap"
 )
 
 var (
@@ -61,18 +71,27 @@ var NopLogger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Lev
 
 type Config struct {
 	ListenAddr                   string                `yaml:"listen_addr,omitempty"`
+	PprofListenAddr              string                `yaml:"pprof_listen_addr,omitempty"`
 	KubeConfigFilePath           string                `yaml:"kube_config_file_path,omitempty"`
 	ClusterConfig                *rest.Config          `yaml:"-"`
 	RootLogger                   logr.Logger           `yaml:"-"`
 	CollectorSelector            *metav1.LabelSelector `yaml:"collector_selector,omitempty"`
+	CollectorOwner               CollectorOwnerConfig  `yaml:"collector_owner,omitempty"`
 	CollectorNamespace           string                `yaml:"collector_namespace,omitempty"`
 	PromConfig                   *promconfig.Config    `yaml:"config"`
 	AllocationStrategy           string                `yaml:"allocation_strategy,omitempty"`
 	AllocationFallbackStrategy   string                `yaml:"allocation_fallback_strategy,omitempty"`
+	ConsistentHashing            HashRingConfig        `yaml:"consistent_hashing,omitempty"`
 	FilterStrategy               string                `yaml:"filter_strategy,omitempty"`
+	Filter                       TargetFilterConfig    `yaml:"filter,omitempty"`
 	PrometheusCR                 PrometheusCRConfig    `yaml:"prometheus_cr,omitempty"`
 	HTTPS                        HTTPSServerConfig     `yaml:"https,omitempty"`
+	Authentication               AuthenticationConfig  `yaml:"authentication,omitempty"`
 	CollectorNotReadyGracePeriod time.Duration         `yaml:"collector_not_ready_grace_period,omitempty"`
+	CollectorDrainTimeout        time.Duration         `yaml:"collector_drain_timeout,omitempty"`
+	State                        StateConfig           `yaml:"state,omitempty"`
+	LeaderElection               LeaderElectionConfig  `yaml:"leader_election,omitempty"`
+	Tenancy                      TenancyConfig         `yaml:"tenancy,omitempty"`
 }
 
 type PrometheusCRConfig struct {
@@ -89,6 +108,10 @@ type PrometheusCRConfig struct {
 	ProbeNamespaceSelector          *metav1.LabelSelector         `yaml:"probe_namespace_selector,omitempty"`
 	ScrapeProtocols                 []monitoringv1.ScrapeProtocol `yaml:"scrape_protocols,omitempty"`
 	ScrapeInterval                  model.Duration                `yaml:"scrape_interval,omitempty"`
+	// ScrapeConfigsEnabled controls whether ScrapeConfig resources are watched, the cluster may not serve them.
+	ScrapeConfigsEnabled bool `yaml:"scrape_configs_enabled"`
+	// ProbesEnabled controls whether Probe resources are watched, the cluster may not serve them.
+	ProbesEnabled bool `yaml:"probes_enabled"`
 }
 
 type HTTPSServerConfig struct {
@@ -99,6 +122,65 @@ type HTTPSServerConfig struct {
 	TLSKeyFilePath  string `yaml:"tls_key_file_path,omitempty"`
 }
 
+// HashRingConfig tunes the hash ring of the consistent-hashing and zone-aware allocation strategies. Zero
+// values keep the defaults.
+type HashRingConfig struct {
+	PartitionCount    int    `yaml:"partition_count,omitempty"`
+	ReplicationFactor int    `yaml:"replication_factor,omitempty"`
+	Hash              string `yaml:"hash,omitempty"`
+}
+
+// CollectorOwnerConfig restricts the collectors to the pods of a workload, a StatefulSet, a Deployment or a DaemonSet.
+type CollectorOwnerConfig struct {
+	Kind string `yaml:"kind,omitempty"`
+	Name string `yaml:"name,omitempty"`
+}
+
+// AuthenticationConfig restricts the allocator API to the given service accounts, authenticated with their bearer
+// tokens through the Kubernetes TokenReview API.
+type AuthenticationConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// ServiceAccounts are the allowed service accounts, in the namespace/name format.
+	ServiceAccounts []string `yaml:"service_accounts,omitempty"`
+}
+
+// TargetFilterConfig keeps or drops the discovered targets based on their labels, before they are allocated.
+type TargetFilterConfig struct {
+	// Keep lists label selectors such as {__meta_kubernetes_namespace=~"team-.*"}, when set, the targets must match
+	// at least one of them.
+	Keep []string `yaml:"keep,omitempty"`
+	// Drop lists label selectors, the targets matching any of them are dropped.
+	Drop []string `yaml:"drop,omitempty"`
+}
+
+// StateConfig configures where the target assignments are checkpointed, to restore them after a restart.
+type StateConfig struct {
+	// Backend is either file or configmap, the assignments aren't saved when it's empty.
+	Backend string `yaml:"backend,omitempty"`
+	// Path is the file the assignments are saved to with the file backend, it should be on a persistent volume.
+	Path string `yaml:"path,omitempty"`
+	// ConfigMapName is the ConfigMap the assignments are saved to with the configmap backend, in the collector namespace.
+	ConfigMapName      string        `yaml:"config_map_name,omitempty"`
+	CheckpointInterval time.Duration `yaml:"checkpoint_interval,omitempty"`
+}
+
+// LeaderElectionConfig allows running several replicas: the leader, elected with a Lease in the collector namespace,
+// saves the target assignments to the state backend, and the other replicas follow them.
+type LeaderElectionConfig struct {
+	Enabled   bool   `yaml:"enabled,omitempty"`
+	LeaseName string `yaml:"lease_name,omitempty"`
+}
+
+// TenancyConfig shares the allocator between tenants: the targets of a tenant, the value of the tenant label, are only
+// assigned to the collectors of the same tenant, set by their opentelemetry.io/ta-tenant annotation.
+type TenancyConfig struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Label is the target label holding the tenant, the namespace of the target by default.
+	Label string `yaml:"label,omitempty"`
+	// AllocationStrategies sets the allocation strategy of some tenants, instead of the default one.
+	AllocationStrategies map[string]string `yaml:"allocation_strategies,omitempty"`
+}
+
 // StringToModelOrTimeDurationHookFunc returns a DecodeHookFuncType
 // that converts string to time.Duration, which can also be used
 // as model.Duration.
@@ -230,6 +312,12 @@ func LoadFromCLI(target *Config, flagSet *pflag.FlagSet) error {
 		target.ListenAddr = listenAddr
 	}
 
+	if pprofListenAddr, changed, flagErr := getPprofListenAddr(flagSet); flagErr != nil {
+		return flagErr
+	} else if changed {
+		target.PprofListenAddr = pprofListenAddr
+	}
+
 	if prometheusCREnabled, changed, flagErr := getPrometheusCREnabled(flagSet); flagErr != nil {
 		return flagErr
 	} else if changed {
@@ -332,8 +420,16 @@ func CreateDefaultConfig() Config {
 			ScrapeConfigNamespaceSelector:   &metav1.LabelSelector{},
 			ProbeNamespaceSelector:          &metav1.LabelSelector{},
 			ScrapeProtocols:                 defaultScrapeProtocolsCR,
+			ScrapeConfigsEnabled:            true,
+			ProbesEnabled:                   true,
 		},
 		CollectorNotReadyGracePeriod: DefaultCollectorNotReadyGracePeriod,
+		State: StateConfig{
+			CheckpointInterval: DefaultStateCheckpointInterval,
+		},
+		Tenancy: TenancyConfig{
+			Label: DefaultTenantLabel,
+		},
 	}
 }
 
@@ -383,10 +479,120 @@ func ValidateConfig(config *Config) error {
 	if len(config.PrometheusCR.AllowNamespaces) != 0 && len(config.PrometheusCR.DenyNamespaces) != 0 {
 		return fmt.Errorf("only one of allowNamespaces or denyNamespaces can be set")
 	}
+	if config.ConsistentHashing.PartitionCount < 0 || config.ConsistentHashing.ReplicationFactor < 0 {
+		return fmt.Errorf("the consistent hashing partition count and replication factor must be positive")
+	}
+	switch config.ConsistentHashing.Hash {
+	case "", "xxhash", "sha256":
+	default:
+		return fmt.Errorf("unknown consistent hashing hash function %q, expected xxhash or sha256", config.ConsistentHashing.Hash)
+	}
+	switch config.CollectorOwner.Kind {
+	case "":
+	case "StatefulSet", "Deployment", "DaemonSet":
+		if config.CollectorOwner.Name == "" {
+			return fmt.Errorf("the collector owner name must be set")
+		}
+	default:
+		return fmt.Errorf("unknown collector owner kind %q, expected StatefulSet, Deployment or DaemonSet", config.CollectorOwner.Kind)
+	}
+	if config.Authentication.Enabled {
+		if len(config.Authentication.ServiceAccounts) == 0 {
+			return fmt.Errorf("at least one service account must be allowed when authentication is enabled")
+		}
+		for _, serviceAccount := range config.Authentication.ServiceAccounts {
+			if namespace, name, ok := strings.Cut(serviceAccount, "/"); !ok || namespace == "" || name == "" {
+				return fmt.Errorf("invalid service account %q, expected the namespace/name format", serviceAccount)
+			}
+		}
+	}
+	switch config.State.Backend {
+	case "":
+	case StateBackendFile:
+		if config.State.Path == "" {
+			return fmt.Errorf("the state path must be set with the %s backend", StateBackendFile)
+		}
+	case StateBackendConfigMap:
+		if config.State.ConfigMapName == "" {
+			return fmt.Errorf("the state config map name must be set with the %s backend", StateBackendConfigMap)
+		}
+	default:
+		return fmt.Errorf("unknown state backend %q, expected %s or %s", config.State.Backend, StateBackendFile, StateBackendConfigMap)
+	}
+	if config.State.Backend != "" && config.State.CheckpointInterval <= 0 {
+		return fmt.Errorf("the state checkpoint interval must be positive")
+	}
+	if config.LeaderElection.Enabled {
+		if config.LeaderElection.LeaseName == "" {
+			return fmt.Errorf("the lease name must be set when leader election is enabled")
+		}
+		if config.State.Backend != StateBackendConfigMap {
+			return fmt.Errorf("leader election requires the %s state backend", StateBackendConfigMap)
+		}
+	}
+	if config.Tenancy.Enabled && config.Tenancy.Label == "" {
+		return fmt.Errorf("the tenant label must be set when tenancy is enabled")
+	}
 	return nil
 }
 
+// NewTLSConfig returns the TLS config of the HTTPS server. The certificates are read again on the handshakes following
+// a change of their files, so that the renewed ones are used without restarting the target allocator.
 func (c HTTPSServerConfig) NewTLSConfig() (*tls.Config, error) {
+	reloader := &tlsConfigReloader{config: c}
+	tlsConfig, err := reloader.get()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		return reloader.get()
+	}
+	return tlsConfig, nil
+}
+
+// tlsConfigReloader caches the TLS config of the HTTPS server, and loads it again when the modification time of one
+// of its files changes.
+type tlsConfigReloader struct {
+	config    HTTPSServerConfig
+	mu        sync.Mutex
+	modTimes  []time.Time
+	tlsConfig *tls.Config
+}
+
+func (r *tlsConfigReloader) get() (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	modTimes, err := r.config.modTimes()
+	if err == nil && r.tlsConfig != nil && slices.EqualFunc(modTimes, r.modTimes, time.Time.Equal) {
+		return r.tlsConfig, nil
+	}
+	tlsConfig, loadErr := r.config.loadTLSConfig()
+	if err = errors.Join(err, loadErr); err != nil {
+		// the files can be in the middle of their update, the previous certificates are kept until they're read
+		if r.tlsConfig != nil {
+			return r.tlsConfig, nil
+		}
+		return nil, err
+	}
+	r.modTimes, r.tlsConfig = modTimes, tlsConfig
+	return tlsConfig, nil
+}
+
+// modTimes returns the modification times of the files of the TLS config.
+func (c HTTPSServerConfig) modTimes() ([]time.Time, error) {
+	modTimes := make([]time.Time, 0, 3)
+	for _, path := range []string{c.TLSCertFilePath, c.TLSKeyFilePath, c.CAFilePath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	return modTimes, nil
+}
+
+func (c HTTPSServerConfig) loadTLSConfig() (*tls.Config, error) {
 	cert, err := tls.LoadX509KeyPair(c.TLSCertFilePath, c.TLSKeyFilePath)
 	if err != nil {
 		return nil, err
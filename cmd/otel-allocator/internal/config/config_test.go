@@ -4,7 +4,14 @@
 package config
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
@@ -47,7 +54,8 @@ func TestLoadFromFile(t *testing.T) {
 						"app.kubernetes.io/managed-by": "opentelemetry-operator",
 					},
 				},
-				FilterStrategy: DefaultFilterStrategy,
+				ConsistentHashing: HashRingConfig{ReplicationFactor: 20},
+				FilterStrategy:    DefaultFilterStrategy,
 				PrometheusCR: PrometheusCRConfig{
 					Enabled:                         true,
 					ScrapeInterval:                  model.Duration(time.Second * 60),
@@ -56,8 +64,22 @@ func TestLoadFromFile(t *testing.T) {
 					ScrapeConfigNamespaceSelector:   &metav1.LabelSelector{},
 					ProbeNamespaceSelector:          &metav1.LabelSelector{},
 					ScrapeProtocols:                 defaultScrapeProtocolsCR,
+					ScrapeConfigsEnabled:            true,
+					ProbesEnabled:                   true,
 				},
 				CollectorNotReadyGracePeriod: 30 * time.Second,
+				CollectorDrainTimeout:        10 * time.Second,
+				Filter: TargetFilterConfig{
+					Drop: []string{`{__meta_kubernetes_namespace="kube-system"}`},
+				},
+				State: StateConfig{
+					Backend:            StateBackendConfigMap,
+					ConfigMapName:      "test-targetallocator-state",
+					CheckpointInterval: DefaultStateCheckpointInterval,
+				},
+				Tenancy: TenancyConfig{
+					Label: DefaultTenantLabel,
+				},
 				HTTPS: HTTPSServerConfig{
 					Enabled:         true,
 					ListenAddr:      ":8443",
@@ -137,8 +159,16 @@ func TestLoadFromFile(t *testing.T) {
 					ScrapeConfigNamespaceSelector:   &metav1.LabelSelector{},
 					ProbeNamespaceSelector:          &metav1.LabelSelector{},
 					ScrapeProtocols:                 defaultScrapeProtocolsCR,
+					ScrapeConfigsEnabled:            true,
+					ProbesEnabled:                   true,
 				},
 				CollectorNotReadyGracePeriod: 30 * time.Second,
+				State: StateConfig{
+					CheckpointInterval: DefaultStateCheckpointInterval,
+				},
+				Tenancy: TenancyConfig{
+					Label: DefaultTenantLabel,
+				},
 				HTTPS: HTTPSServerConfig{
 					Enabled:         true,
 					ListenAddr:      ":8443",
@@ -235,6 +265,8 @@ func TestLoadFromFile(t *testing.T) {
 					ProbeNamespaceSelector:          &metav1.LabelSelector{},
 					ScrapeProtocols:                 defaultScrapeProtocolsCR,
 					ScrapeInterval:                  DefaultCRScrapeInterval,
+					ScrapeConfigsEnabled:            true,
+					ProbesEnabled:                   true,
 				},
 				HTTPS: HTTPSServerConfig{
 					ListenAddr: ":8443",
@@ -280,6 +312,12 @@ func TestLoadFromFile(t *testing.T) {
 					},
 				},
 				CollectorNotReadyGracePeriod: 30 * time.Second,
+				State: StateConfig{
+					CheckpointInterval: DefaultStateCheckpointInterval,
+				},
+				Tenancy: TenancyConfig{
+					Label: DefaultTenantLabel,
+				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -317,6 +355,8 @@ func TestLoadFromFile(t *testing.T) {
 					ProbeNamespaceSelector:          &metav1.LabelSelector{},
 					ScrapeProtocols:                 defaultScrapeProtocolsCR,
 					ScrapeInterval:                  DefaultCRScrapeInterval,
+					ScrapeConfigsEnabled:            true,
+					ProbesEnabled:                   true,
 				},
 				HTTPS: HTTPSServerConfig{
 					ListenAddr: ":8443",
@@ -362,6 +402,12 @@ func TestLoadFromFile(t *testing.T) {
 					},
 				},
 				CollectorNotReadyGracePeriod: 30 * time.Second,
+				State: StateConfig{
+					CheckpointInterval: DefaultStateCheckpointInterval,
+				},
+				Tenancy: TenancyConfig{
+					Label: DefaultTenantLabel,
+				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -423,6 +469,8 @@ func TestLoadFromFile(t *testing.T) {
 					ProbeNamespaceSelector:          &metav1.LabelSelector{},
 					ScrapeProtocols:                 defaultScrapeProtocolsCR,
 					ScrapeInterval:                  DefaultCRScrapeInterval,
+					ScrapeConfigsEnabled:            true,
+					ProbesEnabled:                   true,
 				},
 				HTTPS: HTTPSServerConfig{
 					ListenAddr: ":8443",
@@ -468,6 +516,12 @@ func TestLoadFromFile(t *testing.T) {
 					},
 				},
 				CollectorNotReadyGracePeriod: 30 * time.Second,
+				State: StateConfig{
+					CheckpointInterval: DefaultStateCheckpointInterval,
+				},
+				Tenancy: TenancyConfig{
+					Label: DefaultTenantLabel,
+				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -529,6 +583,8 @@ func TestLoadFromFile(t *testing.T) {
 					ProbeNamespaceSelector:          &metav1.LabelSelector{},
 					ScrapeProtocols:                 defaultScrapeProtocolsCR,
 					ScrapeInterval:                  DefaultCRScrapeInterval,
+					ScrapeConfigsEnabled:            true,
+					ProbesEnabled:                   true,
 				},
 				HTTPS: HTTPSServerConfig{
 					ListenAddr: ":8443",
@@ -574,6 +630,12 @@ func TestLoadFromFile(t *testing.T) {
 					},
 				},
 				CollectorNotReadyGracePeriod: 30 * time.Second,
+				State: StateConfig{
+					CheckpointInterval: DefaultStateCheckpointInterval,
+				},
+				Tenancy: TenancyConfig{
+					Label: DefaultTenantLabel,
+				},
 			},
 			wantErr: assert.NoError,
 		},
@@ -654,6 +716,152 @@ func TestValidateConfig(t *testing.T) {
 			},
 			expectedErr: fmt.Errorf("only one of allowNamespaces or denyNamespaces can be set"),
 		},
+		{
+			name: "authentication enabled without service accounts",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				Authentication:     AuthenticationConfig{Enabled: true},
+			},
+			expectedErr: fmt.Errorf("at least one service account must be allowed when authentication is enabled"),
+		},
+		{
+			name: "authentication enabled with an invalid service account",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				Authentication:     AuthenticationConfig{Enabled: true, ServiceAccounts: []string{"collector"}},
+			},
+			expectedErr: fmt.Errorf("invalid service account %q, expected the namespace/name format", "collector"),
+		},
+		{
+			name: "file state backend without a path",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				State:              StateConfig{Backend: StateBackendFile},
+			},
+			expectedErr: fmt.Errorf("the state path must be set with the %s backend", StateBackendFile),
+		},
+		{
+			name: "configmap state backend without a name",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				State:              StateConfig{Backend: StateBackendConfigMap},
+			},
+			expectedErr: fmt.Errorf("the state config map name must be set with the %s backend", StateBackendConfigMap),
+		},
+		{
+			name: "unknown state backend",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				State:              StateConfig{Backend: "redis"},
+			},
+			expectedErr: fmt.Errorf("unknown state backend %q, expected %s or %s", "redis", StateBackendFile, StateBackendConfigMap),
+		},
+		{
+			name: "state checkpoint interval not set",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				State:              StateConfig{Backend: StateBackendFile, Path: "/state/assignments"},
+			},
+			expectedErr: fmt.Errorf("the state checkpoint interval must be positive"),
+		},
+		{
+			name: "negative consistent hashing replication factor",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				ConsistentHashing:  HashRingConfig{ReplicationFactor: -1},
+			},
+			expectedErr: fmt.Errorf("the consistent hashing partition count and replication factor must be positive"),
+		},
+		{
+			name: "unknown consistent hashing hash function",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				ConsistentHashing:  HashRingConfig{Hash: "md5"},
+			},
+			expectedErr: fmt.Errorf("unknown consistent hashing hash function \"md5\", expected xxhash or sha256"),
+		},
+		{
+			name: "collector owner",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				CollectorOwner:     CollectorOwnerConfig{Kind: "StatefulSet", Name: "my-collector-collector"},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "collector owner without a name",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				CollectorOwner:     CollectorOwnerConfig{Kind: "Deployment"},
+			},
+			expectedErr: fmt.Errorf("the collector owner name must be set"),
+		},
+		{
+			name: "unknown collector owner kind",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				CollectorOwner:     CollectorOwnerConfig{Kind: "ReplicaSet", Name: "my-collector-collector"},
+			},
+			expectedErr: fmt.Errorf("unknown collector owner kind \"ReplicaSet\", expected StatefulSet, Deployment or DaemonSet"),
+		},
+		{
+			name: "leader election without a lease name",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				LeaderElection:     LeaderElectionConfig{Enabled: true},
+			},
+			expectedErr: fmt.Errorf("the lease name must be set when leader election is enabled"),
+		},
+		{
+			name: "leader election without the configmap state backend",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				State:              StateConfig{Backend: StateBackendFile, Path: "/state/assignments", CheckpointInterval: time.Minute},
+				LeaderElection:     LeaderElectionConfig{Enabled: true, LeaseName: "test-targetallocator"},
+			},
+			expectedErr: fmt.Errorf("leader election requires the %s state backend", StateBackendConfigMap),
+		},
+		{
+			name: "tenancy without label",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				Tenancy:            TenancyConfig{Enabled: true},
+			},
+			expectedErr: fmt.Errorf("the tenant label must be set when tenancy is enabled"),
+		},
+		{
+			name: "leader election",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				State:              StateConfig{Backend: StateBackendConfigMap, ConfigMapName: "test-state", CheckpointInterval: time.Minute},
+				LeaderElection:     LeaderElectionConfig{Enabled: true, LeaseName: "test-targetallocator"},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "authentication enabled",
+			fileConfig: Config{
+				PrometheusCR:       PrometheusCRConfig{Enabled: true},
+				CollectorNamespace: "default",
+				Authentication:     AuthenticationConfig{Enabled: true, ServiceAccounts: []string{"default/collector"}},
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -949,3 +1157,58 @@ kube_config_file_path: "` + kubeConfigPath + `"
 		assert.True(t, config.PrometheusCR.Enabled, "CLI should override config file for prometheus CR enabled")
 	})
 }
+
+func TestHTTPSServerConfigReloadsCertificates(t *testing.T) {
+	dir := t.TempDir()
+	c := HTTPSServerConfig{
+		CAFilePath:      filepath.Join(dir, "ca.crt"),
+		TLSCertFilePath: filepath.Join(dir, "tls.crt"),
+		TLSKeyFilePath:  filepath.Join(dir, "tls.key"),
+	}
+	// a self-signed certificate, being its own CA
+	writeCertificate := func(modTime time.Time) []byte {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{SerialNumber: big.NewInt(time.Now().UnixNano()), NotAfter: time.Now().Add(time.Hour)}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		require.NoError(t, os.WriteFile(c.CAFilePath, cert, 0o600))
+		require.NoError(t, os.WriteFile(c.TLSCertFilePath, cert, 0o600))
+		require.NoError(t, os.WriteFile(c.TLSKeyFilePath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+		// the modification times are set, as the file systems can have a coarser resolution than the test
+		for _, path := range []string{c.CAFilePath, c.TLSCertFilePath, c.TLSKeyFilePath} {
+			require.NoError(t, os.Chtimes(path, modTime, modTime))
+		}
+		return der
+	}
+
+	now := time.Now()
+	initial := writeCertificate(now)
+	tlsConfig, err := c.NewTLSConfig()
+	require.NoError(t, err)
+
+	// the certificates are only read again when their files change
+	handshakeConfig, err := tlsConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.Len(t, handshakeConfig.Certificates, 1)
+	assert.Equal(t, initial, handshakeConfig.Certificates[0].Certificate[0])
+	cached, err := tlsConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Same(t, handshakeConfig, cached)
+
+	// the renewed certificate is used by the next handshakes
+	renewed := writeCertificate(now.Add(time.Minute))
+	handshakeConfig, err = tlsConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	require.Len(t, handshakeConfig.Certificates, 1)
+	assert.Equal(t, renewed, handshakeConfig.Certificates[0].Certificate[0])
+
+	// the previous certificates are kept while the files are being updated
+	require.NoError(t, os.Remove(c.TLSKeyFilePath))
+	handshakeConfig, err = tlsConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, renewed, handshakeConfig.Certificates[0].Certificate[0])
+}
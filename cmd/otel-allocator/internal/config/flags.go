@@ -22,6 +22,7 @@ const (
 	httpsCAFilePathFlagName      = "https-ca-file"
 	httpsTLSCertFilePathFlagName = "https-tls-cert-file"
 	httpsTLSKeyFilePathFlagName  = "https-tls-key-file"
+	pprofListenAddrFlagName      = "pprof-listen-addr"
 )
 
 // We can't bind this flag to our FlagSet, so we need to handle it separately.
@@ -38,6 +39,7 @@ func getFlagSet(errorHandling pflag.ErrorHandling) *pflag.FlagSet {
 	flagSet.String(httpsCAFilePathFlagName, "", "The path to the HTTPS server TLS CA file.")
 	flagSet.String(httpsTLSCertFilePathFlagName, "", "The path to the HTTPS server TLS certificate file.")
 	flagSet.String(httpsTLSKeyFilePathFlagName, "", "The path to the HTTPS server TLS key file.")
+	flagSet.String(pprofListenAddrFlagName, "", "The address where the pprof profiling endpoints are served. Disabled when empty.")
 	zapFlagSet := flag.NewFlagSet("", flag.ErrorHandling(errorHandling))
 	zapCmdLineOpts.BindFlags(zapFlagSet)
 	flagSet.AddGoFlagSet(zapFlagSet)
@@ -80,6 +82,10 @@ func getHttpsTLSKeyFilePath(flagSet *pflag.FlagSet) (value string, changed bool,
 	return getFlagValueAndChangedString(flagSet, httpsTLSKeyFilePathFlagName)
 }
 
+func getPprofListenAddr(flagSet *pflag.FlagSet) (value string, changed bool, err error) {
+	return getFlagValueAndChangedString(flagSet, pprofListenAddrFlagName)
+}
+
 // getFlagValueAndChanged returns the given flag's string value and whether it was changed.
 func getFlagValueAndChangedString(flagSet *pflag.FlagSet, flagName string) (value string, changed bool, err error) {
 	if changed = flagSet.Changed(flagName); !changed {
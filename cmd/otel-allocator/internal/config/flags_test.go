@@ -53,6 +53,15 @@ func TestFlagGetters(t *testing.T) {
 				return value, err
 			},
 		},
+		{
+			name:          "GetPprofListenAddr",
+			flagArgs:      []string{"--" + pprofListenAddrFlagName, "localhost:6060"},
+			expectedValue: "localhost:6060",
+			getterFunc: func(fs *pflag.FlagSet) (interface{}, error) {
+				value, _, err := getPprofListenAddr(fs)
+				return value, err
+			},
+		},
 		{
 			name:          "GetPrometheusCREnabled",
 			flagArgs:      []string{"--" + prometheusCREnabledFlagName, "true"},
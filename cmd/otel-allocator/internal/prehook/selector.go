@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prehook
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+var _ Hook = &selectorTargetFilter{}
+
+// selectorTargetFilter keeps the targets matching one of the keep selectors, if any, and none of the drop selectors,
+// before applying the next hook.
+type selectorTargetFilter struct {
+	log  logr.Logger
+	keep [][]*labels.Matcher
+	drop [][]*labels.Matcher
+	next Hook
+}
+
+// WithSelectors filters the targets with the given label selectors, such as {__meta_kubernetes_namespace="a"},
+// before the next hook, which may be nil.
+func WithSelectors(log logr.Logger, next Hook, keep, drop []string) (Hook, error) {
+	keepMatchers, err := parseSelectors(keep)
+	if err != nil {
+		return nil, err
+	}
+	dropMatchers, err := parseSelectors(drop)
+	if err != nil {
+		return nil, err
+	}
+	return &selectorTargetFilter{
+		log:  log.WithName("Prehook").WithName("selectors"),
+		keep: keepMatchers,
+		drop: dropMatchers,
+		next: next,
+	}, nil
+}
+
+func parseSelectors(selectors []string) ([][]*labels.Matcher, error) {
+	matchers := make([][]*labels.Matcher, len(selectors))
+	for i, selector := range selectors {
+		var err error
+		if matchers[i], err = parser.ParseMetricSelector(selector); err != nil {
+			return nil, fmt.Errorf("invalid target selector %q: %w", selector, err)
+		}
+	}
+	return matchers, nil
+}
+
+func (sf *selectorTargetFilter) Apply(targets []*target.Item) []*target.Item {
+	numTargets := len(targets)
+	targets = slices.DeleteFunc(targets, func(item *target.Item) bool {
+		if len(sf.keep) > 0 && !matchesAny(sf.keep, item.Labels) {
+			return true
+		}
+		return matchesAny(sf.drop, item.Labels)
+	})
+	sf.log.V(2).Info("Filtering complete", "seen", numTargets, "kept", len(targets))
+
+	if sf.next == nil {
+		return targets
+	}
+	return sf.next.Apply(targets)
+}
+
+func (sf *selectorTargetFilter) SetConfig(cfgs map[string][]*relabel.Config) {
+	if sf.next != nil {
+		sf.next.SetConfig(cfgs)
+	}
+}
+
+func (sf *selectorTargetFilter) GetConfig() map[string][]*relabel.Config {
+	if sf.next == nil {
+		return nil
+	}
+	return sf.next.GetConfig()
+}
+
+// matchesAny returns whether the labels match all the matchers of at least one selector.
+func matchesAny(selectors [][]*labels.Matcher, lset labels.Labels) bool {
+	for _, matchers := range selectors {
+		matches := true
+		for _, matcher := range matchers {
+			if !matcher.Matches(lset.Get(matcher.Name)) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prehook
+
+import (
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+func makeNamespacedTargets(namespaces ...string) []*target.Item {
+	var targets []*target.Item
+	for _, namespace := range namespaces {
+		lset := labels.Labels{
+			{Name: "__meta_kubernetes_namespace", Value: namespace},
+			{Name: "__address__", Value: namespace + ":8080"},
+		}
+		targets = append(targets, target.NewItem("job", namespace+":8080", lset, ""))
+	}
+	return targets
+}
+
+func namespacesOf(targets []*target.Item) []string {
+	var namespaces []string
+	for _, item := range targets {
+		namespaces = append(namespaces, item.Labels.Get("__meta_kubernetes_namespace"))
+	}
+	return namespaces
+}
+
+func TestSelectorTargetFilter(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		keep     []string
+		drop     []string
+		expected []string
+	}{
+		{
+			desc:     "drop",
+			drop:     []string{`{__meta_kubernetes_namespace="kube-system"}`},
+			expected: []string{"team-a", "team-b", "default"},
+		},
+		{
+			desc:     "keep",
+			keep:     []string{`{__meta_kubernetes_namespace=~"team-.*"}`, `{__meta_kubernetes_namespace="default"}`},
+			expected: []string{"team-a", "team-b", "default"},
+		},
+		{
+			desc:     "keep and drop",
+			keep:     []string{`{__meta_kubernetes_namespace=~"team-.*"}`},
+			drop:     []string{`{__meta_kubernetes_namespace="team-b"}`},
+			expected: []string{"team-a"},
+		},
+		{
+			desc:     "all matchers of a selector must match",
+			drop:     []string{`{__meta_kubernetes_namespace="team-a", __address__="other:8080"}`},
+			expected: []string{"team-a", "team-b", "kube-system", "default"},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			filter, err := WithSelectors(logger, nil, tt.keep, tt.drop)
+			require.NoError(t, err)
+			targets := filter.Apply(makeNamespacedTargets("team-a", "team-b", "kube-system", "default"))
+			assert.Equal(t, tt.expected, namespacesOf(targets))
+		})
+	}
+}
+
+func TestSelectorTargetFilterWithNextHook(t *testing.T) {
+	filter, err := WithSelectors(logger, New(relabelConfigTargetFilterName, logger), nil, []string{`{__meta_kubernetes_namespace="kube-system"}`})
+	require.NoError(t, err)
+	cfgs := map[string][]*relabel.Config{
+		"job": {
+			{
+				SourceLabels: model.LabelNames{"__meta_kubernetes_namespace"},
+				Regex:        relabel.MustNewRegexp("default"),
+				Action:       "drop",
+				Separator:    ";",
+				Replacement:  "$1",
+			},
+		},
+	}
+	filter.SetConfig(cfgs)
+	assert.Len(t, filter.GetConfig()["job"], 1)
+
+	targets := filter.Apply(makeNamespacedTargets("team-a", "kube-system", "default"))
+	assert.Equal(t, []string{"team-a"}, namespacesOf(targets))
+}
+
+func TestWithSelectorsInvalid(t *testing.T) {
+	_, err := WithSelectors(logger, nil, []string{`{namespace=}`}, nil)
+	assert.ErrorContains(t, err, "invalid target selector")
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/singleflight"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// tokenReviewCacheTTL is how long the result of a token review is reused for the same token.
+	tokenReviewCacheTTL = time.Minute
+	tokenReviewTimeout  = 10 * time.Second
+)
+
+var (
+	errMissingToken = errors.New("missing bearer token")
+	errInvalidToken = errors.New("invalid bearer token")
+)
+
+// Authenticator authenticates the requests to the allocator API.
+type Authenticator interface {
+	// Authenticate returns an error if the bearer token isn't valid or doesn't belong to an allowed client.
+	Authenticate(ctx context.Context, token string) error
+}
+
+var _ Authenticator = &TokenReviewAuthenticator{}
+
+// TokenReviewAuthenticator validates service account tokens with the Kubernetes TokenReview API, and only allows
+// the configured service accounts.
+type TokenReviewAuthenticator struct {
+	client  kubernetes.Interface
+	allowed map[string]struct{}
+
+	// reviews shares the review of a token between the concurrent requests sending it, e.g. when the collectors start
+	reviews singleflight.Group
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]tokenReviewResult
+}
+
+type tokenReviewResult struct {
+	err     error
+	expires time.Time
+}
+
+// NewTokenReviewAuthenticator creates an authenticator allowing the given service accounts, in the namespace/name format.
+func NewTokenReviewAuthenticator(client kubernetes.Interface, serviceAccounts []string) *TokenReviewAuthenticator {
+	allowed := make(map[string]struct{}, len(serviceAccounts))
+	for _, serviceAccount := range serviceAccounts {
+		namespace, name, _ := strings.Cut(serviceAccount, "/")
+		allowed[fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)] = struct{}{}
+	}
+	return &TokenReviewAuthenticator{
+		client:  client,
+		allowed: allowed,
+		cache:   make(map[[sha256.Size]byte]tokenReviewResult),
+	}
+}
+
+func (a *TokenReviewAuthenticator) Authenticate(ctx context.Context, token string) error {
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+
+	a.mu.Lock()
+	cached, ok := a.cache[key]
+	a.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.err
+	}
+
+	_, err, _ := a.reviews.Do(string(key[:]), func() (interface{}, error) {
+		return nil, a.review(context.WithoutCancel(ctx), token)
+	})
+	// failures to reach the API server aren't cached
+	if err == nil || errors.Is(err, errInvalidToken) {
+		a.mu.Lock()
+		for k, v := range a.cache {
+			if now.After(v.expires) {
+				delete(a.cache, k)
+			}
+		}
+		a.cache[key] = tokenReviewResult{err: err, expires: now.Add(tokenReviewCacheTTL)}
+		a.mu.Unlock()
+	}
+	return err
+}
+
+func (a *TokenReviewAuthenticator) review(ctx context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, tokenReviewTimeout)
+	defer cancel()
+	review, err := a.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to review the bearer token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return errInvalidToken
+	}
+	if _, ok := a.allowed[review.Status.User.Username]; !ok {
+		return fmt.Errorf("%w: %s isn't allowed", errInvalidToken, review.Status.User.Username)
+	}
+	return nil
+}
+
+// AuthenticationMiddleware rejects the requests without a valid bearer token, when an authenticator is configured.
+func (s *Server) AuthenticationMiddleware(c *gin.Context) {
+	if s.authenticator == nil {
+		c.Next()
+		return
+	}
+
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		s.logger.V(3).Info("Rejected unauthenticated request", "path", c.Request.URL.Path, "error", errMissingToken)
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	if err := s.authenticator.Authenticate(c.Request.Context(), token); err != nil {
+		status := http.StatusForbidden
+		if !errors.Is(err, errInvalidToken) {
+			status = http.StatusServiceUnavailable
+		}
+		s.logger.V(3).Info("Rejected unauthenticated request", "path", c.Request.URL.Path, "error", err)
+		c.AbortWithStatus(status)
+		return
+	}
+	c.Next()
+}
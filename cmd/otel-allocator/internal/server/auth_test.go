@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newFakeTokenReviewClient returns a client authenticating the given tokens as the mapped users.
+func newFakeTokenReviewClient(users map[string]string, reviews *int) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		*reviews++
+		review := action.(k8stesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "unavailable" {
+			return true, nil, errors.New("connection refused")
+		}
+		if user, ok := users[review.Spec.Token]; ok {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: user},
+			}
+		}
+		return true, review, nil
+	})
+	return client
+}
+
+func TestTokenReviewAuthenticator(t *testing.T) {
+	reviews := 0
+	client := newFakeTokenReviewClient(map[string]string{
+		"collector-token": "system:serviceaccount:default:collector",
+		"other-token":     "system:serviceaccount:default:other",
+	}, &reviews)
+	authenticator := NewTokenReviewAuthenticator(client, []string{"default/collector"})
+
+	assert.NoError(t, authenticator.Authenticate(context.Background(), "collector-token"))
+	assert.ErrorIs(t, authenticator.Authenticate(context.Background(), "other-token"), errInvalidToken)
+	assert.ErrorIs(t, authenticator.Authenticate(context.Background(), "invalid-token"), errInvalidToken)
+	err := authenticator.Authenticate(context.Background(), "unavailable")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errInvalidToken)
+	assert.Equal(t, 4, reviews)
+
+	// the results are cached, except for failed reviews
+	assert.NoError(t, authenticator.Authenticate(context.Background(), "collector-token"))
+	assert.ErrorIs(t, authenticator.Authenticate(context.Background(), "other-token"), errInvalidToken)
+	assert.Error(t, authenticator.Authenticate(context.Background(), "unavailable"))
+	assert.Equal(t, 5, reviews)
+}
+
+func TestServer_AuthenticationMiddleware(t *testing.T) {
+	reviews := 0
+	client := newFakeTokenReviewClient(map[string]string{
+		"collector-token": "system:serviceaccount:default:collector",
+		"other-token":     "system:serviceaccount:default:other",
+	}, &reviews)
+	s := NewServer(logger, nil, ":8080", WithAuthenticator(NewTokenReviewAuthenticator(client, []string{"default/collector"})))
+	s.UpdateScrapeConfigResponse(nil)
+
+	for _, tt := range []struct {
+		desc     string
+		path     string
+		token    string
+		expected int
+	}{
+		{desc: "missing token", path: "/scrape_configs", expected: http.StatusUnauthorized},
+		{desc: "not allowed", path: "/scrape_configs", token: "other-token", expected: http.StatusForbidden},
+		{desc: "review failure", path: "/jobs", token: "unavailable", expected: http.StatusServiceUnavailable},
+		{desc: "allowed", path: "/scrape_configs", token: "collector-token", expected: http.StatusOK},
+		{desc: "unauthenticated endpoint", path: "/livez", expected: http.StatusOK},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			request := httptest.NewRequest("GET", tt.path, nil)
+			if tt.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+
+			s.server.Handler.ServeHTTP(w, request)
+			require.Equal(t, tt.expected, w.Result().StatusCode)
+		})
+	}
+}
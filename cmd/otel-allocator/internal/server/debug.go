@@ -0,0 +1,186 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"cmp"
+	"html/template"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/prometheus/model/labels"
+)
+
+// debugPage renders the allocation status and the targets, like the targets page of Prometheus.
+var debugPage = template.Must(template.New("debug").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>Target Allocator</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+.unassigned { color: #b00; }
+.label { display: inline-block; background: #eee; border-radius: 3px; margin: 1px; padding: 0 4px; font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>Target Allocator</h1>
+<p>
+Strategy: <b>{{ .Status.Strategy }}</b>{{ with .Status.FallbackStrategy }}, fallback: <b>{{ . }}</b>{{ end }}<br>
+Last discovery refresh: {{ with .Status.LastDiscovery }}{{ .Format "2006-01-02T15:04:05Z07:00" }}{{ else }}never{{ end }}<br>
+Targets: {{ .Status.Targets }}, unassigned: {{ .Status.UnassignedTargets }}
+</p>
+<h2>Collectors</h2>
+<table>
+<tr><th>Name</th><th>Node</th><th>Zone</th><th>Weight</th><th>Draining</th><th>Targets</th></tr>
+{{ range .Status.Collectors }}<tr>
+<td><a href="?collector_id={{ .Name }}">{{ .Name }}</a></td><td>{{ .NodeName }}</td><td>{{ .Zone }}</td><td>{{ .Weight }}</td><td>{{ .Draining }}</td><td>{{ .NumTargets }}</td>
+</tr>
+{{ end }}</table>
+<h2>Targets{{ with .Collector }} of {{ . }}{{ end }}{{ with .Job }} in job {{ . }}{{ end }}</h2>
+<p><a href="?">All targets</a></p>
+<table>
+<tr><th>Job</th><th>Target</th><th>Collector</th><th>Labels</th></tr>
+{{ range .Targets }}<tr>
+<td><a href="?job={{ .JobName }}">{{ .JobName }}</a></td><td>{{ .TargetURL }}</td>
+<td>{{ with .CollectorName }}<a href="?collector_id={{ . }}">{{ . }}</a>{{ else }}<span class="unassigned">unassigned</span>{{ end }}</td>
+<td>{{ range $name, $value := .Labels.Map }}<span class="label">{{ $name }}="{{ $value }}"</span> {{ end }}</td>
+</tr>
+{{ end }}</table>
+</body>
+</html>
+`))
+
+type debugStatusJSON struct {
+	Strategy          string               `json:"strategy"`
+	FallbackStrategy  string               `json:"fallback_strategy,omitempty"`
+	LastDiscovery     *time.Time           `json:"last_discovery,omitempty"`
+	Targets           int                  `json:"targets"`
+	UnassignedTargets int                  `json:"unassigned_targets"`
+	Collectors        []debugCollectorJSON `json:"collectors"`
+}
+
+type debugCollectorJSON struct {
+	Link       string `json:"_link"`
+	Name       string `json:"name"`
+	NodeName   string `json:"node_name,omitempty"`
+	Zone       string `json:"zone,omitempty"`
+	Tenant     string `json:"tenant,omitempty"`
+	Weight     int    `json:"weight"`
+	Draining   bool   `json:"draining"`
+	NumTargets int    `json:"num_targets"`
+}
+
+type debugTargetJSON struct {
+	JobName       string        `json:"job_name"`
+	TargetURL     string        `json:"target"`
+	CollectorName string        `json:"collector_name"`
+	Labels        labels.Labels `json:"labels"`
+}
+
+// WithDebugInfo sets the allocation strategies and the time of the last target discovery, shown by the debug
+// endpoints.
+func WithDebugInfo(strategy, fallbackStrategy string, lastDiscovery func() time.Time) Option {
+	return func(s *Server) {
+		s.strategy = strategy
+		s.fallbackStrategy = fallbackStrategy
+		s.lastDiscovery = lastDiscovery
+	}
+}
+
+// DebugStatusHandler returns the allocation strategy and the collectors with their number of targets.
+func (s *Server) DebugStatusHandler(c *gin.Context) {
+	s.jsonHandler(c.Writer, s.debugStatus())
+}
+
+// DebugTargetsHandler returns all the targets with their labels and collector, optionally filtered by the
+// collector_id and job query parameters. Unassigned targets have an empty collector.
+func (s *Server) DebugTargetsHandler(c *gin.Context) {
+	s.jsonHandler(c.Writer, s.debugTargets(c.Query("collector_id"), c.Query("job")))
+}
+
+// DebugPageHandler renders the status and the targets as an HTML page.
+func (s *Server) DebugPageHandler(c *gin.Context) {
+	collector, job := c.Query("collector_id"), c.Query("job")
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	err := debugPage.Execute(c.Writer, struct {
+		Status    debugStatusJSON
+		Collector string
+		Job       string
+		Targets   []debugTargetJSON
+	}{
+		Status:    s.debugStatus(),
+		Collector: collector,
+		Job:       job,
+		Targets:   s.debugTargets(collector, job),
+	})
+	if err != nil {
+		s.logger.Error(err, "failed to render the debug page")
+	}
+}
+
+func (s *Server) debugStatus() debugStatusJSON {
+	status := debugStatusJSON{
+		Strategy:         s.strategy,
+		FallbackStrategy: s.fallbackStrategy,
+		Collectors:       []debugCollectorJSON{},
+	}
+	if s.lastDiscovery != nil {
+		if lastDiscovery := s.lastDiscovery(); !lastDiscovery.IsZero() {
+			status.LastDiscovery = &lastDiscovery
+		}
+	}
+	// the collectors of the targets are read from the assignments, copied under the lock of the allocator
+	assignments := s.allocator.Assignments()
+	for hash := range s.allocator.TargetItems() {
+		status.Targets++
+		if assignments[hash] == "" {
+			status.UnassignedTargets++
+		}
+	}
+	for _, collector := range s.allocator.Collectors() {
+		weight := collector.Weight
+		if weight < 1 {
+			weight = 1
+		}
+		status.Collectors = append(status.Collectors, debugCollectorJSON{
+			Link:       "/debug/targets?collector_id=" + url.QueryEscape(collector.Name),
+			Name:       collector.Name,
+			NodeName:   collector.NodeName,
+			Zone:       collector.Zone,
+			Tenant:     collector.Tenant,
+			Weight:     weight,
+			Draining:   collector.Draining,
+			NumTargets: collector.NumTargets,
+		})
+	}
+	slices.SortFunc(status.Collectors, func(a, b debugCollectorJSON) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return status
+}
+
+func (s *Server) debugTargets(collector, job string) []debugTargetJSON {
+	targets := []debugTargetJSON{}
+	assignments := s.allocator.Assignments()
+	for hash, item := range s.allocator.TargetItems() {
+		collectorName := assignments[hash]
+		if (collector != "" && collectorName != collector) || (job != "" && item.JobName != job) {
+			continue
+		}
+		targets = append(targets, debugTargetJSON{
+			JobName:       item.JobName,
+			TargetURL:     item.TargetURL,
+			CollectorName: collectorName,
+			Labels:        item.Labels,
+		})
+	}
+	slices.SortFunc(targets, func(a, b debugTargetJSON) int {
+		return cmp.Or(cmp.Compare(a.JobName, b.JobName), cmp.Compare(a.TargetURL, b.TargetURL))
+	})
+	return targets
+}
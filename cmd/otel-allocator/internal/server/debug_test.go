@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+func TestServer_DebugHandlers(t *testing.T) {
+	allocator, err := allocation.New("least-weighted", logger)
+	require.NoError(t, err)
+	allocator.SetCollectors(map[string]*allocation.Collector{
+		"test-collector": {Name: "test-collector", NodeName: "test-node", Weight: 2},
+	})
+	allocator.SetTargets([]*target.Item{
+		target.NewItem("job-a", "url-a", labels.FromStrings("pod", "pod-a"), ""),
+		target.NewItem("job-b", "url-b", labels.FromStrings("pod", "pod-b"), ""),
+	})
+	lastDiscovery := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := NewServer(logger, allocator, ":8080", WithDebugInfo("least-weighted", "", func() time.Time {
+		return lastDiscovery
+	}))
+
+	get := func(path string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(w, request)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w
+	}
+
+	t.Run("status", func(t *testing.T) {
+		var status debugStatusJSON
+		require.NoError(t, json.Unmarshal(get("/debug/status").Body.Bytes(), &status))
+		assert.Equal(t, debugStatusJSON{
+			Strategy:      "least-weighted",
+			LastDiscovery: &lastDiscovery,
+			Targets:       2,
+			Collectors: []debugCollectorJSON{{
+				Link:       "/debug/targets?collector_id=test-collector",
+				Name:       "test-collector",
+				NodeName:   "test-node",
+				Weight:     2,
+				NumTargets: 2,
+			}},
+		}, status)
+	})
+
+	t.Run("targets", func(t *testing.T) {
+		var targets []debugTargetJSON
+		require.NoError(t, json.Unmarshal(get("/debug/targets").Body.Bytes(), &targets))
+		require.Len(t, targets, 2)
+		assert.Equal(t, "job-a", targets[0].JobName)
+		assert.Equal(t, "test-collector", targets[0].CollectorName)
+		assert.Equal(t, "job-b", targets[1].JobName)
+
+		require.NoError(t, json.Unmarshal(get("/debug/targets?job=job-b").Body.Bytes(), &targets))
+		require.Len(t, targets, 1)
+		assert.Equal(t, "url-b", targets[0].TargetURL)
+		assert.Equal(t, "pod-b", targets[0].Labels.Get("pod"))
+
+		require.NoError(t, json.Unmarshal(get("/debug/targets?collector_id=other-collector").Body.Bytes(), &targets))
+		assert.Empty(t, targets)
+	})
+
+	t.Run("page", func(t *testing.T) {
+		w := get("/debug?collector_id=test-collector")
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		body := w.Body.String()
+		assert.Contains(t, body, "least-weighted")
+		assert.Contains(t, body, "2024-01-02T03:04:05Z")
+		assert.Contains(t, body, "url-a")
+		assert.Contains(t, body, `pod="pod-b"`)
+	})
+}
+
+func TestServer_DebugHandlersConcurrentWithAllocation(t *testing.T) {
+	tenantLabel := "__meta_kubernetes_namespace"
+	allocator, err := allocation.NewWithTenants("consistent-hashing", logger, tenantLabel, nil)
+	require.NoError(t, err)
+	s := NewServer(logger, allocator, ":8080", WithDebugInfo("consistent-hashing", "", time.Now))
+
+	// the allocation changes while the handlers read it, which the race detector checks
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			allocator.SetCollectors(map[string]*allocation.Collector{
+				"collector-a": {Name: "collector-a", Tenant: "team-a", Weight: i%3 + 1},
+				"collector-b": {Name: "collector-b", Tenant: "team-a", Draining: i%2 == 0},
+			})
+			allocator.SetTargets([]*target.Item{
+				target.NewItem("job-a", "url-a", labels.FromStrings(tenantLabel, "team-a", "i", strconv.Itoa(i)), ""),
+				target.NewItem("job-b", "url-b", labels.FromStrings(tenantLabel, "team-a"), ""),
+			})
+		}
+	}()
+	for _, path := range []string{"/debug/status", "/debug/targets", "/debug", "/targets/metadata", "/jobs?tenant=team-a", "/jobs/job-a/targets?tenant=team-a"} {
+		for range 20 {
+			request := httptest.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+			s.server.Handler.ServeHTTP(w, request)
+			assert.Contains(t, []int{http.StatusOK, http.StatusNotFound}, w.Code, path)
+		}
+	}
+	close(stop)
+	<-done
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// gzipMinSize is the size under which responses aren't worth compressing.
+const gzipMinSize = 1024
+
+// bufferedWriter holds the response body back, so it can be tagged and compressed once complete.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// EncodingMiddleware sets an ETag on successful responses, answers with a 304 when it matches the If-None-Match
+// request header, and gzip-compresses the responses for the clients accepting it.
+func (s *Server) EncodingMiddleware(c *gin.Context) {
+	writer := &bufferedWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	c.Next()
+	c.Writer = writer.ResponseWriter
+
+	body := writer.body.Bytes()
+	if c.Writer.Status() != http.StatusOK {
+		s.writeBody(c, body)
+		return
+	}
+
+	// the compressed and plain representations have distinct ETags, and the caches must key them on the encoding
+	c.Header("Vary", "Accept-Encoding")
+	gzipped := len(body) >= gzipMinSize && acceptsGzip(c.GetHeader("Accept-Encoding"))
+	h := fnv.New64a()
+	_, _ = h.Write(body)
+	hash := strconv.FormatUint(h.Sum64(), 16)
+	etag := `"` + hash + `"`
+	if gzipped {
+		etag = `"` + hash + `-gzip"`
+	}
+	c.Header("ETag", etag)
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	if !gzipped {
+		s.writeBody(c, body)
+		return
+	}
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write(body)
+	if err == nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		c.Header("ETag", `"`+hash+`"`)
+		s.writeBody(c, body)
+		return
+	}
+	c.Header("Content-Encoding", "gzip")
+	s.writeBody(c, compressed.Bytes())
+}
+
+func (s *Server) writeBody(c *gin.Context, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	if _, err := c.Writer.Write(body); err != nil {
+		s.logger.Error(err, "failed to write the http response")
+	}
+}
+
+// etagMatches returns whether the If-None-Match header lists the ETag.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsGzip returns whether the Accept-Encoding header allows gzip.
+func acceptsGzip(acceptEncoding string) bool {
+	for _, encoding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.TrimSpace(name) != "gzip" {
+			continue
+		}
+		return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+	}
+	return false
+}
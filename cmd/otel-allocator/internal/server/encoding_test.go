@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+func TestServer_EncodingMiddleware(t *testing.T) {
+	targetItems := map[target.ItemHash]*target.Item{}
+	for i := 0; i < 100; i++ {
+		item := target.NewItem(fmt.Sprintf("job-%d", i), "test-url", labels.Labels{{Name: "i", Value: fmt.Sprint(i)}}, "")
+		targetItems[item.Hash()] = item
+	}
+	s := NewServer(logger, &mockAllocator{targetItems: targetItems}, ":8080")
+
+	get := func(headers map[string]string) (*http.Response, []byte) {
+		request := httptest.NewRequest("GET", "/jobs", nil)
+		for name, value := range headers {
+			request.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(w, request)
+		result := w.Result()
+		body, err := io.ReadAll(result.Body)
+		require.NoError(t, err)
+		return result, body
+	}
+
+	result, plain := get(nil)
+	require.Equal(t, http.StatusOK, result.StatusCode)
+	etag := result.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, "Accept-Encoding", result.Header.Get("Vary"))
+	assert.Empty(t, result.Header.Get("Content-Encoding"))
+	assert.Greater(t, len(plain), gzipMinSize)
+
+	t.Run("matching ETag", func(t *testing.T) {
+		result, body := get(map[string]string{"If-None-Match": etag})
+		assert.Equal(t, http.StatusNotModified, result.StatusCode)
+		assert.Equal(t, etag, result.Header.Get("ETag"))
+		assert.Equal(t, "Accept-Encoding", result.Header.Get("Vary"))
+		assert.Empty(t, body)
+	})
+
+	t.Run("outdated ETag", func(t *testing.T) {
+		result, body := get(map[string]string{"If-None-Match": `"outdated"`})
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, plain, body)
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		result, body := get(map[string]string{"Accept-Encoding": "gzip, deflate"})
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, "gzip", result.Header.Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", result.Header.Get("Vary"))
+		gzipETag := result.Header.Get("ETag")
+		assert.NotEqual(t, etag, gzipETag)
+		assert.Less(t, len(body), len(plain))
+
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		require.NoError(t, err)
+		decompressed, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		assert.Equal(t, plain, decompressed)
+
+		// the ETag of a representation only matches the same representation
+		result, _ = get(map[string]string{"Accept-Encoding": "gzip", "If-None-Match": gzipETag})
+		assert.Equal(t, http.StatusNotModified, result.StatusCode)
+		result, _ = get(map[string]string{"If-None-Match": gzipETag})
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		result, _ = get(map[string]string{"Accept-Encoding": "gzip", "If-None-Match": etag})
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+	})
+
+	t.Run("errors are passed through", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "/jobs/job-1/targets?collector_id=test-collector&resource_version=1&timeout=soon", nil)
+		w := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(w, request)
+		assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+		assert.Empty(t, w.Result().Header.Get("ETag"))
+		assert.NotEmpty(t, w.Body.Bytes())
+	})
+}
+
+func TestEtagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`"def", W/"abc"`, `"abc"`))
+	assert.True(t, etagMatches(`*`, `"abc"`))
+	assert.False(t, etagMatches(``, `"abc"`))
+	assert.False(t, etagMatches(`"def"`, `"abc"`))
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	assert.True(t, acceptsGzip("gzip"))
+	assert.True(t, acceptsGzip("deflate, gzip;q=1.0, *;q=0.5"))
+	assert.False(t, acceptsGzip(""))
+	assert.False(t, acceptsGzip("br"))
+	assert.False(t, acceptsGzip("gzip;q=0"))
+}
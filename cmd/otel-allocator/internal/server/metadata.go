@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+const (
+	targetInfoMetric = "opentelemetry_allocator_target_info"
+
+	targetInfoJobLabel       = "job"
+	targetInfoInstanceLabel  = "instance"
+	targetInfoCollectorLabel = "collector_id"
+)
+
+// TargetMetadataHandler serves an info metric for every target, in the Prometheus text or OpenMetrics format. Its labels
+// are the job, the instance, the collector the target is assigned to, and the discovered labels of the target, so that
+// the scraped data can be joined with the allocation on the job and instance labels.
+func (s *Server) TargetMetadataHandler(c *gin.Context) {
+	// the collectors of the targets are read from the assignments, copied under the lock of the allocator
+	assignments := s.allocator.Assignments()
+	items := s.allocator.TargetItems()
+	family := &dto.MetricFamily{
+		Name:   ptr.To(targetInfoMetric),
+		Help:   ptr.To("The discovered labels and the assigned collector of each target."),
+		Type:   dto.MetricType_GAUGE.Enum(),
+		Metric: make([]*dto.Metric, 0, len(items)),
+	}
+	for hash, item := range items {
+		family.Metric = append(family.Metric, targetInfo(item, assignments[hash]))
+	}
+	slices.SortFunc(family.Metric, func(a, b *dto.Metric) int {
+		// the job and instance labels come first
+		return cmp.Or(
+			cmp.Compare(a.Label[0].GetValue(), b.Label[0].GetValue()),
+			cmp.Compare(a.Label[1].GetValue(), b.Label[1].GetValue()),
+		)
+	})
+
+	format := expfmt.NegotiateIncludingOpenMetrics(c.Request.Header)
+	c.Header("Content-Type", string(format))
+	c.Status(http.StatusOK)
+	encoder := expfmt.NewEncoder(c.Writer, format)
+	if err := encoder.Encode(family); err != nil {
+		s.logger.Error(err, "failed to encode the target metadata")
+		return
+	}
+	if closer, ok := encoder.(expfmt.Closer); ok {
+		if err := closer.Close(); err != nil {
+			s.logger.Error(err, "failed to encode the target metadata")
+		}
+	}
+}
+
+// targetInfo returns the info metric of a target assigned to the collector. The discovered labels lose their leading and trailing underscores,
+// __address__ becomes address and __meta_kubernetes_pod_name becomes meta_kubernetes_pod_name, and the ones clashing
+// with the labels of the metric are dropped.
+func targetInfo(item *target.Item, collectorName string) *dto.Metric {
+	metric := &dto.Metric{
+		Label: []*dto.LabelPair{
+			{Name: ptr.To(targetInfoJobLabel), Value: ptr.To(item.JobName)},
+			{Name: ptr.To(targetInfoInstanceLabel), Value: ptr.To(item.TargetURL)},
+			{Name: ptr.To(targetInfoCollectorLabel), Value: ptr.To(collectorName)},
+		},
+		Gauge: &dto.Gauge{Value: ptr.To(1.0)},
+	}
+	seen := map[string]bool{targetInfoJobLabel: true, targetInfoInstanceLabel: true, targetInfoCollectorLabel: true}
+	item.Labels.Range(func(l labels.Label) {
+		name := strings.Trim(l.Name, "_")
+		if seen[name] || !model.LabelName(name).IsValidLegacy() {
+			return
+		}
+		seen[name] = true
+		metric.Label = append(metric.Label, &dto.LabelPair{Name: ptr.To(name), Value: ptr.To(l.Value)})
+	})
+	return metric
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+func TestServer_TargetMetadataHandler(t *testing.T) {
+	allocator, err := allocation.New("least-weighted", logger)
+	require.NoError(t, err)
+	allocator.SetCollectors(map[string]*allocation.Collector{
+		"test-collector": {Name: "test-collector"},
+	})
+	allocator.SetTargets([]*target.Item{
+		target.NewItem("job-b", "url-b", labels.FromStrings("__address__", "url-b", "__meta_kubernetes_pod_name", "pod-b", "job", "ignored"), ""),
+		target.NewItem("job-a", "url-a", labels.FromStrings("__address__", "url-a"), ""),
+	})
+	s := NewServer(logger, allocator, ":8080")
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+		want        string
+	}{
+		{
+			name:        "text",
+			contentType: "text/plain; version=0.0.4; charset=utf-8; escaping=underscores",
+			want: `# HELP opentelemetry_allocator_target_info The discovered labels and the assigned collector of each target.
+# TYPE opentelemetry_allocator_target_info gauge
+opentelemetry_allocator_target_info{job="job-a",instance="url-a",collector_id="test-collector",address="url-a"} 1
+opentelemetry_allocator_target_info{job="job-b",instance="url-b",collector_id="test-collector",address="url-b",meta_kubernetes_pod_name="pod-b"} 1
+`,
+		},
+		{
+			name:        "openmetrics",
+			accept:      "application/openmetrics-text; version=1.0.0",
+			contentType: "application/openmetrics-text; version=1.0.0; charset=utf-8; escaping=underscores",
+			want: `# HELP opentelemetry_allocator_target_info The discovered labels and the assigned collector of each target.
+# TYPE opentelemetry_allocator_target_info gauge
+opentelemetry_allocator_target_info{job="job-a",instance="url-a",collector_id="test-collector",address="url-a"} 1.0
+opentelemetry_allocator_target_info{job="job-b",instance="url-b",collector_id="test-collector",address="url-b",meta_kubernetes_pod_name="pod-b"} 1.0
+# EOF
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/targets/metadata", nil)
+			if tc.accept != "" {
+				request.Header.Set("Accept", tc.accept)
+			}
+			w := httptest.NewRecorder()
+			s.server.Handler.ServeHTTP(w, request)
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tc.contentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tc.want, w.Body.String())
+		})
+	}
+}
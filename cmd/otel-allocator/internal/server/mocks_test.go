@@ -22,6 +22,11 @@ func (m *mockAllocator) Collectors() map[string]*allocation.Collector
 func (m *mockAllocator) GetTargetsForCollectorAndJob(_ string, _ string) []*target.Item { return nil }
 func (m *mockAllocator) SetFilter(_ allocation.Filter)                                  {}
 func (m *mockAllocator) SetFallbackStrategy(_ allocation.Strategy)                      {}
+func (m *mockAllocator) Changed() <-chan struct{}                                       { return nil }
+func (m *mockAllocator) Assignments() map[target.ItemHash]string                        { return nil }
+func (m *mockAllocator) RestoreAssignments(_ map[target.ItemHash]string)                {}
+func (m *mockAllocator) FollowAssignments(_ map[target.ItemHash]string)                 {}
+func (m *mockAllocator) StopFollowing()                                                 {}
 
 func (m *mockAllocator) TargetItems() map[target.ItemHash]*target.Item {
 	return m.targetItems
@@ -6,10 +6,14 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
 	"net/http"
 	"net/http/pprof"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -37,6 +41,13 @@ var (
 	}, []string{"path"})
 )
 
+const (
+	// resourceVersionHeader holds the version of the targets returned for a collector.
+	resourceVersionHeader = "X-Resource-Version"
+	defaultWatchTimeout   = 30 * time.Second
+	maxWatchTimeout       = 5 * time.Minute
+)
+
 type collectorJSON struct {
 	Link string        `json:"_link"`
 	Jobs []*targetJSON `json:"targets"`
@@ -52,10 +63,18 @@ type targetJSON struct {
 }
 
 type Server struct {
-	logger      logr.Logger
-	allocator   allocation.Allocator
-	server      *http.Server
-	httpsServer *http.Server
+	logger        logr.Logger
+	allocator     allocation.Allocator
+	server        *http.Server
+	httpsServer   *http.Server
+	pprofServer   *http.Server
+	authenticator Authenticator
+	ready         func() bool
+
+	// shown by the debug endpoints
+	strategy         string
+	fallbackStrategy string
+	lastDiscovery    func() time.Time
 
 	// Use RWMutex to protect scrapeConfigResponse, since it
 	// will be predominantly read and only written when config
@@ -78,19 +97,48 @@ func WithTLSConfig(tlsConfig *tls.Config, httpsListenAddr string) Option {
 	}
 }
 
+// WithPprof serves the pprof profiling endpoints on a separate server, which shouldn't be exposed outside the pod.
+func WithPprof(pprofListenAddr string) Option {
+	return func(s *Server) {
+		pprofRouter := gin.New()
+		pprofRouter.Use(gin.Recovery())
+		registerPprof(pprofRouter.Group("/debug/pprof/"))
+
+		s.pprofServer = &http.Server{Addr: pprofListenAddr, Handler: pprofRouter, ReadHeaderTimeout: 90 * time.Second}
+	}
+}
+
+// WithReadinessCheck makes the readiness probe also wait for the given check, in addition to the scrape configs.
+func WithReadinessCheck(ready func() bool) Option {
+	return func(s *Server) {
+		s.ready = ready
+	}
+}
+
+// WithAuthenticator requires the clients of the scrape configs and targets endpoints to authenticate.
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(s *Server) {
+		s.authenticator = authenticator
+	}
+}
+
 func (s *Server) setRouter(router *gin.Engine) {
 	router.Use(gin.Recovery())
 	router.UseRawPath = true
 	router.UnescapePathValues = false
 	router.Use(s.PrometheusMiddleware)
 
-	router.GET("/scrape_configs", s.ScrapeConfigsHandler)
-	router.GET("/jobs", s.JobHandler)
-	router.GET("/jobs/:job_id/targets", s.TargetsHandler)
+	authenticated := router.Group("", s.AuthenticationMiddleware, s.EncodingMiddleware)
+	authenticated.GET("/scrape_configs", s.ScrapeConfigsHandler)
+	authenticated.GET("/jobs", s.JobHandler)
+	authenticated.GET("/jobs/:job_id/targets", s.TargetsHandler)
+	authenticated.GET("/targets/metadata", s.TargetMetadataHandler)
+	authenticated.GET("/debug", s.DebugPageHandler)
+	authenticated.GET("/debug/status", s.DebugStatusHandler)
+	authenticated.GET("/debug/targets", s.DebugTargetsHandler)
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 	router.GET("/livez", s.LivenessProbeHandler)
 	router.GET("/readyz", s.ReadinessProbeHandler)
-	registerPprof(router.Group("/debug/pprof/"))
 }
 
 func NewServer(log logr.Logger, allocator allocation.Allocator, listenAddr string, options ...Option) *Server {
@@ -132,6 +180,16 @@ func (s *Server) ShutdownHTTPS(ctx context.Context) error {
 	return s.httpsServer.Shutdown(ctx)
 }
 
+func (s *Server) StartPprof() error {
+	s.logger.Info("Starting pprof server...")
+	return s.pprofServer.ListenAndServe()
+}
+
+func (s *Server) ShutdownPprof(ctx context.Context) error {
+	s.logger.Info("Shutting down pprof server...")
+	return s.pprofServer.Shutdown(ctx)
+}
+
 // RemoveRegexFromRelabelAction is needed specifically for keepequal/dropequal actions because even though the user doesn't specify the
 // regex field for these actions the unmarshalling implementations of prometheus adds back the default regex fields
 // which in turn causes the receiver to error out since the unmarshaling of the json response doesn't expect anything in the regex fields
@@ -243,21 +301,56 @@ func (s *Server) ReadinessProbeHandler(c *gin.Context) {
 	result := s.scrapeConfigResponse
 	s.mtx.RUnlock()
 
-	if result != nil {
+	if result != nil && (s.ready == nil || s.ready()) {
 		c.Status(http.StatusOK)
 	} else {
 		c.Status(http.StatusServiceUnavailable)
 	}
 }
 
+// JobHandler returns the jobs with targets, only the ones of a tenant with the tenant query parameter.
 func (s *Server) JobHandler(c *gin.Context) {
+	allocator, tenant, ok := s.tenantAllocator(c)
+	if !ok {
+		return
+	}
 	displayData := make(map[string]linkJSON)
-	for _, v := range s.allocator.TargetItems() {
-		displayData[v.JobName] = linkJSON{Link: fmt.Sprintf("/jobs/%s/targets", url.QueryEscape(v.JobName))}
+	for _, v := range allocator.TargetItems() {
+		displayData[v.JobName] = linkJSON{Link: fmt.Sprintf("/jobs/%s/targets%s", url.QueryEscape(v.JobName), tenantQuery(tenant))}
 	}
 	s.jsonHandler(c.Writer, displayData)
 }
 
+// tenantAllocator returns the allocator of the tenant set by the tenant query parameter, or the whole allocator
+// without it. It writes the error response when the tenant isn't known.
+func (s *Server) tenantAllocator(c *gin.Context) (allocation.Allocator, string, bool) {
+	tenant, ok := c.GetQuery("tenant")
+	if !ok {
+		return s.allocator, "", true
+	}
+	tenants, ok := s.allocator.(allocation.TenantAllocator)
+	if !ok {
+		c.Writer.WriteHeader(http.StatusBadRequest)
+		s.jsonHandler(c.Writer, "tenancy is not enabled")
+		return nil, "", false
+	}
+	allocator, ok := tenants.Tenant(tenant)
+	if !ok {
+		c.Writer.WriteHeader(http.StatusNotFound)
+		s.jsonHandler(c.Writer, fmt.Sprintf("unknown tenant %q", tenant))
+		return nil, "", false
+	}
+	return allocator, tenant, true
+}
+
+// tenantQuery returns the query string selecting the tenant in links, if any.
+func tenantQuery(tenant string) string {
+	if tenant == "" {
+		return ""
+	}
+	return "?tenant=" + url.QueryEscape(tenant)
+}
+
 func (s *Server) LivenessProbeHandler(c *gin.Context) {
 	c.Status(http.StatusOK)
 }
@@ -279,11 +372,30 @@ func (s *Server) TargetsHandler(c *gin.Context) {
 		return
 	}
 
+	// the tenant is resolved once, so the whole request only sees the targets of the tenant
+	allocator, _, ok := s.tenantAllocator(c)
+	if !ok {
+		return
+	}
 	if len(q) == 0 {
-		displayData := GetAllTargetsByJob(s.allocator, jobId)
+		displayData := GetAllTargetsByJob(allocator, jobId)
 		s.jsonHandler(c.Writer, displayData)
 	} else {
-		targets := GetAllTargetsByCollectorAndJob(s.allocator, q[0], jobId)
+		items, version, modified, err := s.waitForTargets(c, allocator, q[0], jobId)
+		if err != nil {
+			c.Writer.WriteHeader(http.StatusBadRequest)
+			s.jsonHandler(c.Writer, err.Error())
+			return
+		}
+		c.Header(resourceVersionHeader, version)
+		if !modified {
+			c.Status(http.StatusNotModified)
+			return
+		}
+		targets := make([]*targetJSON, len(items))
+		for i, item := range items {
+			targets[i] = targetJsonFromTargetItem(item)
+		}
 		// Displays empty list if nothing matches
 		if len(targets) == 0 {
 			s.jsonHandler(c.Writer, []interface{}{})
@@ -294,6 +406,55 @@ func (s *Server) TargetsHandler(c *gin.Context) {
 
 }
 
+// waitForTargets returns the targets of the collector for the job, from the allocator of the tenant of the request.
+// When the client passes the resource version of the targets it already has, the request is held until they change or
+// the timeout expires, in which case modified is false.
+func (s *Server) waitForTargets(c *gin.Context, allocator allocation.Allocator, collectorName, jobName string) (items []*target.Item, version string, modified bool, err error) {
+	resourceVersion := c.Query("resource_version")
+	timeout := defaultWatchTimeout
+	if param := c.Query("timeout"); param != "" {
+		if timeout, err = time.ParseDuration(param); err != nil {
+			return nil, "", false, fmt.Errorf("invalid timeout %q: %w", param, err)
+		}
+		timeout = min(timeout, maxWatchTimeout)
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		// get the channel first to not miss changes happening while the targets are read
+		changed := allocator.Changed()
+		items = allocator.GetTargetsForCollectorAndJob(collectorName, jobName)
+		version = targetsVersion(items)
+		if resourceVersion == "" || resourceVersion != version {
+			return items, version, true, nil
+		}
+
+		select {
+		case <-changed:
+		case <-deadline.C:
+			return nil, version, false, nil
+		case <-c.Request.Context().Done():
+			return nil, version, false, nil
+		}
+	}
+}
+
+// targetsVersion identifies a set of targets, independently of their order.
+func targetsVersion(items []*target.Item) string {
+	hashes := make([]uint64, len(items))
+	for i, item := range items {
+		hashes[i] = uint64(item.Hash())
+	}
+	slices.Sort(hashes)
+
+	h := fnv.New64a()
+	for _, hash := range hashes {
+		_ = binary.Write(h, binary.LittleEndian, hash)
+	}
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
 func (s *Server) errorHandler(w http.ResponseWriter, err error) {
 	w.WriteHeader(http.StatusInternalServerError)
 	s.jsonHandler(w, err)
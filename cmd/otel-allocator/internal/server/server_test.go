@@ -8,9 +8,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"testing"
 	"time"
 
@@ -188,6 +190,63 @@ func TestServer_TargetsHandler(t *testing.T) {
 	}
 }
 
+func TestServer_TargetsHandlerLongPoll(t *testing.T) {
+	consistentHashing, _ := allocation.New("consistent-hashing", logger)
+	consistentHashing.SetCollectors(map[string]*allocation.Collector{"test-collector": {Name: "test-collector"}})
+	consistentHashing.SetTargets([]*target.Item{baseTargetItem})
+	s := NewServer(logger, consistentHashing, ":8080")
+
+	get := func(query string) *http.Response {
+		request := httptest.NewRequest("GET", "/jobs/test-job/targets?collector_id=test-collector"+query, nil)
+		w := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(w, request)
+		return w.Result()
+	}
+
+	result := get("")
+	require.Equal(t, http.StatusOK, result.StatusCode)
+	version := result.Header.Get(resourceVersionHeader)
+	require.NotEmpty(t, version)
+
+	t.Run("unchanged targets", func(t *testing.T) {
+		result := get("&resource_version=" + version + "&timeout=50ms")
+		assert.Equal(t, http.StatusNotModified, result.StatusCode)
+		assert.Equal(t, version, result.Header.Get(resourceVersionHeader))
+	})
+
+	t.Run("outdated version", func(t *testing.T) {
+		result := get("&resource_version=outdated&timeout=1m")
+		assert.Equal(t, http.StatusOK, result.StatusCode)
+		assert.Equal(t, version, result.Header.Get(resourceVersionHeader))
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		result := get("&resource_version=" + version + "&timeout=soon")
+		assert.Equal(t, http.StatusBadRequest, result.StatusCode)
+	})
+
+	t.Run("changed targets", func(t *testing.T) {
+		responses := make(chan *http.Response)
+		go func() {
+			responses <- get("&resource_version=" + version + "&timeout=1m")
+		}()
+		// the request is held until the targets change
+		time.Sleep(50 * time.Millisecond)
+		consistentHashing.SetTargets([]*target.Item{baseTargetItem, testJobTargetItemTwo})
+
+		select {
+		case result := <-responses:
+			assert.Equal(t, http.StatusOK, result.StatusCode)
+			assert.NotEqual(t, version, result.Header.Get(resourceVersionHeader))
+			var itemResponse []*targetJSON
+			require.NoError(t, json.NewDecoder(result.Body).Decode(&itemResponse))
+			assert.Len(t, itemResponse, 2)
+		case <-time.After(10 * time.Second):
+			t.Fatal("the request wasn't answered after the targets changed")
+		}
+	})
+}
+
 func TestServer_ScrapeConfigsHandler(t *testing.T) {
 	svrConfig := allocatorconfig.HTTPSServerConfig{}
 	tlsConfig, _ := svrConfig.NewTLSConfig()
@@ -610,6 +669,67 @@ func TestServer_JobHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_JobHandlerTenant(t *testing.T) {
+	tenantLabel := "__meta_kubernetes_namespace"
+	allocator, err := allocation.NewWithTenants("consistent-hashing", logger, tenantLabel, nil)
+	require.NoError(t, err)
+	allocator.SetCollectors(map[string]*allocation.Collector{
+		"collector-a": {Name: "collector-a", Tenant: "team-a"},
+		"collector-b": {Name: "collector-b", Tenant: "team-b"},
+	})
+	allocator.SetTargets([]*target.Item{
+		target.NewItem("job-a", "url-a", labels.FromStrings(tenantLabel, "team-a"), ""),
+		target.NewItem("job-b", "url-b", labels.FromStrings(tenantLabel, "team-b"), ""),
+	})
+	s := NewServer(logger, allocator, ":8080")
+
+	get := func(path string) (int, []byte) {
+		request := httptest.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(w, request)
+		bodyBytes, readErr := io.ReadAll(w.Result().Body)
+		require.NoError(t, readErr)
+		return w.Result().StatusCode, bodyBytes
+	}
+
+	code, body := get("/jobs?tenant=team-a")
+	require.Equal(t, http.StatusOK, code)
+	jobs := map[string]linkJSON{}
+	require.NoError(t, json.Unmarshal(body, &jobs))
+	assert.Equal(t, map[string]linkJSON{"job-a": {Link: "/jobs/job-a/targets?tenant=team-a"}}, jobs)
+
+	code, body = get("/jobs/job-b/targets?tenant=team-a")
+	require.Equal(t, http.StatusOK, code)
+	targets := map[string]collectorJSON{}
+	require.NoError(t, json.Unmarshal(body, &targets))
+	assert.Equal(t, []string{"collector-a"}, slices.Collect(maps.Keys(targets)))
+	assert.Empty(t, targets["collector-a"].Jobs)
+
+	// the targets of a collector of another tenant aren't served for the tenant of the request
+	code, body = get("/jobs/job-b/targets?tenant=team-a&collector_id=collector-b")
+	require.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, "[]", string(body))
+	code, body = get("/jobs/job-b/targets?tenant=team-b&collector_id=collector-b")
+	require.Equal(t, http.StatusOK, code)
+	var collectorTargets []*targetJSON
+	require.NoError(t, json.Unmarshal(body, &collectorTargets))
+	assert.Len(t, collectorTargets, 1)
+
+	code, body = get("/jobs")
+	require.Equal(t, http.StatusOK, code)
+	jobs = map[string]linkJSON{}
+	require.NoError(t, json.Unmarshal(body, &jobs))
+	assert.Len(t, jobs, 2)
+
+	code, _ = get("/jobs?tenant=team-c")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	s = NewServer(logger, &mockAllocator{}, ":8080")
+	code, _ = get("/jobs?tenant=team-a")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
 func TestServer_Readiness(t *testing.T) {
 	tests := []struct {
 		description   string
@@ -674,6 +794,24 @@ func TestServer_Readiness(t *testing.T) {
 	}
 }
 
+func TestServer_ReadinessCheck(t *testing.T) {
+	ready := false
+	s := NewServer(logger, nil, ":8080", WithReadinessCheck(func() bool {
+		return ready
+	}))
+	require.NoError(t, s.UpdateScrapeConfigResponse(map[string]*promconfig.ScrapeConfig{}))
+
+	probe := func() int {
+		request := httptest.NewRequest("GET", "/readyz", nil)
+		w := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(w, request)
+		return w.Result().StatusCode
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, probe())
+	ready = true
+	assert.Equal(t, http.StatusOK, probe())
+}
+
 func TestServer_ScrapeConfigRespose(t *testing.T) {
 	tests := []struct {
 		description  string
@@ -734,3 +872,21 @@ func TestServer_ScrapeConfigRespose(t *testing.T) {
 func newLink(jobName string) linkJSON {
 	return linkJSON{Link: fmt.Sprintf("/jobs/%s/targets", url.QueryEscape(jobName))}
 }
+
+func TestServer_Pprof(t *testing.T) {
+	s := NewServer(logger, &mockAllocator{}, ":8080")
+	assert.Nil(t, s.pprofServer)
+	request := httptest.NewRequest("GET", "/debug/pprof/", nil)
+	w := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(w, request)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	s = NewServer(logger, &mockAllocator{}, ":8080", WithPprof("localhost:6060"))
+	require.NotNil(t, s.pprofServer)
+	assert.Equal(t, "localhost:6060", s.pprofServer.Addr)
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/heap"} {
+		w = httptest.NewRecorder()
+		s.pprofServer.Handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		assert.Equal(t, http.StatusOK, w.Code, path)
+	}
+}
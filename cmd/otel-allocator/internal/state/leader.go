@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/allocation"
+)
+
+const (
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+)
+
+// Follow makes the allocator serve the assignments saved by the leader, loading them right away and then once per
+// interval, until the context is done. The replica doesn't assign the targets itself meanwhile, the targets the leader
+// hasn't saved yet are left unassigned.
+func Follow(ctx context.Context, logger logr.Logger, store Store, allocator allocation.Allocator, interval time.Duration) {
+	allocator.FollowAssignments(nil)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		assignments, err := store.Load(ctx)
+		if err != nil {
+			logger.Error(err, "Failed to load the target assignments of the leader")
+		} else {
+			allocator.FollowAssignments(assignments)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// RunWithLeaderElection takes part in the election of the leader with the given Lease. The leader assigns the targets
+// and checkpoints the assignments, while the other replicas serve the ones it saved, until the context is done.
+func RunWithLeaderElection(ctx context.Context, logger logr.Logger, client kubernetes.Interface, namespace, leaseName, identity string, store Store, allocator allocation.Allocator, interval time.Duration) error {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Name: leaseName, Namespace: namespace},
+		Client:     client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+	for {
+		followCtx, cancelFollow := context.WithCancel(ctx)
+		followDone := make(chan struct{})
+		go func() {
+			defer close(followDone)
+			Follow(followCtx, logger, store, allocator, interval)
+		}()
+		// the leader assigns the targets itself, starting from the assignments it followed
+		stopFollowing := func() {
+			cancelFollow()
+			<-followDone
+			allocator.StopFollowing()
+		}
+
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			LeaseDuration:   leaseDuration,
+			RenewDeadline:   renewDeadline,
+			RetryPeriod:     retryPeriod,
+			ReleaseOnCancel: true,
+			Name:            leaseName,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(leaderCtx context.Context) {
+					logger.Info("Started leading, saving the target assignments", "identity", identity)
+					stopFollowing()
+					Checkpoint(leaderCtx, logger, store, allocator, interval)
+				},
+				OnStoppedLeading: func() {
+					logger.Info("Stopped leading", "identity", identity)
+				},
+			},
+		})
+		if err != nil {
+			stopFollowing()
+			return err
+		}
+		// Run returns when the context is done or the leadership is lost, in which case the replica follows again
+		elector.Run(ctx)
+		stopFollowing()
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+func TestFollow(t *testing.T) {
+	allocator, err := allocation.New("least-weighted", logger)
+	require.NoError(t, err)
+	allocator.SetCollectors(allocation.MakeNCollectors(3, 0))
+	targets := allocation.MakeNNewTargets(5, 3, 0)
+	allocator.SetTargets(targets)
+
+	// the targets the leader hasn't saved yet aren't served
+	leaderAssignments := map[target.ItemHash]string{}
+	for _, item := range targets[:3] {
+		leaderAssignments[item.Hash()] = "collector-2"
+	}
+	store := &memoryStore{loaded: leaderAssignments}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go Follow(ctx, logger, store, allocator, 10*time.Millisecond)
+
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(leaderAssignments, allocator.Assignments())
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, store.saves())
+}
+
+func TestRunWithLeaderElection(t *testing.T) {
+	allocator, err := allocation.New("consistent-hashing", logger)
+	require.NoError(t, err)
+	allocator.SetCollectors(allocation.MakeNCollectors(3, 0))
+	client := fake.NewSimpleClientset()
+	store := &memoryStore{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- RunWithLeaderElection(ctx, logger, client, "default", "test-targetallocator", "replica-0", store, allocator, 10*time.Millisecond)
+	}()
+
+	// the only replica becomes the leader, and saves the assignments
+	require.Eventually(t, func() bool {
+		lease, getErr := client.CoordinationV1().Leases("default").Get(ctx, "test-targetallocator", metav1.GetOptions{})
+		return getErr == nil && lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == "replica-0"
+	}, 5*time.Second, 10*time.Millisecond)
+	allocator.SetTargets(allocation.MakeNNewTargets(5, 3, 0))
+	require.Eventually(t, func() bool { return store.saves() > 0 }, 5*time.Second, 10*time.Millisecond)
+	// the leader assigns the targets itself
+	assert.Len(t, allocator.Assignments(), 5)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
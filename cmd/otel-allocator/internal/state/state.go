@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package state checkpoints the target assignments of the allocator, so they can be restored after a restart
+// instead of reshuffling the targets between the collectors.
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+const (
+	// assignmentsKey is the ConfigMap binary data key holding the assignments.
+	assignmentsKey = "assignments.json.gz"
+	// shutdownSaveTimeout bounds the last checkpoint, made when the allocator stops.
+	shutdownSaveTimeout = 5 * time.Second
+	// LoadTimeout bounds the restoration of the assignments when the allocator starts, which doesn't wait for an
+	// unavailable store.
+	LoadTimeout = 10 * time.Second
+	// maxStateSize bounds the size of the saved assignments, below the 1MiB limit of the ConfigMaps.
+	maxStateSize = 1000 << 10
+	// maxDecodedStateSize bounds the size of the decompressed assignments.
+	maxDecodedStateSize = 64 << 20
+)
+
+// Store persists the target assignments, the names of the collectors by target hash.
+type Store interface {
+	// Load returns the saved assignments, which are empty if nothing was saved yet.
+	Load(ctx context.Context) (map[target.ItemHash]string, error)
+	Save(ctx context.Context, assignments map[target.ItemHash]string) error
+}
+
+var (
+	_ Store = &FileStore{}
+	_ Store = &ConfigMapStore{}
+)
+
+// FileStore saves the assignments to a file, which should be on a persistent volume.
+type FileStore struct {
+	path string
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (f *FileStore) Load(_ context.Context) (map[target.ItemHash]string, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[target.ItemHash]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decode(data)
+}
+
+func (f *FileStore) Save(_ context.Context, assignments map[target.ItemHash]string) error {
+	data, err := encode(assignments)
+	if err != nil {
+		return err
+	}
+	// write to a temporary file first, so a crash doesn't leave a truncated file behind
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// ConfigMapStore saves the assignments to a ConfigMap.
+type ConfigMapStore struct {
+	client    kubernetes.Interface
+	namespace string
+	name      string
+}
+
+func NewConfigMapStore(client kubernetes.Interface, namespace, name string) *ConfigMapStore {
+	return &ConfigMapStore{client: client, namespace: namespace, name: name}
+}
+
+func (c *ConfigMapStore) Load(ctx context.Context) (map[target.ItemHash]string, error) {
+	configMap, err := c.client.CoreV1().ConfigMaps(c.namespace).Get(ctx, c.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return map[target.ItemHash]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, ok := configMap.BinaryData[assignmentsKey]
+	if !ok {
+		return map[target.ItemHash]string{}, nil
+	}
+	return decode(data)
+}
+
+func (c *ConfigMapStore) Save(ctx context.Context, assignments map[target.ItemHash]string) error {
+	data, err := encode(assignments)
+	if err != nil {
+		return err
+	}
+	configMaps := c.client.CoreV1().ConfigMaps(c.namespace)
+	configMap, err := configMaps.Get(ctx, c.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(ctx, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: c.name, Namespace: c.namespace},
+			BinaryData: map[string][]byte{assignmentsKey: data},
+		}, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if configMap.BinaryData == nil {
+		configMap.BinaryData = map[string][]byte{}
+	}
+	configMap.BinaryData[assignmentsKey] = data
+	_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+	return err
+}
+
+// Checkpoint saves the assignments of the allocator at most once per interval, when they changed, and a last time
+// when the context is done.
+func Checkpoint(ctx context.Context, logger logr.Logger, store Store, allocator allocation.Allocator, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	changed := allocator.Changed()
+	dirty := false
+	save := func(ctx context.Context) {
+		if err := store.Save(ctx, allocator.Assignments()); err != nil {
+			logger.Error(err, "Failed to save the target assignments")
+			return
+		}
+		dirty = false
+	}
+	for {
+		select {
+		case <-changed:
+			changed = allocator.Changed()
+			dirty = true
+		case <-ticker.C:
+			if dirty {
+				save(ctx)
+			}
+		case <-ctx.Done():
+			select {
+			case <-changed:
+				dirty = true
+			default:
+			}
+			if dirty {
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownSaveTimeout)
+				save(shutdownCtx)
+				cancel()
+			}
+			return
+		}
+	}
+}
+
+func encode(assignments map[target.ItemHash]string) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(gz).Encode(assignments); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	if buf.Len() > maxStateSize {
+		return nil, fmt.Errorf("the assignments of %d targets take %d bytes, more than the %d bytes limit", len(assignments), buf.Len(), maxStateSize)
+	}
+	return buf.Bytes(), nil
+}
+
+// decode reads the saved assignments, discarding the entries without a collector.
+func decode(data []byte) (map[target.ItemHash]string, error) {
+	if len(data) > maxStateSize {
+		return nil, fmt.Errorf("the saved assignments take %d bytes, more than the %d bytes limit", len(data), maxStateSize)
+	}
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	content, err := io.ReadAll(io.LimitReader(gz, maxDecodedStateSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxDecodedStateSize {
+		return nil, fmt.Errorf("the saved assignments take more than %d bytes once decompressed", maxDecodedStateSize)
+	}
+	assignments := map[target.ItemHash]string{}
+	if err = json.Unmarshal(content, &assignments); err != nil {
+		return nil, err
+	}
+	maps.DeleteFunc(assignments, func(_ target.ItemHash, collector string) bool {
+		return collector == ""
+	})
+	return assignments, nil
+}
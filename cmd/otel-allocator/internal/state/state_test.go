@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package state
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/allocation"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
+)
+
+var (
+	logger      = logf.Log.WithName("state-unit-tests")
+	assignments = map[target.ItemHash]string{
+		1:         "collector-0",
+		2:         "collector-1",
+		1<<64 - 1: "collector-1",
+	}
+)
+
+func TestFileStore(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "assignments"))
+
+	loaded, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+
+	require.NoError(t, store.Save(context.Background(), assignments))
+	loaded, err = store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, assignments, loaded)
+}
+
+func TestConfigMapStore(t *testing.T) {
+	store := NewConfigMapStore(fake.NewSimpleClientset(), "default", "test-state")
+
+	loaded, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+
+	// the first save creates the config map, the next ones update it
+	require.NoError(t, store.Save(context.Background(), map[target.ItemHash]string{3: "collector-2"}))
+	require.NoError(t, store.Save(context.Background(), assignments))
+	loaded, err = store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, assignments, loaded)
+}
+
+// memoryStore records the saved assignments, and loads the given ones.
+type memoryStore struct {
+	mu     sync.Mutex
+	loaded map[target.ItemHash]string
+	saved  []map[target.ItemHash]string
+}
+
+func (m *memoryStore) Load(_ context.Context) (map[target.ItemHash]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.loaded == nil {
+		return map[target.ItemHash]string{}, nil
+	}
+	return m.loaded, nil
+}
+
+func (m *memoryStore) Save(_ context.Context, assignments map[target.ItemHash]string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saved = append(m.saved, assignments)
+	return nil
+}
+
+func (m *memoryStore) saves() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.saved)
+}
+
+func TestCheckpoint(t *testing.T) {
+	allocator, err := allocation.New("consistent-hashing", logger)
+	require.NoError(t, err)
+	allocator.SetCollectors(allocation.MakeNCollectors(3, 0))
+	store := &memoryStore{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		Checkpoint(ctx, logger, store, allocator, 10*time.Millisecond)
+		close(done)
+	}()
+
+	// nothing changed, nothing is saved
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, 0, store.saves())
+
+	allocator.SetTargets(allocation.MakeNNewTargets(5, 3, 0))
+	require.Eventually(t, func() bool { return store.saves() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, allocator.Assignments(), store.saved[0])
+
+	// the last changes are saved on shutdown
+	allocator.SetTargets(allocation.MakeNNewTargets(10, 3, 0))
+	cancel()
+	<-done
+	assert.Equal(t, allocator.Assignments(), store.saved[len(store.saved)-1])
+}
+
+func TestStateSize(t *testing.T) {
+	// the collector names don't compress well enough to fit the limit
+	large := map[target.ItemHash]string{}
+	for i := 0; i < 100000; i++ {
+		large[target.ItemHash(i)] = fmt.Sprintf("collector-%x", sha256.Sum256([]byte(strconv.Itoa(i))))
+	}
+	_, err := encode(large)
+	assert.ErrorContains(t, err, "more than the 1024000 bytes limit")
+
+	_, err = decode(make([]byte, maxStateSize+1))
+	assert.Error(t, err)
+}
+
+func TestDecodeDiscardsEmptyCollectors(t *testing.T) {
+	data, err := encode(map[target.ItemHash]string{1: "collector-0", 2: ""})
+	require.NoError(t, err)
+	loaded, err := decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, map[target.ItemHash]string{1: "collector-0"}, loaded)
+}
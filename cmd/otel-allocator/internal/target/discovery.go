@@ -43,6 +43,12 @@ var (
 		Help:    "Duration of processing target groups.",
 		Buckets: []float64{1, 5, 10, 30, 60, 120},
 	}, []string{"job_name"})
+
+	discoveryLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "opentelemetry_allocator_discovery_latency_seconds",
+		Help:    "Time between receiving updated target groups from service discovery and handing the targets to the allocator.",
+		Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120},
+	})
 )
 
 type Discoverer struct {
@@ -57,6 +63,11 @@ type Discoverer struct {
 	targetSets             map[string][]*targetgroup.Group
 	triggerReload          chan struct{}
 	processTargetsCallBack func(targets []*Item)
+
+	// updatedAt is when the oldest target set update not processed yet was received, guarded by mtxScrape.
+	updatedAt time.Time
+	// reloadedAt is when the targets were last handed to the allocator, guarded by mtxScrape.
+	reloadedAt time.Time
 }
 
 type discoveryHook interface {
@@ -132,6 +143,9 @@ func (m *Discoverer) Run() error {
 func (m *Discoverer) UpdateTsets(tsets map[string][]*targetgroup.Group) {
 	m.mtxScrape.Lock()
 	m.targetSets = tsets
+	if m.updatedAt.IsZero() {
+		m.updatedAt = time.Now()
+	}
 	m.mtxScrape.Unlock()
 }
 
@@ -188,9 +202,25 @@ func (m *Discoverer) Reload() {
 			targetsAssigned += len(group.Targets)
 		}
 	}
+	updatedAt := m.updatedAt
+	m.updatedAt = time.Time{}
 	m.mtxScrape.Unlock()
 	wg.Wait()
 	m.processTargetsCallBack(targets)
+	if !updatedAt.IsZero() {
+		discoveryLatency.Observe(time.Since(updatedAt).Seconds())
+	}
+	m.mtxScrape.Lock()
+	m.reloadedAt = time.Now()
+	m.mtxScrape.Unlock()
+}
+
+// LastReload returns when the discovered targets were last handed to the allocator, or the zero time if they
+// weren't yet.
+func (m *Discoverer) LastReload() time.Time {
+	m.mtxScrape.Lock()
+	defer m.mtxScrape.Unlock()
+	return m.reloadedAt
 }
 
 // processTargetGroups processes the target groups and returns a map of targets.
@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"hash"
+	"net/http"
+	"net/http/httptest"
 	"sort"
 	"testing"
 	"time"
@@ -16,6 +18,8 @@ import (
 	"github.com/prometheus/common/model"
 	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/discovery"
+	promhttp "github.com/prometheus/prometheus/discovery/http"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
 	"github.com/prometheus/prometheus/model/relabel"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -361,6 +365,65 @@ func TestDiscovery_NoConfig(t *testing.T) {
 	assert.Equal(t, expectedScrapeConfigs, scu.mockCfg)
 }
 
+func TestDiscovery_HTTPSD(t *testing.T) {
+	sdServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"targets": ["vm-1.domain:9100", "vm-2.domain:9100"], "labels": {"env": "vm"}}]`))
+	}))
+	defer sdServer.Close()
+
+	scu := &mockScrapeConfigUpdater{}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	registry := prometheus.NewRegistry()
+	sdMetrics, err := discovery.CreateAndRegisterSDMetrics(registry)
+	require.NoError(t, err)
+	d := discovery.NewManager(ctx, config.NopLogger, registry, sdMetrics)
+	results := make(chan []*Item)
+	manager := NewDiscoverer(ctrl.Log.WithName("test"), d, nil, scu, func(targets []*Item) {
+		results <- targets
+	})
+	defer func() { manager.Close() }()
+	defer cancelFunc()
+
+	go func() {
+		err := d.Run()
+		assert.Error(t, err)
+	}()
+	go func() {
+		err := manager.Run()
+		assert.NoError(t, err)
+	}()
+
+	// targets discovered over HTTP are allocated like the other ones
+	err = manager.ApplyConfig(allocatorWatcher.EventSourceConfigMap, []*promconfig.ScrapeConfig{
+		{
+			JobName: "vms",
+			ServiceDiscoveryConfigs: discovery.Configs{
+				&promhttp.SDConfig{
+					URL:              sdServer.URL,
+					RefreshInterval:  model.Duration(time.Minute),
+					HTTPClientConfig: commonconfig.DefaultHTTPClientConfig,
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	select {
+	case targets := <-results:
+		var urls []string
+		for _, item := range targets {
+			assert.Equal(t, "vms", item.JobName)
+			assert.Equal(t, "vm", item.Labels.Get("env"))
+			urls = append(urls, item.TargetURL)
+		}
+		sort.Strings(urls)
+		assert.Equal(t, []string{"vm-1.domain:9100", "vm-2.domain:9100"}, urls)
+	case <-time.After(30 * time.Second):
+		t.Fatal("no targets were discovered")
+	}
+}
+
 func BenchmarkApplyScrapeConfig(b *testing.B) {
 	numConfigs := 1000
 	scrapeConfig := promconfig.ScrapeConfig{
@@ -424,3 +487,33 @@ func (m *mockScrapeConfigUpdater) UpdateScrapeConfigResponse(cfg map[string]*pro
 	m.mockCfg = cfg
 	return nil
 }
+
+func TestDiscovery_Latency(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(discoveryLatency)
+	sampleCount := func() uint64 {
+		families, err := registry.Gather()
+		require.NoError(t, err)
+		require.Len(t, families, 1)
+		return families[0].GetMetric()[0].GetHistogram().GetSampleCount()
+	}
+	var targets []*Item
+	manager := NewDiscoverer(ctrl.Log.WithName("test"), nil, nil, nil, func(items []*Item) {
+		targets = items
+	})
+	before := sampleCount()
+
+	// reloading without updates doesn't observe anything
+	manager.Reload()
+	assert.Equal(t, before, sampleCount())
+
+	// successive updates are observed once, from the first one
+	for i := 0; i < 2; i++ {
+		manager.UpdateTsets(map[string][]*targetgroup.Group{
+			"job": {{Targets: []model.LabelSet{{model.AddressLabel: "localhost:9090"}}}},
+		})
+	}
+	manager.Reload()
+	assert.Len(t, targets, 1)
+	assert.Equal(t, before+1, sampleCount())
+}
@@ -19,6 +19,12 @@ var (
 	endpointSliceTargetKindLabel = "__meta_kubernetes_endpointslice_address_target_kind"
 	endpointSliceTargetNameLabel = "__meta_kubernetes_endpointslice_address_target_name"
 	relevantLabelNames           = append(nodeLabels, endpointSliceTargetKindLabel, endpointSliceTargetNameLabel)
+
+	// zoneLabels are labels that are used to identify the topology zone of the given target.
+	zoneLabels = []string{
+		"__meta_kubernetes_endpointslice_endpoint_zone",
+		"__meta_kubernetes_node_label_topology_kubernetes_io_zone",
+	}
 )
 
 type ItemHash uint64
@@ -53,6 +59,17 @@ func (t *Item) GetNodeName() string {
 	return relevantLabels.Get(endpointSliceTargetNameLabel)
 }
 
+// GetZone returns the topology zone of the target, if its labels provide it.
+func (t *Item) GetZone() string {
+	relevantLabels := t.Labels.MatchLabels(true, zoneLabels...)
+	for _, label := range zoneLabels {
+		if val := relevantLabels.Get(label); val != "" {
+			return val
+		}
+	}
+	return ""
+}
+
 // NewItem Creates a new target item.
 // INVARIANTS:
 // * Item fields must not be modified after creation.
@@ -57,7 +57,7 @@ func NewPrometheusCRWatcher(ctx context.Context, logger logr.Logger, cfg allocat
 
 	factory := informers.NewMonitoringInformerFactories(allowList, denyList, mClient, allocatorconfig.DefaultResyncTime, nil)
 
-	monitoringInformers, err := getInformers(factory)
+	monitoringInformers, err := getInformers(factory, cfg.PrometheusCR.ProbesEnabled, cfg.PrometheusCR.ScrapeConfigsEnabled)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +89,8 @@ func NewPrometheusCRWatcher(ctx context.Context, logger logr.Logger, cfg allocat
 		},
 	}
 
-	generator, err := prometheus.NewConfigGenerator(promLogger, prom, prometheus.WithEndpointSliceSupport())
+	// the collectors don't mount the TLS assets of the monitors, so the certificates and keys are served inline
+	generator, err := prometheus.NewConfigGenerator(promLogger, prom, prometheus.WithEndpointSliceSupport(), prometheus.WithInlineTLSConfig())
 
 	if err != nil {
 		return nil, err
@@ -188,8 +189,9 @@ func getNamespaceInformer(ctx context.Context, allowList, denyList map[string]st
 
 }
 
-// getInformers returns a map of informers for the given resources.
-func getInformers(factory informers.FactoriesForNamespaces) (map[string]*informers.ForResource, error) {
+// getInformers returns a map of informers for the given resources. The Probe and ScrapeConfig informers are
+// only created when enabled, as older Prometheus operator installations don't serve these resources.
+func getInformers(factory informers.FactoriesForNamespaces, probes, scrapeConfigs bool) (map[string]*informers.ForResource, error) {
 	serviceMonitorInformers, err := informers.NewInformersForResource(factory, monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.ServiceMonitorName))
 	if err != nil {
 		return nil, err
@@ -200,22 +202,28 @@ func getInformers(factory informers.FactoriesForNamespaces) (map[string]*informe
 		return nil, err
 	}
 
-	probeInformers, err := informers.NewInformersForResource(factory, monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.ProbeName))
-	if err != nil {
-		return nil, err
+	monitoringInformers := map[string]*informers.ForResource{
+		monitoringv1.ServiceMonitorName: serviceMonitorInformers,
+		monitoringv1.PodMonitorName:     podMonitorInformers,
 	}
 
-	scrapeConfigInformers, err := informers.NewInformersForResource(factory, promv1alpha1.SchemeGroupVersion.WithResource(promv1alpha1.ScrapeConfigName))
-	if err != nil {
-		return nil, err
+	if probes {
+		probeInformers, err := informers.NewInformersForResource(factory, monitoringv1.SchemeGroupVersion.WithResource(monitoringv1.ProbeName))
+		if err != nil {
+			return nil, err
+		}
+		monitoringInformers[monitoringv1.ProbeName] = probeInformers
 	}
 
-	return map[string]*informers.ForResource{
-		monitoringv1.ServiceMonitorName: serviceMonitorInformers,
-		monitoringv1.PodMonitorName:     podMonitorInformers,
-		monitoringv1.ProbeName:          probeInformers,
-		promv1alpha1.ScrapeConfigName:   scrapeConfigInformers,
-	}, nil
+	if scrapeConfigs {
+		scrapeConfigInformers, err := informers.NewInformersForResource(factory, promv1alpha1.SchemeGroupVersion.WithResource(promv1alpha1.ScrapeConfigName))
+		if err != nil {
+			return nil, err
+		}
+		monitoringInformers[promv1alpha1.ScrapeConfigName] = scrapeConfigInformers
+	}
+
+	return monitoringInformers, nil
 }
 
 // Watch wrapped informers and wait for an initial sync.
@@ -361,14 +369,20 @@ func (w *PrometheusCRWatcher) LoadConfig(ctx context.Context) (*promconfig.Confi
 			return nil, err
 		}
 
-		probeInstances, err := w.resourceSelector.SelectProbes(ctx, w.informers[monitoringv1.ProbeName].ListAllByNamespace)
-		if err != nil {
-			return nil, err
+		var probeInstances map[string]*monitoringv1.Probe
+		if probeInformers, ok := w.informers[monitoringv1.ProbeName]; ok {
+			probeInstances, err = w.resourceSelector.SelectProbes(ctx, probeInformers.ListAllByNamespace)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		scrapeConfigInstances, err := w.resourceSelector.SelectScrapeConfigs(ctx, w.informers[promv1alpha1.ScrapeConfigName].ListAllByNamespace)
-		if err != nil {
-			return nil, err
+		var scrapeConfigInstances map[string]*promv1alpha1.ScrapeConfig
+		if scrapeConfigInformers, ok := w.informers[promv1alpha1.ScrapeConfigName]; ok {
+			scrapeConfigInstances, err = w.resourceSelector.SelectScrapeConfigs(ctx, scrapeConfigInformers.ListAllByNamespace)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		generatedConfig, err := w.configGenerator.GenerateServerConfiguration(
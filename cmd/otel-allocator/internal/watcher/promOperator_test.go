@@ -39,6 +39,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	namespace := "test"
 	portName := "web"
+	caCert, err := os.ReadFile("testdata/ca.crt")
+	require.NoError(t, err)
 	tests := []struct {
 		name            string
 		serviceMonitors []*monitoringv1.ServiceMonitor
@@ -216,6 +218,82 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "tls ca (serviceMonitor)",
+			serviceMonitors: []*monitoringv1.ServiceMonitor{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "tls",
+						Namespace: namespace,
+					},
+					Spec: monitoringv1.ServiceMonitorSpec{
+						JobLabel: "tls",
+						Endpoints: []monitoringv1.Endpoint{
+							{
+								Port:   portName,
+								Scheme: "https",
+								TLSConfig: &monitoringv1.TLSConfig{
+									SafeTLSConfig: monitoringv1.SafeTLSConfig{
+										CA: monitoringv1.SecretOrConfigMap{
+											ConfigMap: &v1.ConfigMapKeySelector{
+												LocalObjectReference: v1.LocalObjectReference{
+													Name: "ca",
+												},
+												Key: "ca.crt",
+											},
+										},
+									},
+								},
+							},
+						},
+						Selector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app": "tls",
+							},
+						},
+					},
+				},
+			},
+			cfg: allocatorconfig.Config{
+				PrometheusCR: allocatorconfig.PrometheusCRConfig{
+					ServiceMonitorSelector: &metav1.LabelSelector{},
+					PodMonitorSelector:     &metav1.LabelSelector{},
+				},
+			},
+			want: &promconfig.Config{
+				GlobalConfig: promconfig.GlobalConfig{},
+				ScrapeConfigs: []*promconfig.ScrapeConfig{
+					{
+						JobName:         "serviceMonitor/test/tls/0",
+						ScrapeInterval:  model.Duration(30 * time.Second),
+						ScrapeProtocols: promconfig.DefaultScrapeProtocols,
+						ScrapeTimeout:   model.Duration(10 * time.Second),
+						HonorTimestamps: true,
+						HonorLabels:     false,
+						Scheme:          "https",
+						MetricsPath:     "/metrics",
+						ServiceDiscoveryConfigs: []discovery.Config{
+							&kubeDiscovery.SDConfig{
+								Role: "endpointslice",
+								NamespaceDiscovery: kubeDiscovery.NamespaceDiscovery{
+									Names:               []string{namespace},
+									IncludeOwnNamespace: false,
+								},
+								HTTPClientConfig: config.DefaultHTTPClientConfig,
+							},
+						},
+						HTTPClientConfig: config.HTTPClientConfig{
+							FollowRedirects: true,
+							EnableHTTP2:     true,
+							TLSConfig: config.TLSConfig{
+								CA: string(caCert),
+							},
+						},
+						EnableCompression: true,
+					},
+				},
+			},
+		},
 		{
 			name: "bearer token (podMonitor)",
 			podMonitors: []*monitoringv1.PodMonitor{
@@ -1218,9 +1296,23 @@ func getTestPrometheusCRWatcher(
 	if err != nil {
 		t.Fatal(t, err)
 	}
+	caCert, err := os.ReadFile("testdata/ca.crt")
+	if err != nil {
+		t.Fatal(t, err)
+	}
+	_, err = k8sClient.CoreV1().ConfigMaps("test").Create(context.Background(), &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ca",
+			Namespace: "test",
+		},
+		Data: map[string]string{"ca.crt": string(caCert)},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(t, err)
+	}
 
 	factory := informers.NewMonitoringInformerFactories(map[string]struct{}{v1.NamespaceAll: {}}, map[string]struct{}{}, mClient, 0, nil)
-	informers, err := getInformers(factory)
+	informers, err := getInformers(factory, true, true)
 	if err != nil {
 		t.Fatal(t, err)
 	}
@@ -1251,7 +1343,7 @@ func getTestPrometheusCRWatcher(
 
 	promOperatorLogger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
 
-	generator, err := prometheus.NewConfigGenerator(promOperatorLogger, prom, prometheus.WithEndpointSliceSupport())
+	generator, err := prometheus.NewConfigGenerator(promOperatorLogger, prom, prometheus.WithEndpointSliceSupport(), prometheus.WithInlineTLSConfig())
 	if err != nil {
 		t.Fatal(t, err)
 	}
@@ -1303,3 +1395,18 @@ func sanitizeScrapeConfigsForTest(scs []*promconfig.ScrapeConfig) {
 		sc.MetricRelabelConfigs = nil
 	}
 }
+
+func TestGetInformers(t *testing.T) {
+	factory := informers.NewMonitoringInformerFactories(map[string]struct{}{v1.NamespaceAll: {}}, map[string]struct{}{}, fakemonitoringclient.NewSimpleClientset(), 0, nil)
+
+	monitoringInformers, err := getInformers(factory, true, true)
+	require.NoError(t, err)
+	assert.Len(t, monitoringInformers, 4)
+
+	// resources which aren't served by the cluster don't get an informer
+	monitoringInformers, err = getInformers(factory, false, false)
+	require.NoError(t, err)
+	assert.Len(t, monitoringInformers, 2)
+	assert.Contains(t, monitoringInformers, monitoringv1.ServiceMonitorName)
+	assert.Contains(t, monitoringInformers, monitoringv1.PodMonitorName)
+}
@@ -9,11 +9,13 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/oklog/run"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/prometheus/discovery"
+	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -22,6 +24,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/prehook"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/server"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/state"
 	"github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/target"
 	allocatorWatcher "github.com/open-telemetry/opentelemetry-operator/cmd/otel-allocator/internal/watcher"
 )
@@ -69,12 +72,60 @@ func main() {
 	log := ctrl.Log.WithName("allocator")
 
 	allocatorPrehook = prehook.New(cfg.FilterStrategy, log)
-	allocator, err = allocation.New(cfg.AllocationStrategy, log, allocation.WithFilter(allocatorPrehook), allocation.WithFallbackStrategy(cfg.AllocationFallbackStrategy))
+	if len(cfg.Filter.Keep) > 0 || len(cfg.Filter.Drop) > 0 {
+		allocatorPrehook, err = prehook.WithSelectors(log, allocatorPrehook, cfg.Filter.Keep, cfg.Filter.Drop)
+		if err != nil {
+			setupLog.Error(err, "Unable to initialize the target filter")
+			os.Exit(1)
+		}
+	}
+	err = allocation.ConfigureConsistentHashing(allocation.ConsistentHashingConfig{
+		PartitionCount:    cfg.ConsistentHashing.PartitionCount,
+		ReplicationFactor: cfg.ConsistentHashing.ReplicationFactor,
+		Hash:              cfg.ConsistentHashing.Hash,
+	})
+	if err != nil {
+		setupLog.Error(err, "Unable to configure consistent hashing")
+		os.Exit(1)
+	}
+	allocatorOptions := []allocation.Option{allocation.WithFilter(allocatorPrehook), allocation.WithFallbackStrategy(cfg.AllocationFallbackStrategy)}
+	if cfg.Tenancy.Enabled {
+		allocator, err = allocation.NewWithTenants(cfg.AllocationStrategy, log, cfg.Tenancy.Label, cfg.Tenancy.AllocationStrategies, allocatorOptions...)
+	} else {
+		allocator, err = allocation.New(cfg.AllocationStrategy, log, allocatorOptions...)
+	}
 	if err != nil {
 		setupLog.Error(err, "Unable to initialize allocation strategy")
 		os.Exit(1)
 	}
 
+	var (
+		stateStore  state.Store
+		stateClient kubernetes.Interface
+	)
+	switch cfg.State.Backend {
+	case config.StateBackendFile:
+		stateStore = state.NewFileStore(cfg.State.Path)
+	case config.StateBackendConfigMap:
+		stateClient, err = kubernetes.NewForConfig(cfg.ClusterConfig)
+		if err != nil {
+			setupLog.Error(err, "Unable to initialize the state client")
+			os.Exit(1)
+		}
+		stateStore = state.NewConfigMapStore(stateClient, cfg.CollectorNamespace, cfg.State.ConfigMapName)
+	}
+	if stateStore != nil {
+		// a missing state only means the targets get reshuffled, the allocator can still start
+		loadCtx, loadCancel := context.WithTimeout(ctx, state.LoadTimeout)
+		assignments, loadErr := stateStore.Load(loadCtx)
+		loadCancel()
+		if loadErr != nil {
+			setupLog.Error(loadErr, "Unable to restore the target assignments")
+		} else {
+			allocator.RestoreAssignments(assignments)
+		}
+	}
+
 	httpOptions := []server.Option{}
 	if cfg.HTTPS.Enabled {
 		tlsConfig, confErr := cfg.HTTPS.NewTLSConfig()
@@ -84,6 +135,27 @@ func main() {
 		}
 		httpOptions = append(httpOptions, server.WithTLSConfig(tlsConfig, cfg.HTTPS.ListenAddr))
 	}
+	if cfg.Authentication.Enabled {
+		clientset, clientErr := kubernetes.NewForConfig(cfg.ClusterConfig)
+		if clientErr != nil {
+			setupLog.Error(clientErr, "Unable to initialize the authentication client")
+			os.Exit(1)
+		}
+		httpOptions = append(httpOptions, server.WithAuthenticator(server.NewTokenReviewAuthenticator(clientset, cfg.Authentication.ServiceAccounts)))
+	}
+	if cfg.PprofListenAddr != "" {
+		httpOptions = append(httpOptions, server.WithPprof(cfg.PprofListenAddr))
+	}
+	httpOptions = append(httpOptions, server.WithDebugInfo(cfg.AllocationStrategy, cfg.AllocationFallbackStrategy, func() time.Time {
+		return targetDiscoverer.LastReload()
+	}))
+	if cfg.LeaderElection.Enabled {
+		// the replicas can be added at any time, they only serve the collectors once they discovered the targets,
+		// otherwise a new replica would briefly hand out empty target lists
+		httpOptions = append(httpOptions, server.WithReadinessCheck(func() bool {
+			return !targetDiscoverer.LastReload().IsZero()
+		}))
+	}
 	srv := server.NewServer(log, allocator, cfg.ListenAddr, httpOptions...)
 
 	discoveryCtx, discoveryCancel := context.WithCancel(ctx)
@@ -95,7 +167,10 @@ func main() {
 	discoveryManager = discovery.NewManager(discoveryCtx, config.NopLogger, prometheus.DefaultRegisterer, sdMetrics)
 
 	targetDiscoverer = target.NewDiscoverer(log, discoveryManager, allocatorPrehook, srv, allocator.SetTargets)
-	collectorWatcher, collectorWatcherErr := collector.NewCollectorWatcher(log, cfg.ClusterConfig, cfg.CollectorNotReadyGracePeriod)
+	collectorWatcher, collectorWatcherErr := collector.NewCollectorWatcher(log, cfg.ClusterConfig, cfg.CollectorNotReadyGracePeriod, cfg.CollectorDrainTimeout, collector.Owner{
+		Kind: cfg.CollectorOwner.Kind,
+		Name: cfg.CollectorOwner.Name,
+	})
 	if collectorWatcherErr != nil {
 		setupLog.Error(collectorWatcherErr, "Unable to initialize collector watcher")
 		os.Exit(1)
@@ -175,6 +250,28 @@ func main() {
 			setupLog.Info("Closing collector watcher")
 			collectorWatcher.Close()
 		})
+	if stateStore != nil {
+		checkpointCtx, checkpointCancel := context.WithCancel(ctx)
+		runGroup.Add(
+			func() error {
+				if cfg.LeaderElection.Enabled {
+					identity, hostnameErr := os.Hostname()
+					if hostnameErr != nil {
+						return hostnameErr
+					}
+					leaderErr := state.RunWithLeaderElection(checkpointCtx, log.WithName("state"), stateClient, cfg.CollectorNamespace, cfg.LeaderElection.LeaseName, identity, stateStore, allocator, cfg.State.CheckpointInterval)
+					setupLog.Info("Leader election exited")
+					return leaderErr
+				}
+				state.Checkpoint(checkpointCtx, log.WithName("state"), stateStore, allocator, cfg.State.CheckpointInterval)
+				setupLog.Info("State checkpointing exited")
+				return nil
+			},
+			func(_ error) {
+				setupLog.Info("Closing state checkpointing")
+				checkpointCancel()
+			})
+	}
 	runGroup.Add(
 		func() error {
 			err := srv.Start()
@@ -201,6 +298,20 @@ func main() {
 				}
 			})
 	}
+	if cfg.PprofListenAddr != "" {
+		runGroup.Add(
+			func() error {
+				err := srv.StartPprof()
+				setupLog.Info("pprof server failed to start")
+				return err
+			},
+			func(_ error) {
+				setupLog.Info("Closing pprof server")
+				if shutdownErr := srv.ShutdownPprof(ctx); shutdownErr != nil {
+					setupLog.Error(shutdownErr, "Error on pprof server shutdown")
+				}
+			})
+	}
 	runGroup.Add(
 		func() error {
 			for {
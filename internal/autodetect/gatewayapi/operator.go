@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package gatewayapi
+
+// Availability represents that the Gateway API routes (HTTPRoute and GRPCRoute) are served by the cluster.
+type Availability int
+
+const (
+	// NotAvailable represents the gateway.networking.k8s.io routes are not available.
+	NotAvailable Availability = iota
+
+	// Available represents the gateway.networking.k8s.io routes are available.
+	Available
+)
+
+func (p Availability) String() string {
+	return [...]string{"NotAvailable", "Available"}[p]
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package istio
+
+// Availability represents that the Istio service mesh is installed in the cluster.
+type Availability int
+
+const (
+	// NotAvailable represents the networking.istio.io API is not available.
+	NotAvailable Availability = iota
+
+	// Available represents the networking.istio.io API is available.
+	Available
+)
+
+func (p Availability) String() string {
+	return [...]string{"NotAvailable", "Available"}[p]
+}
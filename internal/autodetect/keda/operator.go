@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package keda
+
+// Availability represents that the KEDA ScaledObject API is available in the cluster.
+type Availability int
+
+const (
+	// NotAvailable represents the keda.sh API is not available.
+	NotAvailable Availability = iota
+
+	// Available represents the keda.sh API is available.
+	Available
+)
+
+func (p Availability) String() string {
+	return [...]string{"NotAvailable", "Available"}[p]
+}
@@ -6,35 +6,57 @@ package autodetect
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/netip"
 	"slices"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sversion "k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/fips"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/istio"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 )
 
 var _ AutoDetect = (*autoDetect)(nil)
 
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list
+
 // AutoDetect provides an assortment of routines that auto-detect traits based on the runtime.
 type AutoDetect interface {
 	OpenShiftRoutesAvailability() (openshift.RoutesAvailability, error)
 	PrometheusCRsAvailability() (prometheus.Availability, error)
+	PrometheusCRFeatures() (prometheus.Features, error)
 	RBACPermissions(ctx context.Context) (autoRBAC.Availability, error)
 	CertManagerAvailability(ctx context.Context) (certmanager.Availability, error)
 	TargetAllocatorAvailability() (targetallocator.Availability, error)
 	CollectorAvailability() (collector.Availability, error)
+	GatewayAPIAvailability() (gatewayapi.Availability, error)
+	IstioAvailability() (istio.Availability, error)
+	KedaAvailability() (keda.Availability, error)
+	VPAAvailability() (vpa.Availability, error)
+	NativeSidecarAvailability() (nativesidecar.Availability, error)
+	Platform(ctx context.Context) (platform.Platform, error)
+	NodePlatforms(ctx context.Context) ([]platform.NodePlatform, error)
+	IPFamilies(ctx context.Context) ([]corev1.IPFamily, error)
+	OpenShiftSCCAvailability() (openshift.SCCAvailability, error)
 	FIPSEnabled(ctx context.Context) bool
 }
 
@@ -95,6 +117,40 @@ func (a *autoDetect) PrometheusCRsAvailability() (prometheus.Availability, error
 	return prometheus.NotAvailable, nil
 }
 
+// PrometheusCRFeatures determines the version of the monitoring.coreos.com API and whether the optional
+// ScrapeConfig and Probe resources are served.
+func (a *autoDetect) PrometheusCRFeatures() (prometheus.Features, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return prometheus.Features{}, err
+	}
+
+	features := prometheus.Features{}
+	apiGroups := apiList.Groups
+	for i := 0; i < len(apiGroups); i++ {
+		if apiGroups[i].Name != "monitoring.coreos.com" {
+			continue
+		}
+		features.Version = apiGroups[i].PreferredVersion.Version
+		for _, version := range apiGroups[i].Versions {
+			resources, err := a.dcl.ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				return prometheus.Features{}, err
+			}
+
+			for _, resource := range resources.APIResources {
+				if resource.Kind == "ScrapeConfig" {
+					features.ScrapeConfigs = true
+				} else if resource.Kind == "Probe" {
+					features.Probes = true
+				}
+			}
+		}
+	}
+
+	return features, nil
+}
+
 // OpenShiftRoutesAvailability checks if OpenShift Route are available.
 func (a *autoDetect) OpenShiftRoutesAvailability() (openshift.RoutesAvailability, error) {
 	apiList, err := a.dcl.ServerGroups()
@@ -112,6 +168,34 @@ func (a *autoDetect) OpenShiftRoutesAvailability() (openshift.RoutesAvailability
 	return openshift.RoutesNotAvailable, nil
 }
 
+// OpenShiftSCCAvailability checks if the OpenShift SecurityContextConstraints are available.
+func (a *autoDetect) OpenShiftSCCAvailability() (openshift.SCCAvailability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return openshift.SCCNotAvailable, err
+	}
+
+	apiGroups := apiList.Groups
+	for i := 0; i < len(apiGroups); i++ {
+		if apiGroups[i].Name == "security.openshift.io" {
+			for _, version := range apiGroups[i].Versions {
+				resources, err := a.dcl.ServerResourcesForGroupVersion(version.GroupVersion)
+				if err != nil {
+					return openshift.SCCNotAvailable, err
+				}
+
+				for _, resource := range resources.APIResources {
+					if resource.Kind == "SecurityContextConstraints" {
+						return openshift.SCCAvailable, nil
+					}
+				}
+			}
+		}
+	}
+
+	return openshift.SCCNotAvailable, nil
+}
+
 func (a *autoDetect) RBACPermissions(ctx context.Context) (autoRBAC.Availability, error) {
 	w, err := autoRBAC.CheckRBACPermissions(ctx, a.reviewer)
 	if err != nil {
@@ -218,6 +302,242 @@ func (a *autoDetect) CollectorAvailability() (collector.Availability, error) {
 	return collector.NotAvailable, nil
 }
 
+// GatewayAPIAvailability checks if the Gateway API HTTPRoute and GRPCRoute resources are available.
+func (a *autoDetect) GatewayAPIAvailability() (gatewayapi.Availability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return gatewayapi.NotAvailable, err
+	}
+
+	foundHTTPRoute := false
+	foundGRPCRoute := false
+	apiGroups := apiList.Groups
+	for i := 0; i < len(apiGroups); i++ {
+		if apiGroups[i].Name == "gateway.networking.k8s.io" {
+			for _, version := range apiGroups[i].Versions {
+				// the operator only creates routes for the GA version of the API
+				if version.Version != "v1" {
+					continue
+				}
+				resources, err := a.dcl.ServerResourcesForGroupVersion(version.GroupVersion)
+				if err != nil {
+					return gatewayapi.NotAvailable, err
+				}
+
+				for _, resource := range resources.APIResources {
+					if resource.Kind == "HTTPRoute" {
+						foundHTTPRoute = true
+					} else if resource.Kind == "GRPCRoute" {
+						foundGRPCRoute = true
+					}
+				}
+			}
+		}
+	}
+
+	if foundHTTPRoute && foundGRPCRoute {
+		return gatewayapi.Available, nil
+	}
+
+	return gatewayapi.NotAvailable, nil
+}
+
+// IstioAvailability checks if the Istio service mesh is installed, based on the availability of its networking API.
+func (a *autoDetect) IstioAvailability() (istio.Availability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return istio.NotAvailable, err
+	}
+
+	apiGroups := apiList.Groups
+	for i := 0; i < len(apiGroups); i++ {
+		if apiGroups[i].Name == "networking.istio.io" {
+			return istio.Available, nil
+		}
+	}
+
+	return istio.NotAvailable, nil
+}
+
+// KedaAvailability checks if the KEDA ScaledObject resource is available.
+func (a *autoDetect) KedaAvailability() (keda.Availability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return keda.NotAvailable, err
+	}
+
+	apiGroups := apiList.Groups
+	for i := 0; i < len(apiGroups); i++ {
+		if apiGroups[i].Name == "keda.sh" {
+			for _, version := range apiGroups[i].Versions {
+				if version.Version != "v1alpha1" {
+					continue
+				}
+				resources, err := a.dcl.ServerResourcesForGroupVersion(version.GroupVersion)
+				if err != nil {
+					return keda.NotAvailable, err
+				}
+
+				for _, resource := range resources.APIResources {
+					if resource.Kind == "ScaledObject" {
+						return keda.Available, nil
+					}
+				}
+			}
+		}
+	}
+
+	return keda.NotAvailable, nil
+}
+
+// VPAAvailability checks if the VerticalPodAutoscaler resource is available.
+func (a *autoDetect) VPAAvailability() (vpa.Availability, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return vpa.NotAvailable, err
+	}
+
+	apiGroups := apiList.Groups
+	for i := 0; i < len(apiGroups); i++ {
+		if apiGroups[i].Name == "autoscaling.k8s.io" {
+			for _, version := range apiGroups[i].Versions {
+				if version.Version != "v1" {
+					continue
+				}
+				resources, err := a.dcl.ServerResourcesForGroupVersion(version.GroupVersion)
+				if err != nil {
+					return vpa.NotAvailable, err
+				}
+
+				for _, resource := range resources.APIResources {
+					if resource.Kind == "VerticalPodAutoscaler" {
+						return vpa.Available, nil
+					}
+				}
+			}
+		}
+	}
+
+	return vpa.NotAvailable, nil
+}
+
+// NativeSidecarAvailability checks if the cluster supports native sidecar containers, which are enabled by default
+// since Kubernetes 1.29.
+func (a *autoDetect) NativeSidecarAvailability() (nativesidecar.Availability, error) {
+	info, err := a.dcl.ServerVersion()
+	if err != nil {
+		return nativesidecar.NotAvailable, err
+	}
+
+	v, err := k8sversion.ParseGeneric(info.GitVersion)
+	if err != nil {
+		return nativesidecar.NotAvailable, err
+	}
+	if v.AtLeast(k8sversion.MajorMinor(1, 29)) {
+		return nativesidecar.Available, nil
+	}
+
+	return nativesidecar.NotAvailable, nil
+}
+
+// Platform determines the platform the operator is running on, based on the well-known API groups and
+// on the labels and provider ID of the cluster nodes.
+func (a *autoDetect) Platform(ctx context.Context) (platform.Platform, error) {
+	apiList, err := a.dcl.ServerGroups()
+	if err != nil {
+		return platform.Unknown, err
+	}
+
+	apiGroups := apiList.Groups
+	for i := 0; i < len(apiGroups); i++ {
+		if apiGroups[i].Name == "config.openshift.io" {
+			return platform.OpenShift, nil
+		}
+	}
+
+	raw, err := a.dcl.RESTClient().Get().AbsPath("/api/v1/nodes").Param("limit", "1").DoRaw(ctx)
+	if err != nil {
+		return platform.Unknown, fmt.Errorf("failed to list the cluster nodes: %w", err)
+	}
+	nodes := &corev1.NodeList{}
+	if err = json.Unmarshal(raw, nodes); err != nil {
+		return platform.Unknown, err
+	}
+	if len(nodes.Items) == 0 {
+		return platform.Unknown, nil
+	}
+
+	return platform.FromNode(nodes.Items[0]), nil
+}
+
+// NodePlatforms lists the distinct operating systems and architectures of the cluster nodes.
+func (a *autoDetect) NodePlatforms(ctx context.Context) ([]platform.NodePlatform, error) {
+	// only the node metadata is needed, which keeps the response small on large clusters
+	raw, err := a.dcl.RESTClient().Get().AbsPath("/api/v1/nodes").
+		SetHeader("Accept", "application/json;as=PartialObjectMetadataList;g=meta.k8s.io;v=v1,application/json").
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the cluster nodes: %w", err)
+	}
+	nodes := &metav1.PartialObjectMetadataList{}
+	if err = json.Unmarshal(raw, nodes); err != nil {
+		return nil, err
+	}
+
+	nodesMeta := make([]metav1.ObjectMeta, len(nodes.Items))
+	for i, node := range nodes.Items {
+		nodesMeta[i] = node.ObjectMeta
+	}
+	return platform.NodePlatforms(nodesMeta), nil
+}
+
+// IPFamilies determines the IP families of the pod addresses, based on the pod CIDRs of a cluster node or, when the
+// network plugin doesn't allocate them from the node CIDRs, on the families of the kubernetes service.
+func (a *autoDetect) IPFamilies(ctx context.Context) ([]corev1.IPFamily, error) {
+	raw, err := a.dcl.RESTClient().Get().AbsPath("/api/v1/nodes").Param("limit", "1").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the cluster nodes: %w", err)
+	}
+	nodes := &corev1.NodeList{}
+	if err = json.Unmarshal(raw, nodes); err != nil {
+		return nil, err
+	}
+
+	var families []corev1.IPFamily
+	for _, node := range nodes.Items {
+		cidrs := node.Spec.PodCIDRs
+		if len(cidrs) == 0 && node.Spec.PodCIDR != "" {
+			cidrs = []string{node.Spec.PodCIDR}
+		}
+		for _, cidr := range cidrs {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				continue
+			}
+			family := corev1.IPv4Protocol
+			if prefix.Addr().Is6() {
+				family = corev1.IPv6Protocol
+			}
+			if !slices.Contains(families, family) {
+				families = append(families, family)
+			}
+		}
+	}
+	if len(families) > 0 {
+		return families, nil
+	}
+
+	raw, err = a.dcl.RESTClient().Get().AbsPath("/api/v1/namespaces/default/services/kubernetes").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the kubernetes service: %w", err)
+	}
+	svc := &corev1.Service{}
+	if err = json.Unmarshal(raw, svc); err != nil {
+		return nil, err
+	}
+	return svc.Spec.IPFamilies, nil
+}
+
 func (a *autoDetect) FIPSEnabled(_ context.Context) bool {
 	return fips.IsFipsEnabled()
 }
@@ -240,6 +560,13 @@ func ApplyAutoDetect(autoDetect AutoDetect, c *config.Config, logger logr.Logger
 	c.PrometheusCRAvailability = pcrd
 	logger.V(2).Info("prometheus cr detected", "availability", pcrd)
 
+	pf, err := autoDetect.PrometheusCRFeatures()
+	if err != nil {
+		return err
+	}
+	c.PrometheusCRFeatures = pf
+	logger.V(2).Info("prometheus cr features detected", "version", pf.Version, "scrapeConfigs", pf.ScrapeConfigs, "probes", pf.Probes)
+
 	rAuto, err := autoDetect.RBACPermissions(context.Background())
 	if err != nil {
 		logger.V(2).Info("the rbac permissions are not set for the operator", "reason", err)
@@ -268,5 +595,68 @@ func ApplyAutoDetect(autoDetect AutoDetect, c *config.Config, logger logr.Logger
 	c.CollectorAvailability = coAvl
 	logger.V(2).Info("determined Collector CRD availability", "availability", coAvl)
 
+	gwAvl, err := autoDetect.GatewayAPIAvailability()
+	if err != nil {
+		return err
+	}
+	c.GatewayAPIAvailability = gwAvl
+	logger.V(2).Info("determined Gateway API availability", "availability", gwAvl)
+
+	isAvl, err := autoDetect.IstioAvailability()
+	if err != nil {
+		return err
+	}
+	c.IstioAvailability = isAvl
+	logger.V(2).Info("determined Istio availability", "availability", isAvl)
+
+	keAvl, err := autoDetect.KedaAvailability()
+	if err != nil {
+		return err
+	}
+	c.KedaAvailability = keAvl
+	logger.V(2).Info("determined KEDA availability", "availability", keAvl)
+
+	vpaAvl, err := autoDetect.VPAAvailability()
+	if err != nil {
+		return err
+	}
+	c.VPAAvailability = vpaAvl
+	logger.V(2).Info("determined VerticalPodAutoscaler availability", "availability", vpaAvl)
+
+	nsAvl, err := autoDetect.NativeSidecarAvailability()
+	if err != nil {
+		return err
+	}
+	c.NativeSidecarAvailability = nsAvl
+	logger.V(2).Info("determined native sidecar containers availability", "availability", nsAvl)
+
+	pl, err := autoDetect.Platform(context.Background())
+	if err != nil {
+		logger.V(2).Info("the platform could not be fully determined", "reason", err)
+	}
+	c.Platform = pl
+	logger.V(2).Info("determined platform", "platform", pl)
+
+	np, err := autoDetect.NodePlatforms(context.Background())
+	if err != nil {
+		logger.V(2).Info("the node platforms could not be determined", "reason", err)
+	}
+	c.NodePlatforms = np
+	logger.V(2).Info("determined node platforms", "platforms", np)
+
+	ipf, err := autoDetect.IPFamilies(context.Background())
+	if err != nil {
+		logger.V(2).Info("the IP families could not be determined", "reason", err)
+	}
+	c.IPFamilies = ipf
+	logger.V(2).Info("determined IP families", "families", ipf)
+
+	sccAvl, err := autoDetect.OpenShiftSCCAvailability()
+	if err != nil {
+		return err
+	}
+	c.OpenShiftSCCAvailability = sccAvl
+	logger.V(2).Info("determined OpenShift SecurityContextConstraints availability", "availability", sccAvl)
+
 	return nil
 }
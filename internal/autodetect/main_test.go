@@ -14,8 +14,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/rest"
@@ -26,10 +28,16 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/autodetectutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/istio"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 )
@@ -77,6 +85,77 @@ func TestDetectPlatformBasedOnAvailableAPIGroups(t *testing.T) {
 	}
 }
 
+func TestOpenShiftSCCAvailability(t *testing.T) {
+	securityGroups := &metav1.APIGroupList{
+		Groups: []metav1.APIGroup{
+			{
+				Name: "security.openshift.io",
+				Versions: []metav1.GroupVersionForDiscovery{
+					{GroupVersion: "security.openshift.io/v1", Version: "v1"},
+				},
+			},
+		},
+	}
+	for _, tt := range []struct {
+		desc         string
+		apiGroupList *metav1.APIGroupList
+		resources    *metav1.APIResourceList
+		expected     openshift.SCCAvailability
+	}{
+		{
+			desc:         "no security api",
+			apiGroupList: &metav1.APIGroupList{},
+			resources:    &metav1.APIResourceList{},
+			expected:     openshift.SCCNotAvailable,
+		},
+		{
+			desc:         "no security context constraints",
+			apiGroupList: securityGroups,
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "RangeAllocation"}},
+			},
+			expected: openshift.SCCNotAvailable,
+		},
+		{
+			desc:         "security context constraints",
+			apiGroupList: securityGroups,
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "RangeAllocation"}, {Kind: "SecurityContextConstraints"}},
+			},
+			expected: openshift.SCCAvailable,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var output []byte
+				var err error
+				if req.URL.Path == "/apis" {
+					output, err = json.Marshal(tt.apiGroupList)
+				} else {
+					output, err = json.Marshal(tt.resources)
+				}
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+			require.NoError(t, err)
+
+			// test
+			scc, err := autoDetect.OpenShiftSCCAvailability()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, scc)
+		})
+	}
+}
+
 func TestDetectPlatformBasedOnAvailableAPIGroupsPrometheus(t *testing.T) {
 	for _, tt := range []struct {
 		apiGroupList *metav1.APIGroupList
@@ -160,6 +239,643 @@ func TestDetectPlatformBasedOnAvailableAPIGroupsPrometheus(t *testing.T) {
 	}
 }
 
+func TestPrometheusCRFeatures(t *testing.T) {
+	monitoringGroups := &metav1.APIGroupList{
+		Groups: []metav1.APIGroup{
+			{
+				Name: "monitoring.coreos.com",
+				Versions: []metav1.GroupVersionForDiscovery{
+					{GroupVersion: "monitoring.coreos.com/v1", Version: "v1"},
+					{GroupVersion: "monitoring.coreos.com/v1alpha1", Version: "v1alpha1"},
+				},
+				PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "monitoring.coreos.com/v1", Version: "v1"},
+			},
+		},
+	}
+	for _, tt := range []struct {
+		desc         string
+		apiGroupList *metav1.APIGroupList
+		resources    map[string]*metav1.APIResourceList
+		expected     prometheus.Features
+	}{
+		{
+			desc:         "no prometheus operator",
+			apiGroupList: &metav1.APIGroupList{},
+			expected:     prometheus.Features{},
+		},
+		{
+			desc:         "monitors only",
+			apiGroupList: monitoringGroups,
+			resources: map[string]*metav1.APIResourceList{
+				"/apis/monitoring.coreos.com/v1": {
+					APIResources: []metav1.APIResource{{Kind: "PodMonitor"}, {Kind: "ServiceMonitor"}},
+				},
+			},
+			expected: prometheus.Features{Version: "v1"},
+		},
+		{
+			desc:         "probes and scrape configs",
+			apiGroupList: monitoringGroups,
+			resources: map[string]*metav1.APIResourceList{
+				"/apis/monitoring.coreos.com/v1": {
+					APIResources: []metav1.APIResource{{Kind: "PodMonitor"}, {Kind: "ServiceMonitor"}, {Kind: "Probe"}},
+				},
+				"/apis/monitoring.coreos.com/v1alpha1": {
+					APIResources: []metav1.APIResource{{Kind: "ScrapeConfig"}},
+				},
+			},
+			expected: prometheus.Features{Version: "v1", ScrapeConfigs: true, Probes: true},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var output []byte
+				var err error
+				if req.URL.Path == "/apis" {
+					output, err = json.Marshal(tt.apiGroupList)
+				} else if resources, ok := tt.resources[req.URL.Path]; ok {
+					output, err = json.Marshal(resources)
+				} else {
+					output, err = json.Marshal(&metav1.APIResourceList{})
+				}
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+			require.NoError(t, err)
+
+			// test
+			features, err := autoDetect.PrometheusCRFeatures()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, features)
+		})
+	}
+}
+
+func TestGatewayAPIAvailability(t *testing.T) {
+	gatewayGroups := &metav1.APIGroupList{
+		Groups: []metav1.APIGroup{
+			{
+				Name: "gateway.networking.k8s.io",
+				Versions: []metav1.GroupVersionForDiscovery{
+					{GroupVersion: "gateway.networking.k8s.io/v1", Version: "v1"},
+				},
+			},
+		},
+	}
+	for _, tt := range []struct {
+		desc         string
+		apiGroupList *metav1.APIGroupList
+		resources    *metav1.APIResourceList
+		expected     gatewayapi.Availability
+	}{
+		{
+			desc:         "no gateway api",
+			apiGroupList: &metav1.APIGroupList{},
+			resources:    &metav1.APIResourceList{},
+			expected:     gatewayapi.NotAvailable,
+		},
+		{
+			desc:         "only http routes",
+			apiGroupList: gatewayGroups,
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "Gateway"}, {Kind: "HTTPRoute"}},
+			},
+			expected: gatewayapi.NotAvailable,
+		},
+		{
+			desc:         "http and grpc routes",
+			apiGroupList: gatewayGroups,
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "Gateway"}, {Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+			},
+			expected: gatewayapi.Available,
+		},
+		{
+			desc: "only beta version",
+			apiGroupList: &metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name: "gateway.networking.k8s.io",
+						Versions: []metav1.GroupVersionForDiscovery{
+							{GroupVersion: "gateway.networking.k8s.io/v1beta1", Version: "v1beta1"},
+						},
+					},
+				},
+			},
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "HTTPRoute"}, {Kind: "GRPCRoute"}},
+			},
+			expected: gatewayapi.NotAvailable,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var output []byte
+				var err error
+				if req.URL.Path == "/apis" {
+					output, err = json.Marshal(tt.apiGroupList)
+				} else {
+					output, err = json.Marshal(tt.resources)
+				}
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+			require.NoError(t, err)
+
+			// test
+			gwa, err := autoDetect.GatewayAPIAvailability()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, gwa)
+		})
+	}
+}
+
+func TestIstioAvailability(t *testing.T) {
+	for _, tt := range []struct {
+		apiGroupList *metav1.APIGroupList
+		expected     istio.Availability
+	}{
+		{
+			&metav1.APIGroupList{},
+			istio.NotAvailable,
+		},
+		{
+			&metav1.APIGroupList{
+				Groups: []metav1.APIGroup{
+					{
+						Name: "networking.istio.io",
+					},
+				},
+			},
+			istio.Available,
+		},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			output, err := json.Marshal(tt.apiGroupList)
+			require.NoError(t, err)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write(output)
+			require.NoError(t, err)
+		}))
+		defer server.Close()
+
+		autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+		require.NoError(t, err)
+
+		// test
+		ia, err := autoDetect.IstioAvailability()
+
+		// verify
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, ia)
+	}
+}
+
+func TestKedaAvailability(t *testing.T) {
+	kedaGroups := &metav1.APIGroupList{
+		Groups: []metav1.APIGroup{
+			{
+				Name: "keda.sh",
+				Versions: []metav1.GroupVersionForDiscovery{
+					{GroupVersion: "keda.sh/v1alpha1", Version: "v1alpha1"},
+				},
+			},
+		},
+	}
+	for _, tt := range []struct {
+		desc         string
+		apiGroupList *metav1.APIGroupList
+		resources    *metav1.APIResourceList
+		expected     keda.Availability
+	}{
+		{
+			desc:         "no keda",
+			apiGroupList: &metav1.APIGroupList{},
+			resources:    &metav1.APIResourceList{},
+			expected:     keda.NotAvailable,
+		},
+		{
+			desc:         "no scaled objects",
+			apiGroupList: kedaGroups,
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "ScaledJob"}},
+			},
+			expected: keda.NotAvailable,
+		},
+		{
+			desc:         "scaled objects",
+			apiGroupList: kedaGroups,
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "ScaledJob"}, {Kind: "ScaledObject"}},
+			},
+			expected: keda.Available,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var output []byte
+				var err error
+				if req.URL.Path == "/apis" {
+					output, err = json.Marshal(tt.apiGroupList)
+				} else {
+					output, err = json.Marshal(tt.resources)
+				}
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+			require.NoError(t, err)
+
+			// test
+			ka, err := autoDetect.KedaAvailability()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ka)
+		})
+	}
+}
+
+func TestVPAAvailability(t *testing.T) {
+	vpaGroups := &metav1.APIGroupList{
+		Groups: []metav1.APIGroup{
+			{
+				Name: "autoscaling.k8s.io",
+				Versions: []metav1.GroupVersionForDiscovery{
+					{GroupVersion: "autoscaling.k8s.io/v1", Version: "v1"},
+				},
+			},
+		},
+	}
+	for _, tt := range []struct {
+		desc         string
+		apiGroupList *metav1.APIGroupList
+		resources    *metav1.APIResourceList
+		expected     vpa.Availability
+	}{
+		{
+			desc:         "no vpa",
+			apiGroupList: &metav1.APIGroupList{},
+			resources:    &metav1.APIResourceList{},
+			expected:     vpa.NotAvailable,
+		},
+		{
+			desc:         "no vertical pod autoscalers",
+			apiGroupList: vpaGroups,
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "VerticalPodAutoscalerCheckpoint"}},
+			},
+			expected: vpa.NotAvailable,
+		},
+		{
+			desc:         "vertical pod autoscalers",
+			apiGroupList: vpaGroups,
+			resources: &metav1.APIResourceList{
+				APIResources: []metav1.APIResource{{Kind: "VerticalPodAutoscalerCheckpoint"}, {Kind: "VerticalPodAutoscaler"}},
+			},
+			expected: vpa.Available,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var output []byte
+				var err error
+				if req.URL.Path == "/apis" {
+					output, err = json.Marshal(tt.apiGroupList)
+				} else {
+					output, err = json.Marshal(tt.resources)
+				}
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+			require.NoError(t, err)
+
+			// test
+			va, err := autoDetect.VPAAvailability()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, va)
+		})
+	}
+}
+
+func TestNativeSidecarAvailability(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		version  string
+		expected nativesidecar.Availability
+	}{
+		{desc: "1.28", version: "v1.28.9", expected: nativesidecar.NotAvailable},
+		{desc: "1.29", version: "v1.29.0", expected: nativesidecar.Available},
+		{desc: "distribution suffix", version: "v1.30.4-eks-a737599", expected: nativesidecar.Available},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				output, err := json.Marshal(version.Info{GitVersion: tt.version})
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+			require.NoError(t, err)
+
+			// test
+			nsa, err := autoDetect.NativeSidecarAvailability()
+
+			// verify
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, nsa)
+		})
+	}
+}
+
+func TestPlatform(t *testing.T) {
+	for _, tt := range []struct {
+		desc         string
+		apiGroupList *metav1.APIGroupList
+		nodes        *corev1.NodeList
+		expected     platform.Platform
+		expectedErr  bool
+	}{
+		{
+			desc: "openshift",
+			apiGroupList: &metav1.APIGroupList{
+				Groups: []metav1.APIGroup{{Name: "config.openshift.io"}},
+			},
+			nodes:    &corev1.NodeList{},
+			expected: platform.OpenShift,
+		},
+		{
+			desc:         "eks node label",
+			apiGroupList: &metav1.APIGroupList{},
+			nodes: &corev1.NodeList{Items: []corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"eks.amazonaws.com/nodegroup": "default"}}},
+			}},
+			expected: platform.EKS,
+		},
+		{
+			desc:         "gke node label",
+			apiGroupList: &metav1.APIGroupList{},
+			nodes: &corev1.NodeList{Items: []corev1.Node{
+				{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"cloud.google.com/gke-nodepool": "default-pool"}}},
+			}},
+			expected: platform.GKE,
+		},
+		{
+			desc:         "aks provider id",
+			apiGroupList: &metav1.APIGroupList{},
+			nodes: &corev1.NodeList{Items: []corev1.Node{
+				{Spec: corev1.NodeSpec{ProviderID: "azure:///subscriptions/id/resourceGroups/rg/providers/Microsoft.Compute/virtualMachineScaleSets/vmss/virtualMachines/0"}},
+			}},
+			expected: platform.AKS,
+		},
+		{
+			desc:         "k3s provider id",
+			apiGroupList: &metav1.APIGroupList{},
+			nodes: &corev1.NodeList{Items: []corev1.Node{
+				{Spec: corev1.NodeSpec{ProviderID: "k3s://node-1"}},
+			}},
+			expected: platform.K3s,
+		},
+		{
+			desc:         "unknown",
+			apiGroupList: &metav1.APIGroupList{},
+			nodes: &corev1.NodeList{Items: []corev1.Node{
+				{Spec: corev1.NodeSpec{ProviderID: "kind://docker/kind/kind-control-plane"}},
+			}},
+			expected: platform.Unknown,
+		},
+		{
+			desc:         "nodes can't be listed",
+			apiGroupList: &metav1.APIGroupList{},
+			expected:     platform.Unknown,
+			expectedErr:  true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var output []byte
+				var err error
+				switch req.URL.Path {
+				case "/apis":
+					output, err = json.Marshal(tt.apiGroupList)
+				case "/api/v1/nodes":
+					if tt.nodes == nil {
+						w.WriteHeader(http.StatusForbidden)
+						return
+					}
+					output, err = json.Marshal(tt.nodes)
+				default:
+					output, err = json.Marshal(&metav1.APIResourceList{})
+				}
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+			require.NoError(t, err)
+
+			// test
+			p, err := autoDetect.Platform(context.Background())
+
+			// verify
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, p)
+		})
+	}
+}
+
+func TestNodePlatforms(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		nodes       *metav1.PartialObjectMetadataList
+		expected    []platform.NodePlatform
+		expectedErr bool
+	}{
+		{
+			desc: "mixed nodes",
+			nodes: &metav1.PartialObjectMetadataList{Items: []metav1.PartialObjectMetadata{
+				{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"kubernetes.io/os": "linux", "kubernetes.io/arch": "arm64"}}},
+				{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"kubernetes.io/os": "linux", "kubernetes.io/arch": "amd64"}}},
+				{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"kubernetes.io/os": "linux", "kubernetes.io/arch": "amd64"}}},
+				{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"kubernetes.io/os": "windows", "kubernetes.io/arch": "amd64"}}},
+				{ObjectMeta: metav1.ObjectMeta{}},
+			}},
+			expected: []platform.NodePlatform{
+				{OS: "linux", Architecture: "amd64"},
+				{OS: "linux", Architecture: "arm64"},
+				{OS: "windows", Architecture: "amd64"},
+			},
+		},
+		{
+			desc:  "no nodes",
+			nodes: &metav1.PartialObjectMetadataList{},
+		},
+		{
+			desc:        "nodes can't be listed",
+			expectedErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/api/v1/nodes" || tt.nodes == nil {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				output, err := json.Marshal(tt.nodes)
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+			require.NoError(t, err)
+
+			// test
+			platforms, err := autoDetect.NodePlatforms(context.Background())
+
+			// verify
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, platforms)
+		})
+	}
+}
+
+func TestIPFamilies(t *testing.T) {
+	for _, tt := range []struct {
+		desc        string
+		nodes       *corev1.NodeList
+		service     *corev1.Service
+		expected    []corev1.IPFamily
+		expectedErr bool
+	}{
+		{
+			desc: "ipv4 pod cidrs",
+			nodes: &corev1.NodeList{Items: []corev1.Node{
+				{Spec: corev1.NodeSpec{PodCIDR: "10.244.0.0/24"}},
+			}},
+			expected: []corev1.IPFamily{corev1.IPv4Protocol},
+		},
+		{
+			desc: "dual-stack pod cidrs",
+			nodes: &corev1.NodeList{Items: []corev1.Node{
+				{Spec: corev1.NodeSpec{PodCIDRs: []string{"10.244.0.0/24", "fd00:10:244::/64"}}},
+			}},
+			expected: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+		},
+		{
+			desc:  "kubernetes service",
+			nodes: &corev1.NodeList{Items: []corev1.Node{{}}},
+			service: &corev1.Service{Spec: corev1.ServiceSpec{
+				IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+			}},
+			expected: []corev1.IPFamily{corev1.IPv6Protocol},
+		},
+		{
+			desc:        "nodes can't be listed",
+			expectedErr: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				var obj interface{}
+				switch req.URL.Path {
+				case "/api/v1/nodes":
+					if tt.nodes != nil {
+						obj = tt.nodes
+					}
+				case "/api/v1/namespaces/default/services/kubernetes":
+					if tt.service != nil {
+						obj = tt.service
+					}
+				}
+				if obj == nil {
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				output, err := json.Marshal(obj)
+				require.NoError(t, err)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write(output)
+				require.NoError(t, err)
+			}))
+			defer server.Close()
+
+			autoDetect, err := autodetect.New(&rest.Config{Host: server.URL}, nil)
+			require.NoError(t, err)
+
+			// test
+			families, err := autoDetect.IPFamilies(context.Background())
+
+			// verify
+			if tt.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, families)
+		})
+	}
+}
+
 type fakeClientGenerator func() kubernetes.Interface
 
 const (
@@ -399,10 +1115,20 @@ var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 type mockAutoDetect struct {
 	OpenShiftRoutesAvailabilityFunc func() (openshift.RoutesAvailability, error)
 	PrometheusCRsAvailabilityFunc   func() (prometheus.Availability, error)
+	PrometheusCRFeaturesFunc        func() (prometheus.Features, error)
 	RBACPermissionsFunc             func(ctx context.Context) (autoRBAC.Availability, error)
 	CertManagerAvailabilityFunc     func(ctx context.Context) (certmanager.Availability, error)
 	TargetAllocatorAvailabilityFunc func() (targetallocator.Availability, error)
 	CollectorAvailabilityFunc       func() (collector.Availability, error)
+	GatewayAPIAvailabilityFunc      func() (gatewayapi.Availability, error)
+	OpenShiftSCCAvailabilityFunc    func() (openshift.SCCAvailability, error)
+	PlatformFunc                    func() (platform.Platform, error)
+	NodePlatformsFunc               func() ([]platform.NodePlatform, error)
+	IPFamiliesFunc                  func() ([]corev1.IPFamily, error)
+	KedaAvailabilityFunc            func() (keda.Availability, error)
+	VPAAvailabilityFunc             func() (vpa.Availability, error)
+	NativeSidecarAvailabilityFunc   func() (nativesidecar.Availability, error)
+	IstioAvailabilityFunc           func() (istio.Availability, error)
 }
 
 func (m *mockAutoDetect) CollectorAvailability() (collector.Availability, error) {
@@ -412,6 +1138,76 @@ func (m *mockAutoDetect) CollectorAvailability() (collector.Availability, error)
 	return collector.NotAvailable, nil
 }
 
+func (m *mockAutoDetect) GatewayAPIAvailability() (gatewayapi.Availability, error) {
+	if m.GatewayAPIAvailabilityFunc != nil {
+		return m.GatewayAPIAvailabilityFunc()
+	}
+	return gatewayapi.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) IstioAvailability() (istio.Availability, error) {
+	if m.IstioAvailabilityFunc != nil {
+		return m.IstioAvailabilityFunc()
+	}
+	return istio.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) KedaAvailability() (keda.Availability, error) {
+	if m.KedaAvailabilityFunc != nil {
+		return m.KedaAvailabilityFunc()
+	}
+	return keda.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) VPAAvailability() (vpa.Availability, error) {
+	if m.VPAAvailabilityFunc != nil {
+		return m.VPAAvailabilityFunc()
+	}
+	return vpa.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) NativeSidecarAvailability() (nativesidecar.Availability, error) {
+	if m.NativeSidecarAvailabilityFunc != nil {
+		return m.NativeSidecarAvailabilityFunc()
+	}
+	return nativesidecar.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) Platform(_ context.Context) (platform.Platform, error) {
+	if m.PlatformFunc != nil {
+		return m.PlatformFunc()
+	}
+	return platform.Unknown, nil
+}
+
+func (m *mockAutoDetect) PrometheusCRFeatures() (prometheus.Features, error) {
+	if m.PrometheusCRFeaturesFunc != nil {
+		return m.PrometheusCRFeaturesFunc()
+	}
+	return prometheus.Features{}, nil
+}
+
+func (m *mockAutoDetect) OpenShiftSCCAvailability() (openshift.SCCAvailability, error) {
+	if m.OpenShiftSCCAvailabilityFunc != nil {
+		return m.OpenShiftSCCAvailabilityFunc()
+	}
+	return openshift.SCCNotAvailable, nil
+}
+
+func (m *mockAutoDetect) NodePlatforms(_ context.Context) ([]platform.NodePlatform, error) {
+	if m.NodePlatformsFunc != nil {
+		return m.NodePlatformsFunc()
+	}
+	return nil, nil
+}
+
+func (m *mockAutoDetect) IPFamilies(_ context.Context) ([]corev1.IPFamily, error) {
+	if m.IPFamiliesFunc != nil {
+		return m.IPFamiliesFunc()
+	}
+	return nil, nil
+}
+
 func (m *mockAutoDetect) FIPSEnabled(_ context.Context) bool {
 	return false
 }
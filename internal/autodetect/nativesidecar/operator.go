@@ -0,0 +1,20 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package nativesidecar
+
+// Availability represents that the cluster supports native sidecar containers, that is init containers with the
+// Always restart policy.
+type Availability int
+
+const (
+	// NotAvailable represents the cluster doesn't support native sidecar containers, it runs Kubernetes < 1.29.
+	NotAvailable Availability = iota
+
+	// Available represents the cluster supports native sidecar containers, it runs Kubernetes >= 1.29.
+	Available
+)
+
+func (p Availability) String() string {
+	return [...]string{"NotAvailable", "Available"}[p]
+}
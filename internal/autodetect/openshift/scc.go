@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openshift
+
+// SCCAvailability holds the auto-detected OpenShift SecurityContextConstraints API availability.
+type SCCAvailability int
+
+const (
+	// SCCNotAvailable represents the security.openshift.io API is not available.
+	SCCNotAvailable SCCAvailability = iota
+
+	// SCCAvailable represents the security.openshift.io API is available.
+	SCCAvailable
+)
+
+func (p SCCAvailability) String() string {
+	return [...]string{"NotAvailable", "Available"}[p]
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package platform contains the platform (cloud provider or Kubernetes distribution) classification.
+package platform
+
+import (
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Platform holds the platform the operator is running on.
+type Platform int
+
+const (
+	// Unknown represents a platform which couldn't be determined, or without specific defaults.
+	Unknown Platform = iota
+	// OpenShift represents Red Hat OpenShift.
+	OpenShift
+	// EKS represents Amazon Elastic Kubernetes Service.
+	EKS
+	// GKE represents Google Kubernetes Engine.
+	GKE
+	// AKS represents Azure Kubernetes Service.
+	AKS
+	// K3s represents the k3s distribution.
+	K3s
+)
+
+func (p Platform) String() string {
+	return [...]string{"Unknown", "OpenShift", "EKS", "GKE", "AKS", "k3s"}[p]
+}
+
+// ResourceDetectors returns the resourcedetection processor detectors relevant for the platform,
+// or nil when the platform has no specific detectors.
+func (p Platform) ResourceDetectors() []string {
+	switch p {
+	case OpenShift:
+		return []string{"env", "openshift"}
+	case EKS:
+		return []string{"env", "eks", "ec2"}
+	case GKE:
+		return []string{"env", "gcp"}
+	case AKS:
+		return []string{"env", "aks", "azure"}
+	}
+	return nil
+}
+
+// FromNode classifies the platform based on the labels and the provider ID of the given node.
+func FromNode(node corev1.Node) Platform {
+	for label, value := range node.Labels {
+		switch {
+		case strings.HasPrefix(label, "eks.amazonaws.com/"):
+			return EKS
+		case label == "cloud.google.com/gke-nodepool":
+			return GKE
+		case strings.HasPrefix(label, "kubernetes.azure.com/"):
+			return AKS
+		case label == "node.kubernetes.io/instance-type" && value == "k3s":
+			return K3s
+		}
+	}
+
+	providerID := node.Spec.ProviderID
+	switch {
+	case strings.HasPrefix(providerID, "aws://"):
+		return EKS
+	case strings.HasPrefix(providerID, "gce://"):
+		return GKE
+	case strings.HasPrefix(providerID, "azure://"):
+		return AKS
+	case strings.HasPrefix(providerID, "k3s://"):
+		return K3s
+	}
+	return Unknown
+}
+
+// NodePlatform is the operating system and CPU architecture of cluster nodes, or of a container image.
+type NodePlatform struct {
+	OS           string
+	Architecture string
+}
+
+func (p NodePlatform) String() string {
+	return p.OS + "/" + p.Architecture
+}
+
+// NodePlatforms returns the sorted, distinct platforms of the given nodes, based on their well-known
+// kubernetes.io/os and kubernetes.io/arch labels.
+func NodePlatforms(nodes []metav1.ObjectMeta) []NodePlatform {
+	var platforms []NodePlatform
+	for _, node := range nodes {
+		p := NodePlatform{OS: node.Labels[corev1.LabelOSStable], Architecture: node.Labels[corev1.LabelArchStable]}
+		if p.OS == "" || p.Architecture == "" || slices.Contains(platforms, p) {
+			continue
+		}
+		platforms = append(platforms, p)
+	}
+	slices.SortFunc(platforms, func(a, b NodePlatform) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return platforms
+}
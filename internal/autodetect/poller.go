@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autodetect
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+var _ manager.Runnable = (*Poller)(nil)
+var _ manager.LeaderElectionRunnable = (*Poller)(nil)
+
+// ChangeFunc is called by the Poller whenever the auto-detected traits of the cluster change.
+type ChangeFunc func(ctx context.Context, previous, current config.Config) error
+
+// Poller periodically re-runs the auto-detection routines, so that APIs which are installed or removed
+// after the operator started (Prometheus Operator, cert-manager, ...) are picked up without a restart.
+type Poller struct {
+	autoDetect AutoDetect
+	interval   time.Duration
+	logger     logr.Logger
+	current    config.Config
+	onChange   ChangeFunc
+}
+
+// NewPoller creates a new Poller, starting from the given (already auto-detected) configuration.
+func NewPoller(autoDetect AutoDetect, cfg config.Config, interval time.Duration, logger logr.Logger, onChange ChangeFunc) *Poller {
+	return &Poller{
+		autoDetect: autoDetect,
+		interval:   interval,
+		logger:     logger,
+		current:    cfg,
+		onChange:   onChange,
+	}
+}
+
+// Start runs the auto-detection routines at every interval, until the context is done.
+func (p *Poller) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			p.detect(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns false, as every operator replica needs an up-to-date view of the cluster.
+func (p *Poller) NeedLeaderElection() bool {
+	return false
+}
+
+func (p *Poller) detect(ctx context.Context) {
+	next := p.current
+	if err := ApplyAutoDetect(p.autoDetect, &next, p.logger); err != nil {
+		p.logger.Error(err, "failed to auto-detect the configuration, will retry at the next interval")
+		return
+	}
+	if !AvailabilityChanged(p.current, next) {
+		return
+	}
+
+	p.logger.Info("the auto-detected cluster capabilities changed",
+		"openshift-routes", next.OpenShiftRoutesAvailability,
+		"prometheus-crs", next.PrometheusCRAvailability,
+		"prometheus-cr-features", next.PrometheusCRFeatures,
+		"cert-manager", next.CertManagerAvailability,
+		"rbac-permissions", next.CreateRBACPermissions,
+		"target-allocator-crd", next.TargetAllocatorAvailability,
+		"collector-crd", next.CollectorAvailability,
+		"gateway-api", next.GatewayAPIAvailability,
+		"openshift-scc", next.OpenShiftSCCAvailability,
+		"platform", next.Platform,
+		"node-platforms", next.NodePlatforms,
+		"ip-families", next.IPFamilies,
+		"keda", next.KedaAvailability,
+		"vpa", next.VPAAvailability,
+		"native-sidecars", next.NativeSidecarAvailability,
+		"istio", next.IstioAvailability,
+	)
+	if err := p.onChange(ctx, p.current, next); err != nil {
+		// keep the previous state, so that the change is retried at the next interval
+		p.logger.Error(err, "failed to apply the auto-detected changes")
+		return
+	}
+	p.current = next
+}
+
+// AvailabilityChanged returns true when any of the auto-detected traits differs between the given configurations.
+func AvailabilityChanged(previous, current config.Config) bool {
+	return previous.OpenShiftRoutesAvailability != current.OpenShiftRoutesAvailability ||
+		previous.PrometheusCRAvailability != current.PrometheusCRAvailability ||
+		previous.CertManagerAvailability != current.CertManagerAvailability ||
+		previous.CreateRBACPermissions != current.CreateRBACPermissions ||
+		previous.TargetAllocatorAvailability != current.TargetAllocatorAvailability ||
+		previous.CollectorAvailability != current.CollectorAvailability ||
+		previous.GatewayAPIAvailability != current.GatewayAPIAvailability ||
+		previous.IstioAvailability != current.IstioAvailability ||
+		previous.KedaAvailability != current.KedaAvailability ||
+		previous.VPAAvailability != current.VPAAvailability ||
+		previous.NativeSidecarAvailability != current.NativeSidecarAvailability ||
+		previous.Platform != current.Platform ||
+		!slices.Equal(previous.NodePlatforms, current.NodePlatforms) ||
+		!slices.Equal(previous.IPFamilies, current.IPFamilies) ||
+		previous.PrometheusCRFeatures != current.PrometheusCRFeatures ||
+		previous.OpenShiftSCCAvailability != current.OpenShiftSCCAvailability
+}
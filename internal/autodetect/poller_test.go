@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autodetect_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestPollerNotifiesOnChange(t *testing.T) {
+	// prepare
+	mock := &mockAutoDetect{
+		PrometheusCRsAvailabilityFunc: func() (prometheus.Availability, error) {
+			return prometheus.Available, nil
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var previous, current config.Config
+	calls := 0
+	poller := autodetect.NewPoller(mock, config.New(), 10*time.Millisecond, logr.Discard(), func(_ context.Context, p, c config.Config) error {
+		previous, current = p, c
+		calls++
+		cancel()
+		return nil
+	})
+
+	// test
+	require.NoError(t, poller.Start(ctx))
+
+	// verify
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, prometheus.NotAvailable, previous.PrometheusCRAvailability)
+	assert.Equal(t, prometheus.Available, current.PrometheusCRAvailability)
+}
+
+func TestPollerIgnoresUnchangedCapabilities(t *testing.T) {
+	// prepare
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	poller := autodetect.NewPoller(&mockAutoDetect{}, config.New(), 10*time.Millisecond, logr.Discard(), func(context.Context, config.Config, config.Config) error {
+		calls++
+		return nil
+	})
+
+	// test
+	require.NoError(t, poller.Start(ctx))
+
+	// verify
+	assert.Zero(t, calls)
+}
+
+func TestAvailabilityChanged(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		current  config.Config
+		expected bool
+	}{
+		{
+			desc:     "nothing changed",
+			current:  config.New(),
+			expected: false,
+		},
+		{
+			desc:     "cert-manager became available",
+			current:  config.New(config.WithCertManagerAvailability(certmanager.Available)),
+			expected: true,
+		},
+		{
+			desc:     "non-detected fields are ignored",
+			current:  config.New(config.WithCollectorImage("some-image")),
+			expected: false,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, autodetect.AvailabilityChanged(config.New(), tt.current))
+		})
+	}
+}
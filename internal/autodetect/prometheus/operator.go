@@ -17,3 +17,13 @@ const (
 func (p Availability) String() string {
 	return [...]string{"NotAvailable", "Available"}[p]
 }
+
+// Features represents the monitoring.coreos.com API version and optional resources served by the cluster.
+type Features struct {
+	// Version is the preferred version of the monitoring.coreos.com API, empty when the API is not served.
+	Version string
+	// ScrapeConfigs is true when the ScrapeConfig resource is served.
+	ScrapeConfigs bool
+	// Probes is true when the Probe resource is served.
+	Probes bool
+}
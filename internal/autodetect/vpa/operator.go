@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vpa
+
+// Availability represents that the VerticalPodAutoscaler API is available in the cluster.
+type Availability int
+
+const (
+	// NotAvailable represents the autoscaling.k8s.io API is not available.
+	NotAvailable Availability = iota
+
+	// Available represents the autoscaling.k8s.io API is available.
+	Available
+)
+
+func (p Availability) String() string {
+	return [...]string{"NotAvailable", "Available"}[p]
+}
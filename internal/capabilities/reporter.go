@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package capabilities publishes what the operator auto-detected about the cluster, so that admins and tools
+// can inspect it without going through the operator logs.
+package capabilities
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap holding the auto-detected capabilities, in the operator's namespace.
+	ConfigMapName = "opentelemetry-operator-capabilities"
+)
+
+var (
+	// namespaceFile is the path to the namespace file for the service account.
+	namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+var _ manager.Runnable = (*Reporter)(nil)
+var _ manager.LeaderElectionRunnable = (*Reporter)(nil)
+
+// Reporter keeps the capabilities ConfigMap in sync with the auto-detected configuration.
+type Reporter struct {
+	kubeClient  client.Client
+	log         logr.Logger
+	fipsEnabled bool
+
+	mu        sync.Mutex
+	namespace string
+	current   config.Config
+}
+
+// NewReporter creates a Reporter publishing the capabilities of the given (already auto-detected) configuration.
+func NewReporter(restConfig *rest.Config, scheme *runtime.Scheme, cfg config.Config, fipsEnabled bool, log logr.Logger) (*Reporter, error) {
+	kubeClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reporter{
+		kubeClient:  kubeClient,
+		log:         log,
+		fipsEnabled: fipsEnabled,
+		current:     cfg,
+	}, nil
+}
+
+// Start publishes the capabilities detected at startup.
+func (r *Reporter) Start(ctx context.Context) error {
+	rawNamespace, err := os.ReadFile(namespaceFile)
+	if err != nil {
+		// most likely running outside a cluster, nothing to publish to
+		r.log.Error(err, "error reading namespace file, the capabilities won't be published")
+		return nil
+	}
+
+	r.mu.Lock()
+	r.namespace = strings.TrimSpace(string(rawNamespace))
+	cfg := r.current
+	r.mu.Unlock()
+
+	if err := r.Update(ctx, cfg); err != nil {
+		r.log.Error(err, "failed to publish the operator capabilities")
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false, all the replicas publish the same content.
+func (r *Reporter) NeedLeaderElection() bool {
+	return false
+}
+
+// Update publishes the capabilities of the given configuration.
+func (r *Reporter) Update(ctx context.Context, cfg config.Config) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.current = cfg
+	if r.namespace == "" {
+		// not started yet, the current configuration is published on start
+		return nil
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigMapName,
+			Namespace: r.namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.kubeClient, cm, func() error {
+		cm.Labels = map[string]string{
+			"app.kubernetes.io/name":       "opentelemetry-operator",
+			"app.kubernetes.io/part-of":    "opentelemetry-operator",
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		}
+		cm.Data = Data(cfg, r.fipsEnabled)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write the %s/%s ConfigMap: %w", r.namespace, ConfigMapName, err)
+	}
+	return nil
+}
+
+func nodePlatforms(platforms []platform.NodePlatform) string {
+	names := make([]string, len(platforms))
+	for i, p := range platforms {
+		names[i] = p.String()
+	}
+	return strings.Join(names, ",")
+}
+
+func ipFamilies(families []corev1.IPFamily) string {
+	names := make([]string, len(families))
+	for i, f := range families {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ",")
+}
+
+// Data returns the content of the capabilities ConfigMap for the given configuration.
+func Data(cfg config.Config, fipsEnabled bool) map[string]string {
+	return map[string]string{
+		"openshift-routes":     cfg.OpenShiftRoutesAvailability.String(),
+		"prometheus-crs":       cfg.PrometheusCRAvailability.String(),
+		"cert-manager":         cfg.CertManagerAvailability.String(),
+		"rbac-permissions":     cfg.CreateRBACPermissions.String(),
+		"target-allocator-crd": cfg.TargetAllocatorAvailability.String(),
+		"collector-crd":        cfg.CollectorAvailability.String(),
+		"openshift-scc":        cfg.OpenShiftSCCAvailability.String(),
+		"gateway-api":          cfg.GatewayAPIAvailability.String(),
+		"keda":                 cfg.KedaAvailability.String(),
+		"vpa":                  cfg.VPAAvailability.String(),
+		"native-sidecars":      cfg.NativeSidecarAvailability.String(),
+		"istio":                cfg.IstioAvailability.String(),
+		"platform":             cfg.Platform.String(),
+		"node-platforms":       nodePlatforms(cfg.NodePlatforms),
+		"ip-families":          ipFamilies(cfg.IPFamilies),
+		"fips":                 strconv.FormatBool(fipsEnabled),
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package capabilities
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestNewReporter(t *testing.T) {
+	reporter, err := NewReporter(&rest.Config{}, runtime.NewScheme(), config.New(), false, logr.Discard())
+	assert.NoError(t, err)
+	assert.NotNil(t, reporter.kubeClient)
+}
+
+func TestReporter(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "namespace")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("test-namespace")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	previous := namespaceFile
+	namespaceFile = tmpFile.Name()
+	t.Cleanup(func() { namespaceFile = previous })
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cfg := config.New(config.WithOpenShiftRoutesAvailability(openshift.RoutesAvailable))
+	reporter := &Reporter{kubeClient: client, log: logr.Discard(), fipsEnabled: true, current: cfg}
+	key := types.NamespacedName{Name: ConfigMapName, Namespace: "test-namespace"}
+	ctx := context.Background()
+
+	// updates before start are published on start
+	cfg = config.New(config.WithOpenShiftRoutesAvailability(openshift.RoutesAvailable), config.WithPrometheusCRAvailability(prometheus.Available))
+	require.NoError(t, reporter.Update(ctx, cfg))
+	cm := &corev1.ConfigMap{}
+	assert.Error(t, client.Get(ctx, key, cm))
+
+	require.NoError(t, reporter.Start(ctx))
+	require.NoError(t, client.Get(ctx, key, cm))
+	assert.Equal(t, "Available", cm.Data["openshift-routes"])
+	assert.Equal(t, "Available", cm.Data["prometheus-crs"])
+	assert.Equal(t, "NotAvailable", cm.Data["cert-manager"])
+	assert.Equal(t, "true", cm.Data["fips"])
+	assert.Equal(t, "opentelemetry-operator", cm.Labels["app.kubernetes.io/managed-by"])
+
+	cfg = config.New(config.WithCertManagerAvailability(certmanager.Available))
+	require.NoError(t, reporter.Update(ctx, cfg))
+	require.NoError(t, client.Get(ctx, key, cm))
+	assert.Equal(t, "NotAvailable", cm.Data["openshift-routes"])
+	assert.Equal(t, "Available", cm.Data["cert-manager"])
+}
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package certs generates the self-signed CA and the certificates it signs, used by the operator for the mTLS of its
+// workloads when cert-manager isn't available.
+package certs
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+const (
+	// CAValidity is how long the CA certificates are valid.
+	CAValidity = 365 * 24 * time.Hour
+	// LeafValidity is how long the certificates signed by the CA are valid.
+	LeafValidity = 90 * 24 * time.Hour
+
+	organizationalUnit = "opentelemetry-operator"
+)
+
+// KeyPair is a PEM encoded certificate and its PEM encoded private key.
+type KeyPair struct {
+	Cert []byte
+	Key  []byte
+}
+
+// NewCA generates a self-signed CA, valid from now for CAValidity.
+func NewCA(commonName string, now time.Time) (KeyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName, OrganizationalUnit: []string{organizationalUnit}},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(CAValidity),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	return newKeyPair(template, nil, nil)
+}
+
+// NewLeaf generates a certificate for the DNS names, signed by the CA and valid from now for LeafValidity, for both
+// the clients and the servers.
+func NewLeaf(ca KeyPair, commonName string, dnsNames []string, now time.Time) (KeyPair, error) {
+	caCert, err := ParseCertificate(ca.Cert)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	caKey, err := parseKey(ca.Key)
+	if err != nil {
+		return KeyPair{}, fmt.Errorf("invalid CA key: %w", err)
+	}
+	notAfter := now.Add(LeafValidity)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: commonName, OrganizationalUnit: []string{organizationalUnit}},
+		DNSNames:    dnsNames,
+		NotBefore:   now.Add(-time.Minute),
+		NotAfter:    notAfter,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	return newKeyPair(template, caCert, caKey)
+}
+
+// RenewalTime returns when the certificate is due for renewal, after two thirds of its validity, as cert-manager
+// does by default.
+func RenewalTime(cert *x509.Certificate) time.Time {
+	return cert.NotAfter.Add(-cert.NotAfter.Sub(cert.NotBefore) / 3)
+}
+
+// Valid reports whether the certificate of the key pair is signed by the CA, has the DNS names and isn't due for
+// renewal, in which case it can be kept.
+func Valid(pair KeyPair, ca *x509.Certificate, dnsNames []string, now time.Time) bool {
+	cert, err := ParseCertificate(pair.Cert)
+	if err != nil {
+		return false
+	}
+	if _, err = parseKey(pair.Key); err != nil {
+		return false
+	}
+	if ca != nil && cert.CheckSignatureFrom(ca) != nil {
+		return false
+	}
+	return slices.Equal(cert.DNSNames, dnsNames) && now.Before(RenewalTime(cert))
+}
+
+// Bundle returns the PEM bundle of the certificates of the PEM encoded data, without the duplicated and the expired
+// ones, e.g. to keep trusting the previous CA during its renewal.
+func Bundle(now time.Time, data ...[]byte) []byte {
+	var bundle bytes.Buffer
+	seen := map[string]bool{}
+	for _, rest := range data {
+		for {
+			var block *pem.Block
+			if block, rest = pem.Decode(rest); block == nil {
+				break
+			}
+			if block.Type != "CERTIFICATE" || seen[string(block.Bytes)] {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil || now.After(cert.NotAfter) {
+				continue
+			}
+			seen[string(block.Bytes)] = true
+			_ = pem.Encode(&bundle, block)
+		}
+	}
+	return bundle.Bytes()
+}
+
+// ParseCertificate parses the first certificate of the PEM encoded data.
+func ParseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parseKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PRIVATE KEY" {
+		return nil, errors.New("no PEM encoded private key")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the private key isn't an ECDSA key")
+	}
+	return ecKey, nil
+}
+
+// newKeyPair generates a key and its certificate, self-signed when the parent is nil. The keys are ECDSA P-256 keys,
+// approved by FIPS 140.
+func newKeyPair(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return KeyPair{}, err
+	}
+	template.SerialNumber = serial
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return KeyPair{}, err
+	}
+	return KeyPair{
+		Cert: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		Key:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package certs
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLeaf(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	ca, err := NewCA("test-ca-cert", now)
+	require.NoError(t, err)
+	caCert, err := ParseCertificate(ca.Cert)
+	require.NoError(t, err)
+	assert.True(t, caCert.IsCA)
+
+	dnsNames := []string{"test-targetallocator", "test-targetallocator.default.svc"}
+	leaf, err := NewLeaf(ca, "test-ta-server-cert", dnsNames, now)
+	require.NoError(t, err)
+	_, err = tls.X509KeyPair(leaf.Cert, leaf.Key)
+	require.NoError(t, err)
+
+	cert, err := ParseCertificate(leaf.Cert)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	_, err = cert.Verify(x509.VerifyOptions{
+		DNSName:     "test-targetallocator.default.svc",
+		Roots:       roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(LeafValidity), cert.NotAfter)
+
+	// the leaf certificates don't outlive their CA
+	leaf, err = NewLeaf(ca, "test-ta-server-cert", dnsNames, now.Add(CAValidity-time.Hour))
+	require.NoError(t, err)
+	cert, err = ParseCertificate(leaf.Cert)
+	require.NoError(t, err)
+	assert.Equal(t, caCert.NotAfter, cert.NotAfter)
+}
+
+func TestValid(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	ca, err := NewCA("test-ca-cert", now)
+	require.NoError(t, err)
+	caCert, err := ParseCertificate(ca.Cert)
+	require.NoError(t, err)
+	otherCA, err := NewCA("other-ca-cert", now)
+	require.NoError(t, err)
+	otherCACert, err := ParseCertificate(otherCA.Cert)
+	require.NoError(t, err)
+
+	dnsNames := []string{"test-targetallocator"}
+	leaf, err := NewLeaf(ca, "test-ta-client-cert", dnsNames, now)
+	require.NoError(t, err)
+
+	assert.True(t, Valid(leaf, caCert, dnsNames, now))
+	assert.True(t, Valid(ca, nil, nil, now))
+	assert.False(t, Valid(leaf, caCert, dnsNames, now.Add(LeafValidity*2/3)), "due for renewal")
+	assert.False(t, Valid(leaf, otherCACert, dnsNames, now), "signed by another CA")
+	assert.False(t, Valid(leaf, caCert, []string{"other-targetallocator"}, now), "other DNS names")
+	assert.False(t, Valid(KeyPair{Cert: leaf.Cert}, caCert, dnsNames, now), "no key")
+	assert.False(t, Valid(KeyPair{}, caCert, dnsNames, now), "no certificate")
+}
+
+func TestBundle(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	previous, err := NewCA("test-ca-cert", now.Add(-CAValidity*2/3))
+	require.NoError(t, err)
+	current, err := NewCA("test-ca-cert", now)
+	require.NoError(t, err)
+
+	bundle := Bundle(now, current.Cert, previous.Cert, nil)
+	assert.Equal(t, append(append([]byte{}, current.Cert...), previous.Cert...), bundle)
+	assert.Equal(t, bundle, Bundle(now, current.Cert, bundle), "deduplicated")
+	assert.Equal(t, current.Cert, Bundle(now.Add(CAValidity/2), current.Cert, bundle), "expired")
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivers
+
+import (
+	"slices"
+
+	"github.com/go-logr/logr"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+type prometheusConfig struct {
+	Config struct {
+		ScrapeConfigs []struct {
+			KubernetesSDConfigs []kubernetesSDConfig `mapstructure:"kubernetes_sd_configs"`
+		} `mapstructure:"scrape_configs"`
+	} `mapstructure:"config"`
+	TargetAllocator map[string]interface{} `mapstructure:"target_allocator"`
+}
+
+type kubernetesSDConfig struct {
+	Role           string `mapstructure:"role"`
+	APIServer      string `mapstructure:"api_server"`
+	KubeConfig     string `mapstructure:"kubeconfig_file"`
+	AttachMetadata struct {
+		Node bool `mapstructure:"node"`
+	} `mapstructure:"attach_metadata"`
+}
+
+func generatePrometheusRbacRules(_ logr.Logger, config prometheusConfig) ([]rbacv1.PolicyRule, error) {
+	// https://prometheus.io/docs/prometheus/latest/configuration/configuration/#kubernetes_sd_config
+	// the target allocator discovers the targets of the collectors using it
+	if config.TargetAllocator != nil {
+		return nil, nil
+	}
+	resources := map[string][]string{}
+	add := func(group string, names ...string) {
+		for _, name := range names {
+			if !slices.Contains(resources[group], name) {
+				resources[group] = append(resources[group], name)
+			}
+		}
+	}
+	for _, scrapeConfig := range config.Config.ScrapeConfigs {
+		for _, sd := range scrapeConfig.KubernetesSDConfigs {
+			// the other clusters are out of reach of the operator
+			if sd.APIServer != "" || sd.KubeConfig != "" {
+				continue
+			}
+			switch sd.Role {
+			case "node":
+				add("", "nodes")
+			case "pod":
+				add("", "pods")
+			case "service":
+				add("", "services")
+			case "endpoints":
+				add("", "endpoints", "services", "pods")
+			case "endpointslice":
+				add("discovery.k8s.io", "endpointslices")
+				add("", "services", "pods")
+			case "ingress":
+				add("networking.k8s.io", "ingresses")
+			default:
+				continue
+			}
+			if sd.AttachMetadata.Node && sd.Role != "node" && sd.Role != "service" && sd.Role != "ingress" {
+				add("", "nodes")
+			}
+		}
+	}
+
+	var prs []rbacv1.PolicyRule
+	for _, group := range []string{"", "discovery.k8s.io", "networking.k8s.io"} {
+		if len(resources[group]) == 0 {
+			continue
+		}
+		slices.Sort(resources[group])
+		prs = append(prs, rbacv1.PolicyRule{
+			APIGroups: []string{group},
+			Resources: resources[group],
+			Verbs:     []string{"get", "list", "watch"},
+		})
+	}
+	return prs, nil
+}
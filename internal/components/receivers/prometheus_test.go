@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receivers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestGeneratePrometheusRbacRules(t *testing.T) {
+	verbs := []string{"get", "list", "watch"}
+	scrapeConfig := func(sdConfigs ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"config": map[string]interface{}{
+				"scrape_configs": []interface{}{
+					map[string]interface{}{"job_name": "job", "kubernetes_sd_configs": sdConfigs},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		config map[string]interface{}
+		want   []rbacv1.PolicyRule
+	}{
+		{
+			name: "static targets",
+			config: map[string]interface{}{
+				"config": map[string]interface{}{
+					"scrape_configs": []interface{}{
+						map[string]interface{}{"job_name": "job", "static_configs": []interface{}{map[string]interface{}{"targets": []interface{}{"app:8080"}}}},
+					},
+				},
+			},
+		},
+		{
+			name:   "pod role",
+			config: scrapeConfig(map[string]interface{}{"role": "pod"}),
+			want:   []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: verbs}},
+		},
+		{
+			name:   "pod role with the node metadata",
+			config: scrapeConfig(map[string]interface{}{"role": "pod", "attach_metadata": map[string]interface{}{"node": true}}),
+			want:   []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"nodes", "pods"}, Verbs: verbs}},
+		},
+		{
+			name: "several roles",
+			config: scrapeConfig(
+				map[string]interface{}{"role": "endpointslice", "namespaces": map[string]interface{}{"own_namespace": true}},
+				map[string]interface{}{"role": "ingress"},
+				map[string]interface{}{"role": "service"},
+			),
+			want: []rbacv1.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"pods", "services"}, Verbs: verbs},
+				{APIGroups: []string{"discovery.k8s.io"}, Resources: []string{"endpointslices"}, Verbs: verbs},
+				{APIGroups: []string{"networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: verbs},
+			},
+		},
+		{
+			name:   "other cluster",
+			config: scrapeConfig(map[string]interface{}{"role": "node", "api_server": "https://other:6443"}),
+		},
+		{
+			name: "target allocator",
+			config: map[string]interface{}{
+				"config":           scrapeConfig(map[string]interface{}{"role": "pod"})["config"],
+				"target_allocator": map[string]interface{}{"endpoint": "http://collector-targetallocator"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReceiverFor("prometheus").GetRBACRules(logr.Discard(), tt.config)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -5,15 +5,27 @@
 package config
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/istio"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
@@ -34,8 +46,14 @@ type Config struct {
 	AutoInstrumentationPythonImage string
 	// CollectorImage represents the flag to override the OpenTelemetry Collector container image.
 	CollectorImage string
+	// ConfigReloaderImage is the image of the container reloading the configuration of the collectors with the Reload
+	// rollout strategy. It must provide sh, md5sum and pkill.
+	ConfigReloaderImage string
 	// CollectorConfigMapEntry represents the configuration file name for the collector. Immutable.
 	CollectorConfigMapEntry string
+	// DefaultSidecarCollector is the namespace/name of the sidecar collector injected into the pods requesting a
+	// sidecar when their namespace has no sidecar collector.
+	DefaultSidecarCollector string
 	// CreateRBACPermissions is true when the operator can create RBAC permissions for SAs running a collector instance. Immutable.
 	CreateRBACPermissions autoRBAC.Availability
 	// EnableMultiInstrumentation is true when the operator supports multi instrumentation.
@@ -48,12 +66,27 @@ type Config struct {
 	EnableGoAutoInstrumentation bool
 	// EnableNginxAutoInstrumentation is true when the operator supports nginx auto instrumentation.
 	EnableNginxAutoInstrumentation bool
+	// EnablePHPAutoInstrumentation is true when the operator supports PHP auto instrumentation.
+	EnablePHPAutoInstrumentation bool
+	// EnableRubyAutoInstrumentation is true when the operator supports Ruby auto instrumentation.
+	EnableRubyAutoInstrumentation bool
 	// EnablePythonAutoInstrumentation is true when the operator supports dotnet auto instrumentation.
 	EnablePythonAutoInstrumentation bool
 	// EnableNodeJSAutoInstrumentation is true when the operator supports dotnet auto instrumentation.
 	EnableNodeJSAutoInstrumentation bool
 	// EnableJavaAutoInstrumentation is true when the operator supports java auto instrumentation.
 	EnableJavaAutoInstrumentation bool
+	// InstrumentationExcludedNamespaces selects the namespaces whose pods are never auto-instrumented, whatever their
+	// annotations. Nil when no namespace is excluded.
+	InstrumentationExcludedNamespaces labels.Selector
+	// ResourceSelector selects the OpenTelemetryCollector and Instrumentation resources the operator reconciles,
+	// letting several operators share a cluster. Nil when the operator reconciles all of them.
+	ResourceSelector labels.Selector
+	// InstrumentationExcludedPods selects the pods which are never auto-instrumented. Nil when no pod is excluded.
+	InstrumentationExcludedPods labels.Selector
+	// InstrumentationExcludedContainers matches the names of the containers which are never auto-instrumented.
+	// Nil when no container is excluded.
+	InstrumentationExcludedContainers *regexp.Regexp
 	// AutoInstrumentationDotNetImage is the OpenTelemetry DotNet auto-instrumentation container image.
 	AutoInstrumentationDotNetImage string
 	// AutoInstrumentationGoImage is the OpenTelemetry Go auto-instrumentation container image.
@@ -62,6 +95,10 @@ type Config struct {
 	AutoInstrumentationApacheHttpdImage string
 	// AutoInstrumentationNginxImage is the OpenTelemetry Nginx auto-instrumentation container image.
 	AutoInstrumentationNginxImage string
+	// AutoInstrumentationPHPImage is the OpenTelemetry PHP auto-instrumentation container image.
+	AutoInstrumentationPHPImage string
+	// AutoInstrumentationRubyImage is the OpenTelemetry Ruby auto-instrumentation container image.
+	AutoInstrumentationRubyImage string
 	// TargetAllocatorConfigMapEntry represents the configuration file name for the TargetAllocator. Immutable.
 	TargetAllocatorConfigMapEntry string
 	// OperatorOpAMPBridgeImageConfigMapEntry represents the configuration file name for the OpAMPBridge. Immutable.
@@ -75,14 +112,42 @@ type Config struct {
 	OpenShiftRoutesAvailability openshift.RoutesAvailability
 	// PrometheusCRAvailability represents the availability of the Prometheus Operator CRDs.
 	PrometheusCRAvailability prometheus.Availability
+	// PrometheusCRFeatures represents the version and the optional resources of the Prometheus operator API.
+	PrometheusCRFeatures prometheus.Features
 	// CertManagerAvailability represents the availability of the Cert-Manager.
 	CertManagerAvailability certmanager.Availability
 	// TargetAllocatorAvailability represents the availability of the TargetAllocator CRD.
 	TargetAllocatorAvailability targetallocator.Availability
 	// CollectorAvailability represents the availability of the OpenTelemetryCollector CRD.
 	CollectorAvailability collector.Availability
+	// GatewayAPIAvailability represents the availability of the Gateway API routes.
+	GatewayAPIAvailability gatewayapi.Availability
+	// OpenShiftSCCAvailability represents the availability of the OpenShift SecurityContextConstraints API.
+	OpenShiftSCCAvailability openshift.SCCAvailability
+	// Platform represents the platform (cloud provider or distribution) the operator is running on.
+	Platform platform.Platform
+	// NodePlatforms represents the distinct operating systems and architectures of the cluster nodes.
+	NodePlatforms []platform.NodePlatform
+	// IPFamilies represents the IP families of the pod addresses of the cluster.
+	IPFamilies []corev1.IPFamily
+	// KedaAvailability represents the availability of the KEDA ScaledObject API.
+	KedaAvailability keda.Availability
+	// VPAAvailability represents the availability of the VerticalPodAutoscaler API.
+	VPAAvailability vpa.Availability
+	// NativeSidecarAvailability represents whether the cluster supports native sidecar containers.
+	NativeSidecarAvailability nativesidecar.Availability
+	// IstioAvailability represents the availability of the Istio service mesh.
+	IstioAvailability istio.Availability
+	// AutoDetectFrequency is how often the operator re-runs the auto-detection routines. Zero disables the periodic detection.
+	AutoDetectFrequency time.Duration
 	// IgnoreMissingCollectorCRDs is true if the operator can ignore missing OpenTelemetryCollector CRDs.
 	IgnoreMissingCollectorCRDs bool
+	// ReconcileOptions limits the concurrency and the retries of the reconciliations of the controllers.
+	ReconcileOptions ReconcileOptions
+	// ControllerReconcileOptions overrides the ReconcileOptions of the controllers, by controller name.
+	ControllerReconcileOptions map[string]ReconcileOptions
+	// UpgradePolicy controls the automatic upgrades of the collectors when the operator is upgraded.
+	UpgradePolicy UpgradePolicy
 	// LabelsFilter Returns the filters converted to regex strings used to filter out unwanted labels from propagations.
 	LabelsFilter []string
 	// AnnotationsFilter Returns the filters converted to regex strings used to filter out unwanted labels from propagations.
@@ -99,6 +164,13 @@ func New(opts ...Option) Config {
 		certManagerAvailability:           certmanager.NotAvailable,
 		targetAllocatorAvailability:       targetallocator.NotAvailable,
 		collectorAvailability:             collector.NotAvailable,
+		gatewayAPIAvailability:            gatewayapi.NotAvailable,
+		openShiftSCCAvailability:          openshift.SCCNotAvailable,
+		platform:                          platform.Unknown,
+		kedaAvailability:                  keda.NotAvailable,
+		vpaAvailability:                   vpa.NotAvailable,
+		nativeSidecarAvailability:         nativesidecar.NotAvailable,
+		istioAvailability:                 istio.NotAvailable,
 		collectorConfigMapEntry:           defaultCollectorConfigMapEntry,
 		targetAllocatorConfigMapEntry:     defaultTargetAllocatorConfigMapEntry,
 		operatorOpAMPBridgeConfigMapEntry: defaultOperatorOpAMPBridgeConfigMapEntry,
@@ -106,6 +178,8 @@ func New(opts ...Option) Config {
 		version:                           version.Get(),
 		enableJavaInstrumentation:         true,
 		annotationsFilter:                 []string{"kubectl.kubernetes.io/last-applied-configuration"},
+		reconcileOptions:                  DefaultReconcileOptions,
+		upgradePolicy:                     UpgradePolicy{Channel: UpgradeChannelMinor},
 	}
 
 	for _, opt := range opts {
@@ -115,14 +189,25 @@ func New(opts ...Option) Config {
 	return Config{
 		CollectorImage:                      o.collectorImage,
 		CollectorConfigMapEntry:             o.collectorConfigMapEntry,
+		ConfigReloaderImage:                 o.configReloaderImage,
+		DefaultSidecarCollector:             o.defaultSidecarCollector,
 		EnableMultiInstrumentation:          o.enableMultiInstrumentation,
 		EnableApacheHttpdInstrumentation:    o.enableApacheHttpdInstrumentation,
 		EnableDotNetInstrumentation:         o.enableDotNetInstrumentation,
 		EnableGoAutoInstrumentation:         o.enableGoInstrumentation,
 		EnableNginxAutoInstrumentation:      o.enableNginxInstrumentation,
+		EnablePHPAutoInstrumentation:        o.enablePHPInstrumentation,
+		EnableRubyAutoInstrumentation:       o.enableRubyInstrumentation,
 		EnablePythonAutoInstrumentation:     o.enablePythonInstrumentation,
 		EnableNodeJSAutoInstrumentation:     o.enableNodeJSInstrumentation,
 		EnableJavaAutoInstrumentation:       o.enableJavaInstrumentation,
+		InstrumentationExcludedNamespaces:   o.instrumentationExcludedNamespaces,
+		InstrumentationExcludedPods:         o.instrumentationExcludedPods,
+		ResourceSelector:                    o.resourceSelector,
+		ReconcileOptions:                    o.reconcileOptions,
+		ControllerReconcileOptions:          o.controllerReconcileOptions,
+		UpgradePolicy:                       o.upgradePolicy,
+		InstrumentationExcludedContainers:   o.instrumentationExcludedContainers,
 		TargetAllocatorImage:                o.targetAllocatorImage,
 		OperatorOpAMPBridgeImage:            o.operatorOpAMPBridgeImage,
 		TargetAllocatorConfigMapEntry:       o.targetAllocatorConfigMapEntry,
@@ -130,9 +215,20 @@ func New(opts ...Option) Config {
 		logger:                              o.logger,
 		OpenShiftRoutesAvailability:         o.openshiftRoutesAvailability,
 		PrometheusCRAvailability:            o.prometheusCRAvailability,
+		PrometheusCRFeatures:                o.prometheusCRFeatures,
 		CertManagerAvailability:             o.certManagerAvailability,
 		TargetAllocatorAvailability:         o.targetAllocatorAvailability,
 		CollectorAvailability:               o.collectorAvailability,
+		GatewayAPIAvailability:              o.gatewayAPIAvailability,
+		OpenShiftSCCAvailability:            o.openShiftSCCAvailability,
+		Platform:                            o.platform,
+		NodePlatforms:                       o.nodePlatforms,
+		IPFamilies:                          o.ipFamilies,
+		KedaAvailability:                    o.kedaAvailability,
+		VPAAvailability:                     o.vpaAvailability,
+		NativeSidecarAvailability:           o.nativeSidecarAvailability,
+		IstioAvailability:                   o.istioAvailability,
+		AutoDetectFrequency:                 o.autoDetectFrequency,
 		IgnoreMissingCollectorCRDs:          o.ignoreMissingCollectorCRDs,
 		AutoInstrumentationJavaImage:        o.autoInstrumentationJavaImage,
 		AutoInstrumentationNodeJSImage:      o.autoInstrumentationNodeJSImage,
@@ -141,8 +237,16 @@ func New(opts ...Option) Config {
 		AutoInstrumentationGoImage:          o.autoInstrumentationGoImage,
 		AutoInstrumentationApacheHttpdImage: o.autoInstrumentationApacheHttpdImage,
 		AutoInstrumentationNginxImage:       o.autoInstrumentationNginxImage,
+		AutoInstrumentationPHPImage:         o.autoInstrumentationPHPImage,
+		AutoInstrumentationRubyImage:        o.autoInstrumentationRubyImage,
 		LabelsFilter:                        o.labelsFilter,
 		AnnotationsFilter:                   o.annotationsFilter,
 		CreateRBACPermissions:               o.createRBACPermissions,
 	}
 }
+
+// ManagesResource tells whether the labels of the OpenTelemetryCollector or Instrumentation resource match the
+// ResourceSelector of the operator.
+func (c Config) ManagesResource(obj metav1.Object) bool {
+	return c.ResourceSelector == nil || c.ResourceSelector.Matches(labels.Set(obj.GetLabels()))
+}
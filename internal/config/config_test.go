@@ -10,14 +10,23 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/istio"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 )
 
@@ -37,6 +46,19 @@ func TestNewConfig(t *testing.T) {
 	assert.Equal(t, prometheus.Available, cfg.PrometheusCRAvailability)
 }
 
+func TestManagesResource(t *testing.T) {
+	selector, err := labels.Parse("team=a")
+	require.NoError(t, err)
+	matching := &metav1.ObjectMeta{Labels: map[string]string{"team": "a"}}
+	other := &metav1.ObjectMeta{Labels: map[string]string{"team": "b"}}
+
+	assert.True(t, config.New().ManagesResource(other))
+	cfg := config.New(config.WithResourceSelector(selector))
+	assert.True(t, cfg.ManagesResource(matching))
+	assert.False(t, cfg.ManagesResource(other))
+	assert.False(t, cfg.ManagesResource(&metav1.ObjectMeta{}))
+}
+
 func TestConfigChangesOnAutoDetect(t *testing.T) {
 	// prepare
 	mock := &mockAutoDetect{
@@ -85,10 +107,90 @@ var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 type mockAutoDetect struct {
 	OpenShiftRoutesAvailabilityFunc func() (openshift.RoutesAvailability, error)
 	PrometheusCRsAvailabilityFunc   func() (prometheus.Availability, error)
+	PrometheusCRFeaturesFunc        func() (prometheus.Features, error)
 	RBACPermissionsFunc             func(ctx context.Context) (rbac.Availability, error)
 	CertManagerAvailabilityFunc     func(ctx context.Context) (certmanager.Availability, error)
 	TargetAllocatorAvailabilityFunc func() (targetallocator.Availability, error)
 	CollectorAvailabilityFunc       func() (collector.Availability, error)
+	GatewayAPIAvailabilityFunc      func() (gatewayapi.Availability, error)
+	OpenShiftSCCAvailabilityFunc    func() (openshift.SCCAvailability, error)
+	PlatformFunc                    func() (platform.Platform, error)
+	NodePlatformsFunc               func() ([]platform.NodePlatform, error)
+	IPFamiliesFunc                  func() ([]corev1.IPFamily, error)
+	KedaAvailabilityFunc            func() (keda.Availability, error)
+	VPAAvailabilityFunc             func() (vpa.Availability, error)
+	NativeSidecarAvailabilityFunc   func() (nativesidecar.Availability, error)
+	IstioAvailabilityFunc           func() (istio.Availability, error)
+}
+
+func (m *mockAutoDetect) GatewayAPIAvailability() (gatewayapi.Availability, error) {
+	if m.GatewayAPIAvailabilityFunc != nil {
+		return m.GatewayAPIAvailabilityFunc()
+	}
+	return gatewayapi.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) IstioAvailability() (istio.Availability, error) {
+	if m.IstioAvailabilityFunc != nil {
+		return m.IstioAvailabilityFunc()
+	}
+	return istio.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) KedaAvailability() (keda.Availability, error) {
+	if m.KedaAvailabilityFunc != nil {
+		return m.KedaAvailabilityFunc()
+	}
+	return keda.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) VPAAvailability() (vpa.Availability, error) {
+	if m.VPAAvailabilityFunc != nil {
+		return m.VPAAvailabilityFunc()
+	}
+	return vpa.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) NativeSidecarAvailability() (nativesidecar.Availability, error) {
+	if m.NativeSidecarAvailabilityFunc != nil {
+		return m.NativeSidecarAvailabilityFunc()
+	}
+	return nativesidecar.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) Platform(_ context.Context) (platform.Platform, error) {
+	if m.PlatformFunc != nil {
+		return m.PlatformFunc()
+	}
+	return platform.Unknown, nil
+}
+
+func (m *mockAutoDetect) PrometheusCRFeatures() (prometheus.Features, error) {
+	if m.PrometheusCRFeaturesFunc != nil {
+		return m.PrometheusCRFeaturesFunc()
+	}
+	return prometheus.Features{}, nil
+}
+
+func (m *mockAutoDetect) OpenShiftSCCAvailability() (openshift.SCCAvailability, error) {
+	if m.OpenShiftSCCAvailabilityFunc != nil {
+		return m.OpenShiftSCCAvailabilityFunc()
+	}
+	return openshift.SCCNotAvailable, nil
+}
+
+func (m *mockAutoDetect) NodePlatforms(_ context.Context) ([]platform.NodePlatform, error) {
+	if m.NodePlatformsFunc != nil {
+		return m.NodePlatformsFunc()
+	}
+	return nil, nil
+}
+
+func (m *mockAutoDetect) IPFamilies(_ context.Context) ([]corev1.IPFamily, error) {
+	if m.IPFamiliesFunc != nil {
+		return m.IPFamiliesFunc()
+	}
+	return nil, nil
 }
 
 func (m *mockAutoDetect) FIPSEnabled(_ context.Context) bool {
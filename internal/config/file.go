@@ -0,0 +1,289 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+// File is the configuration file of the operator. Its settings replace the defaults of the flags of the same name,
+// the flags set on the command line or by their env var taking precedence over the file.
+type File struct {
+	Images                  FileImages          `json:"images,omitempty"`
+	FeatureGates            *string             `json:"featureGates,omitempty"`
+	Webhook                 FileWebhook         `json:"webhook,omitempty"`
+	Detection               FileDetection       `json:"detection,omitempty"`
+	Instrumentation         FileInstrumentation `json:"instrumentation,omitempty"`
+	Reconcile               FileReconcile       `json:"reconcile,omitempty"`
+	Tracing                 FileTracing         `json:"tracing,omitempty"`
+	Upgrade                 FileUpgrade         `json:"upgrade,omitempty"`
+	DefaultSidecarCollector *string             `json:"defaultSidecarCollector,omitempty"`
+	ResourceSelector        *string             `json:"resourceSelector,omitempty"`
+	WatchNamespaceSelector  *string             `json:"watchNamespaceSelector,omitempty"`
+	LabelsFilter            []string            `json:"labelsFilter,omitempty"`
+	AnnotationsFilter       []string            `json:"annotationsFilter,omitempty"`
+}
+
+// FileImages are the default images of the managed workloads.
+type FileImages struct {
+	Collector           *string                       `json:"collector,omitempty"`
+	TargetAllocator     *string                       `json:"targetAllocator,omitempty"`
+	OpAMPBridge         *string                       `json:"opampBridge,omitempty"`
+	ConfigReloader      *string                       `json:"configReloader,omitempty"`
+	AutoInstrumentation FileAutoInstrumentationImages `json:"autoInstrumentation,omitempty"`
+}
+
+// FileAutoInstrumentationImages are the default images of the auto-instrumentations.
+type FileAutoInstrumentationImages struct {
+	ApacheHttpd *string `json:"apacheHttpd,omitempty"`
+	DotNet      *string `json:"dotnet,omitempty"`
+	Go          *string `json:"go,omitempty"`
+	Java        *string `json:"java,omitempty"`
+	Nginx       *string `json:"nginx,omitempty"`
+	NodeJS      *string `json:"nodejs,omitempty"`
+	PHP         *string `json:"php,omitempty"`
+	Python      *string `json:"python,omitempty"`
+	Ruby        *string `json:"ruby,omitempty"`
+}
+
+// FileWebhook configures the webhook server of the operator, and its MutatingWebhookConfiguration.
+type FileWebhook struct {
+	Port                         *int                          `json:"port,omitempty"`
+	TLSMinVersion                *string                       `json:"tlsMinVersion,omitempty"`
+	TLSCipherSuites              []string                      `json:"tlsCipherSuites,omitempty"`
+	MutatingWebhookConfiguration *string                       `json:"mutatingWebhookConfiguration,omitempty"`
+	ExcludedNamespaces           []string                      `json:"excludedNamespaces,omitempty"`
+	Webhooks                     map[string]FileWebhookOptions `json:"webhooks,omitempty"`
+}
+
+// FileWebhookOptions are the failure policy and the timeout of a mutating webhook of the operator.
+type FileWebhookOptions struct {
+	FailurePolicy  *string `json:"failurePolicy,omitempty"`
+	TimeoutSeconds *int    `json:"timeoutSeconds,omitempty"`
+}
+
+// FileDetection configures the auto-detection of the cluster capabilities.
+type FileDetection struct {
+	Frequency                  *metav1.Duration `json:"frequency,omitempty"`
+	IgnoreMissingCollectorCRDs *bool            `json:"ignoreMissingCollectorCRDs,omitempty"`
+	CheckImagePlatforms        *bool            `json:"checkImagePlatforms,omitempty"`
+}
+
+// FileInstrumentation configures the auto-instrumentation of the pods.
+type FileInstrumentation struct {
+	EnableMultiInstrumentation *bool                  `json:"enableMultiInstrumentation,omitempty"`
+	Languages                  FileInstrumentationSet `json:"languages,omitempty"`
+	ExcludedNamespaces         *string                `json:"excludedNamespaces,omitempty"`
+	ExcludedPods               *string                `json:"excludedPods,omitempty"`
+	ExcludedContainers         *string                `json:"excludedContainers,omitempty"`
+}
+
+// FileInstrumentationSet enables the auto-instrumentation of the languages.
+type FileInstrumentationSet struct {
+	ApacheHttpd *bool `json:"apacheHttpd,omitempty"`
+	DotNet      *bool `json:"dotnet,omitempty"`
+	Go          *bool `json:"go,omitempty"`
+	Java        *bool `json:"java,omitempty"`
+	Nginx       *bool `json:"nginx,omitempty"`
+	NodeJS      *bool `json:"nodejs,omitempty"`
+	PHP         *bool `json:"php,omitempty"`
+	Python      *bool `json:"python,omitempty"`
+	Ruby        *bool `json:"ruby,omitempty"`
+}
+
+// FileReconcile limits the concurrency and the retries of the reconciliations of all the controllers, and of each
+// controller by name.
+type FileReconcile struct {
+	FileReconcileOptions
+	Controllers map[string]FileReconcileOptions `json:"controllers,omitempty"`
+}
+
+// FileReconcileOptions limits the concurrency and the retries of the reconciliations of a controller.
+type FileReconcileOptions struct {
+	MaxConcurrentReconciles *int             `json:"maxConcurrentReconciles,omitempty"`
+	BaseBackoff             *metav1.Duration `json:"baseBackoff,omitempty"`
+	MaxBackoff              *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// FileTracing configures the tracing of the reconciliations and the webhooks of the operator.
+type FileTracing struct {
+	OTLPEndpoint *string `json:"otlpEndpoint,omitempty"`
+}
+
+// FileUpgrade controls the automatic upgrades of the collectors when the operator is upgraded.
+type FileUpgrade struct {
+	Channel            *string                 `json:"channel,omitempty"`
+	MaintenanceWindows []FileMaintenanceWindow `json:"maintenanceWindows,omitempty"`
+}
+
+// FileMaintenanceWindow is a recurring period during which the collectors can be upgraded.
+type FileMaintenanceWindow struct {
+	Schedule string          `json:"schedule"`
+	Duration metav1.Duration `json:"duration"`
+}
+
+// ParseFile parses the content of the configuration file, rejecting the unknown settings.
+func ParseFile(content []byte) (*File, error) {
+	f := &File{}
+	if err := yaml.UnmarshalStrict(content, f); err != nil {
+		return nil, fmt.Errorf("the operator configuration file is invalid: %w", err)
+	}
+	return f, nil
+}
+
+// Flags returns the values of the flags set by the file, by flag name.
+func (f *File) Flags() map[string][]string {
+	flags := map[string][]string{}
+	setString := func(name string, value *string) {
+		if value != nil {
+			flags[name] = []string{*value}
+		}
+	}
+	setBool := func(name string, value *bool) {
+		if value != nil {
+			flags[name] = []string{strconv.FormatBool(*value)}
+		}
+	}
+	setStrings := func(name string, values []string) {
+		if len(values) > 0 {
+			flags[name] = values
+		}
+	}
+
+	setString("collector-image", f.Images.Collector)
+	setString("target-allocator-image", f.Images.TargetAllocator)
+	setString("operator-opamp-bridge-image", f.Images.OpAMPBridge)
+	setString("config-reloader-image", f.Images.ConfigReloader)
+	setString("auto-instrumentation-apache-httpd-image", f.Images.AutoInstrumentation.ApacheHttpd)
+	setString("auto-instrumentation-dotnet-image", f.Images.AutoInstrumentation.DotNet)
+	setString("auto-instrumentation-go-image", f.Images.AutoInstrumentation.Go)
+	setString("auto-instrumentation-java-image", f.Images.AutoInstrumentation.Java)
+	setString("auto-instrumentation-nginx-image", f.Images.AutoInstrumentation.Nginx)
+	setString("auto-instrumentation-nodejs-image", f.Images.AutoInstrumentation.NodeJS)
+	setString("auto-instrumentation-php-image", f.Images.AutoInstrumentation.PHP)
+	setString("auto-instrumentation-python-image", f.Images.AutoInstrumentation.Python)
+	setString("auto-instrumentation-ruby-image", f.Images.AutoInstrumentation.Ruby)
+	setString("feature-gates", f.FeatureGates)
+
+	if f.Webhook.Port != nil {
+		flags["webhook-port"] = []string{strconv.Itoa(*f.Webhook.Port)}
+	}
+	setString("tls-min-version", f.Webhook.TLSMinVersion)
+	setStrings("tls-cipher-suites", f.Webhook.TLSCipherSuites)
+	setString("mutating-webhook-configuration", f.Webhook.MutatingWebhookConfiguration)
+	setStrings("webhook-excluded-namespaces", f.Webhook.ExcludedNamespaces)
+	var webhookOptions []string
+	for _, name := range slices.Sorted(maps.Keys(f.Webhook.Webhooks)) {
+		o := f.Webhook.Webhooks[name]
+		var settings []string
+		if o.FailurePolicy != nil {
+			settings = append(settings, "failurePolicy="+*o.FailurePolicy)
+		}
+		if o.TimeoutSeconds != nil {
+			settings = append(settings, "timeoutSeconds="+strconv.Itoa(*o.TimeoutSeconds))
+		}
+		if len(settings) > 0 {
+			webhookOptions = append(webhookOptions, name+":"+strings.Join(settings, ","))
+		}
+	}
+	setStrings("webhook-options", webhookOptions)
+
+	if f.Detection.Frequency != nil {
+		flags["auto-detect-frequency"] = []string{f.Detection.Frequency.Duration.String()}
+	}
+	setBool("ignore-missing-collector-crds", f.Detection.IgnoreMissingCollectorCRDs)
+	setBool("check-image-platforms", f.Detection.CheckImagePlatforms)
+
+	setBool("enable-multi-instrumentation", f.Instrumentation.EnableMultiInstrumentation)
+	setBool(constants.FlagApacheHttpd, f.Instrumentation.Languages.ApacheHttpd)
+	setBool(constants.FlagDotNet, f.Instrumentation.Languages.DotNet)
+	setBool(constants.FlagGo, f.Instrumentation.Languages.Go)
+	setBool(constants.FlagJava, f.Instrumentation.Languages.Java)
+	setBool(constants.FlagNginx, f.Instrumentation.Languages.Nginx)
+	setBool(constants.FlagNodeJS, f.Instrumentation.Languages.NodeJS)
+	setBool(constants.FlagPHP, f.Instrumentation.Languages.PHP)
+	setBool(constants.FlagPython, f.Instrumentation.Languages.Python)
+	setBool(constants.FlagRuby, f.Instrumentation.Languages.Ruby)
+	setString("instrumentation-excluded-namespaces", f.Instrumentation.ExcludedNamespaces)
+	setString("instrumentation-excluded-pods", f.Instrumentation.ExcludedPods)
+	setString("instrumentation-excluded-containers", f.Instrumentation.ExcludedContainers)
+
+	if f.Reconcile.MaxConcurrentReconciles != nil {
+		flags["max-concurrent-reconciles"] = []string{strconv.Itoa(*f.Reconcile.MaxConcurrentReconciles)}
+	}
+	if f.Reconcile.BaseBackoff != nil {
+		flags["reconcile-base-backoff"] = []string{f.Reconcile.BaseBackoff.Duration.String()}
+	}
+	if f.Reconcile.MaxBackoff != nil {
+		flags["reconcile-max-backoff"] = []string{f.Reconcile.MaxBackoff.Duration.String()}
+	}
+	var controllerOptions []string
+	for _, name := range slices.Sorted(maps.Keys(f.Reconcile.Controllers)) {
+		o := f.Reconcile.Controllers[name]
+		var settings []string
+		if o.MaxConcurrentReconciles != nil {
+			settings = append(settings, "maxConcurrentReconciles="+strconv.Itoa(*o.MaxConcurrentReconciles))
+		}
+		if o.BaseBackoff != nil {
+			settings = append(settings, "baseBackoff="+o.BaseBackoff.Duration.String())
+		}
+		if o.MaxBackoff != nil {
+			settings = append(settings, "maxBackoff="+o.MaxBackoff.Duration.String())
+		}
+		if len(settings) > 0 {
+			controllerOptions = append(controllerOptions, name+":"+strings.Join(settings, ","))
+		}
+	}
+	setStrings("controller-reconcile-options", controllerOptions)
+	setString("tracing-otlp-endpoint", f.Tracing.OTLPEndpoint)
+	setString("upgrade-channel", f.Upgrade.Channel)
+	var windows []string
+	for _, w := range f.Upgrade.MaintenanceWindows {
+		windows = append(windows, w.Schedule+";"+w.Duration.Duration.String())
+	}
+	setStrings("upgrade-maintenance-window", windows)
+
+	setString("default-sidecar-collector", f.DefaultSidecarCollector)
+	setString("resource-selector", f.ResourceSelector)
+	setString("watch-namespace-selector", f.WatchNamespaceSelector)
+	setStrings("labels-filter", f.LabelsFilter)
+	setStrings("annotations-filter", f.AnnotationsFilter)
+	return flags
+}
+
+// reloadableFlags are the flags whose changes are applied to the running operator, by re-rendering the manifests of
+// the collectors and upgrading the Instrumentations using the previous default images. Changing the other flags
+// requires restarting the operator.
+var reloadableFlags = map[string]func(c *Config, value string){
+	"collector-image":                         func(c *Config, value string) { c.CollectorImage = value },
+	"config-reloader-image":                   func(c *Config, value string) { c.ConfigReloaderImage = value },
+	"auto-instrumentation-apache-httpd-image": func(c *Config, value string) { c.AutoInstrumentationApacheHttpdImage = value },
+	"auto-instrumentation-dotnet-image":       func(c *Config, value string) { c.AutoInstrumentationDotNetImage = value },
+	"auto-instrumentation-go-image":           func(c *Config, value string) { c.AutoInstrumentationGoImage = value },
+	"auto-instrumentation-java-image":         func(c *Config, value string) { c.AutoInstrumentationJavaImage = value },
+	"auto-instrumentation-nginx-image":        func(c *Config, value string) { c.AutoInstrumentationNginxImage = value },
+	"auto-instrumentation-nodejs-image":       func(c *Config, value string) { c.AutoInstrumentationNodeJSImage = value },
+	"auto-instrumentation-php-image":          func(c *Config, value string) { c.AutoInstrumentationPHPImage = value },
+	"auto-instrumentation-python-image":       func(c *Config, value string) { c.AutoInstrumentationPythonImage = value },
+	"auto-instrumentation-ruby-image":         func(c *Config, value string) { c.AutoInstrumentationRubyImage = value },
+}
+
+// ApplyReloadable sets the settings of the file which are applied to the running operator, except the ones
+// overridden on the command line.
+func (f *File) ApplyReloadable(c *Config, overridden func(flag string) bool) {
+	for name, values := range f.Flags() {
+		if apply, ok := reloadableFlags[name]; ok && !overridden(name) {
+			apply(c, values[0])
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFile(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "operator-config.yaml"))
+	require.NoError(t, err)
+	f, err := ParseFile(content)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		"collector-image":                     {"ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-contrib:0.120.0"},
+		"config-reloader-image":               {"docker.io/library/busybox:1.37"},
+		"auto-instrumentation-java-image":     {"ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-java:2.12.0"},
+		"feature-gates":                       {"+operator.collector.default.config"},
+		"webhook-port":                        {"9443"},
+		"tls-cipher-suites":                   {"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+		"webhook-excluded-namespaces":         {"kube-system", "istio-system"},
+		"webhook-options":                     {"mpod.kb.io:failurePolicy=Fail,timeoutSeconds=5"},
+		"auto-detect-frequency":               {"5m0s"},
+		"ignore-missing-collector-crds":       {"true"},
+		"enable-go-instrumentation":           {"true"},
+		"enable-python-instrumentation":       {"false"},
+		"instrumentation-excluded-namespaces": {"kubernetes.io/metadata.name in (kube-system)"},
+		"max-concurrent-reconciles":           {"2"},
+		"controller-reconcile-options":        {"opentelemetrycollector:maxConcurrentReconciles=8,maxBackoff=5m0s"},
+		"tracing-otlp-endpoint":               {"http://otel-collector.observability:4318"},
+		"upgrade-channel":                     {"patch"},
+		"upgrade-maintenance-window":          {"0 2 * * SAT;4h0m0s"},
+		"resource-selector":                   {"opentelemetry.io/operator=team-a"},
+		"labels-filter":                       {".*filter.out"},
+	}, f.Flags())
+}
+
+func TestParseFileRejectsUnknownSettings(t *testing.T) {
+	_, err := ParseFile([]byte("images:\n  colector: otelcol:latest\n"))
+	assert.ErrorContains(t, err, `unknown field "colector"`)
+}
+
+func TestApplyReloadable(t *testing.T) {
+	f, err := ParseFile([]byte("images:\n  collector: otelcol:1\n  configReloader: busybox:1\n  targetAllocator: ta:1\n  autoInstrumentation:\n    java: java:1\n"))
+	require.NoError(t, err)
+	cfg := New(WithCollectorImage("otelcol:0"), WithConfigReloaderImage("busybox:0"), WithTargetAllocatorImage("ta:0"), WithAutoInstrumentationJavaImage("java:0"))
+
+	f.ApplyReloadable(&cfg, func(flag string) bool { return flag == "config-reloader-image" })
+
+	assert.Equal(t, "otelcol:1", cfg.CollectorImage)
+	assert.Equal(t, "busybox:0", cfg.ConfigReloaderImage)
+	assert.Equal(t, "ta:0", cfg.TargetAllocatorImage)
+	assert.Equal(t, "java:1", cfg.AutoInstrumentationJavaImage)
+}
+
+func TestFileWatcher(t *testing.T) {
+	notOverridden := func(string) bool { return false }
+	for _, tt := range []struct {
+		name       string
+		content    string
+		overridden func(string) bool
+		reloaded   bool
+		err        error
+	}{
+		{
+			name:       "unchanged settings",
+			content:    "images:\n  collector: otelcol:0\n\n# comment\n",
+			overridden: notOverridden,
+		},
+		{
+			name:       "reloadable setting",
+			content:    "images:\n  collector: otelcol:1\n",
+			overridden: notOverridden,
+			reloaded:   true,
+		},
+		{
+			name:       "reloadable instrumentation image",
+			content:    "images:\n  collector: otelcol:1\n  autoInstrumentation:\n    java: java:1\n",
+			overridden: notOverridden,
+			reloaded:   true,
+		},
+		{
+			name:       "removed reloadable setting",
+			content:    "images: {}\n",
+			overridden: notOverridden,
+			err:        ErrFileChanged,
+		},
+		{
+			name:       "setting requiring a restart",
+			content:    "images:\n  collector: otelcol:0\n  targetAllocator: ta:1\n",
+			overridden: notOverridden,
+			err:        ErrFileChanged,
+		},
+		{
+			name:       "setting overridden on the command line",
+			content:    "images:\n  collector: otelcol:0\n  targetAllocator: ta:1\n",
+			overridden: func(flag string) bool { return flag == "target-allocator-image" },
+		},
+		{
+			name:       "invalid file",
+			content:    "images:\n  colector: otelcol:1\n",
+			overridden: notOverridden,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			initial := []byte("images:\n  collector: otelcol:0\n")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			reloaded := false
+			watcher, err := NewFileWatcher(path, initial, time.Millisecond, tt.overridden, logr.Discard(), func(_ context.Context, f *File) error {
+				reloaded = true
+				assert.Equal(t, "otelcol:1", *f.Images.Collector)
+				return nil
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.err, watcher.check(context.Background()))
+			assert.Equal(t, tt.reloaded, reloaded)
+		})
+	}
+}
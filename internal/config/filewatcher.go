@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var _ manager.Runnable = (*FileWatcher)(nil)
+var _ manager.LeaderElectionRunnable = (*FileWatcher)(nil)
+
+// ErrFileChanged is returned by the FileWatcher when settings which can't be applied to the running operator changed
+// in the configuration file, so that the operator restarts with them.
+var ErrFileChanged = errors.New("the operator configuration file changed")
+
+// ReloadFunc is called by the FileWatcher with the configuration file whose reloadable settings changed.
+type ReloadFunc func(ctx context.Context, file *File) error
+
+// FileWatcher periodically reads the configuration file of the operator, applies the changes of the reloadable
+// settings through the ReloadFunc, and stops the manager with ErrFileChanged when any other setting changes.
+type FileWatcher struct {
+	path       string
+	interval   time.Duration
+	logger     logr.Logger
+	overridden func(flag string) bool
+	onReload   ReloadFunc
+	content    []byte
+	current    *File
+}
+
+// NewFileWatcher creates a new FileWatcher, starting from the content of the file the operator was started with.
+func NewFileWatcher(path string, content []byte, interval time.Duration, overridden func(flag string) bool, logger logr.Logger, onReload ReloadFunc) (*FileWatcher, error) {
+	current, err := ParseFile(content)
+	if err != nil {
+		return nil, err
+	}
+	return &FileWatcher{
+		path:       path,
+		interval:   interval,
+		logger:     logger,
+		overridden: overridden,
+		onReload:   onReload,
+		content:    content,
+		current:    current,
+	}, nil
+}
+
+// Start reads the configuration file at every interval, until the context is done or a setting requiring a restart
+// changes.
+func (w *FileWatcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.check(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns false, as every operator replica has to apply the configuration file.
+func (w *FileWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+func (w *FileWatcher) check(ctx context.Context) error {
+	content, err := os.ReadFile(w.path)
+	if err != nil {
+		w.logger.Error(err, "failed to read the operator configuration file, will retry at the next interval")
+		return nil
+	}
+	if bytes.Equal(content, w.content) {
+		return nil
+	}
+	next, err := ParseFile(content)
+	if err != nil {
+		// keep the previous configuration until the file is fixed
+		w.logger.Error(err, "failed to parse the operator configuration file, will retry at the next interval")
+		return nil
+	}
+
+	changed := w.changedFlags(next)
+	for _, name := range changed {
+		if _, ok := reloadableFlags[name]; !ok || len(next.Flags()[name]) == 0 {
+			w.logger.Info("the operator configuration file changed, restarting the operator", "changed", changed)
+			return ErrFileChanged
+		}
+	}
+	if len(changed) > 0 {
+		w.logger.Info("reloading the operator configuration file", "changed", changed)
+		if err := w.onReload(ctx, next); err != nil {
+			// keep the previous content, so that the reload is retried at the next interval
+			w.logger.Error(err, "failed to reload the operator configuration file")
+			return nil
+		}
+	}
+	w.content, w.current = content, next
+	return nil
+}
+
+// changedFlags returns the sorted names of the flags whose values differ between the current and the next file,
+// except the ones overridden on the command line.
+func (w *FileWatcher) changedFlags(next *File) []string {
+	current, updated := w.current.Flags(), next.Flags()
+	var changed []string
+	for name, values := range updated {
+		if !slices.Equal(current[name], values) && !w.overridden(name) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range current {
+		if _, ok := updated[name]; !ok && !w.overridden(name) {
+			changed = append(changed, name)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
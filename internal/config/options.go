@@ -4,15 +4,26 @@
 package config
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/go-logr/logr"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/istio"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
@@ -29,26 +40,50 @@ type options struct {
 	autoInstrumentationPythonImage      string
 	autoInstrumentationApacheHttpdImage string
 	autoInstrumentationNginxImage       string
+	autoInstrumentationPHPImage         string
+	autoInstrumentationRubyImage        string
 	collectorImage                      string
 	collectorConfigMapEntry             string
+	configReloaderImage                 string
 	createRBACPermissions               autoRBAC.Availability
+	defaultSidecarCollector             string
 	enableMultiInstrumentation          bool
 	enableApacheHttpdInstrumentation    bool
 	enableDotNetInstrumentation         bool
 	enableGoInstrumentation             bool
 	enableNginxInstrumentation          bool
+	enablePHPInstrumentation            bool
+	enableRubyInstrumentation           bool
 	enablePythonInstrumentation         bool
 	enableNodeJSInstrumentation         bool
 	enableJavaInstrumentation           bool
+	instrumentationExcludedNamespaces   labels.Selector
+	instrumentationExcludedPods         labels.Selector
+	resourceSelector                    labels.Selector
+	reconcileOptions                    ReconcileOptions
+	controllerReconcileOptions          map[string]ReconcileOptions
+	upgradePolicy                       UpgradePolicy
+	instrumentationExcludedContainers   *regexp.Regexp
 	targetAllocatorConfigMapEntry       string
 	operatorOpAMPBridgeConfigMapEntry   string
 	targetAllocatorImage                string
 	operatorOpAMPBridgeImage            string
 	openshiftRoutesAvailability         openshift.RoutesAvailability
 	prometheusCRAvailability            prometheus.Availability
+	prometheusCRFeatures                prometheus.Features
 	certManagerAvailability             certmanager.Availability
 	targetAllocatorAvailability         targetallocator.Availability
 	collectorAvailability               collector.Availability
+	gatewayAPIAvailability              gatewayapi.Availability
+	openShiftSCCAvailability            openshift.SCCAvailability
+	platform                            platform.Platform
+	nodePlatforms                       []platform.NodePlatform
+	ipFamilies                          []corev1.IPFamily
+	kedaAvailability                    keda.Availability
+	vpaAvailability                     vpa.Availability
+	nativeSidecarAvailability           nativesidecar.Availability
+	istioAvailability                   istio.Availability
+	autoDetectFrequency                 time.Duration
 	ignoreMissingCollectorCRDs          bool
 	labelsFilter                        []string
 	annotationsFilter                   []string
@@ -69,6 +104,16 @@ func WithCollectorImage(s string) Option {
 		o.collectorImage = s
 	}
 }
+func WithConfigReloaderImage(s string) Option {
+	return func(o *options) {
+		o.configReloaderImage = s
+	}
+}
+func WithDefaultSidecarCollector(s string) Option {
+	return func(o *options) {
+		o.defaultSidecarCollector = s
+	}
+}
 func WithCollectorConfigMapEntry(s string) Option {
 	return func(o *options) {
 		o.collectorConfigMapEntry = s
@@ -99,6 +144,16 @@ func WithEnableNginxInstrumentation(s bool) Option {
 		o.enableNginxInstrumentation = s
 	}
 }
+func WithEnablePHPInstrumentation(s bool) Option {
+	return func(o *options) {
+		o.enablePHPInstrumentation = s
+	}
+}
+func WithEnableRubyInstrumentation(s bool) Option {
+	return func(o *options) {
+		o.enableRubyInstrumentation = s
+	}
+}
 func WithEnableJavaInstrumentation(s bool) Option {
 	return func(o *options) {
 		o.enableJavaInstrumentation = s
@@ -177,6 +232,18 @@ func WithAutoInstrumentationNginxImage(s string) Option {
 	}
 }
 
+func WithAutoInstrumentationPHPImage(s string) Option {
+	return func(o *options) {
+		o.autoInstrumentationPHPImage = s
+	}
+}
+
+func WithAutoInstrumentationRubyImage(s string) Option {
+	return func(o *options) {
+		o.autoInstrumentationRubyImage = s
+	}
+}
+
 func WithOpenShiftRoutesAvailability(os openshift.RoutesAvailability) Option {
 	return func(o *options) {
 		o.openshiftRoutesAvailability = os
@@ -189,6 +256,12 @@ func WithPrometheusCRAvailability(pcrd prometheus.Availability) Option {
 	}
 }
 
+func WithPrometheusCRFeatures(features prometheus.Features) Option {
+	return func(o *options) {
+		o.prometheusCRFeatures = features
+	}
+}
+
 func WithRBACPermissions(rAuto autoRBAC.Availability) Option {
 	return func(o *options) {
 		o.createRBACPermissions = rAuto
@@ -201,6 +274,60 @@ func WithCertManagerAvailability(cmAvl certmanager.Availability) Option {
 	}
 }
 
+func WithGatewayAPIAvailability(gwAvl gatewayapi.Availability) Option {
+	return func(o *options) {
+		o.gatewayAPIAvailability = gwAvl
+	}
+}
+
+func WithIstioAvailability(avl istio.Availability) Option {
+	return func(o *options) {
+		o.istioAvailability = avl
+	}
+}
+
+func WithKedaAvailability(avl keda.Availability) Option {
+	return func(o *options) {
+		o.kedaAvailability = avl
+	}
+}
+
+func WithVPAAvailability(avl vpa.Availability) Option {
+	return func(o *options) {
+		o.vpaAvailability = avl
+	}
+}
+
+func WithNativeSidecarAvailability(avl nativesidecar.Availability) Option {
+	return func(o *options) {
+		o.nativeSidecarAvailability = avl
+	}
+}
+
+func WithPlatform(p platform.Platform) Option {
+	return func(o *options) {
+		o.platform = p
+	}
+}
+
+func WithOpenShiftSCCAvailability(avl openshift.SCCAvailability) Option {
+	return func(o *options) {
+		o.openShiftSCCAvailability = avl
+	}
+}
+
+func WithNodePlatforms(nodePlatforms []platform.NodePlatform) Option {
+	return func(o *options) {
+		o.nodePlatforms = nodePlatforms
+	}
+}
+
+func WithIPFamilies(ipFamilies []corev1.IPFamily) Option {
+	return func(o *options) {
+		o.ipFamilies = ipFamilies
+	}
+}
+
 func WithLabelFilters(labelFilters []string) Option {
 	return func(o *options) {
 		o.labelsFilter = append(o.labelsFilter, labelFilters...)
@@ -216,6 +343,55 @@ func WithAnnotationFilters(annotationFilters []string) Option {
 	}
 }
 
+// WithInstrumentationExcludedNamespaces sets the selector of the namespaces whose pods are never auto-instrumented.
+func WithInstrumentationExcludedNamespaces(s labels.Selector) Option {
+	return func(o *options) {
+		o.instrumentationExcludedNamespaces = s
+	}
+}
+
+// WithInstrumentationExcludedPods sets the selector of the pods which are never auto-instrumented.
+func WithInstrumentationExcludedPods(s labels.Selector) Option {
+	return func(o *options) {
+		o.instrumentationExcludedPods = s
+	}
+}
+
+// WithResourceSelector sets the selector of the OpenTelemetryCollector and Instrumentation resources the operator reconciles.
+func WithResourceSelector(s labels.Selector) Option {
+	return func(o *options) {
+		o.resourceSelector = s
+	}
+}
+
+// WithReconcileOptions sets the options limiting the concurrency and the retries of the reconciliations of the controllers.
+func WithReconcileOptions(r ReconcileOptions) Option {
+	return func(o *options) {
+		o.reconcileOptions = r
+	}
+}
+
+// WithControllerReconcileOptions sets the options overriding the reconcile options of the controllers, by controller name.
+func WithControllerReconcileOptions(r map[string]ReconcileOptions) Option {
+	return func(o *options) {
+		o.controllerReconcileOptions = r
+	}
+}
+
+// WithUpgradePolicy sets the channel and the maintenance windows of the automatic upgrades of the collectors.
+func WithUpgradePolicy(p UpgradePolicy) Option {
+	return func(o *options) {
+		o.upgradePolicy = p
+	}
+}
+
+// WithInstrumentationExcludedContainers sets the pattern of the names of the containers which are never auto-instrumented.
+func WithInstrumentationExcludedContainers(r *regexp.Regexp) Option {
+	return func(o *options) {
+		o.instrumentationExcludedContainers = r
+	}
+}
+
 func WithEncodeLevelFormat(s string) zapcore.LevelEncoder {
 	if s == "lowercase" {
 		return zapcore.LowercaseLevelEncoder
@@ -224,6 +400,13 @@ func WithEncodeLevelFormat(s string) zapcore.LevelEncoder {
 	}
 }
 
+// WithAutoDetectFrequency sets how often the auto-detection routines are re-run. Zero disables the periodic detection.
+func WithAutoDetectFrequency(t time.Duration) Option {
+	return func(o *options) {
+		o.autoDetectFrequency = t
+	}
+}
+
 func WithIgnoreMissingCollectorCRDs(b bool) Option {
 	return func(o *options) {
 		o.ignoreMissingCollectorCRDs = b
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import "sync"
+
+// Provider shares the configuration of the operator with the webhooks and the pod mutators. The configuration is
+// replaced at runtime by the auto-detection poller and the configuration file watcher, the webhooks and the mutators
+// reading the current one on every request.
+type Provider struct {
+	mu  sync.RWMutex
+	cfg Config
+}
+
+// NewProvider returns a Provider of the given configuration.
+func NewProvider(cfg Config) *Provider {
+	return &Provider{cfg: cfg}
+}
+
+// Config returns the current configuration, the zero configuration for a nil Provider.
+func (p *Provider) Config() Config {
+	if p == nil {
+		return Config{}
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.cfg
+}
+
+// Set replaces the current configuration.
+func (p *Provider) Set(cfg Config) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg = cfg
+}
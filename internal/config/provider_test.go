@@ -0,0 +1,21 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvider(t *testing.T) {
+	p := NewProvider(New(WithCollectorImage("otelcol:0")))
+	assert.Equal(t, "otelcol:0", p.Config().CollectorImage)
+
+	p.Set(New(WithCollectorImage("otelcol:1")))
+	assert.Equal(t, "otelcol:1", p.Config().CollectorImage)
+
+	var nilProvider *Provider
+	assert.Equal(t, Config{}, nilProvider.Config())
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// The names of the controllers of the operator.
+const (
+	ControllerOpenTelemetryCollector = "opentelemetrycollector"
+	ControllerTargetAllocator        = "targetallocator"
+	ControllerOpAMPBridge            = "opampbridge"
+	ControllerInstrumentation        = "instrumentation"
+)
+
+var controllerNames = []string{ControllerInstrumentation, ControllerOpAMPBridge, ControllerOpenTelemetryCollector, ControllerTargetAllocator}
+
+// ReconcileOptions limits the concurrency and the retries of the reconciliations of a controller.
+type ReconcileOptions struct {
+	// MaxConcurrentReconciles is the number of resources reconciled concurrently.
+	MaxConcurrentReconciles int
+	// BaseBackoff is the delay before retrying a failed reconciliation, doubled at every new failure of the resource.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the delay before retrying a failed reconciliation.
+	MaxBackoff time.Duration
+}
+
+// DefaultReconcileOptions are the options of the controllers when not configured, the defaults of controller-runtime.
+var DefaultReconcileOptions = ReconcileOptions{
+	MaxConcurrentReconciles: 1,
+	BaseBackoff:             5 * time.Millisecond,
+	MaxBackoff:              1000 * time.Second,
+}
+
+// Validate returns an error when the options can't be applied to a controller.
+func (o ReconcileOptions) Validate() error {
+	if o.MaxConcurrentReconciles < 1 {
+		return fmt.Errorf("the max concurrent reconciles must be at least 1, got %d", o.MaxConcurrentReconciles)
+	}
+	if o.BaseBackoff <= 0 || o.MaxBackoff < o.BaseBackoff {
+		return fmt.Errorf("the base backoff must be positive and lower than the max backoff, got %s and %s", o.BaseBackoff, o.MaxBackoff)
+	}
+	return nil
+}
+
+// ParseControllerReconcileOptions parses the options of the controllers overriding the defaults, each value being
+// formatted as <controller>:<option>=<value>,... e.g. opentelemetrycollector:maxConcurrentReconciles=4,maxBackoff=5m.
+func ParseControllerReconcileOptions(values []string, defaults ReconcileOptions) (map[string]ReconcileOptions, error) {
+	options := map[string]ReconcileOptions{}
+	for _, value := range values {
+		name, settings, ok := strings.Cut(value, ":")
+		if !ok || !slices.Contains(controllerNames, name) {
+			return nil, fmt.Errorf("invalid controller options %q, the controller must be one of %s", value, strings.Join(controllerNames, ", "))
+		}
+		o, ok := options[name]
+		if !ok {
+			o = defaults
+		}
+		for _, setting := range strings.Split(settings, ",") {
+			key, v, _ := strings.Cut(setting, "=")
+			var err error
+			switch key {
+			case "maxConcurrentReconciles":
+				o.MaxConcurrentReconciles, err = strconv.Atoi(v)
+			case "baseBackoff":
+				o.BaseBackoff, err = time.ParseDuration(v)
+			case "maxBackoff":
+				o.MaxBackoff, err = time.ParseDuration(v)
+			default:
+				err = fmt.Errorf("unknown option %q, must be one of maxConcurrentReconciles, baseBackoff, maxBackoff", key)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid options of the %s controller: %w", name, err)
+			}
+		}
+		if err := o.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid options of the %s controller: %w", name, err)
+		}
+		options[name] = o
+	}
+	return options, nil
+}
+
+// ControllerOptions returns the options of the controller of the given name, limiting its concurrency and the
+// backoff of its retries.
+func (c Config) ControllerOptions(name string) controller.Options {
+	o, ok := c.ControllerReconcileOptions[name]
+	if !ok {
+		o = c.ReconcileOptions
+	}
+	if o == (ReconcileOptions{}) {
+		return controller.Options{}
+	}
+	return controller.Options{
+		MaxConcurrentReconciles: o.MaxConcurrentReconciles,
+		// the overall rate limit of the default rate limiter of controller-runtime is kept
+		RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](o.BaseBackoff, o.MaxBackoff),
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+		),
+	}
+}
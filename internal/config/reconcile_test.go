@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestParseControllerReconcileOptions(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		values   []string
+		expected map[string]ReconcileOptions
+		err      string
+	}{
+		{
+			name:     "none",
+			expected: map[string]ReconcileOptions{},
+		},
+		{
+			name:   "overrides",
+			values: []string{"opentelemetrycollector:maxConcurrentReconciles=4,maxBackoff=5m", "instrumentation:baseBackoff=1s", "instrumentation:maxConcurrentReconciles=2"},
+			expected: map[string]ReconcileOptions{
+				ControllerOpenTelemetryCollector: {MaxConcurrentReconciles: 4, BaseBackoff: 5 * time.Millisecond, MaxBackoff: 5 * time.Minute},
+				ControllerInstrumentation:        {MaxConcurrentReconciles: 2, BaseBackoff: time.Second, MaxBackoff: 1000 * time.Second},
+			},
+		},
+		{
+			name:   "unknown controller",
+			values: []string{"collector:maxConcurrentReconciles=4"},
+			err:    `invalid controller options "collector:maxConcurrentReconciles=4", the controller must be one of instrumentation, opampbridge, opentelemetrycollector, targetallocator`,
+		},
+		{
+			name:   "unknown option",
+			values: []string{"targetallocator:workers=4"},
+			err:    `invalid options of the targetallocator controller: unknown option "workers", must be one of maxConcurrentReconciles, baseBackoff, maxBackoff`,
+		},
+		{
+			name:   "invalid value",
+			values: []string{"opampbridge:maxConcurrentReconciles=0"},
+			err:    "invalid options of the opampbridge controller: the max concurrent reconciles must be at least 1, got 0",
+		},
+		{
+			name:   "base backoff above max backoff",
+			values: []string{"opampbridge:baseBackoff=1h,maxBackoff=1m"},
+			err:    "invalid options of the opampbridge controller: the base backoff must be positive and lower than the max backoff, got 1h0m0s and 1m0s",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := ParseControllerReconcileOptions(tt.values, DefaultReconcileOptions)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, options)
+		})
+	}
+}
+
+func TestControllerOptions(t *testing.T) {
+	cfg := New(
+		WithReconcileOptions(ReconcileOptions{MaxConcurrentReconciles: 2, BaseBackoff: time.Second, MaxBackoff: time.Minute}),
+		WithControllerReconcileOptions(map[string]ReconcileOptions{
+			ControllerOpenTelemetryCollector: {MaxConcurrentReconciles: 8, BaseBackoff: time.Second, MaxBackoff: 2 * time.Second},
+		}),
+	)
+
+	collectorOptions := cfg.ControllerOptions(ControllerOpenTelemetryCollector)
+	assert.Equal(t, 8, collectorOptions.MaxConcurrentReconciles)
+	request := reconcile.Request{}
+	assert.Equal(t, time.Second, collectorOptions.RateLimiter.When(request))
+	assert.Equal(t, 2*time.Second, collectorOptions.RateLimiter.When(request))
+	assert.Equal(t, 2*time.Second, collectorOptions.RateLimiter.When(request))
+
+	instrumentationOptions := cfg.ControllerOptions(ControllerInstrumentation)
+	assert.Equal(t, 2, instrumentationOptions.MaxConcurrentReconciles)
+	assert.Equal(t, time.Second, instrumentationOptions.RateLimiter.When(request))
+
+	assert.Zero(t, Config{}.ControllerOptions(ControllerInstrumentation))
+}
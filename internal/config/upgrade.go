@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/hashicorp/cronexpr"
+)
+
+// UpgradeChannel limits the versions the operator automatically upgrades the collectors to.
+type UpgradeChannel string
+
+const (
+	// UpgradeChannelNone disables the automatic upgrades of the collectors.
+	UpgradeChannelNone UpgradeChannel = "none"
+	// UpgradeChannelPatch only upgrades the collectors to the patch releases of their minor version.
+	UpgradeChannelPatch UpgradeChannel = "patch"
+	// UpgradeChannelMinor upgrades the collectors to the releases of their major version. The default channel.
+	UpgradeChannelMinor UpgradeChannel = "minor"
+)
+
+// ParseUpgradeChannel parses the name of an upgrade channel, an empty name being the default channel.
+func ParseUpgradeChannel(value string) (UpgradeChannel, error) {
+	switch c := UpgradeChannel(value); c {
+	case "":
+		return UpgradeChannelMinor, nil
+	case UpgradeChannelNone, UpgradeChannelPatch, UpgradeChannelMinor:
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown upgrade channel %q, must be one of none, patch and minor", value)
+}
+
+// Allows reports whether the channel allows upgrading a collector from one version to the other. The versions the
+// channel can't compare are allowed, as are all the versions by the zero channel.
+func (c UpgradeChannel) Allows(from, to string) bool {
+	if c == UpgradeChannelNone {
+		return false
+	}
+	fromV, err := semver.NewVersion(from)
+	if err != nil {
+		return true
+	}
+	toV, err := semver.NewVersion(to)
+	if err != nil {
+		return true
+	}
+	switch c {
+	case UpgradeChannelPatch:
+		return fromV.Major() == toV.Major() && fromV.Minor() == toV.Minor()
+	case UpgradeChannelMinor:
+		return fromV.Major() == toV.Major()
+	}
+	return true
+}
+
+// MaintenanceWindow is a recurring period during which the collectors can be upgraded.
+type MaintenanceWindow struct {
+	// Schedule is the cron expression of the starts of the window, in UTC.
+	Schedule string
+	// Duration is how long the window stays open.
+	Duration time.Duration
+
+	expr *cronexpr.Expression
+}
+
+// ParseMaintenanceWindows parses the maintenance windows, each value being formatted as <cron expression>;<duration>
+// e.g. 0 2 * * SAT;4h for four hours from 2am UTC on saturdays.
+func ParseMaintenanceWindows(values []string) ([]MaintenanceWindow, error) {
+	windows := make([]MaintenanceWindow, 0, len(values))
+	for _, value := range values {
+		schedule, duration, ok := strings.Cut(value, ";")
+		if !ok {
+			return nil, fmt.Errorf("invalid maintenance window %q, must be formatted as <cron expression>;<duration>", value)
+		}
+		schedule = strings.TrimSpace(schedule)
+		expr, err := cronexpr.Parse(schedule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule of the maintenance window %q: %w", value, err)
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(duration))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration of the maintenance window %q: %w", value, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("the duration of the maintenance window %q must be positive", value)
+		}
+		windows = append(windows, MaintenanceWindow{Schedule: schedule, Duration: d, expr: expr})
+	}
+	return windows, nil
+}
+
+// String returns the window formatted as parsed by ParseMaintenanceWindows.
+func (w MaintenanceWindow) String() string {
+	return fmt.Sprintf("%s;%s", w.Schedule, w.Duration)
+}
+
+// UpgradePolicy controls the automatic upgrades of the collectors when the operator is upgraded.
+type UpgradePolicy struct {
+	// Channel limits the versions the collectors are upgraded to.
+	Channel UpgradeChannel
+	// MaintenanceWindows are the periods during which the collectors can be upgraded, any time when empty.
+	MaintenanceWindows []MaintenanceWindow
+}
+
+// UntilMaintenanceWindow returns how long the upgrades must wait for the next maintenance window, zero when a window
+// is open or when the policy has no windows.
+func (p UpgradePolicy) UntilMaintenanceWindow(now time.Time) time.Duration {
+	if len(p.MaintenanceWindows) == 0 {
+		return 0
+	}
+	now = now.UTC()
+	var wait time.Duration
+	for _, w := range p.MaintenanceWindows {
+		// the last start of the window, if it's still open
+		if start := w.expr.Next(now.Add(-w.Duration)); !start.IsZero() && !start.After(now) {
+			return 0
+		}
+		next := w.expr.Next(now)
+		if next.IsZero() {
+			continue
+		}
+		if d := next.Sub(now); wait == 0 || d < wait {
+			wait = d
+		}
+	}
+	return wait
+}
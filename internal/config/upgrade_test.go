@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUpgradeChannel(t *testing.T) {
+	c, err := ParseUpgradeChannel("")
+	require.NoError(t, err)
+	assert.Equal(t, UpgradeChannelMinor, c)
+
+	c, err = ParseUpgradeChannel("patch")
+	require.NoError(t, err)
+	assert.Equal(t, UpgradeChannelPatch, c)
+
+	_, err = ParseUpgradeChannel("major")
+	assert.EqualError(t, err, `unknown upgrade channel "major", must be one of none, patch and minor`)
+}
+
+func TestUpgradeChannelAllows(t *testing.T) {
+	for _, tt := range []struct {
+		channel  UpgradeChannel
+		from, to string
+		expected bool
+	}{
+		{channel: UpgradeChannelNone, from: "0.120.0", to: "0.120.1", expected: false},
+		{channel: UpgradeChannelPatch, from: "0.120.0", to: "0.120.1", expected: true},
+		{channel: UpgradeChannelPatch, from: "0.120.0", to: "0.121.0", expected: false},
+		{channel: UpgradeChannelMinor, from: "0.120.0", to: "0.121.0", expected: true},
+		{channel: UpgradeChannelMinor, from: "0.120.0", to: "1.0.0", expected: false},
+		{channel: UpgradeChannelPatch, from: "dev", to: "0.121.0", expected: true},
+		{channel: "", from: "0.120.0", to: "1.0.0", expected: true},
+	} {
+		t.Run(string(tt.channel)+" "+tt.from+" to "+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.channel.Allows(tt.from, tt.to))
+		})
+	}
+}
+
+func TestParseMaintenanceWindows(t *testing.T) {
+	windows, err := ParseMaintenanceWindows([]string{"0 2 * * SAT;4h", " 30 22 * * * ; 90m"})
+	require.NoError(t, err)
+	require.Len(t, windows, 2)
+	assert.Equal(t, "0 2 * * SAT;4h0m0s", windows[0].String())
+	assert.Equal(t, "30 22 * * *;1h30m0s", windows[1].String())
+
+	for _, tt := range []struct {
+		value string
+		err   string
+	}{
+		{value: "0 2 * * SAT", err: `invalid maintenance window "0 2 * * SAT", must be formatted as <cron expression>;<duration>`},
+		{value: "0 25 * * *;1h", err: `invalid schedule of the maintenance window "0 25 * * *;1h"`},
+		{value: "0 2 * * SAT;forever", err: `invalid duration of the maintenance window "0 2 * * SAT;forever"`},
+		{value: "0 2 * * SAT;0s", err: `the duration of the maintenance window "0 2 * * SAT;0s" must be positive`},
+	} {
+		t.Run(tt.value, func(t *testing.T) {
+			_, err := ParseMaintenanceWindows([]string{tt.value})
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestUntilMaintenanceWindow(t *testing.T) {
+	windows, err := ParseMaintenanceWindows([]string{"0 2 * * SAT;4h", "0 22 * * WED;1h"})
+	require.NoError(t, err)
+	policy := UpgradePolicy{Channel: UpgradeChannelMinor, MaintenanceWindows: windows}
+
+	// 2025-03-01 is a saturday
+	for _, tt := range []struct {
+		name     string
+		now      time.Time
+		expected time.Duration
+	}{
+		{name: "window start", now: time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC), expected: 0},
+		{name: "open window", now: time.Date(2025, 3, 1, 5, 59, 0, 0, time.UTC), expected: 0},
+		{name: "window end", now: time.Date(2025, 3, 1, 6, 0, 0, 0, time.UTC), expected: 112 * time.Hour},
+		{name: "before the window", now: time.Date(2025, 3, 1, 1, 0, 0, 0, time.UTC), expected: time.Hour},
+		{name: "other time zone", now: time.Date(2025, 3, 1, 3, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)), expected: time.Hour},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.UntilMaintenanceWindow(tt.now))
+		})
+	}
+
+	assert.Zero(t, UpgradePolicy{}.UntilMaintenanceWindow(time.Now()))
+}
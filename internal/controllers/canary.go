@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// rolloutCanary holds a configuration change back from the collector deployment until a canary deployment running the
+// new configuration has been healthy for the stabilization period. The desired collector deployment keeps mounting the
+// ConfigMap of the previous configuration meanwhile, which is kept, its other changes being rolled out as usual. It
+// sets the rollout status of the collector and returns the objects to reconcile, and when to check the canary again.
+func rolloutCanary(ctx context.Context, cl client.Client, pods client.Reader, params *manifests.Params, desiredObjects []client.Object, ownedObjects map[types.UID]client.Object) ([]client.Object, time.Duration, error) {
+	otelcol := &params.OtelCol
+	otelcol.Status.Rollout = nil
+	if otelcol.Spec.Mode != v1beta1.ModeDeployment || otelcol.Spec.Rollout == nil || otelcol.Spec.Rollout.Canary == nil {
+		return desiredObjects, 0, nil
+	}
+	canarySpec := otelcol.Spec.Rollout.Canary
+
+	var desired *appsv1.Deployment
+	for _, obj := range desiredObjects {
+		if deployment, ok := obj.(*appsv1.Deployment); ok && deployment.Name == naming.Collector(otelcol.Name) {
+			desired = deployment
+		}
+	}
+	if desired == nil {
+		return desiredObjects, 0, nil
+	}
+	desiredHash := desired.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation]
+	otelcol.Status.Rollout = &v1beta1.RolloutStatus{
+		Phase:     v1beta1.RolloutPhaseSucceeded,
+		ConfigMap: naming.ConfigMap(otelcol.Name, desiredHash),
+	}
+
+	existing := &appsv1.Deployment{}
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			// nothing to protect on the first rollout
+			return desiredObjects, 0, nil
+		}
+		return nil, 0, err
+	}
+	existingHash, ok := existing.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation]
+	if !ok || existingHash == desiredHash {
+		return desiredObjects, 0, nil
+	}
+
+	canary := collector.CanaryDeployment(*params, desired)
+	status, requeueAfter, err := canaryStatus(ctx, pods, canary, desiredHash, canarySpec)
+	if err != nil {
+		return nil, 0, err
+	}
+	status.ConfigMap = otelcol.Status.Rollout.ConfigMap
+	otelcol.Status.Rollout = status
+	if status.Phase == v1beta1.RolloutPhaseSucceeded {
+		// the canary isn't desired anymore and gets deleted
+		return desiredObjects, 0, nil
+	}
+
+	previousConfigMap := naming.ConfigMap(otelcol.Name, existingHash)
+	desired.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation] = existingHash
+	for i := range desired.Spec.Template.Spec.Volumes {
+		volume := &desired.Spec.Template.Spec.Volumes[i]
+		if volume.ConfigMap != nil && volume.ConfigMap.Name == naming.ConfigMap(otelcol.Name, desiredHash) {
+			volume.ConfigMap.Name = previousConfigMap
+		}
+	}
+	for uid, obj := range ownedObjects {
+		if _, ok := obj.(*corev1.ConfigMap); ok && obj.GetName() == previousConfigMap {
+			delete(ownedObjects, uid)
+		}
+	}
+	return append(desiredObjects, canary), requeueAfter, nil
+}
+
+// canaryStatus evaluates the pods of the canary deployment running the given configuration. The canary fails when one
+// of them restarted more than the allowed number of times, and succeeds when enough of them have been ready for the
+// stabilization period.
+func canaryStatus(ctx context.Context, pods client.Reader, canary *appsv1.Deployment, configHash string, spec *v1beta1.CanaryRollout) (*v1beta1.RolloutStatus, time.Duration, error) {
+	podList := &corev1.PodList{}
+	if err := pods.List(ctx, podList, client.InNamespace(canary.Namespace), client.MatchingLabels(canary.Spec.Selector.MatchLabels)); err != nil {
+		return nil, 0, err
+	}
+
+	replicas := ptr.Deref(spec.Replicas, 1)
+	period := spec.StabilizationPeriod.Duration
+	requeueAfter := period
+	var stable int32
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp != nil || pod.Annotations[manifestutils.ConfigHashAnnotation] != configHash {
+			continue
+		}
+		var restarts int32
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += status.RestartCount
+		}
+		if restarts > spec.MaxRestarts {
+			return &v1beta1.RolloutStatus{
+				Phase:   v1beta1.RolloutPhaseFailed,
+				Message: fmt.Sprintf("canary pod %s restarted %d times", pod.Name, restarts),
+			}, 0, nil
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type != corev1.PodReady || condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			if remaining := period - time.Since(condition.LastTransitionTime.Time); remaining > 0 {
+				requeueAfter = min(requeueAfter, remaining)
+			} else {
+				stable++
+			}
+		}
+	}
+	if stable >= replicas {
+		return &v1beta1.RolloutStatus{
+			Phase:   v1beta1.RolloutPhaseSucceeded,
+			Message: fmt.Sprintf("%d canary pods stable for %s", stable, period),
+		}, 0, nil
+	}
+	return &v1beta1.RolloutStatus{
+		Phase:   v1beta1.RolloutPhaseProgressing,
+		Message: fmt.Sprintf("%d of %d canary pods stable for %s", stable, replicas, period),
+	}, max(requeueAfter, time.Second), nil
+}
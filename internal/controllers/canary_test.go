@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func TestRolloutCanary(t *testing.T) {
+	newParams := func(exporter string, rollout *v1beta1.Rollout) manifests.Params {
+		return manifests.Params{
+			Config: config.New(),
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeDeployment,
+					Config: v1beta1.Config{
+						Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{exporter: map[string]interface{}{}}},
+					},
+					Rollout: rollout,
+				},
+			},
+		}
+	}
+	canaryRollout := &v1beta1.Rollout{
+		Canary: &v1beta1.CanaryRollout{
+			Replicas:            ptr.To[int32](1),
+			StabilizationPeriod: metav1.Duration{Duration: 5 * time.Minute},
+			MaxRestarts:         1,
+		},
+	}
+	deployment := func(params manifests.Params) *appsv1.Deployment {
+		d, err := collector.Deployment(params)
+		require.NoError(t, err)
+		return d
+	}
+	configMap := func(params manifests.Params) *corev1.ConfigMap {
+		cm, err := collector.ConfigMap(params)
+		require.NoError(t, err)
+		cm.UID = types.UID(cm.Name)
+		return cm
+	}
+	canaryPod := func(params manifests.Params, restarts int32, readySince time.Time) *corev1.Pod {
+		canary := collector.CanaryDeployment(params, deployment(params))
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-collector-canary-abc",
+				Namespace:   "default",
+				Labels:      canary.Spec.Template.Labels,
+				Annotations: canary.Spec.Template.Annotations,
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "otc-container", RestartCount: restarts}},
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(readySince)},
+				},
+			},
+		}
+	}
+	previous := newParams("debug", canaryRollout)
+	current := newParams("otlp", canaryRollout)
+
+	for _, tt := range []struct {
+		name             string
+		params           manifests.Params
+		existing         []client.Object
+		wantStatus       *v1beta1.RolloutStatus
+		wantCanary       bool
+		wantRequeue      bool
+		wantPreviousKept bool
+	}{
+		{
+			name:     "no canary",
+			params:   newParams("otlp", nil),
+			existing: []client.Object{deployment(previous)},
+		},
+		{
+			name:       "first rollout",
+			params:     current,
+			wantStatus: &v1beta1.RolloutStatus{Phase: v1beta1.RolloutPhaseSucceeded, ConfigMap: configMap(current).Name},
+		},
+		{
+			name:       "unchanged config",
+			params:     current,
+			existing:   []client.Object{deployment(current)},
+			wantStatus: &v1beta1.RolloutStatus{Phase: v1beta1.RolloutPhaseSucceeded, ConfigMap: configMap(current).Name},
+		},
+		{
+			name:     "canary starting",
+			params:   current,
+			existing: []client.Object{deployment(previous)},
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseProgressing,
+				ConfigMap: configMap(current).Name,
+				Message:   "0 of 1 canary pods stable for 5m0s",
+			},
+			wantCanary:       true,
+			wantRequeue:      true,
+			wantPreviousKept: true,
+		},
+		{
+			name:     "canary stabilizing",
+			params:   current,
+			existing: []client.Object{deployment(previous), canaryPod(current, 1, time.Now().Add(-time.Minute))},
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseProgressing,
+				ConfigMap: configMap(current).Name,
+				Message:   "0 of 1 canary pods stable for 5m0s",
+			},
+			wantCanary:       true,
+			wantRequeue:      true,
+			wantPreviousKept: true,
+		},
+		{
+			name:     "canary stable",
+			params:   current,
+			existing: []client.Object{deployment(previous), canaryPod(current, 0, time.Now().Add(-10*time.Minute))},
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseSucceeded,
+				ConfigMap: configMap(current).Name,
+				Message:   "1 canary pods stable for 5m0s",
+			},
+		},
+		{
+			name:     "canary crashing",
+			params:   current,
+			existing: []client.Object{deployment(previous), canaryPod(current, 2, time.Now().Add(-10*time.Minute))},
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseFailed,
+				ConfigMap: configMap(current).Name,
+				Message:   "canary pod test-collector-canary-abc restarted 2 times",
+			},
+			wantCanary:       true,
+			wantPreviousKept: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			cl := fake.NewClientBuilder().WithObjects(tt.existing...).Build()
+			previousConfigMap := configMap(previous)
+			ownedObjects := map[types.UID]client.Object{previousConfigMap.UID: previousConfigMap}
+			desired := deployment(params)
+			desiredObjects := []client.Object{configMap(params), desired}
+
+			objects, requeueAfter, err := rolloutCanary(context.Background(), cl, cl, &params, desiredObjects, ownedObjects)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, params.OtelCol.Status.Rollout)
+			assert.Equal(t, tt.wantRequeue, requeueAfter > 0)
+			_, pruned := ownedObjects[previousConfigMap.UID]
+			assert.Equal(t, !tt.wantPreviousKept, pruned)
+			if !tt.wantCanary {
+				assert.Len(t, objects, 2)
+				assert.Equal(t, deployment(params).Spec.Template, desired.Spec.Template)
+				return
+			}
+			require.Len(t, objects, 3)
+			canary, ok := objects[2].(*appsv1.Deployment)
+			require.True(t, ok)
+			assert.Equal(t, "test-collector-canary", canary.Name)
+			assert.Equal(t, collector.ComponentOpenTelemetryCollectorCanary, canary.Spec.Selector.MatchLabels["app.kubernetes.io/component"])
+			assert.Equal(t, configMap(current).Name, canary.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+			// the collector deployment keeps running the previous config
+			assert.Equal(t, deployment(previous).Spec.Template, desired.Spec.Template)
+			assert.Equal(t, configMap(previous).Name, desired.Spec.Template.Spec.Volumes[0].ConfigMap.Name)
+			assert.NotEqual(t, desired.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation], canary.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+		})
+	}
+}
+
+func TestRolloutCanaryHoldsBackOnlyTheConfig(t *testing.T) {
+	newParams := func(exporter, image string) manifests.Params {
+		return manifests.Params{
+			Config: config.New(),
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:                      v1beta1.ModeDeployment,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{Image: image},
+					Config: v1beta1.Config{
+						Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{exporter: map[string]interface{}{}}},
+					},
+					Rollout: &v1beta1.Rollout{Canary: &v1beta1.CanaryRollout{Replicas: ptr.To[int32](1)}},
+				},
+			},
+		}
+	}
+	previous := newParams("debug", "collector:0.1.0")
+	current := newParams("otlp", "collector:0.2.0")
+	existing, err := collector.Deployment(previous)
+	require.NoError(t, err)
+	desired, err := collector.Deployment(current)
+	require.NoError(t, err)
+	cl := fake.NewClientBuilder().WithObjects(existing).Build()
+
+	objects, _, err := rolloutCanary(context.Background(), cl, cl, &current, []client.Object{desired}, map[types.UID]client.Object{})
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	// the new image is rolled out, with the previous config
+	assert.Equal(t, "collector:0.2.0", desired.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, existing.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation], desired.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+	assert.Equal(t, existing.Spec.Template.Spec.Volumes, desired.Spec.Template.Spec.Volumes)
+}
@@ -7,13 +7,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,6 +30,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/opampbridge"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/tracing"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
@@ -138,9 +145,32 @@ func getList[T client.Object](ctx context.Context, cl client.Client, l T, option
 	return ownedObjects, nil
 }
 
-// reconcileDesiredObjects runs the reconcile process using the mutateFn over the given list of objects.
-func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logger logr.Logger, owner metav1.Object, scheme *runtime.Scheme, desiredObjects []client.Object, ownedObjects map[types.UID]client.Object) error {
+// The reasons of the events recorded on the owner of the reconciled objects.
+const (
+	reasonCreated   = "Created"
+	reasonUpdated   = "Updated"
+	reasonRecreated = "Recreated"
+	reasonPruned    = "Pruned"
+)
+
+// reconcileDesiredObjects runs the reconcile process using the mutateFn over the given list of objects, recording an
+// event on the owner listing the objects created, updated, recreated or pruned when the recorder is set.
+func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logger logr.Logger, recorder record.EventRecorder, owner client.Object, scheme *runtime.Scheme, desiredObjects []client.Object, ownedObjects map[types.UID]client.Object) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "reconcileDesiredObjects", trace.WithAttributes(
+		attribute.String("owner", owner.GetName()),
+		attribute.Int("desired_objects", len(desiredObjects)),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	var errs []error
+	// the objects are listed by event reason, so that a reconciliation records at most one event of each reason
+	var created, updated, recreated, pruned []string
+	defer func() {
+		recordObjectsEvent(recorder, owner, corev1.EventTypeWarning, reasonRecreated, "deleted %s to change their immutable fields, they will be created again", recreated)
+		recordObjectsEvent(recorder, owner, corev1.EventTypeNormal, reasonCreated, "created %s", created)
+		recordObjectsEvent(recorder, owner, corev1.EventTypeNormal, reasonUpdated, "updated %s", updated)
+		recordObjectsEvent(recorder, owner, corev1.EventTypeNormal, reasonPruned, "deleted the unmanaged %s", pruned)
+	}()
 	for _, desired := range desiredObjects {
 		l := logger.WithValues(
 			"object_name", desired.GetName(),
@@ -165,10 +195,11 @@ func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logg
 		})
 		if crudErr != nil && errors.As(crudErr, &manifests.ImmutableChangeErr) {
 			l.Error(crudErr, "detected immutable field change, trying to delete, new object will be created on next reconcile", "existing", existing.GetName())
-			delErr := kubeClient.Delete(ctx, existing)
+			delErr := kubeClient.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground))
 			if delErr != nil {
 				return delErr
 			}
+			recreated = append(recreated, objectName(scheme, desired))
 			continue
 		} else if crudErr != nil {
 			l.Error(crudErr, "failed to configure desired")
@@ -177,6 +208,12 @@ func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logg
 		}
 
 		l.V(1).Info(fmt.Sprintf("desired has been %s", op))
+		switch op {
+		case controllerutil.OperationResultCreated:
+			created = append(created, objectName(scheme, desired))
+		case controllerutil.OperationResultUpdated:
+			updated = append(updated, objectName(scheme, desired))
+		}
 		// This object is still managed by the operator, remove it from the list of objects to prune
 		delete(ownedObjects, existing.GetUID())
 	}
@@ -184,13 +221,39 @@ func reconcileDesiredObjects(ctx context.Context, kubeClient client.Client, logg
 		return fmt.Errorf("failed to create objects for %s: %w", owner.GetName(), errors.Join(errs...))
 	}
 	// Pruning owned objects in the cluster which are not should not be present after the reconciliation.
-	err := deleteObjects(ctx, kubeClient, logger, ownedObjects)
+	err = deleteObjects(ctx, kubeClient, logger, ownedObjects)
 	if err != nil {
 		return fmt.Errorf("failed to prune objects for %s: %w", owner.GetName(), err)
 	}
+	for _, obj := range ownedObjects {
+		pruned = append(pruned, objectName(scheme, obj))
+	}
 	return nil
 }
 
+// recordObjectsEvent records an event listing the objects in the message format, unless there's none or the recorder
+// isn't set.
+func recordObjectsEvent(recorder record.EventRecorder, owner client.Object, eventType, reason, messageFmt string, objects []string) {
+	if recorder != nil && len(objects) > 0 {
+		slices.Sort(objects)
+		recorder.Eventf(owner, eventType, reason, messageFmt, strings.Join(objects, ", "))
+	}
+}
+
+// objectName returns the kind and the name of the object.
+func objectName(scheme *runtime.Scheme, obj client.Object) string {
+	return kindOf(scheme, obj) + " " + obj.GetName()
+}
+
+// kindOf returns the kind of the object, which the typed objects built by the operator don't set.
+func kindOf(scheme *runtime.Scheme, obj client.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return fmt.Sprintf("%T", obj)
+	}
+	return gvk.Kind
+}
+
 func deleteObjects(ctx context.Context, kubeClient client.Client, logger logr.Logger, objects map[types.UID]client.Object) error {
 	// Pruning owned objects in the cluster which are not should not be present after the reconciliation.
 	pruneErrs := []error{}
@@ -201,7 +264,8 @@ func deleteObjects(ctx context.Context, kubeClient client.Client, logger logr.Lo
 		)
 
 		l.Info("pruning unmanaged resource")
-		err := kubeClient.Delete(ctx, obj)
+		// the pods of the Jobs are orphaned by default
+		err := kubeClient.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
 		if err != nil {
 			l.Error(err, "failed to delete resource")
 			pruneErrs = append(pruneErrs, err)
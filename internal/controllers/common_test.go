@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestReconcileDesiredObjectsRecordsEvents(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+
+	owner := &v1alpha1.OpAMPBridge{ObjectMeta: metav1.ObjectMeta{Name: "bridge", Namespace: "observability", UID: "bridge-uid"}}
+	unmanaged := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: "observability", UID: "unmanaged-uid"}}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(owner, unmanaged).Build()
+	recorder := record.NewFakeRecorder(10)
+	desired := func(value string) []client.Object {
+		return []client.Object{&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "bridge", Namespace: "observability"},
+			Data:       map[string]string{"key": value},
+		}}
+	}
+
+	// the objects created by a reconciliation are listed in a single event
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "bridge", Namespace: "observability"}}
+	require.NoError(t, reconcileDesiredObjects(context.Background(), cl, logr.Discard(), recorder, owner, scheme, append(desired("a"), service), nil))
+	assert.Equal(t, "Normal Created created ConfigMap bridge, Service bridge", <-recorder.Events)
+	assert.Empty(t, recorder.Events)
+
+	// unchanged objects aren't reported
+	require.NoError(t, reconcileDesiredObjects(context.Background(), cl, logr.Discard(), recorder, owner, scheme, desired("a"), nil))
+	assert.Empty(t, recorder.Events)
+
+	owned := map[types.UID]client.Object{unmanaged.UID: unmanaged}
+	require.NoError(t, reconcileDesiredObjects(context.Background(), cl, logr.Discard(), recorder, owner, scheme, desired("b"), owned))
+	assert.Equal(t, "Normal Updated updated ConfigMap bridge", <-recorder.Events)
+	assert.Equal(t, "Normal Pruned deleted the unmanaged ConfigMap unmanaged", <-recorder.Events)
+}
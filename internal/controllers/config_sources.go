@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+const (
+	// configSourceKey indexes the collectors by the ConfigMaps and Secrets of their config sources.
+	configSourceKey = ".spec.configSources"
+
+	configSourceConfigMap = "ConfigMap"
+	configSourceSecret    = "Secret"
+
+	// configSourcesAnnotation carries the versions of the Secret config sources on the pod template, so that the pods,
+	// which read them from their environment when they start, are replaced when a Secret changes.
+	configSourcesAnnotation = "opentelemetry-operator-config-sources/sha256"
+)
+
+// configSourceIndexValue is the value of configSourceKey for a ConfigMap or Secret.
+func configSourceIndexValue(kind, name string) string {
+	return kind + "/" + name
+}
+
+// indexConfigSources returns the configSourceKey values of a collector.
+func indexConfigSources(obj client.Object) []string {
+	otelcol, ok := obj.(*v1beta1.OpenTelemetryCollector)
+	if !ok {
+		return nil
+	}
+	var values []string
+	for _, source := range otelcol.Spec.ConfigSources {
+		if source.ConfigMapKeyRef != nil {
+			values = append(values, configSourceIndexValue(configSourceConfigMap, source.ConfigMapKeyRef.Name))
+		}
+		if source.SecretKeyRef != nil {
+			values = append(values, configSourceIndexValue(configSourceSecret, source.SecretKeyRef.Name))
+		}
+	}
+	return values
+}
+
+// collectorsForConfigSource returns a handler enqueueing the collectors using a ConfigMap or Secret as a config source.
+func (r *OpenTelemetryCollectorReconciler) collectorsForConfigSource(kind string) func(context.Context, client.Object) []reconcile.Request {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		var collectors v1beta1.OpenTelemetryCollectorList
+		if err := r.List(ctx, &collectors,
+			client.InNamespace(obj.GetNamespace()),
+			client.MatchingFields{configSourceKey: configSourceIndexValue(kind, obj.GetName())},
+		); err != nil {
+			r.log.Error(err, "failed to list the collectors using a config source", "kind", kind, "name", obj.GetName())
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(collectors.Items))
+		for _, collector := range collectors.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&collector)})
+		}
+		return requests
+	}
+}
+
+// resolveConfigSources deep-merges the ConfigMap config sources of the collector, then its inline config, into its
+// config. It returns the paths where the merge replaced a map with another kind of value, or the other way around. The
+// Secret config sources are only passed to the collector, the operator only reads their metadata to replace the pods
+// when they change.
+func resolveConfigSources(ctx context.Context, cl client.Client, logger logr.Logger, otelcol *v1beta1.OpenTelemetryCollector, clusterIPFamilies []corev1.IPFamily) ([]string, error) {
+	if len(otelcol.Spec.ConfigSources) == 0 {
+		return nil, nil
+	}
+
+	layers := make([]map[string]interface{}, 0, len(otelcol.Spec.ConfigSources)+1)
+	var secretVersions []string
+	for i, source := range otelcol.Spec.ConfigSources {
+		if source.SecretKeyRef != nil {
+			version, err := getSecretVersion(ctx, cl, otelcol.Namespace, source.SecretKeyRef)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get config source %d: %w", i, err)
+			}
+			secretVersions = append(secretVersions, source.SecretKeyRef.Name+"="+version)
+			continue
+		}
+		content, found, err := getConfigSource(ctx, cl, otelcol.Namespace, source.ConfigMapKeyRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get config source %d: %w", i, err)
+		}
+		if !found {
+			continue
+		}
+		layer := map[string]interface{}{}
+		if err = yaml.Unmarshal(content, &layer); err != nil {
+			return nil, fmt.Errorf("failed to parse config source %d: %w", i, err)
+		}
+		layers = append(layers, layer)
+	}
+	if len(secretVersions) > 0 {
+		// the annotations of the instance are shared with the cache
+		otelcol.Spec.PodAnnotations = maps.Clone(otelcol.Spec.PodAnnotations)
+		if otelcol.Spec.PodAnnotations == nil {
+			otelcol.Spec.PodAnnotations = map[string]string{}
+		}
+		otelcol.Spec.PodAnnotations[configSourcesAnnotation] = fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(secretVersions, ","))))
+	}
+	if len(layers) == 0 {
+		return nil, nil
+	}
+
+	inline, err := json.Marshal(&otelcol.Spec.Config)
+	if err != nil {
+		return nil, err
+	}
+	layer := map[string]interface{}{}
+	if err = json.Unmarshal(inline, &layer); err != nil {
+		return nil, err
+	}
+	layers = append(layers, layer)
+
+	merged, conflicts := v1beta1.MergeConfigs(layers...)
+	content, err := json.Marshal(merged)
+	if err != nil {
+		return nil, err
+	}
+	cfg := v1beta1.Config{}
+	if err = json.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse the merged config: %w", err)
+	}
+	// the webhook only defaults and validates the inline config
+	if featuregate.EnableConfigDefaulting.IsEnabled() {
+		if err = cfg.ApplyDefaultsForIPFamily(logger, otelcol.Spec.ListenIPFamily(clusterIPFamilies)); err != nil {
+			return nil, err
+		}
+	}
+	// the collector merges the Secret config sources, which can complete the configuration, itself
+	if featuregate.EnableStructuralConfigValidation.IsEnabled() && !otelcol.Spec.HasSecretConfigSources() {
+		if err = cfg.Validate(); err != nil {
+			return nil, fmt.Errorf("the merged collector configuration is invalid: %w", err)
+		}
+	}
+	otelcol.Spec.Config = cfg
+	return conflicts, nil
+}
+
+// getSecretVersion returns the resource version of the Secret of a config source, reading only its metadata. Optional
+// Secrets which don't exist have no version.
+func getSecretVersion(ctx context.Context, cl client.Client, namespace string, ref *corev1.SecretKeySelector) (string, error) {
+	secret := &metav1.PartialObjectMetadata{}
+	secret.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(configSourceSecret))
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		if ptr.Deref(ref.Optional, false) && client.IgnoreNotFound(err) == nil {
+			return "", nil
+		}
+		return "", err
+	}
+	return secret.ResourceVersion, nil
+}
+
+// getConfigSource returns the content of a ConfigMap config source. Optional sources which don't exist aren't found.
+func getConfigSource(ctx context.Context, cl client.Client, namespace string, ref *corev1.ConfigMapKeySelector) ([]byte, bool, error) {
+	if ref == nil {
+		return nil, false, fmt.Errorf("neither a ConfigMap nor a Secret is set")
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, configMap); err != nil {
+		if ptr.Deref(ref.Optional, false) && client.IgnoreNotFound(err) == nil {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if content, ok := configMap.Data[ref.Key]; ok {
+		return []byte(content), true, nil
+	}
+	if content, ok := configMap.BinaryData[ref.Key]; ok {
+		return content, true, nil
+	}
+	if ptr.Deref(ref.Optional, false) {
+		return nil, false, nil
+	}
+	return nil, false, fmt.Errorf("key %q not found in ConfigMap %s", ref.Key, ref.Name)
+}
@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+func TestResolveConfigSources(t *testing.T) {
+	base := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Namespace: "default"},
+		Data: map[string]string{"collector.yaml": `
+receivers:
+  otlp:
+    protocols:
+      grpc: {}
+exporters:
+  debug: {}
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`},
+	}
+	overrides := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "default", ResourceVersion: "7"},
+		Data: map[string][]byte{"collector.yaml": []byte(`
+exporters:
+  otlp:
+    endpoint: backend:4317
+service:
+  pipelines:
+    traces:
+      exporters: [otlp]
+`)},
+	}
+	cl := fake.NewFakeClient(base, overrides)
+
+	t.Run("merged in order", func(t *testing.T) {
+		otelcol := &v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				ConfigSources: []v1beta1.ConfigSource{
+					{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "base"}, Key: "collector.yaml"}},
+					{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "overrides"}, Key: "collector.yaml"}},
+					{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "collector.yaml", Optional: ptr.To(true)}},
+				},
+				Config: v1beta1.Config{
+					Processors: &v1beta1.AnyConfig{Object: map[string]interface{}{"batch": map[string]interface{}{}}},
+					Exporters:  v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{"endpoint": "collector:4317"}}},
+					Service: v1beta1.Service{
+						Pipelines: map[string]*v1beta1.Pipeline{
+							"traces": {Receivers: []string{"otlp"}, Processors: []string{"batch"}, Exporters: []string{"otlp"}},
+						},
+					},
+				},
+			},
+		}
+		conflicts, err := resolveConfigSources(context.Background(), cl, testLogger, otelcol, nil)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+
+		cfg := otelcol.Spec.Config
+		assert.Contains(t, cfg.Receivers.Object, "otlp")
+		assert.Contains(t, cfg.Exporters.Object, "debug")
+		// the Secret is merged by the collector, the operator never reads it
+		assert.Equal(t, map[string]interface{}{"endpoint": "collector:4317"}, cfg.Exporters.Object["otlp"])
+		assert.Contains(t, cfg.Processors.Object, "batch")
+		assert.Equal(t, &v1beta1.Pipeline{Receivers: []string{"otlp"}, Processors: []string{"batch"}, Exporters: []string{"otlp"}}, cfg.Service.Pipelines["traces"])
+		// the merged config is defaulted
+		assert.Equal(t, map[string]interface{}{"grpc": map[string]interface{}{"endpoint": "0.0.0.0:4317"}}, cfg.Receivers.Object["otlp"].(map[string]interface{})["protocols"])
+		// the pods are replaced when the Secret changes
+		assert.Len(t, otelcol.Spec.PodAnnotations[configSourcesAnnotation], 64)
+	})
+
+	t.Run("secret versions", func(t *testing.T) {
+		newCollector := func() *v1beta1.OpenTelemetryCollector {
+			return &v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{PodAnnotations: map[string]string{"team": "a"}},
+					ConfigSources: []v1beta1.ConfigSource{
+						{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "overrides"}, Key: "collector.yaml"}},
+					},
+				},
+			}
+		}
+		secretCl := fake.NewFakeClient(overrides.DeepCopy())
+		otelcol := newCollector()
+		_, err := resolveConfigSources(context.Background(), secretCl, testLogger, otelcol, nil)
+		require.NoError(t, err)
+		before := otelcol.Spec.PodAnnotations[configSourcesAnnotation]
+		assert.NotEmpty(t, before)
+		assert.Equal(t, "a", otelcol.Spec.PodAnnotations["team"])
+		assert.Empty(t, otelcol.Spec.Config.Exporters.Object, "the Secret is not inlined")
+
+		secret := &corev1.Secret{}
+		require.NoError(t, secretCl.Get(context.Background(), client.ObjectKeyFromObject(overrides), secret))
+		secret.Data["collector.yaml"] = []byte("exporters: {}")
+		require.NoError(t, secretCl.Update(context.Background(), secret))
+		otelcol = newCollector()
+		_, err = resolveConfigSources(context.Background(), secretCl, testLogger, otelcol, nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, before, otelcol.Spec.PodAnnotations[configSourcesAnnotation])
+	})
+
+	t.Run("defaulting disabled", func(t *testing.T) {
+		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableConfigDefaulting.ID(), false))
+		t.Cleanup(func() {
+			require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableConfigDefaulting.ID(), true))
+		})
+		otelcol := &v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				ConfigSources: []v1beta1.ConfigSource{
+					{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "base"}, Key: "collector.yaml"}},
+				},
+			},
+		}
+		_, err := resolveConfigSources(context.Background(), cl, testLogger, otelcol, nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"grpc": map[string]interface{}{}}, otelcol.Spec.Config.Receivers.Object["otlp"].(map[string]interface{})["protocols"])
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		otelcol := &v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				ConfigSources: []v1beta1.ConfigSource{
+					{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "base"}, Key: "collector.yaml"}},
+				},
+				Config: v1beta1.Config{
+					Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": "verbose"}},
+				},
+			},
+		}
+		conflicts, err := resolveConfigSources(context.Background(), cl, testLogger, otelcol, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"exporters.debug"}, conflicts)
+		assert.Equal(t, "verbose", otelcol.Spec.Config.Exporters.Object["debug"])
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		otelcol := &v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				ConfigSources: []v1beta1.ConfigSource{
+					{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "base"}, Key: "missing.yaml"}},
+				},
+			},
+		}
+		_, err := resolveConfigSources(context.Background(), cl, testLogger, otelcol, nil)
+		assert.ErrorContains(t, err, `key "missing.yaml" not found in ConfigMap base`)
+	})
+
+	t.Run("no sources", func(t *testing.T) {
+		otelcol := &v1beta1.OpenTelemetryCollector{
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Config: v1beta1.Config{
+					Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": map[string]interface{}{}}},
+				},
+			},
+		}
+		expected := otelcol.DeepCopy()
+		conflicts, err := resolveConfigSources(context.Background(), cl, testLogger, otelcol, nil)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		assert.Equal(t, expected, otelcol)
+	})
+}
+
+func TestIndexConfigSources(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			ConfigSources: []v1beta1.ConfigSource{
+				{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "base"}}},
+				{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "overrides"}}},
+			},
+		},
+	}
+	assert.Equal(t, []string{"ConfigMap/base", "Secret/overrides"}, indexConfigSources(otelcol))
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/tracing"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+// injectedInstrumentationKey indexes the pods by the Instrumentations injected into them.
+const injectedInstrumentationKey = ".metadata.annotations.injected-instrumentations"
+
+// InstrumentationReconciler maintains the cert-manager Certificates of the exporters of the Instrumentations, and
+// their status from the pods they were injected into.
+type InstrumentationReconciler struct {
+	client.Client
+	scheme *runtime.Scheme
+	log    logr.Logger
+	config config.Config
+}
+
+// NewInstrumentationReconciler creates a new reconciler for the Instrumentations.
+func NewInstrumentationReconciler(client client.Client, scheme *runtime.Scheme, cfg config.Config, logger logr.Logger) *InstrumentationReconciler {
+	return &InstrumentationReconciler{
+		Client: client,
+		scheme: scheme,
+		log:    logger,
+		config: cfg,
+	}
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations,verbs=get;list;watch
+// +kubebuilder:rbac:groups=opentelemetry.io,resources=instrumentations/status,verbs=get;update;patch
+
+// Reconcile sets the usage statistics and the Degraded condition of an Instrumentation.
+func (r *InstrumentationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("instrumentation", req.NamespacedName)
+
+	var instance v1alpha1.Instrumentation
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch Instrumentation")
+		}
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if instance.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.reconcileCertificate(ctx, log, &instance); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// the pods of any namespace may use the instrumentation
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.MatchingFields{injectedInstrumentationKey: req.NamespacedName.String()}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list the pods injected with the instrumentation: %w", err)
+	}
+
+	changed := instance.DeepCopy()
+	instrumentation.UpdateStatus(changed, pods.Items)
+	if equality.Semantic.DeepEqual(changed.Status, instance.Status) {
+		return ctrl.Result{}, nil
+	}
+	log.V(2).Info("updating instrumentation status")
+	if err := r.Status().Patch(ctx, changed, client.MergeFrom(&instance)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the Instrumentation: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *InstrumentationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.GetCache().IndexField(context.Background(), &corev1.Pod{}, injectedInstrumentationKey, indexInjectedInstrumentations); err != nil {
+		return err
+	}
+	builder := ctrl.NewControllerManagedBy(mgr).
+		Named("instrumentation").
+		WithOptions(r.config.ControllerOptions(config.ControllerInstrumentation)).
+		For(&v1alpha1.Instrumentation{}).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(instrumentationsForPod))
+	if r.config.CertManagerAvailability == certmanager.Available {
+		builder.Owns(&cmv1.Certificate{})
+	}
+	return builder.Complete(tracing.Reconciler(config.ControllerInstrumentation, r))
+}
+
+// reconcileCertificate creates the cert-manager Certificate requested by the exporter of the instrumentation, and
+// prunes the previous ones.
+func (r *InstrumentationReconciler) reconcileCertificate(ctx context.Context, log logr.Logger, inst *v1alpha1.Instrumentation) error {
+	if r.config.CertManagerAvailability != certmanager.Available {
+		return nil
+	}
+	owned, err := getList(ctx, r.Client, &cmv1.Certificate{},
+		client.InNamespace(inst.Namespace),
+		client.MatchingLabels(manifestutils.SelectorLabels(inst.ObjectMeta, instrumentation.ComponentExporterCertificate)),
+	)
+	if err != nil {
+		return err
+	}
+	for uid, obj := range owned {
+		if !metav1.IsControlledBy(obj, inst) {
+			delete(owned, uid)
+		}
+	}
+	var desired []client.Object
+	if cert := instrumentation.ExporterCertificate(*inst); cert != nil {
+		desired = append(desired, cert)
+	}
+	return reconcileDesiredObjects(ctx, r.Client, log, nil, inst, r.scheme, desired, owned)
+}
+
+// injectedInstrumentations returns the Instrumentations injected into a pod.
+func injectedInstrumentations(obj client.Object) []types.NamespacedName {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return nil
+	}
+	var insts []types.NamespacedName
+	for _, inst := range instrumentation.InjectedInstrumentations(*pod) {
+		if !slices.Contains(insts, inst) {
+			insts = append(insts, inst)
+		}
+	}
+	return insts
+}
+
+// indexInjectedInstrumentations returns the injectedInstrumentationKey values of a pod.
+func indexInjectedInstrumentations(obj client.Object) []string {
+	var values []string
+	for _, inst := range injectedInstrumentations(obj) {
+		values = append(values, inst.String())
+	}
+	return values
+}
+
+// instrumentationsForPod enqueues the Instrumentations injected into a pod.
+func instrumentationsForPod(_ context.Context, obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, inst := range injectedInstrumentations(obj) {
+		requests = append(requests, reconcile.Request{NamespacedName: inst})
+	}
+	return requests
+}
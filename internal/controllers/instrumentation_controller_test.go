@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestInstrumentationReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "observability"}}
+	injectedPod := func(namespace, name, injected string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: map[string]string{"instrumentation.opentelemetry.io/injected": injected},
+		}}
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1alpha1.Instrumentation{}).
+		WithIndex(&corev1.Pod{}, injectedInstrumentationKey, indexInjectedInstrumentations).
+		WithObjects(
+			inst,
+			injectedPod("shop", "cart", "java=observability/my-inst,python=observability/my-inst"),
+			injectedPod("shop", "checkout", "java=observability/my-inst"),
+			injectedPod("observability", "other", "java=observability/other-inst"),
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "shop"}},
+		).
+		Build()
+
+	reconciler := NewInstrumentationReconciler(cl, scheme, config.New(), logr.Discard())
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "my-inst", Namespace: "observability"}})
+	require.NoError(t, err)
+
+	updated := &v1alpha1.Instrumentation{}
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "my-inst", Namespace: "observability"}, updated))
+	require.Len(t, updated.Status.Languages, 2)
+	assert.Equal(t, "java", updated.Status.Languages[0].Language)
+	assert.Equal(t, int32(2), updated.Status.Languages[0].InjectedPods)
+	assert.Equal(t, "python", updated.Status.Languages[1].Language)
+	assert.Equal(t, int32(1), updated.Status.Languages[1].InjectedPods)
+	assert.True(t, meta.IsStatusConditionFalse(updated.Status.Conditions, v1alpha1.InstrumentationConditionDegraded))
+
+	// deleted instrumentations are ignored
+	_, err = reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "missing", Namespace: "observability"}})
+	assert.NoError(t, err)
+}
+
+func TestInstrumentationReconcileCertificate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(cmv1.AddToScheme(scheme))
+
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "observability", UID: "inst-uid"},
+		Spec: v1alpha1.InstrumentationSpec{Exporter: v1alpha1.Exporter{TLS: &v1alpha1.TLS{
+			SecretName:  "my-inst-exporter-tls",
+			Certificate: &v1alpha1.ExporterCertificate{IssuerName: "ca-issuer", IssuerKind: "Issuer"},
+		}}},
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&v1alpha1.Instrumentation{}).
+		WithIndex(&corev1.Pod{}, injectedInstrumentationKey, indexInjectedInstrumentations).
+		WithObjects(inst).
+		Build()
+	cfg := config.New(config.WithCertManagerAvailability(certmanager.Available))
+	reconciler := NewInstrumentationReconciler(cl, scheme, cfg, logr.Discard())
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "my-inst", Namespace: "observability"}}
+
+	_, err := reconciler.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+
+	cert := &cmv1.Certificate{}
+	require.NoError(t, cl.Get(context.Background(), types.NamespacedName{Name: "my-inst-exporter-tls", Namespace: "observability"}, cert))
+	assert.Equal(t, "ca-issuer", cert.Spec.IssuerRef.Name)
+	assert.True(t, metav1.IsControlledBy(cert, inst))
+
+	// the certificate is pruned once it isn't requested anymore
+	require.NoError(t, cl.Get(context.Background(), request.NamespacedName, inst))
+	inst.Spec.Exporter.TLS = nil
+	require.NoError(t, cl.Update(context.Background(), inst))
+	_, err = reconciler.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	var certs cmv1.CertificateList
+	require.NoError(t, cl.List(context.Background(), &certs))
+	assert.Empty(t, certs.Items)
+}
+
+func TestInstrumentationsForPod(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		"instrumentation.opentelemetry.io/injected": "java=apps/my-inst,python=apps/my-inst,sdk=shared/sdk",
+	}}}
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "apps", Name: "my-inst"}},
+		{NamespacedName: types.NamespacedName{Namespace: "shared", Name: "sdk"}},
+	}, instrumentationsForPod(context.Background(), pod))
+	assert.Empty(t, instrumentationsForPod(context.Background(), &corev1.Pod{}))
+}
@@ -6,6 +6,7 @@ package controllers
 import (
 	"context"
 
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -16,9 +17,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	opampbridgeStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/opampbridge"
+	"github.com/open-telemetry/opentelemetry-operator/internal/tracing"
 )
 
 // OpAMPBridgeReconciler reconciles a OpAMPBridge object.
@@ -88,21 +91,27 @@ func (r *OpAMPBridgeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	params := r.getParams(instance)
 
+	_, span := tracing.Tracer().Start(ctx, "BuildOpAMPBridge")
 	desiredObjects, buildErr := BuildOpAMPBridge(params)
+	tracing.End(span, buildErr)
 	if buildErr != nil {
 		return ctrl.Result{}, buildErr
 	}
-	err := reconcileDesiredObjects(ctx, r.Client, log, &params.OpAMPBridge, params.Scheme, desiredObjects, nil)
+	err := reconcileDesiredObjects(ctx, r.Client, log, r.recorder, &params.OpAMPBridge, params.Scheme, desiredObjects, nil)
 	return opampbridgeStatus.HandleReconcileStatus(ctx, log, params, err)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *OpAMPBridgeReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.config.ControllerOptions(config.ControllerOpAMPBridge)).
 		For(&v1alpha1.OpAMPBridge{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&corev1.Service{}).
-		Owns(&appsv1.Deployment{}).
-		Complete(r)
+		Owns(&appsv1.Deployment{})
+	if r.config.CertManagerAvailability == certmanager.Available {
+		builder.Owns(&cmv1.Certificate{})
+	}
+	return builder.Complete(tracing.Reconciler(config.ControllerOpAMPBridge, r))
 }
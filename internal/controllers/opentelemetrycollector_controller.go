@@ -6,7 +6,10 @@ package controllers
 
 import (
 	"context"
+	"reflect"
+	"slices"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -14,33 +17,48 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyV1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	internalRbac "github.com/open-telemetry/opentelemetry-operator/internal/rbac"
 	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/tracing"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	vpav1 "github.com/open-telemetry/opentelemetry-operator/internal/vpa/v1"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
@@ -58,23 +76,38 @@ var (
 // OpenTelemetryCollectorReconciler reconciles a OpenTelemetryCollector object.
 type OpenTelemetryCollectorReconciler struct {
 	client.Client
-	recorder record.EventRecorder
-	scheme   *runtime.Scheme
-	log      logr.Logger
+	pods      client.Reader
+	apiReader client.Reader
+	recorder  record.EventRecorder
+	scheme    *runtime.Scheme
+	log       logr.Logger
+	reviewer  *internalRbac.Reviewer
+	upgrade   *upgrade.VersionUpgrade
+
+	// configMu guards config, which can be updated at runtime by the auto-detection poller.
+	configMu sync.RWMutex
 	config   config.Config
-	reviewer *internalRbac.Reviewer
-	upgrade  *upgrade.VersionUpgrade
+
+	cluster    cluster.Cluster
+	controller controller.Controller
+	// resync is signaled by UpdateConfig to reconcile all the collectors. It is buffered and never blocks, as only
+	// the controller of the leader receives it, the signals sent while a resync is pending being coalesced.
+	resync chan struct{}
 }
 
 // Params is the set of options to build a new OpenTelemetryCollectorReconciler.
 type Params struct {
 	client.Client
-	Recorder record.EventRecorder
-	Scheme   *runtime.Scheme
-	Log      logr.Logger
-	Config   config.Config
-	Reviewer *internalRbac.Reviewer
-	Version  version.Version
+	// Pods reads the pods of the collectors, the client reads them when unset.
+	Pods client.Reader
+	// APIReader reads the objects from the API server, to confirm the cached ones, the client reads them when unset.
+	APIReader client.Reader
+	Recorder  record.EventRecorder
+	Scheme    *runtime.Scheme
+	Log       logr.Logger
+	Config    config.Config
+	Reviewer  *internalRbac.Reviewer
+	Version   version.Version
 }
 
 func (r *OpenTelemetryCollectorReconciler) findOtelOwnedObjects(ctx context.Context, params manifests.Params) (map[types.UID]client.Object, error) {
@@ -159,7 +192,7 @@ func getCollectorConfigMapsToKeep(configVersionsToKeep int, configMaps []*corev1
 
 func (r *OpenTelemetryCollectorReconciler) GetParams(ctx context.Context, instance v1beta1.OpenTelemetryCollector) (manifests.Params, error) {
 	p := manifests.Params{
-		Config:   r.config,
+		Config:   r.getConfig(),
 		Client:   r.Client,
 		OtelCol:  instance,
 		Log:      r.log,
@@ -168,6 +201,36 @@ func (r *OpenTelemetryCollectorReconciler) GetParams(ctx context.Context, instan
 		Reviewer: r.reviewer,
 	}
 
+	conflicts, err := resolveConfigSources(ctx, r.Client, r.log, &p.OtelCol, p.Config.IPFamilies)
+	if err != nil {
+		return p, err
+	}
+	p.OtelCol.Status.ConfigConflicts = conflicts
+
+	// the file storage, the enrichment processors and the preset receivers are added to the rendered config only, so
+	// that they go away with the settings adding them
+	if persistence := p.OtelCol.Spec.Persistence; persistence != nil && persistence.FileStorage && p.OtelCol.Spec.Mode == v1beta1.ModeStatefulSet {
+		p.OtelCol.Spec.Config.AddFileStorage(persistence.MountPath)
+	}
+	if p.OtelCol.Spec.Enrichment.Enabled && p.OtelCol.Spec.Mode != v1beta1.ModeSidecar {
+		p.OtelCol.Spec.Config.AddEnrichment(p.Config.Platform.ResourceDetectors(), p.OtelCol.Spec.Mode == v1beta1.ModeDaemonSet)
+	}
+	if p.OtelCol.Spec.Presets.Enabled() && p.OtelCol.Spec.Mode == v1beta1.ModeDaemonSet {
+		p.OtelCol.Spec.Config.AddPresets(p.OtelCol.Spec.Presets, collector.ContainerLogsPattern(p.OtelCol))
+	}
+	if featuregate.EnforceFIPS.IsEnabled() {
+		p.OtelCol.Spec.Config.EnforceFIPSTLS()
+	}
+
+	// the rules are reported rather than blocking the reconciliation, the collector may not need them all
+	if r.reviewer != nil {
+		missing, err := collector.MissingRbacRules(p)
+		if err != nil {
+			r.log.Error(err, "unable to check the RBAC rules of the collector", "namespace", p.OtelCol.Namespace, "name", p.OtelCol.Name)
+		}
+		p.OtelCol.Status.MissingPermissions = missing
+	}
+
 	// generate the target allocator CR from the collector CR
 	targetAllocator, err := r.getTargetAllocator(ctx, p)
 	if err != nil {
@@ -197,29 +260,105 @@ func NewReconciler(p Params) *OpenTelemetryCollectorReconciler {
 		Log:      p.Log.WithName("collector-upgrade"),
 		Recorder: p.Recorder,
 		Version:  p.Version,
+		Policy:   p.Config.UpgradePolicy,
 	}
 
 	r := &OpenTelemetryCollectorReconciler{
-		Client:   p.Client,
-		log:      p.Log,
-		scheme:   p.Scheme,
-		config:   p.Config,
-		recorder: p.Recorder,
-		reviewer: p.Reviewer,
-		upgrade:  up,
+		Client:    p.Client,
+		pods:      p.Pods,
+		apiReader: p.APIReader,
+		log:       p.Log,
+		scheme:    p.Scheme,
+		config:    p.Config,
+		recorder:  p.Recorder,
+		reviewer:  p.Reviewer,
+		upgrade:   up,
+		resync:    make(chan struct{}, 1),
+	}
+	if r.pods == nil {
+		r.pods = p.Client
+	}
+	if r.apiReader == nil {
+		r.apiReader = p.Client
 	}
 	return r
 }
 
+func (r *OpenTelemetryCollectorReconciler) getConfig() config.Config {
+	r.configMu.RLock()
+	defer r.configMu.RUnlock()
+	return r.config
+}
+
+// UpdateConfig replaces the configuration used by the reconciler, typically after the auto-detection poller noticed
+// a change in the cluster's capabilities. Resource types that became available are watched from now on, and all
+// OpenTelemetryCollector instances are enqueued so that the change takes effect without restarting the operator.
+func (r *OpenTelemetryCollectorReconciler) UpdateConfig(ctx context.Context, cfg config.Config) error {
+	previousResources := r.GetOwnedResourceTypes()
+
+	r.configMu.Lock()
+	r.config = cfg
+	r.configMu.Unlock()
+
+	if r.controller != nil {
+		for _, resource := range r.GetOwnedResourceTypes() {
+			if slices.ContainsFunc(previousResources, func(o client.Object) bool { return reflect.TypeOf(o) == reflect.TypeOf(resource) }) {
+				continue
+			}
+			if err := r.indexOwner(ctx, r.cluster, resource); err != nil {
+				return err
+			}
+			if err := r.controller.Watch(source.Kind(r.cluster.GetCache(), resource,
+				handler.EnqueueRequestForOwner(r.scheme, r.cluster.GetRESTMapper(), &v1beta1.OpenTelemetryCollector{}, handler.OnlyControllerOwner()))); err != nil {
+				return err
+			}
+		}
+	}
+
+	select {
+	case r.resync <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// resyncSource enqueues all the collectors whenever UpdateConfig signals a resync.
+func (r *OpenTelemetryCollectorReconciler) resyncSource() source.Source {
+	return source.Func(func(ctx context.Context, queue workqueue.TypedRateLimitingInterface[reconcile.Request]) error {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-r.resync:
+				}
+				var collectors v1beta1.OpenTelemetryCollectorList
+				if err := r.List(ctx, &collectors); err != nil {
+					r.log.Error(err, "failed to list the collectors to reconcile with the new configuration")
+					continue
+				}
+				for i := range collectors.Items {
+					queue.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&collectors.Items[i])})
+				}
+			}
+		}()
+		return nil
+	})
+}
+
 // +kubebuilder:rbac:groups="",resources=pods;configmaps;services;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=apps,resources=daemonsets;deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
-// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors;podmonitors;prometheusrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=config.openshift.io,resources=infrastructures;infrastructures/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=opentelemetry.io,resources=opentelemetrycollectors/status,verbs=get;update;patch
@@ -276,13 +415,37 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		return ctrl.Result{}, nil
 	}
 
+	var upgradeRequeueAfter time.Duration
 	if r.upgrade.NeedsUpgrade(instance) {
-		err = r.upgrade.Upgrade(ctx, instance)
-		if err != nil {
-			return ctrl.Result{}, err
+		reason, wait := r.upgrade.HeldBack(instance, time.Now())
+		if reason == "" {
+			err = r.upgrade.Upgrade(ctx, instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			// if the OpenTelemetryCollector CR was upgraded (modified), return here and re-queue the reconcile event.
+			return ctrl.Result{Requeue: true, RequeueAfter: 1 * time.Second}, nil
+		}
+		log.V(2).Info("the upgrade of the collector is held back", "version", instance.Status.Version, "reason", reason)
+		// the reason is recorded once, when it changes, rather than at every reconciliation
+		if previous := meta.FindStatusCondition(instance.Status.Conditions, v1beta1.ConditionUpgradeHeldBack); previous == nil || previous.Message != reason {
+			r.recorder.Event(&instance, corev1.EventTypeNormal, "UpgradeHeldBack", reason)
+		}
+		meta.SetStatusCondition(&params.OtelCol.Status.Conditions, metav1.Condition{
+			Type:               v1beta1.ConditionUpgradeHeldBack,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: instance.Generation,
+			Reason:             v1beta1.ReasonHeldBack,
+			Message:            reason,
+		})
+		// the configuration isn't migrated yet, so the collector keeps the image of its version rather than the
+		// default image of the operator
+		if params.OtelCol.Spec.Image == "" && instance.Status.Image != "" {
+			params.OtelCol.Spec.Image = instance.Status.Image
 		}
-		// if the OpenTelemetryCollector CR was upgraded (modified), return here and re-queue the reconcile event.
-		return ctrl.Result{Requeue: true, RequeueAfter: 1 * time.Second}, nil
+		upgradeRequeueAfter = wait
+	} else {
+		meta.RemoveStatusCondition(&params.OtelCol.Status.Conditions, v1beta1.ConditionUpgradeHeldBack)
 	}
 
 	// Add finalizer for this CR
@@ -295,7 +458,9 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		}
 	}
 
+	_, span := tracing.Tracer().Start(ctx, "BuildCollector")
 	desiredObjects, buildErr := BuildCollector(params)
+	tracing.End(span, buildErr)
 	if buildErr != nil {
 		return ctrl.Result{}, buildErr
 	}
@@ -305,8 +470,30 @@ func (r *OpenTelemetryCollectorReconciler) Reconcile(ctx context.Context, req ct
 		return ctrl.Result{}, err
 	}
 
-	err = reconcileDesiredObjects(ctx, r.Client, log, &instance, params.Scheme, desiredObjects, ownedObjects)
-	return collectorStatus.HandleReconcileStatus(ctx, log, params, instance, err)
+	previousRollout := params.OtelCol.Status.Rollout
+	desiredObjects, requeueAfter, err := rolloutCanary(ctx, r.Client, r.pods, &params, desiredObjects, ownedObjects)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	desiredObjects, partitionRequeueAfter, err := rolloutPartitioned(ctx, r.Client, r.pods, r.apiReader, &params, previousRollout, desiredObjects, ownedObjects)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if requeueAfter == 0 || (partitionRequeueAfter > 0 && partitionRequeueAfter < requeueAfter) {
+		requeueAfter = partitionRequeueAfter
+	}
+	if requeueAfter == 0 || (upgradeRequeueAfter > 0 && upgradeRequeueAfter < requeueAfter) {
+		// upgrade the collector when the maintenance window opens
+		requeueAfter = upgradeRequeueAfter
+	}
+
+	err = reconcileDesiredObjects(ctx, r.Client, log, r.recorder, &instance, params.Scheme, desiredObjects, ownedObjects)
+	result, err := collectorStatus.HandleReconcileStatus(ctx, log, params, instance, err)
+	if err == nil && requeueAfter > 0 && (result.RequeueAfter == 0 || requeueAfter < result.RequeueAfter) {
+		// check the canary pods, or the updated pods of the statefulset, again
+		result.RequeueAfter = requeueAfter
+	}
+	return result, err
 }
 
 // SetupWithManager tells the manager what our controller is interested in.
@@ -317,41 +504,53 @@ func (r *OpenTelemetryCollectorReconciler) SetupWithManager(mgr ctrl.Manager) er
 	}
 
 	ownedResources := r.GetOwnedResourceTypes()
-	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&v1beta1.OpenTelemetryCollector{})
+	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.getConfig().ControllerOptions(config.ControllerOpenTelemetryCollector)).
+		For(&v1beta1.OpenTelemetryCollector{}).
+		WatchesRawSource(r.resyncSource()).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.collectorsForConfigSource(configSourceConfigMap))).
+		// only the metadata of the Secrets is cached, the collector reads the Secret config sources itself
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.collectorsForConfigSource(configSourceSecret)), builder.OnlyMetadata)
 
 	for _, resource := range ownedResources {
-		builder.Owns(resource)
+		ctrlBuilder.Owns(resource)
 	}
 
-	return builder.Complete(r)
+	r.cluster = mgr
+	r.controller, err = ctrlBuilder.Build(tracing.Reconciler(config.ControllerOpenTelemetryCollector, r))
+	return err
 }
 
 // SetupCaches sets up caching and indexing for our controller.
 func (r *OpenTelemetryCollectorReconciler) SetupCaches(cluster cluster.Cluster) error {
 	ownedResources := r.GetOwnedResourceTypes()
 	for _, resource := range ownedResources {
-		if err := cluster.GetCache().IndexField(context.Background(), resource, resourceOwnerKey, func(rawObj client.Object) []string {
-			owner := metav1.GetControllerOf(rawObj)
-			if owner == nil {
-				return nil
-			}
-			// make sure it's an OpenTelemetryCollector
-			if owner.Kind != "OpenTelemetryCollector" {
-				return nil
-			}
-
-			return []string{owner.Name}
-		}); err != nil {
+		if err := r.indexOwner(context.Background(), cluster, resource); err != nil {
 			return err
 		}
 	}
-	return nil
+	return cluster.GetCache().IndexField(context.Background(), &v1beta1.OpenTelemetryCollector{}, configSourceKey, indexConfigSources)
+}
+
+func (r *OpenTelemetryCollectorReconciler) indexOwner(ctx context.Context, cluster cluster.Cluster, resource client.Object) error {
+	return cluster.GetCache().IndexField(ctx, resource, resourceOwnerKey, func(rawObj client.Object) []string {
+		owner := metav1.GetControllerOf(rawObj)
+		if owner == nil {
+			return nil
+		}
+		// make sure it's an OpenTelemetryCollector
+		if owner.Kind != "OpenTelemetryCollector" {
+			return nil
+		}
+
+		return []string{owner.Name}
+	})
 }
 
 // GetOwnedResourceTypes returns all the resource types the controller can own. Even though this method returns an array
 // of client.Object, these are (empty) example structs rather than actual resources.
 func (r *OpenTelemetryCollectorReconciler) GetOwnedResourceTypes() []client.Object {
+	cfg := r.getConfig()
 	ownedResources := []client.Object{
 		&corev1.ConfigMap{},
 		&corev1.ServiceAccount{},
@@ -359,25 +558,42 @@ func (r *OpenTelemetryCollectorReconciler) GetOwnedResourceTypes() []client.Obje
 		&appsv1.Deployment{},
 		&appsv1.DaemonSet{},
 		&appsv1.StatefulSet{},
+		&batchv1.Job{},
+		&batchv1.CronJob{},
 		&networkingv1.Ingress{},
+		&networkingv1.NetworkPolicy{},
 		&autoscalingv2.HorizontalPodAutoscaler{},
 		&policyV1.PodDisruptionBudget{},
 	}
 
-	if r.config.CreateRBACPermissions == rbac.Available {
+	if cfg.CreateRBACPermissions == rbac.Available {
 		ownedResources = append(ownedResources, &rbacv1.ClusterRole{})
 		ownedResources = append(ownedResources, &rbacv1.ClusterRoleBinding{})
 	}
 
-	if featuregate.PrometheusOperatorIsAvailable.IsEnabled() && r.config.PrometheusCRAvailability == prometheus.Available {
+	if featuregate.PrometheusOperatorIsAvailable.IsEnabled() && cfg.PrometheusCRAvailability == prometheus.Available {
 		ownedResources = append(ownedResources, &monitoringv1.PodMonitor{})
 		ownedResources = append(ownedResources, &monitoringv1.ServiceMonitor{})
+		ownedResources = append(ownedResources, &monitoringv1.PrometheusRule{})
 	}
 
-	if r.config.OpenShiftRoutesAvailability == openshift.RoutesAvailable {
+	if cfg.OpenShiftRoutesAvailability == openshift.RoutesAvailable {
 		ownedResources = append(ownedResources, &routev1.Route{})
 	}
 
+	if cfg.KedaAvailability == keda.Available {
+		ownedResources = append(ownedResources, &kedav1alpha1.ScaledObject{})
+	}
+
+	if cfg.VPAAvailability == vpa.Available {
+		ownedResources = append(ownedResources, &vpav1.VerticalPodAutoscaler{})
+	}
+
+	if cfg.GatewayAPIAvailability == gatewayapi.Available {
+		ownedResources = append(ownedResources, &gatewayv1.HTTPRoute{})
+		ownedResources = append(ownedResources, &gatewayv1.GRPCRoute{})
+	}
+
 	if featuregate.CollectorUsesTargetAllocatorCR.IsEnabled() {
 		ownedResources = append(ownedResources, &v1alpha1.TargetAllocator{})
 	}
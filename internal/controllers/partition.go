@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// rolloutPartitioned rolls a configuration change out to the collector statefulset one pod at a time, from the highest
+// ordinal, through the partition of its rolling update: the next pod is updated once the updated ones have been ready
+// for the stabilization period. When an updated pod restarts too often, the statefulset is rolled back to the pod
+// template of its current revision, and kept there until the configuration changes again, which the previous rollout
+// status tells. The ConfigMaps of the running pods are kept. The pods are read from the given reader, and confirmed
+// stable with the API reader before the partition moves, as the cached pods may lag behind. It sets the rollout
+// status of the collector and returns the objects to reconcile, and when to check the pods again.
+func rolloutPartitioned(ctx context.Context, cl client.Client, pods, apiReader client.Reader, params *manifests.Params, previous *v1beta1.RolloutStatus, desiredObjects []client.Object, ownedObjects map[types.UID]client.Object) ([]client.Object, time.Duration, error) {
+	otelcol := &params.OtelCol
+	if otelcol.Spec.Mode != v1beta1.ModeStatefulSet || otelcol.Spec.Rollout == nil || otelcol.Spec.Rollout.Partitioned == nil {
+		return desiredObjects, 0, nil
+	}
+	spec := otelcol.Spec.Rollout.Partitioned
+
+	var desired *appsv1.StatefulSet
+	for _, obj := range desiredObjects {
+		if statefulSet, ok := obj.(*appsv1.StatefulSet); ok && statefulSet.Name == naming.Collector(otelcol.Name) {
+			desired = statefulSet
+		}
+	}
+	if desired == nil {
+		return desiredObjects, 0, nil
+	}
+	desiredHash := desired.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation]
+	configMap := naming.ConfigMap(otelcol.Name, desiredHash)
+	otelcol.Status.Rollout = &v1beta1.RolloutStatus{
+		Phase:     v1beta1.RolloutPhaseSucceeded,
+		ConfigMap: configMap,
+	}
+	setPartition(desired, 0)
+
+	existing := &appsv1.StatefulSet{}
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(desired), existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			// nothing to protect on the first rollout
+			return desiredObjects, 0, nil
+		}
+		return nil, 0, err
+	}
+	podList := &corev1.PodList{}
+	if err := pods.List(ctx, podList, client.InNamespace(existing.Namespace), client.MatchingLabels(existing.Spec.Selector.MatchLabels)); err != nil {
+		return nil, 0, err
+	}
+	keepRunningConfigMaps(otelcol.Name, podList.Items, ownedObjects)
+
+	replicas := ptr.Deref(desired.Spec.Replicas, 1)
+	partition := min(existingPartition(existing), max(replicas-1, 0))
+	period := spec.StabilizationPeriod.Duration
+	existingHash, ok := existing.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation]
+
+	switch {
+	case previous != nil && previous.Phase == v1beta1.RolloutPhaseFailed && previous.ConfigMap == configMap:
+		// rolled back until the configuration changes again
+		desired.Spec.Template = *existing.Spec.Template.DeepCopy()
+		setPartition(desired, existingPartition(existing))
+		otelcol.Status.Rollout = previous.DeepCopy()
+		return desiredObjects, 0, nil
+	case !ok:
+		return desiredObjects, 0, nil
+	case existingHash != desiredHash:
+		// a new change, rolled out to the pod with the highest ordinal first
+		setPartition(desired, max(replicas-1, 0))
+		otelcol.Status.Rollout.Phase = v1beta1.RolloutPhaseProgressing
+		otelcol.Status.Rollout.Message = fmt.Sprintf("0 of %d pods updated and stable for %s", replicas, period)
+		return desiredObjects, max(period, time.Second), nil
+	}
+
+	if existing.Status.ObservedGeneration < existing.Generation {
+		// the revisions of the statefulset aren't up to date yet
+		setPartition(desired, partition)
+		otelcol.Status.Rollout.Phase = v1beta1.RolloutPhaseProgressing
+		otelcol.Status.Rollout.Message = "waiting for the statefulset controller to observe the update"
+		return desiredObjects, time.Second, nil
+	}
+	if partition == 0 && existing.Status.CurrentRevision == existing.Status.UpdateRevision {
+		// all the pods are updated, the restarts aren't checked anymore
+		return desiredObjects, 0, nil
+	}
+
+	stable, requeueAfter, restarted := partitionProgress(existing, podList.Items, partition, spec.MaxRestarts, period)
+	if restarted != "" {
+		return rollbackPartitioned(ctx, cl, otelcol, existing, desired, desiredObjects, restarted)
+	}
+	if stable >= replicas-partition {
+		// the partition only moves once the API server confirms the updated pods are stable
+		fresh := &appsv1.StatefulSet{}
+		if err := apiReader.Get(ctx, client.ObjectKeyFromObject(existing), fresh); err != nil {
+			return nil, 0, err
+		}
+		if fresh.Generation != existing.Generation || fresh.Status.UpdateRevision != existing.Status.UpdateRevision {
+			setPartition(desired, partition)
+			otelcol.Status.Rollout.Phase = v1beta1.RolloutPhaseProgressing
+			otelcol.Status.Rollout.Message = "waiting for the cache to observe the statefulset"
+			return desiredObjects, time.Second, nil
+		}
+		freshPods := &corev1.PodList{}
+		if err := apiReader.List(ctx, freshPods, client.InNamespace(existing.Namespace), client.MatchingLabels(existing.Spec.Selector.MatchLabels)); err != nil {
+			return nil, 0, err
+		}
+		stable, requeueAfter, restarted = partitionProgress(existing, freshPods.Items, partition, spec.MaxRestarts, period)
+		if restarted != "" {
+			return rollbackPartitioned(ctx, cl, otelcol, existing, desired, desiredObjects, restarted)
+		}
+	}
+
+	if stable >= replicas-partition {
+		if partition == 0 {
+			otelcol.Status.Rollout.Message = fmt.Sprintf("%d pods updated and stable for %s", stable, period)
+			return desiredObjects, 0, nil
+		}
+		// the updated pods are stable, on to the next one
+		partition--
+		requeueAfter = period
+	}
+	setPartition(desired, partition)
+	otelcol.Status.Rollout.Phase = v1beta1.RolloutPhaseProgressing
+	otelcol.Status.Rollout.Message = fmt.Sprintf("%d of %d pods updated and stable for %s", stable, replicas, period)
+	return desiredObjects, max(requeueAfter, time.Second), nil
+}
+
+// partitionProgress counts the updated pods at or above the partition that have been ready for the stabilization
+// period, and returns when the next one will be. It returns why to roll back when an updated pod restarted too often.
+func partitionProgress(existing *appsv1.StatefulSet, pods []corev1.Pod, partition, maxRestarts int32, period time.Duration) (int32, time.Duration, string) {
+	requeueAfter := period
+	var stable int32
+	for _, pod := range pods {
+		ordinal, ok := podOrdinal(existing, pod)
+		if !ok || ordinal < partition || pod.DeletionTimestamp != nil ||
+			pod.Labels[appsv1.StatefulSetRevisionLabel] != existing.Status.UpdateRevision {
+			continue
+		}
+		var restarts int32
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += status.RestartCount
+		}
+		if restarts > maxRestarts {
+			return 0, 0, fmt.Sprintf("pod %s restarted %d times", pod.Name, restarts)
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type != corev1.PodReady || condition.Status != corev1.ConditionTrue {
+				continue
+			}
+			if remaining := period - time.Since(condition.LastTransitionTime.Time); remaining > 0 {
+				requeueAfter = min(requeueAfter, remaining)
+			} else {
+				stable++
+			}
+		}
+	}
+	return stable, requeueAfter, ""
+}
+
+// rollbackPartitioned rolls the collector statefulset back to the pod template of its current revision, the one of the
+// pods below the partition.
+func rollbackPartitioned(ctx context.Context, cl client.Client, otelcol *v1beta1.OpenTelemetryCollector, existing, desired *appsv1.StatefulSet, desiredObjects []client.Object, reason string) ([]client.Object, time.Duration, error) {
+	otelcol.Status.Rollout.Phase = v1beta1.RolloutPhaseFailed
+	if existing.Status.CurrentRevision == "" || existing.Status.CurrentRevision == existing.Status.UpdateRevision {
+		// no previous revision to roll back to, the rollout stops
+		setPartition(desired, existingPartition(existing))
+		otelcol.Status.Rollout.Message = reason
+		return desiredObjects, 0, nil
+	}
+	revision := &appsv1.ControllerRevision{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: existing.Namespace, Name: existing.Status.CurrentRevision}, revision); err != nil {
+		return nil, 0, fmt.Errorf("failed to get the revision %s to roll back to: %w", existing.Status.CurrentRevision, err)
+	}
+	// the data of the statefulset revisions is a patch replacing the pod template
+	var data struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(revision.Data.Raw, &data); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode the revision %s to roll back to: %w", revision.Name, err)
+	}
+	desired.Spec.Template = data.Spec.Template
+	setPartition(desired, 0)
+	otelcol.Status.Rollout.Message = fmt.Sprintf("%s, rolled back to the revision %s", reason, revision.Name)
+	return desiredObjects, 0, nil
+}
+
+func setPartition(statefulSet *appsv1.StatefulSet, partition int32) {
+	statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(partition)},
+	}
+}
+
+func existingPartition(statefulSet *appsv1.StatefulSet) int32 {
+	if statefulSet.Spec.UpdateStrategy.RollingUpdate == nil {
+		return 0
+	}
+	return ptr.Deref(statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition, 0)
+}
+
+func podOrdinal(statefulSet *appsv1.StatefulSet, pod corev1.Pod) (int32, bool) {
+	suffix, ok := strings.CutPrefix(pod.Name, statefulSet.Name+"-")
+	if !ok {
+		return 0, false
+	}
+	ordinal, err := strconv.ParseInt(suffix, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return int32(ordinal), true
+}
+
+// keepRunningConfigMaps keeps the ConfigMaps the given pods run with from being deleted.
+func keepRunningConfigMaps(name string, pods []corev1.Pod, ownedObjects map[types.UID]client.Object) {
+	running := map[string]bool{}
+	for _, pod := range pods {
+		if hash, ok := pod.Annotations[manifestutils.ConfigHashAnnotation]; ok {
+			running[naming.ConfigMap(name, hash)] = true
+		}
+	}
+	for uid, obj := range ownedObjects {
+		if _, ok := obj.(*corev1.ConfigMap); ok && running[obj.GetName()] {
+			delete(ownedObjects, uid)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func TestRolloutPartitioned(t *testing.T) {
+	newParams := func(exporter string, rollout *v1beta1.Rollout) manifests.Params {
+		return manifests.Params{
+			Config: config.New(),
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: v1beta1.ModeStatefulSet,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Replicas: ptr.To[int32](3),
+					},
+					Config: v1beta1.Config{
+						Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{exporter: map[string]interface{}{}}},
+					},
+					Rollout: rollout,
+				},
+			},
+		}
+	}
+	partitionedRollout := &v1beta1.Rollout{
+		Partitioned: &v1beta1.PartitionedRollout{
+			StabilizationPeriod: metav1.Duration{Duration: 5 * time.Minute},
+			MaxRestarts:         1,
+		},
+	}
+	previous := newParams("debug", partitionedRollout)
+	current := newParams("otlp", partitionedRollout)
+
+	statefulSet := func(params manifests.Params) *appsv1.StatefulSet {
+		s, err := collector.StatefulSet(params)
+		require.NoError(t, err)
+		return s
+	}
+	configMap := func(params manifests.Params) *corev1.ConfigMap {
+		cm, err := collector.ConfigMap(params)
+		require.NoError(t, err)
+		cm.UID = types.UID(cm.Name)
+		return cm
+	}
+	// the existing statefulset, updating the pods from the given partition to the current config
+	updating := func(partition int32) *appsv1.StatefulSet {
+		s := statefulSet(current)
+		s.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To(partition)},
+		}
+		s.Status = appsv1.StatefulSetStatus{CurrentRevision: "test-collector-previous", UpdateRevision: "test-collector-current"}
+		return s
+	}
+	pod := func(params manifests.Params, ordinal int, revision string, restarts int32, readySince time.Time) *corev1.Pod {
+		template := statefulSet(params).Spec.Template
+		labels := map[string]string{appsv1.StatefulSetRevisionLabel: revision}
+		for k, v := range template.Labels {
+			labels[k] = v
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        fmt.Sprintf("test-collector-%d", ordinal),
+				Namespace:   "default",
+				Labels:      labels,
+				Annotations: template.Annotations,
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "otc-container", RestartCount: restarts}},
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(readySince)},
+				},
+			},
+		}
+	}
+	previousPods := func(ordinals ...int) []client.Object {
+		var pods []client.Object
+		for _, ordinal := range ordinals {
+			pods = append(pods, pod(previous, ordinal, "test-collector-previous", 0, time.Now().Add(-time.Hour)))
+		}
+		return pods
+	}
+	previousRevision := func() *appsv1.ControllerRevision {
+		data, err := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{"template": statefulSet(previous).Spec.Template},
+		})
+		require.NoError(t, err)
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-collector-previous", Namespace: "default"},
+			Data:       runtime.RawExtension{Raw: data},
+		}
+	}
+	stable := time.Now().Add(-10 * time.Minute)
+
+	for _, tt := range []struct {
+		name             string
+		params           manifests.Params
+		previousStatus   *v1beta1.RolloutStatus
+		existing         []client.Object
+		fresh            []client.Object
+		wantStatus       *v1beta1.RolloutStatus
+		wantPartition    int32
+		wantTemplate     manifests.Params
+		wantRequeue      bool
+		wantPreviousKept bool
+	}{
+		{
+			name:     "no partitioned rollout",
+			params:   newParams("otlp", nil),
+			existing: []client.Object{statefulSet(previous)},
+		},
+		{
+			name:         "first rollout",
+			params:       current,
+			wantStatus:   &v1beta1.RolloutStatus{Phase: v1beta1.RolloutPhaseSucceeded, ConfigMap: configMap(current).Name},
+			wantTemplate: current,
+		},
+		{
+			name:     "config change",
+			params:   current,
+			existing: append([]client.Object{statefulSet(previous)}, previousPods(0, 1, 2)...),
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseProgressing,
+				ConfigMap: configMap(current).Name,
+				Message:   "0 of 3 pods updated and stable for 5m0s",
+			},
+			wantPartition:    2,
+			wantTemplate:     current,
+			wantRequeue:      true,
+			wantPreviousKept: true,
+		},
+		{
+			name:   "first pod stabilizing",
+			params: current,
+			existing: append([]client.Object{updating(2), pod(current, 2, "test-collector-current", 1, time.Now().Add(-time.Minute))},
+				previousPods(0, 1)...),
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseProgressing,
+				ConfigMap: configMap(current).Name,
+				Message:   "0 of 3 pods updated and stable for 5m0s",
+			},
+			wantPartition:    2,
+			wantTemplate:     current,
+			wantRequeue:      true,
+			wantPreviousKept: true,
+		},
+		{
+			name:     "first pod stable",
+			params:   current,
+			existing: append([]client.Object{updating(2), pod(current, 2, "test-collector-current", 0, stable)}, previousPods(0, 1)...),
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseProgressing,
+				ConfigMap: configMap(current).Name,
+				Message:   "1 of 3 pods updated and stable for 5m0s",
+			},
+			wantPartition:    1,
+			wantTemplate:     current,
+			wantRequeue:      true,
+			wantPreviousKept: true,
+		},
+		{
+			name:     "first pod stable in the cache only",
+			params:   current,
+			existing: append([]client.Object{updating(2), pod(current, 2, "test-collector-current", 0, stable)}, previousPods(0, 1)...),
+			fresh: append([]client.Object{updating(2), pod(current, 2, "test-collector-current", 0, time.Now().Add(-time.Minute))},
+				previousPods(0, 1)...),
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseProgressing,
+				ConfigMap: configMap(current).Name,
+				Message:   "0 of 3 pods updated and stable for 5m0s",
+			},
+			wantPartition:    2,
+			wantTemplate:     current,
+			wantRequeue:      true,
+			wantPreviousKept: true,
+		},
+		{
+			name:   "all pods stable",
+			params: current,
+			existing: []client.Object{
+				updating(0),
+				pod(current, 0, "test-collector-current", 0, stable),
+				pod(current, 1, "test-collector-current", 0, stable),
+				pod(current, 2, "test-collector-current", 0, stable),
+			},
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseSucceeded,
+				ConfigMap: configMap(current).Name,
+				Message:   "3 pods updated and stable for 5m0s",
+			},
+			wantTemplate: current,
+		},
+		{
+			name:   "first pod crashing",
+			params: current,
+			existing: append([]client.Object{updating(2), previousRevision(), pod(current, 2, "test-collector-current", 2, stable)},
+				previousPods(0, 1)...),
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseFailed,
+				ConfigMap: configMap(current).Name,
+				Message:   "pod test-collector-2 restarted 2 times, rolled back to the revision test-collector-previous",
+			},
+			wantTemplate:     previous,
+			wantPreviousKept: true,
+		},
+		{
+			name:   "rolled back",
+			params: current,
+			previousStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseFailed,
+				ConfigMap: configMap(current).Name,
+				Message:   "pod test-collector-2 restarted 2 times, rolled back to the revision test-collector-previous",
+			},
+			existing: append([]client.Object{statefulSet(previous)}, previousPods(0, 1, 2)...),
+			wantStatus: &v1beta1.RolloutStatus{
+				Phase:     v1beta1.RolloutPhaseFailed,
+				ConfigMap: configMap(current).Name,
+				Message:   "pod test-collector-2 restarted 2 times, rolled back to the revision test-collector-previous",
+			},
+			wantTemplate:     previous,
+			wantPreviousKept: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			cl := fake.NewClientBuilder().WithObjects(tt.existing...).Build()
+			apiReader := cl
+			if tt.fresh != nil {
+				apiReader = fake.NewClientBuilder().WithObjects(tt.fresh...).Build()
+			}
+			previousConfigMap := configMap(previous)
+			ownedObjects := map[types.UID]client.Object{previousConfigMap.UID: previousConfigMap}
+			desired := statefulSet(params)
+			desiredObjects := []client.Object{configMap(params), desired}
+
+			objects, requeueAfter, err := rolloutPartitioned(context.Background(), cl, cl, apiReader, &params, tt.previousStatus, desiredObjects, ownedObjects)
+			require.NoError(t, err)
+
+			assert.Len(t, objects, 2)
+			assert.Equal(t, tt.wantStatus, params.OtelCol.Status.Rollout)
+			assert.Equal(t, tt.wantRequeue, requeueAfter > 0)
+			_, pruned := ownedObjects[previousConfigMap.UID]
+			assert.Equal(t, !tt.wantPreviousKept, pruned)
+			if tt.wantStatus == nil {
+				assert.Equal(t, statefulSet(params).Spec, desired.Spec)
+				return
+			}
+			require.NotNil(t, desired.Spec.UpdateStrategy.RollingUpdate)
+			assert.Equal(t, tt.wantPartition, ptr.Deref(desired.Spec.UpdateStrategy.RollingUpdate.Partition, 0))
+			assert.Equal(t, statefulSet(tt.wantTemplate).Spec.Template.Annotations[manifestutils.ConfigHashAnnotation],
+				desired.Spec.Template.Annotations[manifestutils.ConfigHashAnnotation])
+		})
+	}
+}
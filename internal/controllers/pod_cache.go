@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// managedPodsCache is started by the manager with its own cache, before the controllers.
+type managedPodsCache struct {
+	cache.Cache
+}
+
+func (c managedPodsCache) GetCache() cache.Cache {
+	return c.Cache
+}
+
+// NewManagedPodsCache creates the cache of the pods of the workloads managed by the operator, in the given namespaces,
+// or all of them when empty, and adds it to the manager. The other pods, like the ones of the applications, aren't
+// cached.
+func NewManagedPodsCache(mgr ctrl.Manager, namespaces map[string]cache.Config) (cache.Cache, error) {
+	podCache, err := cache.New(mgr.GetConfig(), cache.Options{
+		Scheme:               mgr.GetScheme(),
+		Mapper:               mgr.GetRESTMapper(),
+		DefaultNamespaces:    namespaces,
+		DefaultLabelSelector: labels.SelectorFromSet(labels.Set{"app.kubernetes.io/managed-by": "opentelemetry-operator"}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = mgr.Add(managedPodsCache{podCache}); err != nil {
+		return nil, err
+	}
+	return podCache, nil
+}
@@ -1184,6 +1184,15 @@ func TestRegisterWithManager(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUpdateConfigWithoutController(t *testing.T) {
+	// the replicas which aren't the leader never start the controller, the resyncs must not block them
+	reconciler := controllers.NewReconciler(controllers.Params{})
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, reconciler.UpdateConfig(context.Background(), config.New()))
+	}
+}
+
 func TestOpenTelemetryCollectorReconciler_Finalizer(t *testing.T) {
 	otelcol := &v1alpha1.OpenTelemetryCollector{
 		ObjectMeta: metav1.ObjectMeta{
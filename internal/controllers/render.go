@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
+)
+
+// Render returns the objects the operator would create for the OpenTelemetryCollectors, TargetAllocators and
+// Instrumentations among the given objects, defaulted and validated as by the webhooks, and built as by their
+// reconciliations, along with the warnings of the webhooks. The other objects, e.g. the ConfigMaps of the config
+// sources of the collectors, stand in for the cluster. Objects without a namespace belong to the given namespace.
+func Render(ctx context.Context, logger logr.Logger, scheme *runtime.Scheme, cfg config.Config, namespace string, objects []client.Object) ([]client.Object, []string, error) {
+	provider := config.NewProvider(cfg)
+	collectorWebhook := v1beta1.NewCollectorWebhook(logger, scheme, provider, nil, nil, nil, nil, nil)
+	targetAllocatorWebhook := v1alpha1.NewTargetAllocatorWebhook(logger, scheme, provider, nil)
+	instrumentationWebhook := v1alpha1.NewInstrumentationWebhook(logger, scheme, provider)
+
+	var collectors []*v1beta1.OpenTelemetryCollector
+	var targetAllocators []*v1alpha1.TargetAllocator
+	var instrumentations []*v1alpha1.Instrumentation
+	var cluster []client.Object
+	var warnings []string
+	for _, obj := range objects {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+		if otelcol, ok := obj.(*v1alpha1.OpenTelemetryCollector); ok {
+			converted := &v1beta1.OpenTelemetryCollector{}
+			if err := otelcol.ConvertTo(converted); err != nil {
+				return nil, nil, fmt.Errorf("failed to convert the OpenTelemetryCollector %s: %w", otelcol.Name, err)
+			}
+			obj = converted
+		}
+
+		var admissionWarnings []string
+		var err error
+		switch o := obj.(type) {
+		case *v1beta1.OpenTelemetryCollector:
+			if err = collectorWebhook.Default(ctx, o); err == nil {
+				admissionWarnings, err = collectorWebhook.ValidateCreate(ctx, o)
+			}
+			collectors = append(collectors, o)
+		case *v1alpha1.TargetAllocator:
+			if err = targetAllocatorWebhook.Default(ctx, o); err == nil {
+				admissionWarnings, err = targetAllocatorWebhook.ValidateCreate(ctx, o)
+			}
+			targetAllocators = append(targetAllocators, o)
+		case *v1alpha1.Instrumentation:
+			if err = instrumentationWebhook.Default(ctx, o); err == nil {
+				admissionWarnings, err = instrumentationWebhook.ValidateCreate(ctx, o)
+			}
+			instrumentations = append(instrumentations, o)
+		}
+		kind := kindOf(scheme, obj)
+		if err != nil {
+			return nil, nil, fmt.Errorf("the webhook would reject the %s %s: %w", kind, obj.GetName(), err)
+		}
+		for _, warning := range admissionWarnings {
+			warnings = append(warnings, fmt.Sprintf("%s %s: %s", kind, obj.GetName(), warning))
+		}
+		cluster = append(cluster, obj)
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster...).Build()
+	// the builders record events on the resources, which aren't in a cluster
+	recorder := &record.FakeRecorder{}
+	collectorReconciler := NewReconciler(Params{Client: cl, Recorder: recorder, Scheme: scheme, Log: logger, Config: cfg})
+	targetAllocatorReconciler := NewTargetAllocatorReconciler(cl, scheme, recorder, cfg, logger, nil)
+
+	var rendered []client.Object
+	for _, otelcol := range collectors {
+		if otelcol.Spec.ManagementState == v1beta1.ManagementStateUnmanaged {
+			continue
+		}
+		params, err := collectorReconciler.GetParams(ctx, *otelcol)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to render the OpenTelemetryCollector %s: %w", otelcol.Name, err)
+		}
+		built, err := BuildCollector(params)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to render the OpenTelemetryCollector %s: %w", otelcol.Name, err)
+		}
+		rendered = append(rendered, built...)
+		// the target allocator created by the collector is reconciled on its own
+		for _, obj := range built {
+			ta, ok := obj.(*v1alpha1.TargetAllocator)
+			if !ok {
+				continue
+			}
+			taBuilt, err := BuildTargetAllocator(targetallocator.Params{
+				Client:          cl,
+				Scheme:          scheme,
+				Recorder:        recorder,
+				Log:             logger,
+				Config:          cfg,
+				Collector:       &params.OtelCol,
+				TargetAllocator: *ta,
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to render the TargetAllocator %s: %w", ta.Name, err)
+			}
+			rendered = append(rendered, taBuilt...)
+		}
+	}
+	for _, ta := range targetAllocators {
+		if ta.Spec.ManagementState == v1beta1.ManagementStateUnmanaged {
+			continue
+		}
+		params, err := targetAllocatorReconciler.getParams(ctx, *ta)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to render the TargetAllocator %s: %w", ta.Name, err)
+		}
+		built, err := BuildTargetAllocator(params)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to render the TargetAllocator %s: %w", ta.Name, err)
+		}
+		rendered = append(rendered, built...)
+	}
+	if cfg.CertManagerAvailability == certmanager.Available {
+		for _, inst := range instrumentations {
+			if cert := instrumentation.ExporterCertificate(*inst); cert != nil {
+				rendered = append(rendered, cert)
+			}
+		}
+	}
+
+	// the typed objects built by the operator don't set their kind, which the manifests need
+	for _, obj := range rendered {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, nil, err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+	return rendered, warnings, nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestRender(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeStatefulSet,
+			TargetAllocator: v1beta1.TargetAllocatorEmbedded{
+				Enabled: true,
+			},
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"prometheus": map[string]interface{}{
+						"config": map[string]interface{}{
+							"scrape_configs": []interface{}{map[string]interface{}{"job_name": "self"}},
+						},
+					},
+				}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": map[string]interface{}{}}},
+				Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+					"metrics": {Receivers: []string{"prometheus"}, Exporters: []string{"debug"}},
+				}},
+			},
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("collector:v0.0.0"), config.WithTargetAllocatorImage("ta:v0.0.0"))
+
+	rendered, warnings, err := Render(context.Background(), logr.Discard(), testScheme, cfg, "observability", []client.Object{otelcol})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"OpenTelemetryCollector gateway: spec.resources sets no memory limit, the pods can use all the memory of their node until they or other pods are evicted",
+		"OpenTelemetryCollector gateway: spec.targetAllocator.resources sets no memory limit, the pods can use all the memory of their node until they or other pods are evicted",
+	}, warnings)
+
+	kinds := map[string][]string{}
+	for _, obj := range rendered {
+		assert.Equal(t, "observability", obj.GetNamespace())
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		kinds[kind] = append(kinds[kind], obj.GetName())
+	}
+	assert.Equal(t, []string{"gateway-collector"}, kinds["StatefulSet"])
+	assert.Equal(t, []string{"gateway"}, kinds["TargetAllocator"])
+	assert.Equal(t, []string{"gateway-targetallocator"}, kinds["Deployment"])
+}
+
+func TestRenderRejectedByWebhook(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "sidecar"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode:    v1beta1.ModeSidecar,
+			Presets: v1beta1.Presets{HostMetrics: v1beta1.Preset{Enabled: true}},
+		},
+	}
+	_, _, err := Render(context.Background(), logr.Discard(), testScheme, config.New(), "default", []client.Object{otelcol})
+	assert.ErrorContains(t, err, "the webhook would reject the OpenTelemetryCollector sidecar")
+}
+
+func TestRenderUnmanaged(t *testing.T) {
+	ta := &v1alpha1.TargetAllocator{
+		ObjectMeta: metav1.ObjectMeta{Name: "standalone"},
+		Spec: v1alpha1.TargetAllocatorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{ManagementState: v1beta1.ManagementStateUnmanaged},
+		},
+	}
+	rendered, _, err := Render(context.Background(), logr.Discard(), testScheme, config.New(), "default", []client.Object{ta})
+	require.NoError(t, err)
+	assert.Empty(t, rendered)
+}
@@ -47,10 +47,16 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/istio"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	autoRBAC "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/rbac"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/testdata"
@@ -90,10 +96,90 @@ var _ autodetect.AutoDetect = (*mockAutoDetect)(nil)
 type mockAutoDetect struct {
 	OpenShiftRoutesAvailabilityFunc func() (openshift.RoutesAvailability, error)
 	PrometheusCRsAvailabilityFunc   func() (prometheus.Availability, error)
+	PrometheusCRFeaturesFunc        func() (prometheus.Features, error)
 	RBACPermissionsFunc             func(ctx context.Context) (autoRBAC.Availability, error)
 	CertManagerAvailabilityFunc     func(ctx context.Context) (certmanager.Availability, error)
 	TargetAllocatorAvailabilityFunc func() (targetallocator.Availability, error)
 	CollectorCRDAvailabilityFunc    func() (collector.Availability, error)
+	GatewayAPIAvailabilityFunc      func() (gatewayapi.Availability, error)
+	OpenShiftSCCAvailabilityFunc    func() (openshift.SCCAvailability, error)
+	PlatformFunc                    func() (platform.Platform, error)
+	NodePlatformsFunc               func() ([]platform.NodePlatform, error)
+	IPFamiliesFunc                  func() ([]v1.IPFamily, error)
+	KedaAvailabilityFunc            func() (keda.Availability, error)
+	VPAAvailabilityFunc             func() (vpa.Availability, error)
+	NativeSidecarAvailabilityFunc   func() (nativesidecar.Availability, error)
+	IstioAvailabilityFunc           func() (istio.Availability, error)
+}
+
+func (m *mockAutoDetect) GatewayAPIAvailability() (gatewayapi.Availability, error) {
+	if m.GatewayAPIAvailabilityFunc != nil {
+		return m.GatewayAPIAvailabilityFunc()
+	}
+	return gatewayapi.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) IstioAvailability() (istio.Availability, error) {
+	if m.IstioAvailabilityFunc != nil {
+		return m.IstioAvailabilityFunc()
+	}
+	return istio.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) KedaAvailability() (keda.Availability, error) {
+	if m.KedaAvailabilityFunc != nil {
+		return m.KedaAvailabilityFunc()
+	}
+	return keda.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) VPAAvailability() (vpa.Availability, error) {
+	if m.VPAAvailabilityFunc != nil {
+		return m.VPAAvailabilityFunc()
+	}
+	return vpa.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) NativeSidecarAvailability() (nativesidecar.Availability, error) {
+	if m.NativeSidecarAvailabilityFunc != nil {
+		return m.NativeSidecarAvailabilityFunc()
+	}
+	return nativesidecar.NotAvailable, nil
+}
+
+func (m *mockAutoDetect) Platform(_ context.Context) (platform.Platform, error) {
+	if m.PlatformFunc != nil {
+		return m.PlatformFunc()
+	}
+	return platform.Unknown, nil
+}
+
+func (m *mockAutoDetect) PrometheusCRFeatures() (prometheus.Features, error) {
+	if m.PrometheusCRFeaturesFunc != nil {
+		return m.PrometheusCRFeaturesFunc()
+	}
+	return prometheus.Features{}, nil
+}
+
+func (m *mockAutoDetect) OpenShiftSCCAvailability() (openshift.SCCAvailability, error) {
+	if m.OpenShiftSCCAvailabilityFunc != nil {
+		return m.OpenShiftSCCAvailabilityFunc()
+	}
+	return openshift.SCCNotAvailable, nil
+}
+
+func (m *mockAutoDetect) NodePlatforms(_ context.Context) ([]platform.NodePlatform, error) {
+	if m.NodePlatformsFunc != nil {
+		return m.NodePlatformsFunc()
+	}
+	return nil, nil
+}
+
+func (m *mockAutoDetect) IPFamilies(_ context.Context) ([]v1.IPFamily, error) {
+	if m.IPFamiliesFunc != nil {
+		return m.IPFamiliesFunc()
+	}
+	return nil, nil
 }
 
 func (m *mockAutoDetect) FIPSEnabled(_ context.Context) bool {
@@ -200,21 +286,21 @@ func TestMain(m *testing.M) {
 	}
 	reviewer := rbac.NewReviewer(clientset)
 
-	if err = v1beta1.SetupCollectorWebhook(mgr, config.New(), reviewer, nil, nil, nil); err != nil {
+	if err = v1beta1.SetupCollectorWebhook(mgr, config.NewProvider(config.New()), reviewer, nil, nil, nil); err != nil {
 		fmt.Printf("failed to SetupWebhookWithManager: %v", err)
 		os.Exit(1)
 	}
-	if err = v1alpha1.SetupTargetAllocatorWebhook(mgr, config.New(), reviewer); err != nil {
+	if err = v1alpha1.SetupTargetAllocatorWebhook(mgr, config.NewProvider(config.New()), reviewer); err != nil {
 		fmt.Printf("failed to SetupWebhookWithManager: %v", err)
 		os.Exit(1)
 	}
 
-	if err = v1alpha1.SetupTargetAllocatorWebhook(mgr, config.New(), reviewer); err != nil {
+	if err = v1alpha1.SetupTargetAllocatorWebhook(mgr, config.NewProvider(config.New()), reviewer); err != nil {
 		fmt.Printf("failed to SetupWebhookWithManager: %v", err)
 		os.Exit(1)
 	}
 
-	if err = v1alpha1.SetupOpAMPBridgeWebhook(mgr, config.New()); err != nil {
+	if err = v1alpha1.SetupOpAMPBridgeWebhook(mgr, config.NewProvider(config.New())); err != nil {
 		fmt.Printf("failed to SetupWebhookWithManager: %v", err)
 		os.Exit(1)
 	}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/go-logr/logr"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -21,17 +22,22 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	taStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/tracing"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
@@ -43,6 +49,7 @@ type TargetAllocatorReconciler struct {
 	scheme   *runtime.Scheme
 	log      logr.Logger
 	config   config.Config
+	secrets  cache.Cache
 }
 
 // TargetAllocatorReconcilerParams is the set of options to build a new TargetAllocatorReconciler.
@@ -116,13 +123,16 @@ func (r *TargetAllocatorReconciler) getCollector(ctx context.Context, instance v
 	return &collectors.Items[0], nil
 }
 
-// NewTargetAllocatorReconciler creates a new reconciler for TargetAllocator objects.
+// NewTargetAllocatorReconciler creates a new reconciler for TargetAllocator objects. The certificate Secrets issued
+// without cert-manager are read and watched through the given cache, see NewCertificateSecretsCache, or read through
+// the client when it's nil.
 func NewTargetAllocatorReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
 	recorder record.EventRecorder,
 	config config.Config,
 	logger logr.Logger,
+	secrets cache.Cache,
 ) *TargetAllocatorReconciler {
 	return &TargetAllocatorReconciler{
 		Client:   client,
@@ -130,10 +140,26 @@ func NewTargetAllocatorReconciler(
 		scheme:   scheme,
 		config:   config,
 		recorder: recorder,
+		secrets:  secrets,
 	}
 }
 
-// +kubebuilder:rbac:groups="",resources=pods;configmaps;services;serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// NewCertificateSecretsCache returns the cache of the certificate Secrets issued for the target allocators in the
+// given namespaces, all of them when empty. It's filtered by their labels, so that the operator doesn't cache all the
+// Secrets of the cluster, and has to be added to the manager.
+func NewCertificateSecretsCache(mgr ctrl.Manager, namespaces map[string]cache.Config) (cache.Cache, error) {
+	return cache.New(mgr.GetConfig(), cache.Options{
+		HTTPClient:        mgr.GetHTTPClient(),
+		Scheme:            mgr.GetScheme(),
+		Mapper:            mgr.GetRESTMapper(),
+		DefaultNamespaces: namespaces,
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Secret{}: {Label: targetallocator.CertificateSecretsSelector()},
+		},
+	})
+}
+
+// +kubebuilder:rbac:groups="",resources=pods;configmaps;services;serviceaccounts;secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
@@ -172,18 +198,69 @@ func (r *TargetAllocatorReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	_, span := tracing.Tracer().Start(ctx, "BuildTargetAllocator")
 	desiredObjects, buildErr := BuildTargetAllocator(params)
+	tracing.End(span, buildErr)
 	if buildErr != nil {
 		return ctrl.Result{}, buildErr
 	}
 
-	err = reconcileDesiredObjects(ctx, r.Client, log, &params.TargetAllocator, params.Scheme, desiredObjects, nil)
-	return taStatus.HandleReconcileStatus(ctx, log, params, err)
+	// without cert-manager, the operator issues the certificates of the mTLS itself, and renews them when due
+	var renewal time.Time
+	if r.config.CertManagerAvailability != certmanager.Available && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
+		secrets, next, secretsErr := r.certificateSecrets(ctx, params)
+		if secretsErr != nil {
+			return ctrl.Result{}, secretsErr
+		}
+		desiredObjects = append(desiredObjects, secrets...)
+		renewal = next
+	}
+
+	err = reconcileDesiredObjects(ctx, r.Client, log, r.recorder, &params.TargetAllocator, params.Scheme, desiredObjects, nil)
+	result, err := taStatus.HandleReconcileStatus(ctx, log, params, err)
+	if err == nil && !renewal.IsZero() {
+		result.RequeueAfter = max(time.Until(renewal), time.Second)
+	}
+	return result, err
+}
+
+// certificateSecrets returns the Secrets of the certificates of the mTLS between the target allocator and the
+// collectors, keeping the existing certificates until they are due for renewal, and when the next one is due.
+func (r *TargetAllocatorReconciler) certificateSecrets(ctx context.Context, params targetallocator.Params) ([]client.Object, time.Time, error) {
+	var reader client.Reader = r.Client
+	if r.secrets != nil {
+		reader = r.secrets
+	}
+	existing := map[string]*corev1.Secret{}
+	for _, name := range []string{
+		naming.CACertificate(params.TargetAllocator.Name),
+		naming.TAServerCertificateSecretName(params.TargetAllocator.Name),
+		naming.TAClientCertificateSecretName(params.TargetAllocator.Name),
+	} {
+		secret := &corev1.Secret{}
+		err := reader.Get(ctx, client.ObjectKey{Namespace: params.TargetAllocator.Namespace, Name: name}, secret)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, time.Time{}, err
+		}
+		existing[name] = secret
+	}
+	secrets, renewal, err := targetallocator.CertificateSecrets(params, existing, time.Now())
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to issue the mTLS certificates: %w", err)
+	}
+	objects := make([]client.Object, 0, len(secrets))
+	for _, secret := range secrets {
+		objects = append(objects, secret)
+	}
+	return objects, renewal, nil
 }
 
 // SetupWithManager tells the manager what our controller is interested in.
 func (r *TargetAllocatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(r.config.ControllerOptions(config.ControllerTargetAllocator)).
 		For(&v1alpha1.TargetAllocator{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.ServiceAccount{}).
@@ -191,6 +268,11 @@ func (r *TargetAllocatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Owns(&appsv1.Deployment{}).
 		Owns(&policyV1.PodDisruptionBudget{})
 
+	if r.secrets != nil && r.config.CertManagerAvailability != certmanager.Available && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
+		ctrlBuilder.WatchesRawSource(source.Kind(r.secrets, &corev1.Secret{},
+			handler.TypedEnqueueRequestForOwner[*corev1.Secret](mgr.GetScheme(), mgr.GetRESTMapper(), &v1alpha1.TargetAllocator{}, handler.OnlyControllerOwner())))
+	}
+
 	if featuregate.PrometheusOperatorIsAvailable.IsEnabled() && r.config.PrometheusCRAvailability == prometheus.Available {
 		ctrlBuilder.Owns(&monitoringv1.ServiceMonitor{})
 		ctrlBuilder.Owns(&monitoringv1.PodMonitor{})
@@ -229,7 +311,7 @@ func (r *TargetAllocatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		builder.WithPredicates(selectorPredicate),
 	)
 
-	return ctrlBuilder.Complete(r)
+	return ctrlBuilder.Complete(tracing.Reconciler(config.ControllerTargetAllocator, r))
 }
 
 func getTargetAllocatorForCollector(_ context.Context, collector client.Object) []reconcile.Request {
@@ -39,6 +39,7 @@ func TestNewObjectsOnReconciliation_TargetAllocator(t *testing.T) {
 		record.NewFakeRecorder(10),
 		cfg,
 		testLogger,
+		nil,
 	)
 	created := &v1alpha1.TargetAllocator{
 		ObjectMeta: metav1.ObjectMeta{
@@ -110,6 +111,7 @@ func TestSkipWhenInstanceDoesNotExist_TargetAllocator(t *testing.T) {
 		record.NewFakeRecorder(10),
 		cfg,
 		testLogger,
+		nil,
 	)
 
 	// test
@@ -148,6 +150,7 @@ func TestUnmanaged_TargetAllocator(t *testing.T) {
 		record.NewFakeRecorder(10),
 		cfg,
 		testLogger,
+		nil,
 	)
 	unmanaged := &v1alpha1.TargetAllocator{
 		ObjectMeta: metav1.ObjectMeta{
@@ -204,6 +207,7 @@ func TestBuildError_TargetAllocator(t *testing.T) {
 		record.NewFakeRecorder(10),
 		cfg,
 		testLogger,
+		nil,
 	)
 	unmanaged := &v1alpha1.TargetAllocator{
 		ObjectMeta: metav1.ObjectMeta{
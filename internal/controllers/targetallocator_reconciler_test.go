@@ -58,6 +58,7 @@ func TestTargetAllocatorReconciler_GetCollector(t *testing.T) {
 		record.NewFakeRecorder(10),
 		config.New(),
 		testLogger,
+		nil,
 	)
 
 	t.Run("not owned by a collector", func(t *testing.T) {
@@ -118,6 +119,7 @@ func TestTargetAllocatorReconciler_GetCollector(t *testing.T) {
 			record.NewFakeRecorder(10),
 			config.New(),
 			testLogger,
+			nil,
 		)
 		ta := v1alpha1.TargetAllocator{
 			ObjectMeta: metav1.ObjectMeta{
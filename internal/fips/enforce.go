@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fips
+
+import (
+	"slices"
+	"strings"
+)
+
+// MinTLSVersion is the lowest TLS version approved by FIPS 140, as set in the collector configuration.
+const MinTLSVersion = "1.2"
+
+// TLSCipherSuites are the TLS 1.2 cipher suites approved by FIPS 140, by their Go names. The TLS 1.3 cipher suites
+// aren't configurable, and the FIPS builds of the collector only negotiate the approved ones.
+var TLSCipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+}
+
+// IsApprovedCipherSuite reports whether the TLS cipher suite is approved by FIPS 140.
+func IsApprovedCipherSuite(name string) bool {
+	return slices.Contains(TLSCipherSuites, name)
+}
+
+// IsCompliantImage reports whether the image is built for FIPS, by convention when the name of its repository or its
+// tag contains fips, e.g. otelcol-fips:0.120.0 or otelcol:0.120.0-fips.
+func IsCompliantImage(image string) bool {
+	image, _, _ = strings.Cut(image, "@")
+	if i := strings.LastIndex(image, "/"); i >= 0 {
+		image = image[i+1:]
+	}
+	return strings.Contains(strings.ToLower(image), "fips")
+}
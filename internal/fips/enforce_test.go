@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fips
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsCompliantImage(t *testing.T) {
+	for image, expected := range map[string]bool{
+		"ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:0.120.0": false,
+		"registry.example.com/otelcol-fips:0.120.0":                                               true,
+		"registry.example.com/otelcol:0.120.0-FIPS":                                               true,
+		"registry.example.com:5000/otelcol-fips@sha256:0123456789abcdef":                          true,
+		"registry.example.com/fips/otelcol:0.120.0":                                               false,
+		"otelcol@sha256:fips": false,
+	} {
+		t.Run(image, func(t *testing.T) {
+			assert.Equal(t, expected, IsCompliantImage(image))
+		})
+	}
+}
+
+func TestIsApprovedCipherSuite(t *testing.T) {
+	assert.True(t, IsApprovedCipherSuite("TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"))
+	assert.False(t, IsApprovedCipherSuite("TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"))
+}
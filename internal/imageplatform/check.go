@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package imageplatform
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// Targeted returns the node platforms matching the kubernetes.io/os and kubernetes.io/arch entries of the node selector.
+func Targeted(nodePlatforms []platform.NodePlatform, nodeSelector map[string]string) []platform.NodePlatform {
+	var targeted []platform.NodePlatform
+	for _, p := range nodePlatforms {
+		if os, ok := nodeSelector[corev1.LabelOSStable]; ok && os != p.OS {
+			continue
+		}
+		if arch, ok := nodeSelector[corev1.LabelArchStable]; ok && arch != p.Architecture {
+			continue
+		}
+		targeted = append(targeted, p)
+	}
+	return targeted
+}
+
+// Missing returns the node platforms which aren't provided by the image.
+func Missing(ctx context.Context, resolver Resolver, image string, nodePlatforms []platform.NodePlatform) ([]platform.NodePlatform, error) {
+	imagePlatforms, err := resolver.Platforms(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []platform.NodePlatform
+	for _, p := range nodePlatforms {
+		if !slices.Contains(imagePlatforms, p) {
+			missing = append(missing, p)
+		}
+	}
+	return missing, nil
+}
+
+// CollectorWarnings returns a warning when the image of a DaemonSet-mode collector doesn't provide all the
+// platforms of the nodes it runs on. Images which can't be resolved don't produce warnings.
+func CollectorWarnings(ctx context.Context, resolver Resolver, cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []string {
+	if otelcol.Spec.Mode != v1beta1.ModeDaemonSet {
+		return nil
+	}
+	nodePlatforms := Targeted(cfg.NodePlatforms, otelcol.Spec.NodeSelector)
+	if len(nodePlatforms) == 0 {
+		return nil
+	}
+
+	image := otelcol.Spec.Image
+	if len(image) == 0 {
+		image = cfg.CollectorImage
+	}
+	missing, err := Missing(ctx, resolver, image, nodePlatforms)
+	if err != nil || len(missing) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(missing))
+	for i, p := range missing {
+		names[i] = p.String()
+	}
+	return []string{fmt.Sprintf("the image %s doesn't provide the %s platforms of the nodes targeted by the collector daemonset", image, strings.Join(names, ", "))}
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package imageplatform
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+type fakeResolver map[string][]platform.NodePlatform
+
+func (f fakeResolver) Platforms(_ context.Context, image string) ([]platform.NodePlatform, error) {
+	platforms, ok := f[image]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return platforms, nil
+}
+
+var (
+	linuxAmd64   = platform.NodePlatform{OS: "linux", Architecture: "amd64"}
+	linuxArm64   = platform.NodePlatform{OS: "linux", Architecture: "arm64"}
+	windowsAmd64 = platform.NodePlatform{OS: "windows", Architecture: "amd64"}
+)
+
+func TestTargeted(t *testing.T) {
+	nodes := []platform.NodePlatform{linuxAmd64, linuxArm64, windowsAmd64}
+	assert.Equal(t, nodes, Targeted(nodes, nil))
+	assert.Equal(t, []platform.NodePlatform{linuxAmd64, linuxArm64}, Targeted(nodes, map[string]string{"kubernetes.io/os": "linux"}))
+	assert.Equal(t, []platform.NodePlatform{linuxArm64}, Targeted(nodes, map[string]string{"kubernetes.io/os": "linux", "kubernetes.io/arch": "arm64"}))
+}
+
+func TestCollectorWarnings(t *testing.T) {
+	resolver := fakeResolver{
+		"collector:multi":  {linuxAmd64, linuxArm64},
+		"collector:single": {linuxAmd64},
+	}
+	cfg := config.New(
+		config.WithCollectorImage("collector:multi"),
+		config.WithNodePlatforms([]platform.NodePlatform{linuxAmd64, linuxArm64}),
+	)
+
+	for _, tt := range []struct {
+		desc     string
+		mode     v1beta1.Mode
+		image    string
+		selector map[string]string
+		expected []string
+	}{
+		{
+			desc:  "deployment",
+			mode:  v1beta1.ModeDeployment,
+			image: "collector:single",
+		},
+		{
+			desc: "default image",
+			mode: v1beta1.ModeDaemonSet,
+		},
+		{
+			desc:     "missing platform",
+			mode:     v1beta1.ModeDaemonSet,
+			image:    "collector:single",
+			expected: []string{"the image collector:single doesn't provide the linux/arm64 platforms of the nodes targeted by the collector daemonset"},
+		},
+		{
+			desc:     "missing platform not targeted",
+			mode:     v1beta1.ModeDaemonSet,
+			image:    "collector:single",
+			selector: map[string]string{"kubernetes.io/arch": "amd64"},
+		},
+		{
+			desc:  "unresolved image",
+			mode:  v1beta1.ModeDaemonSet,
+			image: "collector:unknown",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode: tt.mode,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Image:        tt.image,
+						NodeSelector: tt.selector,
+					},
+				},
+			}
+			assert.Equal(t, tt.expected, CollectorWarnings(context.Background(), resolver, cfg, otelcol))
+		})
+	}
+}
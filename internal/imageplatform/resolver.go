@@ -0,0 +1,289 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package imageplatform resolves the platforms (OS and architecture) a container image provides, by reading
+// its manifest from the image registry.
+package imageplatform
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/distribution/reference"
+	godigest "github.com/opencontainers/go-digest"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
+)
+
+const (
+	mediaTypeOCIIndex        = "application/vnd.oci.image.index.v1+json"
+	mediaTypeOCIManifest     = "application/vnd.oci.image.manifest.v1+json"
+	mediaTypeDockerList      = "application/vnd.docker.distribution.manifest.list.v2+json"
+	mediaTypeDockerManifest  = "application/vnd.docker.distribution.manifest.v2+json"
+	defaultDockerHubRegistry = "registry-1.docker.io"
+	maxResponseSize          = 4 << 20
+
+	// tagTTL is how long the digest of a tag is cached, after which the tag is resolved again in case it moved.
+	tagTTL = 10 * time.Minute
+	// failureTTL is how long the failures are cached, so that an unreachable registry doesn't slow every admission.
+	failureTTL = time.Minute
+)
+
+var acceptedTypes = strings.Join([]string{mediaTypeOCIIndex, mediaTypeDockerList, mediaTypeOCIManifest, mediaTypeDockerManifest}, ",")
+
+// unauthorizedError is returned when the registry requires a token, with the challenge describing how to get one.
+type unauthorizedError struct {
+	challenge string
+}
+
+func (e *unauthorizedError) Error() string {
+	return "unauthorized: " + e.challenge
+}
+
+// Resolver returns the platforms provided by container images.
+type Resolver interface {
+	Platforms(ctx context.Context, image string) ([]platform.NodePlatform, error)
+}
+
+var _ Resolver = (*RegistryResolver)(nil)
+
+// RegistryResolver reads the image manifests anonymously from the registries. The platforms are cached by manifest
+// digest, which never changes, while the digests of the tags are cached for a while, as the tags can be moved.
+type RegistryResolver struct {
+	client  *http.Client
+	scheme  string
+	timeout time.Duration
+	now     func() time.Time
+
+	mu        sync.Mutex
+	platforms map[string][]platform.NodePlatform
+	tags      map[string]tagEntry
+}
+
+// tagEntry is the resolution of a tag, either the digest of its manifest or the error which prevented it.
+type tagEntry struct {
+	digest  string
+	err     error
+	expires time.Time
+}
+
+// NewRegistryResolver creates a RegistryResolver whose resolutions, with all their requests, are bounded by the
+// given timeout.
+func NewRegistryResolver(timeout time.Duration) *RegistryResolver {
+	return &RegistryResolver{
+		client:    &http.Client{},
+		scheme:    "https",
+		timeout:   timeout,
+		now:       time.Now,
+		platforms: map[string][]platform.NodePlatform{},
+		tags:      map[string]tagEntry{},
+	}
+}
+
+type manifest struct {
+	Config struct {
+		Digest string `json:"digest"`
+	} `json:"config"`
+	Manifests []struct {
+		Platform *struct {
+			OS           string `json:"os"`
+			Architecture string `json:"architecture"`
+		} `json:"platform"`
+	} `json:"manifests"`
+}
+
+type imageConfig struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+}
+
+// Platforms returns the platforms provided by the given image.
+func (r *RegistryResolver) Platforms(ctx context.Context, image string) ([]platform.NodePlatform, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return nil, err
+	}
+	named = reference.TagNameOnly(named)
+	registry := reference.Domain(named)
+	if registry == "docker.io" {
+		registry = defaultDockerHubRegistry
+	}
+	repository := reference.Path(named)
+
+	ref := ""
+	if digested, ok := named.(reference.Digested); ok {
+		ref = digested.Digest().String()
+	} else if tagged, ok := named.(reference.Tagged); ok {
+		ref = tagged.Tag()
+	}
+
+	r.mu.Lock()
+	if entry, ok := r.tags[image]; ok && r.now().Before(entry.expires) {
+		ref = entry.digest
+		if entry.err != nil {
+			r.mu.Unlock()
+			return nil, entry.err
+		}
+	}
+	cached, ok := r.platforms[registry+"/"+repository+"@"+ref]
+	r.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	digest, platforms, err := r.resolve(ctx, registry, repository, ref)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := named.(reference.Digested); !ok {
+		ttl := tagTTL
+		if err != nil {
+			ttl = failureTTL
+		}
+		r.tags[image] = tagEntry{digest: digest, err: err, expires: r.now().Add(ttl)}
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.platforms[registry+"/"+repository+"@"+digest] = platforms
+	return platforms, nil
+}
+
+// resolve reads the manifest of the reference, returning its digest and the platforms it provides.
+func (r *RegistryResolver) resolve(ctx context.Context, registry, repository, ref string) (string, []platform.NodePlatform, error) {
+	token := ""
+	body, digest, err := r.get(ctx, registry, repository, "manifests/"+ref, acceptedTypes, token)
+	var unauthorized *unauthorizedError
+	if errors.As(err, &unauthorized) {
+		if token, err = r.token(ctx, registry, repository, unauthorized.challenge); err != nil {
+			return "", nil, err
+		}
+		body, digest, err = r.get(ctx, registry, repository, "manifests/"+ref, acceptedTypes, token)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	if digest == "" {
+		digest = godigest.FromBytes(body).String()
+	}
+
+	m := manifest{}
+	if err = json.Unmarshal(body, &m); err != nil {
+		return "", nil, err
+	}
+
+	var platforms []platform.NodePlatform
+	if len(m.Manifests) > 0 {
+		for _, entry := range m.Manifests {
+			// attestations are listed with an unknown platform
+			if entry.Platform == nil || entry.Platform.OS == "unknown" {
+				continue
+			}
+			platforms = append(platforms, platform.NodePlatform{OS: entry.Platform.OS, Architecture: entry.Platform.Architecture})
+		}
+	} else {
+		// a single platform image, its platform is part of the image configuration
+		body, _, err = r.get(ctx, registry, repository, "blobs/"+m.Config.Digest, "*/*", token)
+		if err != nil {
+			return "", nil, err
+		}
+		cfg := imageConfig{}
+		if err = json.Unmarshal(body, &cfg); err != nil {
+			return "", nil, err
+		}
+		platforms = append(platforms, platform.NodePlatform{OS: cfg.OS, Architecture: cfg.Architecture})
+	}
+	return digest, platforms, nil
+}
+
+// get returns the body of the registry response, along with the digest of the content the registry reports.
+func (r *RegistryResolver) get(ctx context.Context, registry, repository, path, accept, token string) ([]byte, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s://%s/v2/%s/%s", r.scheme, registry, repository, path), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", accept)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized && token == "" {
+		return nil, "", &unauthorizedError{challenge: resp.Header.Get("WWW-Authenticate")}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status code %d for %s", resp.StatusCode, req.URL)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	return body, resp.Header.Get("Docker-Content-Digest"), err
+}
+
+// token requests an anonymous pull token, following the registry's Bearer challenge.
+func (r *RegistryResolver) token(ctx context.Context, registry, repository, challenge string) (string, error) {
+	params := parseChallenge(challenge)
+	realm, ok := params["realm"]
+	if !ok {
+		return "", fmt.Errorf("registry %s doesn't provide a bearer token realm", registry)
+	}
+	query := url.Values{}
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", repository))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status code %d for the %s token", resp.StatusCode, registry)
+	}
+
+	body := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// parseChallenge parses the parameters of a `Bearer realm="...",service="..."` challenge.
+func parseChallenge(challenge string) map[string]string {
+	params := map[string]string{}
+	scheme, rest, ok := strings.Cut(challenge, " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return params
+	}
+	for _, param := range strings.Split(rest, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok {
+			continue
+		}
+		params[key] = strings.Trim(value, `"`)
+	}
+	return params
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package imageplatform
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/platform"
+)
+
+func newTestResolver(server *httptest.Server) *RegistryResolver {
+	resolver := NewRegistryResolver(5 * time.Second)
+	resolver.client = server.Client()
+	return resolver
+}
+
+func TestRegistryResolver(t *testing.T) {
+	requests := 0
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests++
+		switch req.URL.Path {
+		case "/token":
+			assert.Equal(t, "repository:otel/collector:pull", req.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte(`{"token":"secret"}`))
+		case "/v2/otel/collector/manifests/multi":
+			if req.Header.Get("Authorization") != "Bearer secret" {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Docker-Content-Digest", "sha256:multi")
+			_, _ = w.Write([]byte(`{"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[
+				{"platform":{"os":"linux","architecture":"amd64"}},
+				{"platform":{"os":"linux","architecture":"arm64","variant":"v8"}},
+				{"platform":{"os":"unknown","architecture":"unknown"}}]}`))
+		case "/v2/otel/collector/manifests/single":
+			_, _ = w.Write([]byte(`{"mediaType":"application/vnd.oci.image.manifest.v1+json","config":{"digest":"sha256:abc"}}`))
+		case "/v2/otel/collector/blobs/sha256:abc":
+			_, _ = w.Write([]byte(`{"os":"linux","architecture":"s390x"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	registry := strings.TrimPrefix(server.URL, "https://")
+
+	t.Run("image index with authentication", func(t *testing.T) {
+		resolver := newTestResolver(server)
+		platforms, err := resolver.Platforms(context.Background(), registry+"/otel/collector:multi")
+		require.NoError(t, err)
+		assert.Equal(t, []platform.NodePlatform{{OS: "linux", Architecture: "amd64"}, {OS: "linux", Architecture: "arm64"}}, platforms)
+
+		// the result is cached
+		requests = 0
+		_, err = resolver.Platforms(context.Background(), registry+"/otel/collector:multi")
+		require.NoError(t, err)
+		assert.Equal(t, 0, requests)
+	})
+
+	t.Run("moved tag", func(t *testing.T) {
+		now := time.Now()
+		resolver := newTestResolver(server)
+		resolver.now = func() time.Time { return now }
+		_, err := resolver.Platforms(context.Background(), registry+"/otel/collector:multi")
+		require.NoError(t, err)
+
+		// the digest is cached by itself
+		requests = 0
+		_, err = resolver.Platforms(context.Background(), registry+"/otel/collector@sha256:0000000000000000000000000000000000000000000000000000000000000000")
+		assert.Error(t, err)
+		assert.Equal(t, 1, requests)
+
+		// the tag is resolved again once its digest expired
+		now = now.Add(tagTTL)
+		requests = 0
+		_, err = resolver.Platforms(context.Background(), registry+"/otel/collector:multi")
+		require.NoError(t, err)
+		assert.Equal(t, 3, requests)
+	})
+
+	t.Run("single platform image", func(t *testing.T) {
+		platforms, err := newTestResolver(server).Platforms(context.Background(), registry+"/otel/collector:single")
+		require.NoError(t, err)
+		assert.Equal(t, []platform.NodePlatform{{OS: "linux", Architecture: "s390x"}}, platforms)
+	})
+
+	t.Run("missing image", func(t *testing.T) {
+		resolver := newTestResolver(server)
+		_, err := resolver.Platforms(context.Background(), registry+"/otel/collector:missing")
+		assert.Error(t, err)
+
+		// the failure is cached
+		requests = 0
+		_, err = resolver.Platforms(context.Background(), registry+"/otel/collector:missing")
+		assert.Error(t, err)
+		assert.Equal(t, 0, requests)
+	})
+}
+
+func TestRegistryResolverTimeout(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		<-req.Context().Done()
+	}))
+	defer server.Close()
+
+	resolver := newTestResolver(server)
+	resolver.timeout = 10 * time.Millisecond
+	_, err := resolver.Platforms(context.Background(), strings.TrimPrefix(server.URL, "https://")+"/otel/collector:slow")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestParseChallenge(t *testing.T) {
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+	}, parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io"`))
+	assert.Empty(t, parseChallenge(`Basic realm="registry"`))
+}
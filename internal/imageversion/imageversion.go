@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package imageversion reads the version of a component from the tag of its image.
+package imageversion
+
+import (
+	"regexp"
+	"strings"
+)
+
+var versionPattern = regexp.MustCompile(`^v?(\d+\.\d+(\.\d+)?)`)
+
+// FromImage returns the version at the start of the tag of the image, without its v prefix and its suffix, e.g.
+// 0.120.0 for otel/opentelemetry-collector:0.120.0 or otelcol:v0.120.0-windows. It is empty when the image has no tag
+// or its tag doesn't start with a version, e.g. latest.
+func FromImage(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return ""
+	}
+	match := versionPattern.FindStringSubmatch(image[i+1:])
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package imageversion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromImage(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected string
+	}{
+		{image: "otel/opentelemetry-collector-contrib:0.120.0", expected: "0.120.0"},
+		{image: "ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector-k8s:v0.120.1", expected: "0.120.1"},
+		{image: "otelcol:v0.120.0-windows", expected: "0.120.0"},
+		{image: "registry:5000/otelcol:0.120.0-windows2022", expected: "0.120.0"},
+		{image: "ghcr.io/org/autoinstrumentation-python:0.48b0", expected: "0.48"},
+		{image: "otelcol:1.2@sha256:0123456789abcdef", expected: "1.2"},
+		{image: "registry:5000/otelcol", expected: ""},
+		{image: "otelcol:latest", expected: ""},
+		{image: "otelcol@sha256:0123456789abcdef", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FromImage(tt.image))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package istio contains helpers to make the injected workloads play well with the Istio service mesh.
+package istio
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// sidecarInject is the label (or legacy annotation) used to opt a pod in or out of the Istio proxy injection.
+	sidecarInject = "sidecar.istio.io/inject"
+	// injectionLabel is the namespace label used to enable the Istio proxy injection.
+	injectionLabel = "istio-injection"
+	// revisionLabel is the label used to enable the Istio proxy injection for a given control plane revision.
+	revisionLabel = "istio.io/rev"
+
+	// ExcludeInboundPortsAnnotation lists the ports for which the inbound traffic isn't redirected to the Istio proxy.
+	ExcludeInboundPortsAnnotation = "traffic.sidecar.istio.io/excludeInboundPorts"
+	// ProxyConfigAnnotation overrides the Istio proxy configuration for a pod.
+	ProxyConfigAnnotation = "proxy.istio.io/config"
+
+	holdApplicationUntilProxyStarts = "holdApplicationUntilProxyStarts: true"
+)
+
+// SidecarInjected returns whether Istio will inject its proxy into the given pod, following the same
+// precedence as the Istio injection webhook: pod settings first, then namespace settings.
+func SidecarInjected(ns corev1.Namespace, pod corev1.Pod) bool {
+	if value, ok := pod.Labels[sidecarInject]; ok {
+		return strings.EqualFold(value, "true")
+	}
+	if value, ok := pod.Annotations[sidecarInject]; ok {
+		return strings.EqualFold(value, "true")
+	}
+	if value, ok := ns.Labels[injectionLabel]; ok {
+		return value == "enabled"
+	}
+	if _, ok := pod.Labels[revisionLabel]; ok {
+		return true
+	}
+	_, ok := ns.Labels[revisionLabel]
+	return ok
+}
+
+// ExcludeInboundPorts adds the given ports to the ports excluded from the Istio proxy inbound redirection,
+// keeping the ones already set on the pod.
+func ExcludeInboundPorts(pod corev1.Pod, ports []int32) corev1.Pod {
+	if len(ports) == 0 {
+		return pod
+	}
+
+	var excluded []string
+	if existing := pod.Annotations[ExcludeInboundPortsAnnotation]; existing != "" {
+		for _, port := range strings.Split(existing, ",") {
+			excluded = append(excluded, strings.TrimSpace(port))
+		}
+	}
+	for _, port := range ports {
+		p := strconv.Itoa(int(port))
+		if !slices.Contains(excluded, p) {
+			excluded = append(excluded, p)
+		}
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[ExcludeInboundPortsAnnotation] = strings.Join(excluded, ",")
+	return pod
+}
+
+// HoldApplicationUntilProxyStarts makes the application containers wait for the Istio proxy to be ready, so
+// that the telemetry sent at startup isn't dropped. An existing proxy configuration is never overridden.
+func HoldApplicationUntilProxyStarts(pod corev1.Pod) corev1.Pod {
+	if _, ok := pod.Annotations[ProxyConfigAnnotation]; ok {
+		return pod
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[ProxyConfigAnnotation] = holdApplicationUntilProxyStarts
+	return pod
+}
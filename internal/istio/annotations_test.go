@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package istio
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSidecarInjected(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		nsLabels map[string]string
+		pod      metav1.ObjectMeta
+		expected bool
+	}{
+		{
+			desc:     "no labels",
+			expected: false,
+		},
+		{
+			desc:     "namespace injection enabled",
+			nsLabels: map[string]string{"istio-injection": "enabled"},
+			expected: true,
+		},
+		{
+			desc:     "namespace injection disabled",
+			nsLabels: map[string]string{"istio-injection": "disabled", "istio.io/rev": "canary"},
+			expected: false,
+		},
+		{
+			desc:     "namespace revision",
+			nsLabels: map[string]string{"istio.io/rev": "canary"},
+			expected: true,
+		},
+		{
+			desc:     "pod opts out",
+			nsLabels: map[string]string{"istio-injection": "enabled"},
+			pod:      metav1.ObjectMeta{Labels: map[string]string{"sidecar.istio.io/inject": "false"}},
+			expected: false,
+		},
+		{
+			desc:     "pod opts in with legacy annotation",
+			pod:      metav1.ObjectMeta{Annotations: map[string]string{"sidecar.istio.io/inject": "true"}},
+			expected: true,
+		},
+		{
+			desc:     "pod revision",
+			pod:      metav1.ObjectMeta{Labels: map[string]string{"istio.io/rev": "stable"}},
+			expected: true,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: tt.nsLabels}}
+			assert.Equal(t, tt.expected, SidecarInjected(ns, corev1.Pod{ObjectMeta: tt.pod}))
+		})
+	}
+}
+
+func TestExcludeInboundPorts(t *testing.T) {
+	t.Run("no ports", func(t *testing.T) {
+		pod := ExcludeInboundPorts(corev1.Pod{}, nil)
+		assert.Nil(t, pod.Annotations)
+	})
+
+	t.Run("new annotation", func(t *testing.T) {
+		pod := ExcludeInboundPorts(corev1.Pod{}, []int32{4317, 4318})
+		assert.Equal(t, "4317,4318", pod.Annotations[ExcludeInboundPortsAnnotation])
+	})
+
+	t.Run("merged with the existing ports", func(t *testing.T) {
+		pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ExcludeInboundPortsAnnotation: "8080, 4317"}}}
+		pod = ExcludeInboundPorts(pod, []int32{4317, 4318})
+		assert.Equal(t, "8080,4317,4318", pod.Annotations[ExcludeInboundPortsAnnotation])
+	})
+}
+
+func TestHoldApplicationUntilProxyStarts(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		pod := HoldApplicationUntilProxyStarts(corev1.Pod{})
+		assert.Equal(t, "holdApplicationUntilProxyStarts: true", pod.Annotations[ProxyConfigAnnotation])
+	})
+
+	t.Run("user configuration is kept", func(t *testing.T) {
+		pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ProxyConfigAnnotation: "concurrency: 2"}}}
+		pod = HoldApplicationUntilProxyStarts(pod)
+		assert.Equal(t, "concurrency: 2", pod.Annotations[ProxyConfigAnnotation])
+	})
+}
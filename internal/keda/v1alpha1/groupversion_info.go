@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package v1alpha1 contains the subset of the KEDA keda.sh/v1alpha1 API the operator manages.
+// The types mirror https://github.com/kedacore/keda/tree/main/apis/keda/v1alpha1, only with
+// the fields the operator sets, so that KEDA itself isn't a dependency of the operator.
+// +kubebuilder:object:generate=true
+// +kubebuilder:skip
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects.
+	GroupVersion = schema.GroupVersion{Group: "keda.sh", Version: "v1alpha1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
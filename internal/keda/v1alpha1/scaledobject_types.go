@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// ScaledObject is a specification for a KEDA ScaledObject resource.
+type ScaledObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ScaledObjectSpec `json:"spec"`
+}
+
+// ScaledObjectSpec is the spec for a ScaledObject resource.
+type ScaledObjectSpec struct {
+	ScaleTargetRef *ScaleTarget `json:"scaleTargetRef"`
+	// +optional
+	PollingInterval *int32 `json:"pollingInterval,omitempty"`
+	// +optional
+	CooldownPeriod *int32 `json:"cooldownPeriod,omitempty"`
+	// +optional
+	MinReplicaCount *int32 `json:"minReplicaCount,omitempty"`
+	// +optional
+	MaxReplicaCount *int32 `json:"maxReplicaCount,omitempty"`
+	// +optional
+	Advanced *AdvancedConfig `json:"advanced,omitempty"`
+
+	Triggers []ScaleTriggers `json:"triggers"`
+}
+
+// AdvancedConfig specifies advance scaling options.
+type AdvancedConfig struct {
+	// +optional
+	HorizontalPodAutoscalerConfig *HorizontalPodAutoscalerConfig `json:"horizontalPodAutoscalerConfig,omitempty"`
+}
+
+// HorizontalPodAutoscalerConfig specifies horizontal scale config.
+type HorizontalPodAutoscalerConfig struct {
+	// +optional
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// ScaleTarget holds the reference to the scale target Object.
+type ScaleTarget struct {
+	Name string `json:"name"`
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// ScaleTriggers reference the scaler that will be used.
+type ScaleTriggers struct {
+	Type string `json:"type"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	UseCachedMetrics bool `json:"useCachedMetrics,omitempty"`
+
+	Metadata map[string]string `json:"metadata"`
+	// +optional
+	AuthenticationRef *AuthenticationRef `json:"authenticationRef,omitempty"`
+	// +optional
+	MetricType autoscalingv2.MetricTargetType `json:"metricType,omitempty"`
+}
+
+// AuthenticationRef points to the TriggerAuthentication or ClusterTriggerAuthentication object that
+// is used to authenticate the scaler with the environment.
+type AuthenticationRef struct {
+	Name string `json:"name"`
+	// Kind of the resource being referred to. Defaults to TriggerAuthentication.
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ScaledObjectList is a list of ScaledObject resources.
+type ScaledObjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+	Items           []ScaledObject `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ScaledObject{}, &ScaledObjectList{})
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// CanaryLabel marks the canary deployment of a collector and its pods.
+const CanaryLabel = "opentelemetry.io/canary"
+
+// CanaryDeployment builds the canary deployment running the pod template of the given collector deployment. Its pods
+// are labeled as another component than the collector pods, so that neither the collector deployment, nor its
+// services, autoscalers and disruption budgets select them.
+func CanaryDeployment(params manifests.Params, deployment *appsv1.Deployment) *appsv1.Deployment {
+	canary := deployment.DeepCopy()
+	canary.Name = naming.CollectorCanary(params.OtelCol.Name)
+	for _, labels := range []map[string]string{canary.Labels, canary.Spec.Selector.MatchLabels, canary.Spec.Template.Labels} {
+		labels["app.kubernetes.io/component"] = ComponentOpenTelemetryCollectorCanary
+		labels[CanaryLabel] = "true"
+	}
+	canary.Spec.Replicas = nil
+	if params.OtelCol.Spec.Rollout != nil && params.OtelCol.Spec.Rollout.Canary != nil {
+		canary.Spec.Replicas = params.OtelCol.Spec.Rollout.Canary.Replicas
+	}
+	return canary
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestCanaryDeployment(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-namespace",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Replicas: ptr.To[int32](5),
+			},
+			Rollout: &v1beta1.Rollout{
+				Canary: &v1beta1.CanaryRollout{
+					Replicas: ptr.To[int32](2),
+				},
+			},
+		},
+	}
+	params := manifests.Params{
+		Config:  config.New(),
+		OtelCol: otelcol,
+		Log:     testLogger,
+	}
+	deployment, err := Deployment(params)
+	require.NoError(t, err)
+
+	// test
+	canary := CanaryDeployment(params, deployment)
+
+	// verify
+	assert.Equal(t, "my-instance-collector-canary", canary.Name)
+	assert.Equal(t, "my-namespace", canary.Namespace)
+	assert.Equal(t, ptr.To[int32](2), canary.Spec.Replicas)
+	assert.Equal(t, "true", canary.Labels[CanaryLabel])
+	assert.Equal(t, "true", canary.Spec.Selector.MatchLabels[CanaryLabel])
+	assert.Equal(t, "true", canary.Spec.Template.Labels[CanaryLabel])
+	// the collector deployment and services don't select the canary pods
+	assert.Equal(t, ComponentOpenTelemetryCollectorCanary, canary.Spec.Selector.MatchLabels["app.kubernetes.io/component"])
+	assert.Equal(t, ComponentOpenTelemetryCollectorCanary, canary.Spec.Template.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, deployment.Spec.Template.Spec, canary.Spec.Template.Spec)
+	assert.Equal(t, deployment.Spec.Template.Annotations, canary.Spec.Template.Annotations)
+
+	// the collector deployment is left untouched
+	assert.Equal(t, ptr.To[int32](5), deployment.Spec.Replicas)
+	assert.NotContains(t, deployment.Labels, CanaryLabel)
+	assert.NotContains(t, deployment.Spec.Selector.MatchLabels, CanaryLabel)
+	assert.NotContains(t, deployment.Spec.Template.Labels, CanaryLabel)
+}
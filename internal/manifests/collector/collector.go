@@ -6,6 +6,7 @@ package collector
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -16,7 +17,8 @@ import (
 )
 
 const (
-	ComponentOpenTelemetryCollector = "opentelemetry-collector"
+	ComponentOpenTelemetryCollector       = "opentelemetry-collector"
+	ComponentOpenTelemetryCollectorCanary = "opentelemetry-collector-canary"
 )
 
 // Build creates the manifest for the collector resource.
@@ -32,18 +34,27 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		manifestFactories = append(manifestFactories, manifests.Factory(PodDisruptionBudget))
 	case v1beta1.ModeDaemonSet:
 		manifestFactories = append(manifestFactories, manifests.Factory(DaemonSet))
+		manifestFactories = append(manifestFactories, manifests.Factory(WindowsDaemonSet))
+	case v1beta1.ModeJob:
+		manifestFactories = append(manifestFactories, manifests.Factory(Job))
+	case v1beta1.ModeCronJob:
+		manifestFactories = append(manifestFactories, manifests.Factory(CronJob))
 	case v1beta1.ModeSidecar:
 		params.Log.V(5).Info("not building sidecar...")
 	}
 	manifestFactories = append(manifestFactories, []manifests.K8sManifestFactory[manifests.Params]{
 		manifests.Factory(ConfigMap),
 		manifests.Factory(HorizontalPodAutoscaler),
+		manifests.Factory(ScaledObject),
+		manifests.Factory(VerticalPodAutoscaler),
 		manifests.Factory(ServiceAccount),
 		manifests.Factory(Service),
 		manifests.Factory(HeadlessService),
+		manifests.Factory(OTLPHeadlessService),
 		manifests.Factory(MonitoringService),
 		manifests.Factory(ExtensionService),
 		manifests.Factory(Ingress),
+		manifests.Factory(NetworkPolicy),
 	}...)
 
 	if featuregate.CollectorUsesTargetAllocatorCR.IsEnabled() {
@@ -56,12 +67,14 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		} else {
 			manifestFactories = append(manifestFactories, manifests.Factory(ServiceMonitor), manifests.Factory(ServiceMonitorMonitoring))
 		}
+		manifestFactories = append(manifestFactories, manifests.Factory(PrometheusRule))
 	}
 
 	if params.Config.CreateRBACPermissions == rbac.Available {
 		manifestFactories = append(manifestFactories,
 			manifests.Factory(ClusterRole),
 			manifests.Factory(ClusterRoleBinding),
+			manifests.Factory(TargetAllocatorClusterRoleBinding),
 		)
 	}
 
@@ -87,6 +100,14 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		return nil, errors.Join(w...)
 	}
 
+	services, err := AdditionalServices(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, service := range services {
+		resourceManifests = append(resourceManifests, service)
+	}
+
 	routes, err := Routes(params)
 	if err != nil {
 		return nil, err
@@ -95,6 +116,22 @@ func Build(params manifests.Params) ([]client.Object, error) {
 	for _, route := range routes {
 		resourceManifests = append(resourceManifests, route)
 	}
+
+	httpRoutes, err := HTTPRoutes(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range httpRoutes {
+		resourceManifests = append(resourceManifests, route)
+	}
+
+	grpcRoutes, err := GRPCRoutes(params)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range grpcRoutes {
+		resourceManifests = append(resourceManifests, route)
+	}
 	return resourceManifests, nil
 }
 
@@ -104,3 +141,17 @@ func needsCheckSaPermissions(params manifests.Params) bool {
 		params.Reviewer != nil &&
 		params.OtelCol.Spec.ServiceAccount != ""
 }
+
+// MissingRbacRules returns the sorted descriptions of the RBAC rules the components of the configuration need and the
+// service account of the collector misses, when the operator can't create them itself.
+func MissingRbacRules(params manifests.Params) ([]string, error) {
+	if params.Config.CreateRBACPermissions != rbac.NotAvailable || params.Reviewer == nil || params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
+		return nil, nil
+	}
+	missing, err := CheckRbacRules(params, ServiceAccountName(params.OtelCol))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(missing)
+	return missing, nil
+}
@@ -130,6 +130,92 @@ func (m *mockReviewer) CanAccess(ctx context.Context, serviceAccount, serviceAcc
 	return nil, nil
 }
 
+// denyingReviewer denies all the resource rules it checks.
+type denyingReviewer struct{ mockReviewer }
+
+func (m *denyingReviewer) CheckPolicyRules(ctx context.Context, serviceAccount, serviceAccountNamespace string, rules ...*rbacv1.PolicyRule) ([]*v1.SubjectAccessReview, error) {
+	var reviews []*v1.SubjectAccessReview
+	for _, rule := range rules {
+		for _, resource := range rule.Resources {
+			for _, verb := range rule.Verbs {
+				reviews = append(reviews, &v1.SubjectAccessReview{
+					Spec: v1.SubjectAccessReviewSpec{
+						User:               fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccountNamespace, serviceAccount),
+						ResourceAttributes: &v1.ResourceAttributes{Group: rule.APIGroups[0], Resource: resource, Verb: verb},
+					},
+				})
+			}
+		}
+	}
+	return reviews, nil
+}
+
+func TestMissingRbacRules(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Config: v1beta1.Config{
+				Processors: &v1beta1.AnyConfig{
+					Object: map[string]any{
+						"resourcedetection": map[string]any{"detectors": []any{"k8snode"}},
+					},
+				},
+				Service: v1beta1.Service{
+					Pipelines: map[string]*v1beta1.Pipeline{
+						"metrics": {
+							Processors: []string{"resourcedetection"},
+						},
+					},
+				},
+			},
+		},
+	}
+	otelcol.Name = "test"
+	otelcol.Namespace = "observability"
+	sidecar := *otelcol.DeepCopy()
+	sidecar.Spec.Mode = v1beta1.ModeSidecar
+
+	tests := []struct {
+		name     string
+		params   manifests.Params
+		expected []string
+	}{
+		{
+			name: "missing rules",
+			params: manifests.Params{
+				Config:   config.New(config.WithRBACPermissions(autoRbac.NotAvailable)),
+				Reviewer: &denyingReviewer{},
+				OtelCol:  otelcol,
+			},
+			expected: []string{"missing the following rules for system:serviceaccount:observability:test-collector - nodes: [get,list]"},
+		},
+		{
+			name: "rbac available",
+			params: manifests.Params{
+				Config:   config.New(config.WithRBACPermissions(autoRbac.Available)),
+				Reviewer: &denyingReviewer{},
+				OtelCol:  otelcol,
+			},
+		},
+		{
+			name: "sidecar",
+			params: manifests.Params{
+				Config:   config.New(config.WithRBACPermissions(autoRbac.NotAvailable)),
+				Reviewer: &denyingReviewer{},
+				OtelCol:  sidecar,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.params.Log = logr.Discard()
+			missing, err := MissingRbacRules(tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, missing)
+		})
+	}
+}
+
 func TestBuild(t *testing.T) {
 	logger := logr.Discard()
 	tests := []struct {
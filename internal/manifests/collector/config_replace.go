@@ -4,6 +4,7 @@
 package collector
 
 import (
+	"errors"
 	"time"
 
 	go_yaml "github.com/goccy/go-yaml"
@@ -15,6 +16,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector/adapters"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+const (
+	// taAuthExtension is the extension authenticating the collector to the target allocator.
+	taAuthExtension = "bearertokenauth/targetallocator"
+	// serviceAccountTokenFile is where the collector's service account token is mounted.
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
 )
 
 type targetAllocator struct {
@@ -58,6 +67,13 @@ func ReplaceConfig(otelcol v1beta1.OpenTelemetryCollector, targetAllocator *v1al
 		return "", validateCfgPromErr
 	}
 
+	if featuregate.EnableTargetAllocatorAuthentication.IsEnabled() {
+		options = append(options, ta.WithServiceAccountTokenAuth(taAuthExtension, serviceAccountTokenFile))
+		if err = addTAAuthExtension(config); err != nil {
+			return "", err
+		}
+	}
+
 	// To avoid issues caused by Prometheus validation logic, which fails regex validation when it encounters
 	// $$ in the prom config, we update the YAML file directly without marshaling and unmarshalling.
 	updPromCfgMap, getCfgPromErr := ta.AddTAConfigToPromConfig(promCfgMap, naming.TAService(targetAllocator.Name), options...)
@@ -75,3 +91,36 @@ func ReplaceConfig(otelcol v1beta1.OpenTelemetryCollector, targetAllocator *v1al
 
 	return string(out), nil
 }
+
+// addTAAuthExtension configures and enables the extension providing the service account token to the target allocator.
+func addTAAuthExtension(config map[interface{}]interface{}) error {
+	if config["extensions"] == nil {
+		config["extensions"] = make(map[interface{}]interface{})
+	}
+	extensions, ok := config["extensions"].(map[interface{}]interface{})
+	if !ok {
+		return errors.New("the extensions property in the configuration isn't a map")
+	}
+	extensions[taAuthExtension] = map[interface{}]interface{}{
+		"filename": serviceAccountTokenFile,
+	}
+
+	if config["service"] == nil {
+		config["service"] = make(map[interface{}]interface{})
+	}
+	service, ok := config["service"].(map[interface{}]interface{})
+	if !ok {
+		return errors.New("the service property in the configuration isn't a map")
+	}
+	enabled, ok := service["extensions"].([]interface{})
+	if !ok && service["extensions"] != nil {
+		return errors.New("the service.extensions property in the configuration isn't a list")
+	}
+	for _, extension := range enabled {
+		if extension == taAuthExtension {
+			return nil
+		}
+	}
+	service["extensions"] = append(enabled, taAuthExtension)
+	return nil
+}
@@ -9,9 +9,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	colfg "go.opentelemetry.io/collector/featuregate"
 	"gopkg.in/yaml.v2"
 
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestPrometheusParser(t *testing.T) {
@@ -125,4 +127,20 @@ func TestReplaceConfig(t *testing.T) {
 
 		assert.YAMLEq(t, expectedConfig, actualConfig)
 	})
+
+	t.Run("should authenticate to the TargetAllocator with the service account token", func(t *testing.T) {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorAuthentication.ID(), true))
+		defer func() {
+			require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorAuthentication.ID(), false))
+		}()
+
+		expectedConfigBytes, err := os.ReadFile("testdata/config_expected_targetallocator_auth.yaml")
+		assert.NoError(t, err)
+		expectedConfig := string(expectedConfigBytes)
+
+		actualConfig, err := ReplaceConfig(param.OtelCol, param.TargetAllocator)
+		assert.NoError(t, err)
+
+		assert.YAMLEq(t, expectedConfig, actualConfig)
+	})
 }
@@ -9,7 +9,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	ta "github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
@@ -19,11 +19,10 @@ import (
 )
 
 func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
-	hash, err := manifestutils.GetConfigMapSHA(params.OtelCol.Spec.Config)
+	name, err := configMapName(params.OtelCol)
 	if err != nil {
 		return nil, err
 	}
-	name := naming.ConfigMap(params.OtelCol.Name, hash)
 	collectorName := naming.Collector(params.OtelCol.Name)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, collectorName, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
 
@@ -34,7 +33,7 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 
 	replaceCfgOpts := []ta.TAOption{}
 
-	if params.OtelCol.Spec.TargetAllocator.Enabled && params.Config.CertManagerAvailability == certmanager.Available && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
+	if params.OtelCol.Spec.TargetAllocator.Enabled && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
 		replaceCfgOpts = append(replaceCfgOpts, ta.WithTLSConfig(
 			filepath.Join(constants.TACollectorTLSDirPath, constants.TACollectorCAFileName),
 			filepath.Join(constants.TACollectorTLSDirPath, constants.TACollectorTLSCertFileName),
@@ -62,3 +61,16 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 		},
 	}, nil
 }
+
+// configMapName returns the name of the ConfigMap of the collector configuration: a new one for every configuration,
+// or a single one updated in place when the collector reloads its configuration.
+func configMapName(otelcol v1beta1.OpenTelemetryCollector) (string, error) {
+	if otelcol.Spec.ReloadsConfig() {
+		return naming.ReloadedConfigMap(otelcol.Name), nil
+	}
+	hash, err := manifestutils.GetConfigMapSHA(otelcol.Spec.Config)
+	if err != nil {
+		return "", err
+	}
+	return naming.ConfigMap(otelcol.Name, hash), nil
+}
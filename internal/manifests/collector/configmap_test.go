@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	colfg "go.opentelemetry.io/collector/featuregate"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
@@ -69,6 +70,17 @@ service:
 		}
 	})
 
+	t.Run("should return the config map updated in place with the reload strategy", func(t *testing.T) {
+		param := deploymentParams()
+		param.OtelCol.Spec.Rollout = &v1beta1.Rollout{Strategy: v1beta1.RolloutStrategyReload}
+
+		actual, err := ConfigMap(param)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "test-collector-config", actual.Name)
+		assert.Equal(t, "test-collector-config", Volumes(param.Config, param.OtelCol)[0].ConfigMap.Name)
+	})
+
 	t.Run("should return expected escaped collector config map with target_allocator config block", func(t *testing.T) {
 		expectedData := map[string]string{
 			"collector.yaml": `exporters:
@@ -146,6 +158,9 @@ service:
 		require.NoError(t, err)
 		flgs := featuregate.Flags(colfg.GlobalRegistry())
 		err = flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+		t.Cleanup(func() {
+			_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+		})
 		require.NoError(t, err)
 
 		hash, _ := manifestutils.GetConfigMapSHA(param.OtelCol.Spec.Config)
@@ -202,6 +217,9 @@ service:
 		require.NoError(t, err)
 		flgs := featuregate.Flags(colfg.GlobalRegistry())
 		err = flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+		t.Cleanup(func() {
+			_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+		})
 		param.TargetAllocator = nil
 		require.NoError(t, err)
 
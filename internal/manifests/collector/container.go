@@ -6,16 +6,21 @@ package collector
 import (
 	"fmt"
 	"path"
+	"regexp"
+	"slices"
 	"sort"
+	"time"
 
 	"github.com/go-logr/logr"
-	"github.com/operator-framework/operator-lib/proxy"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
-	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
@@ -25,6 +30,110 @@ import (
 // https://pkg.go.dev/k8s.io/apimachinery/pkg/util/validation#IsValidPortName
 const maxPortLen = 15
 
+// configReloadPeriodSeconds is how often the config-reloader container checks the configuration file.
+const configReloadPeriodSeconds = 10
+
+// Containers builds the containers of the collector pods: the collector container and the additional containers,
+// placed before or after it depending on the spec.
+func Containers(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector) []corev1.Container {
+	collector := Container(cfg, logger, otelcol, true)
+	containers := make([]corev1.Container, 0, len(otelcol.Spec.AdditionalContainers)+2)
+	if otelcol.Spec.AdditionalContainersPosition == v1beta1.ContainerPositionAfter {
+		containers = append(containers, collector)
+		containers = append(containers, otelcol.Spec.AdditionalContainers...)
+	} else {
+		containers = append(containers, otelcol.Spec.AdditionalContainers...)
+		containers = append(containers, collector)
+	}
+	if otelcol.Spec.ReloadsConfig() {
+		containers = append(containers, ConfigReloaderContainer(cfg, otelcol))
+	}
+	if stopsCollector(otelcol) {
+		containers = append(containers, JobStopperContainer(cfg, otelcol.Spec.Job.Duration.Duration))
+	}
+	return containers
+}
+
+// shareProcessNamespace returns whether the containers of the collector pods share their process namespace, which the
+// config-reloader and job-stopper containers need to signal the collector.
+func shareProcessNamespace(otelcol v1beta1.OpenTelemetryCollector) *bool {
+	return ptr.To(otelcol.Spec.ShareProcessNamespace || otelcol.Spec.ReloadsConfig() || stopsCollector(otelcol))
+}
+
+// stopsCollector returns whether a job-stopper container stops the collector of the job pods after the job duration.
+func stopsCollector(otelcol v1beta1.OpenTelemetryCollector) bool {
+	isJob := otelcol.Spec.Mode == v1beta1.ModeJob || otelcol.Spec.Mode == v1beta1.ModeCronJob
+	return isJob && otelcol.Spec.Job != nil && otelcol.Spec.Job.Duration != nil
+}
+
+// collectorProcessPattern returns the pkill pattern matching the collector process. Its first character is a
+// character class, so that the pattern doesn't match the scripts using it.
+func collectorProcessPattern(cfg config.Config) string {
+	return "[-]-config=" + regexp.QuoteMeta(path.Join("/conf", cfg.CollectorConfigMapEntry))
+}
+
+// configReloaderResources are the resources of the config-reloader container, which only runs a shell loop.
+var configReloaderResources = corev1.ResourceRequirements{
+	Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("5m"),
+		corev1.ResourceMemory: resource.MustParse("8Mi"),
+	},
+	Limits: corev1.ResourceList{
+		corev1.ResourceMemory: resource.MustParse("32Mi"),
+	},
+}
+
+// ConfigReloaderContainer builds the container sending SIGHUP to the collector when its configuration file changes,
+// which makes the collector reload it. It relies on the process namespace shared by the containers of the pod, and
+// on the kubelet updating the files of the ConfigMap volume. It runs with the security context of the collector
+// container, whose user may signal the collector process.
+func ConfigReloaderContainer(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) corev1.Container {
+	file := path.Join("/conf", cfg.CollectorConfigMapEntry)
+	script := fmt.Sprintf(`last=$(md5sum %[1]s)
+while sleep %[3]d; do
+  current=$(md5sum %[1]s)
+  if [ "$current" != "$last" ] && pkill -HUP -f '%[2]s'; then
+    last=$current
+  fi
+done`, file, collectorProcessPattern(cfg), configReloadPeriodSeconds)
+
+	return corev1.Container{
+		Name:    naming.ConfigReloaderContainer(),
+		Image:   cfg.ConfigReloaderImage,
+		Command: []string{"sh", "-c", script},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      naming.ConfigMapVolume(),
+			MountPath: "/conf",
+			ReadOnly:  true,
+		}},
+		Resources:       *configReloaderResources.DeepCopy(),
+		SecurityContext: collectorSecurityContext(cfg, otelcol),
+	}
+}
+
+// JobStopperContainer builds the container sending SIGTERM to the collector once it ran for the given duration, which
+// makes the collector shut down gracefully and the job pod complete. The container exits as soon as the collector,
+// once started, stops on its own. It relies on the process namespace shared by the containers of the pod.
+func JobStopperContainer(cfg config.Config, duration time.Duration) corev1.Container {
+	script := fmt.Sprintf(`elapsed=0
+while [ "$elapsed" -lt %[2]d ]; do
+  sleep 1
+  elapsed=$((elapsed + 1))
+  if pgrep -f '%[1]s' > /dev/null; then
+    started=1
+  elif [ -n "$started" ]; then
+    exit 0
+  fi
+done
+pkill -TERM -f '%[1]s' || true`, collectorProcessPattern(cfg), int64(duration.Seconds()))
+
+	return corev1.Container{
+		Name:    naming.JobStopperContainer(),
+		Image:   cfg.ConfigReloaderImage,
+		Command: []string{"sh", "-c", script},
+	}
+}
+
 // Container builds a container for the given collector.
 func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCollector, addConfig bool) corev1.Container {
 	image := otelcol.Spec.Image
@@ -53,6 +162,13 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 			logger.Info("the 'config' flag isn't allowed and is being ignored")
 			delete(argsMap, "config")
 		}
+		// the collector merges the Secret config sources first, the configuration rendered by the operator overrides
+		// them
+		for i, source := range otelcol.Spec.ConfigSources {
+			if source.SecretKeyRef != nil {
+				args = append(args, fmt.Sprintf("--config=env:%s", v1beta1.ConfigSourceEnvVar(i)))
+			}
+		}
 		args = append(args, fmt.Sprintf("--config=/conf/%s", cfg.CollectorConfigMapEntry))
 		volumeMounts = append(volumeMounts,
 			corev1.VolumeMount{
@@ -61,7 +177,7 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 			})
 	}
 
-	if otelcol.Spec.TargetAllocator.Enabled && cfg.CertManagerAvailability == certmanager.Available && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
+	if otelcol.Spec.TargetAllocator.Enabled && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
 		volumeMounts = append(volumeMounts,
 			corev1.VolumeMount{
 				Name:      naming.TAClientCertificate(otelcol.Name),
@@ -83,6 +199,17 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		volumeMounts = append(volumeMounts, otelcol.Spec.VolumeMounts...)
 	}
 
+	if otelcol.Spec.Mode == v1beta1.ModeDaemonSet {
+		volumeMounts = append(volumeMounts, presetVolumeMounts(otelcol.Spec.Presets)...)
+	}
+
+	if otelcol.Spec.Mode == v1beta1.ModeStatefulSet && otelcol.Spec.Persistence != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      naming.PersistenceVolume(),
+			MountPath: otelcol.Spec.Persistence.MountPath,
+		})
+	}
+
 	if len(otelcol.Spec.ConfigMaps) > 0 {
 		for keyCfgMap := range otelcol.Spec.ConfigMaps {
 			volumeMounts = append(volumeMounts, corev1.VolumeMount{
@@ -92,17 +219,13 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		}
 	}
 
-	livenessProbe, livenessProbeErr := otelcol.Spec.Config.GetLivenessProbe(logger)
+	livenessProbe, livenessProbeErr := getProbe(logger, otelcol.Spec.Config, otelcol.Spec.LivenessProbe, otelcol.Spec.Config.GetLivenessProbe)
 	if livenessProbeErr != nil {
 		logger.Error(livenessProbeErr, "cannot create liveness probe.")
-	} else {
-		defaultProbeSettings(livenessProbe, otelcol.Spec.LivenessProbe)
 	}
-	readinessProbe, readinessProbeErr := otelcol.Spec.Config.GetReadinessProbe(logger)
+	readinessProbe, readinessProbeErr := getProbe(logger, otelcol.Spec.Config, otelcol.Spec.ReadinessProbe, otelcol.Spec.Config.GetReadinessProbe)
 	if readinessProbeErr != nil {
 		logger.Error(readinessProbeErr, "cannot create readiness probe.")
-	} else {
-		defaultProbeSettings(readinessProbe, otelcol.Spec.ReadinessProbe)
 	}
 
 	return corev1.Container{
@@ -112,10 +235,10 @@ func Container(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTeleme
 		Ports:           ports,
 		VolumeMounts:    volumeMounts,
 		Args:            args,
-		Env:             getContainerEnvVars(otelcol, logger),
-		EnvFrom:         otelcol.Spec.EnvFrom,
+		Env:             otelcol.ContainerEnvVars(logger),
+		EnvFrom:         append(slices.Clone(otelcol.Spec.EnvFrom), otelcol.Spec.ConfigEnvFrom...),
 		Resources:       otelcol.Spec.Resources,
-		SecurityContext: otelcol.Spec.SecurityContext,
+		SecurityContext: collectorSecurityContext(cfg, otelcol),
 		LivenessProbe:   livenessProbe,
 		ReadinessProbe:  readinessProbe,
 		Lifecycle:       otelcol.Spec.Lifecycle,
@@ -165,6 +288,36 @@ func getConfigContainerPorts(logger logr.Logger, conf v1beta1.Config) ([]corev1.
 	return ports, nil
 }
 
+// getProbe returns the probe of the collector container, generated from the health_check extension or, with the
+// receiverPort handler, from the port of a receiver.
+func getProbe(logger logr.Logger, conf v1beta1.Config, probeConfig *v1beta1.Probe, healthCheckProbe func(logr.Logger) (*corev1.Probe, error)) (*corev1.Probe, error) {
+	var probe *corev1.Probe
+	var err error
+	if probeConfig != nil && probeConfig.Handler == v1beta1.ProbeHandlerReceiverPort {
+		probe, err = receiverPortProbe(logger, conf, probeConfig.Receiver)
+	} else {
+		probe, err = healthCheckProbe(logger)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defaultProbeSettings(probe, probeConfig)
+	return probe, nil
+}
+
+// receiverPortProbe returns a probe connecting to the port of a receiver.
+func receiverPortProbe(logger logr.Logger, conf v1beta1.Config, receiver string) (*corev1.Probe, error) {
+	port, err := conf.GetReceiverProbePort(logger, receiver)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt32(port.Port)},
+		},
+	}, nil
+}
+
 func defaultProbeSettings(probe *corev1.Probe, probeConfig *v1beta1.Probe) {
 	if probe != nil && probeConfig != nil {
 		if probeConfig.InitialDelaySeconds != nil {
@@ -288,84 +441,38 @@ func getSpecPorts(ports []v1beta1.PortsSpec, logger logr.Logger) []corev1.Contai
 	return specPorts
 }
 
-// getContainerEnvVars returns the environment variables for the collector container.
-// It combines user-defined environment variables from the OpenTelemetryCollector spec
-// with automatically inferred environment variables, giving precedence to user-defined ones.
-func getContainerEnvVars(otelcol v1beta1.OpenTelemetryCollector, logger logr.Logger) []corev1.EnvVar {
-	inferredEnvVars := getInferredContainerEnvVars(otelcol, logger)
-
-	envVars := []corev1.EnvVar{}
-	envVars = append(envVars, otelcol.Spec.Env...)
-
-	userDefinedEnvVars := make(map[string]bool, len(otelcol.Spec.Env))
-	for _, env := range otelcol.Spec.Env {
-		userDefinedEnvVars[env.Name] = true
+// collectorSecurityContext returns the security context of the collector container. The daemonset collectors reading
+// the pod logs of their node, which only root can read, run as root unless the security contexts set another user.
+func collectorSecurityContext(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) *corev1.SecurityContext {
+	securityContext := manifestutils.SecurityContext(cfg, otelcol.Spec.SecurityContext)
+	if securityContext != nil || otelcol.Spec.Mode != v1beta1.ModeDaemonSet || !otelcol.Spec.Presets.LogsCollection.Enabled {
+		return securityContext
 	}
-
-	// We only append the inferred env vars that are not defined by the user.
-	for _, env := range inferredEnvVars {
-		if _, ok := userDefinedEnvVars[env.Name]; !ok {
-			envVars = append(envVars, env)
-		}
+	if pod := otelcol.Spec.PodSecurityContext; pod != nil && (pod.RunAsUser != nil || ptr.Deref(pod.RunAsNonRoot, false)) {
+		return nil
+	}
+	return &corev1.SecurityContext{
+		RunAsUser:  ptr.To[int64](0),
+		RunAsGroup: ptr.To[int64](0),
 	}
-
-	return envVars
 }
 
-// getInferredContainerEnvVars returns environment variables that are automatically added to the collector container.
-// Those include parsing the collector config and adding the env vars derived from it.
-func getInferredContainerEnvVars(otelcol v1beta1.OpenTelemetryCollector, logger logr.Logger) []corev1.EnvVar {
-	envVars := []corev1.EnvVar{}
-
-	envVars = append(envVars, corev1.EnvVar{
-		Name: "POD_NAME",
-		ValueFrom: &corev1.EnvVarSource{
-			FieldRef: &corev1.ObjectFieldSelector{
-				FieldPath: "metadata.name",
-			},
-		},
-	})
-
-	if otelcol.Spec.TargetAllocator.Enabled {
-		// We need to add a SHARD here so the collector is able to keep targets after the hashmod operation which is
-		// added by default by the Prometheus operator's config generator.
-		// All collector instances use SHARD == 0 as they only receive targets
-		// allocated to them and should not use the Prometheus hashmod-based
-		// allocation.
-		envVars = append(envVars, corev1.EnvVar{
-			Name:  "SHARD",
-			Value: "0",
+// presetVolumeMounts mounts the host volumes of the enabled presets read-only.
+func presetVolumeMounts(presets v1beta1.Presets) []corev1.VolumeMount {
+	var volumeMounts []corev1.VolumeMount
+	if presets.HostMetrics.Enabled {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:             naming.HostFSVolume(),
+			MountPath:        v1beta1.PresetHostFSMountPath,
+			ReadOnly:         true,
+			MountPropagation: ptr.To(corev1.MountPropagationHostToContainer),
 		})
 	}
-
-	if featuregate.SetGolangFlags.IsEnabled() {
-		envVars = append(envVars,
-			corev1.EnvVar{
-				Name: "GOMEMLIMIT",
-				ValueFrom: &corev1.EnvVarSource{
-					ResourceFieldRef: &corev1.ResourceFieldSelector{
-						Resource:      "limits.memory",
-						ContainerName: naming.Container(),
-					},
-				},
-			},
-			corev1.EnvVar{
-				Name: "GOMAXPROCS",
-				ValueFrom: &corev1.EnvVarSource{
-					ResourceFieldRef: &corev1.ResourceFieldSelector{
-						Resource:      "limits.cpu",
-						ContainerName: naming.Container(),
-					},
-				},
-			},
+	if presets.LogsCollection.Enabled {
+		volumeMounts = append(volumeMounts,
+			corev1.VolumeMount{Name: naming.PodLogsVolume(), MountPath: v1beta1.PresetPodLogsPath, ReadOnly: true},
+			corev1.VolumeMount{Name: naming.ContainerLogsVolume(), MountPath: v1beta1.PresetContainerLogsPath, ReadOnly: true},
 		)
 	}
-
-	if configEnvVars, err := otelcol.Spec.Config.GetEnvironmentVariables(logger); err != nil {
-		logger.Error(err, "could not get the environment variables from the config")
-	} else {
-		envVars = append(envVars, configEnvVars...)
-	}
-
-	return append(envVars, proxy.ReadProxyVarsFromEnv()...)
+	return volumeMounts
 }
@@ -5,6 +5,7 @@ package collector
 
 import (
 	"testing"
+	"time"
 
 	go_yaml "github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,8 @@ import (
 	colfg "go.opentelemetry.io/collector/featuregate"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
@@ -410,6 +413,139 @@ func TestContainerConfigFlagIsIgnored(t *testing.T) {
 	assert.NotContains(t, c.Args, "--config=/some-custom-file.yaml")
 }
 
+func TestContainersPosition(t *testing.T) {
+	for _, tt := range []struct {
+		position v1beta1.ContainerPosition
+		expected []string
+	}{
+		{position: "", expected: []string{"log-shipper", "proxy", "otc-container"}},
+		{position: v1beta1.ContainerPositionBefore, expected: []string{"log-shipper", "proxy", "otc-container"}},
+		{position: v1beta1.ContainerPositionAfter, expected: []string{"otc-container", "log-shipper", "proxy"}},
+	} {
+		t.Run(string(tt.position), func(t *testing.T) {
+			// prepare
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						AdditionalContainers: []corev1.Container{{Name: "log-shipper"}, {Name: "proxy"}},
+					},
+					AdditionalContainersPosition: tt.position,
+				},
+			}
+
+			// test
+			containers := Containers(config.New(), testLogger, otelcol)
+
+			// verify
+			var names []string
+			for _, c := range containers {
+				names = append(names, c.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Len(t, otelcol.Spec.AdditionalContainers, 2)
+		})
+	}
+}
+
+func TestContainersReloadStrategy(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				AdditionalContainers: []corev1.Container{{Name: "log-shipper"}},
+				SecurityContext:      &corev1.SecurityContext{RunAsUser: ptr.To[int64](10001), RunAsNonRoot: ptr.To(true)},
+			},
+			Rollout: &v1beta1.Rollout{Strategy: v1beta1.RolloutStrategyReload},
+		},
+	}
+	cfg := config.New(config.WithConfigReloaderImage("busybox:latest"))
+
+	// test
+	containers := Containers(cfg, testLogger, otelcol)
+
+	// verify
+	require.Len(t, containers, 3)
+	assert.Equal(t, "otc-container", containers[1].Name)
+	reloader := containers[2]
+	assert.Equal(t, "otc-config-reloader", reloader.Name)
+	assert.Equal(t, "busybox:latest", reloader.Image)
+	assert.Equal(t, []corev1.VolumeMount{{Name: "otc-internal", MountPath: "/conf", ReadOnly: true}}, reloader.VolumeMounts)
+	require.Len(t, reloader.Command, 3)
+	assert.Contains(t, reloader.Command[2], "md5sum /conf/collector.yaml")
+	assert.Contains(t, reloader.Command[2], `pkill -HUP -f '[-]-config=/conf/collector\.yaml'`)
+	assert.Equal(t, containers[1].SecurityContext, reloader.SecurityContext)
+	assert.Equal(t, resource.MustParse("32Mi"), reloader.Resources.Limits[corev1.ResourceMemory])
+	assert.Equal(t, resource.MustParse("5m"), reloader.Resources.Requests[corev1.ResourceCPU])
+	assert.True(t, *shareProcessNamespace(otelcol))
+
+	otelcol.Spec.Rollout.Strategy = v1beta1.RolloutStrategyRestart
+	assert.Len(t, Containers(cfg, testLogger, otelcol), 2)
+	assert.False(t, *shareProcessNamespace(otelcol))
+}
+
+func TestContainersJobStopper(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeJob,
+			Job:  &v1beta1.JobSpec{Duration: &metav1.Duration{Duration: 90 * time.Second}},
+		},
+	}
+	cfg := config.New(config.WithConfigReloaderImage("busybox:latest"))
+
+	// test
+	containers := Containers(cfg, testLogger, otelcol)
+
+	// verify
+	require.Len(t, containers, 2)
+	stopper := containers[1]
+	assert.Equal(t, "otc-job-stopper", stopper.Name)
+	assert.Equal(t, "busybox:latest", stopper.Image)
+	assert.Empty(t, stopper.VolumeMounts)
+	require.Len(t, stopper.Command, 3)
+	assert.Contains(t, stopper.Command[2], `while [ "$elapsed" -lt 90 ]; do`)
+	assert.Contains(t, stopper.Command[2], `pkill -TERM -f '[-]-config=/conf/collector\.yaml'`)
+	assert.True(t, *shareProcessNamespace(otelcol))
+
+	otelcol.Spec.Job.Duration = nil
+	assert.Len(t, Containers(cfg, testLogger, otelcol), 1)
+	assert.False(t, *shareProcessNamespace(otelcol))
+}
+
+func TestContainerLogsCollectionSecurityContext(t *testing.T) {
+	root := &corev1.SecurityContext{RunAsUser: ptr.To[int64](0), RunAsGroup: ptr.To[int64](0)}
+	custom := &corev1.SecurityContext{RunAsUser: ptr.To[int64](1000)}
+	for _, tt := range []struct {
+		name               string
+		mode               v1beta1.Mode
+		securityContext    *corev1.SecurityContext
+		podSecurityContext *corev1.PodSecurityContext
+		expected           *corev1.SecurityContext
+	}{
+		{name: "daemonset", mode: v1beta1.ModeDaemonSet, expected: root},
+		{name: "security context set", mode: v1beta1.ModeDaemonSet, securityContext: custom, expected: custom},
+		{name: "non-root pods", mode: v1beta1.ModeDaemonSet, podSecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(true)}},
+		{name: "sidecar", mode: v1beta1.ModeSidecar},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:    tt.mode,
+					Presets: v1beta1.Presets{LogsCollection: v1beta1.LogsCollectionPreset{Preset: v1beta1.Preset{Enabled: true}}},
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						SecurityContext:    tt.securityContext,
+						PodSecurityContext: tt.podSecurityContext,
+					},
+				},
+			}
+
+			c := Container(config.New(), testLogger, otelcol, true)
+			assert.Equal(t, tt.expected, c.SecurityContext)
+		})
+	}
+}
+
 func TestContainerCustomVolumes(t *testing.T) {
 	// prepare
 	otelcol := v1beta1.OpenTelemetryCollector{
@@ -431,6 +567,26 @@ func TestContainerCustomVolumes(t *testing.T) {
 	assert.Equal(t, "custom-volume-mount", c.VolumeMounts[1].Name)
 }
 
+func TestContainerPersistenceVolume(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeStatefulSet,
+			Persistence: &v1beta1.Persistence{
+				MountPath: "/var/lib/otelcol",
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, testLogger, otelcol, true)
+
+	// verify
+	assert.Len(t, c.VolumeMounts, 2)
+	assert.Equal(t, corev1.VolumeMount{Name: "otc-persistence", MountPath: "/var/lib/otelcol"}, c.VolumeMounts[1])
+}
+
 func TestContainerCustomConfigMapsVolumes(t *testing.T) {
 	// prepare
 	otelcol := v1beta1.OpenTelemetryCollector{
@@ -527,6 +683,49 @@ func TestContainerDefaultEnvVars(t *testing.T) {
 	assert.Equal(t, c.Env[0].Name, "POD_NAME")
 }
 
+func TestContainerNodeNameEnvVar(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		mode       v1beta1.Mode
+		enrichment bool
+		config     string
+		expected   []string
+	}{
+		{name: "daemonset", mode: v1beta1.ModeDaemonSet, expected: []string{"POD_NAME", "K8S_NODE_NAME"}},
+		{name: "daemonset with enrichment", mode: v1beta1.ModeDaemonSet, enrichment: true, expected: []string{"POD_NAME", "K8S_NODE_NAME"}},
+		{name: "deployment with enrichment", mode: v1beta1.ModeDeployment, enrichment: true, expected: []string{"POD_NAME"}},
+		{
+			name:     "daemonset with kubeletstats",
+			mode:     v1beta1.ModeDaemonSet,
+			config:   "receivers:\n  kubeletstats: {}\nservice:\n  pipelines:\n    metrics:\n      receivers: [kubeletstats]\n",
+			expected: []string{"POD_NAME", "K8S_NODE_NAME"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:       tt.mode,
+					Enrichment: v1beta1.Enrichment{Enabled: tt.enrichment},
+				},
+			}
+			if tt.config != "" {
+				otelcol.Spec.Config = mustUnmarshalToConfig(t, tt.config)
+			}
+
+			c := Container(config.New(), testLogger, otelcol, true)
+
+			var names []string
+			for _, env := range c.Env {
+				names = append(names, env.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+			if len(c.Env) > 1 {
+				assert.Equal(t, "spec.nodeName", c.Env[1].ValueFrom.FieldRef.FieldPath)
+			}
+		})
+	}
+}
+
 func TestContainerProxyEnvVars(t *testing.T) {
 	t.Setenv("NO_PROXY", "localhost")
 	otelcol := v1beta1.OpenTelemetryCollector{
@@ -689,6 +888,53 @@ func TestContainerEnvFrom(t *testing.T) {
 	assert.Contains(t, c.EnvFrom, envFrom2)
 }
 
+func TestContainerConfigEnvFrom(t *testing.T) {
+	envFrom := corev1.EnvFromSource{
+		ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "env-as-configmap"}},
+	}
+	configEnvFrom := corev1.EnvFromSource{
+		Prefix:    "BACKEND_",
+		SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "backend"}},
+	}
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				EnvFrom: []corev1.EnvFromSource{envFrom},
+			},
+			ConfigEnvFrom: []corev1.EnvFromSource{configEnvFrom},
+		},
+	}
+
+	c := Container(config.New(), testLogger, otelcol, true)
+
+	assert.Equal(t, []corev1.EnvFromSource{envFrom, configEnvFrom}, c.EnvFrom)
+	assert.Len(t, otelcol.Spec.EnvFrom, 1)
+}
+
+func TestContainerSecretConfigSources(t *testing.T) {
+	secretRef := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "overrides"},
+		Key:                  "collector.yaml",
+		Optional:             ptr.To(true),
+	}
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			ConfigSources: []v1beta1.ConfigSource{
+				{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "base"}, Key: "collector.yaml"}},
+				{SecretKeyRef: secretRef},
+			},
+		},
+	}
+	cfg := config.New()
+
+	c := Container(cfg, testLogger, otelcol, true)
+
+	// the configuration rendered by the operator comes last and overrides the Secret
+	assert.Equal(t, []string{"--config=env:OTEL_CONFIG_SOURCE_1", "--config=/conf/" + cfg.CollectorConfigMapEntry}, c.Args)
+	assert.Contains(t, c.Env, corev1.EnvVar{Name: "OTEL_CONFIG_SOURCE_1", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: secretRef}})
+	assert.NotContains(t, c.Env, corev1.EnvVar{Name: "OTEL_CONFIG_SOURCE_0"})
+}
+
 func TestContainerProbe(t *testing.T) {
 	// prepare
 	initialDelaySeconds := int32(10)
@@ -752,6 +998,63 @@ service:
 	assert.Equal(t, terminationGracePeriodSeconds, *c.ReadinessProbe.TerminationGracePeriodSeconds)
 }
 
+func TestContainerReceiverPortProbe(t *testing.T) {
+	// prepare
+	periodSeconds := int32(5)
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: mustUnmarshalToConfig(t, `receivers:
+  otlp:
+    protocols:
+      grpc:
+      http:
+  zipkin:
+    endpoint: 0.0.0.0:9412
+exporters:
+  debug:
+extensions:
+  health_check:
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [zipkin, otlp]
+      exporters: [debug]`),
+			LivenessProbe: &v1beta1.Probe{
+				PeriodSeconds: &periodSeconds,
+			},
+			ReadinessProbe: &v1beta1.Probe{
+				Handler:       v1beta1.ProbeHandlerReceiverPort,
+				PeriodSeconds: &periodSeconds,
+			},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	c := Container(cfg, testLogger, otelcol, true)
+
+	// verify
+	// the liveness probe keeps checking the health_check extension
+	assert.Equal(t, int32(13133), c.LivenessProbe.HTTPGet.Port.IntVal)
+	// the readiness probe checks the first receiver, by name
+	require.NotNil(t, c.ReadinessProbe.TCPSocket)
+	assert.Nil(t, c.ReadinessProbe.HTTPGet)
+	assert.Equal(t, int32(4317), c.ReadinessProbe.TCPSocket.Port.IntVal)
+	assert.Equal(t, periodSeconds, c.ReadinessProbe.PeriodSeconds)
+
+	// the given receiver
+	otelcol.Spec.ReadinessProbe.Receiver = "zipkin"
+	c = Container(cfg, testLogger, otelcol, true)
+	require.NotNil(t, c.ReadinessProbe.TCPSocket)
+	assert.Equal(t, int32(9412), c.ReadinessProbe.TCPSocket.Port.IntVal)
+
+	// a receiver outside of the pipelines
+	otelcol.Spec.ReadinessProbe.Receiver = "jaeger"
+	c = Container(cfg, testLogger, otelcol, true)
+	assert.Nil(t, c.ReadinessProbe)
+}
+
 func TestContainerProbeEmptyConfig(t *testing.T) {
 	// prepare
 
@@ -851,6 +1154,9 @@ func TestContainerWithCertManagerAvailable(t *testing.T) {
 
 	flgs := featuregate.Flags(colfg.GlobalRegistry())
 	err := flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+	t.Cleanup(func() {
+		_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+	})
 	otelcol.Spec.TargetAllocator.Enabled = true
 
 	require.NoError(t, err)
@@ -872,6 +1178,9 @@ func TestContainerWithFeaturegateEnabledButTADisabled(t *testing.T) {
 
 	flgs := featuregate.Flags(colfg.GlobalRegistry())
 	err := flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+	t.Cleanup(func() {
+		_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+	})
 
 	require.NoError(t, err)
 
@@ -1132,7 +1441,7 @@ service:
 				test.before(t)
 			}
 
-			envVars := getContainerEnvVars(test.otelcol, testLogger)
+			envVars := test.otelcol.ContainerEnvVars(testLogger)
 			assert.ElementsMatch(t, test.expectedEnvVars, envVars)
 
 		})
@@ -4,10 +4,14 @@
 package collector
 
 import (
+	"fmt"
+	"slices"
+
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
@@ -15,7 +19,36 @@ import (
 
 // DaemonSet builds the deployment for the given instance.
 func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
-	name := naming.Collector(params.OtelCol.Name)
+	if !params.OtelCol.Spec.RunsOn(v1beta1.NodeOSLinux) {
+		return nil, nil
+	}
+	ds, err := daemonSet(params, naming.Collector(params.OtelCol.Name))
+	if err != nil {
+		return nil, err
+	}
+	// the pods are only restricted to the Linux nodes along with other operating systems
+	if len(params.OtelCol.Spec.NodeOS) > 0 {
+		ds.Spec.Template.Spec.NodeSelector = nodeOSSelector(ds.Spec.Template.Spec.NodeSelector, corev1.Linux)
+		selectNodeOS(ds, corev1.Linux)
+	}
+	return ds, nil
+}
+
+// WindowsDaemonSet builds the daemonset running the collector on the Windows nodes, if any.
+func WindowsDaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
+	if params.OtelCol.Spec.Mode != v1beta1.ModeDaemonSet || !params.OtelCol.Spec.RunsOn(v1beta1.NodeOSWindows) {
+		return nil, nil
+	}
+	ds, err := daemonSet(params, naming.WindowsCollector(params.OtelCol.Name))
+	if err != nil {
+		return nil, err
+	}
+	windowsPodSpec(params.OtelCol, &ds.Spec.Template.Spec)
+	selectNodeOS(ds, corev1.Windows)
+	return ds, nil
+}
+
+func daemonSet(params manifests.Params, name string) (*appsv1.DaemonSet, error) {
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
 
 	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
@@ -30,7 +63,7 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 
 	return &appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        naming.Collector(params.OtelCol.Name),
+			Name:        name,
 			Namespace:   params.OtelCol.Namespace,
 			Labels:      labels,
 			Annotations: annotations,
@@ -47,15 +80,15 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 				Spec: corev1.PodSpec{
 					ServiceAccountName:            ServiceAccountName(params.OtelCol),
 					InitContainers:                params.OtelCol.Spec.InitContainers,
-					Containers:                    append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
+					Containers:                    Containers(params.Config, params.Log, params.OtelCol),
 					Volumes:                       Volumes(params.Config, params.OtelCol),
-					Tolerations:                   params.OtelCol.Spec.Tolerations,
+					Tolerations:                   tolerations(params.OtelCol),
 					NodeSelector:                  params.OtelCol.Spec.NodeSelector,
 					HostNetwork:                   params.OtelCol.Spec.HostNetwork,
-					ShareProcessNamespace:         &params.OtelCol.Spec.ShareProcessNamespace,
+					ShareProcessNamespace:         shareProcessNamespace(params.OtelCol),
 					DNSPolicy:                     manifestutils.GetDNSPolicy(params.OtelCol.Spec.HostNetwork, params.OtelCol.Spec.PodDNSConfig),
 					DNSConfig:                     &params.OtelCol.Spec.PodDNSConfig,
-					SecurityContext:               params.OtelCol.Spec.PodSecurityContext,
+					SecurityContext:               manifestutils.PodSecurityContext(params.Config, params.OtelCol.Spec.PodSecurityContext),
 					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
 					Affinity:                      params.OtelCol.Spec.Affinity,
 					TerminationGracePeriodSeconds: params.OtelCol.Spec.TerminationGracePeriodSeconds,
@@ -65,3 +98,29 @@ func DaemonSet(params manifests.Params) (*appsv1.DaemonSet, error) {
 		},
 	}, nil
 }
+
+// ContainerLogsPattern returns the path pattern of the logs of the collector containers on the nodes, which the
+// logsCollection preset leaves out.
+func ContainerLogsPattern(otelcol v1beta1.OpenTelemetryCollector) string {
+	return fmt.Sprintf("%s/%s_%s-*_*/%s/*.log", v1beta1.PresetPodLogsPath, otelcol.Namespace, naming.Collector(otelcol.Name), naming.Container())
+}
+
+// tolerations adds the toleration of the control plane nodes to the tolerations of the collector when a preset
+// collects the telemetry of every node, unless the collector already tolerates it.
+func tolerations(otelcol v1beta1.OpenTelemetryCollector) []corev1.Toleration {
+	if !otelcol.Spec.Presets.Enabled() {
+		return otelcol.Spec.Tolerations
+	}
+	controlPlane := corev1.Toleration{
+		Key:      "node-role.kubernetes.io/control-plane",
+		Operator: corev1.TolerationOpExists,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+	taint := &corev1.Taint{Key: controlPlane.Key, Effect: controlPlane.Effect}
+	for _, toleration := range otelcol.Spec.Tolerations {
+		if toleration.ToleratesTaint(taint) {
+			return otelcol.Spec.Tolerations
+		}
+	}
+	return append(slices.Clone(otelcol.Spec.Tolerations), controlPlane)
+}
@@ -656,3 +656,79 @@ func TestDaemonSetTerminationGracePeriodSeconds(t *testing.T) {
 	assert.NotNil(t, d2.Spec.Template.Spec.TerminationGracePeriodSeconds)
 	assert.Equal(t, gracePeriodSec, *d2.Spec.Template.Spec.TerminationGracePeriodSeconds)
 }
+
+func TestDaemonSetPresets(t *testing.T) {
+	controlPlane := v1.Toleration{Key: "node-role.kubernetes.io/control-plane", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule}
+	tolerateAll := v1.Toleration{Operator: v1.TolerationOpExists}
+
+	for _, tt := range []struct {
+		name                string
+		presets             v1beta1.Presets
+		tolerations         []v1.Toleration
+		expectedVolumes     []string
+		expectedTolerations []v1.Toleration
+	}{
+		{
+			name:                "no preset",
+			tolerations:         testTolerationValues,
+			expectedTolerations: testTolerationValues,
+		},
+		{
+			name: "all presets",
+			presets: v1beta1.Presets{
+				HostMetrics:    v1beta1.Preset{Enabled: true},
+				KubeletMetrics: v1beta1.Preset{Enabled: true},
+				LogsCollection: v1beta1.LogsCollectionPreset{Preset: v1beta1.Preset{Enabled: true}},
+			},
+			tolerations:         testTolerationValues,
+			expectedVolumes:     []string{"otc-hostfs", "otc-pod-logs", "otc-container-logs"},
+			expectedTolerations: append(testTolerationValues, controlPlane),
+		},
+		{
+			name:                "control plane already tolerated",
+			presets:             v1beta1.Presets{KubeletMetrics: v1beta1.Preset{Enabled: true}},
+			tolerations:         []v1.Toleration{tolerateAll},
+			expectedTolerations: []v1.Toleration{tolerateAll},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			params := manifests.Params{
+				Config: config.New(),
+				OtelCol: v1beta1.OpenTelemetryCollector{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-instance",
+						Namespace: "my-namespace",
+					},
+					Spec: v1beta1.OpenTelemetryCollectorSpec{
+						Mode:    v1beta1.ModeDaemonSet,
+						Presets: tt.presets,
+						OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+							Tolerations: tt.tolerations,
+						},
+					},
+				},
+				Log: testLogger,
+			}
+
+			d, err := DaemonSet(params)
+			require.NoError(t, err)
+
+			var volumes, mounts []string
+			for _, volume := range d.Spec.Template.Spec.Volumes[1:] {
+				volumes = append(volumes, volume.Name)
+			}
+			for _, mount := range d.Spec.Template.Spec.Containers[0].VolumeMounts[1:] {
+				assert.True(t, mount.ReadOnly)
+				mounts = append(mounts, mount.Name)
+			}
+			assert.Equal(t, tt.expectedVolumes, volumes)
+			assert.Equal(t, tt.expectedVolumes, mounts)
+			assert.Equal(t, tt.expectedTolerations, d.Spec.Template.Spec.Tolerations)
+		})
+	}
+}
+
+func TestContainerLogsPattern(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "observability"}}
+	assert.Equal(t, "/var/log/pods/observability_agent-collector-*_*/otc-container/*.log", ContainerLogsPattern(otelcol))
+}
@@ -48,15 +48,15 @@ func Deployment(params manifests.Params) (*appsv1.Deployment, error) {
 				Spec: corev1.PodSpec{
 					ServiceAccountName:            ServiceAccountName(params.OtelCol),
 					InitContainers:                params.OtelCol.Spec.InitContainers,
-					Containers:                    append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
+					Containers:                    Containers(params.Config, params.Log, params.OtelCol),
 					Volumes:                       Volumes(params.Config, params.OtelCol),
 					DNSPolicy:                     manifestutils.GetDNSPolicy(params.OtelCol.Spec.HostNetwork, params.OtelCol.Spec.PodDNSConfig),
 					DNSConfig:                     &params.OtelCol.Spec.PodDNSConfig,
 					HostNetwork:                   params.OtelCol.Spec.HostNetwork,
-					ShareProcessNamespace:         &params.OtelCol.Spec.ShareProcessNamespace,
+					ShareProcessNamespace:         shareProcessNamespace(params.OtelCol),
 					Tolerations:                   params.OtelCol.Spec.Tolerations,
 					NodeSelector:                  params.OtelCol.Spec.NodeSelector,
-					SecurityContext:               params.OtelCol.Spec.PodSecurityContext,
+					SecurityContext:               manifestutils.PodSecurityContext(params.Config, params.OtelCol.Spec.PodSecurityContext),
 					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
 					Affinity:                      params.OtelCol.Spec.Affinity,
 					TerminationGracePeriodSeconds: params.OtelCol.Spec.TerminationGracePeriodSeconds,
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// HTTPRoutes builds a Gateway API HTTPRoute for every receiver port not served over gRPC.
+func HTTPRoutes(params manifests.Params) ([]*gatewayv1.HTTPRoute, error) {
+	ports, err := gatewayRoutePorts(params, false)
+	if len(ports) == 0 || err != nil {
+		return nil, err
+	}
+
+	routes := make([]*gatewayv1.HTTPRoute, len(ports))
+	for i, p := range ports {
+		routes[i] = &gatewayv1.HTTPRoute{
+			ObjectMeta: gatewayRouteObjectMeta(params, p),
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayRouteCommonSpec(params),
+				Hostnames:       gatewayRouteHostnames(params, p),
+				Rules: []gatewayv1.HTTPRouteRule{
+					{
+						BackendRefs: []gatewayv1.HTTPBackendRef{
+							{BackendRef: gatewayRouteBackendRef(params, p)},
+						},
+					},
+				},
+			},
+		}
+	}
+	return routes, nil
+}
+
+// GRPCRoutes builds a Gateway API GRPCRoute for every receiver port served over gRPC.
+func GRPCRoutes(params manifests.Params) ([]*gatewayv1.GRPCRoute, error) {
+	ports, err := gatewayRoutePorts(params, true)
+	if len(ports) == 0 || err != nil {
+		return nil, err
+	}
+
+	routes := make([]*gatewayv1.GRPCRoute, len(ports))
+	for i, p := range ports {
+		routes[i] = &gatewayv1.GRPCRoute{
+			ObjectMeta: gatewayRouteObjectMeta(params, p),
+			Spec: gatewayv1.GRPCRouteSpec{
+				CommonRouteSpec: gatewayRouteCommonSpec(params),
+				Hostnames:       gatewayRouteHostnames(params, p),
+				Rules: []gatewayv1.GRPCRouteRule{
+					{
+						BackendRefs: []gatewayv1.GRPCBackendRef{
+							{BackendRef: gatewayRouteBackendRef(params, p)},
+						},
+					},
+				},
+			},
+		}
+	}
+	return routes, nil
+}
+
+// gatewayRoutePorts returns the TCP ports of the collector service that should be exposed via the
+// Gateway API, filtered on whether they are served over gRPC or not.
+func gatewayRoutePorts(params manifests.Params, grpc bool) ([]corev1.ServicePort, error) {
+	if params.OtelCol.Spec.Ingress.Type != v1beta1.IngressTypeGateway || params.Config.GatewayAPIAvailability != gatewayapi.Available {
+		return nil, nil
+	}
+
+	if params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
+		params.Log.V(3).Info("ingress settings are not supported in sidecar mode")
+		return nil, nil
+	}
+
+	ports, err := servicePortsFromCfg(params.Log, params.OtelCol)
+
+	// if we have no ports, we don't need a route
+	if len(ports) == 0 || err != nil {
+		params.Log.V(1).Info(
+			"the instance's configuration didn't yield any ports to open, skipping gateway routes",
+			"instance.name", params.OtelCol.Name,
+			"instance.namespace", params.OtelCol.Namespace,
+		)
+		return nil, err
+	}
+
+	var filtered []corev1.ServicePort
+	for _, p := range ports {
+		// HTTPRoute and GRPCRoute only carry TCP traffic
+		if p.Protocol != "" && p.Protocol != corev1.ProtocolTCP {
+			continue
+		}
+		isGRPC := p.AppProtocol != nil && *p.AppProtocol == components.GrpcProtocol
+		if isGRPC == grpc {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, nil
+}
+
+func gatewayRouteObjectMeta(params manifests.Params, p corev1.ServicePort) metav1.ObjectMeta {
+	name := naming.GatewayRoute(params.OtelCol.Name, p.Name)
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   params.OtelCol.Namespace,
+		Annotations: params.OtelCol.Spec.Ingress.Annotations,
+		Labels: map[string]string{
+			"app.kubernetes.io/name":       name,
+			"app.kubernetes.io/instance":   fmt.Sprintf("%s.%s", params.OtelCol.Namespace, params.OtelCol.Name),
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			"app.kubernetes.io/component":  "opentelemetry-collector",
+		},
+	}
+}
+
+func gatewayRouteCommonSpec(params manifests.Params) gatewayv1.CommonRouteSpec {
+	refs := params.OtelCol.Spec.Ingress.Gateway.ParentRefs
+	parentRefs := make([]gatewayv1.ParentReference, len(refs))
+	for i, ref := range refs {
+		parentRefs[i] = gatewayv1.ParentReference{
+			Name: gatewayv1.ObjectName(ref.Name),
+		}
+		if ref.Namespace != "" {
+			namespace := gatewayv1.Namespace(ref.Namespace)
+			parentRefs[i].Namespace = &namespace
+		}
+		if ref.SectionName != "" {
+			sectionName := gatewayv1.SectionName(ref.SectionName)
+			parentRefs[i].SectionName = &sectionName
+		}
+	}
+	return gatewayv1.CommonRouteSpec{ParentRefs: parentRefs}
+}
+
+// gatewayRouteHostnames exposes each receiver port on a unique subdomain of the configured hostname.
+func gatewayRouteHostnames(params manifests.Params, p corev1.ServicePort) []gatewayv1.Hostname {
+	if params.OtelCol.Spec.Ingress.Hostname == "" {
+		return nil
+	}
+	portName := naming.PortName(p.Name, p.Port)
+	return []gatewayv1.Hostname{
+		gatewayv1.Hostname(fmt.Sprintf("%s.%s", portName, params.OtelCol.Spec.Ingress.Hostname)),
+	}
+}
+
+func gatewayRouteBackendRef(params manifests.Params, p corev1.ServicePort) gatewayv1.BackendRef {
+	port := gatewayv1.PortNumber(p.Port)
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(naming.Service(params.OtelCol.Name)),
+			Port: &port,
+		},
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+func TestGatewayRoutes(t *testing.T) {
+	gatewayIngress := v1beta1.Ingress{
+		Type:     v1beta1.IngressTypeGateway,
+		Hostname: "example.com",
+		Gateway: v1beta1.GatewayRoute{
+			ParentRefs: []v1beta1.GatewayParentReference{
+				{Name: "gateway", Namespace: "infra", SectionName: "https"},
+			},
+		},
+	}
+
+	t.Run("should return nil for other ingress types", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		require.NoError(t, err)
+		params.Config.GatewayAPIAvailability = gatewayapi.Available
+		params.OtelCol.Spec.Ingress = v1beta1.Ingress{Type: v1beta1.IngressTypeRoute}
+
+		httpRoutes, err := HTTPRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, httpRoutes)
+
+		grpcRoutes, err := GRPCRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, grpcRoutes)
+	})
+
+	t.Run("should return nil when the gateway api is not available", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		require.NoError(t, err)
+		params.OtelCol.Spec.Ingress = gatewayIngress
+
+		httpRoutes, err := HTTPRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, httpRoutes)
+
+		grpcRoutes, err := GRPCRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, grpcRoutes)
+	})
+
+	t.Run("should return nil in sidecar mode", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		require.NoError(t, err)
+		params.Config.GatewayAPIAvailability = gatewayapi.Available
+		params.OtelCol.Spec.Mode = v1beta1.ModeSidecar
+		params.OtelCol.Spec.Ingress = gatewayIngress
+
+		grpcRoutes, err := GRPCRoutes(params)
+		assert.NoError(t, err)
+		assert.Nil(t, grpcRoutes)
+	})
+
+	t.Run("should split the ports by protocol", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		require.NoError(t, err)
+		params.Config.GatewayAPIAvailability = gatewayapi.Available
+		params.OtelCol.Namespace = "test"
+		params.OtelCol.Spec.Ingress = gatewayIngress
+
+		httpRoutes, err := HTTPRoutes(params)
+		require.NoError(t, err)
+		require.Len(t, httpRoutes, 1)
+		assert.Equal(t, naming.GatewayRoute(params.OtelCol.Name, "web"), httpRoutes[0].Name)
+		assert.Equal(t, []gatewayv1.Hostname{"web.example.com"}, httpRoutes[0].Spec.Hostnames)
+
+		grpcRoutes, err := GRPCRoutes(params)
+		require.NoError(t, err)
+		require.Len(t, grpcRoutes, 2)
+		assert.Equal(t, naming.GatewayRoute(params.OtelCol.Name, "otlp-grpc"), grpcRoutes[0].Name)
+		assert.Equal(t, "test", grpcRoutes[0].Namespace)
+		assert.Equal(t, []gatewayv1.Hostname{"otlp-grpc.example.com"}, grpcRoutes[0].Spec.Hostnames)
+		assert.Equal(t, []gatewayv1.Hostname{"otlp-test-grpc.example.com"}, grpcRoutes[1].Spec.Hostnames)
+
+		namespace := gatewayv1.Namespace("infra")
+		sectionName := gatewayv1.SectionName("https")
+		assert.Equal(t, []gatewayv1.ParentReference{
+			{Name: "gateway", Namespace: &namespace, SectionName: &sectionName},
+		}, grpcRoutes[0].Spec.ParentRefs)
+
+		port := gatewayv1.PortNumber(12345)
+		require.Len(t, grpcRoutes[0].Spec.Rules, 1)
+		assert.Equal(t, []gatewayv1.GRPCBackendRef{
+			{
+				BackendRef: gatewayv1.BackendRef{
+					BackendObjectReference: gatewayv1.BackendObjectReference{
+						Name: gatewayv1.ObjectName(naming.Service(params.OtelCol.Name)),
+						Port: &port,
+					},
+				},
+			},
+		}, grpcRoutes[0].Spec.Rules[0].BackendRefs)
+	})
+
+	t.Run("hostname is not set", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		require.NoError(t, err)
+		params.Config.GatewayAPIAvailability = gatewayapi.Available
+		params.OtelCol.Spec.Ingress = v1beta1.Ingress{
+			Type:    v1beta1.IngressTypeGateway,
+			Gateway: gatewayIngress.Gateway,
+		}
+
+		grpcRoutes, err := GRPCRoutes(params)
+		require.NoError(t, err)
+		require.Len(t, grpcRoutes, 2)
+		assert.Nil(t, grpcRoutes[0].Spec.Hostnames)
+	})
+}
@@ -37,6 +37,16 @@ func HorizontalPodAutoscaler(params manifests.Params) (*autoscalingv2.Horizontal
 		return nil, nil
 	}
 
+	if params.OtelCol.Spec.Autoscaler.MaxReplicas == nil {
+		params.Log.V(4).Info("maxReplicas is unset in the autoscaler, skipping horizontal autoscaler creation")
+		return nil, nil
+	}
+
+	if usesKeda(params) {
+		params.Log.V(4).Info("the collector is scaled by KEDA, skipping autoscaler creation")
+		return nil, nil
+	}
+
 	metrics := []autoscalingv2.MetricSpec{}
 
 	if params.OtelCol.Spec.Autoscaler.TargetMemoryUtilization != nil {
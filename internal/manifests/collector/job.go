@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// Job builds the collector Job of the job mode.
+func Job(params manifests.Params) (*batchv1.Job, error) {
+	name := naming.Collector(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := jobSpec(params, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: spec,
+	}, nil
+}
+
+// CronJob builds the collector CronJob of the cronjob mode.
+func CronJob(params manifests.Params) (*batchv1.CronJob, error) {
+	schedule := params.OtelCol.Spec.CronJob
+	if schedule == nil {
+		return nil, fmt.Errorf("the cronjob mode requires the cronJob attribute")
+	}
+
+	name := naming.Collector(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := jobSpec(params, labels)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   schedule.Schedule,
+			TimeZone:                   schedule.TimeZone,
+			ConcurrencyPolicy:          schedule.ConcurrencyPolicy,
+			Suspend:                    schedule.Suspend,
+			StartingDeadlineSeconds:    schedule.StartingDeadlineSeconds,
+			SuccessfulJobsHistoryLimit: schedule.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     schedule.FailedJobsHistoryLimit,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: spec,
+			},
+		},
+	}, nil
+}
+
+// jobSpec builds the spec of the collector Jobs, running spec.replicas pods which aren't restarted, with the hash of
+// the spec in the JobSpecHashAnnotation of the pod template.
+func jobSpec(params manifests.Params, labels map[string]string) (batchv1.JobSpec, error) {
+	podAnnotations, err := manifestutils.PodAnnotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return batchv1.JobSpec{}, err
+	}
+
+	spec := batchv1.JobSpec{
+		Parallelism: params.OtelCol.Spec.Replicas,
+		Completions: params.OtelCol.Spec.Replicas,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      labels,
+				Annotations: podAnnotations,
+			},
+			Spec: corev1.PodSpec{
+				ServiceAccountName:            ServiceAccountName(params.OtelCol),
+				InitContainers:                params.OtelCol.Spec.InitContainers,
+				Containers:                    Containers(params.Config, params.Log, params.OtelCol),
+				Volumes:                       Volumes(params.Config, params.OtelCol),
+				RestartPolicy:                 corev1.RestartPolicyNever,
+				DNSPolicy:                     manifestutils.GetDNSPolicy(params.OtelCol.Spec.HostNetwork, params.OtelCol.Spec.PodDNSConfig),
+				DNSConfig:                     &params.OtelCol.Spec.PodDNSConfig,
+				HostNetwork:                   params.OtelCol.Spec.HostNetwork,
+				ShareProcessNamespace:         shareProcessNamespace(params.OtelCol),
+				Tolerations:                   params.OtelCol.Spec.Tolerations,
+				NodeSelector:                  params.OtelCol.Spec.NodeSelector,
+				SecurityContext:               manifestutils.PodSecurityContext(params.Config, params.OtelCol.Spec.PodSecurityContext),
+				PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
+				Affinity:                      params.OtelCol.Spec.Affinity,
+				TerminationGracePeriodSeconds: params.OtelCol.Spec.TerminationGracePeriodSeconds,
+				TopologySpreadConstraints:     params.OtelCol.Spec.TopologySpreadConstraints,
+			},
+		},
+	}
+	if job := params.OtelCol.Spec.Job; job != nil {
+		spec.ActiveDeadlineSeconds = job.ActiveDeadlineSeconds
+		spec.BackoffLimit = job.BackoffLimit
+		spec.TTLSecondsAfterFinished = job.TTLSecondsAfterFinished
+	}
+
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return batchv1.JobSpec{}, err
+	}
+	spec.Template.Annotations = maps.Clone(spec.Template.Annotations)
+	if spec.Template.Annotations == nil {
+		spec.Template.Annotations = map[string]string{}
+	}
+	spec.Template.Annotations[manifestutils.JobSpecHashAnnotation] = fmt.Sprintf("%x", sha256.Sum256(b))
+	return spec, nil
+}
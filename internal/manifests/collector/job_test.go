@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func jobParams(mode v1beta1.Mode) manifests.Params {
+	return manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-namespace",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode: mode,
+				OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+					Replicas: ptr.To[int32](2),
+				},
+				Job: &v1beta1.JobSpec{
+					Duration:     &metav1.Duration{Duration: 5 * time.Minute},
+					BackoffLimit: ptr.To[int32](1),
+				},
+			},
+		},
+		Log: testLogger,
+	}
+}
+
+func TestJob(t *testing.T) {
+	params := jobParams(v1beta1.ModeJob)
+
+	job, err := Job(params)
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-instance-collector", job.Name)
+	assert.Equal(t, "my-namespace", job.Namespace)
+	assert.Equal(t, "my-instance-collector", job.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, ptr.To[int32](2), job.Spec.Parallelism)
+	assert.Equal(t, ptr.To[int32](2), job.Spec.Completions)
+	assert.Equal(t, ptr.To[int32](1), job.Spec.BackoffLimit)
+	assert.Nil(t, job.Spec.Selector)
+
+	pod := job.Spec.Template.Spec
+	assert.Equal(t, corev1.RestartPolicyNever, pod.RestartPolicy)
+	assert.Equal(t, ptr.To(true), pod.ShareProcessNamespace)
+	require.Len(t, pod.Containers, 2)
+	assert.Equal(t, "otc-container", pod.Containers[0].Name)
+	assert.Equal(t, "otc-job-stopper", pod.Containers[1].Name)
+	assert.Contains(t, pod.Containers[1].Command[2], `"$elapsed" -lt 300`)
+	assert.NotEmpty(t, job.Spec.Template.Annotations[manifestutils.JobSpecHashAnnotation])
+
+	// the hash follows the spec of the job
+	params.OtelCol.Spec.Job.BackoffLimit = ptr.To[int32](2)
+	changed, err := Job(params)
+	require.NoError(t, err)
+	assert.NotEqual(t, job.Spec.Template.Annotations[manifestutils.JobSpecHashAnnotation], changed.Spec.Template.Annotations[manifestutils.JobSpecHashAnnotation])
+}
+
+func TestJobWithoutDuration(t *testing.T) {
+	params := jobParams(v1beta1.ModeJob)
+	params.OtelCol.Spec.Job = nil
+
+	job, err := Job(params)
+	require.NoError(t, err)
+
+	assert.Nil(t, job.Spec.BackoffLimit)
+	assert.Equal(t, ptr.To(false), job.Spec.Template.Spec.ShareProcessNamespace)
+	require.Len(t, job.Spec.Template.Spec.Containers, 1)
+}
+
+func TestCronJob(t *testing.T) {
+	params := jobParams(v1beta1.ModeCronJob)
+	params.OtelCol.Spec.Job.TTLSecondsAfterFinished = ptr.To[int32](3600)
+	params.OtelCol.Spec.CronJob = &v1beta1.CronJobSpec{
+		Schedule:          "0 * * * *",
+		TimeZone:          ptr.To("Etc/UTC"),
+		ConcurrencyPolicy: batchv1.ForbidConcurrent,
+	}
+
+	cronJob, err := CronJob(params)
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-instance-collector", cronJob.Name)
+	assert.Equal(t, "0 * * * *", cronJob.Spec.Schedule)
+	assert.Equal(t, ptr.To("Etc/UTC"), cronJob.Spec.TimeZone)
+	assert.Equal(t, batchv1.ForbidConcurrent, cronJob.Spec.ConcurrencyPolicy)
+	assert.Equal(t, "my-instance-collector", cronJob.Spec.JobTemplate.Labels["app.kubernetes.io/name"])
+
+	job := cronJob.Spec.JobTemplate.Spec
+	assert.Equal(t, ptr.To[int32](3600), job.TTLSecondsAfterFinished)
+	assert.Equal(t, corev1.RestartPolicyNever, job.Template.Spec.RestartPolicy)
+	require.Len(t, job.Template.Spec.Containers, 2)
+	assert.Equal(t, "otc-job-stopper", job.Template.Spec.Containers[1].Name)
+}
+
+func TestCronJobWithoutSchedule(t *testing.T) {
+	_, err := CronJob(jobParams(v1beta1.ModeCronJob))
+	assert.Error(t, err)
+}
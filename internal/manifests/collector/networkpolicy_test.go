@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestNetworkPolicy(t *testing.T) {
+	newParams := func(mode v1beta1.Mode, enabled bool) manifests.Params {
+		return manifests.Params{
+			Config: config.New(),
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "my-namespace",
+				},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:          mode,
+					NetworkPolicy: v1beta1.NetworkPolicy{Enabled: enabled},
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Ports: []v1beta1.PortsSpec{
+							{ServicePort: corev1.ServicePort{Name: "syslog", Port: 5140, Protocol: corev1.ProtocolUDP}},
+						},
+					},
+					Config: v1beta1.Config{
+						Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+							"otlp": map[string]interface{}{
+								"protocols": map[string]interface{}{
+									"grpc": map[string]interface{}{},
+								},
+							},
+						}},
+						Extensions: &v1beta1.AnyConfig{Object: map[string]interface{}{
+							"health_check": map[string]interface{}{},
+						}},
+						Service: v1beta1.Service{
+							Extensions: []string{"health_check"},
+							Pipelines: map[string]*v1beta1.Pipeline{
+								"traces": {Receivers: []string{"otlp"}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		np, err := NetworkPolicy(newParams(v1beta1.ModeDeployment, false))
+		require.NoError(t, err)
+		assert.Nil(t, np)
+	})
+
+	t.Run("sidecar", func(t *testing.T) {
+		np, err := NetworkPolicy(newParams(v1beta1.ModeSidecar, true))
+		require.NoError(t, err)
+		assert.Nil(t, np)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		np, err := NetworkPolicy(newParams(v1beta1.ModeDeployment, true))
+		require.NoError(t, err)
+		require.NotNil(t, np)
+
+		assert.Equal(t, "my-instance-collector", np.Name)
+		assert.Equal(t, "my-namespace", np.Namespace)
+		assert.Equal(t, map[string]string{
+			"app.kubernetes.io/managed-by": "opentelemetry-operator",
+			"app.kubernetes.io/instance":   "my-namespace.my-instance",
+			"app.kubernetes.io/part-of":    "opentelemetry",
+			"app.kubernetes.io/component":  "opentelemetry-collector",
+		}, np.Spec.PodSelector.MatchLabels)
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}, np.Spec.PolicyTypes)
+		assert.Empty(t, np.Spec.Egress)
+		require.Len(t, np.Spec.Ingress, 1)
+		assert.Empty(t, np.Spec.Ingress[0].From)
+		assert.ElementsMatch(t, []networkingv1.NetworkPolicyPort{
+			{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(4317))},
+			{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(13133))},
+			{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(8888))},
+			{Protocol: ptr.To(corev1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(5140))},
+		}, np.Spec.Ingress[0].Ports)
+	})
+}
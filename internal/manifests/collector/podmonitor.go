@@ -52,6 +52,17 @@ func PodMonitor(params manifests.Params) (*monitoringv1.PodMonitor, error) {
 		},
 	}
 
+	metrics := params.OtelCol.Spec.Observability.Metrics
+	for i := range pm.Spec.PodMetricsEndpoints {
+		pm.Spec.PodMetricsEndpoints[i].Interval = monitoringv1.Duration(metrics.Interval)
+		pm.Spec.PodMetricsEndpoints[i].MetricRelabelConfigs = manifestutils.RelabelConfigs(metrics.MetricRelabelings)
+	}
+	if metrics.TLSConfig != nil {
+		// only the metrics port of the collector is served over HTTPS
+		pm.Spec.PodMetricsEndpoints[0].Scheme = "https"
+		pm.Spec.PodMetricsEndpoints[0].TLSConfig = manifestutils.SafeTLSConfig(metrics.TLSConfig)
+	}
+
 	return &pm, nil
 }
 
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"testing"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
@@ -73,3 +75,37 @@ func TestDesiredPodMonitorsPrometheusNotAvailable(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, actual)
 }
+
+func TestDesiredPodMonitorsWithMetricsConfig(t *testing.T) {
+	params, err := newParams("", "testdata/prometheus-exporter.yaml")
+	assert.NoError(t, err)
+	params.OtelCol.Spec.Mode = v1beta1.ModeSidecar
+	relabelings := []monitoringv1.RelabelConfig{
+		{Action: "drop", SourceLabels: []monitoringv1.LabelName{"__name__"}, Regex: "otelcol_process_.*"},
+	}
+	tlsConfig := &monitoringv1.SafeTLSConfig{InsecureSkipVerify: ptr.To(true)}
+	params.OtelCol.Spec.Observability.Metrics = v1beta1.MetricsConfigSpec{
+		EnableMetrics: true,
+		Interval:      "10s",
+		MetricRelabelings: []v1beta1.RelabelConfig{
+			{Action: "drop", SourceLabels: []v1beta1.LabelName{"__name__"}, Regex: "otelcol_process_.*"},
+		},
+		TLSConfig: &v1beta1.SafeTLSConfig{InsecureSkipVerify: ptr.To(true)},
+	}
+
+	actual, err := PodMonitor(params)
+	assert.NoError(t, err)
+	assert.NotNil(t, actual)
+	assert.Len(t, actual.Spec.PodMetricsEndpoints, 3)
+	for i, endpoint := range actual.Spec.PodMetricsEndpoints {
+		assert.Equal(t, monitoringv1.Duration("10s"), endpoint.Interval)
+		assert.Equal(t, relabelings, endpoint.MetricRelabelConfigs)
+		if i == 0 {
+			assert.Equal(t, "https", endpoint.Scheme)
+			assert.Equal(t, tlsConfig, endpoint.TLSConfig)
+		} else {
+			assert.Empty(t, endpoint.Scheme)
+			assert.Nil(t, endpoint.TLSConfig)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"fmt"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// PrometheusRule returns the prometheus rule with the recommended alerts on the metrics of the collector.
+func PrometheusRule(params manifests.Params) (*monitoringv1.PrometheusRule, error) {
+	metrics := params.OtelCol.Spec.Observability.Metrics
+	if !metrics.EnableAlerts || !metrics.EnableMetrics || params.Config.PrometheusCRAvailability == prometheus.NotAvailable {
+		return nil, nil
+	}
+
+	name := naming.PrometheusRule(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, nil)
+
+	// the job of the metrics scraped by the ServiceMonitor is the name of the monitoring service, and the one of the
+	// metrics scraped by the PodMonitor is the instance label of the pods.
+	job := naming.MonitoringService(params.OtelCol.Name)
+	if params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
+		job = naming.Truncate("%s.%s", 63, params.OtelCol.Namespace, params.OtelCol.Name)
+	}
+	selector := fmt.Sprintf(`job="%s", namespace="%s"`, job, params.OtelCol.Namespace)
+
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: params.OtelCol.Namespace,
+			Name:      name,
+			Labels:    labels,
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name: name,
+					Rules: []monitoringv1.Rule{
+						{
+							Alert: "OtelCollectorExporterQueueFull",
+							Expr:  intstr.FromString(fmt.Sprintf("max by (pod, exporter) (otelcol_exporter_queue_size{%[1]s} / otelcol_exporter_queue_capacity{%[1]s}) > 0.8", selector)),
+							For:   ptr.To(monitoringv1.Duration("5m")),
+							Labels: map[string]string{
+								"severity": "warning",
+							},
+							Annotations: map[string]string{
+								"summary":     "The sending queue of an exporter of the OpenTelemetry Collector is almost full.",
+								"description": "The sending queue of the exporter {{ $labels.exporter }} of the pod {{ $labels.pod }} is more than 80% full, the collector will soon drop data.",
+							},
+						},
+						{
+							Alert: "OtelCollectorExporterFailedSpans",
+							Expr:  intstr.FromString(fmt.Sprintf("sum by (pod, exporter) (rate(otelcol_exporter_send_failed_spans_total{%s}[5m])) > 0", selector)),
+							For:   ptr.To(monitoringv1.Duration("5m")),
+							Labels: map[string]string{
+								"severity": "warning",
+							},
+							Annotations: map[string]string{
+								"summary":     "An exporter of the OpenTelemetry Collector fails to send spans.",
+								"description": "The exporter {{ $labels.exporter }} of the pod {{ $labels.pod }} fails to send {{ $value }} spans per second.",
+							},
+						},
+						{
+							Alert: "OtelCollectorReceiverRefusedSpans",
+							Expr:  intstr.FromString(fmt.Sprintf("sum by (pod, receiver) (rate(otelcol_receiver_refused_spans_total{%s}[5m])) > 0", selector)),
+							For:   ptr.To(monitoringv1.Duration("5m")),
+							Labels: map[string]string{
+								"severity": "warning",
+							},
+							Annotations: map[string]string{
+								"summary":     "A receiver of the OpenTelemetry Collector refuses spans.",
+								"description": "The receiver {{ $labels.receiver }} of the pod {{ $labels.pod }} refuses {{ $value }} spans per second.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
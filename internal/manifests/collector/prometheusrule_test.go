@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestDesiredPrometheusRule(t *testing.T) {
+	params := deploymentParams()
+
+	actual, err := PrometheusRule(params)
+	require.NoError(t, err)
+	assert.Nil(t, actual)
+
+	// the alerts require the metrics
+	params.OtelCol.Spec.Observability.Metrics.EnableAlerts = true
+	actual, err = PrometheusRule(params)
+	require.NoError(t, err)
+	assert.Nil(t, actual)
+
+	params.OtelCol.Spec.Observability.Metrics.EnableMetrics = true
+	actual, err = PrometheusRule(params)
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	assert.Equal(t, "test-collector", actual.Name)
+	assert.Equal(t, params.OtelCol.Namespace, actual.Namespace)
+	assert.Equal(t, "opentelemetry-collector", actual.Labels["app.kubernetes.io/component"])
+	require.Len(t, actual.Spec.Groups, 1)
+	var alerts []string
+	for _, rule := range actual.Spec.Groups[0].Rules {
+		alerts = append(alerts, rule.Alert)
+		assert.Contains(t, rule.Expr.String(), `job="test-collector-monitoring", namespace="default"`)
+	}
+	assert.Equal(t, []string{"OtelCollectorExporterQueueFull", "OtelCollectorExporterFailedSpans", "OtelCollectorReceiverRefusedSpans"}, alerts)
+}
+
+func TestDesiredPrometheusRuleSidecar(t *testing.T) {
+	params := sidecarParams()
+	params.OtelCol.Spec.Observability.Metrics.EnableMetrics = true
+	params.OtelCol.Spec.Observability.Metrics.EnableAlerts = true
+
+	actual, err := PrometheusRule(params)
+	require.NoError(t, err)
+	require.NotNil(t, actual)
+	// the job of the PodMonitor is the instance label of the pods
+	for _, rule := range actual.Spec.Groups[0].Rules {
+		assert.Contains(t, rule.Expr.String(), `job="default.test", namespace="default"`)
+	}
+}
+
+func TestDesiredPrometheusRulePrometheusNotAvailable(t *testing.T) {
+	params, err := newParams("", "testdata/prometheus-exporter.yaml", config.WithPrometheusCRAvailability(prometheus.NotAvailable))
+	require.NoError(t, err)
+	params.OtelCol.Spec.Observability.Metrics = v1beta1.MetricsConfigSpec{EnableMetrics: true, EnableAlerts: true}
+
+	actual, err := PrometheusRule(params)
+	require.NoError(t, err)
+	assert.Nil(t, actual)
+}
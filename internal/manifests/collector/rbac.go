@@ -14,6 +14,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func ClusterRole(params manifests.Params) (*rbacv1.ClusterRole, error) {
@@ -79,6 +80,47 @@ func ClusterRoleBinding(params manifests.Params) (*rbacv1.ClusterRoleBinding, er
 	}, nil
 }
 
+// TargetAllocatorClusterRoleBinding binds the service account of the target allocator to the system:auth-delegator
+// cluster role, which allows it to review the service account tokens of the collectors when its authentication is
+// enabled.
+func TargetAllocatorClusterRoleBinding(params manifests.Params) (*rbacv1.ClusterRoleBinding, error) {
+	if params.TargetAllocator == nil || !featuregate.EnableTargetAllocatorAuthentication.IsEnabled() {
+		return nil, nil
+	}
+
+	name := naming.TargetAllocatorClusterRoleBinding(params.OtelCol.Name, params.OtelCol.Namespace)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccount := params.TargetAllocator.Spec.ServiceAccount
+	if len(serviceAccount) == 0 {
+		serviceAccount = naming.TargetAllocatorServiceAccount(params.TargetAllocator.Name)
+	}
+	return &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: annotations,
+			Labels:      labels,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      serviceAccount,
+				Namespace: params.OtelCol.Namespace,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			Kind:     "ClusterRole",
+			Name:     "system:auth-delegator",
+			APIGroup: "rbac.authorization.k8s.io",
+		},
+	}, nil
+}
+
 func CheckRbacRules(params manifests.Params, saName string) ([]string, error) {
 	ctx := context.Background()
 
@@ -8,7 +8,12 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	colfg "go.opentelemetry.io/collector/featuregate"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 func TestDesiredClusterRoles(t *testing.T) {
@@ -78,3 +83,29 @@ func TestDesiredClusterRolBinding(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotNil(t, crb)
 }
+
+func TestTargetAllocatorClusterRoleBinding(t *testing.T) {
+	params, err := newParams("", "testdata/prometheus-exporter.yaml")
+	require.NoError(t, err)
+	params.TargetAllocator = &v1alpha1.TargetAllocator{ObjectMeta: metav1.ObjectMeta{Name: params.OtelCol.Name}}
+
+	// only with the authentication of the target allocator
+	crb, err := TargetAllocatorClusterRoleBinding(params)
+	require.NoError(t, err)
+	assert.Nil(t, crb)
+
+	require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorAuthentication.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorAuthentication.ID(), false))
+	})
+	crb, err = TargetAllocatorClusterRoleBinding(params)
+	require.NoError(t, err)
+	require.NotNil(t, crb)
+	assert.Equal(t, "system:auth-delegator", crb.RoleRef.Name)
+	assert.Equal(t, []rbacv1.Subject{{Kind: "ServiceAccount", Name: params.OtelCol.Name + "-targetallocator", Namespace: params.OtelCol.Namespace}}, crb.Subjects)
+
+	params.TargetAllocator = nil
+	crb, err = TargetAllocatorClusterRoleBinding(params)
+	require.NoError(t, err)
+	assert.Nil(t, crb)
+}
@@ -26,19 +26,13 @@ func Routes(params manifests.Params) ([]*routev1.Route, error) {
 		return nil, nil
 	}
 
-	var tlsCfg *routev1.TLSConfig
-	switch params.OtelCol.Spec.Ingress.Route.Termination {
-	case v1beta1.TLSRouteTerminationTypeInsecure:
-		// NOTE: insecure, no tls cfg.
-	case v1beta1.TLSRouteTerminationTypeEdge:
-		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}
-	case v1beta1.TLSRouteTerminationTypePassthrough:
-		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}
-	case v1beta1.TLSRouteTerminationTypeReencrypt:
-		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationReencrypt}
-	default: // NOTE: if unsupported, end here.
+	if _, supported := routeTLSConfig(v1beta1.OpenShiftRoutePort{Termination: params.OtelCol.Spec.Ingress.Route.Termination}); !supported {
 		return nil, nil
 	}
+	portSettings := map[string]v1beta1.OpenShiftRoutePort{}
+	for _, port := range params.OtelCol.Spec.Ingress.Route.Ports {
+		portSettings[port.Name] = port
+	}
 
 	ports, err := servicePortsFromCfg(params.Log, params.OtelCol)
 
@@ -52,15 +46,24 @@ func Routes(params manifests.Params) ([]*routev1.Route, error) {
 		return nil, err
 	}
 
-	routes := make([]*routev1.Route, len(ports))
-	for i, p := range ports {
+	routes := make([]*routev1.Route, 0, len(ports))
+	for _, p := range ports {
 		portName := naming.PortName(p.Name, p.Port)
-		host := ""
-		if params.OtelCol.Spec.Ingress.Hostname != "" {
+		settings := portSettings[portName]
+		if settings.Termination == "" {
+			settings.Termination = params.OtelCol.Spec.Ingress.Route.Termination
+		}
+		tlsCfg, supported := routeTLSConfig(settings)
+		if !supported {
+			params.Log.V(1).Info("unsupported route termination, skipping the route of the port", "port", portName, "termination", settings.Termination)
+			continue
+		}
+		host := settings.Hostname
+		if host == "" && params.OtelCol.Spec.Ingress.Hostname != "" {
 			host = fmt.Sprintf("%s.%s", portName, params.OtelCol.Spec.Ingress.Hostname)
 		}
 
-		routes[i] = &routev1.Route{
+		routes = append(routes, &routev1.Route{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:        naming.Route(params.OtelCol.Name, p.Name),
 				Namespace:   params.OtelCol.Namespace,
@@ -84,7 +87,32 @@ func Routes(params manifests.Params) ([]*routev1.Route, error) {
 				WildcardPolicy: routev1.WildcardPolicyNone,
 				TLS:            tlsCfg,
 			},
-		}
+		})
 	}
 	return routes, nil
 }
+
+// routeTLSConfig returns the TLS configuration of the route of a port, and false if its termination isn't supported.
+func routeTLSConfig(port v1beta1.OpenShiftRoutePort) (*routev1.TLSConfig, bool) {
+	var tlsCfg *routev1.TLSConfig
+	switch port.Termination {
+	case v1beta1.TLSRouteTerminationTypeInsecure:
+		// NOTE: insecure, no tls cfg.
+		return nil, true
+	case v1beta1.TLSRouteTerminationTypeEdge:
+		tlsCfg = &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}
+	case v1beta1.TLSRouteTerminationTypePassthrough:
+		return &routev1.TLSConfig{Termination: routev1.TLSTerminationPassthrough}, true
+	case v1beta1.TLSRouteTerminationTypeReencrypt:
+		tlsCfg = &routev1.TLSConfig{
+			Termination:              routev1.TLSTerminationReencrypt,
+			DestinationCACertificate: port.DestinationCACertificate,
+		}
+	default:
+		return nil, false
+	}
+	if port.CertificateSecret != "" {
+		tlsCfg.ExternalCertificate = &routev1.LocalObjectReference{Name: port.CertificateSecret}
+	}
+	return tlsCfg, true
+}
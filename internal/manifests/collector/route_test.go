@@ -159,6 +159,45 @@ func TestDesiredRoutes(t *testing.T) {
 		assert.Equal(t, "", routes[1].Spec.Host)
 		assert.Equal(t, "", routes[2].Spec.Host)
 	})
+	t.Run("port settings", func(t *testing.T) {
+		params, err := newParams("something:tag", testFileIngress)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		params.OtelCol.Namespace = "test"
+		params.OtelCol.Spec.Ingress = v1beta1.Ingress{
+			Hostname: "example.com",
+			Type:     v1beta1.IngressTypeRoute,
+			Route: v1beta1.OpenShiftRoute{
+				Termination: v1beta1.TLSRouteTerminationTypeEdge,
+				Ports: []v1beta1.OpenShiftRoutePort{
+					{Name: "web", Termination: v1beta1.TLSRouteTerminationTypeInsecure},
+					{
+						Name:                     "otlp-grpc",
+						Termination:              v1beta1.TLSRouteTerminationTypeReencrypt,
+						Hostname:                 "otlp.example.org",
+						CertificateSecret:        "otlp-tls",
+						DestinationCACertificate: "ca",
+					},
+				},
+			},
+		}
+
+		routes, err := Routes(params)
+		assert.NoError(t, err)
+		require.Equal(t, 3, len(routes))
+		assert.Equal(t, "web.example.com", routes[0].Spec.Host)
+		assert.Nil(t, routes[0].Spec.TLS)
+		assert.Equal(t, "otlp.example.org", routes[1].Spec.Host)
+		assert.Equal(t, &routev1.TLSConfig{
+			Termination:              routev1.TLSTerminationReencrypt,
+			DestinationCACertificate: "ca",
+			ExternalCertificate:      &routev1.LocalObjectReference{Name: "otlp-tls"},
+		}, routes[1].Spec.TLS)
+		assert.Equal(t, "otlp-test-grpc.example.com", routes[2].Spec.Host)
+		assert.Equal(t, &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge}, routes[2].Spec.TLS)
+	})
 }
 
 func TestRoutes(t *testing.T) {
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"strconv"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// ScaledObject builds a KEDA ScaledObject scaling the collector on the configured KEDA triggers. It takes over
+// the role of the HorizontalPodAutoscaler, which KEDA creates and manages by itself.
+func ScaledObject(params manifests.Params) (*kedav1alpha1.ScaledObject, error) {
+	if !usesKeda(params) {
+		return nil, nil
+	}
+
+	name := naming.Collector(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	autoscaler := params.OtelCol.Spec.Autoscaler
+	var triggers []kedav1alpha1.ScaleTriggers
+	if autoscaler.TargetCPUUtilization != nil {
+		triggers = append(triggers, kedav1alpha1.ScaleTriggers{
+			Type:       "cpu",
+			MetricType: autoscalingv2.UtilizationMetricType,
+			Metadata:   map[string]string{"value": strconv.Itoa(int(*autoscaler.TargetCPUUtilization))},
+		})
+	}
+	if autoscaler.TargetMemoryUtilization != nil {
+		triggers = append(triggers, kedav1alpha1.ScaleTriggers{
+			Type:       "memory",
+			MetricType: autoscalingv2.UtilizationMetricType,
+			Metadata:   map[string]string{"value": strconv.Itoa(int(*autoscaler.TargetMemoryUtilization))},
+		})
+	}
+	for _, trigger := range autoscaler.KedaTriggers {
+		scaleTrigger := kedav1alpha1.ScaleTriggers{
+			Type:       trigger.Type,
+			Name:       trigger.Name,
+			Metadata:   trigger.Metadata,
+			MetricType: trigger.MetricType,
+		}
+		if scaleTrigger.Metadata == nil {
+			// KEDA requires the metadata, even for the scalers without settings
+			scaleTrigger.Metadata = map[string]string{}
+		}
+		if trigger.AuthenticationRef != nil {
+			scaleTrigger.AuthenticationRef = &kedav1alpha1.AuthenticationRef{
+				Name: trigger.AuthenticationRef.Name,
+				Kind: trigger.AuthenticationRef.Kind,
+			}
+		}
+		triggers = append(triggers, scaleTrigger)
+	}
+
+	scaledObject := &kedav1alpha1.ScaledObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.ScaledObject(params.OtelCol.Name),
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: kedav1alpha1.ScaledObjectSpec{
+			ScaleTargetRef: &kedav1alpha1.ScaleTarget{
+				APIVersion: v1beta1.GroupVersion.String(),
+				Kind:       "OpenTelemetryCollector",
+				Name:       naming.OpenTelemetryCollector(params.OtelCol.Name),
+			},
+			MinReplicaCount: autoscaler.MinReplicas,
+			MaxReplicaCount: autoscaler.MaxReplicas,
+			Triggers:        triggers,
+		},
+	}
+	if autoscaler.Behavior != nil {
+		scaledObject.Spec.Advanced = &kedav1alpha1.AdvancedConfig{
+			HorizontalPodAutoscalerConfig: &kedav1alpha1.HorizontalPodAutoscalerConfig{
+				Behavior: autoscaler.Behavior,
+			},
+		}
+	}
+
+	return scaledObject, nil
+}
+
+// usesKeda returns true when the collector should be scaled by KEDA rather than by a HorizontalPodAutoscaler.
+func usesKeda(params manifests.Params) bool {
+	return params.OtelCol.Spec.Autoscaler != nil &&
+		len(params.OtelCol.Spec.Autoscaler.KedaTriggers) > 0 &&
+		params.Config.KedaAvailability == keda.Available
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestScaledObject(t *testing.T) {
+	var minReplicas int32 = 1
+	var maxReplicas int32 = 5
+	var cpuUtilization int32 = 66
+	stabilizationWindow := int32(60)
+
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "observability",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Autoscaler: &v1beta1.AutoscalerSpec{
+				MinReplicas:          &minReplicas,
+				MaxReplicas:          &maxReplicas,
+				TargetCPUUtilization: &cpuUtilization,
+				Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+					ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: &stabilizationWindow},
+				},
+				KedaTriggers: []v1beta1.KedaTrigger{
+					{
+						Type:     "kafka",
+						Name:     "lag",
+						Metadata: map[string]string{"topic": "otlp_spans", "lagThreshold": "100"},
+						AuthenticationRef: &v1beta1.KedaAuthenticationRef{
+							Name: "kafka-credentials",
+						},
+					},
+					{
+						Type: "prometheus",
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("keda is not available", func(t *testing.T) {
+		params := manifests.Params{
+			Config:  config.New(),
+			OtelCol: otelcol,
+			Log:     testLogger,
+		}
+
+		scaledObject, err := ScaledObject(params)
+		require.NoError(t, err)
+		assert.Nil(t, scaledObject)
+
+		hpa, err := HorizontalPodAutoscaler(params)
+		require.NoError(t, err)
+		assert.NotNil(t, hpa)
+	})
+
+	t.Run("no keda triggers", func(t *testing.T) {
+		withoutTriggers := *otelcol.DeepCopy()
+		withoutTriggers.Spec.Autoscaler.KedaTriggers = nil
+		params := manifests.Params{
+			Config:  config.New(config.WithKedaAvailability(keda.Available)),
+			OtelCol: withoutTriggers,
+			Log:     testLogger,
+		}
+
+		scaledObject, err := ScaledObject(params)
+		require.NoError(t, err)
+		assert.Nil(t, scaledObject)
+	})
+
+	t.Run("keda replaces the hpa", func(t *testing.T) {
+		params := manifests.Params{
+			Config:  config.New(config.WithKedaAvailability(keda.Available)),
+			OtelCol: otelcol,
+			Log:     testLogger,
+		}
+
+		hpa, err := HorizontalPodAutoscaler(params)
+		require.NoError(t, err)
+		assert.Nil(t, hpa)
+
+		scaledObject, err := ScaledObject(params)
+		require.NoError(t, err)
+		require.NotNil(t, scaledObject)
+
+		assert.Equal(t, "my-instance-collector", scaledObject.Name)
+		assert.Equal(t, "observability", scaledObject.Namespace)
+		assert.Equal(t, &kedav1alpha1.ScaleTarget{
+			APIVersion: "opentelemetry.io/v1beta1",
+			Kind:       "OpenTelemetryCollector",
+			Name:       "my-instance",
+		}, scaledObject.Spec.ScaleTargetRef)
+		assert.Equal(t, &minReplicas, scaledObject.Spec.MinReplicaCount)
+		assert.Equal(t, &maxReplicas, scaledObject.Spec.MaxReplicaCount)
+		assert.Equal(t, otelcol.Spec.Autoscaler.Behavior, scaledObject.Spec.Advanced.HorizontalPodAutoscalerConfig.Behavior)
+		assert.Equal(t, []kedav1alpha1.ScaleTriggers{
+			{
+				Type:       "cpu",
+				MetricType: autoscalingv2.UtilizationMetricType,
+				Metadata:   map[string]string{"value": "66"},
+			},
+			{
+				Type:              "kafka",
+				Name:              "lag",
+				Metadata:          map[string]string{"topic": "otlp_spans", "lagThreshold": "100"},
+				AuthenticationRef: &kedav1alpha1.AuthenticationRef{Name: "kafka-credentials"},
+			},
+			{
+				Type:     "prometheus",
+				Metadata: map[string]string{},
+			},
+		}, scaledObject.Spec.Triggers)
+	})
+}
@@ -5,13 +5,17 @@ package collector
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/components"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
@@ -32,10 +36,12 @@ const (
 	HeadlessServiceType
 	MonitoringServiceType
 	ExtensionServiceType
+	AdditionalServiceType
+	OTLPHeadlessServiceType
 )
 
 func (s ServiceType) String() string {
-	return [...]string{"base", "headless", "monitoring", "extension"}[s]
+	return [...]string{"base", "headless", "monitoring", "extension", "additional", "otlp-headless"}[s]
 }
 
 func HeadlessService(params manifests.Params) (*corev1.Service, error) {
@@ -58,9 +64,63 @@ func HeadlessService(params manifests.Params) (*corev1.Service, error) {
 	h.Annotations = annotations
 
 	h.Spec.ClusterIP = "None"
+	// the headless services aren't load balanced by kube-proxy
+	h.Spec.TrafficDistribution = nil
 	return h, nil
 }
 
+// OTLPHeadlessService builds the headless service exposing the ports of the otlp receivers of the pipelines, for the
+// clients balancing the load over the collector pods themselves, when the topology-aware routing is enabled.
+func OTLPHeadlessService(params manifests.Params) (*corev1.Service, error) {
+	if !params.OtelCol.Spec.TopologyAwareRouting.Enabled || params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
+		return nil, nil
+	}
+
+	var receivers []string
+	for receiver := range params.OtelCol.Spec.Config.GetEnabledComponents()[v1beta1.KindReceiver] {
+		if components.ComponentType(receiver) == "otlp" {
+			receivers = append(receivers, receiver)
+		}
+	}
+	ports, err := servicePorts(params)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := additionalServicePorts(params, v1beta1.CollectorService{Receivers: receivers}, ports)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		params.Log.V(1).Info("the instance's configuration has no otlp receiver port, skipping the otlp headless service", "instance.name", params.OtelCol.Name, "instance.namespace", params.OtelCol.Namespace)
+		return nil, nil
+	}
+
+	name := naming.OTLPHeadlessService(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+	labels[serviceTypeLabel] = OTLPHeadlessServiceType.String()
+
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector:       manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			ClusterIP:      "None",
+			Ports:          selected,
+			IPFamilies:     params.OtelCol.Spec.IpFamilies,
+			IPFamilyPolicy: params.OtelCol.Spec.IpFamilyPolicy,
+		},
+	}, nil
+}
+
 func MonitoringService(params manifests.Params) (*corev1.Service, error) {
 	name := naming.MonitoringService(params.OtelCol.Name)
 	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
@@ -124,8 +184,10 @@ func ExtensionService(params manifests.Params) (*corev1.Service, error) {
 			Annotations: annotations,
 		},
 		Spec: corev1.ServiceSpec{
-			Ports:    ports,
-			Selector: manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			Ports:          ports,
+			Selector:       manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			IPFamilies:     params.OtelCol.Spec.IpFamilies,
+			IPFamilyPolicy: params.OtelCol.Spec.IpFamilyPolicy,
 		},
 	}, nil
 }
@@ -140,6 +202,60 @@ func Service(params manifests.Params) (*corev1.Service, error) {
 		return nil, err
 	}
 
+	ports, err := servicePorts(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// if we have no ports, we don't need a service
+	if len(ports) == 0 {
+
+		params.Log.V(1).Info("the instance's configuration didn't yield any ports to open, skipping service", "instance.name", params.OtelCol.Name, "instance.namespace", params.OtelCol.Namespace)
+		return nil, err
+	}
+
+	trafficPolicy, trafficDistribution := serviceRouting(params.OtelCol)
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.Service(params.OtelCol.Name),
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			InternalTrafficPolicy: trafficPolicy,
+			TrafficDistribution:   trafficDistribution,
+			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+			ClusterIP:             "",
+			Ports:                 ports,
+			IPFamilies:            params.OtelCol.Spec.IpFamilies,
+			IPFamilyPolicy:        params.OtelCol.Spec.IpFamilyPolicy,
+		},
+	}, nil
+}
+
+// serviceRouting returns the internal traffic policy and the traffic distribution of the collector service and of the
+// additional services.
+func serviceRouting(otelcol v1beta1.OpenTelemetryCollector) (*corev1.ServiceInternalTrafficPolicy, *string) {
+	trafficPolicy := corev1.ServiceInternalTrafficPolicyCluster
+	if otelcol.Spec.Mode == v1beta1.ModeDaemonSet {
+		trafficPolicy = corev1.ServiceInternalTrafficPolicyLocal
+	}
+	routing := otelcol.Spec.TopologyAwareRouting
+	if routing.InternalTrafficPolicy != "" {
+		trafficPolicy = routing.InternalTrafficPolicy
+	}
+	var trafficDistribution *string
+	if routing.Enabled {
+		trafficDistribution = ptr.To(corev1.ServiceTrafficDistributionPreferClose)
+	}
+	return &trafficPolicy, trafficDistribution
+}
+
+// servicePorts returns the ports of the collector service: the ports of the spec, followed by the ones parsed from
+// the receivers and exporters of the configuration which don't clash with them.
+func servicePorts(params manifests.Params) ([]corev1.ServicePort, error) {
 	ports, err := params.OtelCol.Spec.Config.GetReceiverAndExporterPorts(params.Log)
 	if err != nil {
 		return nil, err
@@ -173,34 +289,98 @@ func Service(params manifests.Params) (*corev1.Service, error) {
 		ports = append(toServicePorts(params.OtelCol.Spec.Ports), resultingInferredPorts...)
 	}
 
-	// if we have no ports, we don't need a service
-	if len(ports) == 0 {
+	return ports, nil
+}
 
-		params.Log.V(1).Info("the instance's configuration didn't yield any ports to open, skipping service", "instance.name", params.OtelCol.Name, "instance.namespace", params.OtelCol.Namespace)
+// AdditionalServices builds the additional services of the collector, each exposing the ports of its receivers and
+// the ports with its port names.
+func AdditionalServices(params manifests.Params) ([]*corev1.Service, error) {
+	if len(params.OtelCol.Spec.Services) == 0 || params.OtelCol.Spec.Mode == v1beta1.ModeSidecar {
+		return nil, nil
+	}
+
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
 		return nil, err
 	}
 
-	trafficPolicy := corev1.ServiceInternalTrafficPolicyCluster
-	if params.OtelCol.Spec.Mode == v1beta1.ModeDaemonSet {
-		trafficPolicy = corev1.ServiceInternalTrafficPolicyLocal
+	ports, err := servicePorts(params)
+	if err != nil {
+		return nil, err
 	}
 
-	return &corev1.Service{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        naming.Service(params.OtelCol.Name),
-			Namespace:   params.OtelCol.Namespace,
-			Labels:      labels,
-			Annotations: annotations,
-		},
-		Spec: corev1.ServiceSpec{
-			InternalTrafficPolicy: &trafficPolicy,
-			Selector:              manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
-			ClusterIP:             "",
-			Ports:                 ports,
-			IPFamilies:            params.OtelCol.Spec.IpFamilies,
-			IPFamilyPolicy:        params.OtelCol.Spec.IpFamilyPolicy,
-		},
-	}, nil
+	trafficPolicy, trafficDistribution := serviceRouting(params.OtelCol)
+
+	var services []*corev1.Service
+	for _, spec := range params.OtelCol.Spec.Services {
+		selected, err := additionalServicePorts(params, spec, ports)
+		if err != nil {
+			return nil, err
+		}
+		if len(selected) == 0 {
+			params.Log.V(1).Info("the additional service doesn't match any port, skipping it", "service", spec.Name, "instance.name", params.OtelCol.Name, "instance.namespace", params.OtelCol.Namespace)
+			continue
+		}
+
+		name := naming.AdditionalService(params.OtelCol.Name, spec.Name)
+		labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, []string{})
+		labels[serviceTypeLabel] = AdditionalServiceType.String()
+
+		// copy to avoid modifying the annotations shared by the services
+		serviceAnnotations := maps.Clone(annotations)
+		if serviceAnnotations == nil {
+			serviceAnnotations = map[string]string{}
+		}
+		maps.Copy(serviceAnnotations, spec.Annotations)
+
+		serviceType := spec.Type
+		if serviceType == "" {
+			serviceType = corev1.ServiceTypeClusterIP
+		}
+
+		services = append(services, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   params.OtelCol.Namespace,
+				Labels:      labels,
+				Annotations: serviceAnnotations,
+			},
+			Spec: corev1.ServiceSpec{
+				Type:                     serviceType,
+				InternalTrafficPolicy:    trafficPolicy,
+				TrafficDistribution:      trafficDistribution,
+				Selector:                 manifestutils.SelectorLabels(params.OtelCol.ObjectMeta, ComponentOpenTelemetryCollector),
+				Ports:                    selected,
+				LoadBalancerSourceRanges: spec.LoadBalancerSourceRanges,
+				IPFamilies:               params.OtelCol.Spec.IpFamilies,
+				IPFamilyPolicy:           params.OtelCol.Spec.IpFamilyPolicy,
+			},
+		})
+	}
+	return services, nil
+}
+
+// additionalServicePorts returns the ports of the collector service exposed by the given additional service, keeping
+// their order.
+func additionalServicePorts(params manifests.Params, spec v1beta1.CollectorService, ports []corev1.ServicePort) ([]corev1.ServicePort, error) {
+	receiverPorts := map[PortNumberKey]bool{}
+	for _, receiver := range spec.Receivers {
+		parsed, err := params.OtelCol.Spec.Config.GetPortsForReceiver(params.Log, receiver)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range parsed {
+			receiverPorts[newPortNumberKey(p.Port, p.Protocol)] = true
+		}
+	}
+
+	var selected []corev1.ServicePort
+	for _, p := range ports {
+		if slices.Contains(spec.Ports, p.Name) || receiverPorts[newPortNumberKey(p.Port, p.Protocol)] {
+			selected = append(selected, p)
+		}
+	}
+	return selected, nil
 }
 
 type PortNumberKey struct {
@@ -7,9 +7,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
@@ -511,6 +513,215 @@ func TestExtensionService(t *testing.T) {
 	}
 }
 
+func TestAdditionalServices(t *testing.T) {
+	newParams := func(mode v1beta1.Mode, services ...v1beta1.CollectorService) manifests.Params {
+		return manifests.Params{
+			Config: config.New(),
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-instance",
+					Namespace:   "my-namespace",
+					Annotations: map[string]string{"owner": "team"},
+				},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:     mode,
+					Services: services,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+						Ports: []v1beta1.PortsSpec{
+							{ServicePort: v1.ServicePort{Name: "syslog", Port: 5140, Protocol: v1.ProtocolUDP}},
+						},
+					},
+					Config: v1beta1.Config{
+						Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+							"otlp": map[string]interface{}{
+								"protocols": map[string]interface{}{
+									"grpc": map[string]interface{}{},
+									"http": map[string]interface{}{},
+								},
+							},
+							"zipkin": map[string]interface{}{},
+						}},
+						Service: v1beta1.Service{
+							Pipelines: map[string]*v1beta1.Pipeline{
+								"traces": {Receivers: []string{"otlp", "zipkin"}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("no services", func(t *testing.T) {
+		services, err := AdditionalServices(newParams(v1beta1.ModeDeployment))
+		assert.NoError(t, err)
+		assert.Empty(t, services)
+	})
+
+	t.Run("sidecar", func(t *testing.T) {
+		services, err := AdditionalServices(newParams(v1beta1.ModeSidecar, v1beta1.CollectorService{Name: "otlp", Receivers: []string{"otlp"}}))
+		assert.NoError(t, err)
+		assert.Empty(t, services)
+	})
+
+	t.Run("services", func(t *testing.T) {
+		services, err := AdditionalServices(newParams(v1beta1.ModeDeployment,
+			v1beta1.CollectorService{
+				Name:                     "ingest",
+				Type:                     v1.ServiceTypeLoadBalancer,
+				Receivers:                []string{"otlp"},
+				Annotations:              map[string]string{"service.beta.kubernetes.io/aws-load-balancer-type": "nlb"},
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			},
+			v1beta1.CollectorService{
+				Name:  "internal",
+				Ports: []string{"syslog", "zipkin"},
+			},
+			v1beta1.CollectorService{
+				Name:  "unmatched",
+				Ports: []string{"unknown"},
+			},
+		))
+		assert.NoError(t, err)
+		if !assert.Len(t, services, 2) {
+			return
+		}
+
+		ingest := services[0]
+		assert.Equal(t, "my-instance-collector-ingest", ingest.Name)
+		assert.Equal(t, "my-namespace", ingest.Namespace)
+		assert.Equal(t, AdditionalServiceType.String(), ingest.Labels[serviceTypeLabel])
+		assert.Equal(t, map[string]string{
+			"owner": "team",
+			"service.beta.kubernetes.io/aws-load-balancer-type": "nlb",
+		}, ingest.Annotations)
+		assert.Equal(t, v1.ServiceTypeLoadBalancer, ingest.Spec.Type)
+		assert.Equal(t, []string{"10.0.0.0/8"}, ingest.Spec.LoadBalancerSourceRanges)
+		var names []string
+		for _, p := range ingest.Spec.Ports {
+			names = append(names, p.Name)
+		}
+		assert.Equal(t, []string{"otlp-grpc", "otlp-http"}, names)
+
+		internal := services[1]
+		assert.Equal(t, "my-instance-collector-internal", internal.Name)
+		assert.Equal(t, map[string]string{"owner": "team"}, internal.Annotations)
+		assert.Equal(t, v1.ServiceTypeClusterIP, internal.Spec.Type)
+		names = nil
+		for _, p := range internal.Spec.Ports {
+			names = append(names, p.Name)
+		}
+		assert.Equal(t, []string{"syslog", "zipkin"}, names)
+	})
+}
+
+func TestTopologyAwareRouting(t *testing.T) {
+	newParams := func(mode v1beta1.Mode, routing v1beta1.TopologyAwareRouting) manifests.Params {
+		return manifests.Params{
+			Config: config.New(),
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "observability"},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:                 mode,
+					Services:             []v1beta1.CollectorService{{Name: "ingest", Receivers: []string{"otlp/in"}}},
+					TopologyAwareRouting: routing,
+					Config: v1beta1.Config{
+						Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+							"otlp/in": map[string]interface{}{
+								"protocols": map[string]interface{}{
+									"grpc": map[string]interface{}{},
+									"http": map[string]interface{}{},
+								},
+							},
+							"zipkin": map[string]interface{}{},
+						}},
+						Service: v1beta1.Service{
+							Pipelines: map[string]*v1beta1.Pipeline{
+								"traces": {Receivers: []string{"otlp/in", "zipkin"}},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                        string
+		mode                        v1beta1.Mode
+		routing                     v1beta1.TopologyAwareRouting
+		expectedTrafficPolicy       v1.ServiceInternalTrafficPolicy
+		expectedTrafficDistribution *string
+		expectedOTLPHeadless        bool
+	}{
+		{
+			name:                  "disabled",
+			mode:                  v1beta1.ModeDeployment,
+			expectedTrafficPolicy: v1.ServiceInternalTrafficPolicyCluster,
+		},
+		{
+			name:                        "enabled",
+			mode:                        v1beta1.ModeDeployment,
+			routing:                     v1beta1.TopologyAwareRouting{Enabled: true},
+			expectedTrafficPolicy:       v1.ServiceInternalTrafficPolicyCluster,
+			expectedTrafficDistribution: ptr.To(v1.ServiceTrafficDistributionPreferClose),
+			expectedOTLPHeadless:        true,
+		},
+		{
+			name:                  "internal traffic policy",
+			mode:                  v1beta1.ModeStatefulSet,
+			routing:               v1beta1.TopologyAwareRouting{InternalTrafficPolicy: v1.ServiceInternalTrafficPolicyLocal},
+			expectedTrafficPolicy: v1.ServiceInternalTrafficPolicyLocal,
+		},
+		{
+			name:                        "daemonset",
+			mode:                        v1beta1.ModeDaemonSet,
+			routing:                     v1beta1.TopologyAwareRouting{Enabled: true},
+			expectedTrafficPolicy:       v1.ServiceInternalTrafficPolicyLocal,
+			expectedTrafficDistribution: ptr.To(v1.ServiceTrafficDistributionPreferClose),
+			expectedOTLPHeadless:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := newParams(tt.mode, tt.routing)
+
+			service, err := Service(params)
+			require.NoError(t, err)
+			assert.Equal(t, &tt.expectedTrafficPolicy, service.Spec.InternalTrafficPolicy)
+			assert.Equal(t, tt.expectedTrafficDistribution, service.Spec.TrafficDistribution)
+
+			services, err := AdditionalServices(params)
+			require.NoError(t, err)
+			require.Len(t, services, 1)
+			assert.Equal(t, &tt.expectedTrafficPolicy, services[0].Spec.InternalTrafficPolicy)
+			assert.Equal(t, tt.expectedTrafficDistribution, services[0].Spec.TrafficDistribution)
+
+			headless, err := HeadlessService(params)
+			require.NoError(t, err)
+			assert.Nil(t, headless.Spec.TrafficDistribution)
+
+			otlp, err := OTLPHeadlessService(params)
+			require.NoError(t, err)
+			if !tt.expectedOTLPHeadless {
+				assert.Nil(t, otlp)
+				return
+			}
+			require.NotNil(t, otlp)
+			assert.Equal(t, "gateway-collector-otlp-headless", otlp.Name)
+			assert.Equal(t, OTLPHeadlessServiceType.String(), otlp.Labels[serviceTypeLabel])
+			assert.Equal(t, "None", otlp.Spec.ClusterIP)
+			var names []string
+			for _, p := range otlp.Spec.Ports {
+				names = append(names, p.Name)
+			}
+			assert.Equal(t, []string{"otlp-in-grpc", "otlp-in-http"}, names)
+		})
+	}
+}
+
 func service(name string, ports []v1beta1.PortsSpec) v1.Service {
 	return serviceWithInternalTrafficPolicy(name, ports, v1.ServiceInternalTrafficPolicyCluster)
 }
@@ -22,6 +22,9 @@ import (
 func ServiceMonitor(params manifests.Params) (*monitoringv1.ServiceMonitor, error) {
 	name := naming.ServiceMonitor(params.OtelCol.Name)
 	endpoints := endpointsFromConfig(params.Log, params.OtelCol)
+	for i := range endpoints {
+		endpoints[i] = endpointWithMetricsConfig(endpoints[i], params.OtelCol.Spec.Observability.Metrics, false)
+	}
 	if len(endpoints) > 0 {
 		return createServiceMonitor(name, params, BaseServiceType, endpoints)
 	}
@@ -32,9 +35,7 @@ func ServiceMonitor(params manifests.Params) (*monitoringv1.ServiceMonitor, erro
 func ServiceMonitorMonitoring(params manifests.Params) (*monitoringv1.ServiceMonitor, error) {
 	name := naming.ServiceMonitor(fmt.Sprintf("%s-monitoring", params.OtelCol.Name))
 	endpoints := []monitoringv1.Endpoint{
-		{
-			Port: "monitoring",
-		},
+		endpointWithMetricsConfig(monitoringv1.Endpoint{Port: "monitoring"}, params.OtelCol.Spec.Observability.Metrics, true),
 	}
 	return createServiceMonitor(name, params, MonitoringServiceType, endpoints)
 }
@@ -73,6 +74,18 @@ func createServiceMonitor(name string, params manifests.Params, serviceType Serv
 	return &sm, nil
 }
 
+// endpointWithMetricsConfig applies the scrape settings of the metrics config to the endpoint. The TLS config is only
+// applied to the endpoint of the metrics port of the operand, as the ports of the prometheus exporters are served over HTTP.
+func endpointWithMetricsConfig(endpoint monitoringv1.Endpoint, metrics v1beta1.MetricsConfigSpec, metricsPort bool) monitoringv1.Endpoint {
+	endpoint.Interval = monitoringv1.Duration(metrics.Interval)
+	endpoint.MetricRelabelConfigs = manifestutils.RelabelConfigs(metrics.MetricRelabelings)
+	if metricsPort && metrics.TLSConfig != nil {
+		endpoint.Scheme = "https"
+		endpoint.TLSConfig = &monitoringv1.TLSConfig{SafeTLSConfig: *manifestutils.SafeTLSConfig(metrics.TLSConfig)}
+	}
+	return endpoint
+}
+
 func shouldCreateServiceMonitor(params manifests.Params) bool {
 	l := params.Log.WithValues(
 		"params.OtelCol.name", params.OtelCol.Name,
@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"testing"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 )
@@ -72,3 +75,39 @@ func TestDesiredServiceMonitorsPrometheusNotAvailable(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, actual)
 }
+
+func TestDesiredServiceMonitorsWithMetricsConfig(t *testing.T) {
+	params, err := newParams("", "testdata/prometheus-exporter.yaml")
+	assert.NoError(t, err)
+	relabelings := []monitoringv1.RelabelConfig{
+		{Action: "drop", SourceLabels: []monitoringv1.LabelName{"__name__"}, Regex: "otelcol_process_.*"},
+	}
+	tlsConfig := &monitoringv1.SafeTLSConfig{ServerName: ptr.To("test-collector-monitoring")}
+	params.OtelCol.Spec.Observability.Metrics = v1beta1.MetricsConfigSpec{
+		EnableMetrics: true,
+		Interval:      "10s",
+		MetricRelabelings: []v1beta1.RelabelConfig{
+			{Action: "drop", SourceLabels: []v1beta1.LabelName{"__name__"}, Regex: "otelcol_process_.*"},
+		},
+		TLSConfig: &v1beta1.SafeTLSConfig{ServerName: ptr.To("test-collector-monitoring")},
+	}
+
+	actual, err := ServiceMonitor(params)
+	assert.NoError(t, err)
+	assert.NotNil(t, actual)
+	for _, endpoint := range actual.Spec.Endpoints {
+		assert.Equal(t, monitoringv1.Duration("10s"), endpoint.Interval)
+		assert.Equal(t, relabelings, endpoint.MetricRelabelConfigs)
+		// the prometheus exporters are scraped over HTTP
+		assert.Empty(t, endpoint.Scheme)
+		assert.Nil(t, endpoint.TLSConfig)
+	}
+
+	actual, err = ServiceMonitorMonitoring(params)
+	assert.NoError(t, err)
+	assert.NotNil(t, actual)
+	assert.Equal(t, monitoringv1.Duration("10s"), actual.Spec.Endpoints[0].Interval)
+	assert.Equal(t, relabelings, actual.Spec.Endpoints[0].MetricRelabelConfigs)
+	assert.Equal(t, "https", actual.Spec.Endpoints[0].Scheme)
+	assert.Equal(t, &monitoringv1.TLSConfig{SafeTLSConfig: *tlsConfig}, actual.Spec.Endpoints[0].TLSConfig)
+}
@@ -48,15 +48,15 @@ func StatefulSet(params manifests.Params) (*appsv1.StatefulSet, error) {
 				Spec: corev1.PodSpec{
 					ServiceAccountName:            ServiceAccountName(params.OtelCol),
 					InitContainers:                params.OtelCol.Spec.InitContainers,
-					Containers:                    append(params.OtelCol.Spec.AdditionalContainers, Container(params.Config, params.Log, params.OtelCol, true)),
+					Containers:                    Containers(params.Config, params.Log, params.OtelCol),
 					Volumes:                       Volumes(params.Config, params.OtelCol),
 					DNSPolicy:                     manifestutils.GetDNSPolicy(params.OtelCol.Spec.HostNetwork, params.OtelCol.Spec.PodDNSConfig),
 					DNSConfig:                     &params.OtelCol.Spec.PodDNSConfig,
 					HostNetwork:                   params.OtelCol.Spec.HostNetwork,
-					ShareProcessNamespace:         &params.OtelCol.Spec.ShareProcessNamespace,
+					ShareProcessNamespace:         shareProcessNamespace(params.OtelCol),
 					Tolerations:                   params.OtelCol.Spec.Tolerations,
 					NodeSelector:                  params.OtelCol.Spec.NodeSelector,
-					SecurityContext:               params.OtelCol.Spec.PodSecurityContext,
+					SecurityContext:               persistencePodSecurityContext(params.Config, params.OtelCol),
 					PriorityClassName:             params.OtelCol.Spec.PriorityClassName,
 					Affinity:                      params.OtelCol.Spec.Affinity,
 					TopologySpreadConstraints:     params.OtelCol.Spec.TopologySpreadConstraints,
@@ -12,8 +12,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 )
@@ -280,6 +282,62 @@ func TestStatefulSetPodSecurityContext(t *testing.T) {
 	assert.Equal(t, &runasGroup, d.Spec.Template.Spec.SecurityContext.RunAsGroup)
 }
 
+func TestStatefulSetPersistenceFSGroup(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		cfg                config.Config
+		podSecurityContext *corev1.PodSecurityContext
+		expected           *corev1.PodSecurityContext
+	}{
+		{
+			name:     "defaulted",
+			cfg:      config.New(),
+			expected: &corev1.PodSecurityContext{FSGroup: ptr.To[int64](10001)},
+		},
+		{
+			name:               "defaulted with the other settings",
+			cfg:                config.New(),
+			podSecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](1337)},
+			expected:           &corev1.PodSecurityContext{RunAsUser: ptr.To[int64](1337), FSGroup: ptr.To[int64](10001)},
+		},
+		{
+			name:               "set",
+			cfg:                config.New(),
+			podSecurityContext: &corev1.PodSecurityContext{FSGroup: ptr.To[int64](2000)},
+			expected:           &corev1.PodSecurityContext{FSGroup: ptr.To[int64](2000)},
+		},
+		{
+			name: "assigned by OpenShift",
+			cfg:  config.New(config.WithOpenShiftSCCAvailability(openshift.SCCAvailable)),
+			expected: &corev1.PodSecurityContext{
+				RunAsNonRoot:   ptr.To(true),
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-instance"},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:                      v1beta1.ModeStatefulSet,
+					OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{PodSecurityContext: tt.podSecurityContext},
+					Persistence:               &v1beta1.Persistence{Size: resource.MustParse("1Gi")},
+				},
+			}
+			var original *corev1.PodSecurityContext
+			if tt.podSecurityContext != nil {
+				original = tt.podSecurityContext.DeepCopy()
+			}
+
+			ss, err := StatefulSet(manifests.Params{OtelCol: otelcol, Config: tt.cfg, Log: testLogger})
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expected, ss.Spec.Template.Spec.SecurityContext)
+			assert.Equal(t, original, otelcol.Spec.PodSecurityContext, "the spec is left untouched")
+		})
+	}
+}
+
 func TestStatefulSetHostNetwork(t *testing.T) {
 	// Test default
 	otelcol1 := v1beta1.OpenTelemetryCollector{
@@ -52,10 +52,12 @@ func TargetAllocator(params manifests.Params) (*v1alpha1.TargetAllocator, error)
 				PodDisruptionBudget:       taSpec.PodDisruptionBudget,
 			},
 			AllocationStrategy:           taSpec.AllocationStrategy,
+			ConsistentHashing:            taSpec.ConsistentHashing,
 			FilterStrategy:               taSpec.FilterStrategy,
 			PrometheusCR:                 taSpec.PrometheusCR,
 			Observability:                taSpec.Observability,
 			CollectorNotReadyGracePeriod: taSpec.CollectorNotReadyGracePeriod,
+			CollectorDrainTimeout:        taSpec.CollectorDrainTimeout,
 		},
 	}, nil
 }
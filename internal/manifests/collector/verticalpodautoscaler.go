@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	vpav1 "github.com/open-telemetry/opentelemetry-operator/internal/vpa/v1"
+)
+
+// VerticalPodAutoscaler builds the VerticalPodAutoscaler recommending, and in the Auto update mode applying, the
+// resources of the collector container of the deployment or daemonset.
+func VerticalPodAutoscaler(params manifests.Params) (*vpav1.VerticalPodAutoscaler, error) {
+	if params.OtelCol.Spec.Autoscaler == nil || params.OtelCol.Spec.Autoscaler.VPA == nil {
+		return nil, nil
+	}
+	if params.Config.VPAAvailability != vpa.Available {
+		params.Log.V(4).Info("the VerticalPodAutoscaler API isn't available, skipping vertical autoscaler creation")
+		return nil, nil
+	}
+
+	var kind string
+	switch params.OtelCol.Spec.Mode {
+	case v1beta1.ModeDeployment:
+		kind = "Deployment"
+	case v1beta1.ModeDaemonSet:
+		kind = "DaemonSet"
+	default:
+		return nil, nil
+	}
+
+	name := naming.VerticalPodAutoscaler(params.OtelCol.Name)
+	labels := manifestutils.Labels(params.OtelCol.ObjectMeta, name, params.OtelCol.Spec.Image, ComponentOpenTelemetryCollector, params.Config.LabelsFilter)
+	annotations, err := manifestutils.Annotations(params.OtelCol, params.Config.AnnotationsFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := params.OtelCol.Spec.Autoscaler.VPA
+	updateMode := vpav1.UpdateModeOff
+	if spec.UpdateMode == v1beta1.VPAUpdateModeAuto {
+		updateMode = vpav1.UpdateModeAuto
+	}
+
+	return &vpav1.VerticalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   params.OtelCol.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: vpav1.VerticalPodAutoscalerSpec{
+			TargetRef: &autoscalingv1.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       naming.Collector(params.OtelCol.Name),
+			},
+			UpdatePolicy: &vpav1.PodUpdatePolicy{
+				UpdateMode: &updateMode,
+			},
+			ResourcePolicy: &vpav1.PodResourcePolicy{
+				ContainerPolicies: []vpav1.ContainerResourcePolicy{
+					{
+						ContainerName: naming.Container(),
+						MinAllowed:    spec.MinAllowed,
+						MaxAllowed:    spec.MaxAllowed,
+					},
+				},
+			},
+		},
+	}, nil
+}
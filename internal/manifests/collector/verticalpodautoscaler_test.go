@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	vpav1 "github.com/open-telemetry/opentelemetry-operator/internal/vpa/v1"
+)
+
+func TestVerticalPodAutoscaler(t *testing.T) {
+	minAllowed := corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")}
+	maxAllowed := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("2Gi")}
+	newParams := func(mode v1beta1.Mode, vpaSpec *v1beta1.VPASpec, avl vpa.Availability) manifests.Params {
+		return manifests.Params{
+			Config: config.New(config.WithVPAAvailability(avl)),
+			Log:    testLogger,
+			OtelCol: v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-instance",
+					Namespace: "observability",
+				},
+				Spec: v1beta1.OpenTelemetryCollectorSpec{
+					Mode:       mode,
+					Autoscaler: &v1beta1.AutoscalerSpec{VPA: vpaSpec},
+				},
+			},
+		}
+	}
+	autoVPA := &v1beta1.VPASpec{UpdateMode: v1beta1.VPAUpdateModeAuto, MinAllowed: minAllowed, MaxAllowed: maxAllowed}
+
+	for _, tt := range []struct {
+		name     string
+		params   manifests.Params
+		expected *vpav1.VerticalPodAutoscalerSpec
+	}{
+		{
+			name:   "vpa is not available",
+			params: newParams(v1beta1.ModeDeployment, autoVPA, vpa.NotAvailable),
+		},
+		{
+			name:   "no vpa",
+			params: newParams(v1beta1.ModeDeployment, nil, vpa.Available),
+		},
+		{
+			name:   "statefulset",
+			params: newParams(v1beta1.ModeStatefulSet, autoVPA, vpa.Available),
+		},
+		{
+			name:   "deployment",
+			params: newParams(v1beta1.ModeDeployment, autoVPA, vpa.Available),
+			expected: &vpav1.VerticalPodAutoscalerSpec{
+				TargetRef:    &autoscalingv1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "my-instance-collector"},
+				UpdatePolicy: &vpav1.PodUpdatePolicy{UpdateMode: ptr.To(vpav1.UpdateModeAuto)},
+				ResourcePolicy: &vpav1.PodResourcePolicy{
+					ContainerPolicies: []vpav1.ContainerResourcePolicy{
+						{ContainerName: "otc-container", MinAllowed: minAllowed, MaxAllowed: maxAllowed},
+					},
+				},
+			},
+		},
+		{
+			name:   "daemonset recommendations only",
+			params: newParams(v1beta1.ModeDaemonSet, &v1beta1.VPASpec{}, vpa.Available),
+			expected: &vpav1.VerticalPodAutoscalerSpec{
+				TargetRef:    &autoscalingv1.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "my-instance-collector"},
+				UpdatePolicy: &vpav1.PodUpdatePolicy{UpdateMode: ptr.To(vpav1.UpdateModeOff)},
+				ResourcePolicy: &vpav1.PodResourcePolicy{
+					ContainerPolicies: []vpav1.ContainerResourcePolicy{{ContainerName: "otc-container"}},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := VerticalPodAutoscaler(tt.params)
+			require.NoError(t, err)
+			if tt.expected == nil {
+				assert.Nil(t, actual)
+				return
+			}
+			require.NotNil(t, actual)
+			assert.Equal(t, "my-instance-collector", actual.Name)
+			assert.Equal(t, "observability", actual.Namespace)
+			assert.Equal(t, *tt.expected, actual.Spec)
+
+			// the autoscaler only configures the vpa, so no hpa is created
+			hpa, err := HorizontalPodAutoscaler(tt.params)
+			require.NoError(t, err)
+			assert.Nil(t, hpa)
+		})
+	}
+}
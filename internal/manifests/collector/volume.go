@@ -8,22 +8,19 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
-	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
-	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 // Volumes builds the volumes for the given instance, including the config map volume.
 func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []corev1.Volume {
-	hash, _ := manifestutils.GetConfigMapSHA(otelcol.Spec.Config)
-	configMapName := naming.ConfigMap(otelcol.Name, hash)
+	name, _ := configMapName(otelcol)
 	volumes := []corev1.Volume{{
 		Name: naming.ConfigMapVolume(),
 		VolumeSource: corev1.VolumeSource{
 			ConfigMap: &corev1.ConfigMapVolumeSource{
-				LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
 				Items: []corev1.KeyToPath{{
 					Key:  cfg.CollectorConfigMapEntry,
 					Path: cfg.CollectorConfigMapEntry,
@@ -32,7 +29,7 @@ func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []corev1
 		},
 	}}
 
-	if otelcol.Spec.TargetAllocator.Enabled && cfg.CertManagerAvailability == certmanager.Available && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
+	if otelcol.Spec.TargetAllocator.Enabled && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
 		volumes = append(volumes, corev1.Volume{
 			Name: naming.TAClientCertificate(otelcol.Name),
 			VolumeSource: corev1.VolumeSource{
@@ -47,6 +44,10 @@ func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []corev1
 		volumes = append(volumes, otelcol.Spec.Volumes...)
 	}
 
+	if otelcol.Spec.Mode == v1beta1.ModeDaemonSet {
+		volumes = append(volumes, presetVolumes(otelcol.Spec.Presets)...)
+	}
+
 	if len(otelcol.Spec.ConfigMaps) > 0 {
 		for keyCfgMap := range otelcol.Spec.ConfigMaps {
 			volumes = append(volumes, corev1.Volume{
@@ -64,3 +65,24 @@ func Volumes(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) []corev1
 
 	return volumes
 }
+
+// presetVolumes builds the host volumes of the enabled presets.
+func presetVolumes(presets v1beta1.Presets) []corev1.Volume {
+	hostPath := func(name, path string) corev1.Volume {
+		return corev1.Volume{
+			Name:         name,
+			VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: path}},
+		}
+	}
+	var volumes []corev1.Volume
+	if presets.HostMetrics.Enabled {
+		volumes = append(volumes, hostPath(naming.HostFSVolume(), "/"))
+	}
+	if presets.LogsCollection.Enabled {
+		volumes = append(volumes,
+			hostPath(naming.PodLogsVolume(), v1beta1.PresetPodLogsPath),
+			hostPath(naming.ContainerLogsVolume(), v1beta1.PresetContainerLogsPath),
+		)
+	}
+	return volumes
+}
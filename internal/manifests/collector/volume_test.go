@@ -95,6 +95,9 @@ func TestVolumeWithTargetAllocatorMTLS(t *testing.T) {
 
 		flgs := featuregate.Flags(colfg.GlobalRegistry())
 		err := flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+		t.Cleanup(func() {
+			_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+		})
 		otelcol.Spec.TargetAllocator.Enabled = true
 		require.NoError(t, err)
 
@@ -112,15 +115,31 @@ func TestVolumeWithTargetAllocatorMTLS(t *testing.T) {
 	})
 
 	t.Run("CertManager not available", func(t *testing.T) {
-		otelcol := v1beta1.OpenTelemetryCollector{}
+		otelcol := v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-collector",
+			},
+		}
+		otelcol.Spec.TargetAllocator.Enabled = true
 		cfg := config.New(config.WithCertManagerAvailability(certmanager.NotAvailable))
 
 		flgs := featuregate.Flags(colfg.GlobalRegistry())
 		err := flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+		t.Cleanup(func() {
+			_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+		})
 		require.NoError(t, err)
 
+		// the operator creates the certificate Secrets itself
 		volumes := Volumes(cfg, otelcol)
-		assert.NotContains(t, volumes, corev1.Volume{Name: naming.TAClientCertificate(otelcol.Name)})
+		assert.Contains(t, volumes, corev1.Volume{
+			Name: naming.TAClientCertificate(otelcol.Name),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: naming.TAClientCertificateSecretName(otelcol.Name),
+				},
+			},
+		})
 	})
 
 	t.Run("EnableTargetAllocatorMTLS disabled", func(t *testing.T) {
@@ -137,6 +156,9 @@ func TestVolumeWithTargetAllocatorMTLS(t *testing.T) {
 
 		flgs := featuregate.Flags(colfg.GlobalRegistry())
 		err := flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+		t.Cleanup(func() {
+			_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+		})
 
 		require.NoError(t, err)
 
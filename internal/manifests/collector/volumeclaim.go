@@ -6,10 +6,19 @@ package collector
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 )
 
+// collectorGroupID is the group of the user of the collector images.
+const collectorGroupID = 10001
+
 // VolumeClaimTemplates builds the volumeClaimTemplates for the given instance,
 // including the config map volume mount.
 func VolumeClaimTemplates(otelcol v1beta1.OpenTelemetryCollector) []corev1.PersistentVolumeClaim {
@@ -17,6 +26,42 @@ func VolumeClaimTemplates(otelcol v1beta1.OpenTelemetryCollector) []corev1.Persi
 		return []corev1.PersistentVolumeClaim{}
 	}
 
-	// Add all user specified claims.
-	return otelcol.Spec.VolumeClaimTemplates
+	if otelcol.Spec.Persistence == nil {
+		// Add all user specified claims.
+		return otelcol.Spec.VolumeClaimTemplates
+	}
+
+	// Add all user specified claims, then the one of the persistence.
+	volumeClaims := make([]corev1.PersistentVolumeClaim, 0, len(otelcol.Spec.VolumeClaimTemplates)+1)
+	volumeClaims = append(volumeClaims, otelcol.Spec.VolumeClaimTemplates...)
+	return append(volumeClaims, corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: naming.PersistenceVolume(),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: otelcol.Spec.Persistence.Size},
+			},
+			StorageClassName: otelcol.Spec.Persistence.StorageClassName,
+		},
+	})
+}
+
+// persistencePodSecurityContext returns the security context of the collector pods, whose fsGroup defaults to the group
+// of the collector images when they mount the persistent volume, so that the collector can write to it. The fsGroup
+// set in the spec is kept, and OpenShift assigns one itself.
+func persistencePodSecurityContext(cfg config.Config, otelcol v1beta1.OpenTelemetryCollector) *corev1.PodSecurityContext {
+	podSecurityContext := manifestutils.PodSecurityContext(cfg, otelcol.Spec.PodSecurityContext)
+	if otelcol.Spec.Persistence == nil || cfg.OpenShiftSCCAvailability == openshift.SCCAvailable ||
+		(podSecurityContext != nil && podSecurityContext.FSGroup != nil) {
+		return podSecurityContext
+	}
+	if podSecurityContext == nil {
+		podSecurityContext = &corev1.PodSecurityContext{}
+	} else {
+		podSecurityContext = podSecurityContext.DeepCopy()
+	}
+	podSecurityContext.FSGroup = ptr.To[int64](collectorGroupID)
+	return podSecurityContext
 }
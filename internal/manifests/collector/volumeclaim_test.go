@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	. "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
@@ -79,3 +80,37 @@ func TestVolumeClaimChecksForStatefulset(t *testing.T) {
 	// verify that volume claim replaces
 	assert.Len(t, volumeClaims, 0)
 }
+
+func TestVolumeClaimPersistence(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: "statefulset",
+			StatefulSetCommonFields: v1beta1.StatefulSetCommonFields{
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "added-volume",
+					},
+				}},
+			},
+			Persistence: &v1beta1.Persistence{
+				Size:             resource.MustParse("5Gi"),
+				StorageClassName: ptr.To("fast"),
+			},
+		},
+	}
+
+	// test
+	volumeClaims := VolumeClaimTemplates(otelcol)
+
+	// verify that the persistence claim comes after the user specified ones
+	assert.Len(t, volumeClaims, 2)
+	assert.Equal(t, "added-volume", volumeClaims[0].Name)
+	assert.Equal(t, "otc-persistence", volumeClaims[1].Name)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, volumeClaims[1].Spec.AccessModes)
+	assert.Equal(t, resource.MustParse("5Gi"), volumeClaims[1].Spec.Resources.Requests["storage"])
+	assert.Equal(t, ptr.To("fast"), volumeClaims[1].Spec.StorageClassName)
+
+	// the user specified claims are left untouched
+	assert.Len(t, otelcol.Spec.VolumeClaimTemplates, 1)
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+)
+
+// nodeOSSelector returns the given node selector restricted to the nodes of the given operating system.
+func nodeOSSelector(selector map[string]string, os corev1.OSName) map[string]string {
+	restricted := maps.Clone(selector)
+	if restricted == nil {
+		restricted = map[string]string{}
+	}
+	restricted[corev1.LabelOSStable] = string(os)
+	return restricted
+}
+
+// NodeOSLabel marks the pods of the daemonsets of the collectors running on the nodes of the given operating systems.
+const NodeOSLabel = "opentelemetry.io/node-os"
+
+// selectNodeOS adds the operating system of the nodes to the selector and the pod labels of the given daemonset, so
+// that the Linux and Windows daemonsets of a collector don't claim the pods of each other.
+func selectNodeOS(ds *appsv1.DaemonSet, os corev1.OSName) {
+	ds.Spec.Selector.MatchLabels = maps.Clone(ds.Spec.Selector.MatchLabels)
+	ds.Spec.Selector.MatchLabels[NodeOSLabel] = string(os)
+	ds.Spec.Template.Labels = maps.Clone(ds.Spec.Template.Labels)
+	ds.Spec.Template.Labels[NodeOSLabel] = string(os)
+}
+
+// windowsPodSpec adapts the pod spec of the collector to the Windows nodes. The Linux options of the security
+// contexts, which would make the API server reject the pods, are left out and the host paths are moved to their
+// Windows paths.
+func windowsPodSpec(otelcol v1beta1.OpenTelemetryCollector, spec *corev1.PodSpec) {
+	windows := otelcol.Spec.Windows
+	if windows == nil {
+		windows = &v1beta1.WindowsSpec{}
+	}
+
+	spec.OS = &corev1.PodOS{Name: corev1.Windows}
+	spec.NodeSelector = nodeOSSelector(spec.NodeSelector, corev1.Windows)
+	spec.ShareProcessNamespace = ptr.To(false)
+	spec.SecurityContext = windowsPodSecurityContext(spec.SecurityContext, windows.SecurityContext)
+	spec.InitContainers = windowsContainers(spec.InitContainers)
+	spec.Containers = windowsContainers(spec.Containers)
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == naming.Container() && windows.Image != "" {
+			spec.Containers[i].Image = windows.Image
+		}
+	}
+
+	spec.Volumes = slices.Clone(spec.Volumes)
+	for i, volume := range spec.Volumes {
+		if volume.HostPath == nil {
+			continue
+		}
+		spec.Volumes[i].HostPath = &corev1.HostPathVolumeSource{
+			Path: windowsHostPath(volume.HostPath.Path, windows.HostPaths),
+			Type: volume.HostPath.Type,
+		}
+	}
+}
+
+func windowsPodSecurityContext(sc *corev1.PodSecurityContext, options *corev1.WindowsSecurityContextOptions) *corev1.PodSecurityContext {
+	if sc == nil && options == nil {
+		return nil
+	}
+	windows := &corev1.PodSecurityContext{WindowsOptions: options}
+	if sc != nil {
+		windows.RunAsNonRoot = sc.RunAsNonRoot
+		if options == nil {
+			windows.WindowsOptions = sc.WindowsOptions
+		}
+	}
+	return windows
+}
+
+func windowsContainers(containers []corev1.Container) []corev1.Container {
+	containers = slices.Clone(containers)
+	for i, container := range containers {
+		sc := container.SecurityContext
+		if sc == nil {
+			continue
+		}
+		if sc.RunAsNonRoot == nil && sc.WindowsOptions == nil {
+			containers[i].SecurityContext = nil
+			continue
+		}
+		containers[i].SecurityContext = &corev1.SecurityContext{
+			RunAsNonRoot:   sc.RunAsNonRoot,
+			WindowsOptions: sc.WindowsOptions,
+		}
+	}
+	return containers
+}
+
+// windowsHostPath returns the path on the Windows nodes of the given host path, using the longest mapped path it is
+// or is below, or else the same path on the C: drive.
+func windowsHostPath(hostPath string, mapping map[string]string) string {
+	found := false
+	var from, to string
+	for linux, windows := range mapping {
+		prefix := strings.TrimSuffix(linux, "/")
+		if hostPath != linux && hostPath != prefix && !strings.HasPrefix(hostPath, prefix+"/") {
+			continue
+		}
+		if !found || len(prefix) > len(from) {
+			found, from, to = true, prefix, windows
+		}
+	}
+	if found {
+		rest := strings.TrimPrefix(hostPath, from)
+		if rest == "" || rest == "/" {
+			return to
+		}
+		return strings.TrimRight(to, `\/`) + strings.ReplaceAll(rest, "/", `\`)
+	}
+	// already a Windows path
+	if !strings.HasPrefix(hostPath, "/") {
+		return hostPath
+	}
+	return `C:` + strings.ReplaceAll(hostPath, "/", `\`)
+}
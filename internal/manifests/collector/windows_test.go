@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func windowsParams(nodeOS ...v1beta1.NodeOS) manifests.Params {
+	return manifests.Params{
+		Config: config.New(),
+		OtelCol: v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-namespace",
+			},
+			Spec: v1beta1.OpenTelemetryCollectorSpec{
+				Mode:   v1beta1.ModeDaemonSet,
+				NodeOS: nodeOS,
+				OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+					NodeSelector: map[string]string{"pool": "logs"},
+					PodSecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot: ptr.To(true),
+						RunAsUser:    ptr.To[int64](10001),
+						FSGroup:      ptr.To[int64](10001),
+					},
+					SecurityContext: &corev1.SecurityContext{
+						ReadOnlyRootFilesystem: ptr.To(true),
+						Capabilities:           &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+					},
+					InitContainers: []corev1.Container{{
+						Name:            "init",
+						SecurityContext: &corev1.SecurityContext{RunAsNonRoot: ptr.To(true), RunAsUser: ptr.To[int64](1)},
+					}},
+					Volumes: []corev1.Volume{
+						{Name: "pods", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/log/pods"}}},
+						{Name: "docker", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/lib/docker/containers"}}},
+						{Name: "cache", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+					},
+				},
+			},
+		},
+		Log: testLogger,
+	}
+}
+
+func TestDaemonSetNodeOS(t *testing.T) {
+	// the default
+	d, err := DaemonSet(windowsParams())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pool": "logs"}, d.Spec.Template.Spec.NodeSelector)
+	assert.NotContains(t, d.Spec.Selector.MatchLabels, NodeOSLabel)
+	w, err := WindowsDaemonSet(windowsParams())
+	require.NoError(t, err)
+	assert.Nil(t, w)
+
+	// the linux and windows nodes
+	params := windowsParams(v1beta1.NodeOSLinux, v1beta1.NodeOSWindows)
+	d, err = DaemonSet(params)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pool": "logs", "kubernetes.io/os": "linux"}, d.Spec.Template.Spec.NodeSelector)
+	assert.Nil(t, d.Spec.Template.Spec.OS)
+	assert.Equal(t, map[string]string{"pool": "logs"}, params.OtelCol.Spec.NodeSelector)
+	w, err = WindowsDaemonSet(params)
+	require.NoError(t, err)
+	assert.Equal(t, "my-instance-collector-windows", w.Name)
+	// the selectors of the daemonsets don't overlap
+	assert.Equal(t, "linux", d.Spec.Selector.MatchLabels[NodeOSLabel])
+	assert.Equal(t, "linux", d.Spec.Template.Labels[NodeOSLabel])
+	assert.NotContains(t, d.Labels, NodeOSLabel)
+	assert.Equal(t, "windows", w.Spec.Selector.MatchLabels[NodeOSLabel])
+	assert.Equal(t, "windows", w.Spec.Template.Labels[NodeOSLabel])
+
+	// the windows nodes only
+	d, err = DaemonSet(windowsParams(v1beta1.NodeOSWindows))
+	require.NoError(t, err)
+	assert.Nil(t, d)
+}
+
+func TestWindowsDaemonSet(t *testing.T) {
+	params := windowsParams(v1beta1.NodeOSWindows)
+	params.OtelCol.Spec.Windows = &v1beta1.WindowsSpec{
+		Image:           "collector:windows",
+		HostPaths:       map[string]string{"/var/lib/docker": `C:\ProgramData\docker`},
+		SecurityContext: &corev1.WindowsSecurityContextOptions{RunAsUserName: ptr.To("ContainerAdministrator")},
+	}
+
+	d, err := WindowsDaemonSet(params)
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-instance-collector-windows", d.Name)
+	assert.Equal(t, "my-instance-collector-windows", d.Labels["app.kubernetes.io/name"])
+	assert.Equal(t, "opentelemetry-collector", d.Spec.Selector.MatchLabels["app.kubernetes.io/component"])
+
+	pod := d.Spec.Template.Spec
+	assert.Equal(t, &corev1.PodOS{Name: corev1.Windows}, pod.OS)
+	assert.Equal(t, map[string]string{"pool": "logs", "kubernetes.io/os": "windows"}, pod.NodeSelector)
+	assert.Equal(t, ptr.To(false), pod.ShareProcessNamespace)
+	assert.Equal(t, &corev1.PodSecurityContext{
+		RunAsNonRoot:   ptr.To(true),
+		WindowsOptions: &corev1.WindowsSecurityContextOptions{RunAsUserName: ptr.To("ContainerAdministrator")},
+	}, pod.SecurityContext)
+	require.Len(t, pod.Containers, 1)
+	assert.Equal(t, "collector:windows", pod.Containers[0].Image)
+	assert.Nil(t, pod.Containers[0].SecurityContext)
+	require.Len(t, pod.InitContainers, 1)
+	assert.Equal(t, &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)}, pod.InitContainers[0].SecurityContext)
+
+	hostPaths := map[string]string{}
+	for _, volume := range pod.Volumes {
+		if volume.HostPath != nil {
+			hostPaths[volume.Name] = volume.HostPath.Path
+		}
+	}
+	assert.Equal(t, map[string]string{"pods": `C:\var\log\pods`, "docker": `C:\ProgramData\docker\containers`}, hostPaths)
+
+	// the spec is left untouched
+	assert.Equal(t, "/var/log/pods", params.OtelCol.Spec.Volumes[0].HostPath.Path)
+	assert.Equal(t, ptr.To[int64](1), params.OtelCol.Spec.InitContainers[0].SecurityContext.RunAsUser)
+}
+
+func TestWindowsHostPath(t *testing.T) {
+	mapping := map[string]string{
+		"/var/log":                   `D:\logs\`,
+		"/var/log/containers/":       `E:\containers`,
+		"/var/lib/docker/containers": `C:\ProgramData\docker\containers`,
+	}
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/var/log", expected: `D:\logs\`},
+		{path: "/var/log/pods", expected: `D:\logs\pods`},
+		{path: "/var/log/containers", expected: `E:\containers`},
+		{path: "/var/log/containers/app.log", expected: `E:\containers\app.log`},
+		{path: "/var/lib/docker/containers", expected: `C:\ProgramData\docker\containers`},
+		{path: "/var/logs", expected: `C:\var\logs`},
+		{path: "/etc/machine-id", expected: `C:\etc\machine-id`},
+		{path: `C:\ProgramData\containerd`, expected: `C:\ProgramData\containerd`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, windowsHostPath(tt.path, mapping))
+		})
+	}
+}
@@ -11,6 +11,13 @@ import (
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 )
 
+// ConfigHashAnnotation is the pod annotation holding the hash of the collector configuration.
+const ConfigHashAnnotation = "opentelemetry-operator-config/sha256"
+
+// JobSpecHashAnnotation is the pod template annotation holding the hash of the spec of a collector Job, whose pod
+// template can't change: the Job is replaced when its hash changes.
+const JobSpecHashAnnotation = "opentelemetry-operator-job/sha256"
+
 // Annotations return the annotations for OpenTelemetryCollector resources.
 func Annotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations []string) (map[string]string, error) {
 	// new map every time, so that we don't touch the instance's annotations
@@ -66,6 +73,11 @@ func PodAnnotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations [
 		}
 	}
 
+	// the collectors reloading their configuration keep running when it changes
+	if instance.Spec.ReloadsConfig() {
+		return podAnnotations, nil
+	}
+
 	// make sure sha256 for configMap is always calculated
 	hash, err := GetConfigMapSHA(instance.Spec.Config)
 	if err != nil {
@@ -73,7 +85,7 @@ func PodAnnotations(instance v1beta1.OpenTelemetryCollector, filterAnnotations [
 	}
 
 	// Adding the ConfigMap Hash only to PodAnnotations
-	podAnnotations["opentelemetry-operator-config/sha256"] = hash
+	podAnnotations[ConfigHashAnnotation] = hash
 
 	return podAnnotations, nil
 }
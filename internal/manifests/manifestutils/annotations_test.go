@@ -40,6 +40,28 @@ func TestDefaultAnnotations(t *testing.T) {
 	assert.Equal(t, "5b3b62aa5e0a3c7250084c2b49190e30b72fc2ad352ffbaa699224e1aa900834", podAnnotations["opentelemetry-operator-config/sha256"])
 }
 
+func TestPodAnnotationsReloadStrategy(t *testing.T) {
+	// prepare
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-instance",
+			Namespace: "my-ns",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode:    v1beta1.ModeDeployment,
+			Rollout: &v1beta1.Rollout{Strategy: v1beta1.RolloutStrategyReload},
+		},
+	}
+
+	// test
+	podAnnotations, err := PodAnnotations(otelcol, []string{})
+	require.NoError(t, err)
+
+	// verify the pods aren't rolled out when the configuration changes
+	assert.NotContains(t, podAnnotations, ConfigHashAnnotation)
+	assert.Equal(t, "true", podAnnotations["prometheus.io/scrape"])
+}
+
 func TestNonDefaultPodAnnotation(t *testing.T) {
 	// prepare
 	otelcol := v1beta1.OpenTelemetryCollector{
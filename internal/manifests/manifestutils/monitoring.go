@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestutils
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// RelabelConfigs converts the relabelings of the metrics config to the ones of the Prometheus Operator.
+func RelabelConfigs(relabelings []v1beta1.RelabelConfig) []monitoringv1.RelabelConfig {
+	if relabelings == nil {
+		return nil
+	}
+	configs := make([]monitoringv1.RelabelConfig, 0, len(relabelings))
+	for _, relabeling := range relabelings {
+		relabeling := relabeling.DeepCopy()
+		config := monitoringv1.RelabelConfig{
+			Separator:   relabeling.Separator,
+			TargetLabel: relabeling.TargetLabel,
+			Regex:       relabeling.Regex,
+			Modulus:     relabeling.Modulus,
+			Replacement: relabeling.Replacement,
+			Action:      relabeling.Action,
+		}
+		for _, label := range relabeling.SourceLabels {
+			config.SourceLabels = append(config.SourceLabels, monitoringv1.LabelName(label))
+		}
+		configs = append(configs, config)
+	}
+	return configs
+}
+
+// SafeTLSConfig converts the TLS config of the metrics config to the one of the Prometheus Operator.
+func SafeTLSConfig(tlsConfig *v1beta1.SafeTLSConfig) *monitoringv1.SafeTLSConfig {
+	if tlsConfig == nil {
+		return nil
+	}
+	tlsConfig = tlsConfig.DeepCopy()
+	return &monitoringv1.SafeTLSConfig{
+		CA:                 monitoringv1.SecretOrConfigMap{Secret: tlsConfig.CA.Secret, ConfigMap: tlsConfig.CA.ConfigMap},
+		Cert:               monitoringv1.SecretOrConfigMap{Secret: tlsConfig.Cert.Secret, ConfigMap: tlsConfig.Cert.ConfigMap},
+		KeySecret:          tlsConfig.KeySecret,
+		ServerName:         tlsConfig.ServerName,
+		InsecureSkipVerify: tlsConfig.InsecureSkipVerify,
+		MinVersion:         (*monitoringv1.TLSVersion)(tlsConfig.MinVersion),
+		MaxVersion:         (*monitoringv1.TLSVersion)(tlsConfig.MaxVersion),
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestutils
+
+import (
+	"testing"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestRelabelConfigs(t *testing.T) {
+	assert.Nil(t, RelabelConfigs(nil))
+	assert.Equal(t, []monitoringv1.RelabelConfig{
+		{
+			SourceLabels: []monitoringv1.LabelName{"namespace", "pod"},
+			Separator:    ptr.To("/"),
+			TargetLabel:  "workload",
+			Regex:        "(.*)",
+			Replacement:  ptr.To("$1"),
+			Action:       "replace",
+		},
+		{Modulus: 4, Action: "hashmod"},
+	}, RelabelConfigs([]v1beta1.RelabelConfig{
+		{
+			SourceLabels: []v1beta1.LabelName{"namespace", "pod"},
+			Separator:    ptr.To("/"),
+			TargetLabel:  "workload",
+			Regex:        "(.*)",
+			Replacement:  ptr.To("$1"),
+			Action:       "replace",
+		},
+		{Modulus: 4, Action: "hashmod"},
+	}))
+}
+
+func TestSafeTLSConfig(t *testing.T) {
+	ca := &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}, Key: "ca.crt"}
+	cert := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "client"}, Key: "tls.crt"}
+	key := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "client"}, Key: "tls.key"}
+
+	assert.Nil(t, SafeTLSConfig(nil))
+	assert.Equal(t, &monitoringv1.SafeTLSConfig{
+		CA:                 monitoringv1.SecretOrConfigMap{ConfigMap: ca},
+		Cert:               monitoringv1.SecretOrConfigMap{Secret: cert},
+		KeySecret:          key,
+		ServerName:         ptr.To("collector"),
+		InsecureSkipVerify: ptr.To(false),
+		MinVersion:         ptr.To(monitoringv1.TLSVersion12),
+	}, SafeTLSConfig(&v1beta1.SafeTLSConfig{
+		CA:                 v1beta1.SecretOrConfigMap{ConfigMap: ca},
+		Cert:               v1beta1.SecretOrConfigMap{Secret: cert},
+		KeySecret:          key,
+		ServerName:         ptr.To("collector"),
+		InsecureSkipVerify: ptr.To(false),
+		MinVersion:         ptr.To[v1beta1.TLSVersion]("TLS12"),
+	}))
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestutils
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// SecurityContext returns the given container security context. When none is given and the OpenShift
+// SecurityContextConstraints are available, it defaults to one compatible with the restricted-v2 SCC.
+func SecurityContext(cfg config.Config, securityContext *corev1.SecurityContext) *corev1.SecurityContext {
+	if securityContext != nil || cfg.OpenShiftSCCAvailability != openshift.SCCAvailable {
+		return securityContext
+	}
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		RunAsNonRoot:             ptr.To(true),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
+// PodSecurityContext returns the given pod security context. When none is given and the OpenShift
+// SecurityContextConstraints are available, it defaults to one compatible with the restricted-v2 SCC.
+// The user and group IDs are left for OpenShift to assign from the namespace range.
+func PodSecurityContext(cfg config.Config, podSecurityContext *corev1.PodSecurityContext) *corev1.PodSecurityContext {
+	if podSecurityContext != nil || cfg.OpenShiftSCCAvailability != openshift.SCCAvailable {
+		return podSecurityContext
+	}
+	return &corev1.PodSecurityContext{
+		RunAsNonRoot: ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestSecurityContext(t *testing.T) {
+	openShiftCfg := config.New(config.WithOpenShiftSCCAvailability(openshift.SCCAvailable))
+	userSecurityContext := &corev1.SecurityContext{RunAsUser: ptr.To(int64(0))}
+
+	// no defaults outside OpenShift
+	assert.Nil(t, SecurityContext(config.New(), nil))
+
+	// user defined security contexts are kept as is
+	assert.Equal(t, userSecurityContext, SecurityContext(openShiftCfg, userSecurityContext))
+
+	assert.Equal(t, &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		RunAsNonRoot:             ptr.To(true),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}, SecurityContext(openShiftCfg, nil))
+}
+
+func TestPodSecurityContext(t *testing.T) {
+	openShiftCfg := config.New(config.WithOpenShiftSCCAvailability(openshift.SCCAvailable))
+	userPodSecurityContext := &corev1.PodSecurityContext{FSGroup: ptr.To(int64(1000))}
+
+	assert.Nil(t, PodSecurityContext(config.New(), nil))
+	assert.Equal(t, userPodSecurityContext, PodSecurityContext(openShiftCfg, userPodSecurityContext))
+	assert.Equal(t, &corev1.PodSecurityContext{
+		RunAsNonRoot: ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}, PodSecurityContext(openShiftCfg, nil))
+}
@@ -13,6 +13,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	policyV1 "k8s.io/api/policy/v1"
@@ -20,8 +21,12 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	vpav1 "github.com/open-telemetry/opentelemetry-operator/internal/vpa/v1"
 )
 
 type ImmutableFieldChangeErr struct {
@@ -49,10 +54,18 @@ var (
 // - Deployment
 // - DaemonSet
 // - StatefulSet
+// - Job
+// - CronJob
 // - ServiceMonitor
+// - PodMonitor
+// - PrometheusRule
 // - Ingress
 // - HorizontalPodAutoscaler
+// - ScaledObject
+// - VerticalPodAutoscaler
 // - Route
+// - HTTPRoute
+// - GRPCRoute
 // - Secret
 // - TargetAllocator
 // In order for the operator to reconcile other types, they must be added here.
@@ -131,6 +144,16 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantSts := desired.(*appsv1.StatefulSet)
 			return mutateStatefulSet(sts, wantSts)
 
+		case *batchv1.Job:
+			job := existing.(*batchv1.Job)
+			wantJob := desired.(*batchv1.Job)
+			return mutateJob(job, wantJob)
+
+		case *batchv1.CronJob:
+			cronJob := existing.(*batchv1.CronJob)
+			wantCronJob := desired.(*batchv1.CronJob)
+			mutateCronJob(cronJob, wantCronJob)
+
 		case *monitoringv1.ServiceMonitor:
 			svcMonitor := existing.(*monitoringv1.ServiceMonitor)
 			wantSvcMonitor := desired.(*monitoringv1.ServiceMonitor)
@@ -141,16 +164,36 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantPodMonitor := desired.(*monitoringv1.PodMonitor)
 			mutatePodMonitor(podMonitor, wantPodMonitor)
 
+		case *monitoringv1.PrometheusRule:
+			rule := existing.(*monitoringv1.PrometheusRule)
+			wantRule := desired.(*monitoringv1.PrometheusRule)
+			mutatePrometheusRule(rule, wantRule)
+
 		case *networkingv1.Ingress:
 			ing := existing.(*networkingv1.Ingress)
 			wantIng := desired.(*networkingv1.Ingress)
 			mutateIngress(ing, wantIng)
 
+		case *networkingv1.NetworkPolicy:
+			np := existing.(*networkingv1.NetworkPolicy)
+			wantNp := desired.(*networkingv1.NetworkPolicy)
+			mutateNetworkPolicy(np, wantNp)
+
 		case *autoscalingv2.HorizontalPodAutoscaler:
 			existingHPA := existing.(*autoscalingv2.HorizontalPodAutoscaler)
 			desiredHPA := desired.(*autoscalingv2.HorizontalPodAutoscaler)
 			mutateAutoscalingHPA(existingHPA, desiredHPA)
 
+		case *vpav1.VerticalPodAutoscaler:
+			vpa := existing.(*vpav1.VerticalPodAutoscaler)
+			wantVpa := desired.(*vpav1.VerticalPodAutoscaler)
+			mutateVerticalPodAutoscaler(vpa, wantVpa)
+
+		case *kedav1alpha1.ScaledObject:
+			so := existing.(*kedav1alpha1.ScaledObject)
+			wantSo := desired.(*kedav1alpha1.ScaledObject)
+			mutateScaledObject(so, wantSo)
+
 		case *policyV1.PodDisruptionBudget:
 			existingPDB := existing.(*policyV1.PodDisruptionBudget)
 			desiredPDB := desired.(*policyV1.PodDisruptionBudget)
@@ -161,6 +204,16 @@ func MutateFuncFor(existing, desired client.Object) controllerutil.MutateFn {
 			wantRt := desired.(*routev1.Route)
 			mutateRoute(rt, wantRt)
 
+		case *gatewayv1.HTTPRoute:
+			rt := existing.(*gatewayv1.HTTPRoute)
+			wantRt := desired.(*gatewayv1.HTTPRoute)
+			mutateHTTPRoute(rt, wantRt)
+
+		case *gatewayv1.GRPCRoute:
+			rt := existing.(*gatewayv1.GRPCRoute)
+			wantRt := desired.(*gatewayv1.GRPCRoute)
+			mutateGRPCRoute(rt, wantRt)
+
 		case *corev1.Secret:
 			pr := existing.(*corev1.Secret)
 			wantPr := desired.(*corev1.Secret)
@@ -244,6 +297,12 @@ func mutatePolicyV1PDB(existing, desired *policyV1.PodDisruptionBudget) {
 	existing.Spec = desired.Spec
 }
 
+func mutateNetworkPolicy(existing, desired *networkingv1.NetworkPolicy) {
+	existing.Labels = desired.Labels
+	existing.Annotations = desired.Annotations
+	existing.Spec = desired.Spec
+}
+
 func mutateIngress(existing, desired *networkingv1.Ingress) {
 	existing.Labels = desired.Labels
 	existing.Annotations = desired.Annotations
@@ -258,6 +317,30 @@ func mutateRoute(existing, desired *routev1.Route) {
 	existing.Spec = desired.Spec
 }
 
+func mutateHTTPRoute(existing, desired *gatewayv1.HTTPRoute) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
+func mutateGRPCRoute(existing, desired *gatewayv1.GRPCRoute) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
+func mutateVerticalPodAutoscaler(existing, desired *vpav1.VerticalPodAutoscaler) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
+func mutateScaledObject(existing, desired *kedav1alpha1.ScaledObject) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutateServiceMonitor(existing, desired *monitoringv1.ServiceMonitor) {
 	existing.Annotations = desired.Annotations
 	existing.Labels = desired.Labels
@@ -270,6 +353,12 @@ func mutatePodMonitor(existing, desired *monitoringv1.PodMonitor) {
 	existing.Spec = desired.Spec
 }
 
+func mutatePrometheusRule(existing, desired *monitoringv1.PrometheusRule) {
+	existing.Annotations = desired.Annotations
+	existing.Labels = desired.Labels
+	existing.Spec = desired.Spec
+}
+
 func mutateTargetAllocator(existing, desired *v1alpha1.TargetAllocator) {
 	existing.Annotations = desired.Annotations
 	existing.Labels = desired.Labels
@@ -279,6 +368,32 @@ func mutateTargetAllocator(existing, desired *v1alpha1.TargetAllocator) {
 func mutateService(existing, desired *corev1.Service) {
 	existing.Spec.Ports = desired.Spec.Ports
 	existing.Spec.Selector = desired.Spec.Selector
+	// the type is defaulted by the API server, only the additional services of the collector set it
+	if desired.Spec.Type != "" {
+		existing.Spec.Type = desired.Spec.Type
+	}
+	existing.Spec.LoadBalancerSourceRanges = desired.Spec.LoadBalancerSourceRanges
+	// the internal traffic policy is defaulted by the API server, only some services of the collector set it
+	if desired.Spec.InternalTrafficPolicy != nil {
+		existing.Spec.InternalTrafficPolicy = desired.Spec.InternalTrafficPolicy
+	}
+	existing.Spec.TrafficDistribution = desired.Spec.TrafficDistribution
+	// the IP families are defaulted by the API server from the policy, and only the primary family is kept when the
+	// policy becomes single-stack
+	if len(desired.Spec.IPFamilies) > 0 {
+		existing.Spec.IPFamilies = desired.Spec.IPFamilies
+	}
+	if desired.Spec.IPFamilyPolicy != nil {
+		existing.Spec.IPFamilyPolicy = desired.Spec.IPFamilyPolicy
+	}
+	if existing.Spec.IPFamilyPolicy != nil && *existing.Spec.IPFamilyPolicy == corev1.IPFamilyPolicySingleStack {
+		if len(existing.Spec.IPFamilies) > 1 {
+			existing.Spec.IPFamilies = existing.Spec.IPFamilies[:1]
+		}
+		if len(existing.Spec.ClusterIPs) > 1 {
+			existing.Spec.ClusterIPs = existing.Spec.ClusterIPs[:1]
+		}
+	}
 }
 
 func mutateDaemonset(existing, desired *appsv1.DaemonSet) error {
@@ -361,6 +476,26 @@ func mutateStatefulSet(existing, desired *appsv1.StatefulSet) error {
 	return nil
 }
 
+func mutateJob(existing, desired *batchv1.Job) error {
+	// the pod template of a Job can't change, the Job is replaced to run the new one
+	if !existing.CreationTimestamp.IsZero() &&
+		existing.Spec.Template.Annotations[manifestutils.JobSpecHashAnnotation] != desired.Spec.Template.Annotations[manifestutils.JobSpecHashAnnotation] {
+		return &ImmutableFieldChangeErr{Field: "Spec.Template"}
+	}
+	return nil
+}
+
+func mutateCronJob(existing, desired *batchv1.CronJob) {
+	existing.Spec.Schedule = desired.Spec.Schedule
+	existing.Spec.TimeZone = desired.Spec.TimeZone
+	existing.Spec.ConcurrencyPolicy = desired.Spec.ConcurrencyPolicy
+	existing.Spec.Suspend = desired.Spec.Suspend
+	existing.Spec.StartingDeadlineSeconds = desired.Spec.StartingDeadlineSeconds
+	existing.Spec.SuccessfulJobsHistoryLimit = desired.Spec.SuccessfulJobsHistoryLimit
+	existing.Spec.FailedJobsHistoryLimit = desired.Spec.FailedJobsHistoryLimit
+	existing.Spec.JobTemplate = desired.Spec.JobTemplate
+}
+
 func mutateCertificate(existing, desired *cmv1.Certificate) {
 	existing.Annotations = desired.Annotations
 	existing.Labels = desired.Labels
@@ -9,8 +9,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestMutateServiceAccount(t *testing.T) {
@@ -39,6 +41,63 @@ func TestMutateServiceAccount(t *testing.T) {
 	}, existing)
 }
 
+func TestMutateServiceIPFamilies(t *testing.T) {
+	dualStack := func() corev1.Service {
+		return corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "collector"},
+			Spec: corev1.ServiceSpec{
+				ClusterIP:      "10.0.0.1",
+				ClusterIPs:     []string{"10.0.0.1", "fd00::1"},
+				IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+				IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicyPreferDualStack),
+			},
+		}
+	}
+	for _, tt := range []struct {
+		name               string
+		desired            corev1.ServiceSpec
+		expectedFamilies   []corev1.IPFamily
+		expectedClusterIPs []string
+		expectedPolicy     corev1.IPFamilyPolicy
+	}{
+		{
+			name:               "unset",
+			expectedFamilies:   []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+			expectedClusterIPs: []string{"10.0.0.1", "fd00::1"},
+			expectedPolicy:     corev1.IPFamilyPolicyPreferDualStack,
+		},
+		{
+			name:               "single-stack policy",
+			desired:            corev1.ServiceSpec{IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicySingleStack)},
+			expectedFamilies:   []corev1.IPFamily{corev1.IPv4Protocol},
+			expectedClusterIPs: []string{"10.0.0.1"},
+			expectedPolicy:     corev1.IPFamilyPolicySingleStack,
+		},
+		{
+			name: "families set",
+			desired: corev1.ServiceSpec{
+				IPFamilies:     []corev1.IPFamily{corev1.IPv4Protocol},
+				IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicySingleStack),
+			},
+			expectedFamilies:   []corev1.IPFamily{corev1.IPv4Protocol},
+			expectedClusterIPs: []string{"10.0.0.1"},
+			expectedPolicy:     corev1.IPFamilyPolicySingleStack,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := dualStack()
+			desired := corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "collector"}, Spec: tt.desired}
+
+			require.NoError(t, MutateFuncFor(&existing, &desired)())
+
+			assert.Equal(t, tt.expectedFamilies, existing.Spec.IPFamilies)
+			assert.Equal(t, tt.expectedClusterIPs, existing.Spec.ClusterIPs)
+			assert.Equal(t, "10.0.0.1", existing.Spec.ClusterIP)
+			assert.Equal(t, tt.expectedPolicy, *existing.Spec.IPFamilyPolicy)
+		})
+	}
+}
+
 func TestMutateDaemonsetAdditionalContainers(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -2481,3 +2540,70 @@ func TestMutateStatefulSetLabelChange(t *testing.T) {
 		})
 	}
 }
+
+func TestMutateJob(t *testing.T) {
+	job := func(hash string) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.Now(),
+				Name:              "job",
+			},
+			Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{"opentelemetry-operator-job/sha256": hash},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "collector",
+								Image: "collector:" + hash,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// the pod template of the job is kept as is
+	existing := job("1")
+	mutateFn := MutateFuncFor(existing, job("1"))
+	require.NoError(t, mutateFn())
+	assert.Equal(t, "collector:1", existing.Spec.Template.Spec.Containers[0].Image)
+
+	// the job is replaced when its spec changes
+	mutateFn = MutateFuncFor(job("1"), job("2"))
+	err := mutateFn()
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &ImmutableChangeErr)
+}
+
+func TestMutateCronJob(t *testing.T) {
+	existing := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.Now(),
+			Name:              "cronjob",
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "@hourly",
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Name: "collector", Image: "collector:1"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	desired := existing.DeepCopy()
+	desired.Spec.Schedule = "@daily"
+	desired.Spec.Suspend = ptr.To(true)
+	desired.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image = "collector:2"
+
+	mutateFn := MutateFuncFor(existing, desired)
+	require.NoError(t, mutateFn())
+	assert.Equal(t, desired.Spec, existing.Spec)
+}
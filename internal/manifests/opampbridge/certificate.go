@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampbridge
+
+import (
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+// ClientCertificate returns the cert-manager Certificate of the client certificate the bridge connects to the OpAMP
+// server with, or nil when the bridge doesn't request it.
+func ClientCertificate(params manifests.Params) *cmv1.Certificate {
+	tls := params.OpAMPBridge.Spec.TLS
+	if tls == nil || tls.Certificate == nil || tls.SecretName == "" {
+		return nil
+	}
+	labels := manifestutils.Labels(params.OpAMPBridge.ObjectMeta, tls.SecretName, params.OpAMPBridge.Spec.Image, ComponentOpAMPBridge, nil)
+	commonName := tls.Certificate.CommonName
+	if commonName == "" {
+		commonName = params.OpAMPBridge.Name
+	}
+	return &cmv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tls.SecretName,
+			Namespace: params.OpAMPBridge.Namespace,
+			Labels:    labels,
+		},
+		Spec: cmv1.CertificateSpec{
+			CommonName: commonName,
+			Subject: &cmv1.X509Subject{
+				OrganizationalUnits: []string{"opentelemetry-operator"},
+			},
+			Duration:   tls.Certificate.Duration,
+			SecretName: tls.SecretName,
+			IssuerRef: cmmeta.ObjectReference{
+				Name:  tls.Certificate.IssuerName,
+				Kind:  tls.Certificate.IssuerKind,
+				Group: "cert-manager.io",
+			},
+			Usages: []cmv1.KeyUsage{
+				cmv1.UsageDigitalSignature,
+				cmv1.UsageKeyEncipherment,
+				cmv1.UsageClientAuth,
+			},
+		},
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opampbridge
+
+import (
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+)
+
+func TestClientCertificate(t *testing.T) {
+	duration := &metav1.Duration{Duration: 24 * time.Hour}
+	tests := []struct {
+		name     string
+		tls      *v1alpha1.OpAMPBridgeTLS
+		expected *cmv1.Certificate
+	}{
+		{
+			name: "no tls",
+		},
+		{
+			name: "certificate not requested",
+			tls:  &v1alpha1.OpAMPBridgeTLS{SecretName: "my-instance-opamp-bridge-tls"},
+		},
+		{
+			name: "certificate requested",
+			tls: &v1alpha1.OpAMPBridgeTLS{
+				SecretName: "my-instance-opamp-bridge-tls",
+				Certificate: &v1alpha1.OpAMPBridgeCertificate{
+					IssuerName: "opamp-ca",
+					IssuerKind: "ClusterIssuer",
+					Duration:   duration,
+				},
+			},
+			expected: &cmv1.Certificate{
+				Spec: cmv1.CertificateSpec{
+					CommonName: "my-instance",
+					Duration:   duration,
+					SecretName: "my-instance-opamp-bridge-tls",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := manifests.Params{
+				Config: config.New(),
+				OpAMPBridge: v1alpha1.OpAMPBridge{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-instance",
+						Namespace: "my-namespace",
+					},
+					Spec: v1alpha1.OpAMPBridgeSpec{
+						TLS: tt.tls,
+					},
+				},
+			}
+			actual := ClientCertificate(params)
+			if tt.expected == nil {
+				assert.Nil(t, actual)
+				return
+			}
+			assert.Equal(t, "my-instance-opamp-bridge-tls", actual.Name)
+			assert.Equal(t, "my-namespace", actual.Namespace)
+			assert.Equal(t, tt.expected.Spec.CommonName, actual.Spec.CommonName)
+			assert.Equal(t, tt.expected.Spec.Duration, actual.Spec.Duration)
+			assert.Equal(t, tt.expected.Spec.SecretName, actual.Spec.SecretName)
+			assert.Equal(t, "opamp-ca", actual.Spec.IssuerRef.Name)
+			assert.Equal(t, "ClusterIssuer", actual.Spec.IssuerRef.Kind)
+			assert.Contains(t, actual.Spec.Usages, cmv1.UsageClientAuth)
+		})
+	}
+}
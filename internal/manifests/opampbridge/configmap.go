@@ -4,10 +4,13 @@
 package opampbridge
 
 import (
+	"path"
+
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
@@ -15,6 +18,12 @@ import (
 
 const (
 	OpAMPBridgeFilename = "remoteconfiguration.yaml"
+	// TLSMountPath is the directory the secret with the client certificate is mounted into.
+	TLSMountPath = "/etc/opamp-bridge/tls"
+	// CAMountPath is the directory the config map with the CA certificate of the server is mounted into.
+	CAMountPath = "/etc/opamp-bridge/ca"
+	// caCertKey is the key of the CA certificate in the config maps and in the secrets written by cert-manager.
+	caCertKey = "ca.crt"
 )
 
 func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
@@ -31,6 +40,26 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 		config["headers"] = params.OpAMPBridge.Spec.Headers
 	}
 
+	if tls := params.OpAMPBridge.Spec.TLS; tls != nil {
+		config["tls"] = tlsConfig(*tls)
+	}
+
+	if len(params.OpAMPBridge.Spec.Transport) > 0 {
+		config["transport"] = params.OpAMPBridge.Spec.Transport
+	}
+
+	if params.OpAMPBridge.Spec.PollingInterval != nil {
+		config["pollingInterval"] = params.OpAMPBridge.Spec.PollingInterval.Duration.String()
+	}
+
+	if params.OpAMPBridge.Spec.HeartbeatInterval != nil {
+		config["heartbeatInterval"] = params.OpAMPBridge.Spec.HeartbeatInterval.Duration.String()
+	}
+
+	if params.OpAMPBridge.Spec.EnableCompression {
+		config["enableCompression"] = true
+	}
+
 	if params.OpAMPBridge.Spec.Capabilities != nil {
 		config["capabilities"] = params.OpAMPBridge.Spec.Capabilities
 	}
@@ -39,6 +68,10 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 		config["componentsAllowed"] = params.OpAMPBridge.Spec.ComponentsAllowed
 	}
 
+	if len(params.OpAMPBridge.Spec.ManagedKinds) > 0 {
+		config["managedKinds"] = params.OpAMPBridge.Spec.ManagedKinds
+	}
+
 	if params.OpAMPBridge.Spec.Description != nil {
 		config["description"] = map[string]any{
 			"non_identifying_attributes": params.OpAMPBridge.Spec.Description.NonIdentifyingAttributes,
@@ -62,3 +95,23 @@ func ConfigMap(params manifests.Params) (*corev1.ConfigMap, error) {
 		},
 	}, nil
 }
+
+// tlsConfig returns the TLS configuration of the bridge, with the paths of the certificates mounted into the pod.
+func tlsConfig(tls v1alpha1.OpAMPBridgeTLS) map[string]any {
+	config := map[string]any{}
+	if tls.SecretName != "" {
+		config["certFile"] = path.Join(TLSMountPath, corev1.TLSCertKey)
+		config["keyFile"] = path.Join(TLSMountPath, corev1.TLSPrivateKeyKey)
+		// only the secrets of the certificates requested by the operator are known to hold the CA certificate
+		if tls.Certificate != nil {
+			config["caFile"] = path.Join(TLSMountPath, caCertKey)
+		}
+	}
+	if tls.ConfigMapName != "" {
+		config["caFile"] = path.Join(CAMountPath, caCertKey)
+	}
+	if tls.ServerName != "" {
+		config["serverName"] = tls.ServerName
+	}
+	return config
+}
@@ -5,6 +5,7 @@ package opampbridge
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -52,6 +53,9 @@ description:
 endpoint: ws://opamp-server:4320/v1/opamp
 headers:
   authorization: access-12345-token
+managedKinds:
+- TargetAllocator
+- Instrumentation
 `}
 	tests := []struct {
 		description    string
@@ -107,6 +111,7 @@ headers:
 						v1alpha1.OpAMPBridgeCapabilityReportsRemoteConfig:            true,
 					},
 					ComponentsAllowed: map[string][]string{"receivers": {"otlp"}, "processors": {"memory_limiter"}, "exporters": {"debug"}},
+					ManagedKinds:      []v1alpha1.OpAMPBridgeManagedKind{v1alpha1.OpAMPBridgeManagedKindTargetAllocator, v1alpha1.OpAMPBridgeManagedKindInstrumentation},
 				},
 			}
 
@@ -127,3 +132,95 @@ headers:
 		})
 	}
 }
+
+func TestDesiredConfigMapWithTLS(t *testing.T) {
+	tests := []struct {
+		description string
+		tls         v1alpha1.OpAMPBridgeTLS
+		expected    string
+	}{
+		{
+			description: "client certificate from the secret",
+			tls:         v1alpha1.OpAMPBridgeTLS{SecretName: "opamp-bridge-client"},
+			expected: `tls:
+  certFile: /etc/opamp-bridge/tls/tls.crt
+  keyFile: /etc/opamp-bridge/tls/tls.key
+`,
+		},
+		{
+			description: "client certificate and CA certificate from the secret of cert-manager",
+			tls: v1alpha1.OpAMPBridgeTLS{
+				SecretName:  "my-instance-opamp-bridge-tls",
+				Certificate: &v1alpha1.OpAMPBridgeCertificate{IssuerName: "opamp-ca"},
+			},
+			expected: `tls:
+  caFile: /etc/opamp-bridge/tls/ca.crt
+  certFile: /etc/opamp-bridge/tls/tls.crt
+  keyFile: /etc/opamp-bridge/tls/tls.key
+`,
+		},
+		{
+			description: "CA certificate from the config map",
+			tls:         v1alpha1.OpAMPBridgeTLS{SecretName: "my-instance-opamp-bridge-tls", ConfigMapName: "opamp-server-ca", ServerName: "opamp.example.com"},
+			expected: `tls:
+  caFile: /etc/opamp-bridge/ca/ca.crt
+  certFile: /etc/opamp-bridge/tls/tls.crt
+  keyFile: /etc/opamp-bridge/tls/tls.key
+  serverName: opamp.example.com
+`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			tls := tc.tls
+			params := manifests.Params{
+				Config: config.New(),
+				OpAMPBridge: v1alpha1.OpAMPBridge{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "my-instance",
+						Namespace: "my-namespace",
+					},
+					Spec: v1alpha1.OpAMPBridgeSpec{
+						Endpoint: "wss://opamp-server:4320/v1/opamp",
+						TLS:      &tls,
+					},
+				},
+				Log: logger,
+			}
+
+			actual, err := ConfigMap(params)
+			assert.NoError(t, err)
+			assert.Equal(t, "endpoint: wss://opamp-server:4320/v1/opamp\n"+tc.expected, actual.Data[OpAMPBridgeFilename])
+		})
+	}
+}
+
+func TestDesiredConfigMapWithTransport(t *testing.T) {
+	params := manifests.Params{
+		Config: config.New(),
+		OpAMPBridge: v1alpha1.OpAMPBridge{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-instance",
+				Namespace: "my-namespace",
+			},
+			Spec: v1alpha1.OpAMPBridgeSpec{
+				Endpoint:          "wss://opamp-server:4320/v1/opamp",
+				Transport:         "http",
+				PollingInterval:   &metav1.Duration{Duration: 10 * time.Second},
+				HeartbeatInterval: &metav1.Duration{Duration: time.Minute},
+				EnableCompression: true,
+			},
+		},
+		Log: logger,
+	}
+
+	actual, err := ConfigMap(params)
+	assert.NoError(t, err)
+	assert.Equal(t, `enableCompression: true
+endpoint: wss://opamp-server:4320/v1/opamp
+heartbeatInterval: 1m0s
+pollingInterval: 10s
+transport: http
+`, actual.Data[OpAMPBridgeFilename])
+}
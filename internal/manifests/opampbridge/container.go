@@ -10,6 +10,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
@@ -26,6 +27,24 @@ func Container(cfg config.Config, logger logr.Logger, opampBridge v1alpha1.OpAMP
 		MountPath: "/conf",
 	}}
 
+	// the secret and the config map are mounted without subPath, for the rotated certificates to be updated
+	if tls := opampBridge.Spec.TLS; tls != nil {
+		if tls.SecretName != "" {
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      naming.OpAMPBridgeTLSVolume(),
+				MountPath: TLSMountPath,
+				ReadOnly:  true,
+			})
+		}
+		if tls.ConfigMapName != "" {
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      naming.OpAMPBridgeCAVolume(),
+				MountPath: CAMountPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+
 	if len(opampBridge.Spec.VolumeMounts) > 0 {
 		volumeMounts = append(volumeMounts, opampBridge.Spec.VolumeMounts...)
 	}
@@ -84,6 +103,6 @@ func Container(cfg config.Config, logger logr.Logger, opampBridge v1alpha1.OpAMP
 		VolumeMounts:    volumeMounts,
 		EnvFrom:         opampBridge.Spec.EnvFrom,
 		Resources:       opampBridge.Spec.Resources,
-		SecurityContext: opampBridge.Spec.SecurityContext,
+		SecurityContext: manifestutils.SecurityContext(cfg, opampBridge.Spec.SecurityContext),
 	}
 }
@@ -49,7 +49,7 @@ func Deployment(params manifests.Params) *appsv1.Deployment {
 					HostNetwork:               params.OpAMPBridge.Spec.HostNetwork,
 					Tolerations:               params.OpAMPBridge.Spec.Tolerations,
 					NodeSelector:              params.OpAMPBridge.Spec.NodeSelector,
-					SecurityContext:           params.OpAMPBridge.Spec.PodSecurityContext,
+					SecurityContext:           manifestutils.PodSecurityContext(params.Config, params.OpAMPBridge.Spec.PodSecurityContext),
 					PriorityClassName:         params.OpAMPBridge.Spec.PriorityClassName,
 					Affinity:                  params.OpAMPBridge.Spec.Affinity,
 					TopologySpreadConstraints: params.OpAMPBridge.Spec.TopologySpreadConstraints,
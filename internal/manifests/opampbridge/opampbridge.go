@@ -6,6 +6,7 @@ package opampbridge
 import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
 )
 
@@ -22,6 +23,9 @@ func Build(params manifests.Params) ([]client.Object, error) {
 		manifests.FactoryWithoutError(ServiceAccount),
 		manifests.FactoryWithoutError(Service),
 	}
+	if params.Config.CertManagerAvailability == certmanager.Available {
+		resourceFactories = append(resourceFactories, manifests.FactoryWithoutError(ClientCertificate))
+	}
 	for _, factory := range resourceFactories {
 		res, err := factory(params)
 		if err != nil {
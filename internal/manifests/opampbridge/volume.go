@@ -28,5 +28,28 @@ func Volumes(cfg config.Config, opampBridge v1alpha1.OpAMPBridge) []corev1.Volum
 		},
 	}}
 
+	if tls := opampBridge.Spec.TLS; tls != nil {
+		if tls.SecretName != "" {
+			volumes = append(volumes, corev1.Volume{
+				Name: naming.OpAMPBridgeTLSVolume(),
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: tls.SecretName,
+					},
+				},
+			})
+		}
+		if tls.ConfigMapName != "" {
+			volumes = append(volumes, corev1.Volume{
+				Name: naming.OpAMPBridgeCAVolume(),
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: tls.ConfigMapName},
+					},
+				},
+			})
+		}
+	}
+
 	return volumes
 }
@@ -30,3 +30,23 @@ func TestVolumeNewDefault(t *testing.T) {
 	// check that it's the opamp-bridge-internal volume, with the config map
 	assert.Equal(t, naming.OpAMPBridgeConfigMapVolume(), volumes[0].Name)
 }
+
+func TestVolumeWithTLS(t *testing.T) {
+	// prepare
+	opampBridge := v1alpha1.OpAMPBridge{
+		Spec: v1alpha1.OpAMPBridgeSpec{
+			TLS: &v1alpha1.OpAMPBridgeTLS{SecretName: "opamp-bridge-tls", ConfigMapName: "opamp-server-ca"},
+		},
+	}
+	cfg := config.New()
+
+	// test
+	volumes := Volumes(cfg, opampBridge)
+
+	// verify
+	assert.Len(t, volumes, 3)
+	assert.Equal(t, naming.OpAMPBridgeTLSVolume(), volumes[1].Name)
+	assert.Equal(t, "opamp-bridge-tls", volumes[1].VolumeSource.Secret.SecretName)
+	assert.Equal(t, naming.OpAMPBridgeCAVolume(), volumes[2].Name)
+	assert.Equal(t, "opamp-server-ca", volumes[2].VolumeSource.ConfigMap.Name)
+}
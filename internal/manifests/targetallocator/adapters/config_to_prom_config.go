@@ -269,6 +269,31 @@ func WithTLSConfig(caFile, certFile, keyFile, taServiceName string) TAOption {
 	}
 }
 
+// WithServiceAccountTokenAuth makes the collector authenticate to the target allocator with its service account
+// token, read from tokenFile for the service discovery requests and by the given authenticator extension otherwise.
+func WithServiceAccountTokenAuth(authenticator, tokenFile string) TAOption {
+	return func(targetAllocatorCfg map[interface{}]interface{}) error {
+		targetAllocatorCfg["auth"] = map[interface{}]interface{}{
+			"authenticator": authenticator,
+		}
+
+		if _, exists := targetAllocatorCfg["http_sd_config"]; !exists {
+			targetAllocatorCfg["http_sd_config"] = make(map[interface{}]interface{})
+		}
+
+		httpSDCfg, ok := targetAllocatorCfg["http_sd_config"].(map[interface{}]interface{})
+		if !ok {
+			return errorNotAMap("http_sd_config")
+		}
+
+		httpSDCfg["authorization"] = map[interface{}]interface{}{
+			"credentials_file": tokenFile,
+		}
+
+		return nil
+	}
+}
+
 // AddTAConfigToPromConfig adds or updates the target_allocator configuration in the Prometheus configuration.
 // If the `EnableTargetAllocatorRewrite` feature flag for the target allocator is enabled, this function
 // removes the existing scrape_configs from the collector's Prometheus configuration as it's not required.
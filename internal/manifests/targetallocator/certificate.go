@@ -52,11 +52,7 @@ func ServingCertificate(params Params) *cmv1.Certificate {
 			Labels:    labels,
 		},
 		Spec: cmv1.CertificateSpec{
-			DNSNames: []string{
-				naming.TAService(params.TargetAllocator.Name),
-				fmt.Sprintf("%s.%s.svc", naming.TAService(params.TargetAllocator.Name), params.TargetAllocator.Namespace),
-				fmt.Sprintf("%s.%s.svc.cluster.local", naming.TAService(params.TargetAllocator.Name), params.TargetAllocator.Namespace),
-			},
+			DNSNames: certificateDNSNames(params),
 			IssuerRef: cmmeta.ObjectReference{
 				Kind: "Issuer",
 				Name: naming.CAIssuer(params.TargetAllocator.Name),
@@ -85,11 +81,7 @@ func ClientCertificate(params Params) *cmv1.Certificate {
 			Labels:    labels,
 		},
 		Spec: cmv1.CertificateSpec{
-			DNSNames: []string{
-				naming.TAService(params.TargetAllocator.Name),
-				fmt.Sprintf("%s.%s.svc", naming.TAService(params.TargetAllocator.Name), params.TargetAllocator.Namespace),
-				fmt.Sprintf("%s.%s.svc.cluster.local", naming.TAService(params.TargetAllocator.Name), params.TargetAllocator.Namespace),
-			},
+			DNSNames: certificateDNSNames(params),
 			IssuerRef: cmmeta.ObjectReference{
 				Kind: "Issuer",
 				Name: naming.CAIssuer(params.TargetAllocator.Name),
@@ -105,3 +97,12 @@ func ClientCertificate(params Params) *cmv1.Certificate {
 		},
 	}
 }
+
+// certificateDNSNames returns the DNS names of the target allocator service, for its serving and client certificates.
+func certificateDNSNames(params Params) []string {
+	return []string{
+		naming.TAService(params.TargetAllocator.Name),
+		fmt.Sprintf("%s.%s.svc", naming.TAService(params.TargetAllocator.Name), params.TargetAllocator.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", naming.TAService(params.TargetAllocator.Name), params.TargetAllocator.Namespace),
+	}
+}
@@ -4,6 +4,7 @@
 package targetallocator
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/mitchellh/mapstructure"
@@ -12,7 +13,6 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
-	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator/adapters"
@@ -80,6 +80,10 @@ func ConfigMap(params Params) (*corev1.ConfigMap, error) {
 		taConfig["allocation_strategy"] = v1beta1.TargetAllocatorAllocationStrategyConsistentHashing
 	}
 
+	if consistentHashing := consistentHashingConfig(taSpec.ConsistentHashing); len(consistentHashing) > 0 {
+		taConfig["consistent_hashing"] = consistentHashing
+	}
+
 	if featuregate.EnableTargetAllocatorFallbackStrategy.IsEnabled() {
 		taConfig["allocation_fallback_strategy"] = v1beta1.TargetAllocatorAllocationStrategyConsistentHashing
 	}
@@ -110,10 +114,16 @@ func ConfigMap(params Params) (*corev1.ConfigMap, error) {
 
 		prometheusCRConfig["probe_selector"] = taSpec.PrometheusCR.ProbeSelector
 
+		// only restrict the watched resources when the operator could inspect the Prometheus operator API
+		if features := params.Config.PrometheusCRFeatures; features.Version != "" {
+			prometheusCRConfig["scrape_configs_enabled"] = features.ScrapeConfigs
+			prometheusCRConfig["probes_enabled"] = features.Probes
+		}
+
 		taConfig["prometheus_cr"] = prometheusCRConfig
 	}
 
-	if params.Config.CertManagerAvailability == certmanager.Available && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
+	if featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
 		taConfig["https"] = map[string]interface{}{
 			"enabled":            true,
 			"listen_addr":        ":8443",
@@ -123,10 +133,21 @@ func ConfigMap(params Params) (*corev1.ConfigMap, error) {
 		}
 	}
 
+	if params.Collector != nil && featuregate.EnableTargetAllocatorAuthentication.IsEnabled() {
+		taConfig["authentication"] = map[string]interface{}{
+			"enabled":          true,
+			"service_accounts": []string{fmt.Sprintf("%s/%s", params.Collector.Namespace, collector.ServiceAccountName(*params.Collector))},
+		}
+	}
+
 	if taSpec.CollectorNotReadyGracePeriod.Size() > 0 {
 		taConfig["collector_not_ready_grace_period"] = taSpec.CollectorNotReadyGracePeriod.Duration
 	}
 
+	if taSpec.CollectorDrainTimeout != nil && taSpec.CollectorDrainTimeout.Duration > 0 {
+		taConfig["collector_drain_timeout"] = taSpec.CollectorDrainTimeout.Duration
+	}
+
 	taConfigYAML, err := yaml.Marshal(taConfig)
 	if err != nil {
 		return &corev1.ConfigMap{}, err
@@ -145,6 +166,21 @@ func ConfigMap(params Params) (*corev1.ConfigMap, error) {
 	}, nil
 }
 
+// consistentHashingConfig returns the hash ring settings which are set.
+func consistentHashingConfig(spec v1beta1.TargetAllocatorConsistentHashing) map[string]interface{} {
+	consistentHashing := map[string]interface{}{}
+	if spec.PartitionCount > 0 {
+		consistentHashing["partition_count"] = spec.PartitionCount
+	}
+	if spec.ReplicationFactor > 0 {
+		consistentHashing["replication_factor"] = spec.ReplicationFactor
+	}
+	if spec.Hash != "" {
+		consistentHashing["hash"] = spec.Hash
+	}
+	return consistentHashing
+}
+
 func getGlobalConfig(taGlobalConfig v1beta1.AnyConfig, collectorConfig v1beta1.Config) (map[string]any, error) {
 	// global config from the target allocator has priority
 	if len(taGlobalConfig.Object) > 0 {
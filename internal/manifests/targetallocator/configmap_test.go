@@ -17,6 +17,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
@@ -249,6 +250,48 @@ prometheus_cr:
 
 	})
 
+	t.Run("should return expected target allocator config map with the served prometheus cr resources", func(t *testing.T) {
+		expectedData := map[string]string{
+			targetAllocatorFilename: `allocation_strategy: consistent-hashing
+collector_selector:
+  matchlabels:
+    app.kubernetes.io/component: opentelemetry-collector
+    app.kubernetes.io/instance: default.my-instance
+    app.kubernetes.io/managed-by: opentelemetry-operator
+    app.kubernetes.io/part-of: opentelemetry
+  matchexpressions: []
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+filter_strategy: relabel-config
+prometheus_cr:
+  enabled: true
+  pod_monitor_selector: null
+  probe_selector: null
+  probes_enabled: true
+  scrape_config_selector: null
+  scrape_configs_enabled: false
+  scrape_interval: 30s
+  service_monitor_selector: null
+`,
+		}
+
+		featuresParams := params
+		featuresParams.TargetAllocator = targetAllocator
+		featuresParams.Config = config.New(config.WithPrometheusCRFeatures(prometheus.Features{Version: "v1", Probes: true}))
+		actual, err := ConfigMap(featuresParams)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "my-instance-targetallocator", actual.Name)
+		assert.Equal(t, expectedLabels, actual.Labels)
+		assert.Equal(t, expectedData, actual.Data)
+	})
+
 	t.Run("should return expected target allocator config map with HTTPS configuration", func(t *testing.T) {
 		expectedLabels["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
 		expectedLabels["app.kubernetes.io/name"] = "my-instance-targetallocator"
@@ -257,6 +300,9 @@ prometheus_cr:
 
 		flgs := featuregate.Flags(colfg.GlobalRegistry())
 		err := flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+		t.Cleanup(func() {
+			_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+		})
 		require.NoError(t, err)
 
 		testParams := Params{
@@ -342,12 +388,6 @@ config:
       - 0.0.0.0:8888
       - 0.0.0.0:9999
 filter_strategy: relabel-config
-https:
-  ca_file_path: /tls/ca.crt
-  enabled: true
-  listen_addr: :8443
-  tls_cert_file_path: /tls/tls.crt
-  tls_key_file_path: /tls/tls.key
 prometheus_cr:
   enabled: true
   pod_monitor_selector: null
@@ -365,6 +405,30 @@ prometheus_cr:
 		assert.Equal(t, expectedLabels, actual.Labels)
 		assert.Equal(t, expectedData, actual.Data)
 	})
+
+	t.Run("should return expected target allocator config map with authentication", func(t *testing.T) {
+		expectedLabels["app.kubernetes.io/component"] = "opentelemetry-targetallocator"
+		expectedLabels["app.kubernetes.io/name"] = "my-instance-targetallocator"
+
+		require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorAuthentication.ID(), true))
+		defer func() {
+			require.NoError(t, colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorAuthentication.ID(), false))
+		}()
+
+		testParams := Params{
+			Collector:       collector,
+			TargetAllocator: targetAllocator,
+		}
+
+		actual, err := ConfigMap(testParams)
+		assert.NoError(t, err)
+
+		assert.Contains(t, actual.Data[targetAllocatorFilename], `authentication:
+  enabled: true
+  service_accounts:
+  - default/my-instance-collector
+`)
+	})
 }
 
 func TestGetScrapeConfigsFromOtelConfig(t *testing.T) {
@@ -771,3 +835,93 @@ filter_strategy: relabel-config
 		assert.Equal(t, expectedData[targetAllocatorFilename], actual.Data[targetAllocatorFilename])
 	})
 }
+
+func TestGetCollectorDrainTimeout(t *testing.T) {
+	collector := collectorInstance()
+	targetAllocator := targetAllocatorInstanceWithCollectorNotReadyGracePeriod()
+	targetAllocator.Spec.CollectorDrainTimeout = &metav1.Duration{Duration: 10 * time.Second}
+	cfg := config.New()
+	params := Params{
+		Collector:       collector,
+		TargetAllocator: targetAllocator,
+		Config:          cfg,
+		Log:             logr.Discard(),
+	}
+
+	t.Run("should return expected target allocator config map with collector_drain_timeout", func(t *testing.T) {
+		expectedData := map[string]string{
+			targetAllocatorFilename: `allocation_fallback_strategy: consistent-hashing
+allocation_strategy: consistent-hashing
+collector_drain_timeout: 10s
+collector_not_ready_grace_period: 30s
+collector_selector:
+  matchlabels:
+    app.kubernetes.io/component: opentelemetry-collector
+    app.kubernetes.io/instance: default.my-instance
+    app.kubernetes.io/managed-by: opentelemetry-operator
+    app.kubernetes.io/part-of: opentelemetry
+  matchexpressions: []
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+filter_strategy: relabel-config
+`,
+		}
+
+		actual, err := ConfigMap(params)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedData[targetAllocatorFilename], actual.Data[targetAllocatorFilename])
+	})
+}
+
+func TestGetConsistentHashing(t *testing.T) {
+	collector := collectorInstance()
+	targetAllocator := targetAllocatorInstanceWithCollectorNotReadyGracePeriod()
+	targetAllocator.Spec.ConsistentHashing = v1beta1.TargetAllocatorConsistentHashing{ReplicationFactor: 20, Hash: "sha256"}
+	cfg := config.New()
+	params := Params{
+		Collector:       collector,
+		TargetAllocator: targetAllocator,
+		Config:          cfg,
+		Log:             logr.Discard(),
+	}
+
+	t.Run("should return expected target allocator config map with consistent_hashing", func(t *testing.T) {
+		expectedData := map[string]string{
+			targetAllocatorFilename: `allocation_fallback_strategy: consistent-hashing
+allocation_strategy: consistent-hashing
+collector_not_ready_grace_period: 30s
+collector_selector:
+  matchlabels:
+    app.kubernetes.io/component: opentelemetry-collector
+    app.kubernetes.io/instance: default.my-instance
+    app.kubernetes.io/managed-by: opentelemetry-operator
+    app.kubernetes.io/part-of: opentelemetry
+  matchexpressions: []
+config:
+  scrape_configs:
+  - job_name: otel-collector
+    scrape_interval: 10s
+    static_configs:
+    - targets:
+      - 0.0.0.0:8888
+      - 0.0.0.0:9999
+consistent_hashing:
+  hash: sha256
+  replication_factor: 20
+filter_strategy: relabel-config
+`,
+		}
+
+		actual, err := ConfigMap(params)
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedData[targetAllocatorFilename], actual.Data[targetAllocatorFilename])
+	})
+}
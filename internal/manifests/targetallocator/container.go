@@ -13,8 +13,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
-	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
@@ -119,7 +119,7 @@ func Container(cfg config.Config, logger logr.Logger, instance v1alpha1.TargetAl
 		},
 	}
 
-	if cfg.CertManagerAvailability == certmanager.Available && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
+	if featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
 		ports = append(ports, corev1.ContainerPort{
 			Name:          "https",
 			ContainerPort: 8443,
@@ -142,7 +142,7 @@ func Container(cfg config.Config, logger logr.Logger, instance v1alpha1.TargetAl
 		Env:             envVars,
 		EnvFrom:         instance.Spec.EnvFrom,
 		Resources:       instance.Spec.Resources,
-		SecurityContext: instance.Spec.SecurityContext,
+		SecurityContext: manifestutils.SecurityContext(cfg, instance.Spec.SecurityContext),
 		LivenessProbe:   livenessProbe,
 		ReadinessProbe:  readinessProbe,
 		Lifecycle:       instance.Spec.Lifecycle,
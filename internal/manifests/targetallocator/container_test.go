@@ -380,6 +380,9 @@ func TestContainerWithCertManagerAvailable(t *testing.T) {
 
 	flgs := featuregate.Flags(colfg.GlobalRegistry())
 	err := flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+	t.Cleanup(func() {
+		_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+	})
 	require.NoError(t, err)
 
 	cfg := config.New(config.WithCertManagerAvailability(certmanager.Available))
@@ -51,7 +51,7 @@ func Deployment(params Params) (*appsv1.Deployment, error) {
 					ShareProcessNamespace:         &params.TargetAllocator.Spec.ShareProcessNamespace,
 					Tolerations:                   params.TargetAllocator.Spec.Tolerations,
 					NodeSelector:                  params.TargetAllocator.Spec.NodeSelector,
-					SecurityContext:               params.TargetAllocator.Spec.PodSecurityContext,
+					SecurityContext:               manifestutils.PodSecurityContext(params.Config, params.TargetAllocator.Spec.PodSecurityContext),
 					PriorityClassName:             params.TargetAllocator.Spec.PriorityClassName,
 					Affinity:                      params.TargetAllocator.Spec.Affinity,
 					TerminationGracePeriodSeconds: params.TargetAllocator.Spec.TerminationGracePeriodSeconds,
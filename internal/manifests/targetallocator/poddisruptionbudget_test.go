@@ -101,7 +101,7 @@ var tests = []test{
 
 func TestPDBWithValidStrategy(t *testing.T) {
 	for _, test := range tests {
-		for _, strategy := range []v1beta1.TargetAllocatorAllocationStrategy{v1beta1.TargetAllocatorAllocationStrategyPerNode, v1beta1.TargetAllocatorAllocationStrategyConsistentHashing} {
+		for _, strategy := range []v1beta1.TargetAllocatorAllocationStrategy{v1beta1.TargetAllocatorAllocationStrategyPerNode, v1beta1.TargetAllocatorAllocationStrategyConsistentHashing, v1beta1.TargetAllocatorAllocationStrategyZoneAware} {
 			t.Run(fmt.Sprintf("%s-%s", strategy, test.name), func(t *testing.T) {
 				targetAllocator := v1alpha1.TargetAllocator{
 					ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package targetallocator
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/certs"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+// CertificateSecrets returns the Secrets of a self-signed CA, and of the serving and client certificates it signs,
+// for the mTLS between the target allocator and the collectors when cert-manager isn't available. They have the
+// names and keys of the Secrets of the cert-manager Certificates. The certificates of the existing Secrets are kept
+// until they are due for renewal, the previous CA being trusted until it expires, and the Secrets are returned with
+// the time of their next renewal.
+func CertificateSecrets(params Params, existing map[string]*corev1.Secret, now time.Time) ([]*corev1.Secret, time.Time, error) {
+	caName := naming.CACertificate(params.TargetAllocator.Name)
+	ca := keyPair(existing[caName])
+	if !certs.Valid(ca, nil, nil, now) {
+		var err error
+		if ca, err = certs.NewCA(caName, now); err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+	caCert, err := certs.ParseCertificate(ca.Cert)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var previousCAs []byte
+	if secret := existing[caName]; secret != nil {
+		previousCAs = secret.Data[constants.TACollectorCAFileName]
+	}
+	caBundle := certs.Bundle(now, ca.Cert, previousCAs)
+
+	secrets := []*corev1.Secret{certificateSecret(params, caName, ca, caBundle)}
+	renewal := certs.RenewalTime(caCert)
+	dnsNames := certificateDNSNames(params)
+	for _, name := range []string{naming.TAServerCertificateSecretName(params.TargetAllocator.Name), naming.TAClientCertificateSecretName(params.TargetAllocator.Name)} {
+		leaf := keyPair(existing[name])
+		if !certs.Valid(leaf, caCert, dnsNames, now) {
+			if leaf, err = certs.NewLeaf(ca, name, dnsNames, now); err != nil {
+				return nil, time.Time{}, err
+			}
+		}
+		leafCert, err := certs.ParseCertificate(leaf.Cert)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		if r := certs.RenewalTime(leafCert); r.Before(renewal) {
+			renewal = r
+		}
+		secrets = append(secrets, certificateSecret(params, name, leaf, caBundle))
+	}
+	return secrets, renewal, nil
+}
+
+// CertificateSecretsSelector selects the Secrets returned by CertificateSecrets, so that the operator only caches them
+// rather than all the Secrets of the cluster.
+func CertificateSecretsSelector() labels.Selector {
+	return labels.SelectorFromSet(labels.Set{
+		"app.kubernetes.io/managed-by": "opentelemetry-operator",
+		"app.kubernetes.io/component":  ComponentOpenTelemetryTargetAllocator,
+	})
+}
+
+func certificateSecret(params Params, name string, pair certs.KeyPair, caBundle []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: params.TargetAllocator.Namespace,
+			Labels:    manifestutils.Labels(params.TargetAllocator.ObjectMeta, name, params.TargetAllocator.Spec.Image, ComponentOpenTelemetryTargetAllocator, nil),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			constants.TACollectorCAFileName:      caBundle,
+			constants.TACollectorTLSCertFileName: pair.Cert,
+			constants.TACollectorTLSKeyFileName:  pair.Key,
+		},
+	}
+}
+
+func keyPair(secret *corev1.Secret) certs.KeyPair {
+	if secret == nil {
+		return certs.KeyPair{}
+	}
+	return certs.KeyPair{
+		Cert: secret.Data[constants.TACollectorTLSCertFileName],
+		Key:  secret.Data[constants.TACollectorTLSKeyFileName],
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package targetallocator
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/certs"
+)
+
+func TestCertificateSecrets(t *testing.T) {
+	params := Params{
+		TargetAllocator: v1alpha1.TargetAllocator{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-instance", Namespace: "my-namespace"},
+		},
+	}
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	secrets, renewal, err := CertificateSecrets(params, nil, now)
+	require.NoError(t, err)
+	require.Len(t, secrets, 3)
+	assert.Equal(t, "my-instance-ca-cert", secrets[0].Name)
+	assert.Equal(t, "my-instance-ta-server-cert", secrets[1].Name)
+	assert.Equal(t, "my-instance-ta-client-cert", secrets[2].Name)
+	for _, secret := range secrets {
+		assert.Equal(t, "my-namespace", secret.Namespace)
+		assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+		assert.Equal(t, "opentelemetry-operator", secret.Labels["app.kubernetes.io/managed-by"])
+		// the Secrets are cached by their labels
+		assert.True(t, CertificateSecretsSelector().Matches(labels.Set(secret.Labels)))
+		assert.Equal(t, secrets[0].Data["tls.crt"], secret.Data["ca.crt"])
+	}
+	assert.WithinDuration(t, now.Add(certs.LeafValidity*2/3), renewal, time.Minute)
+
+	caCert, err := certs.ParseCertificate(secrets[0].Data["tls.crt"])
+	require.NoError(t, err)
+	serverCert, err := certs.ParseCertificate(secrets[1].Data["tls.crt"])
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	_, err = serverCert.Verify(x509.VerifyOptions{DNSName: "my-instance-targetallocator.my-namespace.svc", Roots: roots, CurrentTime: now})
+	assert.NoError(t, err)
+
+	existing := map[string]*corev1.Secret{}
+	for _, secret := range secrets {
+		existing[secret.Name] = secret
+	}
+
+	t.Run("kept until renewal", func(t *testing.T) {
+		kept, _, err := CertificateSecrets(params, existing, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, secrets, kept)
+	})
+
+	t.Run("leaves renewed", func(t *testing.T) {
+		renewed, _, err := CertificateSecrets(params, existing, renewal)
+		require.NoError(t, err)
+		assert.Equal(t, secrets[0], renewed[0])
+		assert.NotEqual(t, secrets[1].Data["tls.crt"], renewed[1].Data["tls.crt"])
+		assert.NotEqual(t, secrets[2].Data["tls.crt"], renewed[2].Data["tls.crt"])
+	})
+
+	t.Run("CA renewed", func(t *testing.T) {
+		later := now.Add(certs.CAValidity * 2 / 3)
+		renewed, _, err := CertificateSecrets(params, existing, later)
+		require.NoError(t, err)
+		assert.NotEqual(t, secrets[0].Data["tls.crt"], renewed[0].Data["tls.crt"])
+		// the previous CA is still trusted
+		bundle := append(append([]byte{}, renewed[0].Data["tls.crt"]...), secrets[0].Data["tls.crt"]...)
+		for _, secret := range renewed {
+			assert.Equal(t, bundle, secret.Data["ca.crt"])
+		}
+		newCACert, err := certs.ParseCertificate(renewed[0].Data["tls.crt"])
+		require.NoError(t, err)
+		clientCert, err := certs.ParseCertificate(renewed[2].Data["tls.crt"])
+		require.NoError(t, err)
+		assert.NoError(t, clientCert.CheckSignatureFrom(newCACert))
+	})
+}
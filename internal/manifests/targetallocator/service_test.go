@@ -42,6 +42,9 @@ func TestServicePortsWithTargetAllocatorMTLS(t *testing.T) {
 
 	flgs := featuregate.Flags(colfg.GlobalRegistry())
 	err := flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+	t.Cleanup(func() {
+		_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+	})
 	require.NoError(t, err)
 
 	params := Params{
@@ -15,6 +15,16 @@ import (
 func ServiceMonitor(params Params) *monitoringv1.ServiceMonitor {
 	name := naming.TargetAllocator(params.TargetAllocator.Name)
 	labels := manifestutils.Labels(params.TargetAllocator.ObjectMeta, name, params.TargetAllocator.Spec.Image, ComponentOpenTelemetryTargetAllocator, nil)
+	metrics := params.TargetAllocator.Spec.Observability.Metrics
+	endpoint := monitoringv1.Endpoint{
+		Port:                 "targetallocation",
+		Interval:             monitoringv1.Duration(metrics.Interval),
+		MetricRelabelConfigs: manifestutils.RelabelConfigs(metrics.MetricRelabelings),
+	}
+	if metrics.TLSConfig != nil {
+		endpoint.Scheme = "https"
+		endpoint.TLSConfig = &monitoringv1.TLSConfig{SafeTLSConfig: *manifestutils.SafeTLSConfig(metrics.TLSConfig)}
+	}
 
 	return &monitoringv1.ServiceMonitor{
 		ObjectMeta: metav1.ObjectMeta{
@@ -23,11 +33,7 @@ func ServiceMonitor(params Params) *monitoringv1.ServiceMonitor {
 			Labels:    labels,
 		},
 		Spec: monitoringv1.ServiceMonitorSpec{
-			Endpoints: []monitoringv1.Endpoint{
-				{
-					Port: "targetallocation",
-				},
-			},
+			Endpoints: []monitoringv1.Endpoint{endpoint},
 
 			NamespaceSelector: monitoringv1.NamespaceSelector{
 				MatchNames: []string{params.TargetAllocator.Namespace},
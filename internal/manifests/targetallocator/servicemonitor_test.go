@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"testing"
 
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -40,5 +41,21 @@ func TestDesiredServiceMonitors(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("%s-targetallocator", params.TargetAllocator.Name), actual.Name)
 	assert.Equal(t, params.TargetAllocator.Namespace, actual.Namespace)
 	assert.Equal(t, "targetallocation", actual.Spec.Endpoints[0].Port)
-
+	assert.Empty(t, actual.Spec.Endpoints[0].Interval)
+	assert.Empty(t, actual.Spec.Endpoints[0].Scheme)
+	assert.Nil(t, actual.Spec.Endpoints[0].TLSConfig)
+
+	params.TargetAllocator.Spec.Observability.Metrics = v1beta1.MetricsConfigSpec{
+		EnableMetrics: true,
+		Interval:      "1m",
+		MetricRelabelings: []v1beta1.RelabelConfig{
+			{Action: "labeldrop", Regex: "pod"},
+		},
+		TLSConfig: &v1beta1.SafeTLSConfig{ServerName: &params.TargetAllocator.Name},
+	}
+	actual = ServiceMonitor(params)
+	assert.Equal(t, monitoringv1.Duration("1m"), actual.Spec.Endpoints[0].Interval)
+	assert.Equal(t, []monitoringv1.RelabelConfig{{Action: "labeldrop", Regex: "pod"}}, actual.Spec.Endpoints[0].MetricRelabelConfigs)
+	assert.Equal(t, "https", actual.Spec.Endpoints[0].Scheme)
+	assert.Equal(t, &monitoringv1.TLSConfig{SafeTLSConfig: monitoringv1.SafeTLSConfig{ServerName: &params.TargetAllocator.Name}}, actual.Spec.Endpoints[0].TLSConfig)
 }
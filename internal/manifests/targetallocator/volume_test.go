@@ -75,6 +75,9 @@ func TestVolumeWithTargetAllocatorMTLS(t *testing.T) {
 
 		flgs := featuregate.Flags(colfg.GlobalRegistry())
 		err := flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+		t.Cleanup(func() {
+			_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+		})
 		require.NoError(t, err)
 
 		volumes := Volumes(cfg, ta)
@@ -100,10 +103,21 @@ func TestVolumeWithTargetAllocatorMTLS(t *testing.T) {
 
 		flgs := featuregate.Flags(colfg.GlobalRegistry())
 		err := flgs.Parse([]string{"--feature-gates=operator.targetallocator.mtls"})
+		t.Cleanup(func() {
+			_ = colfg.GlobalRegistry().Set(featuregate.EnableTargetAllocatorMTLS.ID(), false)
+		})
 		require.NoError(t, err)
 
+		// the operator creates the certificate Secrets itself
 		volumes := Volumes(cfg, ta)
-		assert.NotContains(t, volumes, corev1.Volume{Name: naming.TAServerCertificate(ta.Name)})
+		assert.Contains(t, volumes, corev1.Volume{
+			Name: naming.TAServerCertificate(ta.Name),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: naming.TAServerCertificateSecretName(ta.Name),
+				},
+			},
+		})
 	})
 
 	t.Run("EnableTargetAllocatorMTLS disabled", func(t *testing.T) {
@@ -10,6 +10,11 @@ func ConfigMap(otelcol, configHash string) string {
 	return DNSName(Truncate("%s-collector-%s", 63, otelcol, configHash[:8]))
 }
 
+// ReloadedConfigMap builds the name for the config map updated in place when the collector reloads its configuration.
+func ReloadedConfigMap(otelcol string) string {
+	return DNSName(Truncate("%s-collector-config", 63, otelcol))
+}
+
 // TAConfigMap returns the name for the config map used in the TargetAllocator.
 func TAConfigMap(targetAllocator string) string {
 	return DNSName(Truncate("%s-targetallocator", 63, targetAllocator))
@@ -25,6 +30,27 @@ func ConfigMapVolume() string {
 	return "otc-internal"
 }
 
+// PersistenceVolume returns the name to use for the persistent volume of the collector pods.
+func PersistenceVolume() string {
+	return "otc-persistence"
+}
+
+// HostFSVolume returns the name to use for the volume of the file system of the node in the collector pods.
+func HostFSVolume() string {
+	return "otc-hostfs"
+}
+
+// PodLogsVolume returns the name to use for the volume of the pod logs of the node in the collector pods.
+func PodLogsVolume() string {
+	return "otc-pod-logs"
+}
+
+// ContainerLogsVolume returns the name to use for the volume of the Docker container logs of the node in the
+// collector pods.
+func ContainerLogsVolume() string {
+	return "otc-container-logs"
+}
+
 // ConfigMapExtra returns the prefix to use for the extras mounted configmaps in the pod.
 func ConfigMapExtra(extraConfigMapName string) string {
 	return DNSName(Truncate("configmap-%s", 63, extraConfigMapName))
@@ -40,11 +66,31 @@ func OpAMPBridgeConfigMapVolume() string {
 	return "opamp-bridge-internal"
 }
 
+// OpAMPBridgeTLSVolume returns the name to use for the client certificate's volume in the OpAMPBridge pod.
+func OpAMPBridgeTLSVolume() string {
+	return "opamp-bridge-tls"
+}
+
+// OpAMPBridgeCAVolume returns the name to use for the server CA certificate's volume in the OpAMPBridge pod.
+func OpAMPBridgeCAVolume() string {
+	return "opamp-bridge-ca"
+}
+
 // Container returns the name to use for the container in the pod.
 func Container() string {
 	return "otc-container"
 }
 
+// ConfigReloaderContainer returns the name of the container reloading the configuration of the collector.
+func ConfigReloaderContainer() string {
+	return "otc-config-reloader"
+}
+
+// JobStopperContainer returns the name of the container stopping the collector of the job pods.
+func JobStopperContainer() string {
+	return "otc-job-stopper"
+}
+
 // TAContainer returns the name to use for the container in the TargetAllocator pod.
 func TAContainer() string {
 	return "ta-container"
@@ -60,11 +106,36 @@ func Collector(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// WindowsCollector builds the name of the collector DaemonSet of the Windows nodes based on the instance.
+func WindowsCollector(otelcol string) string {
+	return DNSName(Truncate("%s-collector-windows", 63, otelcol))
+}
+
+// CollectorNetworkPolicy builds the network policy name of the collector based on the instance.
+func CollectorNetworkPolicy(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
+// CollectorCanary builds the name of the canary deployment of the collector based on the instance.
+func CollectorCanary(otelcol string) string {
+	return DNSName(Truncate("%s-collector-canary", 63, otelcol))
+}
+
 // HorizontalPodAutoscaler builds the autoscaler name based on the instance.
 func HorizontalPodAutoscaler(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// ScaledObject builds the KEDA ScaledObject name based on the instance.
+func ScaledObject(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
+// VerticalPodAutoscaler builds the VerticalPodAutoscaler name based on the instance.
+func VerticalPodAutoscaler(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
 // PodDisruptionBudget builds the pdb name based on the instance.
 func PodDisruptionBudget(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
@@ -100,6 +171,11 @@ func HeadlessService(otelcol string) string {
 	return DNSName(Truncate("%s-headless", 63, Service(otelcol)))
 }
 
+// OTLPHeadlessService builds the name for the headless service of the OTLP receivers based on the instance.
+func OTLPHeadlessService(otelcol string) string {
+	return DNSName(Truncate("%s-otlp-headless", 63, Service(otelcol)))
+}
+
 // MonitoringService builds the name for the monitoring service based on the instance.
 func MonitoringService(otelcol string) string {
 	return DNSName(Truncate("%s-monitoring", 63, Service(otelcol)))
@@ -110,6 +186,11 @@ func ExtensionService(otelcol string) string {
 	return DNSName(Truncate("%s-extension", 63, Service(otelcol)))
 }
 
+// AdditionalService builds the name of an additional service of the instance.
+func AdditionalService(otelcol, service string) string {
+	return DNSName(Truncate("%s-%s", 63, Service(otelcol), service))
+}
+
 // Service builds the service name based on the instance.
 func Service(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
@@ -125,6 +206,11 @@ func Route(otelcol string, prefix string) string {
 	return DNSName(Truncate("%s-%s-route", 63, prefix, otelcol))
 }
 
+// GatewayRoute builds the Gateway API route name based on the instance.
+func GatewayRoute(otelcol string, prefix string) string {
+	return DNSName(Truncate("%s-%s-gateway-route", 63, prefix, otelcol))
+}
+
 // ClusterRole builds the cluster role name based on the instance.
 func ClusterRole(otelcol string, namespace string) string {
 	return DNSName(Truncate("%s-%s-cluster-role", 63, otelcol, namespace))
@@ -135,6 +221,11 @@ func ClusterRoleBinding(otelcol, namespace string) string {
 	return DNSName(Truncate("%s-%s-collector", 63, otelcol, namespace))
 }
 
+// TargetAllocatorClusterRoleBinding builds the name of the cluster role binding of the target allocator of the instance.
+func TargetAllocatorClusterRoleBinding(otelcol, namespace string) string {
+	return DNSName(Truncate("%s-%s-targetallocator", 63, otelcol, namespace))
+}
+
 // TAService returns the name to use for the TargetAllocator service.
 func TAService(taName string) string {
 	return DNSName(Truncate("%s-targetallocator", 63, taName))
@@ -160,6 +251,11 @@ func PodMonitor(otelcol string) string {
 	return DNSName(Truncate("%s-collector", 63, otelcol))
 }
 
+// PrometheusRule builds the prometheus rule name based on the instance.
+func PrometheusRule(otelcol string) string {
+	return DNSName(Truncate("%s-collector", 63, otelcol))
+}
+
 // TargetAllocatorServiceAccount returns the TargetAllocator service account resource name.
 func TargetAllocatorServiceAccount(otelcol string) string {
 	return DNSName(Truncate("%s-targetallocator", 63, otelcol))
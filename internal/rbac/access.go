@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	v1 "k8s.io/api/authorization/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -27,6 +29,16 @@ var _ SAReviewer = &Reviewer{}
 
 type Reviewer struct {
 	client kubernetes.Interface
+
+	// ttl is how long the results of the reviews are reused, they aren't when zero
+	ttl     time.Duration
+	mu      sync.Mutex
+	reviews map[string]cachedReview
+}
+
+type cachedReview struct {
+	review  *v1.SubjectAccessReview
+	expires time.Time
 }
 
 func NewReviewer(c kubernetes.Interface) *Reviewer {
@@ -35,6 +47,16 @@ func NewReviewer(c kubernetes.Interface) *Reviewer {
 	}
 }
 
+// NewCachingReviewer creates a reviewer reusing the results of the reviews for the given duration, which spares the API
+// server the same reviews on every reconciliation. The changes of the permissions are seen once the results expire.
+func NewCachingReviewer(c kubernetes.Interface, ttl time.Duration) *Reviewer {
+	return &Reviewer{
+		client:  c,
+		ttl:     ttl,
+		reviews: map[string]cachedReview{},
+	}
+}
+
 // AllSubjectAccessReviewsAllowed checks if all of subjectAccessReviews are explicitly allowed. If false, the method
 // returns the reviews that were denied.
 func AllSubjectAccessReviewsAllowed(subjectAccessReviews []*v1.SubjectAccessReview) (bool, []*v1.SubjectAccessReview) {
@@ -87,7 +109,44 @@ func (r *Reviewer) CanAccess(ctx context.Context, serviceAccount, serviceAccount
 			User:                  fmt.Sprintf(serviceAccountFmtStr, serviceAccountNamespace, serviceAccount),
 		},
 	}
-	return r.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if r.ttl == 0 {
+		return r.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	}
+
+	key := cacheKey(sar.Spec)
+	now := time.Now()
+	r.mu.Lock()
+	cached, ok := r.reviews[key]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.review.DeepCopy(), nil
+	}
+
+	review, err := r.client.AuthorizationV1().SubjectAccessReviews().Create(ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return review, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, c := range r.reviews {
+		if !now.Before(c.expires) {
+			delete(r.reviews, k)
+		}
+	}
+	r.reviews[key] = cachedReview{review: review.DeepCopy(), expires: now.Add(r.ttl)}
+	return review, nil
+}
+
+// cacheKey identifies the reviews of the same access by the same user.
+func cacheKey(spec v1.SubjectAccessReviewSpec) string {
+	key := spec.User
+	if res := spec.ResourceAttributes; res != nil {
+		key += fmt.Sprintf("|%s|%s|%s|%s|%s|%s|%s", res.Namespace, res.Verb, res.Group, res.Version, res.Resource, res.Subresource, res.Name)
+	}
+	if nonRes := spec.NonResourceAttributes; nonRes != nil {
+		key += fmt.Sprintf("|%s|%s", nonRes.Path, nonRes.Verb)
+	}
+	return key
 }
 
 // policyRuleToResourceAttributes converts a single policy rule in to a list of resource attribute requests.
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/authorization/v1"
@@ -224,3 +225,39 @@ func TestReviewer_CheckPolicyRules(t *testing.T) {
 		})
 	}
 }
+
+func TestCachingReviewer_CanAccess(t *testing.T) {
+	c := fake.NewSimpleClientset()
+	reviews := 0
+	c.PrependReactor(createVerb, sarResource, func(action kubeTesting.Action) (handled bool, ret runtime.Object, err error) {
+		reviews++
+		sar := action.(kubeTesting.CreateAction).GetObject().DeepCopyObject().(*v1.SubjectAccessReview)
+		sar.Status = v1.SubjectAccessReviewStatus{Allowed: true}
+		return true, sar, nil
+	})
+	pods := &v1.ResourceAttributes{Verb: "list", Resource: "pods"}
+
+	r := NewCachingReviewer(c, time.Hour)
+	for i := 0; i < 2; i++ {
+		sar, err := r.CanAccess(context.Background(), "test", "default", pods, nil)
+		assert.NoError(t, err)
+		assert.True(t, sar.Status.Allowed)
+	}
+	assert.Equal(t, 1, reviews)
+
+	// another service account or another access is reviewed
+	_, err := r.CanAccess(context.Background(), "other", "default", pods, nil)
+	assert.NoError(t, err)
+	_, err = r.CanAccess(context.Background(), "test", "default", &v1.ResourceAttributes{Verb: "watch", Resource: "pods"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, reviews)
+
+	// the expired results are reviewed again
+	r = NewCachingReviewer(c, time.Nanosecond)
+	for i := 0; i < 2; i++ {
+		_, err = r.CanAccess(context.Background(), "test", "default", pods, nil)
+		assert.NoError(t, err)
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, 5, reviews)
+}
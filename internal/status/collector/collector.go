@@ -9,10 +9,15 @@ import (
 	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/imageversion"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
@@ -30,6 +35,9 @@ func updateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 	if mode == v1beta1.ModeSidecar {
 		changed.Status.Scale.Replicas = 0
 		changed.Status.Scale.Selector = ""
+		changed.Status.Job = nil
+		changed.Status.ImageVersion = ""
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1beta1.ConditionRolloutComplete)
 		return nil
 	}
 
@@ -53,6 +61,8 @@ func updateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 	var readyReplicas int32
 	var statusReplicas string
 	var statusImage string
+	// the progress of the rollout of the pods, outside of the job modes
+	rollout := rolloutProgress{observed: true}
 
 	switch mode { // nolint:exhaustive
 	case v1beta1.ModeDeployment:
@@ -64,6 +74,8 @@ func updateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 		readyReplicas = obj.Status.ReadyReplicas
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		rollout.add(obj.Generation, obj.Status.ObservedGeneration, ptr.Deref(obj.Spec.Replicas, 1),
+			obj.Status.Replicas, obj.Status.UpdatedReplicas, obj.Status.AvailableReplicas)
 
 	case v1beta1.ModeStatefulSet:
 		obj := &appsv1.StatefulSet{}
@@ -74,21 +86,129 @@ func updateCollectorStatus(ctx context.Context, cli client.Client, changed *v1be
 		readyReplicas = obj.Status.ReadyReplicas
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		rollout.add(obj.Generation, obj.Status.ObservedGeneration, ptr.Deref(obj.Spec.Replicas, 1),
+			obj.Status.Replicas, obj.Status.UpdatedReplicas, obj.Status.AvailableReplicas)
 
 	case v1beta1.ModeDaemonSet:
-		obj := &appsv1.DaemonSet{}
+		// the pods of the daemonsets of every operating system of the nodes
+		var names []string
+		if changed.Spec.RunsOn(v1beta1.NodeOSLinux) {
+			names = append(names, naming.Collector(changed.Name))
+		}
+		if changed.Spec.RunsOn(v1beta1.NodeOSWindows) {
+			names = append(names, naming.WindowsCollector(changed.Name))
+		}
+		for _, name := range names {
+			obj := &appsv1.DaemonSet{}
+			if err := cli.Get(ctx, client.ObjectKey{Namespace: changed.GetNamespace(), Name: name}, obj); err != nil {
+				return fmt.Errorf("failed to get daemonSet status.replicas: %w", err)
+			}
+			replicas += obj.Status.DesiredNumberScheduled
+			readyReplicas += obj.Status.NumberReady
+			rollout.add(obj.Generation, obj.Status.ObservedGeneration, obj.Status.DesiredNumberScheduled,
+				obj.Status.CurrentNumberScheduled, obj.Status.UpdatedNumberScheduled, obj.Status.NumberAvailable)
+			if statusImage == "" {
+				statusImage = obj.Spec.Template.Spec.Containers[0].Image
+			}
+		}
+		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
+
+	case v1beta1.ModeJob:
+		obj := &batchv1.Job{}
 		if err := cli.Get(ctx, objKey, obj); err != nil {
-			return fmt.Errorf("failed to get daemonSet status.replicas: %w", err)
+			return fmt.Errorf("failed to get job status.active: %w", err)
 		}
-		replicas = obj.Status.DesiredNumberScheduled
-		readyReplicas = obj.Status.NumberReady
+		replicas = obj.Status.Active
+		readyReplicas = ptr.Deref(obj.Status.Ready, 0)
 		statusReplicas = strconv.Itoa(int(readyReplicas)) + "/" + strconv.Itoa(int(replicas))
 		statusImage = obj.Spec.Template.Spec.Containers[0].Image
+		changed.Status.Job = &v1beta1.JobStatus{
+			Phase:          jobPhase(obj),
+			Active:         obj.Status.Active,
+			Succeeded:      obj.Status.Succeeded,
+			Failed:         obj.Status.Failed,
+			StartTime:      obj.Status.StartTime,
+			CompletionTime: obj.Status.CompletionTime,
+		}
+
+	case v1beta1.ModeCronJob:
+		obj := &batchv1.CronJob{}
+		if err := cli.Get(ctx, objKey, obj); err != nil {
+			return fmt.Errorf("failed to get cronJob status.active: %w", err)
+		}
+		replicas = int32(len(obj.Status.Active))
+		statusImage = obj.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image
+		changed.Status.Job = &v1beta1.JobStatus{
+			Active:             replicas,
+			LastScheduleTime:   obj.Status.LastScheduleTime,
+			LastSuccessfulTime: obj.Status.LastSuccessfulTime,
+		}
+	}
+	if mode != v1beta1.ModeJob && mode != v1beta1.ModeCronJob {
+		changed.Status.Job = nil
+		meta.SetStatusCondition(&changed.Status.Conditions, rollout.condition(changed.Generation))
+	} else {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1beta1.ConditionRolloutComplete)
 	}
 
 	changed.Status.Scale.Replicas = replicas
 	changed.Status.Image = statusImage
+	changed.Status.ImageVersion = imageversion.FromImage(statusImage)
 	changed.Status.Scale.StatusReplicas = statusReplicas
 
 	return nil
 }
+
+// rolloutProgress sums the pods of the workloads of the collector.
+type rolloutProgress struct {
+	observed bool
+	desired  int32
+	total    int32
+	updated  int32
+	// the available pods among the updated ones
+	available int32
+}
+
+func (r *rolloutProgress) add(generation, observedGeneration int64, desired, total, updated, available int32) {
+	r.observed = r.observed && observedGeneration >= generation
+	r.desired += desired
+	r.total += total
+	r.updated += updated
+	r.available += min(available, updated)
+}
+
+func (r *rolloutProgress) condition(generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionRolloutComplete,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             v1beta1.ReasonRolloutInProgress,
+	}
+	switch {
+	case !r.observed:
+		condition.Message = "the workloads of the collector haven't observed their latest spec yet"
+	case r.updated == r.desired && r.available == r.desired && r.total == r.desired:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.ReasonRolloutComplete
+		condition.Message = fmt.Sprintf("%d pods updated and available", r.desired)
+	default:
+		condition.Message = fmt.Sprintf("%d of %d pods updated, %d available, %d old pods", r.updated, r.desired, r.available, max(r.total-r.updated, 0))
+	}
+	return condition
+}
+
+// jobPhase returns the phase of the given Job from its conditions.
+func jobPhase(job *batchv1.Job) v1beta1.JobPhase {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type { // nolint:exhaustive
+		case batchv1.JobComplete:
+			return v1beta1.JobPhaseSucceeded
+		case batchv1.JobFailed:
+			return v1beta1.JobPhaseFailed
+		}
+	}
+	return v1beta1.JobPhaseRunning
+}
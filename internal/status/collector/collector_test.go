@@ -8,9 +8,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -86,6 +90,76 @@ func TestUpdateCollectorStatusDeploymentMode(t *testing.T) {
 	assert.Equal(t, "app:latest", changed.Status.Image, "expected image to be app:latest")
 }
 
+func TestUpdateCollectorStatusRolloutComplete(t *testing.T) {
+	ctx := context.TODO()
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-deployment-collector",
+			Namespace:  "default",
+			Generation: 2,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](3),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "otel/opentelemetry-collector:0.120.0"}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           4,
+			UpdatedReplicas:    2,
+			ReadyReplicas:      4,
+			AvailableReplicas:  4,
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(deployment).Build()
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-deployment",
+			Namespace:  "default",
+			Generation: 5,
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+		},
+	}
+
+	require.NoError(t, updateCollectorStatus(ctx, cli, changed))
+	condition := meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionRolloutComplete)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1beta1.ReasonRolloutInProgress, condition.Reason)
+	assert.Equal(t, "2 of 3 pods updated, 2 available, 2 old pods", condition.Message)
+	assert.Equal(t, int64(5), condition.ObservedGeneration)
+	assert.Equal(t, "0.120.0", changed.Status.ImageVersion)
+
+	deployment.Status = appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3}
+	require.NoError(t, cli.Status().Update(ctx, deployment))
+	require.NoError(t, updateCollectorStatus(ctx, cli, changed))
+	condition = meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionRolloutComplete)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, "3 pods updated and available", condition.Message)
+
+	// the spec changed since
+	changedSpec := deployment.DeepCopy()
+	changedSpec.ResourceVersion = ""
+	changedSpec.Generation = 3
+	cli = fake.NewClientBuilder().WithObjects(changedSpec).Build()
+	require.NoError(t, updateCollectorStatus(ctx, cli, changed))
+	condition = meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionRolloutComplete)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+
+	// the sidecars are rolled out with the workloads they are injected in
+	changed.Spec.Mode = v1beta1.ModeSidecar
+	require.NoError(t, updateCollectorStatus(ctx, cli, changed))
+	assert.Nil(t, meta.FindStatusCondition(changed.Status.Conditions, v1beta1.ConditionRolloutComplete))
+	assert.Empty(t, changed.Status.ImageVersion)
+}
+
 func createMockKubernetesClientStatefulset() client.Client {
 	statefulset := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -185,3 +259,157 @@ func TestUpdateCollectorStatusDaemonsetMode(t *testing.T) {
 	assert.Contains(t, changed.Status.Scale.Selector, "customLabel=customValue", "expected selector to contain customlabel=customValue")
 	assert.Equal(t, "app:latest", changed.Status.Image, "expected image to be app:latest")
 }
+
+func TestUpdateCollectorStatusWindowsDaemonsets(t *testing.T) {
+	ctx := context.TODO()
+	windows := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-daemonset-collector-windows",
+			Namespace: "default",
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "app", Image: "app:windows"}},
+				},
+			},
+		},
+		Status: appsv1.DaemonSetStatus{
+			DesiredNumberScheduled: 2,
+			NumberReady:            1,
+		},
+	}
+	cli := createMockKubernetesClientDaemonset()
+	require.NoError(t, cli.Create(ctx, windows))
+
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-daemonset",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode:   v1beta1.ModeDaemonSet,
+			NodeOS: []v1beta1.NodeOS{v1beta1.NodeOSLinux, v1beta1.NodeOSWindows},
+		},
+	}
+
+	err := updateCollectorStatus(ctx, cli, changed)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(3), changed.Status.Scale.Replicas)
+	assert.Equal(t, "2/3", changed.Status.Scale.StatusReplicas)
+	assert.Equal(t, "app:latest", changed.Status.Image)
+
+	// only the windows nodes
+	changed.Spec.NodeOS = []v1beta1.NodeOS{v1beta1.NodeOSWindows}
+	err = updateCollectorStatus(ctx, cli, changed)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "1/2", changed.Status.Scale.StatusReplicas)
+	assert.Equal(t, "app:windows", changed.Status.Image)
+}
+
+func TestUpdateCollectorStatusJobMode(t *testing.T) {
+	ctx := context.TODO()
+	start := metav1.Unix(1735689600, 0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-job-collector",
+			Namespace: "default",
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "app",
+							Image: "app:latest",
+						},
+					},
+				},
+			},
+		},
+		Status: batchv1.JobStatus{
+			Succeeded:      2,
+			StartTime:      &start,
+			CompletionTime: &start,
+			Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobSuccessCriteriaMet, Status: corev1.ConditionTrue},
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(job).Build()
+
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-job",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeJob,
+		},
+	}
+
+	err := updateCollectorStatus(ctx, cli, changed)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(0), changed.Status.Scale.Replicas, "expected replicas to be 0")
+	assert.Equal(t, "app:latest", changed.Status.Image, "expected image to be app:latest")
+	assert.Equal(t, &v1beta1.JobStatus{
+		Phase:          v1beta1.JobPhaseSucceeded,
+		Succeeded:      2,
+		StartTime:      job.Status.StartTime,
+		CompletionTime: job.Status.CompletionTime,
+	}, changed.Status.Job)
+}
+
+func TestUpdateCollectorStatusCronJobMode(t *testing.T) {
+	ctx := context.TODO()
+	scheduled := metav1.Unix(1735689600, 0)
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cronjob-collector",
+			Namespace: "default",
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule: "@hourly",
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:  "app",
+									Image: "app:latest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: batchv1.CronJobStatus{
+			Active:           []corev1.ObjectReference{{Name: "test-cronjob-collector-1"}},
+			LastScheduleTime: &scheduled,
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(cronJob).Build()
+
+	changed := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cronjob",
+			Namespace: "default",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeCronJob,
+		},
+	}
+
+	err := updateCollectorStatus(ctx, cli, changed)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int32(1), changed.Status.Scale.Replicas, "expected replicas to be 1")
+	assert.Equal(t, "app:latest", changed.Status.Image, "expected image to be app:latest")
+	assert.Equal(t, &v1beta1.JobStatus{Active: 1, LastScheduleTime: cronJob.Status.LastScheduleTime}, changed.Status.Job)
+}
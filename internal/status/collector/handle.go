@@ -6,20 +6,33 @@ package collector
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
-	reasonError         = "Error"
-	reasonStatusFailure = "StatusFailure"
-	reasonInfo          = "Info"
+	reasonError          = "Error"
+	reasonStatusFailure  = "StatusFailure"
+	reasonInfo           = "Info"
+	reasonConfigConflict = "ConfigConflict"
+	reasonRolloutFailed  = "RolloutFailed"
+	reasonRBACMissing    = "MissingPermissions"
+	reasonJobFailed      = "JobFailed"
+	reasonConfigInvalid  = "ConfigInvalid"
+	reasonConfigWarning  = "ConfigWarning"
+	reasonConfigRendered = "ConfigRendered"
 )
 
 // HandleReconcileStatus handles updating the status of the CRDs managed by the operator.
@@ -27,16 +40,94 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params manifest
 	log.V(2).Info("updating collector status")
 	if err != nil {
 		params.Recorder.Event(&otelcol, corev1.EventTypeWarning, reasonError, err.Error())
+		// the GitOps tools see the collector degraded rather than still progressing
+		changed := otelcol.DeepCopy()
+		changed.Status.ObservedGeneration = changed.Generation
+		meta.SetStatusCondition(&changed.Status.Conditions, reconcileFailedCondition(changed, err))
+		if patchErr := params.Client.Status().Patch(ctx, changed, client.MergeFrom(&otelcol)); patchErr != nil {
+			log.Error(patchErr, "failed to apply the reconcile failure to the OpenTelemetry CR status")
+		}
 		return ctrl.Result{}, err
 	}
 
 	changed := otelcol.DeepCopy()
+	// the config sources are merged and the RBAC rules are checked while building the params
+	changed.Status.ConfigConflicts = params.OtelCol.Status.ConfigConflicts
+	if len(changed.Status.ConfigConflicts) > 0 && !slices.Equal(changed.Status.ConfigConflicts, otelcol.Status.ConfigConflicts) {
+		params.Recorder.Event(changed, corev1.EventTypeWarning, reasonConfigConflict, fmt.Sprintf("config sources conflict at %s", strings.Join(changed.Status.ConfigConflicts, ", ")))
+	}
+	changed.Status.MissingPermissions = params.OtelCol.Status.MissingPermissions
+	if len(changed.Status.MissingPermissions) > 0 && !slices.Equal(changed.Status.MissingPermissions, otelcol.Status.MissingPermissions) {
+		params.Recorder.Event(changed, corev1.EventTypeWarning, reasonRBACMissing, fmt.Sprintf("RBAC rules are missing: %s", strings.Join(changed.Status.MissingPermissions, "; ")))
+	}
+	// the canary and partitioned rollouts are evaluated while reconciling the collector deployment or statefulset
+	changed.Status.Rollout = params.OtelCol.Status.Rollout
+	if rollout := changed.Status.Rollout; rollout != nil && rollout.Phase == v1beta1.RolloutPhaseFailed {
+		params.Recorder.Event(changed, corev1.EventTypeWarning, reasonRolloutFailed, fmt.Sprintf("rollout of %s failed: %s", rollout.ConfigMap, rollout.Message))
+	}
+	// the configuration rendered by the operator, with the config sources and the enrichment processors
+	configHash, err := manifestutils.GetConfigMapSHA(params.OtelCol.Spec.Config)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if configHash != otelcol.Status.ConfigHash {
+		params.Recorder.Event(changed, corev1.EventTypeNormal, reasonConfigRendered, fmt.Sprintf("rendered the collector configuration %s", configHash))
+	}
+	changed.Status.ConfigHash = configHash
+	configCondition := metav1.Condition{
+		Type:               v1beta1.ConditionConfigValid,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: changed.Generation,
+		Reason:             v1beta1.ReasonValid,
+		Message:            "the collector configuration is valid",
+	}
+	if params.OtelCol.Spec.HasSecretConfigSources() {
+		// the collector completes its configuration with the Secret config sources
+		configCondition.Status = metav1.ConditionUnknown
+		configCondition.Reason = v1beta1.ReasonUnvalidated
+		configCondition.Message = "the collector configuration includes Secret config sources, which only the collector reads"
+	} else if err := params.OtelCol.Spec.Config.Validate(); err != nil {
+		configCondition.Status = metav1.ConditionFalse
+		configCondition.Reason = v1beta1.ReasonInvalid
+		configCondition.Message = err.Error()
+		params.Recorder.Event(changed, corev1.EventTypeWarning, reasonConfigInvalid, fmt.Sprintf("the collector configuration is invalid: %s", err))
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, configCondition)
+	if featuregate.EnforceFIPS.IsEnabled() {
+		meta.SetStatusCondition(&changed.Status.Conditions, fipsCompliantCondition(&params.OtelCol, params.Config))
+	} else {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1beta1.ConditionFIPSCompliant)
+	}
+	// the upgrade of the collector is checked before building its manifests
+	if heldBack := meta.FindStatusCondition(params.OtelCol.Status.Conditions, v1beta1.ConditionUpgradeHeldBack); heldBack != nil {
+		meta.SetStatusCondition(&changed.Status.Conditions, *heldBack)
+	} else {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1beta1.ConditionUpgradeHeldBack)
+	}
+	warnings := v1beta1.ConfigWarnings(&params.OtelCol)
+	warningsCondition := configWarningsCondition(changed, warnings)
+	// the warnings are recorded once, when they change, rather than at every reconciliation
+	if previous := meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionConfigWarnings); previous == nil || previous.Message != warningsCondition.Message {
+		for _, warning := range warnings {
+			params.Recorder.Event(changed, corev1.EventTypeWarning, reasonConfigWarning, warning)
+		}
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, warningsCondition)
+
+	// the ExporterHealthy condition is set by the HealthChecker
 	statusErr := updateCollectorStatus(ctx, params.Client, changed)
 
+	if job := changed.Status.Job; job != nil && job.Phase == v1beta1.JobPhaseFailed &&
+		(otelcol.Status.Job == nil || otelcol.Status.Job.Phase != v1beta1.JobPhaseFailed) {
+		params.Recorder.Event(changed, corev1.EventTypeWarning, reasonJobFailed, fmt.Sprintf("the collector job failed after %d failed pods", job.Failed))
+	}
+
 	if statusErr != nil {
 		params.Recorder.Event(changed, corev1.EventTypeWarning, reasonStatusFailure, statusErr.Error())
 		return ctrl.Result{}, statusErr
 	}
+	meta.SetStatusCondition(&changed.Status.Conditions, readyCondition(changed))
+	changed.Status.ObservedGeneration = changed.Generation
 	statusPatch := client.MergeFrom(&otelcol)
 	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to apply status changes to the OpenTelemetry CR: %w", err)
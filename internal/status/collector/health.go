@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+const (
+	// healthCheckPeriod is how often the health of the collector pods is checked.
+	healthCheckPeriod = time.Minute
+	// maxHealthCheckedPods bounds the number of pods checked at once, e.g. for the daemonsets of large clusters.
+	maxHealthCheckedPods = 5
+)
+
+var healthClient = &http.Client{Timeout: 2 * time.Second}
+
+// HealthChecker checks the health of the collector pods every minute and sets the ExporterHealthy condition of their
+// collectors. It runs apart from the reconciliations, which the health of the pods doesn't trigger.
+type HealthChecker struct {
+	client client.Client
+	pods   client.Reader
+	log    logr.Logger
+}
+
+// NewHealthChecker creates a health checker reading the collectors with the given client and their pods with the given
+// reader, like the cache of the pods managed by the operator.
+func NewHealthChecker(cl client.Client, pods client.Reader, log logr.Logger) *HealthChecker {
+	return &HealthChecker{client: cl, pods: pods, log: log}
+}
+
+// Start checks the health of the collectors until the context is done.
+func (h *HealthChecker) Start(ctx context.Context) error {
+	wait.UntilWithContext(ctx, h.checkCollectors, healthCheckPeriod)
+	return nil
+}
+
+// NeedLeaderElection makes only the leader check the health of the collectors.
+func (h *HealthChecker) NeedLeaderElection() bool {
+	return true
+}
+
+func (h *HealthChecker) checkCollectors(ctx context.Context) {
+	collectors := &v1beta1.OpenTelemetryCollectorList{}
+	if err := h.client.List(ctx, collectors); err != nil {
+		h.log.Error(err, "failed to list the collectors to check their health")
+		return
+	}
+	for i := range collectors.Items {
+		otelcol := &collectors.Items[i]
+		if err := h.check(ctx, otelcol); err != nil {
+			h.log.Error(err, "failed to check the health of the collector", "namespace", otelcol.Namespace, "name", otelcol.Name)
+		}
+	}
+}
+
+// check patches the ExporterHealthy condition of the given collector when it changed.
+func (h *HealthChecker) check(ctx context.Context, otelcol *v1beta1.OpenTelemetryCollector) error {
+	probe, err := otelcol.Spec.Config.GetLivenessProbe(h.log)
+	if err != nil {
+		return err
+	}
+	changed := otelcol.DeepCopy()
+	if err = updateExporterHealth(ctx, h.pods, changed, probe); err != nil {
+		return err
+	}
+	if apiequality.Semantic.DeepEqual(changed.Status.Conditions, otelcol.Status.Conditions) {
+		return nil
+	}
+	return h.client.Status().Patch(ctx, changed, client.MergeFrom(otelcol))
+}
+
+// updateExporterHealth sets the ExporterHealthy condition from the health_check extension of the ready collector
+// pods, reached through the endpoint of the liveness probe.
+func updateExporterHealth(ctx context.Context, pods client.Reader, changed *v1beta1.OpenTelemetryCollector, probe *corev1.Probe) error {
+	if changed.Spec.Mode == v1beta1.ModeSidecar || probe == nil || probe.HTTPGet == nil || probe.HTTPGet.Port.IntValue() == 0 {
+		meta.RemoveStatusCondition(&changed.Status.Conditions, v1beta1.ConditionExporterHealthy)
+		return nil
+	}
+
+	podList := &corev1.PodList{}
+	if err := pods.List(ctx, podList, client.InNamespace(changed.Namespace),
+		client.MatchingLabels(manifestutils.SelectorLabels(changed.ObjectMeta, collector.ComponentOpenTelemetryCollector))); err != nil {
+		return fmt.Errorf("failed to list the collector pods: %w", err)
+	}
+	var ready []corev1.Pod
+	for _, pod := range podList.Items {
+		if pod.DeletionTimestamp == nil && pod.Status.PodIP != "" && podReady(pod) {
+			ready = append(ready, pod)
+		}
+	}
+	slices.SortFunc(ready, func(a, b corev1.Pod) int { return strings.Compare(a.Name, b.Name) })
+	if len(ready) > maxHealthCheckedPods {
+		ready = ready[:maxHealthCheckedPods]
+	}
+
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionExporterHealthy,
+		ObservedGeneration: changed.Generation,
+	}
+	var unhealthy, unreachable []string
+	for _, pod := range ready {
+		url := fmt.Sprintf("http://%s%s", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(probe.HTTPGet.Port.IntValue())), probe.HTTPGet.Path)
+		status, err := checkHealth(ctx, url)
+		if err != nil {
+			unreachable = append(unreachable, fmt.Sprintf("%s: %v", pod.Name, err))
+		} else if status < http.StatusOK || status >= http.StatusMultipleChoices {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s: HTTP %d", pod.Name, status))
+		}
+	}
+	switch {
+	case len(ready) == 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = v1beta1.ReasonUnreachable
+		condition.Message = "no ready collector pod to check"
+	case len(unhealthy) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.ReasonUnhealthy
+		condition.Message = "the health_check extension reports unhealthy pods: " + strings.Join(unhealthy, ", ")
+	case len(unreachable) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = v1beta1.ReasonUnreachable
+		condition.Message = "the health_check extension of some pods is unreachable: " + strings.Join(unreachable, ", ")
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.ReasonHealthy
+		condition.Message = fmt.Sprintf("the health_check extension reports the %d checked pods healthy", len(ready))
+	}
+	meta.SetStatusCondition(&changed.Status.Conditions, condition)
+	return nil
+}
+
+func checkHealth(ctx context.Context, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := healthClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func podReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
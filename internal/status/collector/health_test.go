@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+func TestUpdateExporterHealth(t *testing.T) {
+	healthy := map[string]bool{"/": true}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy[r.URL.Path] {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverURL.Port())
+	require.NoError(t, err)
+	probe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromInt(port)}}}
+
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 2},
+		Spec:       v1beta1.OpenTelemetryCollectorSpec{Mode: v1beta1.ModeDeployment},
+	}
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    manifestutils.SelectorLabels(otelcol.ObjectMeta, collector.ComponentOpenTelemetryCollector),
+			},
+			Status: corev1.PodStatus{
+				PodIP:      "127.0.0.1",
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	ctx := context.Background()
+
+	// no ready pod
+	cli := fake.NewClientBuilder().WithObjects(pod("test-collector-0", corev1.ConditionFalse)).Build()
+	require.NoError(t, updateExporterHealth(ctx, cli, otelcol, probe))
+	condition := meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionExporterHealthy)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, v1beta1.ReasonUnreachable, condition.Reason)
+
+	// healthy pods
+	require.NoError(t, cli.Create(ctx, pod("test-collector-1", corev1.ConditionTrue)))
+	require.NoError(t, updateExporterHealth(ctx, cli, otelcol, probe))
+	condition = meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionExporterHealthy)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1beta1.ReasonHealthy, condition.Reason)
+	assert.Equal(t, "the health_check extension reports the 1 checked pods healthy", condition.Message)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+
+	// unhealthy pods
+	probe.HTTPGet.Path = "/unhealthy"
+	require.NoError(t, updateExporterHealth(ctx, cli, otelcol, probe))
+	condition = meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionExporterHealthy)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1beta1.ReasonUnhealthy, condition.Reason)
+	assert.Equal(t, "the health_check extension reports unhealthy pods: test-collector-1: HTTP 503", condition.Message)
+
+	// unreachable pods
+	server.Close()
+	require.NoError(t, updateExporterHealth(ctx, cli, otelcol, probe))
+	condition = meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionExporterHealthy)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, v1beta1.ReasonUnreachable, condition.Reason)
+
+	// without the health_check extension
+	require.NoError(t, updateExporterHealth(ctx, cli, otelcol, nil))
+	assert.Nil(t, meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionExporterHealthy))
+}
+
+func TestUpdateExporterHealthChecksFewPods(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { requests++ }))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverURL.Port())
+	require.NoError(t, err)
+	probe := &corev1.Probe{ProbeHandler: corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/", Port: intstr.FromInt(port)}}}
+
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       v1beta1.OpenTelemetryCollectorSpec{Mode: v1beta1.ModeDaemonSet},
+	}
+	var pods []client.Object
+	for i := 0; i < 8; i++ {
+		pods = append(pods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-collector-%d", i),
+				Namespace: "default",
+				Labels:    manifestutils.SelectorLabels(otelcol.ObjectMeta, collector.ComponentOpenTelemetryCollector),
+			},
+			Status: corev1.PodStatus{
+				PodIP:      "127.0.0.1",
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		})
+	}
+	cli := fake.NewClientBuilder().WithObjects(pods...).Build()
+
+	require.NoError(t, updateExporterHealth(context.Background(), cli, otelcol, probe))
+	assert.Equal(t, maxHealthCheckedPods, requests)
+	condition := meta.FindStatusCondition(otelcol.Status.Conditions, v1beta1.ConditionExporterHealthy)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+}
+
+func TestHealthCheckerCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeDeployment,
+			Config: v1beta1.Config{
+				Extensions: &v1beta1.AnyConfig{Object: map[string]interface{}{
+					"health_check": map[string]interface{}{"endpoint": "0.0.0.0:" + serverURL.Port()},
+				}},
+				Service: v1beta1.Service{Extensions: []string{"health_check"}},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-collector-0",
+			Namespace: "default",
+			Labels:    manifestutils.SelectorLabels(otelcol.ObjectMeta, collector.ComponentOpenTelemetryCollector),
+		},
+		Status: corev1.PodStatus{
+			PodIP:      "127.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(otelcol, pod).WithStatusSubresource(otelcol).Build()
+	ctx := context.Background()
+
+	checker := NewHealthChecker(cli, cli, logr.Discard())
+	checker.checkCollectors(ctx)
+	checked := &v1beta1.OpenTelemetryCollector{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(otelcol), checked))
+	condition := meta.FindStatusCondition(checked.Status.Conditions, v1beta1.ConditionExporterHealthy)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	// the status isn't patched again while the health doesn't change
+	require.NoError(t, checker.check(ctx, checked))
+	unchanged := &v1beta1.OpenTelemetryCollector{}
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(otelcol), unchanged))
+	assert.Equal(t, checked.ResourceVersion, unchanged.ResourceVersion)
+}
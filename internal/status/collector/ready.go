@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// readyCondition sums up the status of the collector, computed from its other conditions and the status of its
+// workloads, into the Ready condition. The ExporterHealthy condition is left out, as the exporters of healthy
+// collectors may fail for a while, e.g. when their backend restarts.
+func readyCondition(otelcol *v1beta1.OpenTelemetryCollector) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: otelcol.Generation,
+	}
+	status := otelcol.Status
+
+	if config := meta.FindStatusCondition(status.Conditions, v1beta1.ConditionConfigValid); config != nil && config.Status == metav1.ConditionFalse {
+		condition.Reason = v1beta1.ReasonDegraded
+		condition.Message = fmt.Sprintf("the collector configuration is invalid: %s", config.Message)
+		return condition
+	}
+	if rollout := status.Rollout; rollout != nil && rollout.Phase == v1beta1.RolloutPhaseFailed {
+		condition.Reason = v1beta1.ReasonDegraded
+		condition.Message = fmt.Sprintf("the rollout of the configuration failed: %s", rollout.Message)
+		return condition
+	}
+
+	switch otelcol.Spec.Mode { // nolint:exhaustive
+	case v1beta1.ModeSidecar:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.ReasonReady
+		condition.Message = "the collector is injected as a sidecar into the annotated pods"
+	case v1beta1.ModeJob:
+		switch {
+		case status.Job == nil:
+			condition.Reason = v1beta1.ReasonProgressing
+			condition.Message = "the collector job hasn't started yet"
+		case status.Job.Phase == v1beta1.JobPhaseFailed:
+			condition.Reason = v1beta1.ReasonDegraded
+			condition.Message = fmt.Sprintf("the collector job failed after %d failed pods", status.Job.Failed)
+		case status.Job.Phase == v1beta1.JobPhaseSucceeded:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = v1beta1.ReasonReady
+			condition.Message = "the collector job completed"
+		case status.Job.Active > 0:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = v1beta1.ReasonReady
+			condition.Message = "the collector job is running"
+		default:
+			condition.Reason = v1beta1.ReasonProgressing
+			condition.Message = "the collector job has no active pod yet"
+		}
+	case v1beta1.ModeCronJob:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.ReasonReady
+		condition.Message = "the collector cronjob is scheduled"
+	default:
+		rollout := meta.FindStatusCondition(status.Conditions, v1beta1.ConditionRolloutComplete)
+		switch {
+		case rollout == nil:
+			condition.Reason = v1beta1.ReasonProgressing
+			condition.Message = "the rollout of the collector pods is unknown"
+		case rollout.Status == metav1.ConditionTrue:
+			condition.Status = metav1.ConditionTrue
+			condition.Reason = v1beta1.ReasonReady
+			condition.Message = rollout.Message
+		default:
+			condition.Reason = v1beta1.ReasonProgressing
+			condition.Message = rollout.Message
+		}
+	}
+	return condition
+}
+
+// fipsCompliantCondition is the FIPSCompliant condition of the collector, telling whether its images are built for
+// FIPS.
+func fipsCompliantCondition(otelcol *v1beta1.OpenTelemetryCollector, cfg config.Config) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionFIPSCompliant,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: otelcol.Generation,
+		Reason:             v1beta1.ReasonCompliant,
+		Message:            "the images are built for FIPS",
+	}
+	if images := v1beta1.NonFIPSImages(otelcol, cfg); len(images) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = v1beta1.ReasonNonCompliant
+		condition.Message = fmt.Sprintf("the images %s aren't built for FIPS", strings.Join(images, ", "))
+	}
+	return condition
+}
+
+// configWarningsCondition is the ConfigWarnings condition of the collector, listing the warnings of its
+// configuration.
+func configWarningsCondition(otelcol *v1beta1.OpenTelemetryCollector, warnings []string) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               v1beta1.ConditionConfigWarnings,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: otelcol.Generation,
+		Reason:             v1beta1.ReasonNoWarnings,
+		Message:            "the collector configuration has no warnings",
+	}
+	if len(warnings) > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1beta1.ReasonWarnings
+		condition.Message = strings.Join(warnings, "; ")
+	}
+	return condition
+}
+
+// reconcileFailedCondition is the Ready condition of a collector the operator failed to reconcile.
+func reconcileFailedCondition(otelcol *v1beta1.OpenTelemetryCollector, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:               v1beta1.ConditionReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: otelcol.Generation,
+		Reason:             v1beta1.ReasonReconcileFailed,
+		Message:            err.Error(),
+	}
+}
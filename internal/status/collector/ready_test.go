@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestReadyCondition(t *testing.T) {
+	rolloutComplete := metav1.Condition{Type: v1beta1.ConditionRolloutComplete, Status: metav1.ConditionTrue, Message: "3 pods updated and available"}
+	rolloutInProgress := metav1.Condition{Type: v1beta1.ConditionRolloutComplete, Status: metav1.ConditionFalse, Message: "1 of 3 pods updated, 1 available, 2 old pods"}
+	configInvalid := metav1.Condition{Type: v1beta1.ConditionConfigValid, Status: metav1.ConditionFalse, Message: "no pipeline"}
+	unhealthy := metav1.Condition{Type: v1beta1.ConditionExporterHealthy, Status: metav1.ConditionFalse}
+
+	tests := []struct {
+		name            string
+		mode            v1beta1.Mode
+		status          v1beta1.OpenTelemetryCollectorStatus
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "rollout complete",
+			mode:            v1beta1.ModeDeployment,
+			status:          v1beta1.OpenTelemetryCollectorStatus{Conditions: []metav1.Condition{rolloutComplete, unhealthy}},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.ReasonReady,
+			expectedMessage: "3 pods updated and available",
+		},
+		{
+			name:            "rollout in progress",
+			mode:            v1beta1.ModeStatefulSet,
+			status:          v1beta1.OpenTelemetryCollectorStatus{Conditions: []metav1.Condition{rolloutInProgress}},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.ReasonProgressing,
+			expectedMessage: "1 of 3 pods updated, 1 available, 2 old pods",
+		},
+		{
+			name:            "rollout unknown",
+			mode:            v1beta1.ModeDaemonSet,
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.ReasonProgressing,
+			expectedMessage: "the rollout of the collector pods is unknown",
+		},
+		{
+			name:            "invalid config",
+			mode:            v1beta1.ModeDeployment,
+			status:          v1beta1.OpenTelemetryCollectorStatus{Conditions: []metav1.Condition{configInvalid, rolloutComplete}},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.ReasonDegraded,
+			expectedMessage: "the collector configuration is invalid: no pipeline",
+		},
+		{
+			name: "failed canary rollout",
+			mode: v1beta1.ModeDeployment,
+			status: v1beta1.OpenTelemetryCollectorStatus{
+				Rollout:    &v1beta1.RolloutStatus{Phase: v1beta1.RolloutPhaseFailed, Message: "canary pods crashed"},
+				Conditions: []metav1.Condition{rolloutComplete},
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.ReasonDegraded,
+			expectedMessage: "the rollout of the configuration failed: canary pods crashed",
+		},
+		{
+			name:            "sidecar",
+			mode:            v1beta1.ModeSidecar,
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.ReasonReady,
+			expectedMessage: "the collector is injected as a sidecar into the annotated pods",
+		},
+		{
+			name:            "job running",
+			mode:            v1beta1.ModeJob,
+			status:          v1beta1.OpenTelemetryCollectorStatus{Job: &v1beta1.JobStatus{Phase: v1beta1.JobPhaseRunning, Active: 1}},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.ReasonReady,
+			expectedMessage: "the collector job is running",
+		},
+		{
+			name:            "job pending",
+			mode:            v1beta1.ModeJob,
+			status:          v1beta1.OpenTelemetryCollectorStatus{Job: &v1beta1.JobStatus{Phase: v1beta1.JobPhaseRunning}},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.ReasonProgressing,
+			expectedMessage: "the collector job has no active pod yet",
+		},
+		{
+			name:            "job succeeded",
+			mode:            v1beta1.ModeJob,
+			status:          v1beta1.OpenTelemetryCollectorStatus{Job: &v1beta1.JobStatus{Phase: v1beta1.JobPhaseSucceeded}},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.ReasonReady,
+			expectedMessage: "the collector job completed",
+		},
+		{
+			name:            "job failed",
+			mode:            v1beta1.ModeJob,
+			status:          v1beta1.OpenTelemetryCollectorStatus{Job: &v1beta1.JobStatus{Phase: v1beta1.JobPhaseFailed, Failed: 4}},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  v1beta1.ReasonDegraded,
+			expectedMessage: "the collector job failed after 4 failed pods",
+		},
+		{
+			name:            "cronjob",
+			mode:            v1beta1.ModeCronJob,
+			status:          v1beta1.OpenTelemetryCollectorStatus{Job: &v1beta1.JobStatus{}},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  v1beta1.ReasonReady,
+			expectedMessage: "the collector cronjob is scheduled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otelcol := &v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 3},
+				Spec:       v1beta1.OpenTelemetryCollectorSpec{Mode: tt.mode},
+				Status:     tt.status,
+			}
+			condition := readyCondition(otelcol)
+			assert.Equal(t, v1beta1.ConditionReady, condition.Type)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, tt.expectedMessage, condition.Message)
+			assert.Equal(t, int64(3), condition.ObservedGeneration)
+		})
+	}
+}
+
+func TestReconcileFailedCondition(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 2}}
+	condition := reconcileFailedCondition(otelcol, errors.New("failed to create the service"))
+	assert.Equal(t, v1beta1.ConditionReady, condition.Type)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1beta1.ReasonReconcileFailed, condition.Reason)
+	assert.Equal(t, "failed to create the service", condition.Message)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+}
+
+func TestConfigWarningsCondition(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 2}}
+
+	condition := configWarningsCondition(otelcol, nil)
+	assert.Equal(t, v1beta1.ConditionConfigWarnings, condition.Type)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1beta1.ReasonNoWarnings, condition.Reason)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+
+	condition = configWarningsCondition(otelcol, []string{"first warning", "second warning"})
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1beta1.ReasonWarnings, condition.Reason)
+	assert.Equal(t, "first warning; second warning", condition.Message)
+}
+
+func TestFIPSCompliantCondition(t *testing.T) {
+	cfg := config.New(config.WithCollectorImage("collector-fips:v0.0.0"), config.WithTargetAllocatorImage("ta:v0.0.0"))
+	otelcol := &v1beta1.OpenTelemetryCollector{ObjectMeta: metav1.ObjectMeta{Name: "test", Generation: 2}}
+
+	condition := fipsCompliantCondition(otelcol, cfg)
+	assert.Equal(t, v1beta1.ConditionFIPSCompliant, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, v1beta1.ReasonCompliant, condition.Reason)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+
+	otelcol.Spec.TargetAllocator.Enabled = true
+	condition = fipsCompliantCondition(otelcol, cfg)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, v1beta1.ReasonNonCompliant, condition.Reason)
+	assert.Equal(t, "the images ta:v0.0.0 aren't built for FIPS", condition.Message)
+}
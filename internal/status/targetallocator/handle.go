@@ -12,7 +12,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
-	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
 const (
@@ -32,9 +31,11 @@ func HandleReconcileStatus(ctx context.Context, log logr.Logger, params targetal
 		return ctrl.Result{}, err
 	}
 	changed := params.TargetAllocator.DeepCopy()
+	statusErr := updateTargetAllocatorStatus(ctx, params.Client, changed)
 
-	if changed.Status.Version == "" {
-		changed.Status.Version = version.TargetAllocator()
+	if statusErr != nil {
+		params.Recorder.Event(changed, eventTypeWarning, reasonStatusFailure, statusErr.Error())
+		return ctrl.Result{}, statusErr
 	}
 	statusPatch := client.MergeFrom(&params.TargetAllocator)
 	if err := params.Client.Status().Patch(ctx, changed, statusPatch); err != nil {
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package targetallocator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+)
+
+func updateTargetAllocatorStatus(ctx context.Context, cli client.Client, changed *v1alpha1.TargetAllocator) error {
+	if changed.Status.Version == "" {
+		// a version is not set, otherwise let the upgrade mechanism take care of it!
+		changed.Status.Version = version.TargetAllocator()
+	}
+
+	// Set the scale selector
+	labels := manifestutils.TASelectorLabels(*changed, targetallocator.ComponentOpenTelemetryTargetAllocator)
+	selector, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchLabels: labels})
+	if err != nil {
+		return fmt.Errorf("failed to get selector for labelSelector: %w", err)
+	}
+	changed.Status.Scale.Selector = selector.String()
+
+	// Set the scale replicas
+	objKey := client.ObjectKey{
+		Namespace: changed.GetNamespace(),
+		Name:      naming.TargetAllocator(changed.Name),
+	}
+	obj := &appsv1.Deployment{}
+	if err := cli.Get(ctx, objKey, obj); err != nil {
+		// the deployment is only created once the target allocator is reconciled, the next reconcile reports it
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get deployment status.replicas: %w", err)
+	}
+	changed.Status.Scale.Replicas = obj.Status.Replicas
+	changed.Status.Scale.StatusReplicas = strconv.Itoa(int(obj.Status.ReadyReplicas)) + "/" + strconv.Itoa(int(obj.Status.Replicas))
+	if len(obj.Spec.Template.Spec.Containers) > 0 {
+		changed.Status.Image = obj.Spec.Template.Spec.Containers[0].Image
+	}
+
+	return nil
+}
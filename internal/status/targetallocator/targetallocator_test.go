@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package targetallocator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestUpdateTargetAllocatorStatus(t *testing.T) {
+	ctx := context.TODO()
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-targetallocator",
+			Namespace: "default",
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:      3,
+			ReadyReplicas: 2,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "ta-container",
+							Image: "targetallocator:latest",
+						},
+					},
+				},
+			},
+		},
+	}
+	cli := fake.NewClientBuilder().WithObjects(deployment).Build()
+
+	changed := &v1alpha1.TargetAllocator{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}
+
+	err := updateTargetAllocatorStatus(ctx, cli, changed)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(3), changed.Status.Scale.Replicas)
+	assert.Equal(t, "2/3", changed.Status.Scale.StatusReplicas)
+	assert.Equal(t, "targetallocator:latest", changed.Status.Image)
+	assert.Contains(t, changed.Status.Scale.Selector, "app.kubernetes.io/component=opentelemetry-targetallocator")
+	assert.Contains(t, changed.Status.Scale.Selector, "app.kubernetes.io/instance=default.test")
+	assert.NotEmpty(t, changed.Status.Version)
+}
+
+func TestUpdateTargetAllocatorStatusMissingDeployment(t *testing.T) {
+	changed := &v1alpha1.TargetAllocator{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}
+
+	// the deployment doesn't exist before the first reconcile
+	err := updateTargetAllocatorStatus(context.TODO(), fake.NewClientBuilder().Build(), changed)
+	require.NoError(t, err)
+	assert.NotEmpty(t, changed.Status.Scale.Selector)
+	assert.Zero(t, changed.Status.Scale.Replicas)
+	assert.Empty(t, changed.Status.Image)
+}
+
+func TestUpdateTargetAllocatorStatusWithoutContainers(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-targetallocator",
+			Namespace: "default",
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas:      1,
+			ReadyReplicas: 1,
+		},
+	}
+	changed := &v1alpha1.TargetAllocator{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "default",
+		},
+	}
+
+	err := updateTargetAllocatorStatus(context.TODO(), fake.NewClientBuilder().WithObjects(deployment).Build(), changed)
+	require.NoError(t, err)
+	assert.Equal(t, "1/1", changed.Status.Scale.StatusReplicas)
+	assert.Empty(t, changed.Status.Image)
+}
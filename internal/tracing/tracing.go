@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing traces the reconciliations and the webhooks of the operator with OpenTelemetry.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const (
+	tracerName  = "github.com/open-telemetry/opentelemetry-operator"
+	serviceName = "opentelemetry-operator"
+	tracesPath  = "/v1/traces"
+)
+
+// Tracer returns the tracer of the operator, from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Bootstrap sets the global tracer provider, exporting the spans over OTLP/HTTP to the endpoint, and returns the
+// function flushing the pending spans and stopping it. The sampler is configured with the OTEL_TRACES_SAMPLER env var.
+func Bootstrap(ctx context.Context, endpoint string, version string) (func(context.Context) error, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q, must be an http or https URL", endpoint)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = tracesPath
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(u.String()))
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceNameKey.String(serviceName),
+		semconv.ServiceVersionKey.String(version),
+	))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// End records the error, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+type reconciler struct {
+	controller string
+	reconciler reconcile.Reconciler
+}
+
+// Reconciler wraps the reconciler of the controller, tracing every reconciliation.
+func Reconciler(controller string, r reconcile.Reconciler) reconcile.Reconciler {
+	return &reconciler{controller: controller, reconciler: r}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := Tracer().Start(ctx, "reconcile "+r.controller, trace.WithAttributes(
+		attribute.String("controller", r.controller),
+		semconv.K8SNamespaceNameKey.String(req.Namespace),
+		attribute.String("name", req.Name),
+	))
+	result, err := r.reconciler.Reconcile(ctx, req)
+	if result.RequeueAfter > 0 {
+		span.SetAttributes(attribute.String("requeue_after", result.RequeueAfter.String()))
+	}
+	End(span, err)
+	return result, err
+}
+
+type server struct {
+	webhook.Server
+}
+
+// WebhookServer wraps the webhook server, tracing the requests to every registered webhook.
+func WebhookServer(s webhook.Server) webhook.Server {
+	return server{Server: s}
+}
+
+func (s server) Register(path string, hook http.Handler) {
+	s.Server.Register(path, otelhttp.NewHandler(hook, "webhook "+path))
+}
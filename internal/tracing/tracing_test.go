@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestReconciler(t *testing.T) {
+	recorder := recordSpans(t)
+	failure := errors.New("failed to reconcile")
+	r := Reconciler("opentelemetrycollector", reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		_, span := Tracer().Start(ctx, "BuildCollector")
+		End(span, nil)
+		return reconcile.Result{RequeueAfter: time.Minute}, failure
+	}))
+
+	result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "observability", Name: "otel"}})
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	build, reconciliation := spans[0], spans[1]
+	assert.Equal(t, "BuildCollector", build.Name())
+	assert.Equal(t, reconciliation.SpanContext().SpanID(), build.Parent().SpanID())
+	assert.Equal(t, codes.Unset, build.Status().Code)
+
+	assert.Equal(t, "reconcile opentelemetrycollector", reconciliation.Name())
+	assert.Equal(t, codes.Error, reconciliation.Status().Code)
+	assert.Equal(t, "failed to reconcile", reconciliation.Status().Description)
+	assert.Subset(t, reconciliation.Attributes(), []attribute.KeyValue{
+		attribute.String("controller", "opentelemetrycollector"),
+		attribute.String("k8s.namespace.name", "observability"),
+		attribute.String("name", "otel"),
+		attribute.String("requeue_after", "1m0s"),
+	})
+}
+
+func TestWebhookServer(t *testing.T) {
+	recorder := recordSpans(t)
+	s := WebhookServer(webhook.NewServer(webhook.Options{}))
+	s.Register("/mutate-v1-pod", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	w := httptest.NewRecorder()
+	s.WebhookMux().ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/mutate-v1-pod", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "webhook /mutate-v1-pod", spans[0].Name())
+}
+
+func TestBootstrapRejectsInvalidEndpoints(t *testing.T) {
+	for _, endpoint := range []string{"otel-collector:4318", "grpc://otel-collector:4317", "http://"} {
+		_, err := Bootstrap(context.Background(), endpoint, "0.120.0")
+		assert.ErrorContains(t, err, "invalid OTLP endpoint", endpoint)
+	}
+}
@@ -22,6 +22,8 @@ var (
 	autoInstrumentationApacheHttpd string
 	autoInstrumentationNginx       string
 	autoInstrumentationGo          string
+	autoInstrumentationPHP         string
+	autoInstrumentationRuby        string
 )
 
 // Version holds this Operator's version as well as the version of some of the components it uses.
@@ -39,6 +41,8 @@ type Version struct {
 	AutoInstrumentationGo          string `json:"auto-instrumentation-go"`
 	AutoInstrumentationApacheHttpd string `json:"auto-instrumentation-apache-httpd"`
 	AutoInstrumentationNginx       string `json:"auto-instrumentation-nginx"`
+	AutoInstrumentationPHP         string `json:"auto-instrumentation-php"`
+	AutoInstrumentationRuby        string `json:"auto-instrumentation-ruby"`
 }
 
 // Get returns the Version object with the relevant information.
@@ -57,12 +61,14 @@ func Get() Version {
 		AutoInstrumentationGo:          AutoInstrumentationGo(),
 		AutoInstrumentationApacheHttpd: AutoInstrumentationApacheHttpd(),
 		AutoInstrumentationNginx:       AutoInstrumentationNginx(),
+		AutoInstrumentationPHP:         AutoInstrumentationPHP(),
+		AutoInstrumentationRuby:        AutoInstrumentationRuby(),
 	}
 }
 
 func (v Version) String() string {
 	return fmt.Sprintf(
-		"Version(Operator='%v', BuildDate='%v', OpenTelemetryCollector='%v', Go='%v', TargetAllocator='%v', OperatorOpAMPBridge='%v', AutoInstrumentationJava='%v', AutoInstrumentationNodeJS='%v', AutoInstrumentationPython='%v', AutoInstrumentationDotNet='%v', AutoInstrumentationGo='%v', AutoInstrumentationApacheHttpd='%v', AutoInstrumentationNginx='%v')",
+		"Version(Operator='%v', BuildDate='%v', OpenTelemetryCollector='%v', Go='%v', TargetAllocator='%v', OperatorOpAMPBridge='%v', AutoInstrumentationJava='%v', AutoInstrumentationNodeJS='%v', AutoInstrumentationPython='%v', AutoInstrumentationDotNet='%v', AutoInstrumentationGo='%v', AutoInstrumentationApacheHttpd='%v', AutoInstrumentationNginx='%v', AutoInstrumentationPHP='%v', AutoInstrumentationRuby='%v')",
 		v.Operator,
 		v.BuildDate,
 		v.OpenTelemetryCollector,
@@ -76,6 +82,8 @@ func (v Version) String() string {
 		v.AutoInstrumentationGo,
 		v.AutoInstrumentationApacheHttpd,
 		v.AutoInstrumentationNginx,
+		v.AutoInstrumentationPHP,
+		v.AutoInstrumentationRuby,
 	)
 }
 
@@ -154,6 +162,20 @@ func AutoInstrumentationNginx() string {
 	return "0.0.0"
 }
 
+func AutoInstrumentationPHP() string {
+	if len(autoInstrumentationPHP) > 0 {
+		return autoInstrumentationPHP
+	}
+	return "0.0.0"
+}
+
+func AutoInstrumentationRuby() string {
+	if len(autoInstrumentationRuby) > 0 {
+		return autoInstrumentationRuby
+	}
+	return "0.0.0"
+}
+
 func AutoInstrumentationGo() string {
 	if len(autoInstrumentationGo) > 0 {
 		return autoInstrumentationGo
@@ -0,0 +1,25 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package v1 contains the subset of the Vertical Pod Autoscaler autoscaling.k8s.io/v1 API the operator manages.
+// The types mirror https://github.com/kubernetes/autoscaler/tree/master/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1,
+// only with the fields the operator sets, so that the autoscaler itself isn't a dependency of the operator.
+// +kubebuilder:object:generate=true
+// +kubebuilder:skip
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects.
+	GroupVersion = schema.GroupVersion{Group: "autoscaling.k8s.io", Version: "v1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme.
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package watchnamespace resolves the namespaces the cache of the operator is restricted to.
+package watchnamespace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+var _ manager.Runnable = (*Watcher)(nil)
+var _ manager.LeaderElectionRunnable = (*Watcher)(nil)
+
+// ErrNamespacesChanged is returned by the Watcher when the namespaces matching the selector change. The cache can't
+// be reconfigured once the manager is running, so the operator has to restart to watch them.
+var ErrNamespacesChanged = errors.New("the namespaces matching the watched namespace selector changed")
+
+// ParseList returns the namespaces of a comma-separated list, ignoring the blanks and the empty entries.
+func ParseList(list string) []string {
+	var namespaces []string
+	for _, ns := range strings.Split(list, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" && !slices.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// Resolve returns the sorted union of the given namespaces and of the namespaces matching the selector, when set.
+func Resolve(ctx context.Context, clientset kubernetes.Interface, namespaces []string, selector labels.Selector) ([]string, error) {
+	resolved := map[string]struct{}{}
+	for _, ns := range namespaces {
+		resolved[ns] = struct{}{}
+	}
+	if selector != nil {
+		list, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the namespaces matching %q: %w", selector.String(), err)
+		}
+		for _, ns := range list.Items {
+			resolved[ns.Name] = struct{}{}
+		}
+	}
+	return slices.Sorted(maps.Keys(resolved)), nil
+}
+
+// CacheConfigs returns the per-namespace configurations of the cache watching the given namespaces.
+func CacheConfigs(namespaces []string) map[string]cache.Config {
+	configs := make(map[string]cache.Config, len(namespaces))
+	for _, ns := range namespaces {
+		configs[ns] = cache.Config{}
+	}
+	return configs
+}
+
+// Watcher periodically lists the namespaces matching the selector, and stops the manager with ErrNamespacesChanged
+// when they differ from the namespaces the cache was started with.
+type Watcher struct {
+	clientset  kubernetes.Interface
+	namespaces []string
+	selector   labels.Selector
+	interval   time.Duration
+	logger     logr.Logger
+	current    []string
+}
+
+// NewWatcher creates a new Watcher, starting from the namespaces resolved when the manager was created.
+func NewWatcher(clientset kubernetes.Interface, namespaces []string, selector labels.Selector, current []string, interval time.Duration, logger logr.Logger) *Watcher {
+	return &Watcher{
+		clientset:  clientset,
+		namespaces: namespaces,
+		selector:   selector,
+		interval:   interval,
+		logger:     logger,
+		current:    current,
+	}
+}
+
+// Start resolves the namespaces at every interval, until the context is done or the namespaces change.
+func (w *Watcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			next, err := Resolve(ctx, w.clientset, w.namespaces, w.selector)
+			if err != nil {
+				w.logger.Error(err, "failed to resolve the watched namespaces, will retry at the next interval")
+				continue
+			}
+			if !slices.Equal(w.current, next) {
+				w.logger.Info("the watched namespaces changed, restarting the operator", "previous", w.current, "current", next)
+				return ErrNamespacesChanged
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns true, so that only the leader restarts when the namespaces change, instead of all the
+// replicas at once. A replica elected later starts the Watcher with the namespaces its cache was started with, and
+// restarts at its first interval when they changed since.
+func (w *Watcher) NeedLeaderElection() bool {
+	return true
+}
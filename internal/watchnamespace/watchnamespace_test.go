@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package watchnamespace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+func namespace(name string, tenant string) *corev1.Namespace {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if tenant != "" {
+		ns.Labels = map[string]string{"tenant": tenant}
+	}
+	return ns
+}
+
+func TestParseList(t *testing.T) {
+	for _, tt := range []struct {
+		list     string
+		expected []string
+	}{
+		{list: "", expected: nil},
+		{list: "default", expected: []string{"default"}},
+		{list: "team-a, team-b,,team-a ", expected: []string{"team-a", "team-b"}},
+	} {
+		t.Run(tt.list, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseList(tt.list))
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	clientset := fake.NewSimpleClientset(namespace("team-a", "a"), namespace("team-b", "b"), namespace("other", ""))
+	selector, err := labels.Parse("tenant in (a,b)")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		name       string
+		namespaces []string
+		selector   labels.Selector
+		expected   []string
+	}{
+		{name: "list", namespaces: []string{"other"}, expected: []string{"other"}},
+		{name: "selector", selector: selector, expected: []string{"team-a", "team-b"}},
+		{name: "list and selector", namespaces: []string{"other", "team-a"}, selector: selector, expected: []string{"other", "team-a", "team-b"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := Resolve(context.Background(), clientset, tt.namespaces, tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved)
+		})
+	}
+}
+
+func TestCacheConfigs(t *testing.T) {
+	assert.Equal(t, map[string]cache.Config{"team-a": {}, "team-b": {}}, CacheConfigs([]string{"team-a", "team-b"}))
+}
+
+func TestWatcherStopsOnChange(t *testing.T) {
+	clientset := fake.NewSimpleClientset(namespace("team-a", "a"))
+	selector, err := labels.Parse("tenant")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	watcher := NewWatcher(clientset, nil, selector, []string{"team-a"}, 10*time.Millisecond, logr.Discard())
+
+	_, err = clientset.CoreV1().Namespaces().Create(ctx, namespace("team-b", "b"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, watcher.Start(ctx), ErrNamespacesChanged)
+}
+
+func TestWatcherIgnoresUnchangedNamespaces(t *testing.T) {
+	clientset := fake.NewSimpleClientset(namespace("team-a", "a"), namespace("other", ""))
+	selector, err := labels.Parse("tenant")
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	watcher := NewWatcher(clientset, []string{"other"}, selector, []string{"other", "team-a"}, 10*time.Millisecond, logr.Discard())
+
+	assert.NoError(t, watcher.Start(ctx))
+}
@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhookconfig manages the MutatingWebhookConfiguration of the operator, setting the failure policy and the
+// timeout of its webhooks, and excluding the namespaces whose pods must never be mutated.
+package webhookconfig
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,resourceNames=opentelemetry-operator-mutating-webhook-configuration,verbs=get;update
+
+var _ manager.Runnable = (*Manager)(nil)
+var _ manager.LeaderElectionRunnable = (*Manager)(nil)
+
+const (
+	// PodWebhook is the name of the webhook injecting the sidecars and the auto-instrumentations into the pods.
+	PodWebhook = "mpod.kb.io"
+	// ExcludedNamespacesAnnotation lists the namespaces the operator excluded from the pod webhook, so that it can
+	// include them again once they're no longer excluded, without touching the namespaces excluded by the users.
+	ExcludedNamespacesAnnotation = "opentelemetry.io/excluded-namespaces"
+
+	namespaceNameLabel = "kubernetes.io/metadata.name"
+)
+
+// webhookNames are the names of the mutating webhooks of the operator.
+var webhookNames = []string{
+	"mopentelemetrycollectorbeta.kb.io",
+	"minstrumentation.kb.io",
+	"mopampbridge.kb.io",
+	"mtargetallocatorbeta.kb.io",
+	PodWebhook,
+}
+
+// Options are the settings of a webhook, left unchanged when unset.
+type Options struct {
+	FailurePolicy  *admissionregistrationv1.FailurePolicyType
+	TimeoutSeconds *int32
+}
+
+// ParseOptions parses the options of the webhooks, by webhook name, from values such as
+// mpod.kb.io:failurePolicy=Fail,timeoutSeconds=5. The later values override the earlier ones.
+func ParseOptions(values []string) (map[string]Options, error) {
+	options := map[string]Options{}
+	for _, value := range values {
+		name, settings, ok := strings.Cut(value, ":")
+		if !ok || !slices.Contains(webhookNames, name) {
+			return nil, fmt.Errorf("invalid webhook options %q, the webhook must be one of %s", value, strings.Join(webhookNames, ", "))
+		}
+		o := options[name]
+		for _, setting := range strings.Split(settings, ",") {
+			key, v, _ := strings.Cut(setting, "=")
+			switch key {
+			case "failurePolicy":
+				policy := admissionregistrationv1.FailurePolicyType(v)
+				if policy != admissionregistrationv1.Fail && policy != admissionregistrationv1.Ignore {
+					return nil, fmt.Errorf("invalid options of the %s webhook: the failure policy must be Fail or Ignore, got %q", name, v)
+				}
+				o.FailurePolicy = &policy
+			case "timeoutSeconds":
+				timeout, err := strconv.ParseInt(v, 10, 32)
+				if err != nil || timeout < 1 || timeout > 30 {
+					return nil, fmt.Errorf("invalid options of the %s webhook: the timeout must be between 1 and 30 seconds, got %q", name, v)
+				}
+				seconds := int32(timeout)
+				o.TimeoutSeconds = &seconds
+			default:
+				return nil, fmt.Errorf("invalid options of the %s webhook: unknown option %q, must be one of failurePolicy, timeoutSeconds", name, key)
+			}
+		}
+		options[name] = o
+	}
+	return options, nil
+}
+
+// Apply sets the options of the webhooks of the configuration, and excludes the namespaces from the pod webhook
+// with a NotIn expression of its namespace selector, keeping the other expressions. The namespaces it excludes are
+// recorded in the ExcludedNamespacesAnnotation of the configuration. It reports whether the configuration changed.
+func Apply(configuration *admissionregistrationv1.MutatingWebhookConfiguration, options map[string]Options, excludedNamespaces []string) bool {
+	changed := false
+	var managed []string
+	if value := configuration.Annotations[ExcludedNamespacesAnnotation]; value != "" {
+		managed = strings.Split(value, ",")
+	}
+	for i := range configuration.Webhooks {
+		webhook := &configuration.Webhooks[i]
+		if o, ok := options[webhook.Name]; ok {
+			if o.FailurePolicy != nil && (webhook.FailurePolicy == nil || *webhook.FailurePolicy != *o.FailurePolicy) {
+				webhook.FailurePolicy = o.FailurePolicy
+				changed = true
+			}
+			if o.TimeoutSeconds != nil && (webhook.TimeoutSeconds == nil || *webhook.TimeoutSeconds != *o.TimeoutSeconds) {
+				webhook.TimeoutSeconds = o.TimeoutSeconds
+				changed = true
+			}
+		}
+		if webhook.Name == PodWebhook {
+			var webhookChanged bool
+			managed, webhookChanged = excludeNamespaces(webhook, managed, excludedNamespaces)
+			changed = changed || webhookChanged
+		}
+	}
+	if value := strings.Join(managed, ","); value != configuration.Annotations[ExcludedNamespacesAnnotation] {
+		if value == "" {
+			delete(configuration.Annotations, ExcludedNamespacesAnnotation)
+		} else {
+			if configuration.Annotations == nil {
+				configuration.Annotations = map[string]string{}
+			}
+			configuration.Annotations[ExcludedNamespacesAnnotation] = value
+		}
+		changed = true
+	}
+	return changed
+}
+
+// excludeNamespaces updates the NotIn expression of the namespace names of the namespace selector of the webhook,
+// adding the namespaces missing from it, and removing the managed namespaces, the ones previously added by the
+// operator, which are no longer excluded. The namespaces excluded by the users are kept. It returns the namespaces
+// now managed, and whether the webhook changed.
+func excludeNamespaces(webhook *admissionregistrationv1.MutatingWebhook, managed, namespaces []string) ([]string, bool) {
+	i := -1
+	if webhook.NamespaceSelector != nil {
+		i = slices.IndexFunc(webhook.NamespaceSelector.MatchExpressions, func(e metav1.LabelSelectorRequirement) bool {
+			return e.Key == namespaceNameLabel && e.Operator == metav1.LabelSelectorOpNotIn
+		})
+	}
+	if i == -1 {
+		if len(namespaces) == 0 {
+			return nil, false
+		}
+		if webhook.NamespaceSelector == nil {
+			webhook.NamespaceSelector = &metav1.LabelSelector{}
+		}
+		webhook.NamespaceSelector.MatchExpressions = append(webhook.NamespaceSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      namespaceNameLabel,
+			Operator: metav1.LabelSelectorOpNotIn,
+		})
+		i = len(webhook.NamespaceSelector.MatchExpressions) - 1
+	}
+	selector := webhook.NamespaceSelector
+	expression := &selector.MatchExpressions[i]
+
+	values := slices.DeleteFunc(slices.Clone(expression.Values), func(ns string) bool {
+		return slices.Contains(managed, ns) && !slices.Contains(namespaces, ns)
+	})
+	var nowManaged []string
+	for _, ns := range managed {
+		if slices.Contains(values, ns) && !slices.Contains(nowManaged, ns) {
+			nowManaged = append(nowManaged, ns)
+		}
+	}
+	for _, ns := range namespaces {
+		if !slices.Contains(values, ns) {
+			values = append(values, ns)
+			nowManaged = append(nowManaged, ns)
+		}
+	}
+	changed := !slices.Equal(values, expression.Values)
+	expression.Values = values
+	if len(values) == 0 {
+		// a NotIn expression needs values
+		selector.MatchExpressions = slices.Delete(selector.MatchExpressions, i, i+1)
+	}
+	return nowManaged, changed
+}
+
+// Manager applies the options of the webhooks and the excluded namespaces to the MutatingWebhookConfiguration of the
+// operator when it starts, and again at every interval, reverting the changes made by the deployment tools.
+type Manager struct {
+	clientset          kubernetes.Interface
+	name               string
+	options            map[string]Options
+	excludedNamespaces []string
+	interval           time.Duration
+	logger             logr.Logger
+}
+
+// NewManager creates a new Manager of the MutatingWebhookConfiguration of the given name.
+func NewManager(clientset kubernetes.Interface, name string, options map[string]Options, excludedNamespaces []string, interval time.Duration, logger logr.Logger) *Manager {
+	return &Manager{
+		clientset:          clientset,
+		name:               name,
+		options:            options,
+		excludedNamespaces: excludedNamespaces,
+		interval:           interval,
+		logger:             logger,
+	}
+}
+
+// Start applies the settings until the context is done. The failures are logged and retried at the next interval,
+// the webhooks working with their previous settings meanwhile.
+func (m *Manager) Start(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Sync(ctx); err != nil {
+			m.logger.Error(err, "failed to update the mutating webhook configuration, will retry at the next interval", "name", m.name)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync applies the settings to the MutatingWebhookConfiguration, which is left alone when it doesn't exist, e.g.
+// when the operator is installed with another name for it.
+func (m *Manager) Sync(ctx context.Context) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configuration, err := m.clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, m.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			m.logger.V(2).Info("the mutating webhook configuration doesn't exist, skipping", "name", m.name)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !Apply(configuration, m.options, m.excludedNamespaces) {
+			return nil
+		}
+		m.logger.Info("updating the mutating webhook configuration", "name", m.name)
+		_, err = m.clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(ctx, configuration, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// NeedLeaderElection returns true, as a single replica of the operator updates the configuration.
+func (m *Manager) NeedLeaderElection() bool {
+	return true
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+func TestParseOptions(t *testing.T) {
+	options, err := ParseOptions([]string{
+		"mpod.kb.io:failurePolicy=Fail,timeoutSeconds=5",
+		"minstrumentation.kb.io:timeoutSeconds=10",
+		"mpod.kb.io:timeoutSeconds=3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Options{
+		"mpod.kb.io":             {FailurePolicy: ptr.To(admissionregistrationv1.Fail), TimeoutSeconds: ptr.To[int32](3)},
+		"minstrumentation.kb.io": {TimeoutSeconds: ptr.To[int32](10)},
+	}, options)
+
+	for _, value := range []string{
+		"mpod.kb.io",
+		"vpod.kb.io:timeoutSeconds=5",
+		"mpod.kb.io:failurePolicy=Retry",
+		"mpod.kb.io:timeoutSeconds=31",
+		"mpod.kb.io:timeoutSeconds=five",
+		"mpod.kb.io:sideEffects=None",
+	} {
+		_, err = ParseOptions([]string{value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestApply(t *testing.T) {
+	configuration := &admissionregistrationv1.MutatingWebhookConfiguration{
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{Name: "mopentelemetrycollectorbeta.kb.io", FailurePolicy: ptr.To(admissionregistrationv1.Fail)},
+			{
+				Name:          PodWebhook,
+				FailurePolicy: ptr.To(admissionregistrationv1.Ignore),
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "a"},
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: namespaceNameLabel, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"istio-system"}},
+					},
+				},
+			},
+		},
+	}
+	options := map[string]Options{
+		PodWebhook: {FailurePolicy: ptr.To(admissionregistrationv1.Fail), TimeoutSeconds: ptr.To[int32](5)},
+	}
+
+	assert.True(t, Apply(configuration, options, []string{"kube-system", "opentelemetry-operator-system"}))
+	assert.Equal(t, admissionregistrationv1.MutatingWebhook{Name: "mopentelemetrycollectorbeta.kb.io", FailurePolicy: ptr.To(admissionregistrationv1.Fail)}, configuration.Webhooks[0])
+	pod := configuration.Webhooks[1]
+	assert.Equal(t, admissionregistrationv1.Fail, *pod.FailurePolicy)
+	assert.Equal(t, int32(5), *pod.TimeoutSeconds)
+	assert.Equal(t, &metav1.LabelSelector{
+		MatchLabels: map[string]string{"team": "a"},
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: namespaceNameLabel, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"istio-system", "kube-system", "opentelemetry-operator-system"}},
+		},
+	}, pod.NamespaceSelector)
+	assert.Equal(t, "kube-system,opentelemetry-operator-system", configuration.Annotations[ExcludedNamespacesAnnotation])
+
+	assert.False(t, Apply(configuration, options, []string{"kube-system", "opentelemetry-operator-system"}), "already applied")
+
+	// the namespaces no longer excluded are removed, unlike the ones excluded by the users
+	assert.True(t, Apply(configuration, options, []string{"kube-system"}))
+	assert.Equal(t, []string{"istio-system", "kube-system"}, configuration.Webhooks[1].NamespaceSelector.MatchExpressions[0].Values)
+	assert.Equal(t, "kube-system", configuration.Annotations[ExcludedNamespacesAnnotation])
+
+	// the expression is removed with its last value
+	configuration.Webhooks[1].NamespaceSelector.MatchExpressions[0].Values = []string{"kube-system"}
+	assert.True(t, Apply(configuration, options, nil))
+	assert.Empty(t, configuration.Webhooks[1].NamespaceSelector.MatchExpressions)
+	assert.NotContains(t, configuration.Annotations, ExcludedNamespacesAnnotation)
+}
+
+func TestManagerSync(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "opentelemetry-operator-mutating-webhook-configuration"},
+		Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: PodWebhook}},
+	})
+	m := NewManager(clientset, "opentelemetry-operator-mutating-webhook-configuration", nil, []string{"kube-system"}, 0, logr.Discard())
+	require.NoError(t, m.Sync(context.Background()))
+
+	configuration, err := clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.Background(), "opentelemetry-operator-mutating-webhook-configuration", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []metav1.LabelSelectorRequirement{
+		{Key: namespaceNameLabel, Operator: metav1.LabelSelectorOpNotIn, Values: []string{"kube-system"}},
+	}, configuration.Webhooks[0].NamespaceSelector.MatchExpressions)
+
+	// the configurations installed with another name are left alone
+	m = NewManager(clientset, "other", nil, []string{"kube-system"}, 0, logr.Discard())
+	assert.NoError(t, m.Sync(context.Background()))
+}
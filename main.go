@@ -13,6 +13,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -21,7 +22,9 @@ import (
 	"github.com/spf13/pflag"
 	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -29,30 +32,44 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/autodetectutils"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
 	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/targetallocator"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
+	"github.com/open-telemetry/opentelemetry-operator/internal/capabilities"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/controllers"
 	"github.com/open-telemetry/opentelemetry-operator/internal/fips"
+	"github.com/open-telemetry/opentelemetry-operator/internal/imageplatform"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
 	collectorManifests "github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	openshiftDashboards "github.com/open-telemetry/opentelemetry-operator/internal/openshift/dashboards"
 	operatormetrics "github.com/open-telemetry/opentelemetry-operator/internal/operator-metrics"
 	"github.com/open-telemetry/opentelemetry-operator/internal/rbac"
+	collectorStatus "github.com/open-telemetry/opentelemetry-operator/internal/status/collector"
+	"github.com/open-telemetry/opentelemetry-operator/internal/tracing"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	vpav1 "github.com/open-telemetry/opentelemetry-operator/internal/vpa/v1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/watchnamespace"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
+	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/webhookconfig"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/instrumentation"
@@ -75,15 +92,27 @@ func init() {
 
 // stringFlagOrEnv defines a string flag which can be set by an environment variable.
 // Precedence: flag > env var > default value.
+// envFlags are the flags set by their env var, which take precedence over the configuration file as the flags set on
+// the command line.
+var envFlags = map[string]bool{}
+
 func stringFlagOrEnv(p *string, name string, envName string, defaultValue string, usage string) {
 	envValue := os.Getenv(envName)
 	if envValue != "" {
 		defaultValue = envValue
+		envFlags[name] = true
 	}
 	pflag.StringVar(p, name, defaultValue, usage)
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == renderCommand {
+		os.Exit(render(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
+	if len(os.Args) > 1 && os.Args[1] == upgradeDiffCommand {
+		os.Exit(upgradeDiff(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+	}
+
 	// registers any flags that underlying libraries might use
 	opts := zap.Options{}
 	flagset := featuregate.Flags(colfeaturegate.GlobalRegistry())
@@ -107,30 +136,54 @@ func main() {
 		enableGoInstrumentation          bool
 		enablePythonInstrumentation      bool
 		enableNginxInstrumentation       bool
+		enablePHPInstrumentation         bool
+		enableRubyInstrumentation        bool
 		enableNodeJSInstrumentation      bool
 		enableJavaInstrumentation        bool
 		enableCRMetrics                  bool
 		createSMOperatorMetrics          bool
 		ignoreMissingCollectorCRDs       bool
+		checkImagePlatforms              bool
 		collectorImage                   string
 		targetAllocatorImage             string
 		operatorOpAMPBridgeImage         string
+		configReloaderImage              string
+		defaultSidecarCollector          string
 		autoInstrumentationJava          string
 		autoInstrumentationNodeJS        string
 		autoInstrumentationPython        string
 		autoInstrumentationDotNet        string
 		autoInstrumentationApacheHttpd   string
 		autoInstrumentationNginx         string
+		autoInstrumentationPHP           string
+		autoInstrumentationRuby          string
 		autoInstrumentationGo            string
 		labelsFilter                     []string
 		annotationsFilter                []string
+		excludedNamespaces               string
+		excludedPods                     string
+		excludedContainers               string
 		webhookPort                      int
+		mutatingWebhookConfiguration     string
+		webhookExcludedNamespaces        []string
+		webhookOptions                   []string
 		tlsOpt                           config.TLSConfig
 		encodeMessageKey                 string
 		encodeLevelKey                   string
 		encodeTimeKey                    string
 		encodeLevelFormat                string
 		fipsDisabledComponents           string
+		autoDetectFrequency              time.Duration
+		watchNamespaceSelector           string
+		watchNamespaceFrequency          time.Duration
+		resourceSelector                 string
+		configFile                       string
+		configFileFrequency              time.Duration
+		reconcileOptions                 config.ReconcileOptions
+		controllerReconcileOptions       []string
+		tracingEndpoint                  string
+		upgradeChannel                   string
+		maintenanceWindows               []string
 	)
 
 	pflag.StringVar(&metricsAddr, "metrics-addr", ":8080", "The address the metric endpoint binds to.")
@@ -147,15 +200,19 @@ func main() {
 	pflag.BoolVar(&enableGoInstrumentation, constants.FlagGo, false, "Controls whether the operator supports Go auto-instrumentation")
 	pflag.BoolVar(&enablePythonInstrumentation, constants.FlagPython, true, "Controls whether the operator supports python auto-instrumentation")
 	pflag.BoolVar(&enableNginxInstrumentation, constants.FlagNginx, false, "Controls whether the operator supports nginx auto-instrumentation")
+	pflag.BoolVar(&enablePHPInstrumentation, constants.FlagPHP, false, "Controls whether the operator supports PHP auto-instrumentation")
+	pflag.BoolVar(&enableRubyInstrumentation, constants.FlagRuby, false, "Controls whether the operator supports Ruby auto-instrumentation")
 	pflag.BoolVar(&enableNodeJSInstrumentation, constants.FlagNodeJS, true, "Controls whether the operator supports nodejs auto-instrumentation")
 	pflag.BoolVar(&enableJavaInstrumentation, constants.FlagJava, true, "Controls whether the operator supports java auto-instrumentation")
 	pflag.BoolVar(&enableCRMetrics, constants.FlagCRMetrics, false, "Controls whether exposing the CR metrics is enabled")
 	pflag.BoolVar(&createSMOperatorMetrics, "create-sm-operator-metrics", false, "Create a ServiceMonitor for the operator metrics")
 	pflag.BoolVar(&ignoreMissingCollectorCRDs, "ignore-missing-collector-crds", false, "Ignore missing OpenTelemetryCollector CRDs presence in the cluster")
+	pflag.BoolVar(&checkImagePlatforms, "check-image-platforms", false, "Warn when the image of a DaemonSet collector doesn't provide the platforms of the cluster nodes, reading the image manifests from the registries")
 
 	stringFlagOrEnv(&collectorImage, "collector-image", "RELATED_IMAGE_COLLECTOR", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector), "The default OpenTelemetry collector image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&targetAllocatorImage, "target-allocator-image", "RELATED_IMAGE_TARGET_ALLOCATOR", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator), "The default OpenTelemetry target allocator image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&operatorOpAMPBridgeImage, "operator-opamp-bridge-image", "RELATED_IMAGE_OPERATOR_OPAMP_BRIDGE", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/operator-opamp-bridge:%s", v.OperatorOpAMPBridge), "The default OpenTelemetry Operator OpAMP Bridge image. This image is used when no image is specified in the CustomResource.")
+	stringFlagOrEnv(&configReloaderImage, "config-reloader-image", "RELATED_IMAGE_CONFIG_RELOADER", "docker.io/library/busybox:1.37", "The image of the container reloading the configuration of the collectors with the Reload rollout strategy. It must provide sh, md5sum and pkill.")
 	stringFlagOrEnv(&autoInstrumentationJava, "auto-instrumentation-java-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_JAVA", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-java:%s", v.AutoInstrumentationJava), "The default OpenTelemetry Java instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationNodeJS, "auto-instrumentation-nodejs-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_NODEJS", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-nodejs:%s", v.AutoInstrumentationNodeJS), "The default OpenTelemetry NodeJS instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationPython, "auto-instrumentation-python-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_PYTHON", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-python:%s", v.AutoInstrumentationPython), "The default OpenTelemetry Python instrumentation image. This image is used when no image is specified in the CustomResource.")
@@ -163,7 +220,13 @@ func main() {
 	stringFlagOrEnv(&autoInstrumentationGo, "auto-instrumentation-go-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_GO", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-go-instrumentation/autoinstrumentation-go:%s", v.AutoInstrumentationGo), "The default OpenTelemetry Go instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationApacheHttpd, "auto-instrumentation-apache-httpd-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_APACHE_HTTPD", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationApacheHttpd), "The default OpenTelemetry Apache HTTPD instrumentation image. This image is used when no image is specified in the CustomResource.")
 	stringFlagOrEnv(&autoInstrumentationNginx, "auto-instrumentation-nginx-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_NGINX", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-apache-httpd:%s", v.AutoInstrumentationNginx), "The default OpenTelemetry Nginx instrumentation image. This image is used when no image is specified in the CustomResource.")
+	stringFlagOrEnv(&autoInstrumentationPHP, "auto-instrumentation-php-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_PHP", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-php:%s", v.AutoInstrumentationPHP), "The default OpenTelemetry PHP instrumentation image. This image is used when no image is specified in the CustomResource.")
+	stringFlagOrEnv(&autoInstrumentationRuby, "auto-instrumentation-ruby-image", "RELATED_IMAGE_AUTO_INSTRUMENTATION_RUBY", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/autoinstrumentation-ruby:%s", v.AutoInstrumentationRuby), "The default OpenTelemetry Ruby instrumentation image. This image is used when no image is specified in the CustomResource.")
+	pflag.StringVar(&defaultSidecarCollector, "default-sidecar-collector", "", "The namespace/name of the sidecar collector injected into the pods requesting a sidecar, with the sidecar.opentelemetry.io/inject annotation set to true, when their namespace has no sidecar collector.")
 	pflag.StringArrayVar(&labelsFilter, "labels-filter", []string{}, "Labels to filter away from propagating onto deploys. It should be a string array containing patterns, which are literal strings optionally containing a * wildcard character. Example: --labels-filter=.*filter.out will filter out labels that looks like: label.filter.out: true")
+	pflag.StringVar(&excludedNamespaces, "instrumentation-excluded-namespaces", "", "Label selector of the namespaces whose pods are never auto-instrumented, even when annotated. Example: --instrumentation-excluded-namespaces='kubernetes.io/metadata.name in (kube-system,kube-public)'")
+	pflag.StringVar(&excludedPods, "instrumentation-excluded-pods", "", "Label selector of the pods which are never auto-instrumented, even when annotated.")
+	pflag.StringVar(&excludedContainers, "instrumentation-excluded-containers", "", "Regular expression matching the whole name of the containers which are never auto-instrumented, even when annotated. Example: --instrumentation-excluded-containers='istio-proxy|linkerd-.*'")
 	pflag.StringArrayVar(&annotationsFilter, "annotations-filter", []string{}, "Annotations to filter away from propagating onto deploys. It should be a string array containing patterns, which are literal strings optionally containing a * wildcard character. Example: --annotations-filter=.*filter.out will filter out annotations that looks like: annotation.filter.out: true")
 	pflag.StringVar(&tlsOpt.MinVersion, "tls-min-version", "VersionTLS12", "Minimum TLS version supported. Value must match version names from https://golang.org/pkg/crypto/tls/#pkg-constants.")
 	pflag.StringSliceVar(&tlsOpt.CipherSuites, "tls-cipher-suites", nil, "Comma-separated list of cipher suites for the server. Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants). If omitted, the default Go cipher suites will be used")
@@ -173,6 +236,22 @@ func main() {
 	pflag.StringVar(&encodeLevelFormat, "zap-level-format", "uppercase", "The level format to be used in the customized Log Encoder")
 	pflag.StringVar(&fipsDisabledComponents, "fips-disabled-components", "uppercase", "Disabled collector components when operator runs on FIPS enabled platform. Example flag value =receiver.foo,receiver.bar,exporter.baz")
 	pflag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook endpoint binds to.")
+	pflag.StringVar(&mutatingWebhookConfiguration, "mutating-webhook-configuration", "", "The name of the MutatingWebhookConfiguration of the operator, whose webhook options and excluded namespaces the operator applies, e.g. opentelemetry-operator-mutating-webhook-configuration. Empty string, the default, leaves it unmanaged.")
+	pflag.StringSliceVar(&webhookExcludedNamespaces, "webhook-excluded-namespaces", []string{"kube-system"}, "Comma-separated namespaces whose pods the pod webhook never mutates, in addition to the namespace of the operator.")
+	pflag.StringArrayVar(&webhookOptions, "webhook-options", []string{}, "The failure policy and the timeout of a mutating webhook of the operator. Example: --webhook-options=mpod.kb.io:failurePolicy=Fail,timeoutSeconds=5")
+	pflag.DurationVar(&autoDetectFrequency, "auto-detect-frequency", 0, "How often the operator re-detects the cluster capabilities (OpenShift routes, Prometheus CRDs, cert-manager, ...). Default is 0 which only detects them at startup.")
+	stringFlagOrEnv(&watchNamespaceSelector, "watch-namespace-selector", "WATCH_NAMESPACE_SELECTOR", "", "Label selector of the namespaces the operator watches, in addition to the comma-separated namespaces of the WATCH_NAMESPACE env var. Example: --watch-namespace-selector='tenant in (team-a,team-b)'")
+	pflag.DurationVar(&watchNamespaceFrequency, "watch-namespace-selector-frequency", time.Minute, "How often the operator lists the namespaces matching the watched namespace selector, restarting when they change. 0 only lists them at startup.")
+	pflag.StringVar(&resourceSelector, "resource-selector", "", "Label selector of the OpenTelemetryCollector and Instrumentation resources the operator reconciles, letting several operators share a cluster. Example: --resource-selector='opentelemetry.io/operator=team-a'")
+	pflag.IntVar(&reconcileOptions.MaxConcurrentReconciles, "max-concurrent-reconciles", config.DefaultReconcileOptions.MaxConcurrentReconciles, "The number of resources each controller reconciles concurrently.")
+	pflag.DurationVar(&reconcileOptions.BaseBackoff, "reconcile-base-backoff", config.DefaultReconcileOptions.BaseBackoff, "The delay before retrying a failed reconciliation, doubled at every new failure of the resource.")
+	pflag.DurationVar(&reconcileOptions.MaxBackoff, "reconcile-max-backoff", config.DefaultReconcileOptions.MaxBackoff, "The maximum delay before retrying a failed reconciliation.")
+	pflag.StringArrayVar(&controllerReconcileOptions, "controller-reconcile-options", []string{}, "The reconcile options of a controller, overriding the ones of all the controllers. Example: --controller-reconcile-options=opentelemetrycollector:maxConcurrentReconciles=4,baseBackoff=1s,maxBackoff=5m")
+	stringFlagOrEnv(&configFile, "config-file", "OPERATOR_CONFIG_FILE", "", "The YAML configuration file of the operator, whose settings replace the defaults of the flags. The flags set on the command line or by their env var take precedence over the file.")
+	stringFlagOrEnv(&tracingEndpoint, "tracing-otlp-endpoint", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "", "The OTLP/HTTP endpoint the operator exports the traces of its reconciliations and webhooks to. Default is empty string which disables the tracing. Example: --tracing-otlp-endpoint=http://otel-collector.observability:4318")
+	pflag.StringVar(&upgradeChannel, "upgrade-channel", string(config.UpgradeChannelMinor), "The versions the operator automatically upgrades the collectors to: none disables the upgrades, patch only upgrades to the patch releases of their minor version and minor to the releases of their major version.")
+	pflag.StringArrayVar(&maintenanceWindows, "upgrade-maintenance-window", []string{}, "A recurring window, as a cron expression in UTC and a duration, during which the operator upgrades the collectors. The collectors are upgraded at any time when no window is set. Example: --upgrade-maintenance-window='0 2 * * SAT;4h'")
+	pflag.DurationVar(&configFileFrequency, "config-file-reload-frequency", 10*time.Second, "How often the operator reads its configuration file, reloading the collector and auto-instrumentation images and restarting on the other changes. 0 only reads it at startup.")
 	pflag.Parse()
 
 	opts.EncoderConfigOptions = append(opts.EncoderConfigOptions, func(ec *zapcore.EncoderConfig) {
@@ -185,11 +264,41 @@ func main() {
 	logger := zap.New(zap.UseFlagOptions(&opts))
 	ctrl.SetLogger(logger)
 
+	// the flags set on the command line or by their env var take precedence over the configuration file
+	explicitFlags := map[string]bool{}
+	pflag.Visit(func(f *pflag.Flag) { explicitFlags[f.Name] = true })
+	isExplicitFlag := func(name string) bool { return explicitFlags[name] || envFlags[name] }
+	var operatorFile *config.File
+	var operatorFileContent []byte
+	if configFile != "" {
+		var fileErr error
+		if operatorFileContent, fileErr = os.ReadFile(configFile); fileErr == nil {
+			operatorFile, fileErr = config.ParseFile(operatorFileContent)
+		}
+		if fileErr != nil {
+			setupLog.Error(fileErr, "failed to read the operator configuration file", "path", configFile)
+			os.Exit(1)
+		}
+		for name, values := range operatorFile.Flags() {
+			if isExplicitFlag(name) {
+				continue
+			}
+			for _, value := range values {
+				if fileErr = pflag.Set(name, value); fileErr != nil {
+					setupLog.Error(fileErr, "invalid setting in the operator configuration file", "flag", name)
+					os.Exit(1)
+				}
+			}
+		}
+	}
+
 	logger.Info("Starting the OpenTelemetry Operator",
 		"opentelemetry-operator", v.Operator,
 		"opentelemetry-collector", collectorImage,
 		"opentelemetry-targetallocator", targetAllocatorImage,
 		"operator-opamp-bridge", operatorOpAMPBridgeImage,
+		"config-reloader", configReloaderImage,
+		"default-sidecar-collector", defaultSidecarCollector,
 		"ignore-missing-collector-crds", ignoreMissingCollectorCRDs,
 		"auto-instrumentation-java", autoInstrumentationJava,
 		"auto-instrumentation-nodejs", autoInstrumentationNodeJS,
@@ -198,6 +307,8 @@ func main() {
 		"auto-instrumentation-go", autoInstrumentationGo,
 		"auto-instrumentation-apache-httpd", autoInstrumentationApacheHttpd,
 		"auto-instrumentation-nginx", autoInstrumentationNginx,
+		"auto-instrumentation-php", autoInstrumentationPHP,
+		"auto-instrumentation-ruby", autoInstrumentationRuby,
 		"feature-gates", flagset.Lookup(featuregate.FeatureGatesFlag).Value.String(),
 		"build-date", v.BuildDate,
 		"go-version", v.Go,
@@ -205,12 +316,17 @@ func main() {
 		"go-os", runtime.GOOS,
 		"labels-filter", labelsFilter,
 		"annotations-filter", annotationsFilter,
+		"instrumentation-excluded-namespaces", excludedNamespaces,
+		"instrumentation-excluded-pods", excludedPods,
+		"instrumentation-excluded-containers", excludedContainers,
 		"enable-multi-instrumentation", enableMultiInstrumentation,
 		"enable-apache-httpd-instrumentation", enableApacheHttpdInstrumentation,
 		"enable-dotnet-instrumentation", enableDotNetInstrumentation,
 		"enable-go-instrumentation", enableGoInstrumentation,
 		"enable-python-instrumentation", enablePythonInstrumentation,
 		"enable-nginx-instrumentation", enableNginxInstrumentation,
+		"enable-php-instrumentation", enablePHPInstrumentation,
+		"enable-ruby-instrumentation", enableRubyInstrumentation,
 		"enable-nodejs-instrumentation", enableNodeJSInstrumentation,
 		"enable-java-instrumentation", enableJavaInstrumentation,
 		"create-openshift-dashboard", createOpenShiftDashboard,
@@ -219,20 +335,83 @@ func main() {
 		"zap-level-key", encodeLevelKey,
 		"zap-time-key", encodeTimeKey,
 		"zap-level-format", encodeLevelFormat,
+		"auto-detect-frequency", autoDetectFrequency,
+		"check-image-platforms", checkImagePlatforms,
+		"watch-namespace-selector", watchNamespaceSelector,
+		"watch-namespace-selector-frequency", watchNamespaceFrequency,
+		"resource-selector", resourceSelector,
+		"max-concurrent-reconciles", reconcileOptions.MaxConcurrentReconciles,
+		"reconcile-base-backoff", reconcileOptions.BaseBackoff,
+		"reconcile-max-backoff", reconcileOptions.MaxBackoff,
+		"controller-reconcile-options", controllerReconcileOptions,
+		"config-file", configFile,
+		"config-file-reload-frequency", configFileFrequency,
+		"tracing-otlp-endpoint", tracingEndpoint,
+		"mutating-webhook-configuration", mutatingWebhookConfiguration,
+		"webhook-excluded-namespaces", webhookExcludedNamespaces,
+		"webhook-options", webhookOptions,
+		"upgrade-channel", upgradeChannel,
+		"upgrade-maintenance-window", maintenanceWindows,
 	)
 
 	restConfig := ctrl.GetConfigOrDie()
 
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		setupLog.Error(err, "failed to create kubernetes clientset")
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+
+	var shutdownTracing func(context.Context) error
+	if tracingEndpoint != "" {
+		if shutdownTracing, err = tracing.Bootstrap(ctx, tracingEndpoint, v.Operator); err != nil {
+			setupLog.Error(err, "failed to set up the tracing")
+			os.Exit(1)
+		}
+	}
+
 	var namespaces map[string]cache.Config
-	watchNamespace, found := os.LookupEnv("WATCH_NAMESPACE")
-	if found {
-		setupLog.Info("watching namespace(s)", "namespaces", watchNamespace)
-		namespaces = map[string]cache.Config{}
-		for _, ns := range strings.Split(watchNamespace, ",") {
-			namespaces[ns] = cache.Config{}
+	var namespaceSelector labels.Selector
+	if watchNamespaceSelector != "" {
+		if namespaceSelector, err = labels.Parse(watchNamespaceSelector); err != nil {
+			setupLog.Error(err, "The watched namespaces must be set as a label selector.")
+			os.Exit(1)
+		}
+	}
+	// an empty WATCH_NAMESPACE watches all the namespaces, as when it isn't set
+	watchNamespaces := watchnamespace.ParseList(os.Getenv("WATCH_NAMESPACE"))
+	var resolvedNamespaces []string
+	if len(watchNamespaces) > 0 || namespaceSelector != nil {
+		resolvedNamespaces, err = watchnamespace.Resolve(ctx, clientset, watchNamespaces, namespaceSelector)
+		if err != nil {
+			setupLog.Error(err, "failed to resolve the watched namespaces")
+			os.Exit(1)
+		}
+		if len(resolvedNamespaces) == 0 {
+			setupLog.Error(errors.New("no namespace to watch"), "Neither the WATCH_NAMESPACE env var nor the watched namespace selector match a namespace.")
+			os.Exit(1)
 		}
+		setupLog.Info("watching namespace(s)", "namespaces", resolvedNamespaces)
+		namespaces = watchnamespace.CacheConfigs(resolvedNamespaces)
 	} else {
-		setupLog.Info("the env var WATCH_NAMESPACE isn't set, watching all namespaces")
+		setupLog.Info("the env var WATCH_NAMESPACE isn't set or is empty, watching all namespaces")
+	}
+
+	var resourceLabelSelector labels.Selector
+	// only the injected pods are cached, for the status of the Instrumentations
+	cacheByObject := map[client.Object]cache.ByObject{
+		&corev1.Pod{}: {Label: labels.SelectorFromSet(labels.Set{instrumentation.InjectedLabel: "true"})},
+	}
+	if resourceSelector != "" {
+		if resourceLabelSelector, err = labels.Parse(resourceSelector); err != nil {
+			setupLog.Error(err, "The reconciled resources must be set as a label selector.")
+			os.Exit(1)
+		}
+		// the resources of the other operators are kept out of the cache, as if they didn't exist
+		cacheByObject[&otelv1alpha1.OpenTelemetryCollector{}] = cache.ByObject{Label: resourceLabelSelector}
+		cacheByObject[&otelv1beta1.OpenTelemetryCollector{}] = cache.ByObject{Label: resourceLabelSelector}
+		cacheByObject[&otelv1alpha1.Instrumentation{}] = cache.ByObject{Label: resourceLabelSelector}
 	}
 
 	// see https://github.com/openshift/library-go/blob/4362aa519714a4b62b00ab8318197ba2bba51cb7/pkg/config/leaderelection/leaderelection.go#L104
@@ -262,12 +441,13 @@ func main() {
 		RenewDeadline:                 &renewDeadline,
 		RetryPeriod:                   &retryPeriod,
 		PprofBindAddress:              pprofAddr,
-		WebhookServer: webhook.NewServer(webhook.Options{
+		WebhookServer: tracing.WebhookServer(webhook.NewServer(webhook.Options{
 			Port:    webhookPort,
 			TLSOpts: optionsTlSOptsFuncs,
-		}),
+		})),
 		Cache: cache.Options{
 			DefaultNamespaces: namespaces,
+			ByObject:          cacheByObject,
 		},
 	}
 
@@ -277,13 +457,15 @@ func main() {
 		os.Exit(1)
 	}
 
-	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
-	if err != nil {
-		setupLog.Error(err, "failed to create kubernetes clientset")
+	if namespaceSelector != nil && watchNamespaceFrequency > 0 {
+		// the cache can't watch new namespaces once started, so the leader restarts when they change, the other
+		// replicas restarting once they are elected
+		if err = mgr.Add(watchnamespace.NewWatcher(clientset, watchNamespaces, namespaceSelector, resolvedNamespaces, watchNamespaceFrequency, ctrl.Log.WithName("watch-namespace"))); err != nil {
+			setupLog.Error(err, "failed to add the watched namespaces watcher")
+			os.Exit(1)
+		}
 	}
 
-	ctx := ctrl.SetupSignalHandler()
-
 	if createOpenShiftDashboard {
 		dashErr := mgr.Add(openshiftDashboards.NewDashboardManagement(clientset))
 		if dashErr != nil {
@@ -300,6 +482,58 @@ func main() {
 		os.Exit(1)
 	}
 
+	if defaultSidecarCollector != "" {
+		if namespace, name, ok := strings.Cut(defaultSidecarCollector, "/"); !ok || namespace == "" || name == "" {
+			setupLog.Error(fmt.Errorf("invalid default sidecar collector %q", defaultSidecarCollector), "The default sidecar collector must be set as namespace/name.")
+			os.Exit(1)
+		}
+	}
+
+	var excludedNamespaceSelector, excludedPodSelector labels.Selector
+	if excludedNamespaces != "" {
+		if excludedNamespaceSelector, err = labels.Parse(excludedNamespaces); err != nil {
+			setupLog.Error(err, "The excluded namespaces must be set as a label selector.")
+			os.Exit(1)
+		}
+	}
+	if excludedPods != "" {
+		if excludedPodSelector, err = labels.Parse(excludedPods); err != nil {
+			setupLog.Error(err, "The excluded pods must be set as a label selector.")
+			os.Exit(1)
+		}
+	}
+	var excludedContainerPattern *regexp.Regexp
+	if excludedContainers != "" {
+		if excludedContainerPattern, err = regexp.Compile("^(?:" + excludedContainers + ")$"); err != nil {
+			setupLog.Error(err, "The excluded containers must be set as a regular expression.")
+			os.Exit(1)
+		}
+	}
+
+	if err = reconcileOptions.Validate(); err != nil {
+		setupLog.Error(err, "invalid reconcile options")
+		os.Exit(1)
+	}
+	controllerOptions, err := config.ParseControllerReconcileOptions(controllerReconcileOptions, reconcileOptions)
+	if err != nil {
+		setupLog.Error(err, "invalid controller reconcile options")
+		os.Exit(1)
+	}
+	webhookConfigOptions, err := webhookconfig.ParseOptions(webhookOptions)
+	if err != nil {
+		setupLog.Error(err, "invalid webhook options")
+		os.Exit(1)
+	}
+	upgradePolicy := config.UpgradePolicy{}
+	if upgradePolicy.Channel, err = config.ParseUpgradeChannel(upgradeChannel); err != nil {
+		setupLog.Error(err, "invalid upgrade channel")
+		os.Exit(1)
+	}
+	if upgradePolicy.MaintenanceWindows, err = config.ParseMaintenanceWindows(maintenanceWindows); err != nil {
+		setupLog.Error(err, "invalid upgrade maintenance windows")
+		os.Exit(1)
+	}
+
 	configLog := ctrl.Log.WithName("config")
 	cfg := config.New(
 		config.WithLogger(configLog),
@@ -310,11 +544,15 @@ func main() {
 		config.WithEnableDotNetInstrumentation(enableDotNetInstrumentation),
 		config.WithEnableGoInstrumentation(enableGoInstrumentation),
 		config.WithEnableNginxInstrumentation(enableNginxInstrumentation),
+		config.WithEnablePHPInstrumentation(enablePHPInstrumentation),
+		config.WithEnableRubyInstrumentation(enableRubyInstrumentation),
 		config.WithEnablePythonInstrumentation(enablePythonInstrumentation),
 		config.WithEnableNodeJSInstrumentation(enableNodeJSInstrumentation),
 		config.WithEnableJavaInstrumentation(enableJavaInstrumentation),
 		config.WithTargetAllocatorImage(targetAllocatorImage),
 		config.WithOperatorOpAMPBridgeImage(operatorOpAMPBridgeImage),
+		config.WithConfigReloaderImage(configReloaderImage),
+		config.WithDefaultSidecarCollector(defaultSidecarCollector),
 		config.WithAutoInstrumentationJavaImage(autoInstrumentationJava),
 		config.WithAutoInstrumentationNodeJSImage(autoInstrumentationNodeJS),
 		config.WithAutoInstrumentationPythonImage(autoInstrumentationPython),
@@ -322,14 +560,35 @@ func main() {
 		config.WithAutoInstrumentationGoImage(autoInstrumentationGo),
 		config.WithAutoInstrumentationApacheHttpdImage(autoInstrumentationApacheHttpd),
 		config.WithAutoInstrumentationNginxImage(autoInstrumentationNginx),
+		config.WithAutoInstrumentationPHPImage(autoInstrumentationPHP),
+		config.WithAutoInstrumentationRubyImage(autoInstrumentationRuby),
 		config.WithLabelFilters(labelsFilter),
 		config.WithAnnotationFilters(annotationsFilter),
 		config.WithIgnoreMissingCollectorCRDs(ignoreMissingCollectorCRDs),
+		config.WithAutoDetectFrequency(autoDetectFrequency),
+		config.WithInstrumentationExcludedNamespaces(excludedNamespaceSelector),
+		config.WithInstrumentationExcludedPods(excludedPodSelector),
+		config.WithInstrumentationExcludedContainers(excludedContainerPattern),
+		config.WithResourceSelector(resourceLabelSelector),
+		config.WithReconcileOptions(reconcileOptions),
+		config.WithControllerReconcileOptions(controllerOptions),
+		config.WithUpgradePolicy(upgradePolicy),
 	)
 	err = autodetect.ApplyAutoDetect(ad, &cfg, configLog)
 	if err != nil {
 		setupLog.Error(err, "failed to autodetect config variables")
 	}
+	if cfg.AutoDetectFrequency > 0 {
+		// The APIs can become available at any time, and the scheme can't be safely changed once the manager
+		// is running, so we register all of them upfront.
+		setupLog.Info("Periodic auto-detection is enabled, adding all the optional APIs to scheme.", "frequency", cfg.AutoDetectFrequency)
+		utilruntime.Must(monitoringv1.AddToScheme(scheme))
+		utilruntime.Must(routev1.Install(scheme))
+		utilruntime.Must(cmv1.AddToScheme(scheme))
+		utilruntime.Must(gatewayv1.Install(scheme))
+		utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+		utilruntime.Must(vpav1.AddToScheme(scheme))
+	}
 	// Only add these to the scheme if they are available
 	if cfg.PrometheusCRAvailability == prometheus.Available {
 		setupLog.Info("Prometheus CRDs are installed, adding to scheme.")
@@ -343,6 +602,24 @@ func main() {
 	} else {
 		setupLog.Info("Openshift CRDs are not installed, skipping adding to scheme.")
 	}
+	if cfg.GatewayAPIAvailability == gatewayapi.Available {
+		setupLog.Info("Gateway API CRDs are installed, adding to scheme.")
+		utilruntime.Must(gatewayv1.Install(scheme))
+	} else {
+		setupLog.Info("Gateway API CRDs are not installed, skipping adding to scheme.")
+	}
+	if cfg.KedaAvailability == keda.Available {
+		setupLog.Info("KEDA CRDs are installed, adding to scheme.")
+		utilruntime.Must(kedav1alpha1.AddToScheme(scheme))
+	} else {
+		setupLog.Info("KEDA CRDs are not installed, skipping adding to scheme.")
+	}
+	if cfg.VPAAvailability == vpa.Available {
+		setupLog.Info("VerticalPodAutoscaler CRDs are installed, adding to scheme.")
+		utilruntime.Must(vpav1.AddToScheme(scheme))
+	} else {
+		setupLog.Info("VerticalPodAutoscaler CRDs are not installed, skipping adding to scheme.")
+	}
 	if cfg.CertManagerAvailability == certmanager.Available {
 		setupLog.Info("Cert-Manager is available to the operator, adding to scheme.")
 		utilruntime.Must(cmv1.AddToScheme(scheme))
@@ -388,13 +665,22 @@ func main() {
 
 	var collectorReconciler *controllers.OpenTelemetryCollectorReconciler
 	if cfg.CollectorAvailability == collector.Available {
+		// the pods of the applications aren't cached for the collector reconciler
+		managedPods, err := controllers.NewManagedPodsCache(mgr, namespaces)
+		if err != nil {
+			setupLog.Error(err, "failed to create the cache of the managed pods")
+			os.Exit(1)
+		}
 		collectorReconciler = controllers.NewReconciler(controllers.Params{
-			Client:   mgr.GetClient(),
-			Log:      ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollector"),
-			Scheme:   mgr.GetScheme(),
-			Config:   cfg,
-			Recorder: mgr.GetEventRecorderFor("opentelemetry-operator"),
-			Reviewer: reviewer,
+			Client:    mgr.GetClient(),
+			Pods:      managedPods,
+			APIReader: mgr.GetAPIReader(),
+			Log:       ctrl.Log.WithName("controllers").WithName("OpenTelemetryCollector"),
+			Scheme:    mgr.GetScheme(),
+			Config:    cfg,
+			Recorder:  mgr.GetEventRecorderFor("opentelemetry-operator"),
+			// the permissions of the collectors are reviewed on every reconciliation
+			Reviewer: rbac.NewCachingReviewer(clientset, 5*time.Minute),
 			Version:  v,
 		})
 
@@ -402,15 +688,31 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "OpenTelemetryCollector")
 			os.Exit(1)
 		}
+		if err = mgr.Add(collectorStatus.NewHealthChecker(mgr.GetClient(), managedPods, ctrl.Log.WithName("collector-health"))); err != nil {
+			setupLog.Error(err, "failed to add the health checker of the collectors")
+			os.Exit(1)
+		}
 	}
 
 	if cfg.TargetAllocatorAvailability == targetallocator.Available {
+		// the certificate Secrets issued without cert-manager are cached apart, filtered by their labels
+		var taSecrets cache.Cache
+		if cfg.CertManagerAvailability != certmanager.Available && featuregate.EnableTargetAllocatorMTLS.IsEnabled() {
+			if taSecrets, err = controllers.NewCertificateSecretsCache(mgr, namespaces); err == nil {
+				err = mgr.Add(taSecrets)
+			}
+			if err != nil {
+				setupLog.Error(err, "failed to create the cache of the target allocator certificates")
+				os.Exit(1)
+			}
+		}
 		if err = controllers.NewTargetAllocatorReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
 			mgr.GetEventRecorderFor("targetallocator"),
 			cfg,
 			ctrl.Log.WithName("controllers").WithName("TargetAllocator"),
+			taSecrets,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "TargetAllocator")
 			os.Exit(1)
@@ -428,6 +730,85 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = controllers.NewInstrumentationReconciler(
+		mgr.GetClient(),
+		mgr.GetScheme(),
+		cfg,
+		ctrl.Log.WithName("controllers").WithName("Instrumentation"),
+	).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Instrumentation")
+		os.Exit(1)
+	}
+
+	capabilitiesReporter, err := capabilities.NewReporter(mgr.GetConfig(), scheme, cfg, ad.FIPSEnabled(ctx), ctrl.Log.WithName("capabilities"))
+	if err != nil {
+		setupLog.Error(err, "failed to create the capabilities reporter")
+		os.Exit(1)
+	}
+	if err = mgr.Add(capabilitiesReporter); err != nil {
+		setupLog.Error(err, "failed to add the capabilities reporter to the manager")
+		os.Exit(1)
+	}
+
+	// the configuration is updated by both the auto-detection poller and the configuration file watcher, and shared
+	// with the webhooks and the pod mutators through the provider
+	cfgProvider := config.NewProvider(cfg)
+	var configMu sync.Mutex
+	detectedCfg := cfg
+	updateConfig := func(ctx context.Context) error {
+		next := detectedCfg
+		if operatorFile != nil {
+			operatorFile.ApplyReloadable(&next, isExplicitFlag)
+		}
+		cfgProvider.Set(next)
+		if err := capabilitiesReporter.Update(ctx, next); err != nil {
+			return err
+		}
+		if collectorReconciler == nil {
+			return nil
+		}
+		return collectorReconciler.UpdateConfig(ctx, next)
+	}
+
+	if cfg.AutoDetectFrequency > 0 {
+		poller := autodetect.NewPoller(ad, cfg, cfg.AutoDetectFrequency, configLog, func(ctx context.Context, _, current config.Config) error {
+			configMu.Lock()
+			defer configMu.Unlock()
+			detectedCfg = current
+			return updateConfig(ctx)
+		})
+		if err = mgr.Add(poller); err != nil {
+			setupLog.Error(err, "failed to add the auto-detect poller to the manager")
+			os.Exit(1)
+		}
+	}
+
+	if operatorFile != nil && configFileFrequency > 0 {
+		fileWatcher, watcherErr := config.NewFileWatcher(configFile, operatorFileContent, configFileFrequency, isExplicitFlag, configLog, func(ctx context.Context, file *config.File) error {
+			configMu.Lock()
+			defer configMu.Unlock()
+			operatorFile = file
+			if err := updateConfig(ctx); err != nil {
+				return err
+			}
+			// the leader upgrades the Instrumentations using the previous default images
+			select {
+			case <-mgr.Elected():
+				return newInstrumentationUpgrade(mgr, cfgProvider.Config()).ManagedInstances(ctx)
+			default:
+				return nil
+			}
+		})
+		if watcherErr != nil {
+			setupLog.Error(watcherErr, "failed to create the operator configuration file watcher")
+			os.Exit(1)
+		}
+		if err = mgr.Add(fileWatcher); err != nil {
+			setupLog.Error(err, "failed to add the operator configuration file watcher to the manager")
+			os.Exit(1)
+		}
+	}
+
 	if cfg.PrometheusCRAvailability == prometheus.Available && createSMOperatorMetrics {
 		operatorMetrics, opError := operatormetrics.NewOperatorMetrics(mgr.GetConfig(), scheme, ctrl.Log.WithName("operator-metrics-sm"))
 		if opError != nil {
@@ -455,6 +836,10 @@ func main() {
 		}
 
 		if cfg.CollectorAvailability == collector.Available {
+			var imagePlatformResolver imageplatform.Resolver
+			if checkImagePlatforms {
+				imagePlatformResolver = imageplatform.NewRegistryResolver(5 * time.Second)
+			}
 			bv := func(ctx context.Context, collector otelv1beta1.OpenTelemetryCollector) admission.Warnings {
 				var warnings admission.Warnings
 				params, newErr := collectorReconciler.GetParams(ctx, collector)
@@ -469,6 +854,9 @@ func main() {
 					warnings = append(warnings, newErr.Error())
 					return warnings
 				}
+				if imagePlatformResolver != nil {
+					warnings = append(warnings, imageplatform.CollectorWarnings(ctx, imagePlatformResolver, params.Config, collector)...)
+				}
 				return warnings
 			}
 
@@ -478,18 +866,18 @@ func main() {
 				logger.Info("Fips disabled components", "receivers", receivers, "exporters", exporters, "processors", processors, "extensions", extensions)
 				fipsCheck = fips.NewFipsCheck(receivers, exporters, processors, extensions)
 			}
-			if err = otelv1beta1.SetupCollectorWebhook(mgr, cfg, reviewer, crdMetrics, bv, fipsCheck); err != nil {
+			if err = otelv1beta1.SetupCollectorWebhook(mgr, cfgProvider, reviewer, crdMetrics, bv, fipsCheck); err != nil {
 				setupLog.Error(err, "unable to create webhook", "webhook", "OpenTelemetryCollector")
 				os.Exit(1)
 			}
 		}
 		if cfg.TargetAllocatorAvailability == targetallocator.Available {
-			if err = otelv1alpha1.SetupTargetAllocatorWebhook(mgr, cfg, reviewer); err != nil {
+			if err = otelv1alpha1.SetupTargetAllocatorWebhook(mgr, cfgProvider, reviewer); err != nil {
 				setupLog.Error(err, "unable to create webhook", "webhook", "TargetAllocator")
 				os.Exit(1)
 			}
 		}
-		if err = otelv1alpha1.SetupInstrumentationWebhook(mgr, cfg); err != nil {
+		if err = otelv1alpha1.SetupInstrumentationWebhook(mgr, cfgProvider); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "Instrumentation")
 			os.Exit(1)
 		}
@@ -497,15 +885,29 @@ func main() {
 		mgr.GetWebhookServer().Register("/mutate-v1-pod", &webhook.Admission{
 			Handler: podmutation.NewWebhookHandler(cfg, ctrl.Log.WithName("pod-webhook"), decoder, mgr.GetClient(),
 				[]podmutation.PodMutator{
-					sidecar.NewMutator(logger, cfg, mgr.GetClient()),
-					instrumentation.NewMutator(logger, mgr.GetClient(), mgr.GetEventRecorderFor("opentelemetry-operator"), cfg),
+					sidecar.NewMutator(logger, cfgProvider, mgr.GetClient()),
+					instrumentation.NewMutator(logger, mgr.GetClient(), mgr.GetEventRecorderFor("opentelemetry-operator"), cfgProvider),
 				}),
 		})
 
-		if err = otelv1alpha1.SetupOpAMPBridgeWebhook(mgr, cfg); err != nil {
+		if err = otelv1alpha1.SetupOpAMPBridgeWebhook(mgr, cfgProvider); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "OpAMPBridge")
 			os.Exit(1)
 		}
+
+		if mutatingWebhookConfiguration != "" {
+			// the pods of the operator can't wait for the operator to mutate them
+			excluded := webhookExcludedNamespaces
+			if operatorNamespace, nsErr := autodetectutils.GetOperatorNamespace(); nsErr == nil {
+				excluded = append(excluded, operatorNamespace)
+			} else {
+				setupLog.Error(nsErr, "failed to get the operator namespace, its pods won't be excluded from the pod webhook")
+			}
+			if err = mgr.Add(webhookconfig.NewManager(clientset, mutatingWebhookConfiguration, webhookConfigOptions, excluded, time.Minute, ctrl.Log.WithName("webhook-configuration"))); err != nil {
+				setupLog.Error(err, "failed to add the webhook configuration manager")
+				os.Exit(1)
+			}
+		}
 	} else {
 		ctrl.Log.Info("Webhooks are disabled, operator is running an unsupported mode", "ENABLE_WEBHOOKS", "false")
 	}
@@ -522,7 +924,20 @@ func main() {
 
 	setupLog.Info("starting manager")
 	// NOTE: We enable LeaderElectionReleaseOnCancel, and to be safe we need to exit right after the manager does
-	if err := mgr.Start(ctx); err != nil {
+	err = mgr.Start(ctx)
+	if shutdownTracing != nil {
+		// the context of the manager is done, the pending spans are flushed within a new one
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if shutdownErr := shutdownTracing(shutdownCtx); shutdownErr != nil {
+			setupLog.Error(shutdownErr, "failed to flush the spans")
+		}
+		cancel()
+	}
+	if err != nil {
+		if errors.Is(err, watchnamespace.ErrNamespacesChanged) || errors.Is(err, config.ErrFileChanged) {
+			setupLog.Info("exiting to restart with the new configuration")
+			os.Exit(0)
+		}
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -531,13 +946,7 @@ func main() {
 func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config) error {
 	// adds the upgrade mechanism to be executed once the manager is ready
 	err := mgr.Add(manager.RunnableFunc(func(c context.Context) error {
-		u := instrumentationupgrade.NewInstrumentationUpgrade(
-			mgr.GetClient(),
-			ctrl.Log.WithName("instrumentation-upgrade"),
-			mgr.GetEventRecorderFor("opentelemetry-operator"),
-			cfg,
-		)
-		return u.ManagedInstances(c)
+		return newInstrumentationUpgrade(mgr, cfg).ManagedInstances(c)
 	}))
 	if err != nil {
 		return fmt.Errorf("failed to upgrade Instrumentation instances: %w", err)
@@ -545,6 +954,17 @@ func addDependencies(_ context.Context, mgr ctrl.Manager, cfg config.Config) err
 	return nil
 }
 
+// newInstrumentationUpgrade returns the upgrade of the Instrumentations using the default images of a previous
+// configuration to the default images of the given one.
+func newInstrumentationUpgrade(mgr ctrl.Manager, cfg config.Config) *instrumentationupgrade.InstrumentationUpgrade {
+	return instrumentationupgrade.NewInstrumentationUpgrade(
+		mgr.GetClient(),
+		ctrl.Log.WithName("instrumentation-upgrade"),
+		mgr.GetEventRecorderFor("opentelemetry-operator"),
+		cfg,
+	)
+}
+
 func parseFipsFlag(fipsFlag string) ([]string, []string, []string, []string) {
 	split := strings.Split(fipsFlag, ",")
 	var receivers []string
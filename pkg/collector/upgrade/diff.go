@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/yaml"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// Diff is what the upgrade of a collector would change.
+type Diff struct {
+	// From is the version of the collector before the upgrade.
+	From string
+	// To is the version of the collector after the upgrade.
+	To string
+	// Spec is the unified diff of the spec of the collector, in YAML, empty when the spec is unchanged.
+	Spec string
+	// ConfigMigrations are the changes of the configuration reported by the upgrade.
+	ConfigMigrations []v1beta1.ConfigMigration
+	// Events are the events the upgrade would record on the collector, e.g. the changes of defaults to review.
+	Events []string
+}
+
+// Diff returns what upgrading the collector would change, without changing the collector nor the cluster.
+func (u VersionUpgrade) Diff(ctx context.Context, original v1beta1.OpenTelemetryCollector) (Diff, error) {
+	// the deep copies of the collectors share the nested maps of their configuration, which the upgrade routines
+	// change, so the upgrade runs on a copy decoded from the collector
+	raw, err := json.Marshal(&original)
+	if err != nil {
+		return Diff{}, err
+	}
+	instance := v1beta1.OpenTelemetryCollector{}
+	if err = json.Unmarshal(raw, &instance); err != nil {
+		return Diff{}, err
+	}
+	recorder := record.NewFakeRecorder(RecordBufferSize)
+	u.Recorder = recorder
+	upgraded, err := u.ManagedInstance(ctx, instance)
+	if err != nil {
+		return Diff{}, err
+	}
+
+	before, err := yaml.Marshal(&original.Spec)
+	if err != nil {
+		return Diff{}, err
+	}
+	after, err := yaml.Marshal(&upgraded.Spec)
+	if err != nil {
+		return Diff{}, err
+	}
+	spec, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(before)),
+		B:        difflib.SplitLines(string(after)),
+		FromFile: fmt.Sprintf("%s/%s spec (%s)", original.Namespace, original.Name, original.Status.Version),
+		ToFile:   fmt.Sprintf("%s/%s spec (%s)", original.Namespace, original.Name, upgraded.Status.Version),
+		Context:  3,
+	})
+	if err != nil {
+		return Diff{}, err
+	}
+
+	diff := Diff{
+		From:             original.Status.Version,
+		To:               upgraded.Status.Version,
+		Spec:             spec,
+		ConfigMigrations: upgraded.Status.ConfigMigrations[len(original.Status.ConfigMigrations):],
+	}
+	close(recorder.Events)
+	for event := range recorder.Events {
+		diff.Events = append(diff.Events, event)
+	}
+	return diff, nil
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
+)
+
+func TestDiff(t *testing.T) {
+	collector := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "observability"},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{"otlp": map[string]interface{}{}}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"logging": map[string]interface{}{"loglevel": "debug"}}},
+				Service: v1beta1.Service{
+					Telemetry: &v1beta1.AnyConfig{Object: map[string]interface{}{"metrics": map[string]interface{}{"address": "0.0.0.0:8888"}}},
+					Pipelines: map[string]*v1beta1.Pipeline{
+						"traces": {Receivers: []string{"otlp"}, Exporters: []string{"logging"}},
+					},
+				},
+			},
+		},
+		Status: v1beta1.OpenTelemetryCollectorStatus{Version: "0.110.0"},
+	}
+	original, err := json.Marshal(&collector)
+	require.NoError(t, err)
+	versionUpgrade := &upgrade.VersionUpgrade{
+		Log:     logger,
+		Version: makeVersion("0.111.0"),
+	}
+
+	diff, err := versionUpgrade.Diff(context.Background(), collector)
+	require.NoError(t, err)
+
+	unchanged, err := json.Marshal(&collector)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(original), string(unchanged), "the collector must not be changed")
+	assert.Equal(t, "0.110.0", diff.From)
+	assert.Equal(t, "0.111.0", diff.To)
+	assert.Contains(t, diff.Spec, "--- observability/gateway spec (0.110.0)\n+++ observability/gateway spec (0.111.0)\n")
+	assert.Contains(t, diff.Spec, "\n-    logging:\n-      loglevel: debug\n")
+	assert.Contains(t, diff.Spec, "\n+    debug:\n+      verbosity: detailed\n")
+	assert.Contains(t, diff.Spec, "\n-        - logging\n+        - debug\n")
+	assert.Equal(t, []v1beta1.ConfigMigration{{
+		Version:     "0.111.0",
+		Description: "replaced the removed logging exporters with the debug exporters debug",
+	}}, diff.ConfigMigrations)
+}
+
+func TestDiffUpToDate(t *testing.T) {
+	collector := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "observability"},
+		Status:     v1beta1.OpenTelemetryCollectorStatus{Version: "0.111.0"},
+	}
+	versionUpgrade := &upgrade.VersionUpgrade{
+		Log:     logger,
+		Version: makeVersion("0.111.0"),
+	}
+
+	diff, err := versionUpgrade.Diff(context.Background(), collector)
+	require.NoError(t, err)
+	assert.Empty(t, diff.Spec)
+	assert.Empty(t, diff.ConfigMigrations)
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+// configMigrated reports a change of the configuration made by the upgrade to the given collector version in the
+// status of the collector.
+func configMigrated(otelcol *v1beta1.OpenTelemetryCollector, version, description string) {
+	otelcol.Status.ConfigMigrations = append(otelcol.Status.ConfigMigrations, v1beta1.ConfigMigration{
+		Version:     version,
+		Description: description,
+	})
+}
+
+// renameComponentType renames the components of the given kind and type, e.g. logging and logging/detailed for the
+// logging exporters, to the new type, keeping their names, in the configuration and in the pipelines using them.
+// It returns the new IDs of the renamed components, sorted.
+func renameComponentType(cfg *v1beta1.Config, kind v1beta1.ComponentKind, from, to string) ([]string, error) {
+	components := componentsOfKind(cfg, kind)
+	if components == nil {
+		return nil, nil
+	}
+
+	renamed := map[string]string{}
+	for id := range components {
+		componentType, name, _ := strings.Cut(id, "/")
+		if componentType != from {
+			continue
+		}
+		newID := to
+		if name != "" {
+			newID = to + "/" + name
+		}
+		if _, ok := components[newID]; ok {
+			return nil, fmt.Errorf("can't rename the %s %s to %s, which already exists", kind, id, newID)
+		}
+		renamed[id] = newID
+	}
+	if len(renamed) == 0 {
+		return nil, nil
+	}
+	var newIDs []string
+	for id, newID := range renamed {
+		components[newID] = components[id]
+		delete(components, id)
+		newIDs = append(newIDs, newID)
+	}
+
+	rename := func(ids []string) {
+		for i, id := range ids {
+			if newID, ok := renamed[id]; ok {
+				ids[i] = newID
+			}
+		}
+	}
+	if kind == v1beta1.KindExtension {
+		rename(cfg.Service.Extensions)
+	}
+	for _, pipeline := range cfg.Service.Pipelines {
+		if pipeline == nil {
+			continue
+		}
+		switch kind {
+		case v1beta1.KindReceiver:
+			rename(pipeline.Receivers)
+		case v1beta1.KindProcessor:
+			rename(pipeline.Processors)
+		case v1beta1.KindExporter:
+			rename(pipeline.Exporters)
+		}
+	}
+	sort.Strings(newIDs)
+	return newIDs, nil
+}
+
+// componentsOfKind returns the configurations of the components of the given kind, by ID.
+func componentsOfKind(cfg *v1beta1.Config, kind v1beta1.ComponentKind) map[string]interface{} {
+	switch kind {
+	case v1beta1.KindReceiver:
+		return cfg.Receivers.Object
+	case v1beta1.KindExporter:
+		return cfg.Exporters.Object
+	case v1beta1.KindProcessor:
+		if cfg.Processors != nil {
+			return cfg.Processors.Object
+		}
+	case v1beta1.KindExtension:
+		if cfg.Extensions != nil {
+			return cfg.Extensions.Object
+		}
+	}
+	return nil
+}
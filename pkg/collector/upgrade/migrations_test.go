@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+)
+
+func TestRenameComponentType(t *testing.T) {
+	cfg := v1beta1.Config{
+		Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{
+			"logging":          map[string]interface{}{},
+			"logging/detailed": map[string]interface{}{"verbosity": "detailed"},
+			"otlp":             map[string]interface{}{},
+		}},
+		Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+			"traces":  {Receivers: []string{"logging"}, Exporters: []string{"otlp", "logging"}},
+			"metrics": {Exporters: []string{"logging/detailed"}},
+		}},
+	}
+
+	renamed, err := renameComponentType(&cfg, v1beta1.KindExporter, "logging", "debug")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"debug", "debug/detailed"}, renamed)
+	assert.Equal(t, map[string]interface{}{
+		"debug":          map[string]interface{}{},
+		"debug/detailed": map[string]interface{}{"verbosity": "detailed"},
+		"otlp":           map[string]interface{}{},
+	}, cfg.Exporters.Object)
+	// only the exporters are renamed
+	assert.Equal(t, []string{"logging"}, cfg.Service.Pipelines["traces"].Receivers)
+	assert.Equal(t, []string{"otlp", "debug"}, cfg.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, []string{"debug/detailed"}, cfg.Service.Pipelines["metrics"].Exporters)
+
+	renamed, err = renameComponentType(&cfg, v1beta1.KindProcessor, "batch", "batcher")
+	require.NoError(t, err)
+	assert.Empty(t, renamed)
+}
+
+func TestRenameComponentTypeConflict(t *testing.T) {
+	cfg := v1beta1.Config{
+		Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{
+			"logging": map[string]interface{}{},
+			"debug":   map[string]interface{}{},
+		}},
+	}
+	_, err := renameComponentType(&cfg, v1beta1.KindExporter, "logging", "debug")
+	assert.ErrorContains(t, err, "can't rename the exporter logging to debug, which already exists")
+	assert.Contains(t, cfg.Exporters.Object, "logging")
+}
+
+func TestMigrateLoggingExporters(t *testing.T) {
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Config: v1beta1.Config{
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"logging":       map[string]interface{}{"loglevel": "debug"},
+					"logging/quiet": map[string]interface{}{"loglevel": "warn", "verbosity": "normal"},
+				}},
+				Service: v1beta1.Service{Pipelines: map[string]*v1beta1.Pipeline{
+					"traces": {Exporters: []string{"logging", "logging/quiet"}},
+				}},
+			},
+		},
+	}
+
+	require.NoError(t, migrateLoggingExporters(otelcol))
+	assert.Equal(t, map[string]interface{}{
+		"debug":       map[string]interface{}{"verbosity": "detailed"},
+		"debug/quiet": map[string]interface{}{"verbosity": "normal"},
+	}, otelcol.Spec.Config.Exporters.Object)
+	assert.Equal(t, []string{"debug", "debug/quiet"}, otelcol.Spec.Config.Service.Pipelines["traces"].Exporters)
+	assert.Equal(t, []v1beta1.ConfigMigration{{
+		Version:     "0.111.0",
+		Description: "replaced the removed logging exporters with the debug exporters debug, debug/quiet",
+	}}, otelcol.Status.ConfigMigrations)
+
+	// nothing left to migrate
+	require.NoError(t, migrateLoggingExporters(otelcol))
+	assert.Len(t, otelcol.Status.ConfigMigrations, 1)
+}
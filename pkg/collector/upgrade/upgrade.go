@@ -6,7 +6,9 @@ package upgrade
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"time"
 
 	semver "github.com/Masterminds/semver/v3"
 	"github.com/go-logr/logr"
@@ -16,14 +18,20 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 )
 
+// SkipUpgradeAnnotation opts a collector out of the automatic upgrades when set to "true".
+const SkipUpgradeAnnotation = "opentelemetry.io/skip-upgrade"
+
 type VersionUpgrade struct {
 	Client   client.Client
 	Recorder record.EventRecorder
 	Version  version.Version
 	Log      logr.Logger
+	// Policy holds back the upgrades outside of its channel and maintenance windows.
+	Policy config.UpgradePolicy
 }
 
 const RecordBufferSize int = 100
@@ -49,6 +57,21 @@ func (u VersionUpgrade) NeedsUpgrade(instance v1beta1.OpenTelemetryCollector) bo
 		instance.Spec.UpgradeStrategy != v1beta1.UpgradeStrategyNone
 }
 
+// HeldBack returns why the upgrade of a collector needing one is held back at the given time, empty when it can be
+// upgraded, and how long to wait for the next maintenance window, zero when waiting doesn't help.
+func (u VersionUpgrade) HeldBack(instance v1beta1.OpenTelemetryCollector, now time.Time) (string, time.Duration) {
+	if instance.Annotations[SkipUpgradeAnnotation] == "true" {
+		return fmt.Sprintf("the collector is annotated with %s", SkipUpgradeAnnotation), 0
+	}
+	if !u.Policy.Channel.Allows(instance.Status.Version, u.Version.OpenTelemetryCollector) {
+		return fmt.Sprintf("the upgrade from version %s to %s is outside of the %s upgrade channel", instance.Status.Version, u.Version.OpenTelemetryCollector, u.Policy.Channel), 0
+	}
+	if wait := u.Policy.UntilMaintenanceWindow(now); wait > 0 {
+		return fmt.Sprintf("the next maintenance window opens at %s", now.Add(wait).UTC().Format(time.RFC3339)), wait
+	}
+	return "", 0
+}
+
 // Upgrade performs an upgrade of an OpenTelemetryCollector CR in the cluster.
 func (u VersionUpgrade) Upgrade(ctx context.Context, original v1beta1.OpenTelemetryCollector) error {
 	if !u.NeedsUpgrade(original) {
@@ -79,6 +102,10 @@ func (u VersionUpgrade) Upgrade(ctx context.Context, original v1beta1.OpenTeleme
 			return err
 		}
 		itemLogger.Info("instance upgraded", "version", upgraded.Status.Version)
+		u.Recorder.Event(&upgraded, corev1.EventTypeNormal, "Upgraded", fmt.Sprintf("upgraded the collector from version %s to %s", original.Status.Version, upgraded.Status.Version))
+		for _, migration := range upgraded.Status.ConfigMigrations[len(original.Status.ConfigMigrations):] {
+			u.Recorder.Event(&upgraded, corev1.EventTypeNormal, "ConfigMigrated", migration.Description)
+		}
 	}
 
 	return nil
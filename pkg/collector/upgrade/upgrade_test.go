@@ -6,6 +6,7 @@ package upgrade_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/version"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
 )
@@ -88,6 +90,82 @@ func TestNeedsUpgrade(t *testing.T) {
 	}
 }
 
+func TestHeldBack(t *testing.T) {
+	windows, err := config.ParseMaintenanceWindows([]string{"0 2 * * SAT;4h"})
+	require.NoError(t, err)
+	// 2025-03-01 is a saturday
+	inWindow := time.Date(2025, 3, 1, 3, 0, 0, 0, time.UTC)
+	beforeWindow := time.Date(2025, 3, 1, 1, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		desc     string
+		policy   config.UpgradePolicy
+		from     string
+		skip     string
+		now      time.Time
+		expected string
+		wait     time.Duration
+	}{
+		{
+			desc:   "default policy",
+			policy: config.UpgradePolicy{Channel: config.UpgradeChannelMinor},
+			from:   "0.9.0",
+			now:    beforeWindow,
+		},
+		{
+			desc:     "opted out",
+			policy:   config.UpgradePolicy{Channel: config.UpgradeChannelMinor},
+			from:     "0.9.0",
+			skip:     "true",
+			now:      beforeWindow,
+			expected: "the collector is annotated with opentelemetry.io/skip-upgrade",
+		},
+		{
+			desc:     "outside of the channel",
+			policy:   config.UpgradePolicy{Channel: config.UpgradeChannelPatch},
+			from:     "0.9.0",
+			now:      beforeWindow,
+			expected: "the upgrade from version 0.9.0 to 0.10.0 is outside of the patch upgrade channel",
+		},
+		{
+			desc:   "patch release",
+			policy: config.UpgradePolicy{Channel: config.UpgradeChannelPatch},
+			from:   "0.10.0-rc.1",
+			now:    beforeWindow,
+		},
+		{
+			desc:   "in the maintenance window",
+			policy: config.UpgradePolicy{Channel: config.UpgradeChannelMinor, MaintenanceWindows: windows},
+			from:   "0.9.0",
+			now:    inWindow,
+		},
+		{
+			desc:     "before the maintenance window",
+			policy:   config.UpgradePolicy{Channel: config.UpgradeChannelMinor, MaintenanceWindows: windows},
+			from:     "0.9.0",
+			now:      beforeWindow,
+			expected: "the next maintenance window opens at 2025-03-01T02:00:00Z",
+			wait:     time.Hour,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			up := &upgrade.VersionUpgrade{
+				Version: version.Version{OpenTelemetryCollector: "0.10.0"},
+				Policy:  tt.policy,
+			}
+			collector := v1beta1.OpenTelemetryCollector{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{upgrade.SkipUpgradeAnnotation: tt.skip}},
+				Status:     v1beta1.OpenTelemetryCollectorStatus{Version: tt.from},
+			}
+			require.True(t, up.NeedsUpgrade(collector))
+
+			reason, wait := up.HeldBack(collector, tt.now)
+			assert.Equal(t, tt.expected, reason)
+			assert.Equal(t, tt.wait, wait)
+		})
+	}
+}
+
 func TestShouldUpgradeAllToLatestBasedOnUpgradeStrategy(t *testing.T) {
 	const beginV = "0.0.1" // this is the first version we have an upgrade function
 
@@ -5,6 +5,7 @@ package upgrade
 
 import (
 	"fmt"
+	"strings"
 
 	"dario.cat/mergo"
 	"github.com/go-logr/logr"
@@ -13,10 +14,47 @@ import (
 )
 
 func upgrade0_111_0(u VersionUpgrade, otelcol *v1beta1.OpenTelemetryCollector) (*v1beta1.OpenTelemetryCollector, error) { //nolint:unparam
-
+	if err := migrateLoggingExporters(otelcol); err != nil {
+		return otelcol, err
+	}
 	return otelcol, applyDefaults(otelcol, u.Log)
 }
 
+// loggingVerbosities maps the deprecated log levels of the logging exporter to the verbosities of the debug exporter,
+// as the logging exporter did.
+var loggingVerbosities = map[string]string{
+	"debug": "detailed",
+	"info":  "normal",
+	"warn":  "basic",
+	"error": "basic",
+}
+
+// migrateLoggingExporters replaces the logging exporters, removed from the collector in v0.111.0, with debug ones.
+func migrateLoggingExporters(otelcol *v1beta1.OpenTelemetryCollector) error {
+	renamed, err := renameComponentType(&otelcol.Spec.Config, v1beta1.KindExporter, "logging", "debug")
+	if err != nil || len(renamed) == 0 {
+		return err
+	}
+	for _, id := range renamed {
+		exporter, ok := otelcol.Spec.Config.Exporters.Object[id].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		logLevel, ok := exporter["loglevel"].(string)
+		if !ok {
+			continue
+		}
+		delete(exporter, "loglevel")
+		if _, ok := exporter["verbosity"]; !ok {
+			if verbosity, known := loggingVerbosities[strings.ToLower(logLevel)]; known {
+				exporter["verbosity"] = verbosity
+			}
+		}
+	}
+	configMigrated(otelcol, "0.111.0", fmt.Sprintf("replaced the removed logging exporters with the debug exporters %s", strings.Join(renamed, ", ")))
+	return nil
+}
+
 func applyDefaults(otelcol *v1beta1.OpenTelemetryCollector, logger logr.Logger) error {
 	telemetryAddr, telemetryPort, err := otelcol.Spec.Config.Service.MetricsEndpoint(logger)
 	if err != nil {
@@ -4,6 +4,8 @@
 package upgrade
 
 import (
+	"fmt"
+
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
 )
 
@@ -33,6 +35,7 @@ func upgrade0_122_0(u VersionUpgrade, otelcol *v1beta1.OpenTelemetryCollector) (
 	if err != nil {
 		return otelcol, err
 	}
+	configMigrated(otelcol, "0.122.0", fmt.Sprintf("replaced the deprecated service.telemetry.metrics.address with a Prometheus reader on %s:%d", host, port))
 
 	return otelcol, nil
 }
@@ -11,6 +11,7 @@ const (
 	EnvOTELTracesSamplerArg = "OTEL_TRACES_SAMPLER_ARG"
 
 	EnvOTELExporterOTLPEndpoint      = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvOTELExporterOTLPProtocol      = "OTEL_EXPORTER_OTLP_PROTOCOL"
 	EnvOTELExporterCertificate       = "OTEL_EXPORTER_OTLP_CERTIFICATE"
 	EnvOTELExporterClientCertificate = "OTEL_EXPORTER_OTLP_CLIENT_CERTIFICATE"
 	EnvOTELExporterClientKey         = "OTEL_EXPORTER_OTLP_CLIENT_KEY"
@@ -23,6 +24,8 @@ const (
 	AnnotationDefaultAutoInstrumentationGo          = InstrumentationPrefix + "default-auto-instrumentation-go-image"
 	AnnotationDefaultAutoInstrumentationApacheHttpd = InstrumentationPrefix + "default-auto-instrumentation-apache-httpd-image"
 	AnnotationDefaultAutoInstrumentationNginx       = InstrumentationPrefix + "default-auto-instrumentation-nginx-image"
+	AnnotationDefaultAutoInstrumentationPHP         = InstrumentationPrefix + "default-auto-instrumentation-php-image"
+	AnnotationDefaultAutoInstrumentationRuby        = InstrumentationPrefix + "default-auto-instrumentation-ruby-image"
 
 	LabelTargetAllocator              = "opentelemetry.io/target-allocator"
 	ResourceAttributeAnnotationPrefix = "resource.opentelemetry.io/"
@@ -41,6 +44,8 @@ const (
 	FlagNginx       = "enable-nginx-instrumentation"
 	FlagNodeJS      = "enable-nodejs-instrumentation"
 	FlagJava        = "enable-java-instrumentation"
+	FlagPHP         = "enable-php-instrumentation"
+	FlagRuby        = "enable-ruby-instrumentation"
 
 	TACollectorTLSDirPath      = "/tls"
 	TACollectorCAFileName      = "ca.crt"
@@ -25,14 +25,14 @@ var (
 	// EnableNativeSidecarContainers is the feature gate that controls whether a
 	// sidecar should be injected as a native sidecar or the classic way.
 	// Native sidecar containers have been available since kubernetes v1.28 in
-	// alpha and v1.29 in beta.
-	// It needs to be enabled with +featureGate=SidecarContainers.
+	// alpha and v1.29 in beta, so they're only used when the operator detects
+	// a v1.29+ cluster. Disabling the gate always injects classic sidecars.
 	// See:
 	// https://kubernetes.io/docs/reference/command-line-tools-reference/feature-gates/#feature-gates-for-alpha-or-beta-features
 	EnableNativeSidecarContainers = featuregate.GlobalRegistry().MustRegister(
 		"operator.sidecarcontainers.native",
-		featuregate.StageAlpha,
-		featuregate.WithRegisterDescription("controls whether the operator supports sidecar containers as init containers. Should only be enabled on k8s v1.29+"),
+		featuregate.StageBeta,
+		featuregate.WithRegisterDescription("controls whether the operator injects sidecar containers as init containers on k8s v1.29+"),
 		featuregate.WithRegisterFromVersion("v0.111.0"),
 	)
 	// PrometheusOperatorIsAvailable is the feature gate that enables features associated to the Prometheus Operator.
@@ -58,6 +58,14 @@ var (
 		featuregate.WithRegisterDescription("enables mTLS between the target allocator and the collector"),
 		featuregate.WithRegisterFromVersion("v0.111.0"),
 	)
+	// EnableTargetAllocatorAuthentication is the feature gate that restricts the target allocator API to the
+	// collector's service account, using service account tokens.
+	EnableTargetAllocatorAuthentication = featuregate.GlobalRegistry().MustRegister(
+		"operator.targetallocator.authentication",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("restricts the target allocator API to the collector's service account"),
+		featuregate.WithRegisterFromVersion("v0.127.0"),
+	)
 	// EnableTargetAllocatorFallbackStrategy is the feature gate that enables consistent-hashing as the fallback
 	// strategy for allocation strategies that might not assign all jobs (per-node).
 	EnableTargetAllocatorFallbackStrategy = featuregate.GlobalRegistry().MustRegister(
@@ -73,6 +81,23 @@ var (
 		featuregate.WithRegisterDescription("enables the operator to default the endpoint for known components"),
 		featuregate.WithRegisterFromVersion("v0.110.0"),
 	)
+	// EnableStructuralConfigValidation is the feature gate that makes the webhook reject collector configs whose structure
+	// the collector would refuse to start with, like pipelines referencing components which aren't configured. The
+	// settings of the components aren't validated.
+	EnableStructuralConfigValidation = featuregate.GlobalRegistry().MustRegister(
+		"operator.collector.structuralconfigvalidation",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("rejects collector configs with invalid component IDs or pipelines referencing components which aren't configured"),
+		featuregate.WithRegisterFromVersion("v0.127.0"),
+	)
+	// EnforceFIPS is the feature gate that makes the operator reject the collectors and target allocators with
+	// images which aren't built for FIPS, and restrict the TLS settings of the collectors to the FIPS approved ones.
+	EnforceFIPS = featuregate.GlobalRegistry().MustRegister(
+		"operator.fips.enforce",
+		featuregate.StageAlpha,
+		featuregate.WithRegisterDescription("rejects the images which aren't built for FIPS and restricts the TLS settings of the collectors to the FIPS approved ones"),
+		featuregate.WithRegisterFromVersion("v0.127.0"),
+	)
 )
 
 // Flags creates a new FlagSet that represents the available featuregate flags using the supplied featuregate registry.
@@ -4,6 +4,9 @@
 package instrumentation
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,8 +35,39 @@ const (
 	annotationInjectApacheHttpdContainersName = "instrumentation.opentelemetry.io/apache-httpd-container-names"
 	annotationInjectNginx                     = "instrumentation.opentelemetry.io/inject-nginx"
 	annotationInjectNginxContainersName       = "instrumentation.opentelemetry.io/inject-nginx-container-names"
+	annotationInjectPHP                       = "instrumentation.opentelemetry.io/inject-php"
+	annotationInjectPHPContainersName         = "instrumentation.opentelemetry.io/php-container-names"
+	annotationInjectRuby                      = "instrumentation.opentelemetry.io/inject-ruby"
+	annotationInjectRubyContainersName        = "instrumentation.opentelemetry.io/ruby-container-names"
+	// annotationContainerLanguages maps the containers of the pod to the language injected into them, e.g. "app=java,worker=python".
+	annotationContainerLanguages = "instrumentation.opentelemetry.io/container-languages"
+	// annotationSamplerType and annotationSamplerArgument override the sampler of the Instrumentation for a pod or a namespace.
+	annotationSamplerType     = "instrumentation.opentelemetry.io/sampler-type"
+	annotationSamplerArgument = "instrumentation.opentelemetry.io/sampler-argument"
+	// annotationInjected records on the injected pods the Instrumentation of each language, e.g. "java=ns/name,python=ns/name".
+	annotationInjected = "instrumentation.opentelemetry.io/injected"
+	// annotationAllowForeignInstrumentations lets the pods of a namespace use the Instrumentations of other namespaces
+	// selecting it with their namespaceSelector.
+	annotationAllowForeignInstrumentations = "instrumentation.opentelemetry.io/allow-foreign-instrumentations"
 )
 
+// languageAnnotations holds the inject and container names annotations of the languages of annotationContainerLanguages.
+var languageAnnotations = map[string]struct {
+	inject     string
+	containers string
+}{
+	"apache-httpd": {annotationInjectApacheHttpd, annotationInjectApacheHttpdContainersName},
+	"dotnet":       {annotationInjectDotNet, annotationInjectDotnetContainersName},
+	"go":           {annotationInjectGo, annotationInjectGoContainersName},
+	"java":         {annotationInjectJava, annotationInjectJavaContainersName},
+	"nginx":        {annotationInjectNginx, annotationInjectNginxContainersName},
+	"nodejs":       {annotationInjectNodeJS, annotationInjectNodeJSContainersName},
+	"php":          {annotationInjectPHP, annotationInjectPHPContainersName},
+	"python":       {annotationInjectPython, annotationInjectPythonContainersName},
+	"ruby":         {annotationInjectRuby, annotationInjectRubyContainersName},
+	"sdk":          {annotationInjectSdk, annotationInjectSdkContainersName},
+}
+
 // annotationValue returns the effective annotationInjectJava value, based on the annotations from the pod and namespace.
 func annotationValue(ns metav1.ObjectMeta, pod metav1.ObjectMeta, annotation string) string {
 	// is the pod annotated with instructions to inject sidecars? is the namespace annotated?
@@ -65,3 +99,50 @@ func annotationValue(ns metav1.ObjectMeta, pod metav1.ObjectMeta, annotation str
 	// so, the namespace annotation can be used
 	return nsAnnValue
 }
+
+// containerLanguages returns the pod metadata with the annotationContainerLanguages annotation expanded into the inject
+// and container names annotations of each language. A language which isn't injected by the pod or the namespace is
+// injected with the Instrumentation of the namespace, as with "true".
+func containerLanguages(ns metav1.ObjectMeta, pod metav1.ObjectMeta) (metav1.ObjectMeta, error) {
+	value := annotationValue(ns, pod, annotationContainerLanguages)
+	if value == "" {
+		return pod, nil
+	}
+
+	containers := map[string][]string{}
+	mapped := map[string]bool{}
+	for _, entry := range strings.Split(value, ",") {
+		container, language, ok := strings.Cut(entry, "=")
+		container, language = strings.TrimSpace(container), strings.TrimSpace(language)
+		if !ok || container == "" {
+			return pod, fmt.Errorf("invalid entry %q in the %s annotation, expected <container>=<language>", entry, annotationContainerLanguages)
+		}
+		if err := isValidContainersAnnotation(container); err != nil {
+			return pod, err
+		}
+		if _, found := languageAnnotations[language]; !found {
+			return pod, fmt.Errorf("unsupported language %q for the container %s in the %s annotation", language, container, annotationContainerLanguages)
+		}
+		if mapped[container] {
+			return pod, fmt.Errorf("the container %s is mapped to several languages in the %s annotation", container, annotationContainerLanguages)
+		}
+		mapped[container] = true
+		containers[language] = append(containers[language], container)
+	}
+
+	expanded := *pod.DeepCopy()
+	if expanded.Annotations == nil {
+		expanded.Annotations = map[string]string{}
+	}
+	for _, language := range slices.Sorted(maps.Keys(containers)) {
+		annotations := languageAnnotations[language]
+		if annotationValue(ns, pod, annotations.containers) != "" {
+			return pod, fmt.Errorf("the %s annotation can't be combined with the %s annotation", annotationContainerLanguages, annotations.containers)
+		}
+		if annotationValue(ns, pod, annotations.inject) == "" {
+			expanded.Annotations[annotations.inject] = "true"
+		}
+		expanded.Annotations[annotations.containers] = strings.Join(containers[language], ",")
+	}
+	return expanded, nil
+}
@@ -129,3 +129,102 @@ func TestEffectiveAnnotationValue(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerLanguages(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		pod      metav1.ObjectMeta
+		ns       metav1.ObjectMeta
+		expected map[string]string
+		err      string
+	}{
+		{
+			desc: "no container languages",
+			pod: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationInjectJava: "true"},
+			},
+			expected: map[string]string{annotationInjectJava: "true"},
+		},
+		{
+			desc: "containers mapped to languages",
+			pod: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationContainerLanguages: "app=java, worker=python,sidecar=java"},
+			},
+			expected: map[string]string{
+				annotationContainerLanguages:         "app=java, worker=python,sidecar=java",
+				annotationInjectJava:                 "true",
+				annotationInjectJavaContainersName:   "app,sidecar",
+				annotationInjectPython:               "true",
+				annotationInjectPythonContainersName: "worker",
+			},
+		},
+		{
+			desc: "instrumentation selected by the inject annotation",
+			pod: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotationContainerLanguages: "app=nodejs",
+					annotationInjectNodeJS:       "my-instrumentation",
+				},
+			},
+			expected: map[string]string{
+				annotationContainerLanguages:         "app=nodejs",
+				annotationInjectNodeJS:               "my-instrumentation",
+				annotationInjectNodeJSContainersName: "app",
+			},
+		},
+		{
+			desc: "instrumentation selected by the namespace",
+			pod: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationContainerLanguages: "app=go"},
+			},
+			ns: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationInjectGo: "my-instrumentation"},
+			},
+			expected: map[string]string{
+				annotationContainerLanguages:     "app=go",
+				annotationInjectGoContainersName: "app",
+			},
+		},
+		{
+			desc: "invalid entry",
+			pod: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationContainerLanguages: "app"},
+			},
+			err: `invalid entry "app" in the instrumentation.opentelemetry.io/container-languages annotation, expected <container>=<language>`,
+		},
+		{
+			desc: "unsupported language",
+			pod: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationContainerLanguages: "app=cobol"},
+			},
+			err: `unsupported language "cobol" for the container app in the instrumentation.opentelemetry.io/container-languages annotation`,
+		},
+		{
+			desc: "container mapped to several languages",
+			pod: metav1.ObjectMeta{
+				Annotations: map[string]string{annotationContainerLanguages: "app=java,app=python"},
+			},
+			err: "the container app is mapped to several languages in the instrumentation.opentelemetry.io/container-languages annotation",
+		},
+		{
+			desc: "combined with the container names of the language",
+			pod: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotationContainerLanguages:       "app=java",
+					annotationInjectJavaContainersName: "worker",
+				},
+			},
+			err: "the instrumentation.opentelemetry.io/container-languages annotation can't be combined with the instrumentation.opentelemetry.io/java-container-names annotation",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			meta, err := containerLanguages(tt.ns, tt.pod)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, meta.Annotations)
+		})
+	}
+}
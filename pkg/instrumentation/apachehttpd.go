@@ -164,7 +164,7 @@ func injectApacheHttpdagent(_ logr.Logger, apacheSpec v1alpha1.ApacheHttpd, pod
 					MountPath: apacheAgentConfDirFull,
 				},
 			},
-			ImagePullPolicy: instSpec.ImagePullPolicy,
+			ImagePullPolicy: initContainerPullPolicy(apacheSpec.ImagePullPolicy, instSpec.ImagePullPolicy),
 		})
 	}
 
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/manifestutils"
+)
+
+// ComponentExporterCertificate is the component label of the cert-manager Certificates of the exporters.
+const ComponentExporterCertificate = "opentelemetry-instrumentation-exporter"
+
+// ExporterCertificate returns the cert-manager Certificate of the client certificate of the exporter, written to the
+// secret the webhook mounts into the instrumented pods, or nil when the instrumentation doesn't request it.
+func ExporterCertificate(inst v1alpha1.Instrumentation) *cmv1.Certificate {
+	tls := inst.Spec.Exporter.TLS
+	if tls == nil || tls.Certificate == nil || tls.SecretName == "" {
+		return nil
+	}
+	commonName := tls.Certificate.CommonName
+	if commonName == "" {
+		commonName = inst.Name
+	}
+	return &cmv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tls.SecretName,
+			Namespace: inst.Namespace,
+			Labels:    manifestutils.SelectorLabels(inst.ObjectMeta, ComponentExporterCertificate),
+		},
+		Spec: cmv1.CertificateSpec{
+			CommonName: commonName,
+			Subject: &cmv1.X509Subject{
+				OrganizationalUnits: []string{"opentelemetry-operator"},
+			},
+			Duration:   tls.Certificate.Duration,
+			SecretName: tls.SecretName,
+			IssuerRef: cmmeta.ObjectReference{
+				Name:  tls.Certificate.IssuerName,
+				Kind:  tls.Certificate.IssuerKind,
+				Group: "cert-manager.io",
+			},
+			Usages: []cmv1.KeyUsage{
+				cmv1.UsageDigitalSignature,
+				cmv1.UsageKeyEncipherment,
+				cmv1.UsageClientAuth,
+			},
+		},
+	}
+}
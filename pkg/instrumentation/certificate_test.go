@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestExporterCertificate(t *testing.T) {
+	inst := v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "apps"}}
+	assert.Nil(t, ExporterCertificate(inst))
+
+	inst.Spec.Exporter.TLS = &v1alpha1.TLS{SecretName: "my-inst-exporter-tls"}
+	assert.Nil(t, ExporterCertificate(inst))
+
+	inst.Spec.Exporter.TLS.Certificate = &v1alpha1.ExporterCertificate{
+		IssuerName: "ca-issuer",
+		IssuerKind: "ClusterIssuer",
+		Duration:   &metav1.Duration{Duration: 24 * time.Hour},
+	}
+	cert := ExporterCertificate(inst)
+	require.NotNil(t, cert)
+	assert.Equal(t, "my-inst-exporter-tls", cert.Name)
+	assert.Equal(t, "apps", cert.Namespace)
+	assert.Equal(t, ComponentExporterCertificate, cert.Labels["app.kubernetes.io/component"])
+	assert.Equal(t, "my-inst", cert.Spec.CommonName)
+	assert.Equal(t, "my-inst-exporter-tls", cert.Spec.SecretName)
+	assert.Equal(t, "ca-issuer", cert.Spec.IssuerRef.Name)
+	assert.Equal(t, "ClusterIssuer", cert.Spec.IssuerRef.Kind)
+	assert.Equal(t, 24*time.Hour, cert.Spec.Duration.Duration)
+	assert.Contains(t, cert.Spec.Usages, cmv1.UsageClientAuth)
+
+	inst.Spec.Exporter.TLS.Certificate.CommonName = "checkout"
+	assert.Equal(t, "checkout", ExporterCertificate(inst).Spec.CommonName)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const agentConfigMountPath = "/otel-auto-instrumentation-config"
+
+// injectConfigFile mounts the ConfigMap key holding the configuration file of the agent of a language into the
+// container, and returns the path of the file.
+func injectConfigFile(pod *corev1.Pod, container *corev1.Container, ref *corev1.ConfigMapKeySelector, language string) string {
+	configVolumeName := fmt.Sprintf("%s-%s-config", volumeName, language)
+	mountPath := fmt.Sprintf("%s/%s", agentConfigMountPath, language)
+
+	if !hasVolume(pod.Spec.Volumes, configVolumeName) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: configVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: ref.LocalObjectReference,
+					Items:                []corev1.KeyToPath{{Key: ref.Key, Path: ref.Key}},
+					Optional:             ref.Optional,
+				},
+			},
+		})
+	}
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      configVolumeName,
+		MountPath: mountPath,
+		ReadOnly:  true,
+	})
+
+	return fmt.Sprintf("%s/%s", mountPath, ref.Key)
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestValidateConfigMapRef(t *testing.T) {
+	optional := true
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "javaagent-config", Namespace: "app"}}
+	pm := instPodMutator{Client: fake.NewClientBuilder().WithObjects(configMap).Build()}
+	ref := func(name string, optional *bool) *corev1.ConfigMapKeySelector {
+		return &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: "otel.properties", Optional: optional}
+	}
+	insts := func(ref *corev1.ConfigMapKeySelector) languageInstrumentations {
+		return languageInstrumentations{Java: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{
+			Spec: v1alpha1.InstrumentationSpec{Java: v1alpha1.Java{ConfigMapRef: ref}},
+		}}}
+	}
+
+	assert.NoError(t, pm.validateInstrumentations(context.Background(), insts(nil), "app"))
+	assert.NoError(t, pm.validateInstrumentations(context.Background(), insts(ref("javaagent-config", nil)), "app"))
+	assert.NoError(t, pm.validateInstrumentations(context.Background(), insts(ref("missing", &optional)), "app"))
+	err := pm.validateInstrumentations(context.Background(), insts(ref("missing", nil)), "app")
+	assert.ErrorContains(t, err, "configmap app/missing with the agent configuration file does not exist")
+}
+
+func TestConfigFilePresent(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodejs-config", Namespace: "app"},
+		Data:       map[string]string{"otel-config.js": "process.env.OTEL_SERVICE_NAME = 'app';"},
+	}
+	pm := instPodMutator{Client: fake.NewClientBuilder().WithObjects(configMap).Build()}
+	ref := func(name, key string) *corev1.ConfigMapKeySelector {
+		return &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+	}
+
+	for _, test := range []struct {
+		name     string
+		ref      *corev1.ConfigMapKeySelector
+		expected bool
+	}{
+		{name: "present", ref: ref("nodejs-config", "otel-config.js"), expected: true},
+		{name: "missing key", ref: ref("nodejs-config", "other.js")},
+		{name: "missing configmap", ref: ref("missing", "otel-config.js")},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			present, err := pm.configFilePresent(context.Background(), test.ref, "app")
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, present)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package instrumentation
 import (
 	"errors"
 	"fmt"
+	"path"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -31,14 +32,30 @@ const (
 	dotnetVolumeName                    = volumeName + "-dotnet"
 	dotnetInitContainerName             = initContainerName + "-dotnet"
 	dotnetInstrMountPath                = "/otel-auto-instrumentation-dotnet"
+	dotNetProfilerFileName              = "OpenTelemetry.AutoInstrumentation.Native.so"
+	dotNetDetectedProfilersPath         = "/otel-auto-instrumentation-dotnet/profiler"
 )
 
 // Supported .NET runtime identifiers (https://learn.microsoft.com/en-us/dotnet/core/rid-catalog), can be set by instrumentation.opentelemetry.io/inject-dotnet.
 const (
 	dotNetRuntimeLinuxGlibc = "linux-x64"
 	dotNetRuntimeLinuxMusl  = "linux-musl-x64"
+	// dotNetRuntimeAuto detects the runtime of the container when it starts.
+	dotNetRuntimeAuto = "auto"
 )
 
+// dotNetRuntimeHelper is the static busybox of the auto-instrumentation image, copied with the profilers. It runs the
+// detection in the image of the container, which may have no shell.
+const dotNetRuntimeHelper = dotnetInstrMountPath + "/bin/busybox"
+
+// dotNetRuntimeDetectionScript copies the profiler of the C library of the container image into the directory set in
+// $1: the musl one when the musl dynamic loader is found, the glibc one otherwise. Only the shell builtins and the
+// applets of the helper are used.
+const dotNetRuntimeDetectionScript = `runtime=` + dotNetRuntimeLinuxGlibc + `
+for loader in /lib/ld-musl-*; do if [ -e "$loader" ]; then runtime=` + dotNetRuntimeLinuxMusl + `; fi; done
+echo "detected the .NET runtime $runtime"
+"` + dotNetRuntimeHelper + `" mkdir -p "$1" && "` + dotNetRuntimeHelper + `" cp "` + dotnetInstrMountPath + `/$runtime/` + dotNetProfilerFileName + `" "$1/"`
+
 func injectDotNetSDK(dotNetSpec v1alpha1.DotNet, pod corev1.Pod, index int, runtime string, instSpec v1alpha1.InstrumentationSpec) (corev1.Pod, error) {
 
 	volume := instrVolume(dotNetSpec.VolumeClaimTemplate, dotnetVolumeName, dotNetSpec.VolumeSizeLimit)
@@ -63,12 +80,18 @@ func injectDotNetSDK(dotNetSpec v1alpha1.DotNet, pod corev1.Pod, index int, runt
 		return pod, errors.New("OTEL_DOTNET_AUTO_HOME environment variable is already set in the .NET instrumentation spec")
 	}
 
+	// the annotation takes precedence over the runtime of the instrumentation
+	if runtime == "" {
+		runtime = dotNetSpec.Runtime
+	}
 	coreClrProfilerPath := ""
 	switch runtime {
 	case "", dotNetRuntimeLinuxGlibc:
 		coreClrProfilerPath = dotNetCoreClrProfilerGlibcPath
 	case dotNetRuntimeLinuxMusl:
 		coreClrProfilerPath = dotNetCoreClrProfilerMuslPath
+	case dotNetRuntimeAuto:
+		coreClrProfilerPath = path.Join(dotNetDetectedProfilersPath, container.Name, dotNetProfilerFileName)
 	default:
 		return pod, fmt.Errorf("provided instrumentation.opentelemetry.io/dotnet-runtime annotation value '%s' is not supported", runtime)
 	}
@@ -112,7 +135,23 @@ func injectDotNetSDK(dotNetSpec v1alpha1.DotNet, pod corev1.Pod, index int, runt
 				Name:      volume.Name,
 				MountPath: dotnetInstrMountPath,
 			}},
-			ImagePullPolicy: instSpec.ImagePullPolicy,
+			ImagePullPolicy: initContainerPullPolicy(dotNetSpec.ImagePullPolicy, instSpec.ImagePullPolicy),
+		})
+	}
+
+	// the C library is detected in the image of the container, after the profilers are copied into the volume
+	if runtime == dotNetRuntimeAuto {
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
+			Name:      fmt.Sprintf("%s-runtime-%d", dotnetInitContainerName, index),
+			Image:     container.Image,
+			Command:   []string{dotNetRuntimeHelper, "sh", "-c", dotNetRuntimeDetectionScript, "detect-runtime", path.Dir(coreClrProfilerPath)},
+			Resources: dotNetSpec.Resources,
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      volume.Name,
+				MountPath: dotnetInstrMountPath,
+			}},
+			ImagePullPolicy: container.ImagePullPolicy,
+			SecurityContext: container.SecurityContext,
 		})
 	}
 	return pod, nil
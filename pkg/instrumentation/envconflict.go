@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
+)
+
+// envListVariables are the variables whose values are comma separated lists, merged by EnvConflictPolicyAppend.
+var envListVariables = []string{
+	constants.EnvOTELPropagators,
+	"OTEL_TRACES_EXPORTER",
+	"OTEL_METRICS_EXPORTER",
+	"OTEL_LOGS_EXPORTER",
+}
+
+// envConflictExemptions are the OTEL_ variables of the containers never set aside: the ones telling that an
+// instrumentation is already configured, and the ones the operator sets from the downward API.
+var envConflictExemptions = []string{
+	envDotNetOTelAutoHome,
+	constants.EnvPodIP,
+	constants.EnvNodeIP,
+}
+
+// envConflictPolicies returns the policy of the Instrumentation injected into each container. The containers
+// instrumented by Go are left out, their SDK is configured in the agent sidecar.
+func (langInsts *languageInstrumentations) envConflictPolicies(pod corev1.Pod) map[string]v1alpha1.EnvConflictPolicy {
+	policies := map[string]v1alpha1.EnvConflictPolicy{}
+	for _, inst := range langInsts.all() {
+		if inst.Instrumentation == nil || inst == &langInsts.Go {
+			continue
+		}
+		containers := inst.Containers
+		if len(containers) == 0 && len(pod.Spec.Containers) > 0 {
+			containers = []string{pod.Spec.Containers[0].Name}
+		}
+		for _, container := range containers {
+			policies[container] = inst.Instrumentation.Spec.EnvConflictPolicy
+		}
+	}
+	return policies
+}
+
+// setAsideConflictingEnv removes the OTEL_ variables of the containers whose policy resolves the conflicts, for the
+// injection to set its own values, and returns them by container.
+func setAsideConflictingEnv(policies map[string]v1alpha1.EnvConflictPolicy, pod corev1.Pod) (corev1.Pod, map[string][]corev1.EnvVar) {
+	setAside := map[string][]corev1.EnvVar{}
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if policy := policies[container.Name]; policy == "" || policy == v1alpha1.EnvConflictPolicySkip {
+			continue
+		}
+		var kept []corev1.EnvVar
+		for _, env := range container.Env {
+			if strings.HasPrefix(env.Name, "OTEL_") && !strings.HasPrefix(env.Name, "OTEL_RESOURCE_ATTRIBUTES_") && !slices.Contains(envConflictExemptions, env.Name) {
+				setAside[container.Name] = append(setAside[container.Name], env)
+			} else {
+				kept = append(kept, env)
+			}
+		}
+		container.Env = kept
+	}
+	return pod, setAside
+}
+
+// resolveEnvConflicts puts back the variables set aside from the containers, resolving the conflicts with the
+// variables set by the injection with the policy of each container.
+func resolveEnvConflicts(policies map[string]v1alpha1.EnvConflictPolicy, setAside map[string][]corev1.EnvVar, pod corev1.Pod) corev1.Pod {
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		envs, ok := setAside[container.Name]
+		if !ok {
+			continue
+		}
+		policy := policies[container.Name]
+		for _, env := range envs {
+			idx := getIndexOfEnv(container.Env, env.Name)
+			if idx == -1 {
+				container.Env = append(container.Env, env)
+				continue
+			}
+			container.Env[idx] = resolveEnvConflict(policy, env, container.Env[idx])
+		}
+		container.Env = moveEnvToListEnd(container.Env, getIndexOfEnv(container.Env, constants.EnvOTELResourceAttrs))
+	}
+	return pod
+}
+
+// resolveEnvConflict returns the variable resolving the conflict between the variable of the container and the one
+// set by the injection. The values set from a source can't be merged, the one of the policy is kept as is.
+func resolveEnvConflict(policy v1alpha1.EnvConflictPolicy, containerEnv, injectedEnv corev1.EnvVar) corev1.EnvVar {
+	override := policy == v1alpha1.EnvConflictPolicyOverride
+	if containerEnv.ValueFrom != nil || injectedEnv.ValueFrom != nil {
+		if override {
+			return injectedEnv
+		}
+		return containerEnv
+	}
+
+	switch {
+	case containerEnv.Name == constants.EnvOTELResourceAttrs && override:
+		return corev1.EnvVar{Name: containerEnv.Name, Value: mergeResourceAttributes(injectedEnv.Value, containerEnv.Value)}
+	case containerEnv.Name == constants.EnvOTELResourceAttrs:
+		return corev1.EnvVar{Name: containerEnv.Name, Value: mergeResourceAttributes(containerEnv.Value, injectedEnv.Value)}
+	case override:
+		return injectedEnv
+	case slices.Contains(envListVariables, containerEnv.Name):
+		return corev1.EnvVar{Name: containerEnv.Name, Value: mergeList(containerEnv.Value, injectedEnv.Value)}
+	default:
+		return containerEnv
+	}
+}
+
+// mergeResourceAttributes merges two OTEL_RESOURCE_ATTRIBUTES values, the attributes of precedence replacing the ones
+// of others with the same keys. The remaining attributes of others come first.
+func mergeResourceAttributes(precedence, others string) string {
+	keys := map[string]bool{}
+	for _, attribute := range splitList(precedence) {
+		key, _, _ := strings.Cut(attribute, "=")
+		keys[strings.TrimSpace(key)] = true
+	}
+	var merged []string
+	for _, attribute := range splitList(others) {
+		key, _, _ := strings.Cut(attribute, "=")
+		if !keys[strings.TrimSpace(key)] {
+			merged = append(merged, attribute)
+		}
+	}
+	return strings.Join(append(merged, splitList(precedence)...), ",")
+}
+
+// mergeList appends to a comma separated list the values of another it doesn't contain.
+func mergeList(list, other string) string {
+	merged := splitList(list)
+	for _, value := range splitList(other) {
+		if !slices.Contains(merged, value) {
+			merged = append(merged, value)
+		}
+	}
+	return strings.Join(merged, ",")
+}
+
+// splitList returns the trimmed, non-empty values of a comma separated list.
+func splitList(list string) []string {
+	var values []string
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
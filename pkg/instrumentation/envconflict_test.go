@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestEnvConflictPolicies(t *testing.T) {
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}, {Name: "worker"}}}}
+	insts := languageInstrumentations{
+		Java: instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{Spec: v1alpha1.InstrumentationSpec{EnvConflictPolicy: v1alpha1.EnvConflictPolicyOverride}}},
+		Go:   instrumentationWithContainers{Instrumentation: &v1alpha1.Instrumentation{Spec: v1alpha1.InstrumentationSpec{EnvConflictPolicy: v1alpha1.EnvConflictPolicyAppend}}, Containers: []string{"worker"}},
+	}
+
+	assert.Equal(t, map[string]v1alpha1.EnvConflictPolicy{"app": v1alpha1.EnvConflictPolicyOverride}, insts.envConflictPolicies(pod))
+}
+
+func TestResolveEnvConflicts(t *testing.T) {
+	containerEnv := []corev1.EnvVar{
+		{Name: "OTEL_SERVICE_NAME", Value: "my-service"},
+		{Name: "OTEL_PROPAGATORS", Value: "b3"},
+		{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "team=payments,service.version=1.0"},
+		{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "api-key=secret"},
+		{Name: "OTEL_DOTNET_AUTO_HOME", Value: "/otel"},
+		{Name: "JAVA_TOOL_OPTIONS", Value: "-Xmx1g"},
+	}
+	// the variables set by the injection once the conflicting ones are set aside
+	injected := []corev1.EnvVar{
+		{Name: "OTEL_DOTNET_AUTO_HOME", Value: "/otel"},
+		{Name: "JAVA_TOOL_OPTIONS", Value: "-Xmx1g -javaagent:/otel-auto-instrumentation-java/javaagent.jar"},
+		{Name: "OTEL_SERVICE_NAME", Value: "my-deployment"},
+		{Name: "OTEL_PROPAGATORS", Value: "tracecontext,baggage,b3"},
+		{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "k8s.namespace.name=default,service.version=2.0"},
+	}
+
+	tests := []struct {
+		name     string
+		policy   v1alpha1.EnvConflictPolicy
+		expected []corev1.EnvVar
+	}{
+		{
+			name:   "override",
+			policy: v1alpha1.EnvConflictPolicyOverride,
+			expected: []corev1.EnvVar{
+				{Name: "OTEL_DOTNET_AUTO_HOME", Value: "/otel"},
+				{Name: "JAVA_TOOL_OPTIONS", Value: "-Xmx1g -javaagent:/otel-auto-instrumentation-java/javaagent.jar"},
+				{Name: "OTEL_SERVICE_NAME", Value: "my-deployment"},
+				{Name: "OTEL_PROPAGATORS", Value: "tracecontext,baggage,b3"},
+				{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "api-key=secret"},
+				{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "team=payments,k8s.namespace.name=default,service.version=2.0"},
+			},
+		},
+		{
+			name:   "append",
+			policy: v1alpha1.EnvConflictPolicyAppend,
+			expected: []corev1.EnvVar{
+				{Name: "OTEL_DOTNET_AUTO_HOME", Value: "/otel"},
+				{Name: "JAVA_TOOL_OPTIONS", Value: "-Xmx1g -javaagent:/otel-auto-instrumentation-java/javaagent.jar"},
+				{Name: "OTEL_SERVICE_NAME", Value: "my-service"},
+				{Name: "OTEL_PROPAGATORS", Value: "b3,tracecontext,baggage"},
+				{Name: "OTEL_EXPORTER_OTLP_HEADERS", Value: "api-key=secret"},
+				{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "k8s.namespace.name=default,team=payments,service.version=1.0"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policies := map[string]v1alpha1.EnvConflictPolicy{"app": test.policy}
+			pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Env: append([]corev1.EnvVar{}, containerEnv...)}}}}
+
+			pod, setAside := setAsideConflictingEnv(policies, pod)
+			assert.Equal(t, []corev1.EnvVar{{Name: "OTEL_DOTNET_AUTO_HOME", Value: "/otel"}, {Name: "JAVA_TOOL_OPTIONS", Value: "-Xmx1g"}}, pod.Spec.Containers[0].Env)
+
+			pod.Spec.Containers[0].Env = append([]corev1.EnvVar{}, injected...)
+			pod = resolveEnvConflicts(policies, setAside, pod)
+			assert.Equal(t, test.expected, pod.Spec.Containers[0].Env)
+		})
+	}
+}
+
+func TestSetAsideConflictingEnvSkip(t *testing.T) {
+	env := []corev1.EnvVar{{Name: "OTEL_SERVICE_NAME", Value: "my-service"}}
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Env: env}, {Name: "sidecar", Env: env}}}}
+
+	pod, setAside := setAsideConflictingEnv(map[string]v1alpha1.EnvConflictPolicy{"app": v1alpha1.EnvConflictPolicySkip}, pod)
+	assert.Empty(t, setAside)
+	assert.Equal(t, env, pod.Spec.Containers[0].Env)
+	assert.Equal(t, env, pod.Spec.Containers[1].Env)
+}
+
+func TestResolveEnvConflictValueFrom(t *testing.T) {
+	containerEnv := corev1.EnvVar{Name: "OTEL_RESOURCE_ATTRIBUTES", ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{Key: "attributes"}}}
+	injectedEnv := corev1.EnvVar{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "k8s.namespace.name=default"}
+
+	assert.Equal(t, injectedEnv, resolveEnvConflict(v1alpha1.EnvConflictPolicyOverride, containerEnv, injectedEnv))
+	assert.Equal(t, containerEnv, resolveEnvConflict(v1alpha1.EnvConflictPolicyAppend, containerEnv, injectedEnv))
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+// isExcluded returns whether the operator configuration excludes the pod from the auto-instrumentation, by the
+// labels of its namespace or its own labels, whatever the annotations of the pod.
+func isExcluded(cfg config.Config, ns corev1.Namespace, pod corev1.Pod) bool {
+	if cfg.InstrumentationExcludedNamespaces != nil && cfg.InstrumentationExcludedNamespaces.Matches(labels.Set(ns.Labels)) {
+		return true
+	}
+	return cfg.InstrumentationExcludedPods != nil && cfg.InstrumentationExcludedPods.Matches(labels.Set(pod.Labels))
+}
+
+// excludeContainers removes the containers excluded by the operator configuration from the containers of each
+// language, defaulting to the first container of the pod as the injection does, and drops the languages left without
+// container. It returns the excluded containers.
+func (langInsts *languageInstrumentations) excludeContainers(cfg config.Config, pod corev1.Pod) []string {
+	if cfg.InstrumentationExcludedContainers == nil || len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+	var excluded []string
+	for _, inst := range langInsts.all() {
+		if inst.Instrumentation == nil {
+			continue
+		}
+		containers := inst.Containers
+		if len(containers) == 0 {
+			containers = []string{pod.Spec.Containers[0].Name}
+		}
+		var kept []string
+		for _, container := range containers {
+			if !cfg.InstrumentationExcludedContainers.MatchString(container) {
+				kept = append(kept, container)
+			} else if !slices.Contains(excluded, container) {
+				excluded = append(excluded, container)
+			}
+		}
+		if len(kept) == 0 {
+			inst.Instrumentation = nil
+		}
+		inst.Containers = kept
+	}
+	return excluded
+}
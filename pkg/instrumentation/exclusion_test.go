@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestIsExcluded(t *testing.T) {
+	cfg := config.New(
+		config.WithInstrumentationExcludedNamespaces(labels.SelectorFromSet(labels.Set{"kubernetes.io/metadata.name": "kube-system"})),
+		config.WithInstrumentationExcludedPods(labels.SelectorFromSet(labels.Set{"app": "etcd"})),
+	)
+	systemNs := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system", Labels: map[string]string{"kubernetes.io/metadata.name": "kube-system"}}}
+	appsNs := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps", Labels: map[string]string{"kubernetes.io/metadata.name": "apps"}}}
+	etcd := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "etcd"}}}
+	app := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "checkout"}}}
+
+	assert.True(t, isExcluded(cfg, systemNs, app))
+	assert.True(t, isExcluded(cfg, appsNs, etcd))
+	assert.False(t, isExcluded(cfg, appsNs, app))
+	assert.False(t, isExcluded(config.New(), systemNs, etcd))
+}
+
+func TestExcludeContainers(t *testing.T) {
+	cfg := config.New(config.WithInstrumentationExcludedContainers(regexp.MustCompile("^(?:istio-proxy|vault-.*)$")))
+	inst := &v1alpha1.Instrumentation{}
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "istio-proxy"}, {Name: "app"}, {Name: "vault-agent"}}}}
+
+	insts := languageInstrumentations{
+		Java:   instrumentationWithContainers{Instrumentation: inst},
+		Python: instrumentationWithContainers{Instrumentation: inst, Containers: []string{"app", "vault-agent"}},
+		Sdk:    instrumentationWithContainers{Instrumentation: inst, Containers: []string{"vault-agent"}},
+	}
+	assert.Equal(t, []string{"istio-proxy", "vault-agent"}, insts.excludeContainers(cfg, pod))
+	assert.Nil(t, insts.Java.Instrumentation)
+	assert.Equal(t, []string{"app"}, insts.Python.Containers)
+	assert.Same(t, inst, insts.Python.Instrumentation)
+	assert.Nil(t, insts.Sdk.Instrumentation)
+
+	// without excluded containers, the containers are left as is
+	insts = languageInstrumentations{Java: instrumentationWithContainers{Instrumentation: inst}}
+	assert.Empty(t, insts.excludeContainers(config.New(), pod))
+	assert.Empty(t, insts.Java.Containers)
+	assert.Same(t, inst, insts.Java.Instrumentation)
+}
+
+func TestMutateExcludedNamespace(t *testing.T) {
+	cfg := config.New(config.WithInstrumentationExcludedNamespaces(labels.SelectorFromSet(labels.Set{"kubernetes.io/metadata.name": "kube-system"})))
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system", Labels: map[string]string{"kubernetes.io/metadata.name": "kube-system"}}}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system", Annotations: map[string]string{annotationInjectJava: "true"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "coredns"}}},
+	}
+
+	mutated, err := NewMutator(logr.Discard(), nil, record.NewFakeRecorder(10), config.NewProvider(cfg)).Mutate(context.Background(), ns, pod)
+	require.NoError(t, err)
+	assert.Equal(t, pod, mutated)
+}
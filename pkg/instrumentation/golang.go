@@ -20,6 +20,10 @@ const (
 	kernelDebugVolumePath = "/sys/kernel/debug"
 )
 
+// goAgentCapabilities are the capabilities the eBPF agent needs to attach to the process of the application when it
+// isn't privileged.
+var goAgentCapabilities = []corev1.Capability{"SYS_ADMIN", "SYS_PTRACE", "SYS_RESOURCE"}
+
 func injectGoSDK(goSpec v1alpha1.Go, pod corev1.Pod, cfg config.Config, instSpec v1alpha1.InstrumentationSpec) (corev1.Pod, error) {
 	// skip instrumentation if share process namespaces is explicitly disabled
 	if pod.Spec.ShareProcessNamespace != nil && !*pod.Spec.ShareProcessNamespace {
@@ -43,21 +47,30 @@ func injectGoSDK(goSpec v1alpha1.Go, pod corev1.Pod, cfg config.Config, instSpec
 	zero := int64(0)
 	pod.Spec.ShareProcessNamespace = &true
 
+	securityContext := &corev1.SecurityContext{
+		RunAsUser:  &zero,
+		Privileged: &true,
+	}
+	if goSpec.Privileged != nil && !*goSpec.Privileged {
+		securityContext.Privileged = nil
+		securityContext.Capabilities = &corev1.Capabilities{Add: goAgentCapabilities}
+	}
+
 	goAgent := corev1.Container{
-		Name:      sideCarName,
-		Image:     goSpec.Image,
-		Resources: goSpec.Resources,
-		SecurityContext: &corev1.SecurityContext{
-			RunAsUser:  &zero,
-			Privileged: &true,
-		},
+		Name:            sideCarName,
+		Image:           goSpec.Image,
+		Resources:       goSpec.Resources,
+		SecurityContext: securityContext,
 		VolumeMounts: []corev1.VolumeMount{
 			{
 				MountPath: "/sys/kernel/debug",
 				Name:      kernelDebugVolumeName,
 			},
 		},
-		ImagePullPolicy: instSpec.ImagePullPolicy,
+		ImagePullPolicy: initContainerPullPolicy(goSpec.ImagePullPolicy, instSpec.ImagePullPolicy),
+		// the agent logs why it can't instrument the process, e.g. a kernel without the eBPF features it needs,
+		// which is reported in the status of the Instrumentation
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 	}
 
 	// Annotation takes precedence for OTEL_GO_AUTO_TARGET_EXE
@@ -118,6 +118,7 @@ func TestInjectGoSDK(t *testing.T) {
 								RunAsUser:  &zero,
 								Privileged: &true,
 							},
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									MountPath: "/sys/kernel/debug",
@@ -179,6 +180,7 @@ func TestInjectGoSDK(t *testing.T) {
 								RunAsUser:  &zero,
 								Privileged: &true,
 							},
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									MountPath: "/sys/kernel/debug",
@@ -233,6 +235,7 @@ func TestInjectGoSDK(t *testing.T) {
 								RunAsUser:  &zero,
 								Privileged: &true,
 							},
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									MountPath: "/sys/kernel/debug",
@@ -264,6 +267,45 @@ func TestInjectGoSDK(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "unprivileged agent",
+			Go:   v1alpha1.Go{Image: "foo/bar:1", Privileged: &falsee},
+			pod:  corev1.Pod{},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					ShareProcessNamespace: &true,
+					Containers: []corev1.Container{
+						{
+							Name:  sideCarName,
+							Image: "foo/bar:1",
+							SecurityContext: &corev1.SecurityContext{
+								RunAsUser: &zero,
+								Capabilities: &corev1.Capabilities{
+									Add: []corev1.Capability{"SYS_ADMIN", "SYS_PTRACE", "SYS_RESOURCE"},
+								},
+							},
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									MountPath: "/sys/kernel/debug",
+									Name:      kernelDebugVolumeName,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: kernelDebugVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: kernelDebugVolumePath,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
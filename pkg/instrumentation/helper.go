@@ -39,6 +39,8 @@ func isAutoInstrumentationInjected(pod corev1.Pod) bool {
 			javaInitContainerName,
 			nodejsInitContainerName,
 			pythonInitContainerName,
+			phpInitContainerName,
+			rubyInitContainerName,
 			apacheAgentInitContainerName,
 			apacheAgentCloneContainerName,
 		}, cont.Name) {
@@ -13,6 +13,7 @@ import (
 
 const (
 	envJavaToolsOptions   = "JAVA_TOOL_OPTIONS"
+	envJavaConfigFile     = "OTEL_JAVAAGENT_CONFIGURATION_FILE"
 	javaAgent             = " -javaagent:/otel-auto-instrumentation-java/javaagent.jar"
 	javaInitContainerName = initContainerName + "-java"
 	javaVolumeName        = volumeName + "-java"
@@ -33,6 +34,13 @@ func injectJavaagent(javaSpec v1alpha1.Java, pod corev1.Pod, index int, instSpec
 	// inject Java instrumentation spec env vars.
 	container.Env = appendIfNotSet(container.Env, javaSpec.Env...)
 
+	if javaSpec.ConfigMapRef != nil {
+		container.Env = appendIfNotSet(container.Env, corev1.EnvVar{
+			Name:  envJavaConfigFile,
+			Value: injectConfigFile(&pod, container, javaSpec.ConfigMapRef, "java"),
+		})
+	}
+
 	// Create unique mount path for this container
 	containerMountPath := fmt.Sprintf("%s-%s", javaInstrMountPath, container.Name)
 
@@ -68,7 +76,7 @@ func injectJavaagent(javaSpec v1alpha1.Java, pod corev1.Pod, index int, instSpec
 				Name:      volume.Name,
 				MountPath: javaInstrMountPath,
 			}},
-			ImagePullPolicy: instSpec.ImagePullPolicy,
+			ImagePullPolicy: initContainerPullPolicy(javaSpec.ImagePullPolicy, instSpec.ImagePullPolicy),
 		})
 
 		for i, extension := range javaSpec.Extensions {
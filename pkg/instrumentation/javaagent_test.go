@@ -77,6 +77,83 @@ func TestInjectJavaagent(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "configuration file",
+			Java: v1alpha1.Java{Image: "foo/bar:1", ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "javaagent-config"},
+				Key:                  "otel.properties",
+			}},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "test-container",
+						},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "opentelemetry-auto-instrumentation-java-config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "javaagent-config"},
+									Items:                []corev1.KeyToPath{{Key: "otel.properties", Path: "otel.properties"}},
+								},
+							},
+						},
+						{
+							Name: "opentelemetry-auto-instrumentation-java",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{
+									SizeLimit: &defaultVolumeLimitSize,
+								},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    "opentelemetry-auto-instrumentation-java",
+							Image:   "foo/bar:1",
+							Command: []string{"cp", "/javaagent.jar", "/otel-auto-instrumentation-java/javaagent.jar"},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      "opentelemetry-auto-instrumentation-java",
+								MountPath: "/otel-auto-instrumentation-java",
+							}},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name: "test-container",
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "opentelemetry-auto-instrumentation-java-config",
+									MountPath: "/otel-auto-instrumentation-config/java",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "opentelemetry-auto-instrumentation-java",
+									MountPath: "/otel-auto-instrumentation-java-test-container",
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "OTEL_JAVAAGENT_CONFIGURATION_FILE",
+									Value: "/otel-auto-instrumentation-config/java/otel.properties",
+								},
+								{
+									Name:  "JAVA_TOOL_OPTIONS",
+									Value: " -javaagent:/otel-auto-instrumentation-java-test-container/javaagent.jar",
+								},
+							},
+						},
+					},
+				},
+			},
+			err: nil,
+		},
 		{
 			name: "add extensions to JAVA_TOOL_OPTIONS",
 			Java: v1alpha1.Java{Image: "foo/bar:1", Extensions: []v1alpha1.Extensions{
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// The results of the injection of a language into a pod.
+const (
+	injectionResultInjected = "injected"
+	injectionResultSkipped  = "skipped"
+	injectionResultFailed   = "failed"
+)
+
+var (
+	injectionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "opentelemetry_operator_instrumentation_injections_total",
+		Help: "Number of pods the auto-instrumentation of a language was requested for, by language and result.",
+	}, []string{"language", "result"})
+	injectionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "opentelemetry_operator_instrumentation_injection_duration_seconds",
+		Help:    "Duration of the auto-instrumentation injection of the pods, by result.",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	}, []string{"result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(injectionsTotal, injectionDuration)
+}
+
+// requestedLanguages returns the languages the pod or the namespace asks to inject, whether or not they can be.
+func requestedLanguages(ns metav1.ObjectMeta, pod metav1.ObjectMeta) []string {
+	requested := map[string]bool{}
+	for language, annotations := range languageAnnotations {
+		value := annotationValue(ns, pod, annotations.inject)
+		if value != "" && !strings.EqualFold(value, "false") {
+			requested[language] = true
+		}
+	}
+	// the invalid entries are reported by the injection itself
+	for _, entry := range strings.Split(annotationValue(ns, pod, annotationContainerLanguages), ",") {
+		if _, language, ok := strings.Cut(entry, "="); ok {
+			if _, found := languageAnnotations[strings.TrimSpace(language)]; found {
+				requested[strings.TrimSpace(language)] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(requested))
+}
+
+// recordInjection records the result of the injection of the requested languages into the pod, the languages which
+// weren't injected without error are skipped.
+func recordInjection(requested []string, pod corev1.Pod, err error, duration time.Duration) {
+	injected := InjectedInstrumentations(pod)
+	result := injectionResultSkipped
+	if err != nil {
+		result = injectionResultFailed
+	} else if len(injected) > 0 {
+		result = injectionResultInjected
+	}
+	injectionDuration.WithLabelValues(result).Observe(duration.Seconds())
+
+	for _, language := range requested {
+		languageResult := injectionResultSkipped
+		if err != nil {
+			languageResult = injectionResultFailed
+		} else if _, ok := injected[language]; ok {
+			languageResult = injectionResultInjected
+		}
+		injectionsTotal.WithLabelValues(language, languageResult).Inc()
+	}
+}
+
+// eventObject returns the object the injection events of the pod are recorded on: the workload controlling the pod,
+// since the pod doesn't exist yet when it's mutated, or the pod itself when it isn't controlled.
+func eventObject(pod corev1.Pod) runtime.Object {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return pod.DeepCopy()
+	}
+	return &corev1.ObjectReference{
+		APIVersion: owner.APIVersion,
+		Kind:       owner.Kind,
+		Name:       owner.Name,
+		Namespace:  pod.Namespace,
+		UID:        owner.UID,
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+)
+
+func TestRequestedLanguages(t *testing.T) {
+	ns := metav1.ObjectMeta{Annotations: map[string]string{annotationInjectPython: "true", annotationInjectGo: "true"}}
+	pod := metav1.ObjectMeta{Annotations: map[string]string{
+		annotationInjectJava:         "my-inst",
+		annotationInjectGo:           "false",
+		annotationContainerLanguages: "app=java, worker=nodejs,invalid,other=cobol",
+	}}
+
+	assert.Equal(t, []string{"java", "nodejs", "python"}, requestedLanguages(ns, pod))
+	assert.Empty(t, requestedLanguages(metav1.ObjectMeta{}, metav1.ObjectMeta{}))
+}
+
+func TestRecordInjection(t *testing.T) {
+	injectionsTotal.Reset()
+	injectionDuration.Reset()
+
+	injected := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{annotationInjected: "java=ns/inst"}}}
+	recordInjection([]string{"java", "python"}, injected, nil, time.Millisecond)
+	recordInjection([]string{"java"}, corev1.Pod{}, errors.New("no OpenTelemetry Instrumentation instances available"), time.Millisecond)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(injectionsTotal.WithLabelValues("java", injectionResultInjected)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(injectionsTotal.WithLabelValues("python", injectionResultSkipped)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(injectionsTotal.WithLabelValues("java", injectionResultFailed)))
+	assert.Equal(t, 2, testutil.CollectAndCount(injectionDuration))
+}
+
+func TestEventObject(t *testing.T) {
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		GenerateName: "my-app-",
+		Namespace:    "my-ns",
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "apps/v1",
+			Kind:       "ReplicaSet",
+			Name:       "my-app-5d8f",
+			UID:        "1234",
+			Controller: ptr.To(true),
+		}},
+	}}
+	assert.Equal(t, &corev1.ObjectReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "my-app-5d8f", Namespace: "my-ns", UID: "1234"}, eventObject(pod))
+
+	pod.OwnerReferences = nil
+	assert.Equal(t, &pod, eventObject(pod))
+}
+
+func TestMutateMissingInstrumentation(t *testing.T) {
+	injectionsTotal.Reset()
+	ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "missing-instrumentation"}}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "missing-instrumentation", Annotations: map[string]string{annotationInjectJava: "true"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+	}
+	recorder := record.NewFakeRecorder(10)
+
+	_, err := NewMutator(logr.Discard(), k8sClient, recorder, config.NewProvider(config.New())).Mutate(context.Background(), ns, pod)
+	require.Error(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(injectionsTotal.WithLabelValues("java", injectionResultFailed)))
+	require.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.True(t, strings.HasPrefix(event, "Warning InstrumentationInjectionFailed"), event)
+	assert.Contains(t, event, errNoInstancesAvailable.Error())
+}
@@ -227,8 +227,8 @@ mv ${NGINX_AGENT_CONF_DIR_FULL}/opentelemetry_agent.conf  ${NGINX_AGENT_CONF_DIR
 					MountPath: nginxAgentConfDirFull,
 				},
 			},
-			SecurityContext: pod.Spec.Containers[index].SecurityContext,
-			ImagePullPolicy: instSpec.ImagePullPolicy,
+			SecurityContext: initContainerSecurityContext(nginxSpec.SecurityContext, pod.Spec.Containers[index].SecurityContext),
+			ImagePullPolicy: initContainerPullPolicy(nginxSpec.ImagePullPolicy, instSpec.ImagePullPolicy),
 		})
 
 		found := false
@@ -31,14 +31,20 @@ func injectNodeJSSDK(nodeJSSpec v1alpha1.NodeJS, pod corev1.Pod, index int, inst
 	// inject NodeJS instrumentation spec env vars.
 	container.Env = appendIfNotSet(container.Env, nodeJSSpec.Env...)
 
+	// the configuration file is required first, so that it applies to the auto-instrumentation
+	nodeArguments := nodeRequireArgument
+	if nodeJSSpec.ConfigMapRef != nil {
+		nodeArguments = " --require " + injectConfigFile(&pod, container, nodeJSSpec.ConfigMapRef, "nodejs") + nodeRequireArgument
+	}
+
 	idx := getIndexOfEnv(container.Env, envNodeOptions)
 	if idx == -1 {
 		container.Env = append(container.Env, corev1.EnvVar{
 			Name:  envNodeOptions,
-			Value: nodeRequireArgument,
+			Value: nodeArguments,
 		})
 	} else if idx > -1 {
-		container.Env[idx].Value = container.Env[idx].Value + nodeRequireArgument
+		container.Env[idx].Value = container.Env[idx].Value + nodeArguments
 	}
 
 	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
@@ -58,7 +64,7 @@ func injectNodeJSSDK(nodeJSSpec v1alpha1.NodeJS, pod corev1.Pod, index int, inst
 				Name:      volume.Name,
 				MountPath: nodejsInstrMountPath,
 			}},
-			ImagePullPolicy: instSpec.ImagePullPolicy,
+			ImagePullPolicy: initContainerPullPolicy(nodeJSSpec.ImagePullPolicy, instSpec.ImagePullPolicy),
 		})
 	}
 	return pod, nil
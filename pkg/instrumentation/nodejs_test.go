@@ -74,6 +74,76 @@ func TestInjectNodeJSSDK(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			name: "configuration file",
+			NodeJS: v1alpha1.NodeJS{Image: "foo/bar:1", ConfigMapRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "nodejs-config"},
+				Key:                  "otel-config.js",
+			}},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "opentelemetry-auto-instrumentation-nodejs-config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "nodejs-config"},
+									Items:                []corev1.KeyToPath{{Key: "otel-config.js", Path: "otel-config.js"}},
+								},
+							},
+						},
+						{
+							Name: "opentelemetry-auto-instrumentation-nodejs",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{
+									SizeLimit: &defaultVolumeLimitSize,
+								},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    "opentelemetry-auto-instrumentation-nodejs",
+							Image:   "foo/bar:1",
+							Command: []string{"cp", "-r", "/autoinstrumentation/.", "/otel-auto-instrumentation-nodejs"},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      "opentelemetry-auto-instrumentation-nodejs",
+								MountPath: "/otel-auto-instrumentation-nodejs",
+							}},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "opentelemetry-auto-instrumentation-nodejs-config",
+									MountPath: "/otel-auto-instrumentation-config/nodejs",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "opentelemetry-auto-instrumentation-nodejs",
+									MountPath: "/otel-auto-instrumentation-nodejs",
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "NODE_OPTIONS",
+									Value: " --require /otel-auto-instrumentation-config/nodejs/otel-config.js --require /otel-auto-instrumentation-nodejs/autoinstrumentation.js",
+								},
+							},
+						},
+					},
+				},
+			},
+			err: nil,
+		},
 		{
 			name:   "NODE_OPTIONS defined",
 			NodeJS: v1alpha1.NodeJS{Image: "foo/bar:1", Resources: testResourceRequirements},
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+const (
+	envPHPIniScanDir          = "PHP_INI_SCAN_DIR"
+	envOtelPHPAutoloadEnabled = "OTEL_PHP_AUTOLOAD_ENABLED"
+	phpInstrMountPath         = "/otel-auto-instrumentation-php"
+	phpIniScanDir             = phpInstrMountPath + "/php_ini_scan_dir"
+	phpVolumeName             = volumeName + "-php"
+	phpInitContainerName      = initContainerName + "-php"
+	phpIniGeneratorName       = initContainerName + "-php-ini"
+	phpAutoInstrumentationSrc = "/autoinstrumentation/."
+	// phpIniGeneratorScript runs with the php binary of the application image, which may have no shell
+	phpIniGeneratorScript = `$version = PHP_MAJOR_VERSION . "." . PHP_MINOR_VERSION;
+$libc = glob("/lib/ld-musl-*") ? "musl" : "glibc";
+$extension = "` + phpInstrMountPath + `/native_binaries/PHP_{$version}_{$libc}/opentelemetry.so";
+$packages = "` + phpInstrMountPath + `/PHP_packages/PHP_{$version}";
+if (!is_file($extension) || !is_dir($packages)) {
+    echo "PHP {$version} ({$libc}) isn't supported by the auto-instrumentation image, skipping the PHP auto-instrumentation\n";
+    exit(0);
+}
+if (!is_dir("` + phpIniScanDir + `") && !mkdir("` + phpIniScanDir + `", 0755, true)) {
+    exit(1);
+}
+if (file_put_contents("` + phpIniScanDir + `/opentelemetry.ini", "extension={$extension}\nauto_prepend_file={$packages}/autoload.php\n") === false) {
+    exit(1);
+}
+`
+)
+
+// injectPHPSDK injects the PHP extension and auto-instrumentation packages into the container at the given index.
+// The php binary of the image of the container generates the ini file loading the extension and the packages matching
+// its PHP version and libc, which PHP_INI_SCAN_DIR adds to the configuration of PHP.
+func injectPHPSDK(phpSpec v1alpha1.PHP, pod corev1.Pod, index int, instSpec v1alpha1.InstrumentationSpec) (corev1.Pod, error) {
+	volume := instrVolume(phpSpec.VolumeClaimTemplate, phpVolumeName, phpSpec.VolumeSizeLimit)
+
+	// caller checks if there is at least one container.
+	container := &pod.Spec.Containers[index]
+
+	err := validateContainerEnv(container.Env, envPHPIniScanDir)
+	if err != nil {
+		return pod, err
+	}
+
+	// inject PHP instrumentation spec env vars.
+	container.Env = appendIfNotSet(container.Env, phpSpec.Env...)
+
+	idx := getIndexOfEnv(container.Env, envPHPIniScanDir)
+	if idx == -1 {
+		// the leading empty entry keeps the default scan directory of PHP
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  envPHPIniScanDir,
+			Value: ":" + phpIniScanDir,
+		})
+	} else {
+		container.Env[idx].Value = fmt.Sprintf("%s:%s", container.Env[idx].Value, phpIniScanDir)
+	}
+
+	container.Env = appendIfNotSet(container.Env,
+		corev1.EnvVar{
+			Name:  envOtelPHPAutoloadEnabled,
+			Value: "true",
+		},
+		// Set OTEL_EXPORTER_OTLP_PROTOCOL to http/protobuf if not set by user because it is what our autoinstrumentation supports.
+		corev1.EnvVar{
+			Name:  envOtelExporterOTLPProtocol,
+			Value: "http/protobuf",
+		},
+	)
+
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      volume.Name,
+		MountPath: phpInstrMountPath,
+	})
+
+	// We just inject Volumes and init containers for the first processed container.
+	if isInitContainerMissing(pod, phpInitContainerName) {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, corev1.Container{
+			Name:      phpInitContainerName,
+			Image:     phpSpec.Image,
+			Command:   []string{"cp", "-r", phpAutoInstrumentationSrc, phpInstrMountPath},
+			Resources: phpSpec.Resources,
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      volume.Name,
+				MountPath: phpInstrMountPath,
+			}},
+			ImagePullPolicy: initContainerPullPolicy(phpSpec.ImagePullPolicy, instSpec.ImagePullPolicy),
+		}, corev1.Container{
+			Name:      phpIniGeneratorName,
+			Image:     container.Image,
+			Command:   []string{"php", "-r", phpIniGeneratorScript},
+			Resources: phpSpec.Resources,
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      volume.Name,
+				MountPath: phpInstrMountPath,
+			}},
+			ImagePullPolicy: container.ImagePullPolicy,
+		})
+	}
+	return pod, nil
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestInjectPHPSDK(t *testing.T) {
+	tests := []struct {
+		name string
+		v1alpha1.PHP
+		pod      corev1.Pod
+		expected corev1.Pod
+		err      error
+	}{
+		{
+			name: "PHP_INI_SCAN_DIR not defined",
+			PHP:  v1alpha1.PHP{Image: "foo/bar:1"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Image:           "php:8.3-apache",
+							ImagePullPolicy: corev1.PullIfNotPresent,
+						},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: phpVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{
+									SizeLimit: &defaultVolumeLimitSize,
+								},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    "opentelemetry-auto-instrumentation-php",
+							Image:   "foo/bar:1",
+							Command: []string{"cp", "-r", "/autoinstrumentation/.", "/otel-auto-instrumentation-php"},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      "opentelemetry-auto-instrumentation-php",
+								MountPath: "/otel-auto-instrumentation-php",
+							}},
+						},
+						{
+							Name:    "opentelemetry-auto-instrumentation-php-ini",
+							Image:   "php:8.3-apache",
+							Command: []string{"php", "-r", phpIniGeneratorScript},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      "opentelemetry-auto-instrumentation-php",
+								MountPath: "/otel-auto-instrumentation-php",
+							}},
+							ImagePullPolicy: corev1.PullIfNotPresent,
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Image:           "php:8.3-apache",
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "opentelemetry-auto-instrumentation-php",
+									MountPath: "/otel-auto-instrumentation-php",
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "PHP_INI_SCAN_DIR",
+									Value: ":/otel-auto-instrumentation-php/php_ini_scan_dir",
+								},
+								{
+									Name:  "OTEL_PHP_AUTOLOAD_ENABLED",
+									Value: "true",
+								},
+								{
+									Name:  "OTEL_EXPORTER_OTLP_PROTOCOL",
+									Value: "http/protobuf",
+								},
+							},
+						},
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "PHP_INI_SCAN_DIR defined",
+			PHP:  v1alpha1.PHP{Image: "foo/bar:1", Resources: testResourceRequirements},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Image: "php:8.3-fpm-alpine",
+							Env: []corev1.EnvVar{
+								{
+									Name:  "PHP_INI_SCAN_DIR",
+									Value: "/usr/local/etc/php/conf.d",
+								},
+								{
+									Name:  "OTEL_EXPORTER_OTLP_PROTOCOL",
+									Value: "grpc",
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{
+						{
+							Name: "opentelemetry-auto-instrumentation-php",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{
+									SizeLimit: &defaultVolumeLimitSize,
+								},
+							},
+						},
+					},
+					InitContainers: []corev1.Container{
+						{
+							Name:    "opentelemetry-auto-instrumentation-php",
+							Image:   "foo/bar:1",
+							Command: []string{"cp", "-r", "/autoinstrumentation/.", "/otel-auto-instrumentation-php"},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      "opentelemetry-auto-instrumentation-php",
+								MountPath: "/otel-auto-instrumentation-php",
+							}},
+							Resources: testResourceRequirements,
+						},
+						{
+							Name:    "opentelemetry-auto-instrumentation-php-ini",
+							Image:   "php:8.3-fpm-alpine",
+							Command: []string{"php", "-r", phpIniGeneratorScript},
+							VolumeMounts: []corev1.VolumeMount{{
+								Name:      "opentelemetry-auto-instrumentation-php",
+								MountPath: "/otel-auto-instrumentation-php",
+							}},
+							Resources: testResourceRequirements,
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Image: "php:8.3-fpm-alpine",
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "opentelemetry-auto-instrumentation-php",
+									MountPath: "/otel-auto-instrumentation-php",
+								},
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "PHP_INI_SCAN_DIR",
+									Value: "/usr/local/etc/php/conf.d:/otel-auto-instrumentation-php/php_ini_scan_dir",
+								},
+								{
+									Name:  "OTEL_EXPORTER_OTLP_PROTOCOL",
+									Value: "grpc",
+								},
+								{
+									Name:  "OTEL_PHP_AUTOLOAD_ENABLED",
+									Value: "true",
+								},
+							},
+						},
+					},
+				},
+			},
+			err: nil,
+		},
+		{
+			name: "PHP_INI_SCAN_DIR defined as ValueFrom",
+			PHP:  v1alpha1.PHP{Image: "foo/bar:1"},
+			pod: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Env: []corev1.EnvVar{
+								{
+									Name:      "PHP_INI_SCAN_DIR",
+									ValueFrom: &corev1.EnvVarSource{},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: corev1.Pod{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Env: []corev1.EnvVar{
+								{
+									Name:      "PHP_INI_SCAN_DIR",
+									ValueFrom: &corev1.EnvVarSource{},
+								},
+							},
+						},
+					},
+				},
+			},
+			err: fmt.Errorf("the container defines env var value via ValueFrom, envVar: %s", envPHPIniScanDir),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod, err := injectPHPSDK(test.PHP, test.pod, 0, v1alpha1.InstrumentationSpec{})
+			assert.Equal(t, test.expected, pod)
+			assert.Equal(t, test.err, err)
+		})
+	}
+}
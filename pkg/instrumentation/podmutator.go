@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -18,7 +19,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	autoIstio "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/istio"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/istio"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
 )
 
@@ -32,7 +35,7 @@ type instPodMutator struct {
 	sdkInjector *sdkInjector
 	Logger      logr.Logger
 	Recorder    record.EventRecorder
-	config      config.Config
+	config      *config.Provider
 }
 
 type instrumentationWithContainers struct {
@@ -49,9 +52,19 @@ type languageInstrumentations struct {
 	ApacheHttpd instrumentationWithContainers
 	Nginx       instrumentationWithContainers
 	Go          instrumentationWithContainers
+	PHP         instrumentationWithContainers
+	Ruby        instrumentationWithContainers
 	Sdk         instrumentationWithContainers
 }
 
+// all returns the instrumentations of every language.
+func (langInsts *languageInstrumentations) all() []*instrumentationWithContainers {
+	return []*instrumentationWithContainers{
+		&langInsts.Java, &langInsts.NodeJS, &langInsts.Python, &langInsts.DotNet, &langInsts.ApacheHttpd,
+		&langInsts.Nginx, &langInsts.Go, &langInsts.PHP, &langInsts.Ruby, &langInsts.Sdk,
+	}
+}
+
 // Check if specific containers are provided for configured instrumentation.
 func (langInsts languageInstrumentations) areInstrumentedContainersCorrect() (bool, error) {
 	var instrWithoutContainers int
@@ -116,6 +129,22 @@ func (langInsts languageInstrumentations) areInstrumentedContainersCorrect() (bo
 			instrumentationWithNoContainers = true
 		}
 	}
+	if langInsts.PHP.Instrumentation != nil {
+		instrWithContainers += isInstrWithContainers(langInsts.PHP)
+		instrWithoutContainers += isInstrWithoutContainers(langInsts.PHP)
+		allContainers = append(allContainers, langInsts.PHP.Containers...)
+		if len(langInsts.PHP.Containers) == 0 {
+			instrumentationWithNoContainers = true
+		}
+	}
+	if langInsts.Ruby.Instrumentation != nil {
+		instrWithContainers += isInstrWithContainers(langInsts.Ruby)
+		instrWithoutContainers += isInstrWithoutContainers(langInsts.Ruby)
+		allContainers = append(allContainers, langInsts.Ruby.Containers...)
+		if len(langInsts.Ruby.Containers) == 0 {
+			instrumentationWithNoContainers = true
+		}
+	}
 	if langInsts.Sdk.Instrumentation != nil {
 		instrWithContainers += isInstrWithContainers(langInsts.Sdk)
 		instrWithoutContainers += isInstrWithoutContainers(langInsts.Sdk)
@@ -189,6 +218,12 @@ func (langInsts *languageInstrumentations) setCommonInstrumentedContainers(ns co
 	if langInsts.Go.Instrumentation != nil {
 		langInsts.Go.Containers = containers
 	}
+	if langInsts.PHP.Instrumentation != nil {
+		langInsts.PHP.Containers = containers
+	}
+	if langInsts.Ruby.Instrumentation != nil {
+		langInsts.Ruby.Containers = containers
+	}
 	if langInsts.Sdk.Instrumentation != nil {
 		langInsts.Sdk.Containers = containers
 	}
@@ -228,6 +263,14 @@ func (langInsts *languageInstrumentations) setLanguageSpecificContainers(ns meta
 			iwc:        &langInsts.Nginx,
 			annotation: annotationInjectNginxContainersName,
 		},
+		{
+			iwc:        &langInsts.PHP,
+			annotation: annotationInjectPHPContainersName,
+		},
+		{
+			iwc:        &langInsts.Ruby,
+			annotation: annotationInjectRubyContainersName,
+		},
 		{
 			iwc:        &langInsts.Sdk,
 			annotation: annotationInjectSdkContainersName,
@@ -245,7 +288,7 @@ func (langInsts *languageInstrumentations) setLanguageSpecificContainers(ns meta
 
 var _ podmutation.PodMutator = (*instPodMutator)(nil)
 
-func NewMutator(logger logr.Logger, client client.Client, recorder record.EventRecorder, cfg config.Config) *instPodMutator {
+func NewMutator(logger logr.Logger, client client.Client, recorder record.EventRecorder, cfg *config.Provider) *instPodMutator {
 	return &instPodMutator{
 		Logger: logger,
 		Client: client,
@@ -259,6 +302,21 @@ func NewMutator(logger logr.Logger, client client.Client, recorder record.EventR
 }
 
 func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
+	requested := requestedLanguages(ns.ObjectMeta, pod.ObjectMeta)
+	if len(requested) == 0 || isAutoInstrumentationInjected(pod) {
+		return pm.mutate(ctx, ns, pod)
+	}
+
+	start := time.Now()
+	modifiedPod, err := pm.mutate(ctx, ns, pod)
+	recordInjection(requested, modifiedPod, err, time.Since(start))
+	if err != nil {
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationInjectionFailed", err.Error())
+	}
+	return modifiedPod, err
+}
+
+func (pm *instPodMutator) mutate(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (corev1.Pod, error) {
 	logger := pm.Logger.WithValues("namespace", pod.Namespace)
 	if pod.Name != "" {
 		logger = logger.WithValues("name", pod.Name)
@@ -266,12 +324,36 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		logger = logger.WithValues("generateName", pod.GenerateName)
 	}
 
+	cfg := pm.config.Config()
+
 	// We check if Pod is already instrumented.
 	if isAutoInstrumentationInjected(pod) {
 		logger.Info("Skipping pod instrumentation - already instrumented")
 		return pod, nil
 	}
 
+	if isExcluded(cfg, ns, pod) {
+		logger.V(1).Info("Skipping pod instrumentation - excluded by the operator configuration")
+		return pod, nil
+	}
+
+	// the containers mapped to languages are looked up through the equivalent inject and container names annotations,
+	// the pod itself is left as is.
+	annotated := pod
+	if annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationContainerLanguages) != "" {
+		if !cfg.EnableMultiInstrumentation {
+			logger.Error(nil, "support for multi instrumentation is not enabled, ignoring the container languages")
+			pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationRequestRejected", "support for multi instrumentation is not enabled, ignoring the container languages")
+		} else {
+			meta, err := containerLanguages(ns.ObjectMeta, pod.ObjectMeta)
+			if err != nil {
+				logger.Error(err, "failed to map the containers of this pod to languages")
+				return pod, err
+			}
+			annotated.ObjectMeta = meta
+		}
+	}
+
 	var inst *v1alpha1.Instrumentation
 	var err error
 
@@ -279,93 +361,117 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 
 	// We bail out if any annotation fails to process.
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectJava); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, annotated, annotationInjectJava); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
 	}
-	if pm.config.EnableJavaAutoInstrumentation || inst == nil {
+	if cfg.EnableJavaAutoInstrumentation || inst == nil {
 		insts.Java.Instrumentation = inst
 	} else {
 		logger.Error(nil, "support for Java auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Java auto instrumentation is not enabled")
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationRequestRejected", "support for Java auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNodeJS); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, annotated, annotationInjectNodeJS); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
 	}
-	if pm.config.EnableNodeJSAutoInstrumentation || inst == nil {
+	if cfg.EnableNodeJSAutoInstrumentation || inst == nil {
 		insts.NodeJS.Instrumentation = inst
 	} else {
 		logger.Error(nil, "support for NodeJS auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for NodeJS auto instrumentation is not enabled")
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationRequestRejected", "support for NodeJS auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectPython); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, annotated, annotationInjectPython); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
 	}
-	if pm.config.EnablePythonAutoInstrumentation || inst == nil {
+	if cfg.EnablePythonAutoInstrumentation || inst == nil {
 		insts.Python.Instrumentation = inst
 		insts.Python.AdditionalAnnotations = map[string]string{annotationPythonPlatform: annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationPythonPlatform)}
 	} else {
 		logger.Error(nil, "support for Python auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Python auto instrumentation is not enabled")
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationRequestRejected", "support for Python auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectDotNet); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, annotated, annotationInjectDotNet); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
 	}
-	if pm.config.EnableDotNetInstrumentation || inst == nil {
+	if cfg.EnableDotNetInstrumentation || inst == nil {
 		insts.DotNet.Instrumentation = inst
 		insts.DotNet.AdditionalAnnotations = map[string]string{annotationDotNetRuntime: annotationValue(ns.ObjectMeta, pod.ObjectMeta, annotationDotNetRuntime)}
 	} else {
 		logger.Error(nil, "support for .NET auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for .NET auto instrumentation is not enabled")
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationRequestRejected", "support for .NET auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectGo); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, annotated, annotationInjectGo); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
 	}
-	if pm.config.EnableGoAutoInstrumentation || inst == nil {
+	if cfg.EnableGoAutoInstrumentation || inst == nil {
 		insts.Go.Instrumentation = inst
 	} else {
 		logger.Error(err, "support for Go auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Go auto instrumentation is not enabled")
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationRequestRejected", "support for Go auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectApacheHttpd); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, annotated, annotationInjectApacheHttpd); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
 	}
-	if pm.config.EnableApacheHttpdInstrumentation || inst == nil {
+	if cfg.EnableApacheHttpdInstrumentation || inst == nil {
 		insts.ApacheHttpd.Instrumentation = inst
 	} else {
 		logger.Error(nil, "support for Apache HTTPD auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Apache HTTPD auto instrumentation is not enabled")
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationRequestRejected", "support for Apache HTTPD auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectNginx); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, annotated, annotationInjectNginx); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
 	}
-	if pm.config.EnableNginxAutoInstrumentation || inst == nil {
+	if cfg.EnableNginxAutoInstrumentation || inst == nil {
 		insts.Nginx.Instrumentation = inst
 	} else {
 		logger.Error(nil, "support for Nginx auto instrumentation is not enabled")
-		pm.Recorder.Event(pod.DeepCopy(), "Warning", "InstrumentationRequestRejected", "support for Nginx auto instrumentation is not enabled")
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationRequestRejected", "support for Nginx auto instrumentation is not enabled")
 	}
 
-	if inst, err = pm.getInstrumentationInstance(ctx, ns, pod, annotationInjectSdk); err != nil {
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, annotated, annotationInjectPHP); err != nil {
+		// we still allow the pod to be created, but we log a message to the operator's logs
+		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		return pod, err
+	}
+	if cfg.EnablePHPAutoInstrumentation || inst == nil {
+		insts.PHP.Instrumentation = inst
+	} else {
+		logger.Error(nil, "support for PHP auto instrumentation is not enabled")
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationRequestRejected", "support for PHP auto instrumentation is not enabled")
+	}
+
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, annotated, annotationInjectRuby); err != nil {
+		// we still allow the pod to be created, but we log a message to the operator's logs
+		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
+		return pod, err
+	}
+	if cfg.EnableRubyAutoInstrumentation || inst == nil {
+		insts.Ruby.Instrumentation = inst
+	} else {
+		logger.Error(nil, "support for Ruby auto instrumentation is not enabled")
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationRequestRejected", "support for Ruby auto instrumentation is not enabled")
+	}
+
+	if inst, err = pm.getInstrumentationInstance(ctx, ns, annotated, annotationInjectSdk); err != nil {
 		// we still allow the pod to be created, but we log a message to the operator's logs
 		logger.Error(err, "failed to select an OpenTelemetry Instrumentation instance for this pod")
 		return pod, err
@@ -374,7 +480,7 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 
 	if insts.Java.Instrumentation == nil && insts.NodeJS.Instrumentation == nil && insts.Python.Instrumentation == nil &&
 		insts.DotNet.Instrumentation == nil && insts.Go.Instrumentation == nil && insts.ApacheHttpd.Instrumentation == nil &&
-		insts.Nginx.Instrumentation == nil &&
+		insts.Nginx.Instrumentation == nil && insts.PHP.Instrumentation == nil && insts.Ruby.Instrumentation == nil &&
 		insts.Sdk.Instrumentation == nil {
 
 		logger.V(1).Info("annotation not present in deployment, skipping instrumentation injection")
@@ -391,9 +497,24 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		return pod, err
 	}
 
+	// NodeJS fails to start when a required file is missing, so an absent optional configuration file is left out
+	if inst := insts.NodeJS.Instrumentation; inst != nil && inst.Spec.NodeJS.ConfigMapRef != nil {
+		present, presentErr := pm.configFilePresent(ctx, inst.Spec.NodeJS.ConfigMapRef, ns.Name)
+		if presentErr != nil {
+			logger.Error(presentErr, "failed to get the configuration file of the nodejs agent")
+			return pod, presentErr
+		}
+		if !present {
+			logger.V(1).Info("the optional configuration file of the nodejs agent is absent, it isn't required")
+			inst = inst.DeepCopy()
+			inst.Spec.NodeJS.ConfigMapRef = nil
+			insts.NodeJS.Instrumentation = inst
+		}
+	}
+
 	// We retrieve the annotation for podname
-	if pm.config.EnableMultiInstrumentation {
-		err = insts.setLanguageSpecificContainers(ns.ObjectMeta, pod.ObjectMeta)
+	if cfg.EnableMultiInstrumentation {
+		err = insts.setLanguageSpecificContainers(ns.ObjectMeta, annotated.ObjectMeta)
 		if err != nil {
 			return pod, err
 		}
@@ -402,14 +523,35 @@ func (pm *instPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod c
 		ok, msg := insts.areInstrumentedContainersCorrect()
 		if !ok {
 			logger.V(1).Error(msg, "skipping instrumentation injection")
+			pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationInjectionSkipped", msg.Error())
 			return pod, nil
 		}
 	}
 
+	if excluded := insts.excludeContainers(cfg, pod); len(excluded) > 0 {
+		logger.V(1).Info("skipping the containers excluded by the operator configuration", "containers", excluded)
+		if len(insts.injected()) == 0 {
+			return pod, nil
+		}
+	}
+
+	// an invalid sampler override doesn't prevent the injection, the sampler of the instrumentation is used instead
+	if err = insts.overrideSampler(ns.ObjectMeta, pod.ObjectMeta); err != nil {
+		logger.Error(err, "ignoring the sampler annotations")
+		pm.Recorder.Event(eventObject(pod), "Warning", "InstrumentationSamplerIgnored", err.Error())
+	}
+
 	// once it's been determined that instrumentation is desired, none exists yet, and we know which instance it should talk to,
 	// we should inject the instrumentation.
 	modifiedPod := pod
-	modifiedPod = pm.sdkInjector.inject(ctx, insts, ns, modifiedPod, pm.config)
+	modifiedPod = pm.sdkInjector.inject(ctx, insts, ns, modifiedPod, cfg)
+	modifiedPod = recordInjected(insts, modifiedPod)
+
+	// the agents export telemetry as soon as the application starts, which fails until the Istio proxy is ready
+	if cfg.IstioAvailability == autoIstio.Available && istio.SidecarInjected(ns, modifiedPod) {
+		logger.V(1).Info("pod is part of the Istio mesh, holding the application until the proxy starts")
+		modifiedPod = istio.HoldApplicationUntilProxyStarts(modifiedPod)
+	}
 
 	return modifiedPod, nil
 }
@@ -422,7 +564,7 @@ func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns cor
 	}
 
 	if strings.EqualFold(instValue, "true") {
-		return pm.selectInstrumentationInstanceFromNamespace(ctx, ns)
+		return pm.selectInstrumentationInstance(ctx, ns, pod)
 	}
 
 	var instNamespacedName types.NamespacedName
@@ -441,22 +583,6 @@ func (pm *instPodMutator) getInstrumentationInstance(ctx context.Context, ns cor
 	return otelInst, nil
 }
 
-func (pm *instPodMutator) selectInstrumentationInstanceFromNamespace(ctx context.Context, ns corev1.Namespace) (*v1alpha1.Instrumentation, error) {
-	var otelInsts v1alpha1.InstrumentationList
-	if err := pm.Client.List(ctx, &otelInsts, client.InNamespace(ns.Name)); err != nil {
-		return nil, err
-	}
-
-	switch s := len(otelInsts.Items); {
-	case s == 0:
-		return nil, errNoInstancesAvailable
-	case s > 1:
-		return nil, errMultipleInstancesPossible
-	default:
-		return &otelInsts.Items[0], nil
-	}
-}
-
 func (pm *instPodMutator) validateInstrumentations(ctx context.Context, inst languageInstrumentations, podNamespace string) error {
 	instrumentations := []struct {
 		instrumentation *v1alpha1.Instrumentation
@@ -468,6 +594,8 @@ func (pm *instPodMutator) validateInstrumentations(ctx context.Context, inst lan
 		{inst.Go.Instrumentation},
 		{inst.ApacheHttpd.Instrumentation},
 		{inst.Nginx.Instrumentation},
+		{inst.PHP.Instrumentation},
+		{inst.Ruby.Instrumentation},
 		{inst.Sdk.Instrumentation},
 	}
 	var errs []error
@@ -478,6 +606,16 @@ func (pm *instPodMutator) validateInstrumentations(ctx context.Context, inst lan
 			}
 		}
 	}
+	if inst.Java.Instrumentation != nil {
+		if err := pm.validateConfigMapRef(ctx, inst.Java.Instrumentation.Spec.Java.ConfigMapRef, podNamespace); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if inst.NodeJS.Instrumentation != nil {
+		if err := pm.validateConfigMapRef(ctx, inst.NodeJS.Instrumentation.Spec.NodeJS.ConfigMapRef, podNamespace); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	if len(errs) > 0 {
 		return errors.Join(errs...)
@@ -508,3 +646,29 @@ func (pm *instPodMutator) validateInstrumentation(ctx context.Context, inst *v1a
 	}
 	return nil
 }
+
+// validateConfigMapRef checks that the ConfigMap with the configuration file of an agent exists, unless it's optional.
+func (pm *instPodMutator) validateConfigMapRef(ctx context.Context, ref *corev1.ConfigMapKeySelector, podNamespace string) error {
+	if ref == nil || ref.Optional != nil && *ref.Optional {
+		return nil
+	}
+	nsn := types.NamespacedName{Name: ref.Name, Namespace: podNamespace}
+	if err := pm.Client.Get(ctx, nsn, &corev1.ConfigMap{}); apierrors.IsNotFound(err) {
+		return fmt.Errorf("configmap %s with the agent configuration file does not exist: %w", nsn.String(), err)
+	}
+	return nil
+}
+
+// configFilePresent returns whether the ConfigMap with the configuration file of an agent exists and holds its key.
+func (pm *instPodMutator) configFilePresent(ctx context.Context, ref *corev1.ConfigMapKeySelector, podNamespace string) (bool, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := pm.Client.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: podNamespace}, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	_, inData := configMap.Data[ref.Key]
+	_, inBinaryData := configMap.BinaryData[ref.Key]
+	return inData || inBinaryData, nil
+}
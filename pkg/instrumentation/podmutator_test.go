@@ -134,7 +134,9 @@ func TestMutatePod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
 						annotationInjectJava: "true",
+						annotationInjected:   "java=javaagent/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -379,7 +381,9 @@ func TestMutatePod(t *testing.T) {
 					Annotations: map[string]string{
 						annotationInjectJava:          "true",
 						annotationInjectContainerName: "app1,app2",
+						annotationInjected:            "java=javaagent-multiple-containers/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -752,7 +756,9 @@ func TestMutatePod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
 						annotationInjectNodeJS: "true",
+						annotationInjected:     "nodejs=nodejs/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -941,7 +947,9 @@ func TestMutatePod(t *testing.T) {
 					Annotations: map[string]string{
 						annotationInjectNodeJS:        "true",
 						annotationInjectContainerName: "app1,app2",
+						annotationInjected:            "nodejs=nodejs-multiple-containers/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -1298,7 +1306,9 @@ func TestMutatePod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
 						annotationInjectPython: "true",
+						annotationInjected:     "python=python/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -1507,7 +1517,9 @@ func TestMutatePod(t *testing.T) {
 					Annotations: map[string]string{
 						annotationInjectPython:        "true",
 						annotationInjectContainerName: "app1,app2",
+						annotationInjected:            "python=python-multiple-containers/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -1886,7 +1898,9 @@ func TestMutatePod(t *testing.T) {
 					Annotations: map[string]string{
 						annotationInjectDotNet:  "true",
 						annotationDotNetRuntime: dotNetRuntimeLinuxMusl,
+						annotationInjected:      "dotnet=dotnet/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -2083,7 +2097,12 @@ func TestMutatePod(t *testing.T) {
 				},
 			},
 			expected: corev1.Pod{
-				ObjectMeta: metav1.ObjectMeta{},
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotationInjected: "dotnet=dotnet-by-namespace-annotation/example-inst",
+					},
+					Labels: map[string]string{InjectedLabel: "true"},
+				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
 						{
@@ -2287,7 +2306,9 @@ func TestMutatePod(t *testing.T) {
 					Annotations: map[string]string{
 						annotationInjectDotNet:        "true",
 						annotationInjectContainerName: "app1,app2",
+						annotationInjected:            "dotnet=dotnet-multiple-containers/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -2671,7 +2692,9 @@ func TestMutatePod(t *testing.T) {
 					Annotations: map[string]string{
 						annotationInjectGo:   "true",
 						annotationGoExecPath: "/app",
+						annotationInjected:   "go=go/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					ShareProcessNamespace: &true,
@@ -2686,6 +2709,7 @@ func TestMutatePod(t *testing.T) {
 								RunAsUser:  &zero,
 								Privileged: &true,
 							},
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									MountPath: "/sys/kernel/debug",
@@ -2899,7 +2923,9 @@ func TestMutatePod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
 						annotationInjectApacheHttpd: "true",
+						annotationInjected:          "apache-httpd=apache-httpd/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -3150,7 +3176,9 @@ func TestMutatePod(t *testing.T) {
 					Name: "my-nginx-6c44bcbdd",
 					Annotations: map[string]string{
 						annotationInjectNginx: "true",
+						annotationInjected:    "nginx=req-namespace/my-nginx-6c44bcbdd",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -3597,7 +3625,9 @@ func TestMutatePod(t *testing.T) {
 						annotationInjectJavaContainersName:   "java1,java2",
 						annotationInjectNodeJSContainersName: "nodejs1,nodejs2",
 						annotationInjectPythonContainersName: "python1,python2",
+						annotationInjected:                   "dotnet=multi-instrumentation-multi-containers/example-inst,java=multi-instrumentation-multi-containers/example-inst,nodejs=multi-instrumentation-multi-containers/example-inst,python=multi-instrumentation-multi-containers/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -4640,7 +4670,9 @@ func TestMutatePod(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Annotations: map[string]string{
 						annotationInjectDotNet: "true",
+						annotationInjected:     "dotnet=multi-instrumentation-single-container-no-cont/example-inst",
 					},
+					Labels: map[string]string{InjectedLabel: "true"},
 				},
 				Spec: corev1.PodSpec{
 					Volumes: []corev1.Volume{
@@ -4915,7 +4947,7 @@ func TestMutatePod(t *testing.T) {
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
-			mutator := NewMutator(logr.Discard(), k8sClient, record.NewFakeRecorder(100), test.config)
+			mutator := NewMutator(logr.Discard(), k8sClient, record.NewFakeRecorder(100), config.NewProvider(test.config))
 			require.NotNil(t, mutator)
 			if test.setFeatureGates != nil {
 				test.setFeatureGates(t)
@@ -102,7 +102,7 @@ func injectPythonSDK(pythonSpec v1alpha1.Python, pod corev1.Pod, index int, plat
 				Name:      volume.Name,
 				MountPath: pythonInstrMountPath,
 			}},
-			ImagePullPolicy: instSpec.ImagePullPolicy,
+			ImagePullPolicy: initContainerPullPolicy(pythonSpec.ImagePullPolicy, instSpec.ImagePullPolicy),
 		})
 	}
 	return pod, nil
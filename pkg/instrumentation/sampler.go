@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// samplerOverride returns the sampler of an Instrumentation overridden by the annotationSamplerType and
+// annotationSamplerArgument annotations of the pod or, when the pod has none of them, of the namespace. A type
+// override replaces the argument of the Instrumentation, while an argument override keeps its type.
+func samplerOverride(sampler v1alpha1.Sampler, ns metav1.ObjectMeta, pod metav1.ObjectMeta) (v1alpha1.Sampler, error) {
+	annotations := pod.Annotations
+	if annotations[annotationSamplerType] == "" && annotations[annotationSamplerArgument] == "" {
+		annotations = ns.Annotations
+	}
+	samplerType, argument := annotations[annotationSamplerType], annotations[annotationSamplerArgument]
+	switch {
+	case samplerType != "":
+		sampler = v1alpha1.Sampler{Type: v1alpha1.SamplerType(samplerType), Argument: argument}
+	case argument != "":
+		sampler.Argument = argument
+	default:
+		return sampler, nil
+	}
+	return sampler, v1alpha1.ValidateSampler(sampler, annotationSamplerType, annotationSamplerArgument)
+}
+
+// overrideSampler replaces the Instrumentations to inject by copies with the sampler overridden by the annotations
+// of the pod or the namespace. The Instrumentations are left as is when an override isn't valid.
+func (langInsts *languageInstrumentations) overrideSampler(ns metav1.ObjectMeta, pod metav1.ObjectMeta) error {
+	insts := langInsts.all()
+	samplers := make([]v1alpha1.Sampler, len(insts))
+	for i, inst := range insts {
+		if inst.Instrumentation == nil {
+			continue
+		}
+		sampler, err := samplerOverride(inst.Instrumentation.Spec.Sampler, ns, pod)
+		if err != nil {
+			return err
+		}
+		samplers[i] = sampler
+	}
+	for i, inst := range insts {
+		if inst.Instrumentation == nil || inst.Instrumentation.Spec.Sampler == samplers[i] {
+			continue
+		}
+		overridden := inst.Instrumentation.DeepCopy()
+		overridden.Spec.Sampler = samplers[i]
+		inst.Instrumentation = overridden
+	}
+	return nil
+}
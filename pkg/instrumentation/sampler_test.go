@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestSamplerOverride(t *testing.T) {
+	defaults := v1alpha1.Sampler{Type: v1alpha1.ParentBasedTraceIDRatio, Argument: "0.1"}
+
+	for _, tt := range []struct {
+		name    string
+		ns      map[string]string
+		pod     map[string]string
+		sampler v1alpha1.Sampler
+		err     string
+	}{
+		{
+			name:    "no annotations",
+			sampler: defaults,
+		},
+		{
+			name:    "argument on the pod",
+			pod:     map[string]string{annotationSamplerArgument: "1"},
+			sampler: v1alpha1.Sampler{Type: v1alpha1.ParentBasedTraceIDRatio, Argument: "1"},
+		},
+		{
+			name:    "type on the namespace",
+			ns:      map[string]string{annotationSamplerType: "always_on"},
+			sampler: v1alpha1.Sampler{Type: v1alpha1.AlwaysOn},
+		},
+		{
+			name:    "pod over namespace",
+			ns:      map[string]string{annotationSamplerType: "always_off"},
+			pod:     map[string]string{annotationSamplerType: "traceidratio", annotationSamplerArgument: "0.5"},
+			sampler: v1alpha1.Sampler{Type: v1alpha1.TraceIDRatio, Argument: "0.5"},
+		},
+		{
+			name: "invalid type",
+			pod:  map[string]string{annotationSamplerType: "sometimes"},
+			err:  "instrumentation.opentelemetry.io/sampler-type is not valid: sometimes",
+		},
+		{
+			name: "invalid argument",
+			ns:   map[string]string{annotationSamplerArgument: "2"},
+			err:  "instrumentation.opentelemetry.io/sampler-argument should be in rage [0..1]: 2",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler, err := samplerOverride(defaults, metav1.ObjectMeta{Annotations: tt.ns}, metav1.ObjectMeta{Annotations: tt.pod})
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.sampler, sampler)
+		})
+	}
+}
+
+func TestOverrideSampler(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "apps"},
+		Spec:       v1alpha1.InstrumentationSpec{Sampler: v1alpha1.Sampler{Type: v1alpha1.ParentBasedTraceIDRatio, Argument: "0.1"}},
+	}
+	pod := metav1.ObjectMeta{Annotations: map[string]string{annotationSamplerArgument: "1"}}
+
+	insts := languageInstrumentations{Java: instrumentationWithContainers{Instrumentation: inst}}
+	require.NoError(t, insts.overrideSampler(metav1.ObjectMeta{}, pod))
+	assert.Equal(t, "1", insts.Java.Instrumentation.Spec.Sampler.Argument)
+	assert.Equal(t, "my-inst", insts.Java.Instrumentation.Name)
+	assert.Nil(t, insts.Python.Instrumentation)
+	// the shared Instrumentation isn't changed
+	assert.Equal(t, "0.1", inst.Spec.Sampler.Argument)
+
+	insts = languageInstrumentations{Java: instrumentationWithContainers{Instrumentation: inst}}
+	pod.Annotations[annotationSamplerArgument] = "all"
+	assert.Error(t, insts.overrideSampler(metav1.ObjectMeta{}, pod))
+	assert.Same(t, inst, insts.Java.Instrumentation)
+}
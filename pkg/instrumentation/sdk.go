@@ -48,6 +48,10 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 	if len(pod.Spec.Containers) < 1 {
 		return pod
 	}
+	// the conflicting variables of the containers are set aside while the injection sets its own
+	envConflictPolicies := insts.envConflictPolicies(pod)
+	pod, setAsideEnv := setAsideConflictingEnv(envConflictPolicies, pod)
+
 	if insts.Java.Instrumentation != nil {
 		otelinst := *insts.Java.Instrumentation
 		var err error
@@ -65,7 +69,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
 				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, javaInitContainerName)
+				pod = i.setInitContainerSecurityContext(pod, initContainerSecurityContext(otelinst.Spec.Java.SecurityContext, pod.Spec.Containers[index].SecurityContext), javaInitContainerName)
 			}
 		}
 	}
@@ -86,7 +90,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
 				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, nodejsInitContainerName)
+				pod = i.setInitContainerSecurityContext(pod, initContainerSecurityContext(otelinst.Spec.NodeJS.SecurityContext, pod.Spec.Containers[index].SecurityContext), nodejsInitContainerName)
 			}
 		}
 	}
@@ -107,7 +111,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
 				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, pythonInitContainerName)
+				pod = i.setInitContainerSecurityContext(pod, initContainerSecurityContext(otelinst.Spec.Python.SecurityContext, pod.Spec.Containers[index].SecurityContext), pythonInitContainerName)
 			}
 		}
 	}
@@ -128,7 +132,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 			} else {
 				pod = i.injectCommonEnvVar(otelinst, pod, index)
 				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-				pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, dotnetInitContainerName)
+				pod = i.setInitContainerSecurityContext(pod, initContainerSecurityContext(otelinst.Spec.DotNet.SecurityContext, pod.Spec.Containers[index].SecurityContext), dotnetInitContainerName)
 			}
 		}
 	}
@@ -150,7 +154,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		} else {
 			// Common env vars and config need to be applied to the agent contain.
 			pod = i.injectCommonEnvVar(otelinst, pod, len(pod.Spec.Containers)-1)
-			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, len(pod.Spec.Containers)-1, 0)
+			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, len(pod.Spec.Containers)-1, index)
 
 			// Ensure that after all the env var coalescing we have a value for OTEL_GO_AUTO_TARGET_EXE
 			idx := getIndexOfEnv(pod.Spec.Containers[len(pod.Spec.Containers)-1].Env, envOtelTargetExe)
@@ -176,8 +180,8 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 			pod = injectApacheHttpdagent(i.logger, otelinst.Spec.ApacheHttpd, pod, useLabelsForResourceAttributes, index, otelinst.Spec.Endpoint, i.createResourceMap(ctx, otelinst, ns, pod, index), otelinst.Spec)
 			pod = i.injectCommonEnvVar(otelinst, pod, index)
 			pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
-			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, apacheAgentInitContainerName)
-			pod = i.setInitContainerSecurityContext(pod, pod.Spec.Containers[index].SecurityContext, apacheAgentCloneContainerName)
+			pod = i.setInitContainerSecurityContext(pod, initContainerSecurityContext(otelinst.Spec.ApacheHttpd.SecurityContext, pod.Spec.Containers[index].SecurityContext), apacheAgentInitContainerName)
+			pod = i.setInitContainerSecurityContext(pod, initContainerSecurityContext(otelinst.Spec.ApacheHttpd.SecurityContext, pod.Spec.Containers[index].SecurityContext), apacheAgentCloneContainerName)
 		}
 	}
 
@@ -200,6 +204,51 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		}
 	}
 
+	if insts.PHP.Instrumentation != nil {
+		otelinst := *insts.PHP.Instrumentation
+		var err error
+		i.logger.V(1).Info("injecting PHP instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+		if len(insts.PHP.Containers) == 0 {
+			insts.PHP.Containers = []string{pod.Spec.Containers[0].Name}
+		}
+
+		for _, container := range insts.PHP.Containers {
+			index := getContainerIndex(container, pod)
+			pod, err = injectPHPSDK(otelinst.Spec.PHP, pod, index, otelinst.Spec)
+			if err != nil {
+				i.logger.Info("Skipping PHP SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+			} else {
+				pod = i.injectCommonEnvVar(otelinst, pod, index)
+				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+				pod = i.setInitContainerSecurityContext(pod, initContainerSecurityContext(otelinst.Spec.PHP.SecurityContext, pod.Spec.Containers[index].SecurityContext), phpInitContainerName)
+				pod = i.setInitContainerSecurityContext(pod, initContainerSecurityContext(otelinst.Spec.PHP.SecurityContext, pod.Spec.Containers[index].SecurityContext), phpIniGeneratorName)
+			}
+		}
+	}
+
+	if insts.Ruby.Instrumentation != nil {
+		otelinst := *insts.Ruby.Instrumentation
+		var err error
+		i.logger.V(1).Info("injecting Ruby instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
+
+		if len(insts.Ruby.Containers) == 0 {
+			insts.Ruby.Containers = []string{pod.Spec.Containers[0].Name}
+		}
+
+		for _, container := range insts.Ruby.Containers {
+			index := getContainerIndex(container, pod)
+			pod, err = injectRubySDK(otelinst.Spec.Ruby, pod, index, otelinst.Spec)
+			if err != nil {
+				i.logger.Info("Skipping Ruby SDK injection", "reason", err.Error(), "container", pod.Spec.Containers[index].Name)
+			} else {
+				pod = i.injectCommonEnvVar(otelinst, pod, index)
+				pod = i.injectCommonSDKConfig(ctx, otelinst, ns, pod, index, index)
+				pod = i.setInitContainerSecurityContext(pod, initContainerSecurityContext(otelinst.Spec.Ruby.SecurityContext, pod.Spec.Containers[index].SecurityContext), rubyInitContainerName)
+			}
+		}
+	}
+
 	if insts.Sdk.Instrumentation != nil {
 		otelinst := *insts.Sdk.Instrumentation
 		i.logger.V(1).Info("injecting sdk-only instrumentation into pod", "otelinst-namespace", otelinst.Namespace, "otelinst-name", otelinst.Name)
@@ -215,7 +264,7 @@ func (i *sdkInjector) inject(ctx context.Context, insts languageInstrumentations
 		}
 	}
 
-	return pod
+	return resolveEnvConflicts(envConflictPolicies, setAsideEnv, pod)
 }
 
 func (i *sdkInjector) setInitContainerSecurityContext(pod corev1.Pod, securityContext *corev1.SecurityContext, instrInitContainerName string) corev1.Pod {
@@ -228,6 +277,24 @@ func (i *sdkInjector) setInitContainerSecurityContext(pod corev1.Pod, securityCo
 	return pod
 }
 
+// initContainerSecurityContext returns the security context configured for the language,
+// falling back to the security context of the instrumented container.
+func initContainerSecurityContext(langSecurityContext, containerSecurityContext *corev1.SecurityContext) *corev1.SecurityContext {
+	if langSecurityContext != nil {
+		return langSecurityContext.DeepCopy()
+	}
+	return containerSecurityContext
+}
+
+// initContainerPullPolicy returns the image pull policy configured for the language,
+// falling back to the image pull policy of the Instrumentation.
+func initContainerPullPolicy(langPullPolicy, instPullPolicy corev1.PullPolicy) corev1.PullPolicy {
+	if langPullPolicy != "" {
+		return langPullPolicy
+	}
+	return instPullPolicy
+}
+
 func getContainerIndex(containerName string, pod corev1.Pod) int {
 	// We search for specific container to inject variables and if no one is found
 	// We fall back to first container
@@ -549,6 +616,13 @@ func (i *sdkInjector) createResourceMap(ctx context.Context, otelinst v1alpha1.I
 		}
 	}
 
+	// attributes mapped from the labels and annotations of the pod or the namespace override the CRD entries
+	for k, v := range mappedAttributes(otelinst.Spec.Resource, ns.ObjectMeta, pod.ObjectMeta) {
+		if !existingRes[k] {
+			res[k] = v
+		}
+	}
+
 	useLabelsForResourceAttributes := otelinst.Spec.Defaults.UseLabelsForResourceAttributes
 
 	// k8s resources have a higher precedence than CRD entries
@@ -586,6 +660,27 @@ func (i *sdkInjector) createResourceMap(ctx context.Context, otelinst v1alpha1.I
 	return res
 }
 
+// mappedAttributes returns the resource attributes set from the labels and annotations of the pod, or else of the
+// namespace, mapped by LabelsToAttributes and AnnotationsToAttributes. The annotations win over the labels mapped to
+// the same attribute.
+func mappedAttributes(resource v1alpha1.Resource, ns metav1.ObjectMeta, pod metav1.ObjectMeta) map[string]string {
+	attributes := map[string]string{}
+	mapAttributes := func(mapping map[string]string, podValues map[string]string, nsValues map[string]string) {
+		for key, attribute := range mapping {
+			value := podValues[key]
+			if value == "" {
+				value = nsValues[key]
+			}
+			if value != "" {
+				attributes[attribute] = value
+			}
+		}
+	}
+	mapAttributes(resource.LabelsToAttributes, pod.Labels, ns.Labels)
+	mapAttributes(resource.AnnotationsToAttributes, pod.Annotations, ns.Annotations)
+	return attributes
+}
+
 func (i *sdkInjector) addParentResourceLabels(ctx context.Context, uid bool, ns corev1.Namespace, objectMeta metav1.ObjectMeta, resources map[attribute.Key]string) {
 	for _, owner := range objectMeta.OwnerReferences {
 		switch strings.ToLower(owner.Kind) {
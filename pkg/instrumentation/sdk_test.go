@@ -1548,6 +1548,7 @@ func TestInjectGo(t *testing.T) {
 								RunAsUser:  &zero,
 								Privileged: &true,
 							},
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									MountPath: "/sys/kernel/debug",
@@ -1665,6 +1666,7 @@ func TestInjectGo(t *testing.T) {
 								RunAsUser:  &zero,
 								Privileged: &true,
 							},
+							TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
 							VolumeMounts: []corev1.VolumeMount{
 								{
 									MountPath: "/sys/kernel/debug",
@@ -1746,6 +1748,88 @@ func TestInjectGo(t *testing.T) {
 	}
 }
 
+func TestInjectGoTargetContainer(t *testing.T) {
+	insts := languageInstrumentations{
+		Go: instrumentationWithContainers{
+			Containers: []string{"worker"},
+			Instrumentation: &v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					Go:       v1alpha1.Go{Image: "otel/go:1"},
+					Exporter: v1alpha1.Exporter{Endpoint: "http://collector:4318"},
+				},
+			},
+		},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"instrumentation.opentelemetry.io/otel-go-auto-target-exe": "/app/worker"},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}, {Name: "worker"}}},
+	}
+
+	inj := sdkInjector{logger: logr.Discard()}
+	pod = inj.inject(context.Background(), insts, testNamespace, pod, config.New())
+
+	require.Len(t, pod.Spec.Containers, 3)
+	agent := pod.Spec.Containers[2]
+	assert.Equal(t, sideCarName, agent.Name)
+	assert.Contains(t, agent.Env, corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://collector:4318"})
+	// the resource attributes describe the instrumented container
+	idx := getIndexOfEnv(agent.Env, "OTEL_RESOURCE_ATTRIBUTES")
+	require.NotEqual(t, -1, idx)
+	assert.Contains(t, agent.Env[idx].Value, "k8s.container.name=worker")
+}
+
+func TestInjectInitContainerOverrides(t *testing.T) {
+	runAsNonRoot := true
+	privileged := false
+	containerSecurityContext := &corev1.SecurityContext{Privileged: &privileged}
+	insts := languageInstrumentations{
+		Java: instrumentationWithContainers{
+			Containers: []string{"app"},
+			Instrumentation: &v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					Java: v1alpha1.Java{
+						Image:           "otel/java:1",
+						ImagePullPolicy: corev1.PullAlways,
+						SecurityContext: &corev1.SecurityContext{RunAsNonRoot: &runAsNonRoot},
+					},
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				},
+			},
+		},
+		NodeJS: instrumentationWithContainers{
+			Containers: []string{"web"},
+			Instrumentation: &v1alpha1.Instrumentation{
+				Spec: v1alpha1.InstrumentationSpec{
+					NodeJS:          v1alpha1.NodeJS{Image: "otel/nodejs:1"},
+					ImagePullPolicy: corev1.PullIfNotPresent,
+				},
+			},
+		},
+	}
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "app", SecurityContext: containerSecurityContext},
+			{Name: "web", SecurityContext: containerSecurityContext},
+		}},
+	}
+
+	inj := sdkInjector{logger: logr.Discard()}
+	pod = inj.inject(context.Background(), insts, testNamespace, pod, config.New())
+
+	require.Len(t, pod.Spec.InitContainers, 2)
+	java := pod.Spec.InitContainers[0]
+	assert.Equal(t, javaInitContainerName, java.Name)
+	assert.Equal(t, corev1.PullAlways, java.ImagePullPolicy)
+	assert.Equal(t, &corev1.SecurityContext{RunAsNonRoot: &runAsNonRoot}, java.SecurityContext)
+	// without overrides, the init container falls back to the Instrumentation and the instrumented container
+	nodejs := pod.Spec.InitContainers[1]
+	assert.Equal(t, nodejsInitContainerName, nodejs.Name)
+	assert.Equal(t, corev1.PullIfNotPresent, nodejs.ImagePullPolicy)
+	assert.Equal(t, containerSecurityContext, nodejs.SecurityContext)
+}
+
 func TestInjectApacheHttpd(t *testing.T) {
 
 	tests := []struct {
@@ -2689,3 +2773,50 @@ func Test_parseServiceVersionFromImage(t *testing.T) {
 		})
 	}
 }
+
+func TestMappedAttributes(t *testing.T) {
+	resource := v1alpha1.Resource{
+		Attributes:              map[string]string{"team.name": "platform", "deployment.environment": "dev"},
+		LabelsToAttributes:      map[string]string{"team": "team.name", "app.kubernetes.io/version": "app.version", "tier": "app.tier"},
+		AnnotationsToAttributes: map[string]string{"example.com/cost-center": "cost.center", "example.com/team": "team.name"},
+	}
+	ns := metav1.ObjectMeta{
+		Name:        "apps",
+		Labels:      map[string]string{"team": "checkout", "tier": "backend"},
+		Annotations: map[string]string{"example.com/cost-center": "cc-42"},
+	}
+	pod := metav1.ObjectMeta{
+		Namespace: "apps",
+		Name:      "app",
+		Labels:    map[string]string{"app.kubernetes.io/version": "1.2.3", "tier": ""},
+	}
+
+	assert.Equal(t, map[string]string{
+		"team.name":   "checkout",
+		"app.version": "1.2.3",
+		"app.tier":    "backend",
+		"cost.center": "cc-42",
+	}, mappedAttributes(resource, ns, pod))
+
+	// the annotations win over the labels
+	pod.Annotations = map[string]string{"example.com/team": "payments"}
+	assert.Equal(t, "payments", mappedAttributes(resource, ns, pod)["team.name"])
+
+	// the mapped attributes override the attributes of the CRD, but not the Kubernetes ones nor the ones set in the container
+	resource.LabelsToAttributes["namespace-name"] = "k8s.namespace.name"
+	ns.Labels["namespace-name"] = "other"
+	inj := sdkInjector{client: k8sClient}
+	res := inj.createResourceMap(context.Background(), v1alpha1.Instrumentation{Spec: v1alpha1.InstrumentationSpec{Resource: resource}},
+		corev1.Namespace{ObjectMeta: ns},
+		corev1.Pod{
+			ObjectMeta: pod,
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Env:  []corev1.EnvVar{{Name: "OTEL_RESOURCE_ATTRIBUTES", Value: "app.version=from-env"}},
+			}}},
+		}, 0)
+	assert.Equal(t, "payments", res["team.name"])
+	assert.Equal(t, "dev", res["deployment.environment"])
+	assert.Equal(t, "apps", res["k8s.namespace.name"])
+	assert.NotContains(t, res, "app.version")
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// selectInstrumentationInstance selects the Instrumentation used by a pod annotated with `inject-<language>: "true"`.
+func (pm *instPodMutator) selectInstrumentationInstance(ctx context.Context, ns corev1.Namespace, pod corev1.Pod) (*v1alpha1.Instrumentation, error) {
+	// Instrumentations with a namespace selector can be used from other namespaces
+	var otelInsts v1alpha1.InstrumentationList
+	if err := pm.Client.List(ctx, &otelInsts); err != nil {
+		return nil, err
+	}
+
+	return selectInstrumentation(otelInsts.Items, ns, pod)
+}
+
+// selectInstrumentation returns the Instrumentation matching the pod with the highest specificity, and fails when
+// several of them are as specific. The Instrumentations of other namespaces are only used when the namespace of the
+// pod allows them, and when none of its own namespace matches.
+func selectInstrumentation(otelInsts []v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod) (*v1alpha1.Instrumentation, error) {
+	var local, foreign []*v1alpha1.Instrumentation
+	for i := range otelInsts {
+		switch {
+		case otelInsts[i].Namespace == ns.Name:
+			local = append(local, &otelInsts[i])
+		case strings.EqualFold(ns.Annotations[annotationAllowForeignInstrumentations], "true"):
+			foreign = append(foreign, &otelInsts[i])
+		}
+	}
+
+	selected, err := selectMostSpecific(local, ns, pod)
+	if errors.Is(err, errNoInstancesAvailable) {
+		return selectMostSpecific(foreign, ns, pod)
+	}
+	return selected, err
+}
+
+// selectMostSpecific returns the Instrumentation matching the pod with the highest specificity.
+func selectMostSpecific(otelInsts []*v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod) (*v1alpha1.Instrumentation, error) {
+	var selected []*v1alpha1.Instrumentation
+	best := -1
+	for _, otelInst := range otelInsts {
+		specificity, matches := matchInstrumentation(otelInst, ns, pod)
+		switch {
+		case !matches || specificity < best:
+			continue
+		case specificity > best:
+			best = specificity
+			selected = selected[:0]
+		}
+		selected = append(selected, otelInst)
+	}
+
+	switch s := len(selected); {
+	case s == 0:
+		return nil, errNoInstancesAvailable
+	case s > 1:
+		return nil, errMultipleInstancesPossible
+	default:
+		return selected[0], nil
+	}
+}
+
+// matchInstrumentation returns whether the Instrumentation can be used by the pod, and how specific the match is: an
+// object selector is more specific than a namespace selector. The Instrumentations of other namespaces need a
+// namespace selector.
+func matchInstrumentation(otelInst *v1alpha1.Instrumentation, ns corev1.Namespace, pod corev1.Pod) (int, bool) {
+	if otelInst.Namespace != ns.Name && otelInst.Spec.NamespaceSelector == nil {
+		return 0, false
+	}
+
+	specificity := 0
+	for _, s := range []struct {
+		selector    *metav1.LabelSelector
+		labels      map[string]string
+		specificity int
+	}{
+		{otelInst.Spec.NamespaceSelector, ns.Labels, 1},
+		{otelInst.Spec.ObjectSelector, pod.Labels, 2},
+	} {
+		if s.selector == nil {
+			continue
+		}
+		// the selectors are validated by the webhook, an invalid one doesn't match anything
+		selector, err := metav1.LabelSelectorAsSelector(s.selector)
+		if err != nil || !selector.Matches(labels.Set(s.labels)) {
+			return 0, false
+		}
+		if !selector.Empty() {
+			specificity += s.specificity
+		}
+	}
+
+	return specificity, true
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestSelectInstrumentation(t *testing.T) {
+	ns := corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}},
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Labels: map[string]string{"app": "checkout", "tier": "backend"}},
+	}
+	inst := func(namespace, name string, nsSelector, objSelector *metav1.LabelSelector) v1alpha1.Instrumentation {
+		return v1alpha1.Instrumentation{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       v1alpha1.InstrumentationSpec{NamespaceSelector: nsSelector, ObjectSelector: objSelector},
+		}
+	}
+	team := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "payments"}}
+	otherTeam := &metav1.LabelSelector{MatchLabels: map[string]string{"team": "search"}}
+	backend := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}}
+	frontend := &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}}
+
+	tests := []struct {
+		name         string
+		insts        []v1alpha1.Instrumentation
+		allowForeign bool
+		expected     string
+		err          error
+	}{
+		{
+			name:     "single instrumentation of the namespace",
+			insts:    []v1alpha1.Instrumentation{inst("payments", "default", nil, nil), inst("search", "default", nil, nil)},
+			expected: "payments/default",
+		},
+		{
+			name:  "several instrumentations of the namespace",
+			insts: []v1alpha1.Instrumentation{inst("payments", "a", nil, nil), inst("payments", "b", nil, nil)},
+			err:   errMultipleInstancesPossible,
+		},
+		{
+			name:  "no instrumentation",
+			insts: []v1alpha1.Instrumentation{inst("search", "default", nil, nil), inst("platform", "search", otherTeam, nil)},
+			err:   errNoInstancesAvailable,
+		},
+		{
+			name:         "namespace selector from another namespace",
+			insts:        []v1alpha1.Instrumentation{inst("platform", "search", otherTeam, nil), inst("platform", "payments", team, nil)},
+			allowForeign: true,
+			expected:     "platform/payments",
+		},
+		{
+			name:  "namespace selector from another namespace not allowed",
+			insts: []v1alpha1.Instrumentation{inst("platform", "payments", team, nil)},
+			err:   errNoInstancesAvailable,
+		},
+		{
+			name:         "instrumentation of the namespace over namespace selector",
+			insts:        []v1alpha1.Instrumentation{inst("platform", "payments", team, nil), inst("payments", "default", nil, nil)},
+			allowForeign: true,
+			expected:     "payments/default",
+		},
+		{
+			name:         "instrumentation of the namespace over object selector from another namespace",
+			insts:        []v1alpha1.Instrumentation{inst("payments", "default", nil, nil), inst("platform", "backend", team, backend)},
+			allowForeign: true,
+			expected:     "payments/default",
+		},
+		{
+			name:     "object selector over instrumentation of the namespace",
+			insts:    []v1alpha1.Instrumentation{inst("payments", "default", nil, nil), inst("payments", "backend", nil, backend)},
+			expected: "payments/backend",
+		},
+		{
+			name:         "object selector from another namespace",
+			insts:        []v1alpha1.Instrumentation{inst("platform", "payments", team, nil), inst("platform", "backend", team, backend)},
+			allowForeign: true,
+			expected:     "platform/backend",
+		},
+		{
+			name:     "object selector not matching",
+			insts:    []v1alpha1.Instrumentation{inst("payments", "default", nil, nil), inst("payments", "frontend", nil, frontend)},
+			expected: "payments/default",
+		},
+		{
+			name:         "empty selectors are not specific",
+			insts:        []v1alpha1.Instrumentation{inst("platform", "all", &metav1.LabelSelector{}, &metav1.LabelSelector{}), inst("platform", "payments", team, nil)},
+			allowForeign: true,
+			expected:     "platform/payments",
+		},
+		{
+			name: "invalid selector",
+			insts: []v1alpha1.Instrumentation{inst("payments", "invalid", nil, &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Like"}},
+			})},
+			err: errNoInstancesAvailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ns := ns.DeepCopy()
+			if test.allowForeign {
+				ns.Annotations = map[string]string{annotationAllowForeignInstrumentations: "true"}
+			}
+			selected, err := selectInstrumentation(test.insts, *ns, pod)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, selected.Namespace+"/"+selected.Name)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+// InjectedLabel marks the pods injected by the webhook, the only pods cached by the operator for the status of the
+// Instrumentations.
+const InjectedLabel = "instrumentation.opentelemetry.io/injected"
+
+// maxReportedFailures bounds the number of failing pods listed in the Degraded condition.
+const maxReportedFailures = 5
+
+// maxTerminationMessageLength bounds the length of the termination message reported for a failing container.
+const maxTerminationMessageLength = 200
+
+// languageContainers are the containers added to the pods by the injection of each language.
+var languageContainers = map[string][]string{
+	"apache-httpd": {apacheAgentCloneContainerName, apacheAgentInitContainerName},
+	"dotnet":       {dotnetInitContainerName},
+	"go":           {sideCarName},
+	"java":         {javaInitContainerName},
+	"nginx":        {nginxAgentCloneContainerName, nginxAgentInitContainerName},
+	"nodejs":       {nodejsInitContainerName},
+	"php":          {phpInitContainerName, phpIniGeneratorName},
+	"python":       {pythonInitContainerName},
+	"ruby":         {rubyInitContainerName},
+	"sdk":          nil,
+}
+
+// injected returns the Instrumentations to inject, by language.
+func (langInsts languageInstrumentations) injected() map[string]*v1alpha1.Instrumentation {
+	injected := map[string]*v1alpha1.Instrumentation{}
+	for language, inst := range map[string]*v1alpha1.Instrumentation{
+		"apache-httpd": langInsts.ApacheHttpd.Instrumentation,
+		"dotnet":       langInsts.DotNet.Instrumentation,
+		"go":           langInsts.Go.Instrumentation,
+		"java":         langInsts.Java.Instrumentation,
+		"nginx":        langInsts.Nginx.Instrumentation,
+		"nodejs":       langInsts.NodeJS.Instrumentation,
+		"php":          langInsts.PHP.Instrumentation,
+		"python":       langInsts.Python.Instrumentation,
+		"ruby":         langInsts.Ruby.Instrumentation,
+		"sdk":          langInsts.Sdk.Instrumentation,
+	} {
+		if inst != nil {
+			injected[language] = inst
+		}
+	}
+	return injected
+}
+
+// recordInjected annotates the pod with the Instrumentations injected into it, for their status.
+func recordInjected(insts languageInstrumentations, pod corev1.Pod) corev1.Pod {
+	injected := insts.injected()
+	var entries []string
+	for _, language := range slices.Sorted(maps.Keys(injected)) {
+		inst := injected[language]
+		entries = append(entries, fmt.Sprintf("%s=%s/%s", language, inst.Namespace, inst.Name))
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[annotationInjected] = strings.Join(entries, ",")
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[InjectedLabel] = "true"
+	return pod
+}
+
+// InjectedInstrumentations returns the Instrumentations injected into the pod by the webhook, by language.
+func InjectedInstrumentations(pod corev1.Pod) map[string]types.NamespacedName {
+	injected := map[string]types.NamespacedName{}
+	for _, entry := range strings.Split(pod.Annotations[annotationInjected], ",") {
+		language, inst, found := strings.Cut(entry, "=")
+		if !found {
+			continue
+		}
+		namespace, name, found := strings.Cut(inst, "/")
+		if !found {
+			continue
+		}
+		injected[language] = types.NamespacedName{Namespace: namespace, Name: name}
+	}
+	return injected
+}
+
+// UpdateStatus sets the usage statistics and the Degraded condition of the instrumentation from the pods, the pods
+// it wasn't injected into are ignored.
+func UpdateStatus(inst *v1alpha1.Instrumentation, pods []corev1.Pod) {
+	key := types.NamespacedName{Namespace: inst.Namespace, Name: inst.Name}
+	languages := map[string]*v1alpha1.InstrumentationLanguageStatus{}
+	var failures []string
+	var failedPods int
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		var podFailures []string
+		for language, injectedInst := range InjectedInstrumentations(pod) {
+			if injectedInst != key {
+				continue
+			}
+			status, ok := languages[language]
+			if !ok {
+				status = &v1alpha1.InstrumentationLanguageStatus{Language: language}
+				languages[language] = status
+			}
+			status.InjectedPods++
+			if status.LastInjectionTime == nil || status.LastInjectionTime.Before(&pod.CreationTimestamp) {
+				created := pod.CreationTimestamp
+				status.LastInjectionTime = &created
+			}
+			if failure := containersFailure(pod, languageContainers[language]); failure != "" {
+				status.FailedPods++
+				podFailures = append(podFailures, fmt.Sprintf("%s: %s", language, failure))
+			}
+		}
+		if len(podFailures) > 0 {
+			failedPods++
+			if len(failures) < maxReportedFailures {
+				slices.Sort(podFailures)
+				failures = append(failures, fmt.Sprintf("%s/%s (%s)", pod.Namespace, pod.Name, strings.Join(podFailures, ", ")))
+			}
+		}
+	}
+
+	inst.Status.Languages = nil
+	for _, language := range slices.Sorted(maps.Keys(languages)) {
+		inst.Status.Languages = append(inst.Status.Languages, *languages[language])
+	}
+	inst.Status.ObservedGeneration = inst.Generation
+
+	condition := metav1.Condition{
+		Type:               v1alpha1.InstrumentationConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             v1alpha1.InstrumentationReasonInjectionSucceeded,
+		Message:            "the auto-instrumentation containers of the injected pods don't fail",
+		ObservedGeneration: inst.Generation,
+	}
+	if failedPods > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = v1alpha1.InstrumentationReasonInjectionFailed
+		condition.Message = fmt.Sprintf("the auto-instrumentation containers of %d pods fail: %s", failedPods, strings.Join(failures, "; "))
+	}
+	meta.SetStatusCondition(&inst.Status.Conditions, condition)
+}
+
+// containersFailure describes the failure of the first failing container with one of the names, if any.
+func containersFailure(pod corev1.Pod, names []string) string {
+	statuses := append(slices.Clone(pod.Status.InitContainerStatuses), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if !slices.Contains(names, status.Name) {
+			continue
+		}
+		switch {
+		case status.State.Waiting != nil && !slices.Contains([]string{"", "ContainerCreating", "PodInitializing"}, status.State.Waiting.Reason):
+			return fmt.Sprintf("%s %s%s", status.Name, status.State.Waiting.Reason, terminationMessage(status.LastTerminationState.Terminated))
+		case status.State.Terminated != nil && status.State.Terminated.ExitCode != 0:
+			return fmt.Sprintf("%s exited with %d%s", status.Name, status.State.Terminated.ExitCode, terminationMessage(status.State.Terminated))
+		}
+	}
+	return ""
+}
+
+// terminationMessage returns the last line of the termination message of a container, e.g. the error logged by the
+// Go agent when the kernel lacks the eBPF features it needs, prefixed to follow the failure.
+func terminationMessage(terminated *corev1.ContainerStateTerminated) string {
+	if terminated == nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(terminated.Message), "\n")
+	message := strings.TrimSpace(lines[len(lines)-1])
+	if message == "" {
+		return ""
+	}
+	if len(message) > maxTerminationMessageLength {
+		message = message[:maxTerminationMessageLength] + "..."
+	}
+	return ": " + message
+}
@@ -0,0 +1,149 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package instrumentation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+)
+
+func TestRecordInjected(t *testing.T) {
+	inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "apps"}}
+	other := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "shared"}}
+	insts := languageInstrumentations{
+		Python: instrumentationWithContainers{Instrumentation: inst},
+		Java:   instrumentationWithContainers{Instrumentation: inst},
+		Sdk:    instrumentationWithContainers{Instrumentation: other},
+	}
+
+	pod := recordInjected(insts, corev1.Pod{})
+
+	assert.Equal(t, "java=apps/my-inst,python=apps/my-inst,sdk=shared/other", pod.Annotations[annotationInjected])
+	assert.Equal(t, "true", pod.Labels[InjectedLabel])
+	assert.Equal(t, map[string]types.NamespacedName{
+		"java":   {Namespace: "apps", Name: "my-inst"},
+		"python": {Namespace: "apps", Name: "my-inst"},
+		"sdk":    {Namespace: "shared", Name: "other"},
+	}, InjectedInstrumentations(pod))
+}
+
+func TestInjectedInstrumentations(t *testing.T) {
+	assert.Empty(t, InjectedInstrumentations(corev1.Pod{}))
+	assert.Equal(t, map[string]types.NamespacedName{
+		"go": {Namespace: "apps", Name: "my-inst"},
+	}, InjectedInstrumentations(corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		annotationInjected: "go=apps/my-inst,java,nodejs=my-inst",
+	}}}))
+}
+
+func TestUpdateStatus(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(earlier.Add(time.Hour))
+	injectedPod := func(name string, created metav1.Time, injected string, statuses ...corev1.ContainerStatus) corev1.Pod {
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "apps",
+				CreationTimestamp: created,
+				Annotations:       map[string]string{annotationInjected: injected},
+			},
+			Status: corev1.PodStatus{InitContainerStatuses: statuses},
+		}
+	}
+
+	for _, tt := range []struct {
+		name      string
+		pods      []corev1.Pod
+		languages []v1alpha1.InstrumentationLanguageStatus
+		degraded  metav1.ConditionStatus
+		message   string
+	}{
+		{
+			name:     "no injected pods",
+			pods:     []corev1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "apps"}}},
+			degraded: metav1.ConditionFalse,
+			message:  "the auto-instrumentation containers of the injected pods don't fail",
+		},
+		{
+			name: "injected pods",
+			pods: []corev1.Pod{
+				injectedPod("app-1", earlier, "java=apps/my-inst,python=apps/my-inst",
+					corev1.ContainerStatus{Name: javaInitContainerName, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}},
+					corev1.ContainerStatus{Name: pythonInitContainerName, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}},
+				),
+				injectedPod("app-2", later, "java=apps/my-inst"),
+				injectedPod("other", later, "python=apps/other-inst"),
+			},
+			languages: []v1alpha1.InstrumentationLanguageStatus{
+				{Language: "java", InjectedPods: 2, LastInjectionTime: &later},
+				{Language: "python", InjectedPods: 1, LastInjectionTime: &earlier},
+			},
+			degraded: metav1.ConditionFalse,
+			message:  "the auto-instrumentation containers of the injected pods don't fail",
+		},
+		{
+			name: "failing init containers",
+			pods: []corev1.Pod{
+				injectedPod("app-1", earlier, "java=apps/my-inst",
+					corev1.ContainerStatus{Name: javaInitContainerName, State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}}},
+				),
+				injectedPod("app-2", earlier, "php=apps/my-inst",
+					corev1.ContainerStatus{Name: phpInitContainerName, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}},
+					corev1.ContainerStatus{Name: phpIniGeneratorName, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 127}}},
+				),
+				injectedPod("app-3", later, "java=apps/my-inst"),
+			},
+			languages: []v1alpha1.InstrumentationLanguageStatus{
+				{Language: "java", InjectedPods: 2, FailedPods: 1, LastInjectionTime: &later},
+				{Language: "php", InjectedPods: 1, FailedPods: 1, LastInjectionTime: &earlier},
+			},
+			degraded: metav1.ConditionTrue,
+			message: "the auto-instrumentation containers of 2 pods fail: apps/app-1 (java: opentelemetry-auto-instrumentation-java ImagePullBackOff); " +
+				"apps/app-2 (php: opentelemetry-auto-instrumentation-php-ini exited with 127)",
+		},
+		{
+			name: "failing go agent",
+			pods: []corev1.Pod{
+				injectedPod("app-1", earlier, "go=apps/my-inst",
+					corev1.ContainerStatus{
+						Name:  sideCarName,
+						State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+						LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  "starting the instrumentation\nfailed to load the eBPF programs: kernel 4.14 isn't supported\n",
+						}},
+					},
+				),
+			},
+			languages: []v1alpha1.InstrumentationLanguageStatus{
+				{Language: "go", InjectedPods: 1, FailedPods: 1, LastInjectionTime: &earlier},
+			},
+			degraded: metav1.ConditionTrue,
+			message: "the auto-instrumentation containers of 1 pods fail: apps/app-1 (go: opentelemetry-auto-instrumentation CrashLoopBackOff: " +
+				"failed to load the eBPF programs: kernel 4.14 isn't supported)",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			inst := &v1alpha1.Instrumentation{ObjectMeta: metav1.ObjectMeta{Name: "my-inst", Namespace: "apps", Generation: 3}}
+
+			UpdateStatus(inst, tt.pods)
+
+			assert.Equal(t, tt.languages, inst.Status.Languages)
+			assert.Equal(t, int64(3), inst.Status.ObservedGeneration)
+			condition := meta.FindStatusCondition(inst.Status.Conditions, v1alpha1.InstrumentationConditionDegraded)
+			require.NotNil(t, condition)
+			assert.Equal(t, tt.degraded, condition.Status)
+			assert.Equal(t, tt.message, condition.Message)
+		})
+	}
+}
@@ -33,6 +33,8 @@ type InstrumentationUpgrade struct {
 	DefaultAutoInstApacheHttpd string
 	DefaultAutoInstNginx       string
 	DefaultAutoInstGo          string
+	DefaultAutoInstPHP         string
+	DefaultAutoInstRuby        string
 	defaultAnnotationToConfig  map[string]autoInstConfig
 }
 
@@ -45,6 +47,8 @@ func NewInstrumentationUpgrade(client client.Client, logger logr.Logger, recorde
 		constants.AnnotationDefaultAutoInstrumentationPython:      {constants.FlagPython, cfg.EnablePythonAutoInstrumentation},
 		constants.AnnotationDefaultAutoInstrumentationNodeJS:      {constants.FlagNodeJS, cfg.EnableNodeJSAutoInstrumentation},
 		constants.AnnotationDefaultAutoInstrumentationJava:        {constants.FlagJava, cfg.EnableJavaAutoInstrumentation},
+		constants.AnnotationDefaultAutoInstrumentationPHP:         {constants.FlagPHP, cfg.EnablePHPAutoInstrumentation},
+		constants.AnnotationDefaultAutoInstrumentationRuby:        {constants.FlagRuby, cfg.EnableRubyAutoInstrumentation},
 	}
 
 	return &InstrumentationUpgrade{
@@ -57,6 +61,8 @@ func NewInstrumentationUpgrade(client client.Client, logger logr.Logger, recorde
 		DefaultAutoInstGo:          cfg.AutoInstrumentationGoImage,
 		DefaultAutoInstApacheHttpd: cfg.AutoInstrumentationApacheHttpdImage,
 		DefaultAutoInstNginx:       cfg.AutoInstrumentationNginxImage,
+		DefaultAutoInstPHP:         cfg.AutoInstrumentationPHPImage,
+		DefaultAutoInstRuby:        cfg.AutoInstrumentationRubyImage,
 		Recorder:                   recorder,
 		defaultAnnotationToConfig:  defaultAnnotationToConfig,
 	}
@@ -100,38 +106,48 @@ func (u *InstrumentationUpgrade) upgrade(_ context.Context, inst v1alpha1.Instru
 				switch annotation {
 				case constants.AnnotationDefaultAutoInstrumentationApacheHttpd:
 					if inst.Spec.ApacheHttpd.Image == autoInst {
-						upgraded.Spec.ApacheHttpd.Image = u.DefaultAutoInstApacheHttpd
-						upgraded.Annotations[annotation] = u.DefaultAutoInstApacheHttpd
+						upgraded.Spec.ApacheHttpd.Image = inst.Spec.ImageChannel.Image(u.DefaultAutoInstApacheHttpd)
+						upgraded.Annotations[annotation] = inst.Spec.ImageChannel.Image(u.DefaultAutoInstApacheHttpd)
 					}
 				case constants.AnnotationDefaultAutoInstrumentationDotNet:
 					if inst.Spec.DotNet.Image == autoInst {
-						upgraded.Spec.DotNet.Image = u.DefaultAutoInstDotNet
-						upgraded.Annotations[annotation] = u.DefaultAutoInstDotNet
+						upgraded.Spec.DotNet.Image = inst.Spec.ImageChannel.Image(u.DefaultAutoInstDotNet)
+						upgraded.Annotations[annotation] = inst.Spec.ImageChannel.Image(u.DefaultAutoInstDotNet)
 					}
 				case constants.AnnotationDefaultAutoInstrumentationGo:
 					if inst.Spec.Go.Image == autoInst {
-						upgraded.Spec.Go.Image = u.DefaultAutoInstGo
-						upgraded.Annotations[annotation] = u.DefaultAutoInstGo
+						upgraded.Spec.Go.Image = inst.Spec.ImageChannel.Image(u.DefaultAutoInstGo)
+						upgraded.Annotations[annotation] = inst.Spec.ImageChannel.Image(u.DefaultAutoInstGo)
 					}
 				case constants.AnnotationDefaultAutoInstrumentationNginx:
 					if inst.Spec.Nginx.Image == autoInst {
-						upgraded.Spec.Nginx.Image = u.DefaultAutoInstNginx
-						upgraded.Annotations[annotation] = u.DefaultAutoInstNginx
+						upgraded.Spec.Nginx.Image = inst.Spec.ImageChannel.Image(u.DefaultAutoInstNginx)
+						upgraded.Annotations[annotation] = inst.Spec.ImageChannel.Image(u.DefaultAutoInstNginx)
 					}
 				case constants.AnnotationDefaultAutoInstrumentationPython:
 					if inst.Spec.Python.Image == autoInst {
-						upgraded.Spec.Python.Image = u.DefaultAutoInstPython
-						upgraded.Annotations[annotation] = u.DefaultAutoInstPython
+						upgraded.Spec.Python.Image = inst.Spec.ImageChannel.Image(u.DefaultAutoInstPython)
+						upgraded.Annotations[annotation] = inst.Spec.ImageChannel.Image(u.DefaultAutoInstPython)
 					}
 				case constants.AnnotationDefaultAutoInstrumentationNodeJS:
 					if inst.Spec.NodeJS.Image == autoInst {
-						upgraded.Spec.NodeJS.Image = u.DefaultAutoInstNodeJS
-						upgraded.Annotations[annotation] = u.DefaultAutoInstNodeJS
+						upgraded.Spec.NodeJS.Image = inst.Spec.ImageChannel.Image(u.DefaultAutoInstNodeJS)
+						upgraded.Annotations[annotation] = inst.Spec.ImageChannel.Image(u.DefaultAutoInstNodeJS)
 					}
 				case constants.AnnotationDefaultAutoInstrumentationJava:
 					if inst.Spec.Java.Image == autoInst {
-						upgraded.Spec.Java.Image = u.DefaultAutoInstJava
-						upgraded.Annotations[annotation] = u.DefaultAutoInstJava
+						upgraded.Spec.Java.Image = inst.Spec.ImageChannel.Image(u.DefaultAutoInstJava)
+						upgraded.Annotations[annotation] = inst.Spec.ImageChannel.Image(u.DefaultAutoInstJava)
+					}
+				case constants.AnnotationDefaultAutoInstrumentationPHP:
+					if inst.Spec.PHP.Image == autoInst {
+						upgraded.Spec.PHP.Image = inst.Spec.ImageChannel.Image(u.DefaultAutoInstPHP)
+						upgraded.Annotations[annotation] = inst.Spec.ImageChannel.Image(u.DefaultAutoInstPHP)
+					}
+				case constants.AnnotationDefaultAutoInstrumentationRuby:
+					if inst.Spec.Ruby.Image == autoInst {
+						upgraded.Spec.Ruby.Image = inst.Spec.ImageChannel.Image(u.DefaultAutoInstRuby)
+						upgraded.Annotations[annotation] = inst.Spec.ImageChannel.Image(u.DefaultAutoInstRuby)
 					}
 				}
 
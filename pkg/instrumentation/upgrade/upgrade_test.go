@@ -45,7 +45,7 @@ func TestUpgrade(t *testing.T) {
 	err = v1alpha1.NewInstrumentationWebhook(
 		logr.Discard(),
 		testScheme,
-		config.New(
+		config.NewProvider(config.New(
 			config.WithAutoInstrumentationJavaImage("java:1"),
 			config.WithAutoInstrumentationNodeJSImage("nodejs:1"),
 			config.WithAutoInstrumentationPythonImage("python:1"),
@@ -53,6 +53,8 @@ func TestUpgrade(t *testing.T) {
 			config.WithAutoInstrumentationGoImage("go:1"),
 			config.WithAutoInstrumentationApacheHttpdImage("apache-httpd:1"),
 			config.WithAutoInstrumentationNginxImage("nginx:1"),
+			config.WithAutoInstrumentationPHPImage("php:1"),
+			config.WithAutoInstrumentationRubyImage("ruby:1"),
 			config.WithEnableApacheHttpdInstrumentation(true),
 			config.WithEnableDotNetInstrumentation(true),
 			config.WithEnableGoInstrumentation(true),
@@ -60,7 +62,9 @@ func TestUpgrade(t *testing.T) {
 			config.WithEnablePythonInstrumentation(true),
 			config.WithEnableNodeJSInstrumentation(true),
 			config.WithEnableJavaInstrumentation(true),
-		),
+			config.WithEnablePHPInstrumentation(true),
+			config.WithEnableRubyInstrumentation(true),
+		)),
 	).Default(context.Background(), inst)
 	assert.Nil(t, err)
 	assert.Equal(t, "java:1", inst.Spec.Java.Image)
@@ -70,6 +74,8 @@ func TestUpgrade(t *testing.T) {
 	assert.Equal(t, "go:1", inst.Spec.Go.Image)
 	assert.Equal(t, "apache-httpd:1", inst.Spec.ApacheHttpd.Image)
 	assert.Equal(t, "nginx:1", inst.Spec.Nginx.Image)
+	assert.Equal(t, "php:1", inst.Spec.PHP.Image)
+	assert.Equal(t, "ruby:1", inst.Spec.Ruby.Image)
 	err = k8sClient.Create(context.Background(), inst)
 	require.NoError(t, err)
 
@@ -81,6 +87,8 @@ func TestUpgrade(t *testing.T) {
 		config.WithAutoInstrumentationGoImage("go:2"),
 		config.WithAutoInstrumentationApacheHttpdImage("apache-httpd:2"),
 		config.WithAutoInstrumentationNginxImage("nginx:2"),
+		config.WithAutoInstrumentationPHPImage("php:2"),
+		config.WithAutoInstrumentationRubyImage("ruby:2"),
 		config.WithEnableApacheHttpdInstrumentation(true),
 		config.WithEnableDotNetInstrumentation(true),
 		config.WithEnableGoInstrumentation(true),
@@ -88,6 +96,8 @@ func TestUpgrade(t *testing.T) {
 		config.WithEnablePythonInstrumentation(true),
 		config.WithEnableNodeJSInstrumentation(true),
 		config.WithEnableJavaInstrumentation(true),
+		config.WithEnablePHPInstrumentation(true),
+		config.WithEnableRubyInstrumentation(true),
 	)
 	up := NewInstrumentationUpgrade(k8sClient, ctrl.Log.WithName("instrumentation-upgrade"), &record.FakeRecorder{}, cfg)
 
@@ -114,4 +124,8 @@ func TestUpgrade(t *testing.T) {
 	assert.Equal(t, "apache-httpd:2", updated.Spec.ApacheHttpd.Image)
 	assert.Equal(t, "nginx:2", updated.Annotations[constants.AnnotationDefaultAutoInstrumentationNginx])
 	assert.Equal(t, "nginx:2", updated.Spec.Nginx.Image)
+	assert.Equal(t, "php:2", updated.Annotations[constants.AnnotationDefaultAutoInstrumentationPHP])
+	assert.Equal(t, "ruby:2", updated.Annotations[constants.AnnotationDefaultAutoInstrumentationRuby])
+	assert.Equal(t, "php:2", updated.Spec.PHP.Image)
+	assert.Equal(t, "ruby:2", updated.Spec.Ruby.Image)
 }
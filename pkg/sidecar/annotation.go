@@ -12,9 +12,24 @@ import (
 const (
 	// Annotation contains the annotation name that pods contain, indicating whether a sidecar is desired.
 	Annotation = "sidecar.opentelemetry.io/inject"
+
+	// OTLPEnvAnnotation contains the annotation name that pods or namespaces contain, indicating whether the
+	// application containers should be configured to export to the sidecar. The value is either "true", "grpc" or
+	// "http/protobuf", "true" preferring grpc when the sidecar receives both.
+	OTLPEnvAnnotation = "sidecar.opentelemetry.io/inject-otlp-env"
+
+	// CPURequestAnnotation, CPULimitAnnotation, MemoryRequestAnnotation and MemoryLimitAnnotation contain the
+	// annotation names that pods contain, overriding the resource requests and limits of the sidecar set by the
+	// OpenTelemetryCollector.
+	CPURequestAnnotation    = "sidecar.opentelemetry.io/cpu-request"
+	CPULimitAnnotation      = "sidecar.opentelemetry.io/cpu-limit"
+	MemoryRequestAnnotation = "sidecar.opentelemetry.io/memory-request"
+	MemoryLimitAnnotation   = "sidecar.opentelemetry.io/memory-limit"
 )
 
-// annotationValue returns the effective annotation value, based on the annotations from the pod and namespace.
+// annotationValue returns the effective annotation value, based on the annotations from the pod and namespace. A
+// collector named by the pod takes precedence over one named by the namespace. When the effective value is "true",
+// the sole sidecar collector of the namespace is selected or else the default sidecar collector of the operator.
 func annotationValue(ns corev1.Namespace, pod corev1.Pod) string {
 	// is the pod annotated with instructions to inject sidecars? is the namespace annotated?
 	// if any of those is true, a sidecar might be desired.
@@ -45,3 +60,12 @@ func annotationValue(ns corev1.Namespace, pod corev1.Pod) string {
 	// so, the namespace annotation can be used
 	return nsAnnValue
 }
+
+// otlpEnvAnnotationValue returns the effective value of the OTLPEnvAnnotation, the pod annotation taking precedence
+// over the namespace one.
+func otlpEnvAnnotationValue(ns corev1.Namespace, pod corev1.Pod) string {
+	if podAnnValue, ok := pod.Annotations[OTLPEnvAnnotation]; ok {
+		return podAnnValue
+	}
+	return ns.Annotations[OTLPEnvAnnotation]
+}
@@ -129,3 +129,23 @@ func TestEffectiveAnnotationValue(t *testing.T) {
 		})
 	}
 }
+
+func TestOTLPEnvAnnotationValue(t *testing.T) {
+	for _, tt := range []struct {
+		desc     string
+		expected string
+		pod      map[string]string
+		ns       map[string]string
+	}{
+		{desc: "none"},
+		{desc: "pod", expected: "grpc", pod: map[string]string{OTLPEnvAnnotation: "grpc"}},
+		{desc: "namespace", expected: "true", ns: map[string]string{OTLPEnvAnnotation: "true"}},
+		{desc: "pod-overrides-ns", expected: "false", pod: map[string]string{OTLPEnvAnnotation: "false"}, ns: map[string]string{OTLPEnvAnnotation: "true"}},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: tt.pod}}
+			ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Annotations: tt.ns}}
+			assert.Equal(t, tt.expected, otlpEnvAnnotationValue(ns, pod))
+		})
+	}
+}
@@ -7,20 +7,27 @@ package sidecar
 import (
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/manifests/collector"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/constants"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
 )
 
 const (
 	injectedLabel = "sidecar.opentelemetry.io/injected"
 	confEnvVar    = "OTEL_CONFIG"
+
+	otlpProtocolGRPC = "grpc"
+	otlpProtocolHTTP = "http/protobuf"
 )
 
 // add a new sidecar container to the given pod, based on the given OpenTelemetryCollector.
@@ -32,6 +39,7 @@ func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCol
 
 	container := collector.Container(cfg, logger, otelcol, false)
 	container.Args = append(container.Args, fmt.Sprintf("--config=env:%s", confEnvVar))
+	container.Resources = overrideResources(logger, pod, container.Resources)
 
 	container.Env = append(container.Env, corev1.EnvVar{Name: confEnvVar, Value: otelColCfg})
 	if !hasResourceAttributeEnvVar(container.Env) {
@@ -39,7 +47,7 @@ func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCol
 	}
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, otelcol.Spec.InitContainers...)
 
-	if featuregate.EnableNativeSidecarContainers.IsEnabled() {
+	if useNativeSidecar(cfg) {
 		policy := corev1.ContainerRestartPolicyAlways
 		container.RestartPolicy = &policy
 		// NOTE: Use ReadinessProbe as startup probe.
@@ -61,6 +69,72 @@ func add(cfg config.Config, logger logr.Logger, otelcol v1beta1.OpenTelemetryCol
 
 func isOtelColContainer(c corev1.Container) bool { return c.Name == naming.Container() }
 
+// overrideResources returns the given resources of the sidecar with the requests and limits set by the annotations of
+// the given pod. Invalid values are ignored, as are the overrides of a resource leaving its request above its limit.
+func overrideResources(logger logr.Logger, pod corev1.Pod, resources corev1.ResourceRequirements) corev1.ResourceRequirements {
+	overridden := *resources.DeepCopy()
+	for _, override := range []struct {
+		annotation string
+		name       corev1.ResourceName
+		limit      bool
+	}{
+		{CPURequestAnnotation, corev1.ResourceCPU, false},
+		{CPULimitAnnotation, corev1.ResourceCPU, true},
+		{MemoryRequestAnnotation, corev1.ResourceMemory, false},
+		{MemoryLimitAnnotation, corev1.ResourceMemory, true},
+	} {
+		value, ok := pod.Annotations[override.annotation]
+		if !ok {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			logger.Error(err, "invalid sidecar resource annotation, ignoring it", "annotation", override.annotation, "value", value)
+			continue
+		}
+		list := &overridden.Requests
+		if override.limit {
+			list = &overridden.Limits
+		}
+		if *list == nil {
+			*list = corev1.ResourceList{}
+		}
+		(*list)[override.name] = quantity
+	}
+
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		request, hasRequest := overridden.Requests[name]
+		limit, hasLimit := overridden.Limits[name]
+		if !hasLimit {
+			continue
+		}
+		// the request defaults to the limit when it isn't set
+		if !hasRequest || request.Cmp(limit) <= 0 {
+			continue
+		}
+		logger.Error(fmt.Errorf("the %s request %s of the sidecar is above its limit %s", name, request.String(), limit.String()), "invalid sidecar resource annotations, ignoring them")
+		restore(overridden.Requests, resources.Requests, name)
+		restore(overridden.Limits, resources.Limits, name)
+	}
+	return overridden
+}
+
+// restore sets the given resource of the overridden list back to its original value.
+func restore(overridden, original corev1.ResourceList, name corev1.ResourceName) {
+	if quantity, ok := original[name]; ok {
+		overridden[name] = quantity
+	} else {
+		delete(overridden, name)
+	}
+}
+
+// useNativeSidecar returns whether the sidecar is injected as a native sidecar container, which starts before and
+// stops after the application containers. It's the case on clusters supporting them, unless the feature gate is
+// disabled.
+func useNativeSidecar(cfg config.Config) bool {
+	return featuregate.EnableNativeSidecarContainers.IsEnabled() && cfg.NativeSidecarAvailability == nativesidecar.Available
+}
+
 // remove the sidecar container from the given pod.
 func remove(pod corev1.Pod) corev1.Pod {
 	if !existsIn(pod) {
@@ -68,11 +142,86 @@ func remove(pod corev1.Pod) corev1.Pod {
 	}
 
 	pod.Spec.Containers = slices.DeleteFunc(pod.Spec.Containers, isOtelColContainer)
+	// NOTE: we also remove init containers (native sidecars), whether or not they're currently in use, as the pod
+	// might have been injected before the cluster or the operator configuration changed.
+	// This should have no side effects.
+	pod.Spec.InitContainers = slices.DeleteFunc(pod.Spec.InitContainers, isOtelColContainer)
+	return pod
+}
 
-	if featuregate.EnableNativeSidecarContainers.IsEnabled() {
-		// NOTE: we also remove init containers (native sidecars) since k8s 1.28.
-		// This should have no side effects.
-		pod.Spec.InitContainers = slices.DeleteFunc(pod.Spec.InitContainers, isOtelColContainer)
+// containerPorts returns the ports exposed by the sidecar container of the given pod.
+func containerPorts(pod corev1.Pod) []int32 {
+	var ports []int32
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if !isOtelColContainer(c) {
+			continue
+		}
+		for _, p := range c.Ports {
+			ports = append(ports, p.ContainerPort)
+		}
+	}
+	return ports
+}
+
+// otlpEndpoint returns the endpoint and the protocol of the OTLP receiver of the sidecar of the given pod, using the
+// protocol preferred by the OTLPEnvAnnotation value when the receiver serves both.
+func otlpEndpoint(pod corev1.Pod, annValue string) (string, string, bool) {
+	ports := map[string]int32{}
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if !isOtelColContainer(c) {
+			continue
+		}
+		for _, p := range c.Ports {
+			if !strings.HasPrefix(p.Name, "otlp") {
+				continue
+			}
+			// the ports of the otlp receivers are named after the receiver and the protocol, e.g. otlp-grpc
+			if strings.HasSuffix(p.Name, "-grpc") && ports[otlpProtocolGRPC] == 0 {
+				ports[otlpProtocolGRPC] = p.ContainerPort
+			} else if strings.HasSuffix(p.Name, "-http") && ports[otlpProtocolHTTP] == 0 {
+				ports[otlpProtocolHTTP] = p.ContainerPort
+			}
+		}
+	}
+
+	protocols := []string{otlpProtocolGRPC, otlpProtocolHTTP}
+	if strings.EqualFold(annValue, otlpProtocolHTTP) {
+		protocols = []string{otlpProtocolHTTP, otlpProtocolGRPC}
+	}
+	for _, protocol := range protocols {
+		if port, ok := ports[protocol]; ok {
+			return fmt.Sprintf("http://localhost:%d", port), protocol, true
+		}
+	}
+	return "", "", false
+}
+
+// injectOTLPEnv configures the application containers of the given pod to export to the OTLP receiver of the sidecar,
+// without overriding the variables they already set.
+func injectOTLPEnv(logger logr.Logger, pod corev1.Pod, annValue string, attributes []corev1.EnvVar) corev1.Pod {
+	endpoint, protocol, ok := otlpEndpoint(pod, annValue)
+	if !ok {
+		logger.V(1).Info("the sidecar has no otlp receiver port, skipping the injection of the otlp environment variables")
+		return pod
+	}
+
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if isOtelColContainer(*c) {
+			continue
+		}
+		env := []corev1.EnvVar{
+			{Name: constants.EnvOTELExporterOTLPEndpoint, Value: endpoint},
+			{Name: constants.EnvOTELExporterOTLPProtocol, Value: protocol},
+		}
+		if !hasResourceAttributeEnvVar(c.Env) {
+			env = append(env, attributes...)
+		}
+		for _, e := range env {
+			if !slices.ContainsFunc(c.Env, func(existing corev1.EnvVar) bool { return existing.Name == e.Name }) {
+				c.Env = append(c.Env, e)
+			}
+		}
 	}
 	return pod
 }
@@ -83,12 +232,7 @@ func existsIn(pod corev1.Pod) bool {
 		return true
 	}
 
-	if featuregate.EnableNativeSidecarContainers.IsEnabled() {
-		// NOTE: we also check init containers (native sidecars) since k8s 1.28.
-		// This should have no side effects.
-		if slices.ContainsFunc(pod.Spec.InitContainers, isOtelColContainer) {
-			return true
-		}
-	}
-	return false
+	// NOTE: we also check init containers (native sidecars), whether or not they're currently in use.
+	// This should have no side effects.
+	return slices.ContainsFunc(pod.Spec.InitContainers, isOtelColContainer)
 }
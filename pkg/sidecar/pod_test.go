@@ -10,10 +10,12 @@ import (
 	"github.com/stretchr/testify/require"
 	colfeaturegate "go.opentelemetry.io/collector/featuregate"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/nativesidecar"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
 	"github.com/open-telemetry/opentelemetry-operator/internal/naming"
 	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
@@ -21,17 +23,17 @@ import (
 
 var logger = logf.Log.WithName("unit-tests")
 
-func enableSidecarFeatureGate(t *testing.T) {
+func setSidecarFeatureGate(t *testing.T, enabled bool) {
 	originalVal := featuregate.EnableNativeSidecarContainers.IsEnabled()
 	t.Logf("original is: %+v", originalVal)
-	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableNativeSidecarContainers.ID(), true))
+	require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableNativeSidecarContainers.ID(), enabled))
 	t.Cleanup(func() {
 		require.NoError(t, colfeaturegate.GlobalRegistry().Set(featuregate.EnableNativeSidecarContainers.ID(), originalVal))
 	})
 }
 
 func TestAddNativeSidecar(t *testing.T) {
-	enableSidecarFeatureGate(t)
+	setSidecarFeatureGate(t, true)
 	// prepare
 	pod := corev1.Pod{
 		Spec: corev1.PodSpec{
@@ -67,7 +69,10 @@ func TestAddNativeSidecar(t *testing.T) {
 
 	otelcolYaml, err := otelcol.Spec.Config.Yaml()
 	require.NoError(t, err)
-	cfg := config.New(config.WithCollectorImage("some-default-image"))
+	cfg := config.New(
+		config.WithCollectorImage("some-default-image"),
+		config.WithNativeSidecarAvailability(nativesidecar.Available),
+	)
 
 	// test
 	changed, err := add(cfg, logger, otelcol, pod, nil)
@@ -238,6 +243,7 @@ func TestRemoveSidecar(t *testing.T) {
 
 	// verify
 	assert.Len(t, changed.Spec.Containers, 1)
+	assert.Len(t, changed.Spec.InitContainers, 1)
 }
 
 func TestRemoveNonExistingSidecar(t *testing.T) {
@@ -258,8 +264,6 @@ func TestRemoveNonExistingSidecar(t *testing.T) {
 }
 
 func TestExistsIn(t *testing.T) {
-	enableSidecarFeatureGate(t)
-
 	for _, tt := range []struct {
 		desc     string
 		pod      corev1.Pod
@@ -303,6 +307,38 @@ func TestExistsIn(t *testing.T) {
 	}
 }
 
+func TestUseNativeSidecar(t *testing.T) {
+	for _, tt := range []struct {
+		desc         string
+		gate         bool
+		availability nativesidecar.Availability
+		expected     bool
+	}{
+		{desc: "supported", gate: true, availability: nativesidecar.Available, expected: true},
+		{desc: "not supported", gate: true, availability: nativesidecar.NotAvailable, expected: false},
+		{desc: "gate disabled", gate: false, availability: nativesidecar.Available, expected: false},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			setSidecarFeatureGate(t, tt.gate)
+			cfg := config.New(config.WithNativeSidecarAvailability(tt.availability))
+
+			assert.Equal(t, tt.expected, useNativeSidecar(cfg))
+
+			changed, err := add(cfg, logger, v1beta1.OpenTelemetryCollector{}, corev1.Pod{}, nil)
+			require.NoError(t, err)
+			assert.True(t, existsIn(changed))
+			if tt.expected {
+				assert.Empty(t, changed.Spec.Containers)
+				require.Len(t, changed.Spec.InitContainers, 1)
+				assert.Equal(t, corev1.ContainerRestartPolicyAlways, *changed.Spec.InitContainers[0].RestartPolicy)
+			} else {
+				assert.Empty(t, changed.Spec.InitContainers)
+				assert.Len(t, changed.Spec.Containers, 1)
+			}
+		})
+	}
+}
+
 func TestAddSidecarWithAditionalEnv(t *testing.T) {
 	// prepare
 	pod := corev1.Pod{
@@ -336,3 +372,115 @@ func TestAddSidecarWithAditionalEnv(t *testing.T) {
 	assert.Contains(t, changed.Spec.Containers[1].Env, extraEnv)
 
 }
+
+func TestAddSidecarWithResourceOverrides(t *testing.T) {
+	otelcol := v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "otelcol-sample",
+			Namespace: "some-app",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("200m"),
+						corev1.ResourceMemory: resource.MustParse("128Mi"),
+					},
+				},
+			},
+		},
+	}
+	cfg := config.New(config.WithCollectorImage("some-default-image"))
+
+	for _, tt := range []struct {
+		desc        string
+		annotations map[string]string
+		expected    corev1.ResourceRequirements
+	}{
+		{
+			desc:     "no override",
+			expected: otelcol.Spec.Resources,
+		},
+		{
+			desc: "all overridden",
+			annotations: map[string]string{
+				CPURequestAnnotation:    "500m",
+				CPULimitAnnotation:      "1",
+				MemoryRequestAnnotation: "256Mi",
+				MemoryLimitAnnotation:   "512Mi",
+			},
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+			},
+		},
+		{
+			desc: "invalid value",
+			annotations: map[string]string{
+				CPULimitAnnotation:    "lots",
+				MemoryLimitAnnotation: "256Mi",
+			},
+			expected: corev1.ResourceRequirements{
+				Requests: otelcol.Spec.Resources.Requests,
+				Limits: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("200m"),
+					corev1.ResourceMemory: resource.MustParse("256Mi"),
+				},
+			},
+		},
+		{
+			desc: "request above the limit",
+			annotations: map[string]string{
+				CPURequestAnnotation:    "500m",
+				MemoryRequestAnnotation: "96Mi",
+			},
+			expected: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("100m"),
+					corev1.ResourceMemory: resource.MustParse("96Mi"),
+				},
+				Limits: otelcol.Spec.Resources.Limits,
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "my-app"}}},
+			}
+
+			// test
+			changed, err := add(cfg, logger, otelcol, pod, nil)
+
+			// verify
+			require.NoError(t, err)
+			require.Len(t, changed.Spec.Containers, 2)
+			assert.Equal(t, tt.expected, changed.Spec.Containers[1].Resources)
+			// the resources of the collector are left as is
+			assert.Equal(t, resource.MustParse("200m"), otelcol.Spec.Resources.Limits[corev1.ResourceCPU])
+		})
+	}
+}
+
+func TestContainerPorts(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "my-app", Ports: []corev1.ContainerPort{{ContainerPort: 8080}}},
+				{Name: naming.Container(), Ports: []corev1.ContainerPort{{ContainerPort: 4317}, {ContainerPort: 4318}}},
+			},
+		},
+	}
+
+	assert.Equal(t, []int32{4317, 4318}, containerPorts(pod))
+}
@@ -6,17 +6,21 @@ package sidecar
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	autoIstio "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/istio"
 	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/istio"
 	"github.com/open-telemetry/opentelemetry-operator/internal/webhook/podmutation"
 )
 
@@ -29,12 +33,12 @@ var (
 type sidecarPodMutator struct {
 	client client.Client
 	logger logr.Logger
-	config config.Config
+	config *config.Provider
 }
 
 var _ podmutation.PodMutator = (*sidecarPodMutator)(nil)
 
-func NewMutator(logger logr.Logger, config config.Config, client client.Client) *sidecarPodMutator {
+func NewMutator(logger logr.Logger, config *config.Provider, client client.Client) *sidecarPodMutator {
 	return &sidecarPodMutator{
 		config: config,
 		logger: logger,
@@ -86,15 +90,36 @@ func (p *sidecarPodMutator) Mutate(ctx context.Context, ns corev1.Namespace, pod
 	// we should add the sidecar.
 	logger.V(1).Info("injecting sidecar into pod", "otelcol-namespace", otelcol.Namespace, "otelcol-name", otelcol.Name)
 
-	return add(p.config, p.logger, otelcol, pod, attributes)
+	pod, err = add(p.config.Config(), p.logger, otelcol, pod, attributes)
+	if err != nil {
+		return pod, err
+	}
+
+	if otlpEnv := otlpEnvAnnotationValue(ns, pod); otlpEnv != "" && !strings.EqualFold(otlpEnv, "false") {
+		logger.V(1).Info("configuring the application containers to export to the sidecar")
+		pod = injectOTLPEnv(logger, pod, otlpEnv, attributes)
+	}
+
+	// the applications send their telemetry to the sidecar over localhost, which must not go through the Istio proxy
+	if p.config.Config().IstioAvailability == autoIstio.Available && istio.SidecarInjected(ns, pod) {
+		logger.V(1).Info("pod is part of the Istio mesh, excluding the sidecar ports from the proxy redirection")
+		pod = istio.ExcludeInboundPorts(pod, containerPorts(pod))
+	}
+
+	return pod, nil
 }
 
+// getCollectorInstance returns the collector named by the effective annotation value or, when it is "true", the sole
+// sidecar collector of the namespace, falling back to the default sidecar collector of the operator.
 func (p *sidecarPodMutator) getCollectorInstance(ctx context.Context, ns corev1.Namespace, ann string) (v1beta1.OpenTelemetryCollector, error) {
 	if strings.EqualFold(ann, "true") {
-		return p.selectCollectorInstance(ctx, ns)
+		otelcol, err := p.selectCollectorInstance(ctx, ns)
+		if errors.Is(err, errNoInstancesAvailable) && p.config.Config().DefaultSidecarCollector != "" {
+			return p.getDefaultCollectorInstance(ctx)
+		}
+		return otelcol, err
 	}
 
-	otelcol := v1beta1.OpenTelemetryCollector{}
 	var nsnOtelcol types.NamespacedName
 	instNamespace, instName, namespaced := strings.Cut(ann, "/")
 	if namespaced {
@@ -102,6 +127,22 @@ func (p *sidecarPodMutator) getCollectorInstance(ctx context.Context, ns corev1.
 	} else {
 		nsnOtelcol = types.NamespacedName{Name: ann, Namespace: ns.Name}
 	}
+	return p.getNamedCollectorInstance(ctx, nsnOtelcol)
+}
+
+// getDefaultCollectorInstance returns the default sidecar collector of the operator. A missing collector doesn't fail
+// the pod creation, as with no collector in the namespace.
+func (p *sidecarPodMutator) getDefaultCollectorInstance(ctx context.Context) (v1beta1.OpenTelemetryCollector, error) {
+	namespace, name, _ := strings.Cut(p.config.Config().DefaultSidecarCollector, "/")
+	otelcol, err := p.getNamedCollectorInstance(ctx, types.NamespacedName{Name: name, Namespace: namespace})
+	if apierrors.IsNotFound(err) {
+		return otelcol, fmt.Errorf("%w: the default sidecar collector %s doesn't exist", errNoInstancesAvailable, p.config.Config().DefaultSidecarCollector)
+	}
+	return otelcol, err
+}
+
+func (p *sidecarPodMutator) getNamedCollectorInstance(ctx context.Context, nsnOtelcol types.NamespacedName) (v1beta1.OpenTelemetryCollector, error) {
+	otelcol := v1beta1.OpenTelemetryCollector{}
 	err := p.client.Get(ctx, nsnOtelcol, &otelcol)
 	if err != nil {
 		return otelcol, err
@@ -0,0 +1,297 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sidecar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	autoIstio "github.com/open-telemetry/opentelemetry-operator/internal/autodetect/istio"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/istio"
+)
+
+func TestMutateIstioExcludesSidecarPorts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sidecar",
+			Namespace: "my-app",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeSidecar,
+			OpenTelemetryCommonFields: v1beta1.OpenTelemetryCommonFields{
+				Ports: []v1beta1.PortsSpec{
+					{ServicePort: corev1.ServicePort{Name: "otlp-grpc", Port: 4317}},
+				},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(otelcol).Build()
+
+	for _, tt := range []struct {
+		desc     string
+		istio    autoIstio.Availability
+		nsLabels map[string]string
+		expected string
+	}{
+		{
+			desc:     "istio is not available",
+			istio:    autoIstio.NotAvailable,
+			nsLabels: map[string]string{"istio-injection": "enabled"},
+		},
+		{
+			desc:  "namespace is not part of the mesh",
+			istio: autoIstio.Available,
+		},
+		{
+			desc:     "namespace is part of the mesh",
+			istio:    autoIstio.Available,
+			nsLabels: map[string]string{"istio-injection": "enabled"},
+			expected: "8888,4317",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-app", Labels: tt.nsLabels}}
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "my-app",
+					Annotations: map[string]string{Annotation: "sidecar"},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "my-app"}}},
+			}
+			mutator := NewMutator(logger, config.NewProvider(config.New(config.WithIstioAvailability(tt.istio))), cl)
+
+			// test
+			changed, err := mutator.Mutate(context.Background(), ns, pod)
+
+			// verify
+			require.NoError(t, err)
+			assert.True(t, existsIn(changed))
+			assert.Equal(t, tt.expected, changed.Annotations[istio.ExcludeInboundPortsAnnotation])
+		})
+	}
+}
+
+func TestMutateInjectsOTLPEnv(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	otelcol := &v1beta1.OpenTelemetryCollector{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sidecar",
+			Namespace: "my-app",
+		},
+		Spec: v1beta1.OpenTelemetryCollectorSpec{
+			Mode: v1beta1.ModeSidecar,
+			Config: v1beta1.Config{
+				Receivers: v1beta1.AnyConfig{Object: map[string]interface{}{
+					"otlp": map[string]interface{}{
+						"protocols": map[string]interface{}{
+							"grpc": map[string]interface{}{},
+							"http": map[string]interface{}{},
+						},
+					},
+				}},
+				Exporters: v1beta1.AnyConfig{Object: map[string]interface{}{"debug": map[string]interface{}{}}},
+				Service: v1beta1.Service{
+					Pipelines: map[string]*v1beta1.Pipeline{
+						"traces": {Receivers: []string{"otlp"}, Exporters: []string{"debug"}},
+					},
+				},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(otelcol).Build()
+
+	for _, tt := range []struct {
+		desc           string
+		nsAnnotations  map[string]string
+		podAnnotations map[string]string
+		expectedEnv    map[string]string
+	}{
+		{
+			desc: "no annotation",
+		},
+		{
+			desc:           "disabled",
+			podAnnotations: map[string]string{OTLPEnvAnnotation: "false"},
+			nsAnnotations:  map[string]string{OTLPEnvAnnotation: "true"},
+		},
+		{
+			desc:           "grpc preferred",
+			podAnnotations: map[string]string{OTLPEnvAnnotation: "true"},
+			expectedEnv: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4317",
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "grpc",
+			},
+		},
+		{
+			desc:          "http from the namespace",
+			nsAnnotations: map[string]string{OTLPEnvAnnotation: "http/protobuf"},
+			expectedEnv: map[string]string{
+				"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+				"OTEL_EXPORTER_OTLP_PROTOCOL": "http/protobuf",
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "my-app", Annotations: tt.nsAnnotations}}
+			annotations := map[string]string{Annotation: "sidecar"}
+			for k, v := range tt.podAnnotations {
+				annotations[k] = v
+			}
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "my-app",
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "my-app"},
+					{Name: "configured", Env: []corev1.EnvVar{{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://elsewhere:4317"}}},
+				}},
+			}
+			mutator := NewMutator(logger, config.NewProvider(config.New()), cl)
+
+			// test
+			changed, err := mutator.Mutate(context.Background(), ns, pod)
+
+			// verify
+			require.NoError(t, err)
+			require.True(t, existsIn(changed))
+			env := map[string]string{}
+			for _, e := range changed.Spec.Containers[0].Env {
+				env[e.Name] = e.Value
+			}
+			if tt.expectedEnv == nil {
+				assert.Empty(t, env)
+				return
+			}
+			for k, v := range tt.expectedEnv {
+				assert.Equal(t, v, env[k])
+			}
+			assert.Contains(t, env["OTEL_RESOURCE_ATTRIBUTES"], "k8s.namespace.name=my-app")
+			assert.Contains(t, env, "OTEL_RESOURCE_ATTRIBUTES_POD_NAME")
+
+			// the variables set by the application are kept
+			configured := changed.Spec.Containers[1].Env
+			assert.Equal(t, corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://elsewhere:4317"}, configured[0])
+			assert.NotContains(t, configured[1:], corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: tt.expectedEnv["OTEL_EXPORTER_OTLP_ENDPOINT"]})
+		})
+	}
+}
+
+func TestMutateSelectsCollectorByPrecedence(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1beta1.AddToScheme(scheme))
+
+	sidecar := func(namespace, name string) *v1beta1.OpenTelemetryCollector {
+		return &v1beta1.OpenTelemetryCollector{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       v1beta1.OpenTelemetryCollectorSpec{Mode: v1beta1.ModeSidecar},
+		}
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		sidecar("observability", "default"),
+		sidecar("observability", "team"),
+		sidecar("with-sidecar", "local"),
+		sidecar("two-sidecars", "first"),
+		sidecar("two-sidecars", "second"),
+	).Build()
+
+	for _, tt := range []struct {
+		desc             string
+		namespace        string
+		nsAnnotation     string
+		podAnnotation    string
+		defaultCollector string
+		expected         string
+	}{
+		{
+			desc:             "pod naming a collector",
+			namespace:        "with-sidecar",
+			nsAnnotation:     "observability/team",
+			podAnnotation:    "local",
+			defaultCollector: "observability/default",
+			expected:         "with-sidecar.local",
+		},
+		{
+			desc:             "namespace naming a collector of another namespace",
+			namespace:        "with-sidecar",
+			nsAnnotation:     "observability/team",
+			podAnnotation:    "true",
+			defaultCollector: "observability/default",
+			expected:         "observability.team",
+		},
+		{
+			desc:             "sole sidecar collector of the namespace",
+			namespace:        "with-sidecar",
+			podAnnotation:    "true",
+			defaultCollector: "observability/default",
+			expected:         "with-sidecar.local",
+		},
+		{
+			desc:             "default sidecar collector",
+			namespace:        "my-app",
+			nsAnnotation:     "true",
+			defaultCollector: "observability/default",
+			expected:         "observability.default",
+		},
+		{
+			desc:          "no collector and no default",
+			namespace:     "my-app",
+			podAnnotation: "true",
+		},
+		{
+			desc:             "missing default sidecar collector",
+			namespace:        "my-app",
+			podAnnotation:    "true",
+			defaultCollector: "observability/missing",
+		},
+		{
+			desc:             "several sidecar collectors in the namespace",
+			namespace:        "two-sidecars",
+			podAnnotation:    "true",
+			defaultCollector: "observability/default",
+		},
+		{
+			desc:             "no injection requested",
+			namespace:        "my-app",
+			defaultCollector: "observability/default",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			ns := corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: tt.namespace, Annotations: map[string]string{}}}
+			if tt.nsAnnotation != "" {
+				ns.Annotations[Annotation] = tt.nsAnnotation
+			}
+			pod := corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace, Annotations: map[string]string{}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "my-app"}}},
+			}
+			if tt.podAnnotation != "" {
+				pod.Annotations[Annotation] = tt.podAnnotation
+			}
+			mutator := NewMutator(logger, config.NewProvider(config.New(config.WithDefaultSidecarCollector(tt.defaultCollector))), cl)
+
+			// test
+			changed, err := mutator.Mutate(context.Background(), ns, pod)
+
+			// verify
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected != "", existsIn(changed))
+			assert.Equal(t, tt.expected, changed.Labels[injectedLabel])
+		})
+	}
+}
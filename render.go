@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/spf13/pflag"
+	colfeaturegate "go.opentelemetry.io/collector/featuregate"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
+
+	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/cmd/instrumentation-preview/preview"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/certmanager"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/gatewayapi"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/keda"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/openshift"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/prometheus"
+	"github.com/open-telemetry/opentelemetry-operator/internal/autodetect/vpa"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/controllers"
+	kedav1alpha1 "github.com/open-telemetry/opentelemetry-operator/internal/keda/v1alpha1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	vpav1 "github.com/open-telemetry/opentelemetry-operator/internal/vpa/v1"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/featuregate"
+)
+
+// renderCommand is the subcommand of the operator printing the manifests it would create, without a cluster.
+const renderCommand = "render"
+
+// availableAPIs are the optional APIs of the cluster the render subcommand can assume, by name.
+var availableAPIs = map[string]config.Option{
+	"cert-manager":     config.WithCertManagerAvailability(certmanager.Available),
+	"gateway-api":      config.WithGatewayAPIAvailability(gatewayapi.Available),
+	"keda":             config.WithKedaAvailability(keda.Available),
+	"openshift-routes": config.WithOpenShiftRoutesAvailability(openshift.RoutesAvailable),
+	"prometheus-crs":   config.WithPrometheusCRAvailability(prometheus.Available),
+	"vpa":              config.WithVPAAvailability(vpa.Available),
+}
+
+// render prints the manifests the operator would create for the collectors, target allocators and instrumentations
+// of the manifests given as arguments, and returns the exit code.
+func render(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	v := version.Get()
+	var (
+		filenames            []string
+		namespace            string
+		configFile           string
+		verbose              bool
+		collectorImage       string
+		targetAllocatorImage string
+		configReloaderImage  string
+		apis                 []string
+	)
+	flags := pflag.NewFlagSet(renderCommand, pflag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringArrayVarP(&filenames, "filename", "f", nil, "The manifests with the OpenTelemetryCollectors, TargetAllocators and Instrumentations, and the other objects they depend on, '-' reads the standard input")
+	flags.StringVarP(&namespace, "namespace", "n", "default", "The namespace of the objects without one")
+	flags.StringVar(&configFile, "config-file", "", "The YAML configuration file of the operator, whose settings replace the defaults of the flags")
+	flags.BoolVarP(&verbose, "verbose", "v", false, "Prints the logs of the builders on the standard error")
+	// the same flags and defaults as the operator
+	flags.StringVar(&collectorImage, "collector-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-collector-releases/opentelemetry-collector:%s", v.OpenTelemetryCollector), "The default OpenTelemetry collector image. This image is used when no image is specified in the CustomResource.")
+	flags.StringVar(&targetAllocatorImage, "target-allocator-image", fmt.Sprintf("ghcr.io/open-telemetry/opentelemetry-operator/target-allocator:%s", v.TargetAllocator), "The default OpenTelemetry target allocator image. This image is used when no image is specified in the CustomResource.")
+	flags.StringVar(&configReloaderImage, "config-reloader-image", "docker.io/library/busybox:1.37", "The image of the container reloading the configuration of the collectors with the Reload rollout strategy.")
+	flags.StringSliceVar(&apis, "available-apis", nil, "The optional APIs the operator would detect in the cluster, among cert-manager, gateway-api, keda, openshift-routes, prometheus-crs and vpa")
+	flags.AddGoFlagSet(featuregate.Flags(colfeaturegate.GlobalRegistry()))
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if configFile != "" {
+		if err := applyConfigFile(flags, configFile); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	if len(filenames) == 0 {
+		fmt.Fprintln(stderr, "at least one manifest is required, see --help")
+		return 1
+	}
+
+	opts := []config.Option{
+		config.WithVersion(v),
+		config.WithCollectorImage(collectorImage),
+		config.WithTargetAllocatorImage(targetAllocatorImage),
+		config.WithConfigReloaderImage(configReloaderImage),
+	}
+	for _, api := range apis {
+		opt, ok := availableAPIs[api]
+		if !ok {
+			fmt.Fprintf(stderr, "unknown API %q, see --help\n", api)
+			return 1
+		}
+		opts = append(opts, opt)
+	}
+	cfg := config.New(opts...)
+
+	renderScheme := newRenderScheme()
+	var objects []client.Object
+	for _, filename := range filenames {
+		decoded, err := decodeManifests(renderScheme, filename, stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to read %s: %v\n", filename, err)
+			return 1
+		}
+		objects = append(objects, decoded...)
+	}
+
+	logger := zap.New(zap.WriteTo(io.Discard))
+	if verbose {
+		logger = zap.New(zap.WriteTo(stderr), zap.UseDevMode(true))
+	}
+	rendered, warnings, err := controllers.Render(context.Background(), logger, renderScheme, cfg, namespace, objects)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	for _, warning := range warnings {
+		fmt.Fprintln(stderr, warning)
+	}
+	manifests := make([]string, 0, len(rendered))
+	for _, obj := range rendered {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		manifests = append(manifests, string(out))
+	}
+	fmt.Fprint(stdout, strings.Join(manifests, "---\n"))
+	return 0
+}
+
+// applyConfigFile sets the flags of the render subcommand from the operator configuration file, the flags set on the
+// command line taking precedence over the file as for the operator.
+func applyConfigFile(flags *pflag.FlagSet, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	file, err := config.ParseFile(content)
+	if err != nil {
+		return err
+	}
+	for name, values := range file.Flags() {
+		if flags.Lookup(name) == nil || flags.Changed(name) {
+			continue
+		}
+		for _, value := range values {
+			if err := flags.Set(name, value); err != nil {
+				return fmt.Errorf("invalid setting in the operator configuration file for the flag %s: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// newRenderScheme returns a scheme with the APIs of the objects the operator may create.
+func newRenderScheme() *k8sruntime.Scheme {
+	s := k8sruntime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(otelv1alpha1.AddToScheme(s))
+	utilruntime.Must(otelv1beta1.AddToScheme(s))
+	utilruntime.Must(monitoringv1.AddToScheme(s))
+	utilruntime.Must(routev1.Install(s))
+	utilruntime.Must(gatewayv1.Install(s))
+	utilruntime.Must(kedav1alpha1.AddToScheme(s))
+	utilruntime.Must(vpav1.AddToScheme(s))
+	utilruntime.Must(cmv1.AddToScheme(s))
+	return s
+}
+
+func decodeManifests(s *k8sruntime.Scheme, filename string, stdin io.Reader) ([]client.Object, error) {
+	if filename == "-" {
+		return preview.Decode(s, stdin)
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return preview.Decode(s, f)
+}
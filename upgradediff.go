@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	otelv1alpha1 "github.com/open-telemetry/opentelemetry-operator/apis/v1alpha1"
+	otelv1beta1 "github.com/open-telemetry/opentelemetry-operator/apis/v1beta1"
+	"github.com/open-telemetry/opentelemetry-operator/internal/config"
+	"github.com/open-telemetry/opentelemetry-operator/internal/version"
+	"github.com/open-telemetry/opentelemetry-operator/pkg/collector/upgrade"
+)
+
+// upgradeDiffCommand is the subcommand of the operator printing what the upgrade of collectors would change, without
+// changing them.
+const upgradeDiffCommand = "upgrade-diff"
+
+// upgradeDiff prints what the operator would change when upgrading the collectors of the manifests given as
+// arguments, and returns the exit code.
+func upgradeDiff(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	v := version.Get()
+	var (
+		filenames      []string
+		namespace      string
+		verbose        bool
+		currentVersion string
+		upgradeChannel string
+	)
+	flags := pflag.NewFlagSet(upgradeDiffCommand, pflag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringArrayVarP(&filenames, "filename", "f", nil, "The manifests with the OpenTelemetryCollectors, e.g. printed by kubectl get opentelemetrycollectors -o yaml, '-' reads the standard input")
+	flags.StringVarP(&namespace, "namespace", "n", "default", "The namespace of the collectors without one")
+	flags.BoolVarP(&verbose, "verbose", "v", false, "Prints the logs of the upgrade routines on the standard error")
+	flags.StringVar(&v.OpenTelemetryCollector, "collector-version", v.OpenTelemetryCollector, "The collector version the collectors would be upgraded to, the one of this operator by default")
+	flags.StringVar(&currentVersion, "current-version", "", "The version of the collectors without a status.version, e.g. the manifests of a git repository")
+	flags.StringVar(&upgradeChannel, "upgrade-channel", string(config.UpgradeChannelMinor), "The upgrade channel of the operator, among none, patch and minor")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if len(filenames) == 0 {
+		fmt.Fprintln(stderr, "at least one manifest is required, see --help")
+		return 1
+	}
+
+	logger := zap.New(zap.WriteTo(io.Discard))
+	if verbose {
+		logger = zap.New(zap.WriteTo(stderr), zap.UseDevMode(true))
+	}
+	channel, err := config.ParseUpgradeChannel(upgradeChannel)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	// the maintenance windows only delay the upgrades
+	up := upgrade.VersionUpgrade{Log: logger, Version: v, Policy: config.UpgradePolicy{Channel: channel}}
+
+	s := newRenderScheme()
+	for _, filename := range filenames {
+		objects, err := decodeManifests(s, filename, stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "failed to read %s: %v\n", filename, err)
+			return 1
+		}
+		for _, obj := range objects {
+			var otelcol otelv1beta1.OpenTelemetryCollector
+			switch o := obj.(type) {
+			case *otelv1beta1.OpenTelemetryCollector:
+				otelcol = *o
+			case *otelv1alpha1.OpenTelemetryCollector:
+				if err := o.ConvertTo(&otelcol); err != nil {
+					fmt.Fprintf(stderr, "failed to convert the OpenTelemetryCollector %s: %v\n", o.Name, err)
+					return 1
+				}
+			default:
+				continue
+			}
+			if otelcol.Namespace == "" {
+				otelcol.Namespace = namespace
+			}
+			if otelcol.Status.Version == "" {
+				otelcol.Status.Version = currentVersion
+			}
+			if err := printUpgradeDiff(context.Background(), stdout, up, otelcol); err != nil {
+				fmt.Fprintf(stderr, "failed to upgrade the OpenTelemetryCollector %s/%s: %v\n", otelcol.Namespace, otelcol.Name, err)
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+func printUpgradeDiff(ctx context.Context, w io.Writer, up upgrade.VersionUpgrade, otelcol otelv1beta1.OpenTelemetryCollector) error {
+	name := otelcol.Namespace + "/" + otelcol.Name
+	if !up.NeedsUpgrade(otelcol) {
+		fmt.Fprintf(w, "# %s: not upgraded\n", name)
+		return nil
+	}
+	if reason, _ := up.HeldBack(otelcol, time.Now()); reason != "" {
+		fmt.Fprintf(w, "# %s: not upgraded, %s\n", name, reason)
+		return nil
+	}
+	diff, err := up.Diff(ctx, otelcol)
+	if err != nil {
+		return err
+	}
+	if diff.From == diff.To {
+		// the collectors newer than the operator are left as they are
+		fmt.Fprintf(w, "# %s: not upgraded\n", name)
+		return nil
+	}
+	fmt.Fprintf(w, "# %s: upgraded from version %s to %s\n", name, diff.From, diff.To)
+	for _, migration := range diff.ConfigMigrations {
+		fmt.Fprintf(w, "# migrated for %s: %s\n", migration.Version, migration.Description)
+	}
+	for _, event := range diff.Events {
+		fmt.Fprintf(w, "# event: %s\n", event)
+	}
+	fmt.Fprint(w, diff.Spec)
+	return nil
+}